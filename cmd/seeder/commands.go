@@ -0,0 +1,646 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/export"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/graph"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/preflight"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/rpc"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/seed"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/utils"
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+// runPreflightCommand checks the API key, database connection and schema
+// privileges and prints the outcome, exiting with 1 if any check failed.
+func runPreflightCommand(opts options, dbConf db.Config) {
+	report := preflight.Run(context.Background(), preflightChecks(
+		os.Getenv("CFBD_API_KEY"), dbConf,
+	))
+
+	var err error
+	if opts.output == outputJSON {
+		err = json.NewEncoder(os.Stdout).Encode(report)
+	} else {
+		err = report.Write(os.Stdout)
+	}
+	if err != nil {
+		slog.Warn("failed to write preflight report", "err", err)
+	}
+
+	if !report.Passed {
+		os.Exit(1)
+	}
+}
+
+// runMigrateCommand runs the verb of the migrate command.
+func runMigrateCommand(opts options, database *db.Database) {
+	err := runMigrate(
+		context.Background(), database, opts.migrateArgs, opts.output,
+	)
+	if err != nil {
+		slog.Error("failed to migrate", "err", err)
+		os.Exit(1)
+	}
+}
+
+// runNukeCommand drops the schema and recreates it empty.
+func runNukeCommand(opts options, database *db.Database) {
+	err := database.DropSchema(context.Background(), opts.confirmDrop)
+	if err == nil {
+		err = database.Initialize()
+	}
+	if err != nil {
+		slog.Error("failed to recreate schema", "err", err)
+		os.Exit(1)
+	}
+	slog.Info("Schema recreated.")
+}
+
+// runAuditCommand audits the references between the tables as they are,
+// without migrating them. It exits with 1 if orphans are only reported.
+func runAuditCommand(opts options, database *db.Database) {
+	orphans, err := runAudit(
+		context.Background(), database, db.OrphanMode(opts.orphanMode),
+		opts.output,
+	)
+	if err != nil {
+		slog.Error("failed to audit references", "err", err)
+		os.Exit(1)
+	}
+	if orphans && opts.orphanMode == string(db.OrphansReport) {
+		os.Exit(1)
+	}
+}
+
+// runResetCommand deletes the rows of --tables and --years from the tables
+// as they are, or of every table with --all.
+func runResetCommand(opts options, database *db.Database) {
+	scope := db.ResetScope{All: opts.resetAll}
+	if opts.exportTables != "" {
+		scope.Tables = strings.Split(opts.exportTables, ",")
+	}
+	if opts.yearSpec != "" {
+		var err error
+		scope.Years, err = utils.ParseYears(opts.yearSpec)
+		if err != nil {
+			slog.Error("invalid years", "err", err)
+			os.Exit(1)
+		}
+	}
+	if len(scope.Tables) == 0 && len(scope.Years) == 0 && !scope.All {
+		slog.Error("reset requires --tables, --years or --all")
+		os.Exit(1)
+	}
+	if scope.All && (len(scope.Tables) > 0 || len(scope.Years) > 0) {
+		slog.Error("--all cannot be combined with --tables or --years")
+		os.Exit(1)
+	}
+
+	err := runReset(
+		context.Background(), database, scope, opts.assumeYes, opts.output,
+	)
+	if err != nil {
+		slog.Error("failed to reset", "err", err)
+		os.Exit(1)
+	}
+}
+
+// runFinalizeCommand completes a load into the tables as they are.
+func runFinalizeCommand(opts options, database *db.Database) {
+	err := runFinalize(context.Background(), database, opts.output)
+	if err != nil {
+		slog.Error("failed to finalize", "err", err)
+		os.Exit(1)
+	}
+}
+
+// runRefreshViewsCommand derives the views from the tables as they are.
+func runRefreshViewsCommand(opts options, database *db.Database) {
+	err := runRefreshViews(context.Background(), database, opts.output)
+	if err != nil {
+		slog.Error("failed to refresh views", "err", err)
+		os.Exit(1)
+	}
+}
+
+// runExportCommand writes the tables as they are, without migrating them,
+// to --out.
+func runExportCommand(opts options, database *db.Database) {
+	exportOpts := export.Options{Format: opts.format, Out: opts.out}
+	if opts.exportTables != "" {
+		exportOpts.Tables = strings.Split(opts.exportTables, ",")
+	}
+	if opts.yearSpec != "" {
+		var err error
+		exportOpts.Years, err = utils.ParseYears(opts.yearSpec)
+		if err != nil {
+			slog.Error("invalid years", "err", err)
+			os.Exit(1)
+		}
+	}
+
+	err := runExport(context.Background(), database, exportOpts)
+	if err != nil {
+		slog.Error("failed to export", "err", err)
+		os.Exit(1)
+	}
+}
+
+// runServeCommand serves queries against the tables as they are, without
+// migrating them, until interrupted.
+func runServeCommand(opts options, database *db.Database) {
+	err := runServe(database, opts.graphqlAddr, opts.grpcAddr)
+	if err != nil {
+		slog.Error("failed to serve queries", "err", err)
+		os.Exit(1)
+	}
+}
+
+// runImportCommand loads the bundle at --source into the migrated tables.
+func runImportCommand(opts options, database *db.Database) {
+	err := runImport(context.Background(), database, opts.source, opts.output)
+	if err != nil {
+		slog.Error("failed to import bundle", "err", err)
+		os.Exit(1)
+	}
+}
+
+// preflightChecks returns the checks run by the preflight command: the API
+// key, the database connection and the privileges creating the schema
+// needs. The schema check is skipped if the database is unreachable.
+func preflightChecks(apiKey string, dbConf db.Config) []preflight.Check {
+	var database *db.Database
+
+	return []preflight.Check{
+		{
+			Name: "api_key",
+			Fn: func(ctx context.Context) (string, error) {
+				api, err := cfbd.New(apiKey)
+				if err != nil {
+					return "", fmt.Errorf("failed to create API client; %w", err)
+				}
+				info, err := seed.VerifyAPIKey(ctx, api)
+				if err != nil {
+					return "", fmt.Errorf("failed to verify API key; %w", err)
+				}
+				return fmt.Sprintf("patron level %v, %v calls remaining",
+					info.PatronLevel, info.RemainingCalls), nil
+			},
+		},
+		{
+			Name: "database",
+			Fn: func(ctx context.Context) (string, error) {
+				conn, err := db.NewDatabase(dbConf)
+				if err == nil {
+					err = conn.Ping(ctx)
+				}
+				if err != nil {
+					return "", fmt.Errorf("failed to connect; %w", err)
+				}
+				database = conn
+				return "connected", nil
+			},
+		},
+		{
+			Name: "schema",
+			Fn: func(ctx context.Context) (string, error) {
+				if database == nil {
+					return "", fmt.Errorf(
+						"%w: database unreachable", preflight.ErrSkipped,
+					)
+				}
+				detail, err := database.CheckPrivileges(ctx)
+				if err != nil {
+					return "", fmt.Errorf("failed to check privileges; %w", err)
+				}
+				return detail, nil
+			},
+		},
+	}
+}
+
+// runMigrate runs a verb of the migrate command: status prints the status
+// of every migration, up applies the pending ones, down rolls back the most
+// recent one and force records the schema as being at the given version.
+func runMigrate(
+	ctx context.Context,
+	database *db.Database,
+	args []string,
+	output string,
+) error {
+	if len(args) == 0 {
+		return errors.New("missing verb; must be one of status, up, down, force")
+	}
+	verb, args := args[0], args[1:]
+	if verb != migrateForce && len(args) > 0 {
+		return fmt.Errorf("unexpected arguments to %s: %s",
+			verb, strings.Join(args, " "))
+	}
+
+	switch verb {
+	case migrateStatus:
+		states, err := database.MigrationStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get migration status; %w", err)
+		}
+		if output == outputJSON {
+			if err = json.NewEncoder(os.Stdout).Encode(states); err != nil {
+				return fmt.Errorf("failed to write migration status; %w", err)
+			}
+			return nil
+		}
+		return writeMigrationStatus(os.Stdout, states)
+	case migrateUp:
+		if err := database.Initialize(); err != nil {
+			return fmt.Errorf("failed to apply migrations; %w", err)
+		}
+		return nil
+	case migrateDown:
+		if err := database.MigrateDown(ctx); err != nil {
+			return fmt.Errorf("failed to roll back migration; %w", err)
+		}
+		return nil
+	case migrateForce:
+		if len(args) != 1 {
+			return errors.New("force takes exactly one version")
+		}
+		version, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		if err = database.ForceVersion(ctx, version); err != nil {
+			return fmt.Errorf("failed to force version; %w", err)
+		}
+		slog.Info("forced schema version", "version", version)
+		return nil
+	default:
+		return fmt.Errorf(
+			"unknown verb %q; must be one of status, up, down, force", verb,
+		)
+	}
+}
+
+// writeMigrationStatus prints the schema version, the highest migration
+// applied, and the status of every migration as an aligned table.
+func writeMigrationStatus(w io.Writer, states []db.MigrationState) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	var version int64
+	for _, state := range states {
+		if state.Status != db.MigrationPending {
+			version = state.Version
+		}
+	}
+
+	fmt.Fprintf(tw, "schema version %d\n\n", version)
+	fmt.Fprintln(tw, "version\tname\tstatus\tapplied at")
+	for _, state := range states {
+		applied := "-"
+		if state.AppliedAt != nil {
+			applied = state.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n",
+			state.Version, state.Name, state.Status, applied)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write migration status; %w", err)
+	}
+
+	return nil
+}
+
+// runAudit audits the references between tables, handling orphaned rows as
+// mode says, and prints what it found. It reports whether any orphans were
+// found.
+func runAudit(
+	ctx context.Context,
+	database *db.Database,
+	mode db.OrphanMode,
+	output string,
+) (bool, error) {
+	reports, err := database.AuditReferences(ctx, mode)
+	if err != nil {
+		return false, err
+	}
+
+	orphans := slices.ContainsFunc(reports, func(r db.OrphanReport) bool {
+		return r.Orphans > 0
+	})
+
+	if output == outputJSON {
+		if err = json.NewEncoder(os.Stdout).Encode(reports); err != nil {
+			return orphans, fmt.Errorf("failed to write audit report; %w", err)
+		}
+		return orphans, nil
+	}
+
+	return orphans, writeAudit(os.Stdout, reports)
+}
+
+// writeAudit prints the orphans of every reference as an aligned table.
+func writeAudit(w io.Writer, reports []db.OrphanReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "reference	orphans	removed	sample")
+	for _, report := range reports {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\n", report.Reference, report.Orphans,
+			report.Removed, strings.Join(report.Sample, ", "))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write audit report; %w", err)
+	}
+
+	return nil
+}
+
+// errResetDeclined is returned by runReset when the reset is not confirmed.
+var errResetDeclined = errors.New("reset not confirmed")
+
+// runReset prints the rows a reset would delete and, once confirmed on
+// stdin or by assumeYes, deletes them and prints what was deleted.
+func runReset(
+	ctx context.Context,
+	database *db.Database,
+	scope db.ResetScope,
+	assumeYes bool,
+	output string,
+) error {
+	planned, err := database.PlanReset(ctx, scope)
+	if err != nil {
+		return err
+	}
+
+	if !assumeYes {
+		if err = writeReset(os.Stderr, planned); err != nil {
+			return err
+		}
+		fmt.Fprint(os.Stderr, "\nDelete these rows? Type \"yes\" to confirm: ")
+		answer, readErr := bufio.NewReader(os.Stdin).ReadString('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return fmt.Errorf("failed to read confirmation; %w", readErr)
+		}
+		if strings.TrimSpace(answer) != "yes" {
+			return errResetDeclined
+		}
+	}
+
+	results, err := database.Reset(ctx, scope)
+	if err != nil {
+		return err
+	}
+
+	if output == outputJSON {
+		if err = json.NewEncoder(os.Stdout).Encode(results); err != nil {
+			return fmt.Errorf("failed to write reset report; %w", err)
+		}
+		return nil
+	}
+
+	return writeReset(os.Stdout, results)
+}
+
+// writeReset prints the rows deleted from every table as an aligned table.
+func writeReset(w io.Writer, results []db.ResetResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "table\trows\tscope")
+	for _, result := range results {
+		scope := "seasons"
+		if result.Truncated {
+			scope = "all"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", result.Table, result.Rows, scope)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write reset report; %w", err)
+	}
+
+	return nil
+}
+
+// errConstraintsFailed is returned by runFinalize when the rows loaded do
+// not satisfy some constraint.
+var errConstraintsFailed = errors.New("constraints not satisfied")
+
+// runFinalize builds the indexes deferred by a load and installs the
+// constraints migrations leave out, and prints the outcome of each
+// constraint.
+func runFinalize(
+	ctx context.Context,
+	database *db.Database,
+	output string,
+) error {
+	if err := database.Finalize(ctx); err != nil {
+		return fmt.Errorf("failed to build deferred indexes; %w", err)
+	}
+
+	results, err := database.InstallConstraints(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to install constraints; %w", err)
+	}
+
+	if output == outputJSON {
+		err = json.NewEncoder(os.Stdout).Encode(results)
+	} else {
+		err = writeConstraints(os.Stdout, results)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write constraints; %w", err)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == db.ConstraintFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", errConstraintsFailed,
+			failed, len(results))
+	}
+
+	return nil
+}
+
+// writeConstraints prints the outcome of every constraint as an aligned
+// table.
+func writeConstraints(w io.Writer, results []db.ConstraintResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "constraint\ttable\tstatus\terror")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			result.Constraint, result.Table, result.Status, result.Error)
+	}
+
+	return tw.Flush()
+}
+
+// runRefreshViews creates or refreshes the analytics views and prints the
+// outcome of each.
+func runRefreshViews(
+	ctx context.Context,
+	database *db.Database,
+	output string,
+) error {
+	results, err := database.RefreshViews(ctx)
+	if err != nil {
+		return err
+	}
+
+	if output == outputJSON {
+		err = json.NewEncoder(os.Stdout).Encode(results)
+	} else {
+		err = writeViews(os.Stdout, results)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write views; %w", err)
+	}
+
+	return nil
+}
+
+// writeViews prints the outcome of every view as an aligned table.
+func writeViews(w io.Writer, results []db.ViewResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "view\tstatus\trows\tduration")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", result.View, result.Status,
+			result.Rows, time.Duration(result.DurationMS)*time.Millisecond)
+	}
+
+	return tw.Flush()
+}
+
+// runExport writes every seeded table to the files opts describe.
+func runExport(
+	ctx context.Context,
+	database *db.Database,
+	opts export.Options,
+) error {
+	exporter, err := export.New(ctx, database, opts)
+	if err != nil {
+		return err
+	}
+
+	return exporter.Run(ctx)
+}
+
+// runImport loads the bundle at source into the database and prints how
+// many rows of each table it inserted.
+func runImport(
+	ctx context.Context,
+	database *db.Database,
+	source string,
+	output string,
+) error {
+	results, err := export.Import(ctx, database, source)
+	if err != nil {
+		return err
+	}
+
+	if output == outputJSON {
+		err = json.NewEncoder(os.Stdout).Encode(results)
+	} else {
+		err = writeImport(os.Stdout, results)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write import results; %w", err)
+	}
+
+	return nil
+}
+
+// writeImport prints the outcome of importing every table as an aligned
+// table.
+func writeImport(w io.Writer, results []export.ImportResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "table\trows\tinserted")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%s\t%d\t%d\n",
+			result.Table, result.Rows, result.Inserted)
+	}
+
+	return tw.Flush()
+}
+
+// runServe serves GraphQL queries against the database on graphqlAddr, and
+// the gRPC API on grpcAddr unless it is empty, until the process is
+// interrupted.
+func runServe(database *db.Database, graphqlAddr, grpcAddr string) error {
+	sch, err := graph.NewSchema(database)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", graph.Handler(sch))
+	srv := &http.Server{
+		Addr:              graphqlAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	var grpcListener net.Listener
+	if grpcAddr != "" {
+		grpcListener, err = net.Listen("tcp", grpcAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s; %w", grpcAddr, err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(
+		context.Background(), os.Interrupt, syscall.SIGTERM,
+	)
+	defer stop()
+
+	served := make(chan error, 2)
+	go func() {
+		slog.Info("Serving GraphQL...", "addr", graphqlAddr)
+		served <- srv.ListenAndServe()
+	}()
+
+	grpcSrv := rpc.NewServer(database)
+	if grpcListener != nil {
+		go func() {
+			slog.Info("Serving gRPC...", "addr", grpcAddr)
+			served <- grpcSrv.Serve(grpcListener)
+		}()
+	}
+
+	select {
+	case err = <-served:
+	case <-ctx.Done():
+	}
+
+	grpcSrv.GracefulStop()
+	if shutdownErr := srv.Shutdown(context.Background()); err == nil {
+		err = shutdownErr
+	}
+
+	return err
+}
@@ -0,0 +1,92 @@
+// Command current-week refreshes the games of the calendar week in progress
+// and the live scoreboard, reusing the seeder's rate limiting and retries.
+// It expects the calendar to have been seeded by a regular run.
+//
+//	go run ./examples/current-week
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/seed"
+	"github.com/clintrovert/cfbd-go/cfbd"
+	"golang.org/x/time/rate"
+	"gorm.io/gorm"
+)
+
+func main() {
+	ctx := context.Background()
+
+	database, err := db.NewDatabase(db.Config{
+		DSN:                      os.Getenv("DATABASE_DSN"),
+		MaxOpenConnections:       db.DefaultMaxOpenConnections,
+		MaxIdleConnections:       10,
+		MaxConnectionLifetimeMin: 30,
+	})
+	if err != nil {
+		slog.Error("failed to connect to database", "err", err)
+		os.Exit(1)
+	}
+
+	// The models are plain GORM models, so ad hoc queries need no helpers.
+	now := time.Now().UTC()
+	var week db.CalendarWeek
+	err = database.WithContext(ctx).
+		Where("start_date <= ? AND end_date >= ?", now, now).
+		First(&week).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Info("no calendar week in progress")
+		return
+	}
+	if err != nil {
+		slog.Error("failed to get current week", "err", err)
+		os.Exit(1)
+	}
+
+	api, err := cfbd.New(os.Getenv("CFBD_API_KEY"))
+	if err != nil {
+		slog.Error("failed to create API client", "err", err)
+		os.Exit(1)
+	}
+
+	games, err := api.GetGames(ctx, cfbd.GetGamesRequest{
+		Year:       week.Season,
+		Week:       week.Week,
+		SeasonType: week.SeasonType,
+	})
+	if err != nil {
+		slog.Error("failed to get games", "err", err)
+		os.Exit(1)
+	}
+
+	if err = database.InsertGames(ctx, games); err != nil {
+		slog.Error("failed to insert games", "err", err)
+		os.Exit(1)
+	}
+
+	seeder, err := seed.NewSeeder(
+		database, api, rate.NewLimiter(rate.Limit(10), db.RateLimiterBurst),
+	)
+	if err != nil {
+		slog.Error("failed to create seeder", "err", err)
+		os.Exit(1)
+	}
+
+	if err = seeder.SeedScoreboard(ctx); err != nil {
+		slog.Error("failed to refresh scoreboard", "err", err)
+		os.Exit(1)
+	}
+
+	slog.Info(
+		"current week synced",
+		"season", week.Season,
+		"week", week.Week,
+		"season_type", week.SeasonType,
+		"games", len(games),
+	)
+}
@@ -0,0 +1,81 @@
+// Command export-csv writes a season's games from the database to CSV, as a
+// starting point for exporting seeded data to other tools.
+//
+//	go run ./examples/export-csv --year=2024 > games.csv
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+)
+
+func main() {
+	year := flag.Int("year", 2024, "season to export")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	database, err := db.NewDatabase(db.Config{
+		DSN:                      os.Getenv("DATABASE_DSN"),
+		MaxOpenConnections:       db.DefaultMaxOpenConnections,
+		MaxIdleConnections:       10,
+		MaxConnectionLifetimeMin: 30,
+	})
+	if err != nil {
+		slog.Error("failed to connect to database", "err", err)
+		os.Exit(1)
+	}
+
+	var games []db.Game
+	err = database.WithContext(ctx).
+		Where("season = ?", *year).
+		Order("start_date, id").
+		Find(&games).Error
+	if err != nil {
+		slog.Error("failed to get games", "err", err)
+		os.Exit(1)
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	_ = w.Write([]string{
+		"id", "season", "week", "season_type", "start_date",
+		"home_team", "home_points", "away_team", "away_points",
+	})
+	for _, g := range games {
+		startDate := ""
+		if g.StartDate != nil {
+			startDate = g.StartDate.UTC().Format(time.RFC3339)
+		}
+		_ = w.Write([]string{
+			strconv.Itoa(int(g.ID)),
+			strconv.Itoa(int(g.Season)),
+			strconv.Itoa(int(g.Week)),
+			g.SeasonType,
+			startDate,
+			g.HomeTeam,
+			points(g.HomePoints),
+			g.AwayTeam,
+			points(g.AwayPoints),
+		})
+	}
+
+	w.Flush()
+	if err = w.Error(); err != nil {
+		slog.Error("failed to write csv", "err", err)
+		os.Exit(1)
+	}
+}
+
+func points(p *int32) string {
+	if p == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*p))
+}
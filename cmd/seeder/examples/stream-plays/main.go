@@ -0,0 +1,79 @@
+// Command stream-plays writes a season's plays to stdout as newline-delimited
+// JSON without a database, using the seeder's streaming API for rate
+// limiting, calendar enumeration and retries.
+//
+//	go run ./examples/stream-plays --year=2024 --week=1 > plays.ndjson
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/seed"
+	"github.com/clintrovert/cfbd-go/cfbd"
+	"golang.org/x/time/rate"
+)
+
+func main() {
+	year := flag.Int("year", 2024, "season to stream")
+	week := flag.Int("week", 0, "week to stream (0 streams every week)")
+	seasonType := flag.String("season-type", "", "season type to stream")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	api, err := cfbd.New(os.Getenv("CFBD_API_KEY"))
+	if err != nil {
+		slog.Error("failed to create API client", "err", err)
+		os.Exit(1)
+	}
+
+	// Streams never write to the database, so none is needed.
+	seeder, err := seed.NewSeeder(
+		nil, api, rate.NewLimiter(rate.Limit(10), db.RateLimiterBurst),
+	)
+	if err != nil {
+		slog.Error("failed to create seeder", "err", err)
+		os.Exit(1)
+	}
+
+	opts := seed.StreamOptions{
+		Years:      []int32{int32(*year)}, //nolint:gosec // always within int32 range
+		SeasonType: *seasonType,
+	}
+	if *week != 0 {
+		opts.Weeks = []int32{int32(*week)} //nolint:gosec // always within int32 range
+	}
+
+	plays, err := seeder.StreamPlays(ctx, opts)
+	if err != nil {
+		slog.Error("failed to stream plays", "err", err)
+		os.Exit(1)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	enc := json.NewEncoder(out)
+	count := 0
+	for play := range plays.C {
+		if err = enc.Encode(play); err != nil {
+			slog.Error("failed to write play", "err", err)
+			os.Exit(1)
+		}
+		count++
+	}
+	if err = plays.Err(); err != nil {
+		slog.Error("failed to stream plays", "err", err)
+		os.Exit(1)
+	}
+	if err = out.Flush(); err != nil {
+		slog.Error("failed to write plays", "err", err)
+		os.Exit(1)
+	}
+
+	slog.Info("plays streamed", "year", *year, "count", count)
+}
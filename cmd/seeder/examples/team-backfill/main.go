@@ -0,0 +1,80 @@
+// Command team-backfill seeds every game of a single team's season along
+// with the advanced box score of each game, using the db package directly
+// rather than running a full seed.
+//
+//	go run ./examples/team-backfill --team=Michigan --year=2023
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+func main() {
+	team := flag.String("team", "Michigan", "team to backfill")
+	year := flag.Int("year", 2024, "season to backfill")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	database, err := db.NewDatabase(db.Config{
+		DSN:                      os.Getenv("DATABASE_DSN"),
+		MaxOpenConnections:       db.DefaultMaxOpenConnections,
+		MaxIdleConnections:       10,
+		MaxConnectionLifetimeMin: 30,
+	})
+	if err != nil {
+		slog.Error("failed to connect to database", "err", err)
+		os.Exit(1)
+	}
+
+	api, err := cfbd.New(os.Getenv("CFBD_API_KEY"))
+	if err != nil {
+		slog.Error("failed to create API client", "err", err)
+		os.Exit(1)
+	}
+
+	games, err := api.GetGames(ctx, cfbd.GetGamesRequest{
+		Year: int32(*year),
+		Team: *team,
+	})
+	if err != nil {
+		slog.Error("failed to get games", "err", err)
+		os.Exit(1)
+	}
+
+	if err = database.InsertGames(ctx, games); err != nil {
+		slog.Error("failed to insert games", "err", err)
+		os.Exit(1)
+	}
+
+	scores := make(map[int32]*cfbd.AdvancedBoxScore, len(games))
+	for _, game := range games {
+		score, scoreErr := api.GetAdvancedBoxScore(
+			ctx, cfbd.GetAdvancedBoxScoreRequest{GameID: game.Id},
+		)
+		if scoreErr != nil {
+			slog.Warn("skipping box score", "game_id", game.Id, "err", scoreErr)
+			continue
+		}
+		scores[game.Id] = score
+	}
+
+	if err = database.InsertAdvancedBoxScores(ctx, scores); err != nil {
+		slog.Error("failed to insert box scores", "err", err)
+		os.Exit(1)
+	}
+
+	slog.Info(
+		"team backfilled",
+		"team", *team,
+		"year", *year,
+		"games", len(games),
+		"box_scores", len(scores),
+	)
+}
@@ -0,0 +1,632 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/export"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/seed"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/utils"
+)
+
+// options are the command and flags the seeder was started with. The flags
+// are described where parseOptions defines them.
+type options struct {
+	// command is the command run, empty for a full seed.
+	command string
+	// migrateArgs are the verb of the migrate command and its arguments.
+	migrateArgs []string
+	// weeks are the weeks of --year the backfill command refreshes, as
+	// parsed from --weeks.
+	weeks []int32
+
+	watch              bool
+	watchInterval      time.Duration
+	compress           bool
+	positionGroups     string
+	statusAddr         string
+	quotaWarn          int64
+	quotaSlow          int64
+	quotaPause         int64
+	quotaInterval      time.Duration
+	closingWindow      time.Duration
+	closingInterval    time.Duration
+	maxRetries         int
+	maxFailureAttempts int
+	weatherWindow      time.Duration
+	weatherInterval    time.Duration
+	verify             bool
+	breakerThreshold   int
+	breakerCooldown    time.Duration
+	profile            string
+	allowDDL           bool
+	progressInterval   time.Duration
+	otlpEndpoint       string
+	notifyURL          string
+	geocoder           bool
+	weatherProvider    string
+	taskTimeout        time.Duration
+	maxConcurrent      int
+	driver             string
+	out                string
+	format             string
+	exportTables       string
+	confirmDrop        string
+	assumeYes          bool
+	resetAll           bool
+	units              string
+	seasonTypes        string
+	teams              string
+	conferences        string
+	classification     string
+	yearSpec           string
+	dryRun             bool
+	archive            string
+	recordFixtures     string
+	source             string
+	eventsURL          string
+	eventTables        string
+	graphqlAddr        string
+	grpcAddr           string
+	pgNotify           bool
+	bulkCopy           bool
+	skipUnchanged      bool
+	history            bool
+	validation         string
+	orphanMode         string
+	diffEntity         string
+	skipIdentical      bool
+	summaryOut         bool
+	storeSummary       bool
+	dashboard          bool
+	dashboardLog       string
+	taskName           string
+	taskYear           int
+	taskWeek           int
+	backfillWeeks      string
+	gameID             int
+	taskSeasonType     string
+	output             string
+	autoMigrate        bool
+	queryIndexes       bool
+	deferIndexes       bool
+	installConstraints bool
+	refreshViews       bool
+	configPath         string
+	resume             bool
+}
+
+// parseOptions parses the command line, which may give flags before and
+// after the command, e.g. "plan --years=2005-2025".
+func parseOptions() options {
+	var o options
+
+	flag.BoolVar(
+		&o.watch,
+		"watch", false,
+		"continuously refresh the scoreboard instead of running a full seed",
+	)
+	flag.DurationVar(
+		&o.watchInterval,
+		"watch-interval", time.Minute,
+		"how often the scoreboard is refreshed in --watch mode",
+	)
+	flag.BoolVar(
+		&o.compress,
+		"compress-payloads", false,
+		"store large json payload columns gzip-compressed (bytea)",
+	)
+	flag.StringVar(
+		&o.positionGroups,
+		"position-groups", "",
+		"comma-separated position groups to seed aggregated recruiting for",
+	)
+	flag.StringVar(
+		&o.statusAddr,
+		"status-addr", "",
+		"serve /status, /healthz and /readyz on this address (e.g. :8080)",
+	)
+	flag.Int64Var(
+		&o.quotaWarn,
+		"quota-warn", defaultQuotaWarn,
+		"warn when remaining API calls drop below this many (0 disables)",
+	)
+	flag.Int64Var(
+		&o.quotaSlow,
+		"quota-slow-below", defaultQuotaSlow,
+		"scale the request rate down when remaining API calls drop below this",
+	)
+	flag.Int64Var(
+		&o.quotaPause,
+		"quota-pause-below", defaultQuotaPause,
+		"pause seeding until quota recovers when remaining calls drop below this",
+	)
+	flag.DurationVar(
+		&o.quotaInterval,
+		"quota-check-interval", defaultQuotaInterval,
+		"how often remaining API quota is polled (0 disables adaptive limiting)",
+	)
+	flag.DurationVar(
+		&o.closingWindow,
+		"closing-line-window", defaultClosingWindow,
+		"in --watch mode, snapshot lines for games kicking off within this "+
+			"window (0 disables)",
+	)
+	flag.DurationVar(
+		&o.closingInterval,
+		"closing-line-interval", defaultClosingInterval,
+		"how often lines are snapshotted for games near kickoff",
+	)
+	flag.IntVar(
+		&o.maxRetries,
+		"max-retries", etl.DefaultMaxRetries,
+		"retries for rate limited (429), 5xx and network API failures",
+	)
+	flag.IntVar(
+		&o.maxFailureAttempts,
+		"max-failure-attempts", seed.DefaultMaxFailureAttempts,
+		"failed per-game fetches are retried until they have failed this "+
+			"many times, then marked permanent",
+	)
+	flag.DurationVar(
+		&o.weatherWindow,
+		"weather-window", defaultWeatherWindow,
+		"in --watch mode, capture weather forecasts for games kicking off "+
+			"within this window and actuals once they finish (0 disables)",
+	)
+	flag.DurationVar(
+		&o.weatherInterval,
+		"weather-interval", defaultWeatherInterval,
+		"how often weather forecasts and actuals are captured",
+	)
+	flag.BoolVar(
+		&o.verify,
+		"verify-sweep", false,
+		"re-verify the next historical week against the API instead of "+
+			"seeding (one week per run, round-robin)",
+	)
+	flag.IntVar(
+		&o.breakerThreshold,
+		"breaker-threshold", etl.DefaultBreakerThreshold,
+		"consecutive failures that pause an endpoint (0 disables)",
+	)
+	flag.DurationVar(
+		&o.breakerCooldown,
+		"breaker-cooldown", etl.DefaultBreakerCooldown,
+		"how long a paused endpoint waits before a probe request",
+	)
+	flag.StringVar(
+		&o.profile,
+		"profile", profileDevelopment,
+		"deployment profile (development or production); production refuses "+
+			"schema changes that lock populated tables without --allow-ddl",
+	)
+	flag.BoolVar(
+		&o.allowDDL,
+		"allow-ddl", false,
+		"apply schema changes to populated tables in the production profile",
+	)
+	flag.DurationVar(
+		&o.progressInterval,
+		"progress-interval", time.Minute,
+		"how often the progress and ETA of long tasks are logged "+
+			"(0 disables)",
+	)
+	flag.StringVar(
+		&o.otlpEndpoint,
+		"otlp-endpoint", "",
+		"OTLP/HTTP endpoint to export traces of tasks, API calls and inserts "+
+			"to, e.g. http://localhost:4318 (disabled when empty)",
+	)
+	flag.StringVar(
+		&o.notifyURL,
+		"notify-url", "",
+		"Slack, Discord or other webhook URL to post a summary to when a run "+
+			"finishes or fails (disabled when empty)",
+	)
+	flag.BoolVar(
+		&o.geocoder,
+		"geocode", false,
+		"fill in venue coordinates and elevation the API is missing with the "+
+			"Open-Meteo geocoding API",
+	)
+	flag.StringVar(
+		&o.weatherProvider,
+		"weather-provider", "",
+		"fill in game weather the API is missing from this provider, e.g. "+
+			"open-meteo (disabled when empty)",
+	)
+	flag.DurationVar(
+		&o.taskTimeout,
+		"task-timeout", 0,
+		"cancel any seed task still running after this long (0 disables)",
+	)
+	flag.IntVar(
+		&o.maxConcurrent,
+		"max-concurrent-tasks", 0,
+		"most seed tasks of a phase run at once (0 means no limit)",
+	)
+	flag.StringVar(
+		&o.driver,
+		"driver", db.DriverPostgres,
+		"database DATABASE_DSN connects to (postgres, mysql or sqlite)",
+	)
+	flag.StringVar(
+		&o.out,
+		"out", "",
+		"SQLite file to seed with --driver=sqlite, in place of DATABASE_DSN; "+
+			"for export, the directory or s3://bucket/prefix written to",
+	)
+	flag.StringVar(
+		&o.format,
+		"format", export.FormatParquet,
+		"file format the export command writes: parquet, csv, ndjson or "+
+			"bundle",
+	)
+	flag.StringVar(
+		&o.exportTables,
+		"tables", "",
+		"comma-separated tables the export command writes or the reset "+
+			"command empties (default all)",
+	)
+	flag.StringVar(
+		&o.confirmDrop,
+		"confirm", "",
+		"nuke: name of the schema to drop, which must be the configured "+
+			"schema (cfbd by default)",
+	)
+	flag.BoolVar(
+		&o.assumeYes,
+		"yes", false,
+		"reset: delete without asking for confirmation",
+	)
+	flag.BoolVar(
+		&o.resetAll,
+		"all", false,
+		"reset: empty every table, without --tables or --years",
+	)
+	flag.StringVar(
+		&o.units,
+		"units", string(db.UnitsImperial),
+		"unit system measurements are stored in (imperial or metric)",
+	)
+	flag.StringVar(
+		&o.seasonTypes,
+		"season-types", "",
+		"comma-separated season types to seed: regular, postseason or both "+
+			"(default all)",
+	)
+	flag.StringVar(
+		&o.teams,
+		"teams", "",
+		"comma-separated teams to limit game-level seeding to, e.g. "+
+			"Michigan,Ohio State (default all)",
+	)
+	flag.StringVar(
+		&o.conferences,
+		"conferences", "",
+		"comma-separated conference abbreviations to limit game-level "+
+			"seeding to, e.g. SEC,B1G (default all)",
+	)
+	flag.StringVar(
+		&o.classification,
+		"classification", "",
+		"classification to limit seeding to: fbs, fcs, ii or iii (default all)",
+	)
+	flag.StringVar(
+		&o.yearSpec,
+		"years", "",
+		"seasons to seed, e.g. 2005-2025 or 2023,2025 (default 2024-2025)",
+	)
+	flag.BoolVar(
+		&o.dryRun,
+		"dry-run", false,
+		"fetch everything but skip all database writes, then report the "+
+			"requests made and rows that would have been written",
+	)
+	flag.StringVar(
+		&o.archive,
+		"archive", "",
+		"directory, s3://bucket/prefix or gs://bucket/prefix every raw API "+
+			"response is archived to before it is inserted",
+	)
+	flag.StringVar(
+		&o.recordFixtures,
+		"record-fixtures", "",
+		"directory every successful API response is recorded to as a "+
+			"fixture for the mock API (disabled when empty)",
+	)
+	flag.StringVar(
+		&o.source,
+		"source", "",
+		"directory, s3://bucket/prefix or gs://bucket/prefix of the archived "+
+			"responses the replay command seeds from, or of the bundle the "+
+			"import command loads",
+	)
+	flag.StringVar(
+		&o.eventsURL,
+		"events", "",
+		"kafka://broker[,broker]/topic or nats://host:port/subject to publish "+
+			"an event per written row to (disabled when empty)",
+	)
+	flag.StringVar(
+		&o.eventTables,
+		"event-tables", "",
+		"comma-separated tables --events publishes the rows of (default all)",
+	)
+	flag.StringVar(
+		&o.graphqlAddr,
+		"graphql-addr", ":8080",
+		"address the serve command serves GraphQL queries on at /graphql",
+	)
+	flag.StringVar(
+		&o.grpcAddr,
+		"grpc-addr", ":9090",
+		"address the serve command serves the gRPC API on "+
+			"(disabled when empty)",
+	)
+	flag.BoolVar(
+		&o.pgNotify,
+		"pg-notify", false,
+		"NOTIFY <schema>_<table>_updated with the rows written per season "+
+			"and week as each task finishes (postgres only)",
+	)
+	flag.BoolVar(
+		&o.bulkCopy,
+		"bulk-copy", true,
+		"load plays and play stats with COPY instead of multi-row INSERTs",
+	)
+	flag.BoolVar(
+		&o.skipUnchanged,
+		"skip-unchanged", false,
+		"hash upserted rows and skip rewriting rows whose content is unchanged "+
+			"since the last run",
+	)
+	flag.BoolVar(
+		&o.history,
+		"history", false,
+		"keep every version of betting lines and poll ranks written in "+
+			"game_lines_history and poll_ranks_history",
+	)
+	flag.StringVar(
+		&o.validation,
+		"validation", string(db.ValidationLog),
+		"what to do with rows that break a validation rule, such as a "+
+			"negative score: fail the insert, skip the row, log it and insert "+
+			"it anyway, or off; every mode but off records the row in "+
+			"validation_errors",
+	)
+	flag.StringVar(
+		&o.orphanMode,
+		"orphans", string(db.OrphansReport),
+		"audit: what to do with rows referencing missing parent rows: "+
+			"report them, delete them, or quarantine them in quarantined_rows",
+	)
+	flag.StringVar(
+		&o.diffEntity,
+		"entity", seed.DiffGames,
+		"diff and refresh: rows to compare with the API (games or lines)",
+	)
+	flag.BoolVar(
+		&o.skipIdentical,
+		"skip-identical", true,
+		"skip writing week syncs whose response is identical to the last "+
+			"one written",
+	)
+	flag.BoolVar(
+		&o.summaryOut,
+		"summary", true,
+		"write a JSON summary of the run (rows, requests, durations and "+
+			"errors per task) to stdout when it finishes",
+	)
+	flag.BoolVar(
+		&o.storeSummary,
+		"store-summary", false,
+		"keep the JSON summary of the run in the summary column of seed_runs",
+	)
+	flag.BoolVar(
+		&o.dashboard,
+		"tui", false,
+		"show a live terminal dashboard instead of streaming logs",
+	)
+	flag.StringVar(
+		&o.dashboardLog,
+		"tui-log", "seeder.log",
+		"file that logs are written to while --tui is enabled",
+	)
+	flag.StringVar(
+		&o.taskName,
+		"name", "",
+		"run-task: task to run, e.g. seed_plays or SeedPlays",
+	)
+	flag.IntVar(
+		&o.taskYear,
+		"year", 0,
+		"run-task: narrow the task to one season; verify, diff and refresh: "+
+			"season to check; backfill: season of --weeks",
+	)
+	flag.IntVar(
+		&o.taskWeek,
+		"week", 0,
+		"run-task, diff and refresh: narrow to one week of --year",
+	)
+	flag.StringVar(
+		&o.backfillWeeks,
+		"weeks", "",
+		"backfill: weeks of --year to refresh, e.g. 8-10",
+	)
+	flag.IntVar(
+		&o.gameID,
+		"id", 0,
+		"game: id of the game to refresh",
+	)
+	flag.StringVar(
+		&o.taskSeasonType,
+		"season-type", "regular",
+		"run-task, diff and refresh: season type of --week (regular or "+
+			"postseason)",
+	)
+	flag.StringVar(
+		&o.output,
+		"output", outputText,
+		"result format of run-task, preflight, verify, diff, refresh, "+
+			"audit, finalize, refresh-views and migrate status (text or json)",
+	)
+	flag.BoolVar(
+		&o.autoMigrate,
+		"auto-migrate", true,
+		"apply pending schema migrations to an existing database on "+
+			"startup; when false the seeder refuses to run until they are "+
+			"applied with the migrate command (a new database is always "+
+			"created)",
+	)
+	flag.BoolVar(
+		&o.queryIndexes,
+		"query-indexes", true,
+		"create composite indexes for common query paths (e.g. games by "+
+			"season and week); disable to keep indexes minimal during loads",
+	)
+	flag.BoolVar(
+		&o.deferIndexes,
+		"defer-indexes", false,
+		"drop secondary indexes of the play and box score tables before "+
+			"seeding and rebuild them concurrently once it finishes",
+	)
+	flag.BoolVar(
+		&o.installConstraints,
+		"constraints", false,
+		"install foreign key and CHECK constraints once seeding finishes, "+
+			"reporting those the loaded rows do not satisfy (postgres only)",
+	)
+	flag.BoolVar(
+		&o.refreshViews,
+		"refresh-views", false,
+		"create or refresh the analytics materialized views once seeding "+
+			"finishes (postgres only)",
+	)
+	flag.StringVar(
+		&o.configPath,
+		"config", "",
+		"path to a JSON config file (e.g. per-endpoint-class rate limits)",
+	)
+	flag.BoolVar(
+		&o.resume,
+		"resume", false,
+		"skip the seed functions completed by the last seed run, if it was "+
+			"stopped by a signal",
+	)
+	flag.Parse()
+
+	// Flags may also follow the command, e.g. "plan --years=2005-2025".
+	o.command = flag.Arg(0)
+	if o.command != "" {
+		if err := flag.CommandLine.Parse(flag.Args()[1:]); err != nil {
+			os.Exit(2)
+		}
+	}
+	// The migrate command takes a verb, and force a version, either of
+	// which flags may follow too, e.g. "migrate force 3".
+	for o.command == migrateCommand && flag.NArg() > 0 {
+		o.migrateArgs = append(o.migrateArgs, flag.Arg(0))
+		if err := flag.CommandLine.Parse(flag.Args()[1:]); err != nil {
+			os.Exit(2)
+		}
+	}
+	if o.command == seedCommand {
+		o.command = ""
+	}
+
+	return o
+}
+
+// check exits if the options name an unknown command or lack what their
+// command requires, and parses the backfill weeks.
+func (o *options) check() {
+	commands := map[string]bool{
+		"":                  true,
+		retryFailedCommand:  true,
+		syncCommand:         true,
+		backfillCommand:     true,
+		gameCommand:         true,
+		planCommand:         true,
+		daemonCommand:       true,
+		runTaskCommand:      true,
+		preflightCommand:    true,
+		migrateCommand:      true,
+		exportCommand:       true,
+		importCommand:       true,
+		replayCommand:       true,
+		serveCommand:        true,
+		verifyCommand:       true,
+		diffCommand:         true,
+		refreshCommand:      true,
+		auditCommand:        true,
+		resetCommand:        true,
+		nukeCommand:         true,
+		finalizeCommand:     true,
+		refreshViewsCommand: true,
+	}
+	if o.profile != profileDevelopment && o.profile != profileProduction {
+		slog.Error("unknown profile", "profile", o.profile)
+		os.Exit(1)
+	}
+	if o.output != outputText && o.output != outputJSON {
+		slog.Error("unknown output format", "output", o.output)
+		os.Exit(1)
+	}
+	if o.command == replayCommand && o.source == "" {
+		slog.Error("replay requires --source")
+		os.Exit(1)
+	}
+	if o.command == importCommand && o.source == "" {
+		slog.Error("import requires --source")
+		os.Exit(1)
+	}
+	if o.command == verifyCommand && o.taskYear == 0 {
+		slog.Error("verify requires --year")
+		os.Exit(1)
+	}
+	if o.command == diffCommand && o.taskYear == 0 {
+		slog.Error("diff requires --year")
+		os.Exit(1)
+	}
+	if o.command == refreshCommand && o.taskYear == 0 {
+		slog.Error("refresh requires --year")
+		os.Exit(1)
+	}
+	if o.command == nukeCommand && o.profile == profileProduction {
+		slog.Error("nuke is refused in the production profile")
+		os.Exit(1)
+	}
+	if o.command == gameCommand && o.gameID == 0 {
+		slog.Error("game requires --id")
+		os.Exit(1)
+	}
+	if o.command == backfillCommand {
+		if o.taskYear == 0 {
+			slog.Error("backfill requires --year")
+			os.Exit(1)
+		}
+		var weeksErr error
+		o.weeks, weeksErr = utils.ParseWeeks(o.backfillWeeks)
+		if weeksErr != nil {
+			slog.Error("backfill requires --weeks", "err", weeksErr)
+			os.Exit(1)
+		}
+	}
+	if o.out != "" && o.driver != db.DriverSQLite && o.command != exportCommand {
+		slog.Error("--out requires --driver=sqlite", "driver", o.driver)
+		os.Exit(1)
+	}
+	if !commands[o.command] || flag.NArg() > 0 {
+		slog.Error("unknown command", "command", strings.Join(
+			append([]string{o.command}, flag.Args()...), " ",
+		))
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,179 @@
+// Package config loads the seeder's optional JSON configuration file.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/schedule"
+)
+
+// ErrInvalidRateLimit is returned when a configured rate limit is not
+// positive.
+var ErrInvalidRateLimit = errors.New("invalid rate limit")
+
+// ErrInvalidConcurrency is returned when a configured worker count is not
+// positive.
+var ErrInvalidConcurrency = errors.New("invalid concurrency")
+
+// ErrInvalidRatings is returned when a configured rating parameter is
+// negative.
+var ErrInvalidRatings = errors.New("invalid ratings")
+
+// GlobalRateLimit is the rate limit key for the limiter shared by every
+// request.
+const GlobalRateLimit = "global"
+
+// Config is the seeder configuration file.
+//
+//	{
+//	  "schema": "cfbd_dev",
+//	  "rate_limits": {
+//	    "global":   {"rps": 10, "burst": 20},
+//	    "per_game": {"rps": 2,  "burst": 2}
+//	  },
+//	  "exclude_columns": {
+//	    "recruit_hometown_info": ["latitude", "longitude"]
+//	  },
+//	  "validation": {
+//	    "games": "fail",
+//	    "plays": "skip"
+//	  },
+//	  "schedules": [
+//	    {"task": "SeedScoreboard", "cron": "*/2 * * * sat"},
+//	    {"task": "SeedRankings",   "cron": "0 6 * * mon"}
+//	  ],
+//	  "concurrency": {
+//	    "phases": {"4": 4},
+//	    "tasks":  {"SeedAdvancedBoxScore": 20}
+//	  },
+//	  "ratings": {"k": 25, "home_advantage": 55, "margin_cap": 28}
+//	}
+type Config struct {
+	// Schema is the PostgreSQL schema the tables are kept in, "cfbd" if it
+	// is empty, so that datasets kept in different schemas can share a
+	// database.
+	Schema string `json:"schema"`
+	// RateLimits maps "global" or an endpoint class (reference, bulk,
+	// per_game, live) to its rate limit.
+	RateLimits map[string]RateLimit `json:"rate_limits"`
+	// ExcludeColumns maps a table name to columns that are cleared before
+	// every insert, so they are never stored.
+	ExcludeColumns map[string][]string `json:"exclude_columns"`
+	// Validation maps a table name to what is done with its rows that break
+	// a validation rule (fail, skip, log or off), overriding --validation.
+	Validation map[string]string `json:"validation"`
+	// Schedules lists the tasks run by the daemon command and when.
+	Schedules []Schedule `json:"schedules"`
+	// Concurrency sets how much of a seed runs at once.
+	Concurrency Concurrency `json:"concurrency"`
+	// Ratings sets the parameters of the locally computed Elo and SRS
+	// ratings.
+	Ratings Ratings `json:"ratings"`
+}
+
+// Ratings holds the parameters of the locally computed ratings; those left
+// out keep their defaults.
+type Ratings struct {
+	// K is how far one game moves an Elo rating.
+	K float64 `json:"k"`
+	// HomeAdvantage is the Elo points the home team is given outside
+	// neutral site games.
+	HomeAdvantage float64 `json:"home_advantage"`
+	// Regression is the share of the distance to the mean Elo a rating
+	// gives back between seasons.
+	Regression float64 `json:"regression"`
+	// Initial is the Elo rating of an FBS team with no previous rating.
+	Initial float64 `json:"initial"`
+	// InitialFCS is the Elo rating of any other team with no previous
+	// rating.
+	InitialFCS float64 `json:"initial_fcs"`
+	// MarginCap caps the margin of victory SRS credits a game with.
+	MarginCap float64 `json:"margin_cap"`
+}
+
+// Concurrency holds worker counts. Higher counts only pay off with an API
+// key whose rate limit can keep up.
+type Concurrency struct {
+	// Phases maps a phase number to how many of its tasks run at once.
+	Phases map[int]int `json:"phases"`
+	// Tasks maps a task that fetches per game (e.g. "SeedWinProbability")
+	// or per season (e.g. "SeedDrives") to how many of its requests are in
+	// flight at once.
+	Tasks map[string]int `json:"tasks"`
+}
+
+// Schedule runs a seeder task (e.g. "SeedRankings") whenever a five-field
+// cron expression matches, in the daemon's local time zone.
+type Schedule struct {
+	Task string `json:"task"`
+	Cron string `json:"cron"`
+}
+
+// RateLimit is a token bucket: RPS requests per second with bursts of up to
+// Burst requests.
+type RateLimit struct {
+	RPS   float64 `json:"rps"`
+	Burst int     `json:"burst"`
+}
+
+// Load reads and validates the configuration file at path.
+func Load(path string) (Config, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("could not read config; %w", err)
+	}
+
+	var conf Config
+	if err = json.Unmarshal(raw, &conf); err != nil {
+		return Config{}, fmt.Errorf("could not parse config; %w", err)
+	}
+
+	for name, limit := range conf.RateLimits {
+		if limit.RPS <= 0 || limit.Burst <= 0 {
+			return Config{}, fmt.Errorf(
+				"%w: %q must have positive rps and burst",
+				ErrInvalidRateLimit, name,
+			)
+		}
+	}
+
+	for phase, workers := range conf.Concurrency.Phases {
+		if workers <= 0 {
+			return Config{}, fmt.Errorf(
+				"%w: phase %d must have a positive worker count",
+				ErrInvalidConcurrency, phase,
+			)
+		}
+	}
+	for task, workers := range conf.Concurrency.Tasks {
+		if workers <= 0 {
+			return Config{}, fmt.Errorf(
+				"%w: %q must have a positive worker count",
+				ErrInvalidConcurrency, task,
+			)
+		}
+	}
+
+	r := conf.Ratings
+	if r.K < 0 || r.HomeAdvantage < 0 || r.Regression < 0 ||
+		r.Regression > 1 || r.Initial < 0 || r.InitialFCS < 0 ||
+		r.MarginCap < 0 {
+		return Config{}, fmt.Errorf(
+			"%w: parameters must not be negative and regression must not "+
+				"exceed 1", ErrInvalidRatings,
+		)
+	}
+
+	for _, sched := range conf.Schedules {
+		if _, err = schedule.ParseCron(sched.Cron); err != nil {
+			return Config{}, fmt.Errorf(
+				"could not parse schedule for %q; %w", sched.Task, err,
+			)
+		}
+	}
+
+	return conf, nil
+}
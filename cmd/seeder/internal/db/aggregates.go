@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// explosiveRushYards and explosivePassYards are the gains from which a
+	// rush or a pass counts as explosive.
+	explosiveRushYards = 12
+	explosivePassYards = 16
+
+	// successValue is 1 for a successful play of plays p and 0 for an
+	// unsuccessful one, to average into a success rate.
+	successValue = "CASE WHEN p.success THEN 1.0 " +
+		"WHEN NOT p.success THEN 0.0 END"
+	// explosiveValue is 1 for an explosive play of plays p and 0 otherwise.
+	explosiveValue = `CASE
+		WHEN p.rush_pass = @rush AND p.yards_gained >= @rushYards THEN 1
+		WHEN p.rush_pass = @pass AND p.yards_gained >= @passYards THEN 1
+		ELSE 0
+	END`
+)
+
+// aggregateParams returns the named parameters of the aggregate queries.
+func aggregateParams(season int32) map[string]any {
+	return map[string]any{
+		"rush":      PlayRush,
+		"pass":      PlayPass,
+		"standard":  DownTypeStandard,
+		"passing":   DownTypePassing,
+		"rushYards": explosiveRushYards,
+		"passYards": explosivePassYards,
+		"now":       time.Now().UTC(),
+		"season":    season,
+	}
+}
+
+// ComputeDriveStats aggregates the rushes and passes of every drive of a
+// season into drive_stats, replacing the season's previous aggregates in
+// one transaction, and returns how many drives it stored. Success is read
+// from the play flags, so ComputePlayFlags must have run for the season
+// first. Drives without a rush or pass are left out.
+func (db *Database) ComputeDriveStats(
+	ctx context.Context,
+	season int32,
+) (int64, error) {
+	var stored int64
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("season = ?", season).Delete(&DriveStats{}).Error
+		if err != nil {
+			return fmt.Errorf("could not clear drive stats; %w", err)
+		}
+
+		result := tx.Exec(`
+			INSERT INTO drive_stats (
+				drive_id, season, game_id, offense, plays, successful_plays,
+				explosive_plays, success_rate, epa, epa_per_play, computed_at
+			)
+			SELECT p.drive_id, p.season, MAX(p.game_id), MAX(p.offense),
+				COUNT(*), SUM(CASE WHEN p.success THEN 1 ELSE 0 END),
+				SUM(`+explosiveValue+`), AVG(`+successValue+`),
+				SUM(p.ppa), AVG(p.ppa), @now
+			FROM plays p
+			WHERE p.season = @season AND p.drive_id <> ''
+				AND p.rush_pass IN (@rush, @pass)
+			GROUP BY p.drive_id, p.season`,
+			aggregateParams(season),
+		)
+		if result.Error != nil {
+			return fmt.Errorf("could not insert drive stats; %w", result.Error)
+		}
+		stored = result.RowsAffected
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return stored, nil
+}
+
+// ComputeTeamWeekStats aggregates every team's rushes and passes outside
+// garbage time in each week of a season into team_week_stats, replacing
+// the season's previous aggregates in one transaction, and returns how many
+// team weeks it stored. Like ComputeDriveStats it reads the play flags.
+func (db *Database) ComputeTeamWeekStats(
+	ctx context.Context,
+	season int32,
+) (int64, error) {
+	var stored int64
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("season = ?", season).Delete(&TeamWeekStats{}).Error
+		if err != nil {
+			return fmt.Errorf("could not clear team week stats; %w", err)
+		}
+
+		result := tx.Exec(`
+			INSERT INTO team_week_stats (
+				season, week, season_type, team, games, plays,
+				plays_per_game, epa_per_play, success_rate, explosive_rate,
+				explosiveness, rush_epa_per_play, pass_epa_per_play,
+				standard_down_plays, standard_down_epa_per_play,
+				standard_down_success_rate, standard_down_explosiveness,
+				passing_down_plays, passing_down_epa_per_play,
+				passing_down_success_rate, passing_down_explosiveness,
+				computed_at
+			)
+			SELECT g.season, g.week, g.season_type, p.offense,
+				COUNT(DISTINCT p.game_id), COUNT(*),
+				COUNT(*) * 1.0 / COUNT(DISTINCT p.game_id),
+				AVG(p.ppa), AVG(`+successValue+`),
+				AVG(`+explosiveValue+`),
+				AVG(CASE WHEN p.success THEN p.ppa END),
+				AVG(CASE WHEN p.rush_pass = @rush THEN p.ppa END),
+				AVG(CASE WHEN p.rush_pass = @pass THEN p.ppa END),
+				SUM(CASE WHEN p.down_type = @standard THEN 1 ELSE 0 END),
+				AVG(CASE WHEN p.down_type = @standard THEN p.ppa END),
+				AVG(CASE WHEN p.down_type = @standard
+					THEN `+successValue+` END),
+				AVG(CASE WHEN p.down_type = @standard AND p.success
+					THEN p.ppa END),
+				SUM(CASE WHEN p.down_type = @passing THEN 1 ELSE 0 END),
+				AVG(CASE WHEN p.down_type = @passing THEN p.ppa END),
+				AVG(CASE WHEN p.down_type = @passing
+					THEN `+successValue+` END),
+				AVG(CASE WHEN p.down_type = @passing AND p.success
+					THEN p.ppa END),
+				@now
+			FROM plays p
+			JOIN games g ON g.id = p.game_id
+			WHERE p.season = @season AND p.offense <> ''
+				AND p.rush_pass IN (@rush, @pass) AND NOT p.garbage_time
+			GROUP BY g.season, g.week, g.season_type, p.offense`,
+			aggregateParams(season),
+		)
+		if result.Error != nil {
+			return fmt.Errorf(
+				"could not insert team week stats; %w", result.Error,
+			)
+		}
+		stored = result.RowsAffected
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return stored, nil
+}
@@ -0,0 +1,293 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// OrphanMode is what AuditReferences does with the orphaned rows it finds.
+type OrphanMode string
+
+const (
+	// OrphansReport only counts the orphaned rows.
+	OrphansReport OrphanMode = "report"
+	// OrphansDelete deletes the orphaned rows.
+	OrphansDelete OrphanMode = "delete"
+	// OrphansQuarantine moves the orphaned rows to quarantined_rows.
+	OrphansQuarantine OrphanMode = "quarantine"
+)
+
+// orphanSampleSize is how many dangling values are reported per reference.
+const orphanSampleSize = 5
+
+// ErrUnknownOrphanMode is returned for an orphan mode other than report,
+// delete or quarantine.
+var ErrUnknownOrphanMode = errors.New("unknown orphan mode")
+
+// ParseOrphanMode returns the orphan mode named s.
+func ParseOrphanMode(s string) (OrphanMode, error) {
+	switch mode := OrphanMode(s); mode {
+	case OrphansReport, OrphansDelete, OrphansQuarantine:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownOrphanMode, s)
+	}
+}
+
+// reference is a column whose values should each match a row of a parent
+// table. Foreign key constraints are not created when migrating, so nothing
+// else enforces it.
+type reference struct {
+	table        string
+	column       string
+	parent       string
+	parentColumn string
+	// optional is true when an empty value references nothing.
+	optional bool
+	// noForeignKey is true when InstallConstraints cannot enforce the
+	// reference with a foreign key.
+	noForeignKey bool
+}
+
+func (r reference) String() string {
+	return fmt.Sprintf("%s.%s -> %s.%s",
+		r.table, r.column, r.parent, r.parentColumn)
+}
+
+// orphaned returns the condition matching the rows of the reference's table
+// whose parent row does not exist.
+func (r reference) orphaned() string {
+	cond := fmt.Sprintf(`%[1]s.%[2]s IS NOT NULL AND NOT EXISTS (
+		SELECT 1 FROM %[3]s p WHERE p.%[4]s = %[1]s.%[2]s
+	)`, r.table, r.column, r.parent, r.parentColumn)
+	if r.optional {
+		cond += fmt.Sprintf(" AND %s.%s <> ''", r.table, r.column)
+	}
+
+	return cond
+}
+
+// references lists the references audited by AuditReferences, parents
+// before their children, so that the children of a row removed as an orphan
+// are found in the same audit.
+var references = []reference{
+	{
+		table: (Drive{}).TableName(), column: "game_id",
+		parent: (Game{}).TableName(), parentColumn: "id",
+	},
+	{
+		table: (Play{}).TableName(), column: "game_id",
+		parent: (Game{}).TableName(), parentColumn: "id",
+	},
+	// Drive and play IDs are only unique within a season, and a play
+	// without a drive has an empty drive ID rather than a null one.
+	{
+		table: (Play{}).TableName(), column: "drive_id",
+		parent: (Drive{}).TableName(), parentColumn: "id",
+		optional: true, noForeignKey: true,
+	},
+	{
+		table: (PlayStat{}).TableName(), column: "play_id",
+		parent: (Play{}).TableName(), parentColumn: "id",
+		noForeignKey: true,
+	},
+	{
+		table: (GameLine{}).TableName(), column: "game_id",
+		parent: (BettingGame{}).TableName(), parentColumn: "id",
+	},
+	// Snapshots are written before the lines they were taken from.
+	{
+		table: (GameLineSnapshot{}).TableName(), column: "game_id",
+		parent: (BettingGame{}).TableName(), parentColumn: "id",
+		noForeignKey: true,
+	},
+	{
+		table: (Poll{}).TableName(), column: "poll_week_id",
+		parent: (PollWeek{}).TableName(), parentColumn: "id",
+	},
+	{
+		table: (PollRank{}).TableName(), column: "poll_id",
+		parent: (Poll{}).TableName(), parentColumn: "id",
+	},
+	{
+		table: (GameTeamStatsTeam{}).TableName(), column: "game_id",
+		parent: (GameTeamStats{}).TableName(), parentColumn: "id",
+	},
+	{
+		table: (GameTeamStatsTeamStat{}).TableName(), column: "team_row_id",
+		parent: (GameTeamStatsTeam{}).TableName(), parentColumn: "id",
+	},
+	{
+		table: (GamePlayerStatsTeam{}).TableName(), column: "game_id",
+		parent: (GamePlayerStats{}).TableName(), parentColumn: "id",
+	},
+	{
+		table: (GamePlayerStatCategories{}).TableName(), column: "team_row_id",
+		parent: (GamePlayerStatsTeam{}).TableName(), parentColumn: "id",
+	},
+	{
+		table: (GamePlayerStatTypes{}).TableName(), column: "category_row_id",
+		parent: (GamePlayerStatCategories{}).TableName(), parentColumn: "id",
+	},
+	{
+		table: (GamePlayerStatPlayer{}).TableName(), column: "type_row_id",
+		parent: (GamePlayerStatTypes{}).TableName(), parentColumn: "id",
+	},
+	{
+		table: (MatchupGame{}).TableName(), column: "matchup_id",
+		parent: (Matchup{}).TableName(), parentColumn: "matchup_id",
+	},
+	{
+		table: (CoachSeason{}).TableName(), column: "coach_id",
+		parent: (Coach{}).TableName(), parentColumn: "id",
+	},
+	{
+		table: (LiveGameTeam{}).TableName(), column: "live_game_id",
+		parent: (LiveGame{}).TableName(), parentColumn: "id",
+	},
+	{
+		table: (LiveGameDrive{}).TableName(), column: "live_game_id",
+		parent: (LiveGame{}).TableName(), parentColumn: "id",
+	},
+	{
+		table: (LiveGamePlay{}).TableName(), column: "drive_id",
+		parent: (LiveGameDrive{}).TableName(), parentColumn: "id",
+	},
+}
+
+// OrphanReport is what AuditReferences found for one reference.
+type OrphanReport struct {
+	Reference string `json:"reference"`
+	Orphans   int64  `json:"orphans"`
+	// Sample holds a few of the values referencing missing rows.
+	Sample []string `json:"sample,omitempty"`
+	// Removed is the number of orphans deleted or quarantined.
+	Removed int64 `json:"removed"`
+}
+
+// AuditReferences counts the rows of every audited table that reference a
+// parent row that does not exist, e.g. plays of a missing drive or lines of
+// a missing betting game, and deletes or quarantines them as mode says.
+// Quarantined rows are copied to quarantined_rows in the same transaction
+// as they are deleted. References whose tables have not been created are
+// left out.
+func (db *Database) AuditReferences(
+	ctx context.Context,
+	mode OrphanMode,
+) ([]OrphanReport, error) {
+	if _, err := ParseOrphanMode(string(mode)); err != nil {
+		return nil, err
+	}
+
+	gdb := db.WithContext(ctx)
+	migrator := gdb.Migrator()
+	reports := make([]OrphanReport, 0, len(references))
+	for _, ref := range references {
+		if !migrator.HasTable(ref.table) || !migrator.HasTable(ref.parent) {
+			continue
+		}
+
+		report := OrphanReport{Reference: ref.String()}
+		err := gdb.Table(ref.table).Where(ref.orphaned()).
+			Count(&report.Orphans).Error
+		if err != nil {
+			return nil, fmt.Errorf("could not count orphaned %s; %w",
+				ref.table, err)
+		}
+		if report.Orphans == 0 {
+			reports = append(reports, report)
+			continue
+		}
+
+		err = gdb.Table(ref.table).Where(ref.orphaned()).
+			Distinct(ref.table+"."+ref.column).
+			Limit(orphanSampleSize).
+			Pluck(ref.table+"."+ref.column, &report.Sample).Error
+		if err != nil {
+			return nil, fmt.Errorf("could not sample orphaned %s; %w",
+				ref.table, err)
+		}
+
+		if mode != OrphansReport {
+			err = gdb.Transaction(func(tx *gorm.DB) error {
+				removed, err := removeOrphans(tx, ref, mode)
+				report.Removed = removed
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+			slog.Info("removed orphaned rows",
+				"reference", report.Reference,
+				"rows", report.Removed,
+				"mode", mode)
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// removeOrphans deletes the orphaned rows of a reference, first copying them
+// to quarantined_rows if mode is OrphansQuarantine, and returns how many
+// were removed.
+func removeOrphans(
+	tx *gorm.DB,
+	ref reference,
+	mode OrphanMode,
+) (int64, error) {
+	if mode == OrphansQuarantine {
+		var rows []map[string]any
+		err := tx.Table(ref.table).Where(ref.orphaned()).Find(&rows).Error
+		if err != nil {
+			return 0, fmt.Errorf("could not read orphaned %s; %w",
+				ref.table, err)
+		}
+
+		now := time.Now().UTC()
+		quarantined := make([]QuarantinedRow, 0, len(rows))
+		for _, row := range rows {
+			// Drivers return text and json columns as bytes, which would
+			// otherwise be encoded as base64.
+			for column, value := range row {
+				if b, ok := value.([]byte); ok {
+					row[column] = string(b)
+				}
+			}
+			payload, err := json.Marshal(row)
+			if err != nil {
+				return 0, fmt.Errorf("could not encode orphaned %s; %w",
+					ref.table, err)
+			}
+			quarantined = append(quarantined, QuarantinedRow{
+				Table:         ref.table,
+				Reference:     ref.String(),
+				Row:           datatypes.JSON(payload),
+				QuarantinedAt: now,
+			})
+		}
+
+		err = tx.CreateInBatches(quarantined, 500).Error
+		if err != nil {
+			return 0, fmt.Errorf("could not quarantine orphaned %s; %w",
+				ref.table, err)
+		}
+	}
+
+	deleted := tx.Exec(fmt.Sprintf(
+		"DELETE FROM %s WHERE %s", ref.table, ref.orphaned(),
+	))
+	if deleted.Error != nil {
+		return 0, fmt.Errorf("could not remove orphaned %s; %w",
+			ref.table, deleted.Error)
+	}
+
+	return deleted.RowsAffected, nil
+}
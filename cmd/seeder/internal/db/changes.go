@@ -0,0 +1,244 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// skipCreateKey marks a create statement whose rows were all dropped,
+	// as unchanged or invalid.
+	skipCreateKey = "cfbd:skip_create"
+	// rowHashesKey holds the hashes of the rows a create statement writes,
+	// to be stored once the write succeeds.
+	rowHashesKey = "cfbd:row_hashes"
+	// writeCountsKey holds how the rows of a filtered upsert batch will be
+	// written, for the table sync callback.
+	writeCountsKey = "cfbd:write_counts"
+)
+
+// SkipUnchanged makes upserts skip rows whose content has not changed since
+// they were last written, which avoids rewriting (and WAL logging) millions
+// of identical rows on repeat runs.
+//
+// The content hash of every upserted row is kept in row_hashes, keyed by
+// table and primary key, and rows whose hash matches are dropped from the
+// batch before it is written. Batches containing rows without a primary key
+// yet (e.g. auto-increment IDs) are always written in full. Rows edited
+// outside the seeder are not detected, so clear row_hashes after doing so.
+func (db *Database) SkipUnchanged(ctx context.Context) error {
+	if err := skipMarkedCreates(db.DB); err != nil {
+		return err
+	}
+
+	callbacks := db.Callback().Create()
+	err := callbacks.Before("gorm:save_before_associations").Register(
+		"cfbd:skip_unchanged", skipUnchanged,
+	)
+	if err != nil {
+		return fmt.Errorf("could not register skip unchanged callback; %w", err)
+	}
+
+	err = callbacks.After("gorm:save_after_associations").Register(
+		"cfbd:store_row_hashes", storeRowHashes,
+	)
+	if err != nil {
+		return fmt.Errorf("could not register row hash callback; %w", err)
+	}
+
+	return nil
+}
+
+// skipMarkedCreates replaces the create callback with one that leaves out
+// the statements marked with skipCreateKey.
+func skipMarkedCreates(gdb *gorm.DB) error {
+	callbacks := gdb.Callback().Create()
+	create := callbacks.Get("gorm:create")
+	if create == nil {
+		return errors.New("could not find create callback")
+	}
+
+	err := callbacks.Replace("gorm:create", func(tx *gorm.DB) {
+		if _, skip := tx.InstanceGet(skipCreateKey); skip {
+			return
+		}
+		create(tx)
+	})
+	if err != nil {
+		return fmt.Errorf("could not replace create callback; %w", err)
+	}
+
+	return nil
+}
+
+// skipUnchanged drops the rows of an upsert batch whose stored hash matches
+// their content, and notes the hashes of the remaining rows.
+func skipUnchanged(tx *gorm.DB) {
+	stmt := tx.Statement
+	if tx.Error != nil || stmt.Schema == nil ||
+		stmt.Table == (RowHash{}).TableName() || !isUpsert(stmt) ||
+		stmt.ReflectValue.Kind() != reflect.Slice {
+		return
+	}
+
+	rows := stmt.ReflectValue
+	keys := make([]string, rows.Len())
+	hashes := make([][]byte, rows.Len())
+	lookup := make([]string, 0, rows.Len())
+	keyed := true
+	for i := range rows.Len() {
+		row := reflect.Indirect(rows.Index(i))
+
+		key, ok := rowKey(stmt.Context, stmt.Schema, row)
+		if !ok {
+			keyed = false
+			continue
+		}
+		payload, err := json.Marshal(row.Interface())
+		if err != nil {
+			_ = tx.AddError(fmt.Errorf("could not hash row; %w", err))
+			return
+		}
+		sum := sha256.Sum256(payload)
+		keys[i], hashes[i] = key, sum[:]
+		lookup = append(lookup, key)
+	}
+	if len(lookup) == 0 {
+		return
+	}
+
+	var stored []RowHash
+	err := tx.Session(&gorm.Session{NewDB: true}).
+		Where("table_name = ? AND row_key IN ?", stmt.Table, lookup).
+		Find(&stored).Error
+	if err != nil {
+		_ = tx.AddError(fmt.Errorf("could not get row hashes; %w", err))
+		return
+	}
+	previous := make(map[string][]byte, len(stored))
+	for _, h := range stored {
+		previous[h.Key] = h.Hash
+	}
+
+	changed := reflect.MakeSlice(rows.Type(), 0, rows.Len())
+	pending := make([]RowHash, 0, rows.Len())
+	var counts etl.WriteCounts
+	for i := range rows.Len() {
+		if keys[i] == "" {
+			changed = reflect.Append(changed, rows.Index(i))
+			continue
+		}
+		if keyed && bytes.Equal(previous[keys[i]], hashes[i]) {
+			counts.Skipped++
+			continue
+		}
+		// A row with a stored hash was written before, so it is updated;
+		// one without may still predate row_hashes.
+		if _, ok := previous[keys[i]]; ok {
+			counts.Updated++
+		} else {
+			counts.Upserted++
+		}
+		changed = reflect.Append(changed, rows.Index(i))
+		pending = append(pending, RowHash{
+			Table: stmt.Table,
+			Key:   keys[i],
+			Hash:  hashes[i],
+		})
+	}
+	tx.InstanceSet(rowHashesKey, pending)
+
+	// Only fully keyed batches are filtered, since rows copied into a new
+	// slice no longer receive generated IDs back from the insert.
+	if !keyed {
+		return
+	}
+	tx.InstanceSet(writeCountsKey, counts)
+	if changed.Len() == rows.Len() {
+		return
+	}
+	stmt.ReflectValue = changed
+	if changed.Len() == 0 {
+		tx.InstanceSet(skipCreateKey, true)
+	}
+}
+
+// storeRowHashes records the hashes of the rows an upsert wrote.
+func storeRowHashes(tx *gorm.DB) {
+	if tx.Error != nil {
+		return
+	}
+	value, ok := tx.InstanceGet(rowHashesKey)
+	if !ok {
+		return
+	}
+	pending, _ := value.([]RowHash)
+	if len(pending) == 0 {
+		return
+	}
+
+	err := tx.Session(&gorm.Session{NewDB: true}).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "table_name"},
+				{Name: "row_key"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"hash",
+			}),
+		}).
+		Create(&pending).Error
+	if err != nil {
+		_ = tx.AddError(fmt.Errorf("could not store row hashes; %w", err))
+	}
+}
+
+// isUpsert reports whether the create statement updates rows that already
+// exist, as opposed to appending or ignoring conflicts.
+func isUpsert(stmt *gorm.Statement) bool {
+	c, ok := stmt.Clauses["ON CONFLICT"]
+	if !ok {
+		return false
+	}
+	onConflict, ok := c.Expression.(clause.OnConflict)
+
+	return ok && (onConflict.UpdateAll || len(onConflict.DoUpdates) > 0)
+}
+
+// rowKey encodes the row's primary key, or reports false if any part of it
+// is unset.
+func rowKey(
+	ctx context.Context,
+	sch *schema.Schema,
+	row reflect.Value,
+) (string, bool) {
+	if len(sch.PrimaryFields) == 0 {
+		return "", false
+	}
+
+	parts := make([]any, 0, len(sch.PrimaryFields))
+	for _, field := range sch.PrimaryFields {
+		value, zero := field.ValueOf(ctx, row)
+		if zero {
+			return "", false
+		}
+		parts = append(parts, value)
+	}
+
+	key, err := json.Marshal(parts)
+	if err != nil {
+		return "", false
+	}
+
+	return string(key), true
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm/clause"
+)
+
+// SaveSeedCheckpoint records the seed functions completed by the stopped
+// run of the command, replacing any earlier checkpoint.
+func (db *Database) SaveSeedCheckpoint(
+	ctx context.Context,
+	command string,
+	tasks []string,
+	at time.Time,
+) error {
+	encoded, err := json.Marshal(tasks)
+	if err != nil {
+		return fmt.Errorf("could not encode checkpoint; %w", err)
+	}
+
+	checkpoint := SeedCheckpoint{
+		Command:   command,
+		Tasks:     datatypes.JSON(encoded),
+		StoppedAt: at,
+	}
+	err = db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "command"}},
+		DoUpdates: clause.AssignmentColumns([]string{"tasks", "stopped_at"}),
+	}).Create(&checkpoint).Error
+	if err != nil {
+		return fmt.Errorf("could not save checkpoint; %w", err)
+	}
+
+	return nil
+}
+
+// SeedCheckpoint returns the seed functions completed by the last run of
+// the command, if it was stopped; nil if there is nothing to resume.
+func (db *Database) SeedCheckpoint(
+	ctx context.Context,
+	command string,
+) ([]string, error) {
+	var checkpoints []SeedCheckpoint
+	err := db.WithContext(ctx).
+		Where("command = ?", command).
+		Limit(1).
+		Find(&checkpoints).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not get checkpoint; %w", err)
+	}
+	if len(checkpoints) == 0 {
+		return nil, nil
+	}
+
+	var tasks []string
+	if err = json.Unmarshal(checkpoints[0].Tasks, &tasks); err != nil {
+		return nil, fmt.Errorf("could not decode checkpoint; %w", err)
+	}
+
+	return tasks, nil
+}
+
+// ClearSeedCheckpoint removes the command's checkpoint, once a run of it
+// has finished without being stopped and so left nothing to resume.
+func (db *Database) ClearSeedCheckpoint(
+	ctx context.Context,
+	command string,
+) error {
+	err := db.WithContext(ctx).
+		Where("command = ?", command).
+		Delete(&SeedCheckpoint{}).Error
+	if err != nil {
+		return fmt.Errorf("could not clear checkpoint; %w", err)
+	}
+
+	return nil
+}
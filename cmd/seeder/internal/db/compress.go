@@ -0,0 +1,153 @@
+package db
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	// ErrUnsupportedPayload is returned when a CompressedJSON column is
+	// scanned from a value that is neither bytes nor a string.
+	ErrUnsupportedPayload = errors.New("unsupported payload value")
+	// ErrCompressionMismatch is returned by NewDatabase when
+	// Config.CompressPayloads differs from the setting the payload columns
+	// were created with.
+	ErrCompressionMismatch = errors.New(
+		"payload compression does not match the schema",
+	)
+)
+
+// gzipMagic prefixes every gzip stream and can never start a JSON document,
+// which lets compressed and plain payloads be told apart on read.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// compressPayloads is set from Config.CompressPayloads when the database is
+// opened. It must not change once the schema has been migrated, because it
+// also decides the column type of every CompressedJSON field;
+// checkPayloadColumns rejects a setting the migrated columns do not match.
+var compressPayloads atomic.Bool
+
+// checkPayloadColumns returns ErrCompressionMismatch if the payload columns
+// of a migrated schema have the type of the other setting of compress. A
+// schema without them yet takes the type of compress when migrated.
+func checkPayloadColumns(gdb *gorm.DB, compress bool) error {
+	migrator := gdb.Migrator()
+	if !migrator.HasTable(&AdvancedBoxScore{}) {
+		return nil
+	}
+
+	columns, err := migrator.ColumnTypes(&AdvancedBoxScore{})
+	if err != nil {
+		return fmt.Errorf("could not read payload column type; %w", err)
+	}
+	for _, column := range columns {
+		if column.Name() != "payload" {
+			continue
+		}
+
+		// MariaDB reports json columns as longtext, so only the compressed
+		// type is compared.
+		actual := column.DatabaseTypeName()
+		compressed := strings.EqualFold(
+			actual, payloadTypes[gdb.Dialector.Name()][true],
+		)
+		if compressed != compress {
+			return fmt.Errorf("%w: CompressPayloads is %t but payloads are %s",
+				ErrCompressionMismatch, compress, actual)
+		}
+	}
+
+	return nil
+}
+
+// CompressedJSON is a JSON payload column that is stored as jsonb by default
+// or, when payload compression is enabled, as gzip-compressed bytea. Reads
+// through GORM always yield plain JSON regardless of how it was stored.
+type CompressedJSON []byte
+
+// GormDataType implements schema.GormDataTypeInterface.
+func (CompressedJSON) GormDataType() string {
+	return "json"
+}
+
+// GormDBDataType implements migrator.GormDBDataTypeInterface.
+func (CompressedJSON) GormDBDataType(*gorm.DB, *schema.Field) string {
+	if compressPayloads.Load() {
+		return "bytea"
+	}
+	return "jsonb"
+}
+
+// Value implements driver.Valuer.
+func (j CompressedJSON) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+
+	if !compressPayloads.Load() {
+		return string(j), nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(j); err != nil {
+		return nil, fmt.Errorf("could not compress payload; %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("could not compress payload; %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Scan implements sql.Scanner, transparently decompressing gzip payloads.
+func (j *CompressedJSON) Scan(value any) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		*j = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedPayload, value)
+	}
+
+	if !bytes.HasPrefix(raw, gzipMagic) {
+		*j = append((*j)[:0], raw...)
+		return nil
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("could not decompress payload; %w", err)
+	}
+	defer zr.Close()
+
+	decoded, err := io.ReadAll(zr)
+	if err != nil {
+		return fmt.Errorf("could not decompress payload; %w", err)
+	}
+
+	*j = decoded
+	return nil
+}
+
+// MarshalJSON returns the payload as-is so it embeds as raw JSON.
+func (j CompressedJSON) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
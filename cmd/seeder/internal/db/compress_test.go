@@ -0,0 +1,53 @@
+package db_test
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+)
+
+// TestCompressionMismatch checks that a SQLite database migrated with one
+// setting of CompressPayloads cannot be opened with the other.
+func TestCompressionMismatch(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		t.Run(fmt.Sprintf("compress=%t", compress), func(t *testing.T) {
+			dsn := filepath.Join(t.TempDir(), "cfbd.db")
+			open := func(compress bool) (*db.Database, error) {
+				database, err := db.NewDatabase(db.Config{
+					Driver:           db.DriverSQLite,
+					DSN:              dsn,
+					CompressPayloads: compress,
+				})
+				if err != nil {
+					return nil, err
+				}
+				t.Cleanup(func() {
+					if sqlDB, err := database.DB.DB(); err == nil {
+						_ = sqlDB.Close()
+					}
+				})
+				return database, nil
+			}
+
+			database, err := open(compress)
+			if err != nil {
+				t.Fatalf("could not open new database; %v", err)
+			}
+			if err = database.Initialize(); err != nil {
+				t.Fatalf("could not initialize schema; %v", err)
+			}
+
+			if _, err = open(compress); err != nil {
+				t.Errorf("could not open with the same setting; %v", err)
+			}
+			_, err = open(!compress)
+			if !errors.Is(err, db.ErrCompressionMismatch) {
+				t.Errorf("opening with the other setting returned %v, want %v",
+					err, db.ErrCompressionMismatch)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrConflictTarget is returned when an upsert's ON CONFLICT target is not
+// the primary key or a unique index of its table.
+var ErrConflictTarget = errors.New("invalid conflict target")
+
+// checkConflictTargets registers a callback that rejects upserts whose
+// conflict target does not match the table's primary key or one of its
+// unique indexes, before they reach the database. Every upsert must name
+// its target explicitly: an implicit target silently follows the model's
+// primary key, which stops matching the table's real constraint as soon as
+// the table gains a surrogate key. Inserts that ignore every conflict with
+// DO NOTHING need no target.
+func checkConflictTargets(gdb *gorm.DB) error {
+	err := gdb.Callback().Create().Before("gorm:create").Register(
+		"cfbd:conflict_target",
+		func(tx *gorm.DB) {
+			if tx.Error != nil || tx.Statement.Schema == nil {
+				return
+			}
+			if err := conflictTarget(tx.Statement); err != nil {
+				_ = tx.AddError(err)
+			}
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("could not register conflict target callback; %w", err)
+	}
+
+	return nil
+}
+
+// conflictTarget checks the target of the statement's ON CONFLICT clause,
+// if it has one.
+func conflictTarget(stmt *gorm.Statement) error {
+	c, ok := stmt.Clauses["ON CONFLICT"]
+	if !ok {
+		return nil
+	}
+	onConflict, ok := c.Expression.(clause.OnConflict)
+	if !ok || onConflict.OnConstraint != "" {
+		return nil
+	}
+
+	target := make([]string, 0, len(onConflict.Columns))
+	for _, column := range onConflict.Columns {
+		target = append(target, column.Name)
+	}
+	if len(target) == 0 {
+		if onConflict.DoNothing && !onConflict.UpdateAll {
+			return nil
+		}
+		return fmt.Errorf("%w: upsert into %s names no conflict columns",
+			ErrConflictTarget, stmt.Table)
+	}
+	slices.Sort(target)
+
+	for _, key := range uniqueKeys(stmt.Schema) {
+		if slices.Equal(key, target) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s (%s) is not a primary key or unique index",
+		ErrConflictTarget, stmt.Table, strings.Join(target, ", "))
+}
+
+// uniqueKeys returns the sorted columns of the primary key and of every
+// unique index of the table.
+func uniqueKeys(sch *schema.Schema) [][]string {
+	var keys [][]string
+
+	key := make([]string, 0, len(sch.PrimaryFieldDBNames))
+	key = append(key, sch.PrimaryFieldDBNames...)
+	keys = append(keys, key)
+
+	for _, field := range sch.Fields {
+		if field.Unique && field.DBName != "" {
+			keys = append(keys, []string{field.DBName})
+		}
+	}
+
+	for _, index := range sch.ParseIndexes() {
+		if index.Class != "UNIQUE" {
+			continue
+		}
+		key := make([]string, 0, len(index.Fields))
+		for _, field := range index.Fields {
+			key = append(key, field.DBName)
+		}
+		keys = append(keys, key)
+	}
+
+	for _, key := range keys {
+		slices.Sort(key)
+	}
+
+	return keys
+}
@@ -0,0 +1,210 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Outcomes of installing a constraint.
+const (
+	ConstraintInstalled = "installed"
+	ConstraintExists    = "exists"
+	ConstraintFailed    = "failed"
+)
+
+const (
+	// foreignKeyViolation is the SQLSTATE of a foreign key violation.
+	foreignKeyViolation = "23503"
+	// checkViolation is the SQLSTATE of a CHECK constraint violation.
+	checkViolation = "23514"
+)
+
+// constraint is a foreign key or CHECK constraint that AutoMigrate does not
+// create, named and defined as in ALTER TABLE ... ADD CONSTRAINT.
+type constraint struct {
+	name       string
+	table      string
+	definition string
+}
+
+// checkConstraints lists the CHECK constraints installed by
+// InstallConstraints: the columns holding one of a fixed set of values.
+var checkConstraints = []constraint{
+	{
+		name:  "ck_game_weather_snapshots_kind",
+		table: (GameWeatherSnapshot{}).TableName(),
+		definition: fmt.Sprintf("CHECK (kind IN ('%s', '%s'))",
+			WeatherForecast, WeatherActual),
+	},
+	{
+		name:  "ck_seed_runs_status",
+		table: (SeedRun{}).TableName(),
+		definition: fmt.Sprintf(
+			"CHECK (status IN ('%s', '%s', '%s', '%s'))",
+			RunRunning, RunSucceeded, RunFailed, RunStopped,
+		),
+	},
+	{
+		name:  "ck_validation_errors_mode",
+		table: (ValidationError{}).TableName(),
+		definition: fmt.Sprintf("CHECK (mode IN ('%s', '%s', '%s'))",
+			ValidationFail, ValidationSkip, ValidationLog),
+	},
+}
+
+// constraints returns every constraint installed by InstallConstraints: a
+// foreign key for each audited reference that can have one, then the CHECK
+// constraints.
+func constraints() []constraint {
+	all := make([]constraint, 0, len(references)+len(checkConstraints))
+	for _, ref := range references {
+		if ref.noForeignKey {
+			continue
+		}
+		all = append(all, constraint{
+			name:  fmt.Sprintf("fk_%s_%s", ref.table, ref.column),
+			table: ref.table,
+			definition: fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)",
+				ref.column, ref.parent, ref.parentColumn),
+		})
+	}
+
+	return append(all, checkConstraints...)
+}
+
+// ConstraintResult is the outcome of installing one constraint.
+type ConstraintResult struct {
+	Constraint string `json:"constraint"`
+	Table      string `json:"table"`
+	Status     string `json:"status"`
+	// Error is why the existing rows do not satisfy a failed constraint.
+	Error string `json:"error,omitempty"`
+}
+
+// InstallConstraints adds the foreign keys and CHECK constraints migrations
+// leave out, so that a bulk load is not slowed or ordered by them. Each is
+// validated against the rows already loaded; one the rows do not satisfy is
+// dropped again and reported as failed with the first offending row, and
+// the others are still installed. Constraints already installed are left
+// as they are, as are those of tables that have not been created.
+func (db *Database) InstallConstraints(
+	ctx context.Context,
+) ([]ConstraintResult, error) {
+	if err := db.requirePostgres("constraints"); err != nil {
+		return nil, err
+	}
+
+	migrator := db.WithContext(ctx).Migrator()
+	all := constraints()
+	results := make([]ConstraintResult, 0, len(all))
+	for _, c := range all {
+		if !migrator.HasTable(c.table) {
+			continue
+		}
+
+		result, err := db.installConstraint(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("could not install constraint %s; %w",
+				c.name, err)
+		}
+		if result.Status == ConstraintFailed {
+			slog.Warn("constraint not satisfied by existing rows",
+				"constraint", c.name, "table", c.table, "err", result.Error)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// installConstraint adds a constraint, unless it is already installed, and
+// validates it. Only an error preventing the attempt is returned; rows
+// violating the constraint are reported in the result.
+func (db *Database) installConstraint(
+	ctx context.Context,
+	c constraint,
+) (ConstraintResult, error) {
+	session := db.WithContext(ctx)
+	result := ConstraintResult{Constraint: c.name, Table: c.table}
+
+	var validated []bool
+	err := session.Raw(`
+		SELECT convalidated FROM pg_constraint
+		WHERE conname = ? AND conrelid = to_regclass(?)`, c.name, c.table,
+	).Scan(&validated).Error
+	if err != nil {
+		return result, fmt.Errorf("could not check constraint; %w", err)
+	}
+	if len(validated) > 0 && validated[0] {
+		result.Status = ConstraintExists
+		return result, nil
+	}
+
+	var partitioned bool
+	err = session.Raw(
+		"SELECT relkind = 'p' FROM pg_class WHERE oid = to_regclass(?)",
+		c.table,
+	).Scan(&partitioned).Error
+	if err != nil {
+		return result, fmt.Errorf("could not check partitioning; %w", err)
+	}
+
+	// A constraint added NOT VALID blocks writes only briefly, and validating
+	// it afterwards does not block them. Partitioned tables cannot add one
+	// NOT VALID, so theirs is validated as it is added, and one left
+	// unvalidated by an interrupted install is only validated.
+	switch {
+	case len(validated) > 0:
+		err = session.Exec(fmt.Sprintf(
+			"ALTER TABLE %s VALIDATE CONSTRAINT %s", c.table, c.name,
+		)).Error
+	case partitioned:
+		err = session.Exec(fmt.Sprintf(
+			"ALTER TABLE %s ADD CONSTRAINT %s %s",
+			c.table, c.name, c.definition,
+		)).Error
+	default:
+		err = session.Exec(fmt.Sprintf(
+			"ALTER TABLE %s ADD CONSTRAINT %s %s NOT VALID",
+			c.table, c.name, c.definition,
+		)).Error
+		if err == nil {
+			err = session.Exec(fmt.Sprintf(
+				"ALTER TABLE %s VALIDATE CONSTRAINT %s", c.table, c.name,
+			)).Error
+		}
+	}
+
+	if err == nil {
+		result.Status = ConstraintInstalled
+		slog.Info("installed constraint", "constraint", c.name, "table", c.table)
+		return result, nil
+	}
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) ||
+		pgErr.Code != foreignKeyViolation && pgErr.Code != checkViolation {
+		return result, err
+	}
+
+	// A constraint left unvalidated would still reject new rows, which the
+	// rows already loaded show upstream does not guarantee.
+	err = session.Exec(fmt.Sprintf(
+		"ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s", c.table, c.name,
+	)).Error
+	if err != nil {
+		return result, fmt.Errorf("could not drop unsatisfied constraint; %w",
+			err)
+	}
+
+	result.Status = ConstraintFailed
+	result.Error = pgErr.Message
+	if pgErr.Detail != "" {
+		result.Error += ": " + pgErr.Detail
+	}
+
+	return result, nil
+}
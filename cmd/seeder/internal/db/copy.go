@@ -0,0 +1,371 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// defaultCopyBatchSize is how many rows each COPY of a bulk-copied table
+	// loads, far more than fit in a single parameterized INSERT.
+	defaultCopyBatchSize = 10000
+	// uniqueViolation is the SQLSTATE of a unique constraint violation.
+	uniqueViolation = "23505"
+)
+
+// BulkCopyTables are the append-heavy tables whose multi-million-row loads
+// are worth bulk copying.
+var BulkCopyTables = []string{
+	(Play{}).TableName(),
+	(PlayStat{}).TableName(),
+}
+
+// errNotCopyable is returned by copyStatement for creates it cannot express
+// as a COPY, which are left to the regular insert.
+var errNotCopyable = errors.New("statement cannot be copied")
+
+// BulkCopy makes creates into the given tables load their rows with
+// PostgreSQL COPY instead of multi-row INSERTs, in batches of
+// defaultCopyBatchSize rows.
+//
+// Each batch is first copied straight into the table. If that hits a unique
+// violation, the batch is instead copied into a temporary staging table and
+// moved across with INSERT ... SELECT under the statement's ON CONFLICT
+// clause, so only batches with conflicting keys pay for an upsert. Each
+// batch commits on its own, and auto-increment IDs are left to the database
+// and not read back into the rows.
+//
+// It must be called before SkipUnchanged and DisableWrites, which build on
+// the create callback it installs. On databases other than PostgreSQL it
+// leaves creates to the regular insert.
+func (db *Database) BulkCopy(tables ...string) error {
+	if err := db.requirePostgres("bulk copy"); err != nil {
+		slog.Warn("bulk copy unavailable; using inserts", "err", err.Error())
+		return nil
+	}
+
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return fmt.Errorf("could not get database handle; %w", err)
+	}
+
+	callbacks := db.Callback().Create()
+	create := callbacks.Get("gorm:create")
+	if create == nil {
+		return errors.New("could not find create callback")
+	}
+
+	copied := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		copied[table] = true
+	}
+
+	err = callbacks.Replace("gorm:create", func(tx *gorm.DB) {
+		stmt := tx.Statement
+		if tx.Error != nil || !copied[stmt.Table] {
+			create(tx)
+			return
+		}
+
+		conn, err := sqlDB.Conn(stmt.Context)
+		if err != nil {
+			_ = tx.AddError(fmt.Errorf("could not get connection; %w", err))
+			return
+		}
+		defer conn.Close()
+
+		err = conn.Raw(func(driverConn any) error {
+			pgxConn, ok := driverConn.(*stdlib.Conn)
+			if !ok {
+				return errNotCopyable
+			}
+			rows, err := copyStatement(stmt.Context, pgxConn.Conn(), stmt)
+			tx.RowsAffected = rows
+			return err
+		})
+		switch {
+		case errors.Is(err, errNotCopyable):
+			create(tx)
+		case err != nil:
+			_ = tx.AddError(fmt.Errorf("could not copy rows; %w", err))
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("could not replace create callback; %w", err)
+	}
+
+	db.copyTables = copied
+
+	return nil
+}
+
+// insertSession returns the session inserts into the table run in, and how
+// many rows each batch should carry: defaultCopyBatchSize if the table is
+// bulk copied, otherwise size. Bulk copies skip GORM's transaction, since
+// every COPY batch commits on a connection of its own and an idle
+// transaction would only hold a second connection from the pool.
+func (db *Database) insertSession(
+	ctx context.Context,
+	table string,
+	size int,
+) (*gorm.DB, int) {
+	session := db.WithContext(ctx)
+	if !db.copyTables[table] {
+		return session, size
+	}
+
+	return session.Session(&gorm.Session{SkipDefaultTransaction: true}),
+		defaultCopyBatchSize
+}
+
+// copyStatement loads the rows of a create statement with COPY, in a single
+// transaction, and returns the number of rows written.
+func copyStatement(
+	ctx context.Context,
+	conn *pgx.Conn,
+	stmt *gorm.Statement,
+) (int64, error) {
+	fields, rows, err := copyRows(ctx, stmt)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	onConflict, target, err := conflictClause(stmt, fields)
+	if err != nil {
+		return 0, err
+	}
+
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = field.DBName
+	}
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not begin copy; %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	written, err := copyDirect(ctx, tx, stmt.Table, columns, rows)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		written, err = copyStaged(
+			ctx, tx, stmt.Table, columns, rows, onConflict, target,
+		)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("could not commit copy; %w", err)
+	}
+
+	return written, nil
+}
+
+// copyDirect copies the rows straight into the table under a savepoint, so
+// that a conflict leaves the transaction usable for copyStaged.
+func copyDirect(
+	ctx context.Context,
+	tx pgx.Tx,
+	table string,
+	columns []string,
+	rows [][]any,
+) (int64, error) {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not create savepoint; %w", err)
+	}
+
+	written, err := savepoint.CopyFrom(
+		ctx, pgx.Identifier{table}, columns, pgx.CopyFromRows(rows),
+	)
+	if err != nil {
+		_ = savepoint.Rollback(ctx)
+		return 0, fmt.Errorf("could not copy into %s; %w", table, err)
+	}
+
+	if err = savepoint.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("could not release savepoint; %w", err)
+	}
+
+	return written, nil
+}
+
+// copyStaged copies the rows into a temporary staging table and moves them
+// into the table with the given ON CONFLICT clause, keeping one row per
+// conflict target.
+func copyStaged(
+	ctx context.Context,
+	tx pgx.Tx,
+	table string,
+	columns []string,
+	rows [][]any,
+	onConflict string,
+	target []string,
+) (int64, error) {
+	staging := "copy_" + table
+	_, err := tx.Exec(ctx, fmt.Sprintf(
+		"CREATE TEMP TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP",
+		pgx.Identifier{staging}.Sanitize(), pgx.Identifier{table}.Sanitize(),
+	))
+	if err != nil {
+		return 0, fmt.Errorf("could not create staging table; %w", err)
+	}
+
+	_, err = tx.CopyFrom(
+		ctx, pgx.Identifier{staging}, columns, pgx.CopyFromRows(rows),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("could not copy into staging table; %w", err)
+	}
+
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = pgx.Identifier{column}.Sanitize()
+	}
+	list := strings.Join(quoted, ", ")
+
+	// A batch can carry a conflict target more than once, which ON CONFLICT
+	// DO UPDATE refuses to apply twice in one command, so only the last copy
+	// of each is moved; rows copied later sit later in the staging table.
+	selection := fmt.Sprintf(
+		"SELECT %s FROM %s", list, pgx.Identifier{staging}.Sanitize(),
+	)
+	if len(target) > 0 {
+		on := strings.Join(target, ", ")
+		selection = fmt.Sprintf(
+			"SELECT DISTINCT ON (%s) %s FROM %s ORDER BY %s, ctid DESC",
+			on, list, pgx.Identifier{staging}.Sanitize(), on,
+		)
+	}
+
+	tag, err := tx.Exec(ctx, fmt.Sprintf(
+		"INSERT INTO %s (%s) %s %s",
+		pgx.Identifier{table}.Sanitize(), list, selection, onConflict,
+	))
+	if err != nil {
+		return 0, fmt.Errorf("could not merge into %s; %w", table, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// copyRows returns the columns a create statement writes and the values of
+// every row for them. Read-only columns and auto-increment IDs, which the
+// database fills in, are left out.
+func copyRows(
+	ctx context.Context,
+	stmt *gorm.Statement,
+) ([]*schema.Field, [][]any, error) {
+	if stmt.Schema == nil {
+		return nil, nil, errNotCopyable
+	}
+
+	var fields []*schema.Field
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" || !field.Creatable || field.AutoIncrement {
+			continue
+		}
+		// Serialized columns are only encoded by the regular insert.
+		if field.Serializer != nil {
+			return nil, nil, errNotCopyable
+		}
+		fields = append(fields, field)
+	}
+
+	value := stmt.ReflectValue
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, nil, errNotCopyable
+	}
+
+	rows := make([][]any, 0, value.Len())
+	for i := range value.Len() {
+		row := reflect.Indirect(value.Index(i))
+		values := make([]any, len(fields))
+		for j, field := range fields {
+			values[j], _ = field.ValueOf(ctx, row)
+		}
+		rows = append(rows, values)
+	}
+
+	return fields, rows, nil
+}
+
+// conflictClause renders the statement's ON CONFLICT clause for moving rows
+// out of a staging table, along with the quoted conflict target columns, if
+// it names any. Without one, conflicts are errors as they would be for a
+// plain insert. Updates other than taking the incoming column value cannot
+// be rendered and leave the statement to the regular insert.
+func conflictClause(
+	stmt *gorm.Statement,
+	fields []*schema.Field,
+) (string, []string, error) {
+	c, ok := stmt.Clauses["ON CONFLICT"]
+	if !ok {
+		return "", nil, nil
+	}
+	onConflict, ok := c.Expression.(clause.OnConflict)
+	if !ok || onConflict.Where.Exprs != nil ||
+		onConflict.TargetWhere.Exprs != nil || onConflict.OnConstraint != "" {
+		return "", nil, errNotCopyable
+	}
+
+	target := make([]string, 0, len(onConflict.Columns))
+	for _, column := range onConflict.Columns {
+		target = append(target, pgx.Identifier{column.Name}.Sanitize())
+	}
+	if len(target) == 0 {
+		for _, field := range stmt.Schema.PrimaryFields {
+			target = append(target, pgx.Identifier{field.DBName}.Sanitize())
+		}
+	}
+	on := "ON CONFLICT (" + strings.Join(target, ", ") + ")"
+
+	var updates []string
+	switch {
+	case onConflict.DoNothing:
+		return "ON CONFLICT DO NOTHING", nil, nil
+	case onConflict.UpdateAll:
+		for _, field := range fields {
+			if !field.PrimaryKey {
+				updates = append(updates, field.DBName)
+			}
+		}
+	default:
+		for _, set := range onConflict.DoUpdates {
+			value, ok := set.Value.(clause.Column)
+			if !ok || value.Table != "excluded" || value.Name != set.Column.Name {
+				return "", nil, errNotCopyable
+			}
+			updates = append(updates, set.Column.Name)
+		}
+	}
+	if len(updates) == 0 {
+		return on + " DO NOTHING", target, nil
+	}
+
+	assignments := make([]string, len(updates))
+	for i, column := range updates {
+		quoted := pgx.Identifier{column}.Sanitize()
+		assignments[i] = quoted + " = EXCLUDED." + quoted
+	}
+
+	return on + " DO UPDATE SET " + strings.Join(assignments, ", "),
+		target, nil
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// WeekCount is the number of rows stored for one week of a season.
+type WeekCount struct {
+	SeasonType string `gorm:"column:season_type"`
+	Week       int32  `gorm:"column:week"`
+	Rows       int64  `gorm:"column:row_count"`
+}
+
+// GameCount is the number of rows stored for one game.
+type GameCount struct {
+	GameID int32 `gorm:"column:game_id"`
+	Rows   int64 `gorm:"column:row_count"`
+}
+
+// PollCount is the number of ranks stored for one poll of a week.
+type PollCount struct {
+	SeasonType string `gorm:"column:season_type"`
+	Week       int32  `gorm:"column:week"`
+	Poll       string `gorm:"column:poll"`
+	Rows       int64  `gorm:"column:row_count"`
+}
+
+// CountGamesPerWeek returns the number of stored games in each week of the
+// season that has any.
+func (db *Database) CountGamesPerWeek(
+	ctx context.Context,
+	season int32,
+) ([]WeekCount, error) {
+	var counts []WeekCount
+	err := db.WithContext(ctx).Model(&Game{}).
+		Select("season_type, week, COUNT(*) AS row_count").
+		Where("season = ?", season).
+		Group("season_type, week").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not count games per week; %w", err)
+	}
+
+	return counts, nil
+}
+
+// CountDrivesPerGame returns the number of stored drives of each game of the
+// season that has any.
+func (db *Database) CountDrivesPerGame(
+	ctx context.Context,
+	season int32,
+) ([]GameCount, error) {
+	var counts []GameCount
+	err := db.WithContext(ctx).Model(&Drive{}).
+		Select("game_id, COUNT(*) AS row_count").
+		Where("season = ?", season).
+		Group("game_id").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not count drives per game; %w", err)
+	}
+
+	return counts, nil
+}
+
+// CountRanksPerPoll returns the number of stored ranks of each poll of the
+// season that has any.
+func (db *Database) CountRanksPerPoll(
+	ctx context.Context,
+	season int32,
+) ([]PollCount, error) {
+	var counts []PollCount
+	err := db.WithContext(ctx).Table("poll_ranks").
+		Select("poll_weeks.season_type, poll_weeks.week, polls.poll, "+
+			"COUNT(*) AS row_count").
+		Joins("JOIN polls ON polls.id = poll_ranks.poll_id").
+		Joins("JOIN poll_weeks ON poll_weeks.id = polls.poll_week_id").
+		Where("poll_weeks.season = ?", season).
+		Group("poll_weeks.season_type, poll_weeks.week, polls.poll").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not count ranks per poll; %w", err)
+	}
+
+	return counts, nil
+}
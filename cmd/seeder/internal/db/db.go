@@ -6,16 +6,18 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/clintrovert/cfbd-etl/seeder/internal/utils"
 	"github.com/clintrovert/cfbd-go/cfbd"
 	"gorm.io/datatypes"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
 )
 
 // ErrDsnMissing todo:describe.
@@ -35,15 +37,43 @@ const (
 
 // Config todo:describe
 type Config struct {
+	// Driver is the database the seeder writes to, DriverPostgres or
+	// DriverMySQL. It defaults to DriverPostgres.
+	Driver                   string
 	DSN                      string
 	MaxOpenConnections       int
 	MaxIdleConnections       int
 	MaxConnectionLifetimeMin int
+	// CompressPayloads stores large json payload columns (advanced box
+	// scores, scoreboard blobs) as gzip-compressed bytea instead of jsonb.
+	// It must match the setting used when the schema was first created;
+	// NewDatabase returns ErrCompressionMismatch if it does not.
+	CompressPayloads bool
+	// Units is the unit system measurements are converted to before they
+	// are inserted. It defaults to UnitsImperial, as returned by the API.
+	Units UnitSystem
+	// Schema is the PostgreSQL schema the tables are created in, which lets
+	// several datasets share a database. It defaults to the first schema of
+	// the DSN's search_path if it sets one, or else DefaultSchema; setting
+	// both is an error. MySQL and SQLite keep the tables in the database
+	// itself.
+	Schema string
 }
 
 // Database creates a new database connection.
 type Database struct {
 	*gorm.DB
+	dialect dialect
+	units   UnitSystem
+	// schema is the schema the tables are created in.
+	schema string
+	// copyTables are the tables BulkCopy loads with COPY.
+	copyTables map[string]bool
+	// partitions holds the season partitions known to exist, by name.
+	partitions sync.Map
+	// updates holds the rows tallied for NotifyUpdates, or nil if it is
+	// disabled.
+	updates *pendingUpdates
 }
 
 // NewDatabase todo:describe
@@ -53,17 +83,46 @@ func NewDatabase(conf Config) (*Database, error) {
 		return nil, ErrDsnMissing
 	}
 
-	// Append search_path to DSN if not already present
-	dsn := conf.DSN
-	if !strings.Contains(dsn, "search_path") {
-		separator := "?"
-		if strings.Contains(dsn, "?") {
-			separator = "&"
+	schemaName := conf.Schema
+	if conf.Driver == "" || conf.Driver == DriverPostgres {
+		fromDSN, ok, err := dsnSchema(conf.DSN)
+		if err != nil {
+			return nil, err
+		}
+		if ok && schemaName != "" {
+			return nil, fmt.Errorf("%w: %q", ErrSchemaConflict, schemaName)
 		}
-		dsn = dsn + separator + "search_path=cfbd,public"
+		if ok {
+			schemaName = fromDSN
+		}
+	}
+	if schemaName == "" {
+		schemaName = DefaultSchema
+	}
+	if !schemaPattern.MatchString(schemaName) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSchema, schemaName)
+	}
+
+	dialect, err := newDialect(conf.Driver, schemaName)
+	if err != nil {
+		return nil, err
 	}
+	dialector, err := dialect.open(conf.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	units := conf.Units
+	if units == "" {
+		units = UnitsImperial
+	}
+	if units != UnitsImperial && units != UnitsMetric {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownUnitSystem, units)
+	}
+
+	compressPayloads.Store(conf.CompressPayloads)
 
-	gdb, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+	gdb, err := gorm.Open(dialector, &gorm.Config{
 		Logger: logger.Default.LogMode(
 			logger.Info,
 		),
@@ -74,6 +133,16 @@ func NewDatabase(conf Config) (*Database, error) {
 		return nil, fmt.Errorf("could not open connection; %w", err)
 	}
 
+	if err = dialect.prepare(gdb); err != nil {
+		return nil, err
+	}
+	if err = checkConflictTargets(gdb); err != nil {
+		return nil, err
+	}
+	if err = checkPayloadColumns(gdb, conf.CompressPayloads); err != nil {
+		return nil, err
+	}
+
 	sqlDB, err := gdb.DB()
 	if err != nil {
 		slog.Error("could not init database", "err", err.Error())
@@ -86,72 +155,60 @@ func NewDatabase(conf Config) (*Database, error) {
 		time.Duration(conf.MaxConnectionLifetimeMin) * time.Minute,
 	)
 
-	return &Database{gdb}, nil
+	return &Database{
+		DB:      gdb,
+		dialect: dialect,
+		units:   units,
+		schema:  schemaName,
+	}, nil
 }
 
-// Initialize creates the cfbd schema (if needed) and migrates all tables
-// defined in the models/model.go I generated (package models).
-//
-// NOTE: Adjust the import path for your models package accordingly.
-func (db *Database) Initialize() error {
-	// Ensure schema exists
-	if err := db.Exec(`CREATE SCHEMA IF NOT EXISTS cfbd;`).Error; err != nil {
-		slog.Error("could not create schema", "err", err.Error())
-		return fmt.Errorf("could not create schema; %w", err)
-	}
+// migrationGroup is a set of models migrated together, named for errors
+// and progress logs.
+type migrationGroup struct {
+	name   string
+	models []any
+}
 
-	// ---- MIGRATION ORDER MATTERS (FKs / dependencies) ----
+// migrationGroups lists every table the baseline migration creates on MySQL
+// and SQLite. On PostgreSQL, whose baseline predates some of them, the
+// migrations after it create the rest. The baseline SQL in
+// migrations/baseline is frozen; a model changed since must come with a
+// migration of its own.
+//
+// ---- MIGRATION ORDER MATTERS (FKs / dependencies) ----
+var migrationGroups = []migrationGroup{
 	// 1) Reference/dim tables first
-	if err := db.AutoMigrate(
+	{"reference tables", []any{
 		&Venue{},
 		&Conference{},
 		&Team{},
-	); err != nil {
-		slog.Error("could not auto-migrate reference tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate reference tables; %w", err)
-	}
-
+	}},
 	// 2) Core spine
-	if err := db.AutoMigrate(
+	{"games table", []any{
 		&Game{},
-	); err != nil {
-		slog.Error("could not auto-migrate games table", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate games table; %w", err)
-	}
-
+	}},
 	// 3) Matchups
-	if err := db.AutoMigrate(
+	{"matchup tables", []any{
 		&Matchup{},
 		&MatchupGame{},
-	); err != nil {
-		slog.Error("could not auto-migrate matchup tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate matchup tables; %w", err)
-	}
-
+	}},
 	// 4) Calendar / scoreboard / records
-	if err := db.AutoMigrate(
+	{"cal/score tables", []any{
 		&CalendarWeek{},
 		&Scoreboard{},
 		&TeamRecords{},
-	); err != nil {
-		slog.Error("could not auto-migrate cal/score tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate cal/score tables; %w", err)
-	}
-
+	}},
 	// 5) Plays / drives + lookup tables
-	if err := db.AutoMigrate(
+	{"play/drive tables", []any{
 		&PlayType{},
 		&PlayStatType{},
 		&Drive{},
 		&Play{},
 		&PlayStat{},
-	); err != nil {
-		slog.Error("could not auto-migrate play/drive tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate play/drive tables; %w", err)
-	}
-
+	}},
 	// 6) Game box score stats (nested)
-	if err := db.AutoMigrate(
+	{"game stats tables", []any{
 		&GameTeamStats{},
 		&GameTeamStatsTeam{},
 		&GameTeamStatsTeamStat{},
@@ -161,63 +218,40 @@ func (db *Database) Initialize() error {
 		&GamePlayerStatCategories{},
 		&GamePlayerStatTypes{},
 		&GamePlayerStatPlayer{},
-	); err != nil {
-		slog.Error("could not auto-migrate game stats tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate game stats tables; %w", err)
-	}
-
+	}},
 	// 7) Live game (nested)
-	if err := db.AutoMigrate(
+	{"live game tables", []any{
 		&LiveGame{},
 		&LiveGameTeam{},
 		&LiveGameDrive{},
 		&LiveGamePlay{},
-	); err != nil {
-		slog.Error("could not auto-migrate live game tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate live game tables; %w", err)
-	}
-
+	}},
 	// 8) Media & weather
-	if err := db.AutoMigrate(
+	{"media/weather tables", []any{
 		&GameMedia{},
 		&GameWeather{},
-	); err != nil {
-		slog.Error("could not migrate media/weather tables", "err", err.Error())
-		return fmt.Errorf("could not migrate media/weather tables; %w", err)
-	}
-
+		&GameWeatherSnapshot{},
+	}},
 	// 9) Win probability
-	if err := db.AutoMigrate(
+	{"win prob tables", []any{
 		&PlayWinProbability{},
 		&PregameWinProbability{},
 		&FieldGoalEP{},
-	); err != nil {
-		slog.Error("could not auto-migrate win prob tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate win prob tables; %w", err)
-	}
-
+	}},
 	// 10) PPA / predicted points
-	if err := db.AutoMigrate(
+	{"PPA tables", []any{
 		&PredictedPointsValue{},
 		&TeamSeasonPredictedPointsAdded{},
 		&TeamGamePredictedPointsAdded{},
 		&PlayerGamePredictedPointsAdded{},
 		&PlayerSeasonPredictedPointsAdded{},
-	); err != nil {
-		slog.Error("could not auto-migrate PPA tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate PPA tables; %w", err)
-	}
-
+	}},
 	// 11) Advanced box score payload table (jsonb)
-	if err := db.AutoMigrate(
+	{"adv score tables", []any{
 		&AdvancedBoxScore{},
-	); err != nil {
-		slog.Error("could not auto-migrate adv score tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate adv score tables; %w", err)
-	}
-
+	}},
 	// 12) Players / roster / usage / transfers / search
-	if err := db.AutoMigrate(
+	{"player tables", []any{
 		&RosterPlayer{},
 		&PlayerSearchResult{},
 		&PlayerUsageSplits{},
@@ -226,75 +260,48 @@ func (db *Database) Initialize() error {
 		&PlayerTransfer{},
 		&PlayerStat{},
 		&TeamStat{},
-	); err != nil {
-		slog.Error("could not auto-migrate player tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate player tables; %w", err)
-	}
-
+	}},
 	// 13) Recruiting
-	if err := db.AutoMigrate(
+	{"recruiting tables", []any{
 		&RecruitHometownInfo{},
 		&Recruit{},
 		&TeamRecruitingRanking{},
 		&AggregatedTeamRecruiting{},
-	); err != nil {
-		slog.Error("could not auto-migrate recruiting tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate recruiting tables; %w", err)
-	}
-
+	}},
 	// 14) Ratings
-	if err := db.AutoMigrate(
+	{"ratings tables", []any{
 		&TeamSP{},
 		&ConferenceSP{},
 		&TeamSRS{},
 		&TeamElo{},
 		&TeamFPI{},
-	); err != nil {
-		slog.Error("could not auto-migrate ratings tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate ratings tables; %w", err)
-	}
-
+	}},
 	// 15) Polls / rankings
-	if err := db.AutoMigrate(
+	{"poll tables", []any{
 		&PollWeek{},
 		&Poll{},
 		&PollRank{},
-	); err != nil {
-		slog.Error("could not auto-migrate poll tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate poll tables; %w", err)
-	}
-
+	}},
 	// 16) Betting / lines
-	if err := db.AutoMigrate(
+	{"betting tables", []any{
 		&BettingGame{},
 		&GameLine{},
-	); err != nil {
-		slog.Error("could not auto-migrate betting tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate betting tables; %w", err)
-	}
-
+		&GameLineSnapshot{},
+	}},
 	// 17) Draft
-	if err := db.AutoMigrate(
+	{"draft tables", []any{
 		&DraftTeam{},
 		&DraftPosition{},
 		&DraftPickHometownInfo{},
 		&DraftPick{},
-	); err != nil {
-		slog.Error("could not auto-migrate draft tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate draft tables; %w", err)
-	}
-
+	}},
 	// 18) Coaches
-	if err := db.AutoMigrate(
+	{"coach tables", []any{
 		&Coach{},
 		&CoachSeason{},
-	); err != nil {
-		slog.Error("could not auto-migrate coach tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate coach tables; %w", err)
-	}
-
+	}},
 	// 19) WEPA / metrics
-	if err := db.AutoMigrate(
+	{"metrics tables", []any{
 		&AdjustedTeamMetrics{},
 		&PlayerWeightedEPA{},
 		&KickerPAAR{},
@@ -309,42 +316,147 @@ func (db *Database) Initialize() error {
 		&AdvancedSeasonStat{},
 		&AdvancedGameStatSide{},
 		&AdvancedGameStat{},
-	); err != nil {
-		slog.Error("could not auto-migrate metrics tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate metrics tables; %w", err)
-	}
-
+	}},
 	// 20) Misc
-	if err := db.AutoMigrate(
+	{"misc tables", []any{
 		&UserInfo{},
 		&Int32List{},
-	); err != nil {
-		slog.Error("could not auto-migrate misc tables", "err", err.Error())
-		return fmt.Errorf("could not auto-migrate misc tables; %w", err)
+		&VerificationCursor{},
+		&VerificationDiff{},
+		&SeedFailure{},
+		&ResponseManifest{},
+		&SeedCheckpoint{},
+		&RowHash{},
+		&ColumnUnit{},
+		&SeedRun{},
+		&TableManifest{},
+	}},
+}
+
+// laterGroups lists the seeder's own bookkeeping tables created by the
+// migrations after the baseline on every driver, and postgresGroups those
+// created on PostgreSQL only. derivedGroups lists the tables those
+// migrations create for what the seeder computes from the seeded tables.
+// Tables lists the derived tables after the baseline ones but leaves out the
+// bookkeeping ones; SchemaDrift checks them all.
+var (
+	laterGroups = []migrationGroup{
+		{"validation errors", []any{&ValidationError{}}},
+		{"quarantined rows", []any{&QuarantinedRow{}}},
+		{"history tables", []any{&GameLineHistory{}, &PollRankHistory{}}},
+		{"venue geocodes", []any{&VenueGeocode{}}},
+	}
+	postgresGroups = []migrationGroup{
+		{"deferred indexes", []any{&DeferredIndex{}}},
+	}
+	derivedGroups = []migrationGroup{
+		{"data quality", []any{&DataQuality{}}},
+		{"local ratings", []any{&LocalGameElo{}, &LocalTeamRating{}}},
+		{"local win probability", []any{&LocalPlayWinProbability{}}},
+		{"drive stats", []any{&DriveStats{}}},
+		{"team week stats", []any{&TeamWeekStats{}}},
+		{"player identities", []any{&PlayerIdentity{}, &PlayerCrosswalk{}}},
+		{"game travel", []any{&GameTravel{}}},
+	}
+)
+
+// migratedGroups returns every group of tables the migrations create on the
+// database's driver.
+func (db *Database) migratedGroups() []migrationGroup {
+	groups := slices.Concat(migrationGroups, laterGroups, derivedGroups)
+	if db.Dialector.Name() == DriverPostgres {
+		groups = append(groups, postgresGroups...)
+	}
+
+	return groups
+}
+
+// Tables returns the name of every table in migrationGroups, followed by
+// the derived tables, in migration order.
+func (db *Database) Tables() ([]string, error) {
+	models, err := db.Models()
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]string, 0, len(models))
+	for _, model := range models {
+		tables = append(tables, model.Table)
+	}
+
+	return tables, nil
+}
+
+// Models returns the parsed schema of every table in migrationGroups,
+// followed by the derived tables, in migration order.
+func (db *Database) Models() ([]*schema.Schema, error) {
+	var models []*schema.Schema
+	for _, group := range slices.Concat(migrationGroups, derivedGroups) {
+		for _, model := range group.models {
+			stmt := &gorm.Statement{DB: db.DB}
+			if err := stmt.Parse(model); err != nil {
+				return nil, fmt.Errorf("could not parse %T; %w", model, err)
+			}
+			models = append(models, stmt.Schema)
+		}
+	}
+
+	return models, nil
+}
+
+// Initialize creates the configured schema (if needed) and applies every
+// pending migration.
+func (db *Database) Initialize() error {
+	// Ensure schema exists
+	if err := db.dialect.createSchema(db.DB); err != nil {
+		slog.Error("could not create schema", "err", err.Error())
+		return fmt.Errorf("could not create schema; %w", err)
+	}
+
+	if err := db.MigrateUp(context.Background()); err != nil {
+		slog.Error("could not migrate schema", "err", err.Error())
+		return err
 	}
 
 	return nil
 }
 
-// IsInitialized returns true if the DB appears initialized.
-func (db *Database) IsInitialized() (bool, error) {
-	type existsRow struct {
-		Exists bool
+var (
+	// ErrDropNotConfirmed is returned by DropSchema unless it is confirmed
+	// with the schema's name.
+	ErrDropNotConfirmed = errors.New("schema drop not confirmed")
+	// ErrProtectedSchema is returned by DropSchema for the public schema
+	// and for a schema holding extensions, which are never dropped.
+	ErrProtectedSchema = errors.New("schema may not be dropped")
+)
+
+// DropSchema drops the configured schema with every table, view and row in
+// it, so that Initialize can recreate it from scratch. As there is no undoing it,
+// confirm must be the schema's name. The public schema and schemas holding
+// extensions are refused. On MySQL and SQLite, whose schema is the database
+// itself, every table of the database is dropped instead.
+func (db *Database) DropSchema(ctx context.Context, confirm string) error {
+	if confirm != db.schema {
+		return fmt.Errorf("%w: confirm with %q", ErrDropNotConfirmed, db.schema)
 	}
 
+	if err := db.dialect.dropSchema(db.WithContext(ctx)); err != nil {
+		return fmt.Errorf("could not drop schema; %w", err)
+	}
+
+	slog.Warn("dropped schema", "schema", db.schema)
+	return nil
+}
+
+// IsInitialized returns true if the DB appears initialized.
+func (db *Database) IsInitialized() (bool, error) {
 	// 1) schema exists?
-	var schema existsRow
-	if err := db.Raw(`
-		SELECT EXISTS (
-			SELECT 1
-			FROM information_schema.schemata
-			WHERE schema_name = 'cfbd'
-		) AS exists;
-	`).Scan(&schema).Error; err != nil {
+	exists, err := db.dialect.hasSchema(db.DB)
+	if err != nil {
 		slog.Error("could not check if schema exists", "err", err.Error())
 		return false, fmt.Errorf("could not check if schema exists; %w", err)
 	}
-	if !schema.Exists {
+	if !exists {
 		return false, nil
 	}
 
@@ -383,19 +495,16 @@ func (db *Database) IsInitialized() (bool, error) {
 		"int32_lists",
 	}
 
-	var foundCount int64
-	if err := db.Raw(`
-		SELECT COUNT(*)
-		FROM information_schema.tables
-		WHERE table_schema = 'cfbd'
-		  AND table_name IN ?;
-	`, requiredTables).Scan(&foundCount).Error; err != nil {
+	tables, err := db.Migrator().GetTables()
+	if err != nil {
 		slog.Error("could not check for sentinel tables", "err", err.Error())
 		return false, fmt.Errorf("could not check for sentinel tables; %w", err)
 	}
 
-	if foundCount != int64(len(requiredTables)) {
-		return false, nil
+	for _, table := range requiredTables {
+		if !slices.Contains(tables, table) {
+			return false, nil
+		}
 	}
 
 	return true, nil
@@ -601,7 +710,8 @@ func (db *Database) InsertPlayTypes(
 	return nil
 }
 
-// InsertPlayStatTypes todo:describe.
+// InsertPlayStatTypes inserts the play stat types not stored yet, keyed on
+// their name.
 func (db *Database) InsertPlayStatTypes(
 	ctx context.Context,
 	names []string,
@@ -624,20 +734,17 @@ func (db *Database) InsertPlayStatTypes(
 		return nil
 	}
 
-	// Assign IDs deterministically in this batch (1..N).
-	// If you already have rows in cfbd.play_stat_types, this will conflict.
-	// We assume these stat types will not change with much frequency.
+	// IDs are assigned by the database, so a stored stat type keeps its ID
+	// whatever order the API lists the names in.
 	models := make([]PlayStatType, 0, len(clean))
-	for i, name := range clean {
-		//nolint:gosec // Array index is always within int32 range
-		models = append(models, PlayStatType{
-			ID:   int32(i + 1),
-			Name: name,
-		})
+	for _, name := range clean {
+		models = append(models, PlayStatType{Name: name})
 	}
 
-	if err := db.WithContext(ctx).
-		CreateInBatches(models, 500).Error; err != nil {
+	if err := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoNothing: true,
+	}).CreateInBatches(models, 500).Error; err != nil {
 		slog.Error("could not insert play stat types", "err", err.Error())
 		return fmt.Errorf("could not insert play stat types; %w", err)
 	}
@@ -867,6 +974,137 @@ func (db *Database) InsertTeams(
 	return nil
 }
 
+// InsertUserInfo records a snapshot of the API key's patron level and
+// remaining calls. Stage describes when the snapshot was taken, e.g. "start"
+// or "end" of a run.
+func (db *Database) InsertUserInfo(
+	ctx context.Context,
+	info *cfbd.UserInfo,
+	stage string,
+) error {
+	if info == nil {
+		return nil
+	}
+
+	if err := db.WithContext(ctx).Create(&UserInfo{
+		PatronLevel:    info.PatronLevel,
+		RemainingCalls: info.RemainingCalls,
+		Stage:          stage,
+		CapturedAt:     time.Now().UTC(),
+	}).Error; err != nil {
+		slog.Error("could not insert user info", "err", err.Error())
+		return fmt.Errorf("could not insert user info; %w", err)
+	}
+
+	return nil
+}
+
+// InsertRosterPlayers inserts RosterPlayer rows into cfbd.roster_players.
+// Players are keyed by athlete id, so inserting rosters oldest year first
+// leaves each player on their most recent team.
+func (db *Database) InsertRosterPlayers(
+	ctx context.Context,
+	players []*cfbd.RosterPlayer,
+) error {
+	if len(players) == 0 {
+		return nil
+	}
+
+	// De-dupe by athlete id; a single batch cannot upsert a row twice.
+	byID := make(map[string]RosterPlayer, len(players))
+	for _, p := range players {
+		if p == nil || p.Id == "" {
+			continue
+		}
+
+		byID[p.Id] = RosterPlayer{
+			ID:             p.Id,
+			FirstName:      strings.TrimSpace(p.FirstName),
+			LastName:       strings.TrimSpace(p.LastName),
+			Team:           p.Team,
+			Height:         db.height(p.Height),
+			Weight:         db.weight(p.Weight),
+			Jersey:         p.Jersey,
+			Position:       p.Position,
+			HomeCity:       p.HomeCity,
+			HomeState:      p.HomeState,
+			HomeCountry:    p.HomeCountry,
+			HomeLatitude:   p.HomeLatitude,
+			HomeLongitude:  p.HomeLongitude,
+			HomeCountyFIPS: p.HomeCounty_FIPS,
+			RecruitIDs:     utils.ToStringArray(p.RecruitIds),
+		}
+	}
+
+	models := make([]RosterPlayer, 0, len(byID))
+	for _, m := range byID {
+		models = append(models, m)
+	}
+
+	return db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"first_name",
+			"last_name",
+			"team",
+			"height",
+			"weight",
+			"jersey",
+			"position",
+			"home_city",
+			"home_state",
+			"home_country",
+			"home_latitude",
+			"home_longitude",
+			"home_county_fips",
+			"recruit_ids",
+		}),
+	}).CreateInBatches(models, 500).Error
+}
+
+// RefreshPlayerSearch rebuilds cfbd.player_search_results from the roster
+// players and team colors already in the database, mirroring the shape of
+// the /player/search endpoint so name lookups can be served locally.
+func (db *Database) RefreshPlayerSearch(ctx context.Context) error {
+	if err := db.WithContext(ctx).Exec(`
+		INSERT INTO player_search_results (
+			id, team, name, first_name, last_name, weight, height, jersey,
+			position, hometown, team_color, team_color_secondary
+		)
+		SELECT id, team, name, first_name, last_name, weight, height, jersey,
+			position, hometown, team_color, team_color_secondary
+		FROM (
+			SELECT
+				r.id,
+				r.team,
+				trim(concat(r.first_name, ' ', r.last_name)) AS name,
+				r.first_name,
+				r.last_name,
+				r.weight,
+				r.height,
+				r.jersey,
+				r.position,
+				concat_ws(', ', nullif(r.home_city, ''), nullif(r.home_state, ''))
+					AS hometown,
+				coalesce(t.color, '') AS team_color,
+				coalesce(t.alternate_color, '') AS team_color_secondary,
+				row_number() OVER (PARTITION BY r.id ORDER BY t.id) AS n
+			FROM roster_players r
+			LEFT JOIN teams t ON t.school = r.team
+		) p
+		WHERE n = 1
+		` + db.dialect.upsert("id", []string{
+		"team", "name", "first_name", "last_name", "weight", "height",
+		"jersey", "position", "hometown", "team_color",
+		"team_color_secondary",
+	})).Error; err != nil {
+		slog.Error("could not refresh player search", "err", err.Error())
+		return fmt.Errorf("could not refresh player search; %w", err)
+	}
+
+	return nil
+}
+
 // InsertCalendarWeeks todo:describe
 func (db *Database) InsertCalendarWeeks(
 	ctx context.Context,
@@ -950,6 +1188,130 @@ func (db *Database) InsertCalendarWeeks(
 	return nil
 }
 
+// newGameModel converts an API game into its row model.
+func newGameModel(g *cfbd.Game) Game {
+	var startDate *time.Time
+	if g.GetStartDate() != nil {
+		t := g.GetStartDate().AsTime()
+		startDate = &t
+	}
+
+	// Optional scalars in proto3 => presence via exported pointer fields
+	var attendance *int32
+	if g.Attendance != nil {
+		x := *g.Attendance
+		attendance = &x
+	}
+
+	var venueID *int32
+	if g.VenueId != nil {
+		x := *g.VenueId
+		venueID = &x
+	}
+
+	var homeID *int32
+	if g.HomeId != nil {
+		x := *g.HomeId
+		homeID = &x
+	}
+	var homePoints *int32
+	if g.HomePoints != nil {
+		x := *g.HomePoints
+		homePoints = &x
+	}
+
+	var awayID *int32
+	if g.AwayId != nil {
+		x := *g.AwayId
+		awayID = &x
+	}
+	var awayPoints *int32
+	if g.AwayPoints != nil {
+		x := *g.AwayPoints
+		awayPoints = &x
+	}
+
+	var homePostWinProb *float64
+	if g.HomePostgameWinProbability != nil {
+		x := *g.HomePostgameWinProbability
+		homePostWinProb = &x
+	}
+	var awayPostWinProb *float64
+	if g.AwayPostgameWinProbability != nil {
+		x := *g.AwayPostgameWinProbability
+		awayPostWinProb = &x
+	}
+
+	var homePregameElo *int32
+	if g.HomePregameElo != nil {
+		x := *g.HomePregameElo
+		homePregameElo = &x
+	}
+	var homePostgameElo *int32
+	if g.HomePostgameElo != nil {
+		x := *g.HomePostgameElo
+		homePostgameElo = &x
+	}
+	var awayPregameElo *int32
+	if g.AwayPregameElo != nil {
+		x := *g.AwayPregameElo
+		awayPregameElo = &x
+	}
+	var awayPostgameElo *int32
+	if g.AwayPostgameElo != nil {
+		x := *g.AwayPostgameElo
+		awayPostgameElo = &x
+	}
+
+	var excitementIndex *float64
+	if g.ExcitementIndex != nil {
+		x := *g.ExcitementIndex
+		excitementIndex = &x
+	}
+
+	return Game{
+		ID:                 g.GetId(),
+		Season:             g.GetSeason(),
+		Week:               g.GetWeek(),
+		SeasonType:         strings.TrimSpace(g.GetSeasonType()),
+		StartDate:          startDate,
+		StartTimeTBD:       g.GetStartTime_TBD(),
+		Completed:          g.GetCompleted(),
+		NeutralSite:        g.GetNeutralSite(),
+		ConferenceGame:     g.GetConferenceGame(),
+		Attendance:         attendance,
+		VenueID:            venueID,
+		Venue:              strings.TrimSpace(g.GetVenue()),
+		HomeID:             homeID,
+		HomeTeam:           strings.TrimSpace(g.GetHomeTeam()),
+		HomeConference:     strings.TrimSpace(g.GetHomeConference()),
+		HomeClassification: strings.TrimSpace(g.GetHomeClassification()),
+		HomePoints:         homePoints,
+		HomeLineScores: utils.Int32SliceToInt64Array(
+			g.GetHomeLineScores(),
+		),
+		HomePostWinProbability: homePostWinProb,
+		HomePregameElo:         homePregameElo,
+		HomePostgameElo:        homePostgameElo,
+		AwayID:                 awayID,
+		AwayTeam:               strings.TrimSpace(g.GetAwayTeam()),
+		AwayConference:         strings.TrimSpace(g.GetAwayConference()),
+		AwayClassification: strings.TrimSpace(
+			g.GetAwayClassification(),
+		),
+		AwayPoints: awayPoints,
+		AwayLineScores: utils.Int32SliceToInt64Array(
+			g.GetAwayLineScores(),
+		),
+		AwayPostWinProbability: awayPostWinProb,
+		AwayPregameElo:         awayPregameElo,
+		AwayPostgameElo:        awayPostgameElo,
+		ExcitementIndex:        excitementIndex,
+		Highlights:             strings.TrimSpace(g.GetHighlights()),
+		Notes:                  strings.TrimSpace(g.GetNotes()),
+	}
+}
+
 func (db *Database) InsertGames(
 	ctx context.Context,
 	games []*cfbd.Game,
@@ -969,126 +1331,7 @@ func (db *Database) InsertGames(
 			continue
 		}
 
-		var startDate *time.Time
-		if g.GetStartDate() != nil {
-			t := g.GetStartDate().AsTime()
-			startDate = &t
-		}
-
-		// Optional scalars in proto3 => presence via exported pointer fields
-		var attendance *int32
-		if g.Attendance != nil {
-			x := *g.Attendance
-			attendance = &x
-		}
-
-		var venueID *int32
-		if g.VenueId != nil {
-			x := *g.VenueId
-			venueID = &x
-		}
-
-		var homeID *int32
-		if g.HomeId != nil {
-			x := *g.HomeId
-			homeID = &x
-		}
-		var homePoints *int32
-		if g.HomePoints != nil {
-			x := *g.HomePoints
-			homePoints = &x
-		}
-
-		var awayID *int32
-		if g.AwayId != nil {
-			x := *g.AwayId
-			awayID = &x
-		}
-		var awayPoints *int32
-		if g.AwayPoints != nil {
-			x := *g.AwayPoints
-			awayPoints = &x
-		}
-
-		var homePostWinProb *float64
-		if g.HomePostgameWinProbability != nil {
-			x := *g.HomePostgameWinProbability
-			homePostWinProb = &x
-		}
-		var awayPostWinProb *float64
-		if g.AwayPostgameWinProbability != nil {
-			x := *g.AwayPostgameWinProbability
-			awayPostWinProb = &x
-		}
-
-		var homePregameElo *int32
-		if g.HomePregameElo != nil {
-			x := *g.HomePregameElo
-			homePregameElo = &x
-		}
-		var homePostgameElo *int32
-		if g.HomePostgameElo != nil {
-			x := *g.HomePostgameElo
-			homePostgameElo = &x
-		}
-		var awayPregameElo *int32
-		if g.AwayPregameElo != nil {
-			x := *g.AwayPregameElo
-			awayPregameElo = &x
-		}
-		var awayPostgameElo *int32
-		if g.AwayPostgameElo != nil {
-			x := *g.AwayPostgameElo
-			awayPostgameElo = &x
-		}
-
-		var excitementIndex *float64
-		if g.ExcitementIndex != nil {
-			x := *g.ExcitementIndex
-			excitementIndex = &x
-		}
-
-		models = append(models, Game{
-			ID:                 id,
-			Season:             g.GetSeason(),
-			Week:               g.GetWeek(),
-			SeasonType:         strings.TrimSpace(g.GetSeasonType()),
-			StartDate:          startDate,
-			StartTimeTBD:       g.GetStartTime_TBD(),
-			Completed:          g.GetCompleted(),
-			NeutralSite:        g.GetNeutralSite(),
-			ConferenceGame:     g.GetConferenceGame(),
-			Attendance:         attendance,
-			VenueID:            venueID,
-			Venue:              strings.TrimSpace(g.GetVenue()),
-			HomeID:             homeID,
-			HomeTeam:           strings.TrimSpace(g.GetHomeTeam()),
-			HomeConference:     strings.TrimSpace(g.GetHomeConference()),
-			HomeClassification: strings.TrimSpace(g.GetHomeClassification()),
-			HomePoints:         homePoints,
-			HomeLineScores: utils.Int32SliceToInt64Array(
-				g.GetHomeLineScores(),
-			),
-			HomePostWinProbability: homePostWinProb,
-			HomePregameElo:         homePregameElo,
-			HomePostgameElo:        homePostgameElo,
-			AwayID:                 awayID,
-			AwayTeam:               strings.TrimSpace(g.GetAwayTeam()),
-			AwayConference:         strings.TrimSpace(g.GetAwayConference()),
-			AwayClassification: strings.TrimSpace(
-				g.GetAwayClassification(),
-			),
-			AwayPoints: awayPoints,
-			AwayLineScores: utils.Int32SliceToInt64Array(
-				g.GetAwayLineScores(),
-			),
-			AwayPostWinProbability: awayPostWinProb,
-			AwayPregameElo:         awayPregameElo,
-			AwayPostgameElo:        awayPostgameElo,
-			ExcitementIndex:        excitementIndex,
-			Highlights:             strings.TrimSpace(g.GetHighlights()),
-			Notes:                  strings.TrimSpace(g.GetNotes()),
-		})
+		models = append(models, newGameModel(g))
 	}
 
 	if len(models) == 0 {
@@ -1141,8 +1384,11 @@ func (db *Database) InsertGames(
 	return nil
 }
 
+// InsertPlays upserts the plays of a season. Plays carry no season of their
+// own, so the caller gives the season they were requested for.
 func (db *Database) InsertPlays(
 	ctx context.Context,
+	season int32,
 	plays []*cfbd.Play,
 ) error {
 	if len(plays) == 0 {
@@ -1208,6 +1454,7 @@ func (db *Database) InsertPlays(
 
 		models = append(models, Play{
 			ID:                id,
+			Season:            season,
 			DriveID:           strings.TrimSpace(p.GetDriveId()),
 			GameID:            p.GetGameId(),
 			DriveNumber:       driveNumber,
@@ -1242,9 +1489,17 @@ func (db *Database) InsertPlays(
 		return nil
 	}
 
-	if err := db.WithContext(ctx).
+	err := db.ensurePartitions(
+		ctx, (Play{}).TableName(), []int64{int64(season)},
+	)
+	if err != nil {
+		return err
+	}
+
+	session, batchSize := db.insertSession(ctx, (Play{}).TableName(), 500)
+	if err := session.
 		Clauses(clause.OnConflict{
-			Columns: []clause.Column{{Name: "id"}},
+			Columns: []clause.Column{{Name: "id"}, {Name: "season"}},
 			DoUpdates: clause.AssignmentColumns([]string{
 				"drive_id",
 				"game_id",
@@ -1275,7 +1530,7 @@ func (db *Database) InsertPlays(
 				"wallclock",
 			}),
 		}).
-		CreateInBatches(models, 500).Error; err != nil {
+		CreateInBatches(models, batchSize).Error; err != nil {
 		slog.Error("could not upsert plays", "err", err.Error())
 		return fmt.Errorf("could not upsert plays; %w", err)
 	}
@@ -1283,8 +1538,11 @@ func (db *Database) InsertPlays(
 	return nil
 }
 
+// InsertDrives upserts the drives of a season. Drives carry no season of
+// their own, so the caller gives the season they were requested for.
 func (db *Database) InsertDrives(
 	ctx context.Context,
+	season int32,
 	drives []*cfbd.Drive,
 ) error {
 	if len(drives) == 0 {
@@ -1358,6 +1616,7 @@ func (db *Database) InsertDrives(
 
 		models = append(models, Drive{
 			ID:                id,
+			Season:            season,
 			GameID:            d.GetGameId(),
 			Offense:           strings.TrimSpace(d.GetOffense()),
 			OffenseConference: strings.TrimSpace(d.GetOffenseConference()),
@@ -1392,9 +1651,16 @@ func (db *Database) InsertDrives(
 		return nil
 	}
 
+	err := db.ensurePartitions(
+		ctx, (Drive{}).TableName(), []int64{int64(season)},
+	)
+	if err != nil {
+		return err
+	}
+
 	if err := db.WithContext(ctx).
 		Clauses(clause.OnConflict{
-			Columns: []clause.Column{{Name: "id"}},
+			Columns: []clause.Column{{Name: "id"}, {Name: "season"}},
 			DoUpdates: clause.AssignmentColumns([]string{
 				"game_id",
 				"offense",
@@ -1441,6 +1707,14 @@ func (db *Database) InsertPlayStats(
 		return nil
 	}
 
+	// A play's stats are keyed by athlete and stat type; the last one given
+	// wins, since an upsert cannot write the same key twice.
+	type playStatKey struct {
+		play, athlete, statType string
+		season                  float64
+	}
+	seen := make(map[playStatKey]int, len(playStats))
+
 	models := make([]PlayStat, 0, len(playStats))
 	for _, ps := range playStats {
 		if ps == nil {
@@ -1464,7 +1738,7 @@ func (db *Database) InsertPlayStats(
 			}
 		}
 
-		models = append(models, PlayStat{
+		model := PlayStat{
 			ID:            0, // Auto-generated by database
 			GameID:        ps.GetGameId(),
 			Season:        ps.GetSeason(),
@@ -1486,18 +1760,65 @@ func (db *Database) InsertPlayStats(
 			AthleteName:   strings.TrimSpace(ps.GetAthleteName()),
 			StatType:      strings.TrimSpace(ps.GetStatType()),
 			Stat:          ps.GetStat(),
-		})
+		}
+
+		key := playStatKey{
+			model.PlayID, model.AthleteID, model.StatType, model.Season,
+		}
+		if i, ok := seen[key]; ok {
+			models[i] = model
+			continue
+		}
+		seen[key] = len(models)
+		models = append(models, model)
 	}
 
 	if len(models) == 0 {
 		return nil
 	}
 
-	// Since ID is auto-generated and there's no unique constraint in the schema,
-	// we use DoNothing to avoid errors on potential duplicates
-	if err := db.WithContext(ctx).
-		Clauses(clause.OnConflict{DoNothing: true}).
-		CreateInBatches(models, 500).Error; err != nil {
+	seasons := make([]int64, 0, len(models))
+	for _, m := range models {
+		seasons = append(seasons, int64(m.Season))
+	}
+	err := db.ensurePartitions(ctx, (PlayStat{}).TableName(), seasons)
+	if err != nil {
+		return err
+	}
+
+	// ID is auto-generated, so re-seeded stats are matched on their natural
+	// key instead.
+	session, batchSize := db.insertSession(
+		ctx, (PlayStat{}).TableName(), 500,
+	)
+	if err := session.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "play_id"},
+				{Name: "athlete_id"},
+				{Name: "stat_type"},
+				{Name: "season"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"game_id",
+				"week",
+				"team",
+				"conference",
+				"opponent",
+				"team_score",
+				"opponent_score",
+				"drive_id",
+				"period",
+				"clock_minutes",
+				"clock_seconds",
+				"yards_to_goal",
+				"down",
+				"distance",
+				"athlete_name",
+				"stat",
+			}),
+		}).
+		CreateInBatches(models, batchSize).Error; err != nil {
 		slog.Error("could not insert play stats", "err", err.Error())
 		return fmt.Errorf("could not insert play stats; %w", err)
 	}
@@ -1539,21 +1860,102 @@ func (db *Database) InsertGameWeather(
 			AwayConference:       w.AwayConference,
 			VenueID:              venueID,
 			Venue:                w.Venue,
-			Temperature:          w.Temperature,
-			DewPoint:             w.DewPoint,
+			Temperature:          db.temperature(w.Temperature),
+			DewPoint:             db.temperature(w.DewPoint),
+			Humidity:             w.Humidity,
+			Precipitation:        db.precipitation(w.Precipitation),
+			Snowfall:             db.precipitation(w.Snowfall),
+			WindDirection:        w.WindDirection,
+			WindSpeed:            db.windSpeed(w.WindSpeed),
+			Pressure:             w.Pressure,
+			WeatherConditionCode: w.WeatherConditionCode,
+			WeatherCondition:     w.WeatherCondition,
+			Source:               WeatherSourceCFBD,
+		})
+	}
+
+	return db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"season",
+			"week",
+			"season_type",
+			"start_time",
+			"game_indoors",
+			"home_team",
+			"home_conference",
+			"away_team",
+			"away_conference",
+			"venue_id",
+			"venue",
+			"temperature",
+			"dew_point",
+			"humidity",
+			"precipitation",
+			"snowfall",
+			"wind_direction",
+			"wind_speed",
+			"pressure",
+			"weather_condition_code",
+			"weather_condition",
+			"source",
+		}),
+	}).CreateInBatches(models, 100).Error
+}
+
+// InsertGameWeatherSnapshots upserts a weather snapshot of the given kind
+// for each game. Re-capturing a kind replaces the previous snapshot, so the
+// stored forecast is always the last one taken before kickoff.
+func (db *Database) InsertGameWeatherSnapshots(
+	ctx context.Context,
+	weather []*cfbd.GameWeather,
+	kind string,
+	capturedAt time.Time,
+) error {
+	models := make([]GameWeatherSnapshot, 0, len(weather))
+	for _, w := range weather {
+		if w == nil {
+			continue
+		}
+		models = append(models, GameWeatherSnapshot{
+			GameID:               w.Id,
+			Kind:                 kind,
+			CapturedAt:           capturedAt,
+			Temperature:          db.temperature(w.Temperature),
+			DewPoint:             db.temperature(w.DewPoint),
 			Humidity:             w.Humidity,
-			Precipitation:        w.Precipitation,
-			Snowfall:             w.Snowfall,
+			Precipitation:        db.precipitation(w.Precipitation),
+			Snowfall:             db.precipitation(w.Snowfall),
 			WindDirection:        w.WindDirection,
-			WindSpeed:            w.WindSpeed,
+			WindSpeed:            db.windSpeed(w.WindSpeed),
 			Pressure:             w.Pressure,
 			WeatherConditionCode: w.WeatherConditionCode,
 			WeatherCondition:     w.WeatherCondition,
 		})
 	}
 
+	if len(models) == 0 {
+		return nil
+	}
+
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "game_id"},
+			{Name: "kind"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"captured_at",
+			"temperature",
+			"dew_point",
+			"humidity",
+			"precipitation",
+			"snowfall",
+			"wind_direction",
+			"wind_speed",
+			"pressure",
+			"weather_condition_code",
+			"weather_condition",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -1595,7 +1997,20 @@ func (db *Database) InsertGameMedia(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"season",
+			"week",
+			"season_type",
+			"start_time",
+			"is_start_time_tbd",
+			"home_team",
+			"home_conference",
+			"away_team",
+			"away_conference",
+			"media_type",
+			"outlet",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -1657,17 +2072,249 @@ func (db *Database) InsertBettingLines(
 		})
 	}
 
-	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
-	}).CreateInBatches(models, 100).Error
-}
+	// The lines are upserted on their own: saved as associations, lines
+	// already stored would be left as they were.
+	allLines := make([]GameLine, 0, len(models))
+	for i := range models {
+		allLines = append(allLines, models[i].Lines...)
+	}
 
-// InsertTeamRecords inserts team records.
-func (db *Database) InsertTeamRecords(
-	ctx context.Context,
-	records []*cfbd.TeamRecords,
-) error {
-	if len(records) == 0 {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit("Lines").Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"season",
+				"season_type",
+				"week",
+				"start_date",
+				"home_team_id",
+				"home_team",
+				"home_conference",
+				"home_classification",
+				"home_score",
+				"away_team_id",
+				"away_team",
+				"away_conference",
+				"away_classification",
+				"away_score",
+			}),
+		}).CreateInBatches(models, 100).Error
+		if err != nil || len(allLines) == 0 {
+			return err
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "game_id"}, {Name: "provider"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"spread",
+				"formatted_spread",
+				"spread_open",
+				"over_under",
+				"over_under_open",
+				"home_moneyline",
+				"away_moneyline",
+			}),
+		}).CreateInBatches(allLines, 500).Error
+	})
+}
+
+// GameWeek identifies a season week containing at least one game of
+// interest, so data for those games can be fetched one request per week.
+type GameWeek struct {
+	Season     int32
+	Week       int32
+	SeasonType string
+}
+
+// GetGamesKickingOffWithin returns the IDs of games starting within the
+// window from now, along with the distinct weeks those games belong to.
+func (db *Database) GetGamesKickingOffWithin(
+	ctx context.Context,
+	window time.Duration,
+) ([]int32, []GameWeek, error) {
+	now := time.Now().UTC()
+
+	var games []Game
+	if err := db.WithContext(ctx).
+		Select("id", "season", "week", "season_type").
+		Where("start_date > ? AND start_date <= ?", now, now.Add(window)).
+		Find(&games).Error; err != nil {
+		return nil, nil, fmt.Errorf("could not get upcoming games; %w", err)
+	}
+
+	ids, weeks := groupGameWeeks(games)
+	return ids, weeks, nil
+}
+
+// GetGamesMissingActualWeather returns the IDs of completed games that have
+// a forecast weather snapshot but no actual one yet, along with the distinct
+// weeks those games belong to.
+func (db *Database) GetGamesMissingActualWeather(
+	ctx context.Context,
+) ([]int32, []GameWeek, error) {
+	var games []Game
+	if err := db.WithContext(ctx).
+		Select("id", "season", "week", "season_type").
+		Where("completed").
+		Where(`EXISTS (
+			SELECT 1 FROM game_weather_snapshots f
+			WHERE f.game_id = games.id AND f.kind = ?
+		)`, WeatherForecast).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM game_weather_snapshots a
+			WHERE a.game_id = games.id AND a.kind = ?
+		)`, WeatherActual).
+		Find(&games).Error; err != nil {
+		return nil, nil, fmt.Errorf("could not get completed games; %w", err)
+	}
+
+	ids, weeks := groupGameWeeks(games)
+	return ids, weeks, nil
+}
+
+// groupGameWeeks returns the IDs of the games and the distinct weeks they
+// belong to, in first-seen order.
+func groupGameWeeks(games []Game) ([]int32, []GameWeek) {
+	ids := make([]int32, 0, len(games))
+	seen := make(map[GameWeek]struct{})
+	weeks := make([]GameWeek, 0)
+	for _, g := range games {
+		ids = append(ids, g.ID)
+
+		week := GameWeek{
+			Season:     g.Season,
+			Week:       g.Week,
+			SeasonType: g.SeasonType,
+		}
+		if _, ok := seen[week]; !ok {
+			seen[week] = struct{}{}
+			weeks = append(weeks, week)
+		}
+	}
+
+	return ids, weeks
+}
+
+// InsertGameLineSnapshots appends a snapshot of every provider's line for
+// the given games, all stamped with the same capture time. A line whose
+// values match the provider's latest snapshot for the game is skipped, so
+// that polling often records movement rather than repeats.
+func (db *Database) InsertGameLineSnapshots(
+	ctx context.Context,
+	games []*cfbd.BettingGame,
+	capturedAt time.Time,
+) error {
+	models := make([]GameLineSnapshot, 0, len(games))
+	ids := make([]int32, 0, len(games))
+	for _, g := range games {
+		if g == nil {
+			continue
+		}
+		ids = append(ids, g.Id)
+		for _, gl := range g.Lines {
+			if gl == nil {
+				continue
+			}
+			models = append(models, GameLineSnapshot{
+				GameID:        g.Id,
+				Provider:      gl.Provider,
+				CapturedAt:    capturedAt,
+				Spread:        gl.Spread,
+				OverUnder:     gl.OverUnder,
+				HomeMoneyline: gl.HomeMoneyline,
+				AwayMoneyline: gl.AwayMoneyline,
+			})
+		}
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	var latest []GameLineSnapshot
+	if err := db.WithContext(ctx).
+		Where("game_id IN ?", ids).
+		Where(`captured_at = (
+			SELECT MAX(s.captured_at) FROM game_line_snapshots s
+			WHERE s.game_id = game_line_snapshots.game_id
+				AND s.provider = game_line_snapshots.provider
+		)`).
+		Find(&latest).Error; err != nil {
+		return fmt.Errorf("could not get latest line snapshots; %w", err)
+	}
+	type lineKey struct {
+		gameID   int32
+		provider string
+	}
+	previous := make(map[lineKey]GameLineSnapshot, len(latest))
+	for _, snap := range latest {
+		previous[lineKey{snap.GameID, snap.Provider}] = snap
+	}
+	models = slices.DeleteFunc(models, func(snap GameLineSnapshot) bool {
+		last, ok := previous[lineKey{snap.GameID, snap.Provider}]
+		return ok && sameSnapshot(last, snap)
+	})
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	return db.WithContext(ctx).CreateInBatches(models, 500).Error
+}
+
+// sameSnapshot reports whether two snapshots of a line have the same
+// values.
+func sameSnapshot(a, b GameLineSnapshot) bool {
+	return formatPtr(a.Spread) == formatPtr(b.Spread) &&
+		formatPtr(a.OverUnder) == formatPtr(b.OverUnder) &&
+		formatPtr(a.HomeMoneyline) == formatPtr(b.HomeMoneyline) &&
+		formatPtr(a.AwayMoneyline) == formatPtr(b.AwayMoneyline)
+}
+
+// MarkClosingLines flags the last snapshot captured before kickoff for each
+// game and provider as the closing line, for games that have kicked off and
+// have no closing line yet.
+func (db *Database) MarkClosingLines(ctx context.Context) (int64, error) {
+	// The closing snapshots are selected through a derived table, which
+	// MySQL requires to read from the table being updated.
+	res := db.WithContext(ctx).Exec(`
+		UPDATE game_line_snapshots
+		SET is_closing = true
+		WHERE id IN (
+			SELECT id FROM (
+				SELECT ls.id, row_number() OVER (
+					PARTITION BY ls.game_id, ls.provider
+					ORDER BY ls.captured_at DESC
+				) AS n
+				FROM game_line_snapshots ls
+				JOIN games g ON g.id = ls.game_id
+				WHERE g.start_date <= CURRENT_TIMESTAMP
+				  AND ls.captured_at < g.start_date
+				  AND NOT EXISTS (
+					SELECT 1
+					FROM game_line_snapshots c
+					WHERE c.game_id = ls.game_id
+					  AND c.provider = ls.provider
+					  AND c.is_closing
+				  )
+			) closing
+			WHERE n = 1
+		);
+	`)
+	if res.Error != nil {
+		slog.Error("could not mark closing lines", "err", res.Error.Error())
+		return 0, fmt.Errorf("could not mark closing lines; %w", res.Error)
+	}
+
+	return res.RowsAffected, nil
+}
+
+// InsertTeamRecords inserts team records.
+func (db *Database) InsertTeamRecords(
+	ctx context.Context,
+	records []*cfbd.TeamRecords,
+) error {
+	if len(records) == 0 {
 		return nil
 	}
 
@@ -1738,7 +2385,45 @@ func (db *Database) InsertTeamRecords(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "year"},
+			{Name: "team"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"team_id",
+			"classification",
+			"conference",
+			"division",
+			"expected_wins",
+			"total_games",
+			"total_wins",
+			"total_losses",
+			"total_ties",
+			"conference_games_games",
+			"conference_games_wins",
+			"conference_games_losses",
+			"conference_games_ties",
+			"home_games_games",
+			"home_games_wins",
+			"home_games_losses",
+			"home_games_ties",
+			"away_games_games",
+			"away_games_wins",
+			"away_games_losses",
+			"away_games_ties",
+			"neutral_site_games_games",
+			"neutral_site_games_wins",
+			"neutral_site_games_losses",
+			"neutral_site_games_ties",
+			"regular_season_games",
+			"regular_season_wins",
+			"regular_season_losses",
+			"regular_season_ties",
+			"postseason_games",
+			"postseason_wins",
+			"postseason_losses",
+			"postseason_ties",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -1764,7 +2449,13 @@ func (db *Database) InsertTeamTalent(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "year"},
+			{Name: "team"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"talent",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -1796,7 +2487,19 @@ func (db *Database) InsertTeamATS(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "year"},
+			{Name: "team_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"team",
+			"conference",
+			"games",
+			"ats_wins",
+			"ats_losses",
+			"ats_pushes",
+			"avg_cover_margin",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -1830,7 +2533,14 @@ func (db *Database) InsertTeamSP(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "year"},
+			{Name: "team"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"conference",
+			"payload",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -1863,7 +2573,13 @@ func (db *Database) InsertConferenceSP(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "year"},
+			{Name: "conference"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"payload",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -1892,7 +2608,16 @@ func (db *Database) InsertTeamSRS(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "year"},
+			{Name: "team"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"conference",
+			"division",
+			"rating",
+			"ranking",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -1919,7 +2644,14 @@ func (db *Database) InsertTeamElo(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "year"},
+			{Name: "team"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"conference",
+			"elo",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -1953,7 +2685,14 @@ func (db *Database) InsertTeamFPI(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "year"},
+			{Name: "team"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"conference",
+			"payload",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -2048,7 +2787,36 @@ func (db *Database) InsertAdjustedTeamMetrics(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "year"},
+			{Name: "team_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"team",
+			"conference",
+			"epa_rushing",
+			"epa_passing",
+			"epa_total",
+			"epa_allowed_rushing",
+			"epa_allowed_passing",
+			"epa_allowed_total",
+			"success_rate_passing_downs",
+			"success_rate_standard_downs",
+			"success_rate_total",
+			"success_rate_allowed_passing_downs",
+			"success_rate_allowed_standard_downs",
+			"success_rate_allowed_total",
+			"rushing_highlight_yards",
+			"rushing_open_field_yards",
+			"rushing_second_level_yards",
+			"rushing_line_yards",
+			"rushing_allowed_highlight_yards",
+			"rushing_allowed_open_field_yards",
+			"rushing_allowed_second_level_yards",
+			"rushing_allowed_line_yards",
+			"explosiveness",
+			"explosiveness_allowed",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -2079,7 +2847,18 @@ func (db *Database) InsertPlayerWeightedEPA(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "year"},
+			{Name: "athlete_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"athlete_name",
+			"position",
+			"team",
+			"conference",
+			"wepa",
+			"plays",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -2109,7 +2888,17 @@ func (db *Database) InsertKickerPAAR(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "year"},
+			{Name: "athlete_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"athlete_name",
+			"team",
+			"conference",
+			"paar",
+			"attempts",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -2147,7 +2936,25 @@ func (db *Database) InsertReturningProduction(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "season"},
+			{Name: "team"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"conference",
+			"total_ppa",
+			"total_passing_ppa",
+			"total_receiving_ppa",
+			"total_rushing_ppa",
+			"percent_ppa",
+			"percent_passing_ppa",
+			"percent_receiving_ppa",
+			"percent_rushing_ppa",
+			"usage",
+			"passing_usage",
+			"receiving_usage",
+			"rushing_usage",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -2187,7 +2994,20 @@ func (db *Database) InsertPlayerTransfers(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "season"},
+			{Name: "first_name"},
+			{Name: "last_name"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"position",
+			"origin",
+			"destination",
+			"transfer_date",
+			"rating",
+			"stars",
+			"eligibility",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -2219,7 +3039,18 @@ func (db *Database) InsertPlayerStats(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"season",
+			"player_id",
+			"player",
+			"position",
+			"team",
+			"conference",
+			"category",
+			"stat_type",
+			"stat",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -2254,7 +3085,14 @@ func (db *Database) InsertTeamStats(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"season",
+			"team",
+			"conference",
+			"stat_name",
+			"stat_value",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -2307,10 +3145,109 @@ func (db *Database) InsertRankings(
 		})
 	}
 
-	// Reduced batch size for complex associations
-	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
-	}).CreateInBatches(models, DefaultBatchSize).Error
+	// Each poll week is written with its polls and ranks in one transaction.
+	return writeUnits(ctx, db.DB, models, upsertPollWeek)
+}
+
+// upsertPollWeek upserts a poll week, its polls and their ranks against
+// their natural keys, since their IDs are auto-generated, and removes the
+// ranks of schools that have dropped out of a poll since it was last
+// written.
+func upsertPollWeek(tx *gorm.DB, unit *PollWeek) error {
+	// The unit is left as given, in case the transaction is retried.
+	week := *unit
+	week.Polls = nil
+
+	// The key columns are updated to themselves so that the existing row's
+	// ID is returned.
+	if err := tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "season"},
+			{Name: "season_type"},
+			{Name: "week"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"season",
+			"season_type",
+			"week",
+		}),
+	}).Create(&week).Error; err != nil {
+		return fmt.Errorf("could not upsert poll week; %w", err)
+	}
+	// Only PostgreSQL returns the ID of a row an upsert updated.
+	if tx.Dialector.Name() != DriverPostgres {
+		if err := tx.Model(&PollWeek{}).Select("id").Where(
+			"season = ? AND season_type = ? AND week = ?",
+			week.Season, week.SeasonType, week.Week,
+		).Scan(&week.ID).Error; err != nil {
+			return fmt.Errorf("could not read poll week; %w", err)
+		}
+	}
+
+	for _, poll := range unit.Polls {
+		ranks := make([]PollRank, 0, len(poll.Ranks))
+		seen := make(map[string]int, len(poll.Ranks))
+		for _, rank := range poll.Ranks {
+			if i, ok := seen[rank.School]; ok {
+				ranks[i] = rank
+				continue
+			}
+			seen[rank.School] = len(ranks)
+			ranks = append(ranks, rank)
+		}
+
+		poll.PollWeekID, poll.Ranks = week.ID, nil
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "poll_week_id"},
+				{Name: "poll"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{"poll"}),
+		}).Create(&poll).Error; err != nil {
+			return fmt.Errorf("could not upsert poll; %w", err)
+		}
+		if tx.Dialector.Name() != DriverPostgres {
+			if err := tx.Model(&Poll{}).Select("id").Where(
+				"poll_week_id = ? AND poll = ?", poll.PollWeekID, poll.Poll,
+			).Scan(&poll.ID).Error; err != nil {
+				return fmt.Errorf("could not read poll; %w", err)
+			}
+		}
+
+		schools := make([]string, len(ranks))
+		for i := range ranks {
+			ranks[i].PollID = poll.ID
+			schools[i] = ranks[i].School
+		}
+
+		if len(ranks) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{
+					{Name: "poll_id"},
+					{Name: "school"},
+				},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"rank",
+					"team_id",
+					"conference",
+					"first_place_votes",
+					"points",
+				}),
+			}).Create(&ranks).Error; err != nil {
+				return fmt.Errorf("could not upsert poll ranks; %w", err)
+			}
+		}
+
+		stale := tx.Where("poll_id = ?", poll.ID)
+		if len(schools) > 0 {
+			stale = stale.Where("school NOT IN ?", schools)
+		}
+		if err := stale.Delete(&PollRank{}).Error; err != nil {
+			return fmt.Errorf("could not remove dropped poll ranks; %w", err)
+		}
+	}
+
+	return nil
 }
 
 // InsertRecruits inserts recruiting data.
@@ -2347,8 +3284,8 @@ func (db *Database) InsertRecruits(
 			School:        r.School,
 			CommittedTo:   r.CommittedTo,
 			Position:      r.Position,
-			Height:        r.Height,
-			Weight:        r.Weight,
+			Height:        db.height(r.Height),
+			Weight:        db.weight(r.Weight),
 			Stars:         r.Stars,
 			Rating:        r.Rating,
 			City:          r.City,
@@ -2359,7 +3296,25 @@ func (db *Database) InsertRecruits(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"athlete_id",
+			"recruit_type",
+			"year",
+			"ranking",
+			"name",
+			"school",
+			"committed_to",
+			"position",
+			"height",
+			"weight",
+			"stars",
+			"rating",
+			"city",
+			"state_province",
+			"country",
+			"hometown_info_id",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -2386,7 +3341,55 @@ func (db *Database) InsertTeamRecruitingRankings(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "year"},
+			{Name: "team"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"rank",
+			"points",
+		}),
+	}).CreateInBatches(models, 100).Error
+}
+
+// InsertAggregatedTeamRecruiting inserts recruiting ratings aggregated by
+// team and position group.
+func (db *Database) InsertAggregatedTeamRecruiting(
+	ctx context.Context,
+	groups []*cfbd.AggregatedTeamRecruiting,
+) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	models := make([]AggregatedTeamRecruiting, 0, len(groups))
+	for _, g := range groups {
+		if g == nil {
+			continue
+		}
+		models = append(models, AggregatedTeamRecruiting{
+			Team:          g.Team,
+			Conference:    g.Conference,
+			PositionGroup: g.PositionGroup,
+			AverageRating: g.AverageRating,
+			TotalRating:   g.TotalRating,
+			Commits:       g.Commits,
+			AverageStars:  g.AverageStars,
+		})
+	}
+
+	return db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "team"},
+			{Name: "conference"},
+			{Name: "position_group"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"average_rating",
+			"total_rating",
+			"commits",
+			"average_stars",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -2431,8 +3434,8 @@ func (db *Database) InsertDraftPicks(
 			Pick:                    p.Pick,
 			Name:                    p.Name,
 			Position:                p.Position,
-			Height:                  p.Height,
-			Weight:                  p.Weight,
+			Height:                  db.height(p.Height),
+			Weight:                  db.weight(p.Weight),
 			PreDraftRanking:         p.PreDraftRanking,
 			PreDraftPositionRanking: p.PreDraftPositionRanking,
 			PreDraftGrade:           p.PreDraftGrade,
@@ -2441,7 +3444,28 @@ func (db *Database) InsertDraftPicks(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"college_athlete_id",
+			"nfl_athlete_id",
+			"college_id",
+			"college_team",
+			"college_conference",
+			"nfl_team_id",
+			"nfl_team",
+			"year",
+			"overall",
+			"round",
+			"pick",
+			"name",
+			"position",
+			"height",
+			"weight",
+			"pre_draft_ranking",
+			"pre_draft_position_ranking",
+			"pre_draft_grade",
+			"hometown_info_id",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -2493,9 +3517,11 @@ func (db *Database) InsertGameTeamStats(
 		})
 	}
 
-	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
-	}).CreateInBatches(models, LargeBatchSize).Error
+	// Each game is written with its team stats in one transaction.
+	return createUnits(ctx, db.DB, clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoNothing: true,
+	}, models)
 }
 
 // InsertGamePlayerStats inserts game player stats.
@@ -2572,15 +3598,22 @@ func (db *Database) InsertGamePlayerStats(
 		})
 	}
 
-	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
-	}).CreateInBatches(models, DefaultBatchSize).Error // Smaller batch
+	// Each game is written with its player stats in one transaction.
+	return createUnits(ctx, db.DB, clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoNothing: true,
+	}, models)
 }
 
-// GetGameIDs returns a slice of game IDs for a given season.
-func (db *Database) GetGameIDs(ctx context.Context, year int) ([]int32, error) {
+// GetGameIDs returns a slice of game IDs for a given season that match the
+// filter.
+func (db *Database) GetGameIDs(
+	ctx context.Context,
+	year int,
+	filter GameFilter,
+) ([]int32, error) {
 	var ids []int32
-	err := db.WithContext(ctx).Model(&Game{}).
+	err := filter.apply(db.WithContext(ctx).Model(&Game{})).
 		Where("season = ?", year).
 		Pluck("id", &ids).Error
 	return ids, err
@@ -2621,7 +3654,26 @@ func (db *Database) InsertPlayWinProbability(
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{
+			{Name: "game_id"},
+			{Name: "play_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"play_text",
+			"home_id",
+			"home",
+			"away_id",
+			"away",
+			"spread",
+			"home_ball",
+			"home_score",
+			"away_score",
+			"yard_line",
+			"down",
+			"distance",
+			"home_win_probability",
+			"play_number",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
 
@@ -2652,11 +3704,427 @@ func (db *Database) InsertAdvancedBoxScores(
 
 		models = append(models, AdvancedBoxScore{
 			GameID:  gameID,
-			Payload: datatypes.JSON(payload),
+			Payload: CompressedJSON(payload),
 		})
 	}
 
 	return db.WithContext(ctx).Clauses(clause.OnConflict{
-		UpdateAll: true,
+		Columns: []clause.Column{{Name: "game_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"payload",
+		}),
 	}).CreateInBatches(models, 100).Error
 }
+
+// InsertScoreboard upserts a snapshot of the live scoreboard. Nested venue,
+// team, weather and betting objects are stored as jsonb payloads.
+func (db *Database) InsertScoreboard(
+	ctx context.Context,
+	games []*cfbd.Scoreboard,
+) error {
+	if len(games) == 0 {
+		return nil
+	}
+
+	models := make([]Scoreboard, 0, len(games))
+	for _, g := range games {
+		if g == nil {
+			continue
+		}
+
+		id := g.GetId()
+		if id == 0 {
+			continue
+		}
+
+		var startDate *time.Time
+		if g.GetStartDate() != nil {
+			t := g.GetStartDate().AsTime()
+			startDate = &t
+		}
+
+		var period *int32
+		if g.Period != nil {
+			x := *g.Period
+			period = &x
+		}
+
+		venue, err := marshalPayload(g.GetVenue())
+		if err != nil {
+			slog.Error("failed to marshal scoreboard venue", "err", err)
+			continue
+		}
+		homeTeam, err := marshalPayload(g.GetHomeTeam())
+		if err != nil {
+			slog.Error("failed to marshal scoreboard home team", "err", err)
+			continue
+		}
+		awayTeam, err := marshalPayload(g.GetAwayTeam())
+		if err != nil {
+			slog.Error("failed to marshal scoreboard away team", "err", err)
+			continue
+		}
+		weather, err := marshalPayload(g.GetWeather())
+		if err != nil {
+			slog.Error("failed to marshal scoreboard weather", "err", err)
+			continue
+		}
+		betting, err := marshalPayload(g.GetBetting())
+		if err != nil {
+			slog.Error("failed to marshal scoreboard betting", "err", err)
+			continue
+		}
+
+		models = append(models, Scoreboard{
+			ID:             id,
+			StartDate:      startDate,
+			StartTimeTBD:   g.GetStartTime_TBD(),
+			TV:             strings.TrimSpace(g.GetTv()),
+			NeutralSite:    g.GetNeutralSite(),
+			ConferenceGame: g.GetConferenceGame(),
+			Status:         strings.TrimSpace(g.GetStatus()),
+			Period:         period,
+			Clock:          strings.TrimSpace(g.GetClock()),
+			Situation:      strings.TrimSpace(g.GetSituation()),
+			Possession:     strings.TrimSpace(g.GetPossession()),
+			LastPlay:       strings.TrimSpace(g.GetLastPlay()),
+			Venue:          venue,
+			HomeTeam:       homeTeam,
+			AwayTeam:       awayTeam,
+			Weather:        weather,
+			Betting:        betting,
+		})
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	if err := db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"start_date",
+				"start_time_tbd",
+				"tv",
+				"neutral_site",
+				"conference_game",
+				"status",
+				"period",
+				"clock",
+				"situation",
+				"possession",
+				"last_play",
+				"venue",
+				"home_team",
+				"away_team",
+				"weather",
+				"betting",
+			}),
+		}).
+		CreateInBatches(models, 500).Error; err != nil {
+		slog.Error("could not upsert scoreboard", "err", err.Error())
+		return fmt.Errorf("could not upsert scoreboard; %w", err)
+	}
+
+	return nil
+}
+
+// marshalPayload encodes a nested API object for a payload column, returning
+// nil (SQL NULL) when the object is absent.
+func marshalPayload[T any](v *T) (CompressedJSON, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal payload; %w", err)
+	}
+
+	return CompressedJSON(payload), nil
+}
+
+// GetActiveScoreboardGameIDs returns the IDs of games the most recent
+// scoreboard snapshot reports as in progress.
+func (db *Database) GetActiveScoreboardGameIDs(
+	ctx context.Context,
+) ([]int32, error) {
+	var ids []int32
+	err := db.WithContext(ctx).Model(&Scoreboard{}).
+		Where("status = ?", "in_progress").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// InsertLiveGame upserts the full nested live game graph (game, teams,
+// drives and plays) in a single transaction so dashboards never observe a
+// partially written game. Team rows have no natural key and are replaced on
+// every refresh; drives and plays are upserted on their API IDs.
+func (db *Database) InsertLiveGame(
+	ctx context.Context,
+	game *cfbd.LiveGame,
+) error {
+	if game == nil || game.Id == 0 {
+		return nil
+	}
+
+	var period *int32
+	if game.Period != nil {
+		x := *game.Period
+		period = &x
+	}
+	var down *int32
+	if game.Down != nil {
+		x := *game.Down
+		down = &x
+	}
+	var distance *int32
+	if game.Distance != nil {
+		x := *game.Distance
+		distance = &x
+	}
+	var yardsToGoal *int32
+	if game.YardsToGoal != nil {
+		x := *game.YardsToGoal
+		yardsToGoal = &x
+	}
+
+	liveGame := LiveGame{
+		ID:          game.Id,
+		Status:      strings.TrimSpace(game.Status),
+		Period:      period,
+		Clock:       strings.TrimSpace(game.Clock),
+		Possession:  strings.TrimSpace(game.Possession),
+		Down:        down,
+		Distance:    distance,
+		YardsToGoal: yardsToGoal,
+	}
+
+	teams := make([]LiveGameTeam, 0, len(game.Teams))
+	for _, t := range game.Teams {
+		if t == nil {
+			continue
+		}
+
+		var avgStart *float64
+		if t.AverageStartYardLine != nil {
+			x := *t.AverageStartYardLine
+			avgStart = &x
+		}
+		var deserveToWin *float64
+		if t.DeserveToWin != nil {
+			x := *t.DeserveToWin
+			deserveToWin = &x
+		}
+
+		teams = append(teams, LiveGameTeam{
+			LiveGameID:              game.Id,
+			TeamID:                  t.TeamId,
+			Team:                    strings.TrimSpace(t.Team),
+			HomeAway:                strings.TrimSpace(t.HomeAway),
+			LineScores:              utils.Int32SliceToInt64Array(t.LineScores),
+			Points:                  t.Points,
+			Drives:                  t.Drives,
+			ScoringOpportunities:    t.ScoringOpportunities,
+			PointsPerOpportunity:    t.PointsPerOpportunity,
+			AverageStartYardLine:    avgStart,
+			Plays:                   t.Plays,
+			LineYards:               t.LineYards,
+			LineYardsPerRush:        t.LineYardsPerRush,
+			SecondLevelYards:        t.SecondLevelYards,
+			SecondLevelYardsPerRush: t.SecondLevelYardsPerRush,
+			OpenFieldYards:          t.OpenFieldYards,
+			OpenFieldYardsPerRush:   t.OpenFieldYardsPerRush,
+			EpaPerPlay:              t.EpaPerPlay,
+			TotalEpa:                t.TotalEpa,
+			PassingEpa:              t.PassingEpa,
+			EpaPerPass:              t.EpaPerPass,
+			RushingEpa:              t.RushingEpa,
+			EpaPerRush:              t.EpaPerRush,
+			SuccessRate:             t.SuccessRate,
+			StandardDownSuccessRate: t.StandardDownSuccessRate,
+			PassingDownSuccessRate:  t.PassingDownSuccessRate,
+			Explosiveness:           t.Explosiveness,
+			DeserveToWin:            deserveToWin,
+		})
+	}
+
+	drives := make([]LiveGameDrive, 0, len(game.Drives))
+	var plays []LiveGamePlay
+	for _, d := range game.Drives {
+		if d == nil || d.Id == "" {
+			continue
+		}
+
+		var endPeriod *int32
+		if d.EndPeriod != nil {
+			x := *d.EndPeriod
+			endPeriod = &x
+		}
+		var endYardsToGoal *int32
+		if d.EndYardsToGoal != nil {
+			x := *d.EndYardsToGoal
+			endYardsToGoal = &x
+		}
+
+		drives = append(drives, LiveGameDrive{
+			ID:                 d.Id,
+			LiveGameID:         game.Id,
+			OffenseID:          d.OffenseId,
+			Offense:            strings.TrimSpace(d.Offense),
+			DefenseID:          d.DefenseId,
+			Defense:            strings.TrimSpace(d.Defense),
+			PlayCount:          d.PlayCount,
+			Yards:              d.Yards,
+			StartPeriod:        d.StartPeriod,
+			StartClock:         strings.TrimSpace(d.StartClock),
+			StartYardsToGoal:   d.StartYardsToGoal,
+			EndPeriod:          endPeriod,
+			EndClock:           strings.TrimSpace(d.EndClock),
+			EndYardsToGoal:     endYardsToGoal,
+			Duration:           strings.TrimSpace(d.Duration),
+			ScoringOpportunity: d.ScoringOpportunity,
+			Result:             strings.TrimSpace(d.Result),
+			PointsGained:       d.PointsGained,
+		})
+
+		for _, p := range d.Plays {
+			if p == nil || p.Id == "" {
+				continue
+			}
+
+			var wallClock *time.Time
+			if p.WallClock != nil {
+				t := p.WallClock.AsTime()
+				wallClock = &t
+			}
+			var epa *float64
+			if p.Epa != nil {
+				x := *p.Epa
+				epa = &x
+			}
+
+			plays = append(plays, LiveGamePlay{
+				ID:          p.Id,
+				DriveID:     d.Id,
+				HomeScore:   p.HomeScore,
+				AwayScore:   p.AwayScore,
+				Period:      p.Period,
+				Clock:       strings.TrimSpace(p.Clock),
+				WallClock:   wallClock,
+				TeamID:      p.TeamId,
+				Team:        strings.TrimSpace(p.Team),
+				Down:        p.Down,
+				Distance:    p.Distance,
+				YardsToGoal: p.YardsToGoal,
+				YardsGained: p.YardsGained,
+				PlayTypeID:  p.PlayTypeId,
+				PlayType:    strings.TrimSpace(p.PlayType),
+				Epa:         epa,
+				GarbageTime: p.GarbageTime,
+				Success:     p.Success,
+				RushPass:    strings.TrimSpace(p.RushPass),
+				DownType:    strings.TrimSpace(p.DownType),
+				PlayText:    strings.TrimSpace(p.PlayText),
+			})
+		}
+	}
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"status",
+				"period",
+				"clock",
+				"possession",
+				"down",
+				"distance",
+				"yards_to_goal",
+			}),
+		}).Create(&liveGame).Error; err != nil {
+			return fmt.Errorf("could not upsert live game; %w", err)
+		}
+
+		if err := tx.Where("live_game_id = ?", game.Id).
+			Delete(&LiveGameTeam{}).Error; err != nil {
+			return fmt.Errorf("could not clear live game teams; %w", err)
+		}
+
+		if len(teams) > 0 {
+			if err := tx.Create(&teams).Error; err != nil {
+				return fmt.Errorf("could not insert live game teams; %w", err)
+			}
+		}
+
+		if len(drives) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "id"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"live_game_id",
+					"offense_id",
+					"offense",
+					"defense_id",
+					"defense",
+					"play_count",
+					"yards",
+					"start_period",
+					"start_clock",
+					"start_yards_to_goal",
+					"end_period",
+					"end_clock",
+					"end_yards_to_goal",
+					"duration",
+					"scoring_opportunity",
+					"result",
+					"points_gained",
+				}),
+			}).CreateInBatches(drives, 500).Error; err != nil {
+				return fmt.Errorf("could not upsert live game drives; %w", err)
+			}
+		}
+
+		if len(plays) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "id"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"drive_id",
+					"home_score",
+					"away_score",
+					"period",
+					"clock",
+					"wall_clock",
+					"team_id",
+					"team",
+					"down",
+					"distance",
+					"yards_to_goal",
+					"yards_gained",
+					"play_type_id",
+					"play_type",
+					"epa",
+					"garbage_time",
+					"success",
+					"rush_pass",
+					"down_type",
+					"play_text",
+				}),
+			}).CreateInBatches(plays, 500).Error; err != nil {
+				return fmt.Errorf("could not upsert live game plays; %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		slog.Error(
+			"could not upsert live game",
+			"game_id", game.Id,
+			"err", err.Error(),
+		)
+		return fmt.Errorf("could not upsert live game %d; %w", game.Id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package db_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+)
+
+const (
+	// defaultImage is the PostgreSQL image started unless DBTEST_IMAGE
+	// names another.
+	defaultImage = "postgres:16-alpine"
+	// startTimeout bounds how long the server may take to accept
+	// connections.
+	startTimeout = time.Minute
+	// retryInterval is how long to wait between connection attempts.
+	retryInterval = 500 * time.Millisecond
+)
+
+// TestInserts checks the Insert functions against a real PostgreSQL. It
+// starts a container with Docker, so it is skipped in short mode and where
+// Docker is not available.
+func TestInserts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping database integration test in short mode")
+	}
+
+	checkInserts(t, postgres(t))
+}
+
+// postgres starts a PostgreSQL container for the test, connects to it and
+// initializes the schema.
+func postgres(tb testing.TB) *db.Database {
+	tb.Helper()
+
+	database := connect(tb, startPostgres(tb), "")
+	if err := database.Initialize(); err != nil {
+		tb.Fatalf("could not initialize schema; %v", err)
+	}
+
+	return database
+}
+
+// startPostgres starts a PostgreSQL container for the test and returns its
+// DSN. The container is removed when the test ends, and the test is skipped
+// where Docker is not available.
+func startPostgres(tb testing.TB) string {
+	tb.Helper()
+
+	if _, err := exec.LookPath("docker"); err != nil {
+		tb.Skip("docker is not available")
+	}
+
+	image := os.Getenv("DBTEST_IMAGE")
+	if image == "" {
+		image = defaultImage
+	}
+
+	id, err := docker(tb, "run", "--detach", "--rm",
+		"--env", "POSTGRES_USER=cfbd",
+		"--env", "POSTGRES_PASSWORD=cfbd",
+		"--env", "POSTGRES_DB=cfbd",
+		"--publish", "127.0.0.1::5432",
+		image)
+	if err != nil {
+		tb.Fatalf("could not start postgres; %v", err)
+	}
+	tb.Cleanup(func() {
+		if _, err := docker(tb, "rm", "--force", id); err != nil {
+			tb.Logf("could not remove postgres container; %v", err)
+		}
+	})
+
+	addr, err := docker(tb, "port", id, "5432/tcp")
+	if err != nil {
+		tb.Fatalf("could not get postgres port; %v", err)
+	}
+	// Docker lists a port per address family; the first is bound to the
+	// loopback address requested.
+	addr, _, _ = strings.Cut(addr, "\n")
+
+	return fmt.Sprintf("postgres://cfbd:cfbd@%s/cfbd?sslmode=disable", addr)
+}
+
+// connect connects to dsn, using schema unless it is empty, and retries
+// until the server accepts connections or startTimeout passes. The
+// connection is closed when the test ends.
+func connect(tb testing.TB, dsn, schema string) *db.Database {
+	tb.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
+	defer cancel()
+
+	for {
+		database, err := db.NewDatabase(db.Config{
+			Driver: db.DriverPostgres,
+			DSN:    dsn,
+			Schema: schema,
+		})
+		if err == nil {
+			tb.Cleanup(func() {
+				if sqlDB, err := database.DB.DB(); err == nil {
+					_ = sqlDB.Close()
+				}
+			})
+			return database
+		}
+
+		select {
+		case <-ctx.Done():
+			tb.Fatalf("could not connect to postgres; %v", err)
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// docker runs a docker command, returning its trimmed output.
+func docker(tb testing.TB, args ...string) (string, error) {
+	tb.Helper()
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("docker", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s",
+			args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
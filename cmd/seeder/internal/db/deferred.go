@@ -0,0 +1,245 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// deferredIndexTables lists the tables whose secondary indexes DeferIndexes
+// drops: those a multi-season backfill writes millions of rows to.
+var deferredIndexTables = []string{
+	(Drive{}).TableName(),
+	(Play{}).TableName(),
+	(PlayStat{}).TableName(),
+	(PlayWinProbability{}).TableName(),
+	(PlayerGamePredictedPointsAdded{}).TableName(),
+	(GameTeamStatsTeam{}).TableName(),
+	(GameTeamStatsTeamStat{}).TableName(),
+	(GamePlayerStatsTeam{}).TableName(),
+	(GamePlayerStatCategories{}).TableName(),
+	(GamePlayerStatTypes{}).TableName(),
+	(GamePlayerStatPlayer{}).TableName(),
+}
+
+// createIndexPattern finds the index a CREATE INDEX statement creates.
+var createIndexPattern = regexp.MustCompile(
+	`(?i)^CREATE (?:UNIQUE )?INDEX (?:CONCURRENTLY )?(?:IF NOT EXISTS )?"?(\w+)"?`,
+)
+
+// DeferIndexes drops the secondary indexes of deferredIndexTables for a bulk
+// load, which then only has to maintain primary keys and the unique indexes
+// upserts depend on. Each index is recorded in deferred_indexes before it is
+// dropped, so Finalize can rebuild it even if the load that deferred it
+// failed.
+func (db *Database) DeferIndexes(ctx context.Context) error {
+	if err := db.requirePostgres("deferred indexes"); err != nil {
+		return err
+	}
+
+	session := db.WithContext(ctx)
+	var indexes []DeferredIndex
+	err := session.Raw(`
+		SELECT ic.relname AS name, tc.relname AS table_name,
+			pg_get_indexdef(x.indexrelid) AS definition
+		FROM pg_index x
+		JOIN pg_class ic ON ic.oid = x.indexrelid
+		JOIN pg_class tc ON tc.oid = x.indrelid
+		WHERE tc.relnamespace = current_schema()::regnamespace
+			AND tc.relname IN ?
+			AND NOT x.indisunique AND NOT x.indisprimary
+		ORDER BY tc.relname, ic.relname`, deferredIndexTables,
+	).Scan(&indexes).Error
+	if err != nil {
+		return fmt.Errorf("could not list indexes; %w", err)
+	}
+
+	for _, index := range indexes {
+		// Only the access method and key are kept; the name and table are
+		// recorded separately, and the index may be rebuilt concurrently or
+		// one partition at a time.
+		using := strings.Index(index.Definition, " USING ")
+		if using < 0 {
+			return fmt.Errorf("could not parse definition of index %s: %q",
+				index.Name, index.Definition)
+		}
+		index.Definition = index.Definition[using+1:]
+		index.DeferredAt = time.Now().UTC()
+
+		err = session.Transaction(func(tx *gorm.DB) error {
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "name"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"table_name", "definition", "deferred_at",
+				}),
+			}).Create(&index).Error
+			if err != nil {
+				return err
+			}
+			return tx.Exec(fmt.Sprintf("DROP INDEX IF EXISTS %s", index.Name)).Error
+		})
+		if err != nil {
+			return fmt.Errorf("could not defer index %s; %w", index.Name, err)
+		}
+		slog.Info("deferred index", "index", index.Name, "table", index.Table)
+	}
+
+	return nil
+}
+
+// Finalize rebuilds the indexes DeferIndexes dropped, without blocking
+// writes to their tables, and forgets each once it is built. A partitioned
+// table's index is built one partition at a time.
+func (db *Database) Finalize(ctx context.Context) error {
+	session := db.WithContext(ctx)
+	if !session.Migrator().HasTable(&DeferredIndex{}) {
+		return nil
+	}
+
+	var deferred []DeferredIndex
+	if err := session.Order("table_name, name").Find(&deferred).Error; err != nil {
+		return fmt.Errorf("could not list deferred indexes; %w", err)
+	}
+
+	for _, index := range deferred {
+		start := time.Now()
+		if err := db.buildIndex(ctx, index); err != nil {
+			slog.Error("could not build deferred index",
+				"index", index.Name, "err", err.Error())
+			return fmt.Errorf("could not build index %s; %w", index.Name, err)
+		}
+		if err := session.Delete(&index).Error; err != nil {
+			return fmt.Errorf("could not forget index %s; %w", index.Name, err)
+		}
+		slog.Info("built deferred index",
+			"index", index.Name,
+			"table", index.Table,
+			"duration", time.Since(start).String(),
+		)
+	}
+
+	return nil
+}
+
+// buildIndex builds a deferred index. Partitioned tables cannot build an
+// index concurrently, so their index is created on the parent alone and each
+// partition's index is built concurrently and attached to it.
+func (db *Database) buildIndex(ctx context.Context, index DeferredIndex) error {
+	session := db.WithContext(ctx)
+
+	var partitioned bool
+	err := session.Raw(
+		"SELECT relkind = 'p' FROM pg_class WHERE oid = to_regclass(?)",
+		index.Table,
+	).Scan(&partitioned).Error
+	if err != nil {
+		return fmt.Errorf("could not check partitioning; %w", err)
+	}
+	if !partitioned {
+		return db.createIndexConcurrently(
+			ctx, index.Name, index.Table, index.Definition,
+		)
+	}
+
+	err = session.Exec(fmt.Sprintf(
+		"CREATE INDEX IF NOT EXISTS %s ON ONLY %s %s",
+		index.Name, index.Table, index.Definition,
+	)).Error
+	if err != nil {
+		return err
+	}
+
+	var partitions []string
+	err = session.Raw(`
+		SELECT c.relname FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = to_regclass(?)
+		ORDER BY c.relname`, index.Table,
+	).Scan(&partitions).Error
+	if err != nil {
+		return fmt.Errorf("could not list partitions; %w", err)
+	}
+
+	for _, partition := range partitions {
+		name := partition + "_" + index.Name
+		err = db.createIndexConcurrently(ctx, name, partition, index.Definition)
+		if err != nil {
+			return err
+		}
+		err = session.Exec(fmt.Sprintf(
+			"ALTER INDEX %s ATTACH PARTITION %s", index.Name, name,
+		)).Error
+		if err != nil {
+			return fmt.Errorf("could not attach index %s; %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// createIndexConcurrently builds an index without blocking writes to its
+// table. An invalid index left behind by an interrupted build is dropped
+// and built again.
+func (db *Database) createIndexConcurrently(
+	ctx context.Context,
+	name, table, definition string,
+) error {
+	session := db.WithContext(ctx)
+
+	var valid []bool
+	err := session.Raw(
+		"SELECT indisvalid FROM pg_index WHERE indexrelid = to_regclass(?)",
+		name,
+	).Scan(&valid).Error
+	if err != nil {
+		return fmt.Errorf("could not check index %s; %w", name, err)
+	}
+	if len(valid) > 0 && valid[0] {
+		return nil
+	}
+	if len(valid) > 0 {
+		err = session.Exec("DROP INDEX CONCURRENTLY IF EXISTS " + name).Error
+		if err != nil {
+			return fmt.Errorf("could not drop invalid index %s; %w", name, err)
+		}
+	}
+
+	err = session.Exec(fmt.Sprintf(
+		"CREATE INDEX CONCURRENTLY %s ON %s %s", name, table, definition,
+	)).Error
+	if err != nil {
+		return fmt.Errorf("could not create index %s; %w", name, err)
+	}
+
+	return nil
+}
+
+// deferredIndexNames returns the names of the indexes deferred and not
+// rebuilt yet.
+func (db *Database) deferredIndexNames(
+	ctx context.Context,
+) (map[string]bool, error) {
+	session := db.WithContext(ctx)
+	if !session.Migrator().HasTable(&DeferredIndex{}) {
+		return nil, nil
+	}
+
+	var names []string
+	err := session.Model(&DeferredIndex{}).Pluck("name", &names).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not list deferred indexes; %w", err)
+	}
+
+	deferred := make(map[string]bool, len(names))
+	for _, name := range names {
+		deferred[name] = true
+	}
+
+	return deferred, nil
+}
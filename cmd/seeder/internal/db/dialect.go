@@ -0,0 +1,234 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	// DriverPostgres stores the seeded data in PostgreSQL, the default.
+	DriverPostgres = "postgres"
+	// DriverMySQL stores the seeded data in MySQL 8 or MariaDB.
+	DriverMySQL = "mysql"
+	// DriverSQLite stores the seeded data in a single SQLite file.
+	DriverSQLite = "sqlite"
+)
+
+var (
+	// ErrUnknownDriver is returned for a driver other than postgres, mysql
+	// or sqlite.
+	ErrUnknownDriver = errors.New("unknown database driver")
+	// ErrUnsupportedDriver is returned by features that only exist on
+	// PostgreSQL, such as full-text search.
+	ErrUnsupportedDriver = errors.New("not supported by database driver")
+	// ErrSchemaConflict is returned by NewDatabase when a schema is
+	// configured and the PostgreSQL DSN sets search_path as well.
+	ErrSchemaConflict = errors.New("schema set by both config and dsn")
+)
+
+// dialect holds what differs between the databases the seeder can write
+// to. Upserts written with GORM's OnConflict clause already translate to
+// each database's syntax; the dialect covers the rest.
+type dialect interface {
+	// open returns the GORM dialector connecting to dsn.
+	open(dsn string) (gorm.Dialector, error)
+	// reopen returns a GORM dialector on an already open connection pool.
+	reopen(conn gorm.ConnPool) gorm.Dialector
+	// prepare adapts the models to the database on a newly opened handle.
+	prepare(gdb *gorm.DB) error
+	// createSchema creates the schema the tables live in, if it is missing.
+	createSchema(tx *gorm.DB) error
+	// hasSchema reports whether the schema the tables live in exists.
+	hasSchema(tx *gorm.DB) (bool, error)
+	// dropSchema drops the schema the tables live in, along with every
+	// table, view and row in it.
+	dropSchema(tx *gorm.DB) error
+	// upsert returns the clause that makes an INSERT ... SELECT update the
+	// given columns of rows that conflict on target.
+	upsert(target string, columns []string) string
+	// estimateRows returns the planner's row estimate for a table.
+	estimateRows(tx *gorm.DB, table string) (int64, error)
+}
+
+// newDialect returns the dialect of the named driver, keeping the tables in
+// the named schema where the database has schemas.
+func newDialect(driver, schema string) (dialect, error) {
+	switch driver {
+	case "", DriverPostgres:
+		return postgresDialect{schema: schema}, nil
+	case DriverMySQL:
+		return mysqlDialect{}, nil
+	case DriverSQLite:
+		return sqliteDialect{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDriver, driver)
+	}
+}
+
+// requirePostgres returns ErrUnsupportedDriver unless the database is
+// PostgreSQL.
+func (db *Database) requirePostgres(feature string) error {
+	if db.Dialector.Name() == DriverPostgres {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s requires %s, not %s",
+		ErrUnsupportedDriver, feature, DriverPostgres, db.Dialector.Name())
+}
+
+// postgresDialect keeps every table in one schema, which connections find
+// them in through their search_path, so that the models' unqualified table
+// names resolve to the schema's tables.
+type postgresDialect struct {
+	schema string
+}
+
+// dsnSchema returns the first schema of the search_path a PostgreSQL DSN
+// sets, or reports false if it sets none.
+func dsnSchema(dsn string) (string, bool, error) {
+	conf, err := pgconn.ParseConfig(dsn)
+	if err != nil {
+		return "", false, fmt.Errorf("could not parse dsn; %w", err)
+	}
+	path, ok := conf.RuntimeParams["search_path"]
+	if !ok {
+		return "", false, nil
+	}
+
+	first, _, _ := strings.Cut(path, ",")
+	return strings.Trim(strings.TrimSpace(first), `"`), true, nil
+}
+
+func (d postgresDialect) open(dsn string) (gorm.Dialector, error) {
+	// Append search_path to DSN if not already present
+	if !strings.Contains(dsn, "search_path") {
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+		dsn = dsn + separator + "search_path=" + d.schema + ",public"
+	}
+
+	return postgres.Open(dsn), nil
+}
+
+func (postgresDialect) reopen(conn gorm.ConnPool) gorm.Dialector {
+	return postgres.New(postgres.Config{Conn: conn})
+}
+
+func (postgresDialect) prepare(*gorm.DB) error {
+	return nil
+}
+
+func (d postgresDialect) createSchema(tx *gorm.DB) error {
+	return tx.Exec("CREATE SCHEMA IF NOT EXISTS " + d.schema).Error
+}
+
+// dropSchema refuses to drop public, which other applications and the
+// extensions the seeder installs share, and any schema an extension lives
+// in, as the cascade would drop the extension for every schema using it.
+func (d postgresDialect) dropSchema(tx *gorm.DB) error {
+	if d.schema == "public" {
+		return fmt.Errorf("%w: %s is shared", ErrProtectedSchema, d.schema)
+	}
+
+	var extensions []string
+	err := tx.Raw(`
+		SELECT e.extname
+		FROM pg_extension e
+		JOIN pg_namespace n ON n.oid = e.extnamespace
+		WHERE n.nspname = ?
+		ORDER BY e.extname`, d.schema,
+	).Scan(&extensions).Error
+	if err != nil {
+		return fmt.Errorf("could not list extensions; %w", err)
+	}
+	if len(extensions) > 0 {
+		return fmt.Errorf("%w: %s holds extensions %s", ErrProtectedSchema,
+			d.schema, strings.Join(extensions, ", "))
+	}
+
+	return tx.Exec("DROP SCHEMA IF EXISTS " + d.schema + " CASCADE").Error
+}
+
+func (d postgresDialect) hasSchema(tx *gorm.DB) (bool, error) {
+	var exists bool
+	err := tx.Raw(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.schemata
+			WHERE schema_name = ?
+		)`, d.schema,
+	).Scan(&exists).Error
+
+	return exists, err
+}
+
+func (postgresDialect) upsert(target string, columns []string) string {
+	set := make([]string, len(columns))
+	for i, column := range columns {
+		set[i] = column + " = EXCLUDED." + column
+	}
+
+	return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s",
+		target, strings.Join(set, ", "))
+}
+
+// estimateRows sums the estimates of a partitioned table's partitions.
+func (postgresDialect) estimateRows(tx *gorm.DB, table string) (int64, error) {
+	var rows int64
+	err := tx.Raw(
+		`SELECT COALESCE((
+			SELECT sum(GREATEST(reltuples, 0)) FROM pg_class
+			WHERE oid = to_regclass(?) OR oid IN (
+				SELECT inhrelid FROM pg_inherits
+				WHERE inhparent = to_regclass(?)
+			)
+		), 0)::bigint`,
+		table, table,
+	).Scan(&rows).Error
+
+	return rows, err
+}
+
+// dropTables drops every table of the database, for databases whose schema
+// is the database itself.
+func dropTables(tx *gorm.DB) error {
+	tables, err := tx.Migrator().GetTables()
+	if err != nil {
+		return fmt.Errorf("could not list tables; %w", err)
+	}
+
+	for _, table := range tables {
+		// SQLite's own bookkeeping tables cannot be dropped.
+		if strings.HasPrefix(table, "sqlite_") {
+			continue
+		}
+		if err = tx.Migrator().DropTable(table); err != nil {
+			return fmt.Errorf("could not drop %s; %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// ignoreTextSearch leaves the generated full-text search column of plays out
+// of the schema, for databases without tsvector.
+func ignoreTextSearch(gdb *gorm.DB) error {
+	stmt := &gorm.Statement{DB: gdb}
+	if err := stmt.Parse(&Play{}); err != nil {
+		return fmt.Errorf("could not parse plays; %w", err)
+	}
+
+	if field := stmt.Schema.LookUpField("play_text_search"); field != nil {
+		field.IgnoreMigration = true
+		delete(field.TagSettings, "INDEX")
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+)
+
+// DisableWrites turns the database read-only for a dry run by replacing
+// GORM's create, update, delete and raw exec callbacks with no-ops. Creates
+// still report the number of rows they would have written, so table sync
+// hooks can estimate row counts. Reads are unaffected. It must be called
+// after the schema has been migrated, since migrations are raw execs.
+func (db *Database) DisableWrites() error {
+	callbacks := db.Callback()
+
+	err := callbacks.Create().Replace("gorm:create", func(tx *gorm.DB) {
+		if tx.Error == nil {
+			tx.RowsAffected = rowCount(tx.Statement.ReflectValue)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("could not disable creates; %w", err)
+	}
+
+	noop := func(*gorm.DB) {}
+	if err = callbacks.Update().Replace("gorm:update", noop); err != nil {
+		return fmt.Errorf("could not disable updates; %w", err)
+	}
+	if err = callbacks.Delete().Replace("gorm:delete", noop); err != nil {
+		return fmt.Errorf("could not disable deletes; %w", err)
+	}
+	if err = callbacks.Raw().Replace("gorm:raw", noop); err != nil {
+		return fmt.Errorf("could not disable raw execs; %w", err)
+	}
+
+	return nil
+}
+
+// rowCount returns the number of rows in a create statement's value.
+func rowCount(value reflect.Value) int64 {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		return int64(value.Len())
+	case reflect.Struct:
+		return 1
+	default:
+		return 0
+	}
+}
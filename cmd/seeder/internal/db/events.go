@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Operations of a RowChange.
+const (
+	// OpInsert is a row written by a plain insert.
+	OpInsert = "insert"
+	// OpUpsert is a row written by an insert that updates the row if it
+	// already exists.
+	OpUpsert = "upsert"
+)
+
+// RowChange is a compact event describing a single row that was written.
+type RowChange struct {
+	// Table is the table the row was written to.
+	Table string `json:"table"`
+	// Key maps the columns of the row's primary key to their values.
+	Key map[string]any `json:"pk"`
+	// Op is how the row was written, OpInsert or OpUpsert.
+	Op string `json:"op"`
+	// Season is the row's season (or year) column, if it has one.
+	Season *int32 `json:"season,omitempty"`
+	// Week is the row's week column, if it has one.
+	Week *int32 `json:"week,omitempty"`
+}
+
+// OnRowChanges registers fn to be called after every successful create or
+// upsert of one of tables (every table when tables is empty) with a change
+// per row written. With SkipUnchanged enabled, unchanged rows are not
+// written and so not reported; inserts that ignore conflicts report all of
+// their rows unless none were inserted, since the ignored ones cannot be
+// told apart. fn is called once the statement's own transaction commits,
+// but rows written within a larger transaction (a game and its team stats)
+// are reported before it commits. It must not block.
+func (db *Database) OnRowChanges(
+	tables []string,
+	fn func(ctx context.Context, changes []RowChange),
+) error {
+	return db.onRowChanges("cfbd:row_changes", tables, fn)
+}
+
+// onRowChanges is OnRowChanges registering the callback under name.
+func (db *Database) onRowChanges(
+	name string,
+	tables []string,
+	fn func(ctx context.Context, changes []RowChange),
+) error {
+	err := db.Callback().Create().
+		After("gorm:commit_or_rollback_transaction").
+		Register(name, func(tx *gorm.DB) {
+			stmt := tx.Statement
+			if tx.Error != nil || stmt.Schema == nil || stmt.Table == "" ||
+				tx.RowsAffected == 0 ||
+				(len(tables) > 0 && !slices.Contains(tables, stmt.Table)) {
+				return
+			}
+			if changes := rowChanges(stmt); len(changes) > 0 {
+				fn(stmt.Context, changes)
+			}
+		})
+	if err != nil {
+		return fmt.Errorf("could not register row changes callback; %w", err)
+	}
+
+	return nil
+}
+
+// rowChanges returns a change for every row of a create statement.
+func rowChanges(stmt *gorm.Statement) []RowChange {
+	op := OpInsert
+	if isUpsert(stmt) {
+		op = OpUpsert
+	}
+	season := stmt.Schema.LookUpField("season")
+	if season == nil {
+		season = stmt.Schema.LookUpField("year")
+	}
+	week := stmt.Schema.LookUpField("week")
+
+	var rows []reflect.Value
+	switch value := reflect.Indirect(stmt.ReflectValue); value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range value.Len() {
+			rows = append(rows, reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		rows = append(rows, value)
+	default:
+		return nil
+	}
+
+	changes := make([]RowChange, 0, len(rows))
+	for _, row := range rows {
+		key := make(map[string]any, len(stmt.Schema.PrimaryFields))
+		for _, field := range stmt.Schema.PrimaryFields {
+			key[field.DBName], _ = field.ValueOf(stmt.Context, row)
+		}
+		changes = append(changes, RowChange{
+			Table:  stmt.Table,
+			Key:    key,
+			Op:     op,
+			Season: intField(stmt.Context, season, row),
+			Week:   intField(stmt.Context, week, row),
+		})
+	}
+
+	return changes
+}
+
+// intField returns the row's value of a numeric field, or nil if the table
+// has no such field or the row's value is nil.
+func intField(
+	ctx context.Context,
+	field *schema.Field,
+	row reflect.Value,
+) *int32 {
+	if field == nil {
+		return nil
+	}
+	value, _ := field.ValueOf(ctx, row)
+	v := reflect.Indirect(reflect.ValueOf(value))
+
+	var n int32
+	switch {
+	case v.CanInt():
+		n = int32(v.Int()) //nolint:gosec // seasons and weeks fit in int32
+	case v.CanUint():
+		n = int32(v.Uint()) //nolint:gosec // seasons and weeks fit in int32
+	case v.CanFloat():
+		n = int32(v.Float())
+	default:
+		return nil
+	}
+
+	return &n
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecordSeedFailure records that fetching the unit identified by params
+// from the endpoint failed. A unit that has failed before is reopened and
+// its attempt count incremented rather than recorded twice.
+func (db *Database) RecordSeedFailure(
+	ctx context.Context,
+	endpoint string,
+	params []byte,
+	cause error,
+	at time.Time,
+) error {
+	failure := SeedFailure{
+		Endpoint: endpoint,
+		Params:   datatypes.JSON(params),
+		Error:    cause.Error(),
+		Attempts: 1,
+		FailedAt: at,
+	}
+
+	err := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "endpoint"}, {Name: "params"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"error":       failure.Error,
+			"failed_at":   at,
+			"resolved_at": nil,
+			"attempts":    gorm.Expr("seed_failures.attempts + 1"),
+		}),
+	}).Create(&failure).Error
+	if err != nil {
+		return fmt.Errorf("could not record seed failure; %w", err)
+	}
+
+	return nil
+}
+
+// GetUnresolvedSeedFailures returns every failure that has not been
+// resolved by a later successful fetch, oldest first.
+func (db *Database) GetUnresolvedSeedFailures(
+	ctx context.Context,
+) ([]SeedFailure, error) {
+	var failures []SeedFailure
+	err := db.WithContext(ctx).
+		Where("resolved_at IS NULL AND permanent_at IS NULL").
+		Order("id").
+		Find(&failures).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not get seed failures; %w", err)
+	}
+
+	return failures, nil
+}
+
+// ResolveSeedFailure marks a failure as resolved at the given time.
+func (db *Database) ResolveSeedFailure(
+	ctx context.Context,
+	id int64,
+	at time.Time,
+) error {
+	err := db.WithContext(ctx).
+		Model(&SeedFailure{}).
+		Where("id = ?", id).
+		Update("resolved_at", at).Error
+	if err != nil {
+		return fmt.Errorf("could not resolve seed failure; %w", err)
+	}
+
+	return nil
+}
+
+// MarkSeedFailurePermanent stops a failure from being retried, as of the
+// given time.
+func (db *Database) MarkSeedFailurePermanent(
+	ctx context.Context,
+	id int64,
+	at time.Time,
+) error {
+	err := db.WithContext(ctx).
+		Model(&SeedFailure{}).
+		Where("id = ?", id).
+		Update("permanent_at", at).Error
+	if err != nil {
+		return fmt.Errorf("could not mark seed failure permanent; %w", err)
+	}
+
+	return nil
+}
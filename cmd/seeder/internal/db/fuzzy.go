@@ -0,0 +1,196 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// DefaultSimilarityThreshold is the minimum trigram similarity (0 to 1) for
+// a fuzzy name match when no threshold is given. It matches the pg_trgm
+// default.
+const DefaultSimilarityThreshold = 0.3
+
+// trigramIndex is a GIN trigram index that serves fuzzy name matching on
+// expr.
+type trigramIndex struct {
+	name, table, expr string
+}
+
+// trigramIndexes cover every name that is matched fuzzily. The API spells
+// the same athlete or school differently across endpoints ("Ole Miss" and
+// "Mississippi", "Cam Ward" and "Cameron Ward"), so exact joins on names
+// miss rows that these indexes can find.
+var trigramIndexes = []trigramIndex{
+	{"idx_teams_school_trgm", "teams", "school"},
+	{"idx_player_search_results_name_trgm", "player_search_results", "name"},
+	{"idx_roster_players_full_name_trgm", "roster_players", rosterFullName},
+	{"idx_recruits_name_trgm", "recruits", "name"},
+	{"idx_draft_picks_name_trgm", "draft_picks", "name"},
+}
+
+// rosterFullName is the expression roster players are matched on, since the
+// roster splits names that every other endpoint keeps whole. Queries must
+// use the same expression for the index to apply.
+const rosterFullName = "(first_name || ' ' || last_name)"
+
+// NameSearch describes a fuzzy match on a name.
+type NameSearch struct {
+	// Name is compared by trigram similarity, so it tolerates typos,
+	// missing punctuation and reordered words.
+	Name string
+	// Threshold is the minimum similarity (0 to 1) of a match; zero means
+	// DefaultSimilarityThreshold.
+	Threshold float64
+	// Limit caps the number of results; zero means DefaultSearchLimit.
+	Limit int
+}
+
+// TeamMatch is a team whose school name fuzzily matched a search.
+type TeamMatch struct {
+	Team
+	Similarity float64 `gorm:"column:similarity"`
+}
+
+// AthleteMatch is a name that fuzzily matched a search in one of the tables
+// that record athletes.
+type AthleteMatch struct {
+	// Source is the table the match came from, e.g. "recruits".
+	Source string `gorm:"column:source"`
+	// ID is the athlete's ID in the source table.
+	ID         string  `gorm:"column:id"`
+	Name       string  `gorm:"column:name"`
+	Team       string  `gorm:"column:team"`
+	Similarity float64 `gorm:"column:similarity"`
+}
+
+// EnableFuzzySearch installs the pg_trgm extension and creates the trigram
+// indexes used by SearchTeams and SearchAthletes. It is idempotent, so it
+// runs on every start and covers databases created before the indexes
+// existed.
+func (db *Database) EnableFuzzySearch(ctx context.Context) error {
+	if err := db.requirePostgres("fuzzy search"); err != nil {
+		return err
+	}
+
+	// An extension belongs to the whole database, so pg_trgm is created in
+	// public, which the search_path of every schema includes.
+	tx := db.WithContext(ctx)
+	err := tx.Exec(`CREATE EXTENSION IF NOT EXISTS pg_trgm SCHEMA public`).Error
+	if err != nil {
+		return fmt.Errorf("could not enable pg_trgm; %w", err)
+	}
+
+	for _, idx := range trigramIndexes {
+		// The index definitions are constants, not user input.
+		err = tx.Exec(fmt.Sprintf(
+			"CREATE INDEX IF NOT EXISTS %s ON %s USING gin (%s gin_trgm_ops)",
+			idx.name, idx.table, idx.expr,
+		)).Error
+		if err != nil {
+			return fmt.Errorf("could not create index %s; %w", idx.name, err)
+		}
+	}
+
+	return nil
+}
+
+// SearchTeams returns the teams whose school name is similar to the search,
+// most similar first.
+func (db *Database) SearchTeams(
+	ctx context.Context,
+	search NameSearch,
+) ([]TeamMatch, error) {
+	var matches []TeamMatch
+	err := db.fuzzy(ctx, search, func(tx *gorm.DB, limit int) error {
+		return tx.
+			Model(&Team{}).
+			Select("teams.*, similarity(school, ?) AS similarity",
+				search.Name).
+			Where("school % ?", search.Name).
+			Order("similarity DESC, id").
+			Limit(limit).
+			Find(&matches).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not search teams; %w", err)
+	}
+
+	return matches, nil
+}
+
+// SearchAthletes returns athletes whose name is similar to the search from
+// the player search results, rosters, recruits and draft picks, most
+// similar first. The same athlete may appear once per source, which is
+// useful for linking IDs between endpoints.
+func (db *Database) SearchAthletes(
+	ctx context.Context,
+	search NameSearch,
+) ([]AthleteMatch, error) {
+	var matches []AthleteMatch
+	err := db.fuzzy(ctx, search, func(tx *gorm.DB, limit int) error {
+		return tx.Raw(`
+			SELECT * FROM (
+				SELECT 'player_search_results' AS source, id, name, team,
+					similarity(name, @name) AS similarity
+				FROM player_search_results WHERE name % @name
+				UNION ALL
+				SELECT 'roster_players', id, `+rosterFullName+`, team,
+					similarity(`+rosterFullName+`, @name)
+				FROM roster_players WHERE `+rosterFullName+` % @name
+				UNION ALL
+				SELECT 'recruits', coalesce(athlete_id, id), name,
+					committed_to, similarity(name, @name)
+				FROM recruits WHERE name % @name
+				UNION ALL
+				SELECT 'draft_picks', coalesce(
+					college_athlete_id::text, id::text
+				), name, college_team, similarity(name, @name)
+				FROM draft_picks WHERE name % @name
+			) matches
+			ORDER BY similarity DESC, source, id
+			LIMIT @limit`,
+			map[string]any{"name": search.Name, "limit": limit},
+		).Scan(&matches).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not search athletes; %w", err)
+	}
+
+	return matches, nil
+}
+
+// fuzzy runs query in a transaction with the search's similarity threshold
+// applied to the % operator. The threshold is set locally to the
+// transaction so it never leaks to other users of the pooled connection.
+func (db *Database) fuzzy(
+	ctx context.Context,
+	search NameSearch,
+	query func(tx *gorm.DB, limit int) error,
+) error {
+	if err := db.requirePostgres("fuzzy search"); err != nil {
+		return err
+	}
+
+	threshold := search.Threshold
+	if threshold <= 0 {
+		threshold = DefaultSimilarityThreshold
+	}
+	limit := search.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(
+			"SELECT set_config('pg_trgm.similarity_threshold', ?, true)",
+			fmt.Sprint(threshold),
+		).Error
+		if err != nil {
+			return err
+		}
+
+		return query(tx, limit)
+	})
+}
@@ -0,0 +1,172 @@
+package db
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TrackHistory keeps every version of the betting lines and poll ranks
+// written from now on. Before an upsert of game_lines or poll_ranks, each
+// row that is new or differs from the stored row is appended to
+// game_lines_history or poll_ranks_history, in the same transaction, so
+// line movement and the evolution of a poll remain queryable although the
+// upsert overwrites the row. Rows removed from a poll keep their last
+// version.
+func (db *Database) TrackHistory() error {
+	err := db.Callback().Create().Before("gorm:create").Register(
+		"cfbd:record_history", recordHistory,
+	)
+	if err != nil {
+		return fmt.Errorf("could not register history callback; %w", err)
+	}
+
+	return nil
+}
+
+// recordHistory appends the versions an upsert of lines or ranks writes to
+// their history table.
+func recordHistory(tx *gorm.DB) {
+	stmt := tx.Statement
+	if tx.Error != nil || !isUpsert(stmt) {
+		return
+	}
+	if _, skip := tx.InstanceGet(skipCreateKey); skip {
+		return
+	}
+
+	session := tx.Session(&gorm.Session{NewDB: true})
+	var err error
+	switch rows := reflect.Indirect(stmt.ReflectValue).Interface().(type) {
+	case []GameLine:
+		err = recordLineHistory(session, rows)
+	case []PollRank:
+		err = recordRankHistory(session, rows)
+	}
+	if err != nil {
+		_ = tx.AddError(err)
+	}
+}
+
+// recordLineHistory appends the lines that are new or changed.
+func recordLineHistory(tx *gorm.DB, lines []GameLine) error {
+	ids := make([]int32, 0, len(lines))
+	for _, l := range lines {
+		ids = append(ids, l.GameID)
+	}
+	var stored []GameLine
+	if err := tx.Where("game_id IN ?", ids).Find(&stored).Error; err != nil {
+		return fmt.Errorf("could not get stored lines; %w", err)
+	}
+	type lineKey struct {
+		gameID   int32
+		provider string
+	}
+	previous := make(map[lineKey]GameLine, len(stored))
+	for _, l := range stored {
+		previous[lineKey{l.GameID, l.Provider}] = l
+	}
+
+	now := time.Now().UTC()
+	history := make([]GameLineHistory, 0, len(lines))
+	for _, l := range lines {
+		if old, ok := previous[lineKey{l.GameID, l.Provider}]; ok &&
+			sameLine(old, l) {
+			continue
+		}
+		history = append(history, GameLineHistory{
+			GameID:          l.GameID,
+			Provider:        l.Provider,
+			Spread:          l.Spread,
+			FormattedSpread: l.FormattedSpread,
+			SpreadOpen:      l.SpreadOpen,
+			OverUnder:       l.OverUnder,
+			OverUnderOpen:   l.OverUnderOpen,
+			HomeMoneyline:   l.HomeMoneyline,
+			AwayMoneyline:   l.AwayMoneyline,
+			RecordedAt:      now,
+		})
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
+	if err := tx.CreateInBatches(history, 500).Error; err != nil {
+		return fmt.Errorf("could not record line history; %w", err)
+	}
+
+	return nil
+}
+
+// recordRankHistory appends the ranks that are new or changed.
+func recordRankHistory(tx *gorm.DB, ranks []PollRank) error {
+	ids := make([]int64, 0, len(ranks))
+	for _, r := range ranks {
+		ids = append(ids, r.PollID)
+	}
+	var stored []PollRank
+	if err := tx.Where("poll_id IN ?", ids).Find(&stored).Error; err != nil {
+		return fmt.Errorf("could not get stored ranks; %w", err)
+	}
+	type rankKey struct {
+		pollID int64
+		school string
+	}
+	previous := make(map[rankKey]PollRank, len(stored))
+	for _, r := range stored {
+		previous[rankKey{r.PollID, r.School}] = r
+	}
+
+	now := time.Now().UTC()
+	history := make([]PollRankHistory, 0, len(ranks))
+	for _, r := range ranks {
+		if old, ok := previous[rankKey{r.PollID, r.School}]; ok &&
+			sameRank(old, r) {
+			continue
+		}
+		history = append(history, PollRankHistory{
+			PollID:          r.PollID,
+			School:          r.School,
+			Rank:            r.Rank,
+			TeamID:          r.TeamID,
+			Conference:      r.Conference,
+			FirstPlaceVotes: r.FirstPlaceVotes,
+			Points:          r.Points,
+			RecordedAt:      now,
+		})
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
+	if err := tx.CreateInBatches(history, 500).Error; err != nil {
+		return fmt.Errorf("could not record rank history; %w", err)
+	}
+
+	return nil
+}
+
+// sameLine reports whether two versions of a line have the same values.
+func sameLine(a, b GameLine) bool {
+	if a.FormattedSpread != b.FormattedSpread {
+		return false
+	}
+	for _, f := range lineFields {
+		if f.value(a) != f.value(b) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameRank reports whether two versions of a rank have the same values.
+func sameRank(a, b PollRank) bool {
+	return formatPtr(a.Rank) == formatPtr(b.Rank) &&
+		formatPtr(a.TeamID) == formatPtr(b.TeamID) &&
+		a.Conference == b.Conference &&
+		formatPtr(a.FirstPlaceVotes) == formatPtr(b.FirstPlaceVotes) &&
+		formatPtr(a.Points) == formatPtr(b.Points)
+}
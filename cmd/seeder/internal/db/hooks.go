@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"gorm.io/gorm"
+)
+
+// OnTableSync registers fn to be called after every successful create or
+// upsert, with the statement's context, the name of the table that was
+// written, how its rows were written and the time of the write. It is
+// intended for progress reporting and must not block.
+func (db *Database) OnTableSync(
+	fn func(
+		ctx context.Context,
+		table string,
+		rows etl.WriteCounts,
+		at time.Time,
+	),
+) error {
+	err := db.Callback().Create().After("gorm:create").Register(
+		"cfbd:table_sync",
+		func(tx *gorm.DB) {
+			if tx.Error != nil || tx.Statement.Table == "" {
+				return
+			}
+			fn(tx.Statement.Context, tx.Statement.Table, writeCounts(tx),
+				time.Now())
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("could not register table sync callback; %w", err)
+	}
+
+	return nil
+}
+
+// writeCounts returns how the rows of a successful create statement were
+// written. Upserts cannot tell inserted rows from updated ones, unless
+// SkipUnchanged has counted them from the stored row hashes, and inserts
+// that ignore conflicts count the ignored rows as skipped.
+func writeCounts(tx *gorm.DB) etl.WriteCounts {
+	if value, ok := tx.InstanceGet(writeCountsKey); ok {
+		if counts, ok := value.(etl.WriteCounts); ok {
+			return counts
+		}
+	}
+
+	if isUpsert(tx.Statement) {
+		return etl.WriteCounts{Upserted: tx.RowsAffected}
+	}
+
+	return etl.WriteCounts{
+		Inserted: tx.RowsAffected,
+		Skipped:  max(rowCount(tx.Statement.ReflectValue)-tx.RowsAffected, 0),
+	}
+}
+
+// Ping verifies that a connection to the database can be established.
+func (db *Database) Ping(ctx context.Context) error {
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return fmt.Errorf("could not get database handle; %w", err)
+	}
+
+	if err = sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("could not ping database; %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"time"
+
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// importBatchSize is how many rows each insert of an import writes.
+const importBatchSize = 500
+
+// importTimeLayouts are the layouts timestamps are parsed with, as exported
+// from PostgreSQL and MySQL and as SQLite stores them.
+var importTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	time.DateOnly,
+}
+
+// ImportRows inserts rows into a seeded table, each a column name to value
+// map as decoded from JSON with numbers kept as json.Number, and returns
+// how many were inserted. Values are converted to the Go type of their
+// column, as if read from the database, so that they are written the way
+// this database stores them: timestamps are parsed, JSON objects are
+// stored as JSON, and payloads are compressed if the database compresses
+// them. Columns the database generates are left out, and so are columns
+// the table's model does not have. Rows whose key is already stored are
+// left as they are, so an interrupted import can be run again. Season
+// partitions are created as needed, and on PostgreSQL the table's ID
+// sequence is moved past the imported IDs.
+func (db *Database) ImportRows(
+	ctx context.Context,
+	table string,
+	rows iter.Seq2[map[string]any, error],
+) (int64, error) {
+	model, err := db.importModel(table)
+	if err != nil {
+		return 0, err
+	}
+	partitioned := slices.ContainsFunc(seasonPartitions,
+		func(p seasonPartition) bool { return p.table == table })
+
+	var inserted int64
+	batch := make([]map[string]any, 0, importBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if partitioned {
+			if err := db.ensureImportPartitions(ctx, table, batch); err != nil {
+				return err
+			}
+		}
+
+		result := db.WithContext(ctx).
+			Model(reflect.New(model.ModelType).Interface()).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&batch)
+		if result.Error != nil {
+			return fmt.Errorf("could not insert into %s; %w",
+				table, result.Error)
+		}
+		inserted += result.RowsAffected
+		batch = batch[:0]
+		return nil
+	}
+
+	for row, err := range rows {
+		if err != nil {
+			return inserted, err
+		}
+		for column, value := range row {
+			field := model.LookUpField(column)
+			if field == nil || field.DBName == "" || !field.Creatable {
+				// Generated columns, like the full-text search of plays or
+				// the PostGIS location of venues, are the database's to
+				// fill.
+				delete(row, column)
+				continue
+			}
+			if row[column], err = importValue(field, value); err != nil {
+				return inserted, fmt.Errorf("could not convert %s.%s; %w",
+					table, column, err)
+			}
+		}
+
+		batch = append(batch, row)
+		if len(batch) == importBatchSize {
+			if err = flush(); err != nil {
+				return inserted, err
+			}
+		}
+	}
+	if err = flush(); err != nil {
+		return inserted, err
+	}
+
+	if model.PrioritizedPrimaryField != nil &&
+		model.PrioritizedPrimaryField.AutoIncrement {
+		err = db.advanceSequence(ctx, table,
+			model.PrioritizedPrimaryField.DBName)
+	}
+
+	return inserted, err
+}
+
+// importModel returns the schema of the seeded table.
+func (db *Database) importModel(table string) (*schema.Schema, error) {
+	models, err := db.Models()
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.IndexFunc(models, func(m *schema.Schema) bool {
+		return m.Table == table
+	})
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTable, table)
+	}
+
+	return models[i], nil
+}
+
+// ensureImportPartitions creates the partitions of the seasons of rows.
+func (db *Database) ensureImportPartitions(
+	ctx context.Context,
+	table string,
+	rows []map[string]any,
+) error {
+	seasons := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		switch season := row["season"].(type) {
+		case int64:
+			seasons = append(seasons, season)
+		case float64:
+			seasons = append(seasons, int64(season))
+		}
+	}
+
+	return db.ensurePartitions(ctx, table, seasons)
+}
+
+// advanceSequence moves the sequence of an auto-increment column past the
+// largest value stored, which rows inserted with their IDs leave behind.
+// Only PostgreSQL needs it; MySQL and SQLite advance on their own.
+func (db *Database) advanceSequence(
+	ctx context.Context,
+	table string,
+	column string,
+) error {
+	if db.Dialector.Name() != DriverPostgres {
+		return nil
+	}
+
+	session := db.WithContext(ctx)
+	var sequence *string
+	err := session.Raw("SELECT pg_get_serial_sequence(?, ?)", table, column).
+		Scan(&sequence).Error
+	if err != nil {
+		return fmt.Errorf("could not find %s sequence; %w", table, err)
+	}
+	if sequence == nil {
+		return nil
+	}
+
+	err = session.Exec(fmt.Sprintf(
+		"SELECT setval(?, COALESCE((SELECT MAX(%s) FROM %s), 0) + 1, false)",
+		column, table,
+	), *sequence).Error
+	if err != nil {
+		return fmt.Errorf("could not advance %s sequence; %w", table, err)
+	}
+
+	return nil
+}
+
+// importValue converts a value decoded from JSON to the type of its field.
+func importValue(field *schema.Field, value any) (any, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case json.Number:
+		switch field.DataType {
+		case schema.Int, schema.Uint:
+			return v.Int64()
+		case schema.Float:
+			return v.Float64()
+		}
+		value = v.String()
+	case map[string]any, []any:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		value = string(raw)
+	case string:
+		if field.DataType == schema.Time {
+			return parseImportTime(v)
+		}
+	}
+
+	// Columns of types that scan themselves, such as arrays and JSON
+	// payloads, are scanned as they would be from the database.
+	scanner, ok := reflect.New(field.IndirectFieldType).Interface().(sql.Scanner)
+	if !ok {
+		return value, nil
+	}
+	if err := scanner.Scan(value); err != nil {
+		return nil, err
+	}
+
+	return scanner, nil
+}
+
+// parseImportTime parses a timestamp in any of importTimeLayouts.
+func parseImportTime(value string) (time.Time, error) {
+	for _, layout := range importTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+}
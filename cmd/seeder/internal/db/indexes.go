@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// queryIndex is a composite index serving a common query path, such as a
+// season's games by week, that the single-column indexes of the models do
+// not cover.
+type queryIndex struct {
+	table   string
+	columns []string
+}
+
+// name returns the index name, following GORM's idx_<table>_<column>.
+func (i queryIndex) name() string {
+	return "idx_" + i.table + "_" + strings.Join(i.columns, "_")
+}
+
+// queryIndexes lists the indexes CreateQueryIndexes creates.
+var queryIndexes = []queryIndex{
+	{table: (Game{}).TableName(), columns: []string{"season", "week"}},
+	{table: (Drive{}).TableName(), columns: []string{"game_id", "drive_number"}},
+	{table: (Play{}).TableName(), columns: []string{"game_id", "play_number"}},
+	{table: (PlayStat{}).TableName(), columns: []string{"athlete_id", "season"}},
+	{table: (PlayerStat{}).TableName(), columns: []string{"season", "team"}},
+	{table: (TeamStat{}).TableName(), columns: []string{"season", "team"}},
+	{table: (BettingGame{}).TableName(), columns: []string{"season", "week"}},
+	{table: (Recruit{}).TableName(), columns: []string{"year", "committed_to"}},
+}
+
+// CreateQueryIndexes creates the indexes for common query paths that do not
+// exist yet. They are not part of the migrated schema, so loads that want
+// as few indexes as possible to maintain can leave them out.
+func (db *Database) CreateQueryIndexes(ctx context.Context) error {
+	// Deferred indexes are built by Finalize once the load is done.
+	deferred, err := db.deferredIndexNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, index := range queryIndexes {
+		if deferred[index.name()] {
+			continue
+		}
+
+		if db.WithContext(ctx).Migrator().HasIndex(index.table, index.name()) {
+			continue
+		}
+
+		err = db.WithContext(ctx).Exec(fmt.Sprintf(
+			"CREATE INDEX %s ON %s (%s)",
+			index.name(), index.table, strings.Join(index.columns, ", "),
+		)).Error
+		if err != nil {
+			slog.Error("could not create index",
+				"index", index.name(), "err", err.Error())
+			return fmt.Errorf("could not create index %s; %w", index.name(), err)
+		}
+		slog.Info("created query index",
+			"index", index.name(), "table", index.table)
+	}
+
+	return nil
+}
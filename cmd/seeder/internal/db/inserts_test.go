@@ -0,0 +1,974 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-go/cfbd"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// capturedAt is the capture time of the snapshot fixtures.
+var capturedAt = time.Date(2024, time.August, 31, 12, 0, 0, 0, time.UTC)
+
+// kickoff is the start time of the game fixtures.
+var kickoff = timestamppb.New(
+	time.Date(2024, time.August, 31, 19, 30, 0, 0, time.UTC),
+)
+
+// insertCase is an Insert function called with fixture data.
+type insertCase struct {
+	// Name is the name of the Insert function.
+	Name string
+	// Rows is the number of rows the first call writes to each table.
+	Rows map[string]int64
+	// Again is the number of rows a second call writes to each table, which
+	// is none for upserts.
+	Again map[string]int64
+	// Insert calls the function.
+	Insert func(ctx context.Context, database *db.Database) error
+}
+
+// insertCases are the cases checkInserts runs, in order, so that the parent
+// rows of each case are written before it.
+var insertCases = []insertCase{
+	{
+		Name: "InsertConferences",
+		Rows: map[string]int64{"conferences": 2},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertConferences(ctx, []*cfbd.Conference{
+				{Id: 1, Name: "SEC", ShortName: "SEC", Abbreviation: "SEC",
+					Classification: "fbs"},
+				{Id: 2, Name: "Big Ten", ShortName: "Big Ten",
+					Abbreviation: "B1G", Classification: "fbs"},
+				// Conferences without an id are skipped.
+				{Name: "Unknown"},
+			})
+		},
+	},
+	{
+		Name: "InsertVenues",
+		Rows: map[string]int64{"venues": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertVenues(ctx, []*cfbd.Venue{{
+				Id:               proto.Int32(3657),
+				Name:             "Sanford Stadium",
+				City:             "Athens",
+				State:            "GA",
+				Zip:              "30602",
+				CountryCode:      "US",
+				Timezone:         "America/New_York",
+				Latitude:         proto.Float64(33.9497),
+				Longitude:        proto.Float64(-83.3733),
+				Capacity:         proto.Int32(92746),
+				ConstructionYear: proto.Int32(1929),
+				Grass:            proto.Bool(true),
+				Dome:             proto.Bool(false),
+			}})
+		},
+	},
+	{
+		Name: "InsertPlayTypes",
+		Rows: map[string]int64{"play_types": 2},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertPlayTypes(ctx, []*cfbd.PlayType{
+				{Id: 5, Text: "Rush", Abbreviation: "RUSH"},
+				{Id: 24, Text: "Pass Reception", Abbreviation: "REC"},
+			})
+		},
+	},
+	{
+		Name: "InsertPlayStatTypes",
+		Rows: map[string]int64{"play_stat_types": 2},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			// Names are trimmed and deduplicated.
+			return database.InsertPlayStatTypes(ctx, []string{
+				"Rush", " Rush ", "Reception", "",
+			})
+		},
+	},
+	{
+		Name: "InsertDraftTeams",
+		Rows: map[string]int64{"draft_teams": 1},
+		// Draft teams have no key besides their generated id, so they are
+		// written again.
+		Again: map[string]int64{"draft_teams": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertDraftTeams(ctx, []*cfbd.DraftTeam{
+				{Location: "Atlanta", Nickname: "Falcons",
+					DisplayName: "Atlanta Falcons"},
+				{Nickname: "Nowhere"},
+			})
+		},
+	},
+	{
+		Name: "InsertDraftPositions",
+		Rows: map[string]int64{"draft_positions": 1},
+		// As for draft teams.
+		Again: map[string]int64{"draft_positions": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertDraftPositions(ctx, []*cfbd.DraftPosition{
+				{Name: "Quarterback", Abbreviation: "QB"},
+				{},
+			})
+		},
+	},
+	{
+		Name: "InsertFieldGoalEP",
+		Rows: map[string]int64{"field_goal_ep": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertFieldGoalEP(ctx, []*cfbd.FieldGoalEP{
+				{YardsToGoal: 20, Distance: 37, ExpectedPoints: 2.1},
+			})
+		},
+	},
+	{
+		Name: "InsertTeams",
+		Rows: map[string]int64{"teams": 2},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertTeams(ctx, []*cfbd.Team{
+				{Id: 61, School: "Georgia", Mascot: "Bulldogs",
+					Abbreviation: "UGA", Conference: "SEC",
+					Classification: "fbs", Color: "#ba0c2f",
+					Logos:    []string{"https://a.espncdn.com/61.png"},
+					Location: &cfbd.Venue{Id: proto.Int32(3657)}},
+				{Id: 333, School: "Alabama", Mascot: "Crimson Tide",
+					Abbreviation: "ALA", Conference: "SEC",
+					Classification: "fbs"},
+			})
+		},
+	},
+	{
+		Name: "InsertRosterPlayers",
+		Rows: map[string]int64{"roster_players": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertRosterPlayers(ctx, []*cfbd.RosterPlayer{
+				{Id: "4432577", FirstName: "Carson", LastName: "Beck",
+					Team: "Georgia", Height: proto.Float64(76),
+					Weight: proto.Int32(220), Jersey: proto.Int32(15),
+					Year: proto.Int32(5), Position: "QB",
+					HomeCity: "Jacksonville", HomeState: "FL",
+					HomeCountry: "USA", HomeCounty_FIPS: "12031"},
+				{FirstName: "Unknown"},
+			})
+		},
+	},
+	{
+		Name: "InsertCalendarWeeks",
+		Rows: map[string]int64{"calendar_weeks": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertCalendarWeeks(ctx, []*cfbd.CalendarWeek{
+				{Season: 2024, Week: 1, SeasonType: "regular",
+					StartDate: kickoff, EndDate: kickoff},
+				{Season: 2024, SeasonType: "regular"},
+			})
+		},
+	},
+	{
+		Name: "InsertGames",
+		Rows: map[string]int64{"games": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertGames(ctx, []*cfbd.Game{{
+				Id:                 401628319,
+				Season:             2024,
+				Week:               1,
+				SeasonType:         "regular",
+				StartDate:          kickoff,
+				Completed:          true,
+				NeutralSite:        true,
+				Attendance:         proto.Int32(75000),
+				VenueId:            proto.Int32(3657),
+				Venue:              "Sanford Stadium",
+				HomeId:             proto.Int32(61),
+				HomeTeam:           "Georgia",
+				HomeConference:     "SEC",
+				HomeClassification: "fbs",
+				HomePoints:         proto.Int32(34),
+				HomeLineScores:     []int32{7, 10, 10, 7},
+				AwayId:             proto.Int32(333),
+				AwayTeam:           "Alabama",
+				AwayConference:     "SEC",
+				AwayClassification: "fbs",
+				AwayPoints:         proto.Int32(3),
+				AwayLineScores:     []int32{0, 3, 0, 0},
+			}})
+		},
+	},
+	{
+		Name: "InsertDrives",
+		Rows: map[string]int64{"drives": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertDrives(ctx, 2024, []*cfbd.Drive{{
+				Id:                "4016283191",
+				GameId:            401628319,
+				DriveNumber:       proto.Int32(1),
+				Offense:           "Georgia",
+				OffenseConference: "SEC",
+				Defense:           "Alabama",
+				DefenseConference: "SEC",
+				Scoring:           true,
+				StartPeriod:       1,
+				StartYardline:     25,
+				StartYardsToGoal:  75,
+				StartTime:         &cfbd.ClockInt32{Minutes: proto.Int32(15)},
+				EndPeriod:         1,
+				EndYardsToGoal:    0,
+				Plays:             2,
+				Yards:             75,
+				DriveResult:       "TD",
+				IsHomeOffense:     true,
+				EndOffenseScore:   7,
+			}})
+		},
+	},
+	{
+		Name: "InsertPlays",
+		Rows: map[string]int64{"plays": 2},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			play := func(id string, number int32, yards int32) *cfbd.Play {
+				return &cfbd.Play{
+					Id:          id,
+					DriveId:     "4016283191",
+					GameId:      401628319,
+					DriveNumber: proto.Int32(1),
+					PlayNumber:  proto.Int32(number),
+					Offense:     "Georgia",
+					Defense:     "Alabama",
+					Home:        "Georgia",
+					Away:        "Alabama",
+					Period:      1,
+					Clock: &cfbd.ClockInt32{
+						Minutes: proto.Int32(14),
+						Seconds: proto.Int32(0),
+					},
+					Yardline:    25,
+					YardsToGoal: 75,
+					Down:        1,
+					Distance:    10,
+					YardsGained: yards,
+					PlayType:    "Rush",
+					PlayText:    "Carson Beck run",
+					Ppa:         proto.Float64(0.4),
+				}
+			}
+
+			return database.InsertPlays(ctx, 2024, []*cfbd.Play{
+				play("401628319101", 1, 5),
+				play("401628319102", 2, 70),
+				{PlayText: "no id"},
+			})
+		},
+	},
+	{
+		Name: "InsertPlayStats",
+		Rows: map[string]int64{"play_stats": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			stat := &cfbd.PlayStat{
+				GameId:      401628319,
+				Season:      2024,
+				Week:        1,
+				Team:        "Georgia",
+				Conference:  "SEC",
+				Opponent:    "Alabama",
+				DriveId:     "4016283191",
+				PlayId:      "401628319101",
+				Period:      1,
+				Clock:       &cfbd.ClockDouble{Minutes: proto.Float64(14)},
+				YardsToGoal: 75,
+				Down:        1,
+				Distance:    10,
+				AthleteId:   "4432577",
+				AthleteName: "Carson Beck",
+				StatType:    "Rush",
+				Stat:        5,
+			}
+
+			// The same stat twice is written once.
+			return database.InsertPlayStats(ctx, []*cfbd.PlayStat{stat, stat})
+		},
+	},
+	{
+		Name: "InsertGameWeather",
+		Rows: map[string]int64{"game_weather": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertGameWeather(ctx, gameWeather())
+		},
+	},
+	{
+		Name: "InsertGameWeatherSnapshots",
+		Rows: map[string]int64{"game_weather_snapshots": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertGameWeatherSnapshots(
+				ctx, gameWeather(), "forecast", capturedAt,
+			)
+		},
+	},
+	{
+		Name: "InsertGameMedia",
+		Rows: map[string]int64{"game_media": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertGameMedia(ctx, []*cfbd.GameMedia{{
+				Id:             401628319,
+				Season:         2024,
+				Week:           1,
+				SeasonType:     "regular",
+				StartTime:      kickoff,
+				HomeTeam:       "Georgia",
+				HomeConference: "SEC",
+				AwayTeam:       "Alabama",
+				AwayConference: "SEC",
+				MediaType:      "tv",
+				Outlet:         "ABC",
+			}})
+		},
+	},
+	{
+		Name: "InsertBettingLines",
+		Rows: map[string]int64{"betting_games": 1, "game_lines": 2},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertBettingLines(ctx, bettingGames())
+		},
+	},
+	{
+		Name:  "InsertGameLineSnapshots",
+		Rows:  map[string]int64{"game_line_snapshots": 2},
+		Again: map[string]int64{"game_line_snapshots": 2},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertGameLineSnapshots(
+				ctx, bettingGames(), capturedAt,
+			)
+		},
+	},
+	{
+		Name: "InsertTeamRecords",
+		Rows: map[string]int64{"team_records": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertTeamRecords(ctx, []*cfbd.TeamRecords{{
+				Year:           2024,
+				TeamId:         proto.Int32(61),
+				Team:           "Georgia",
+				Classification: "fbs",
+				Conference:     "SEC",
+				ExpectedWins:   proto.Float64(10.2),
+				Total:          &cfbd.TeamRecord{Games: 1, Wins: 1},
+				HomeGames:      &cfbd.TeamRecord{Games: 1, Wins: 1},
+			}})
+		},
+	},
+	{
+		Name: "InsertTeamTalent",
+		Rows: map[string]int64{"team_talent": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertTeamTalent(ctx, []*cfbd.TeamTalent{
+				{Year: 2024, Team: "Georgia", Talent: 985.6},
+			})
+		},
+	},
+	{
+		Name: "InsertTeamATS",
+		Rows: map[string]int64{"team_ats": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertTeamATS(ctx, []*cfbd.TeamATS{{
+				Year:           2024,
+				TeamId:         61,
+				Team:           "Georgia",
+				Conference:     "SEC",
+				Games:          proto.Int32(1),
+				AtsWins:        1,
+				AvgCoverMargin: proto.Float64(17.5),
+			}})
+		},
+	},
+	{
+		Name: "InsertTeamSP",
+		Rows: map[string]int64{"team_sp": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertTeamSP(ctx, []*cfbd.TeamSP{{
+				Year:       2024,
+				Team:       "Georgia",
+				Conference: "SEC",
+				Rating:     proto.Float64(24.5),
+				Ranking:    proto.Int32(3),
+			}})
+		},
+	},
+	{
+		Name: "InsertConferenceSP",
+		Rows: map[string]int64{"conference_sp": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertConferenceSP(ctx, []*cfbd.ConferenceSP{
+				{Year: 2024, Conference: "SEC", Rating: 12.3,
+					SecondOrderWins: 0.1},
+			})
+		},
+	},
+	{
+		Name: "InsertTeamSRS",
+		Rows: map[string]int64{"team_srs": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertTeamSRS(ctx, []*cfbd.TeamSRS{
+				{Year: 2024, Team: "Georgia", Conference: "SEC",
+					Rating: 21.4, Ranking: proto.Int32(4)},
+			})
+		},
+	},
+	{
+		Name: "InsertTeamElo",
+		Rows: map[string]int64{"team_elo": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertTeamElo(ctx, []*cfbd.TeamElo{
+				{Year: 2024, Team: "Georgia", Conference: "SEC",
+					Elo: proto.Int32(2050)},
+			})
+		},
+	},
+	{
+		Name: "InsertTeamFPI",
+		Rows: map[string]int64{"team_fpi": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertTeamFPI(ctx, []*cfbd.TeamFPI{
+				{Year: 2024, Team: "Georgia", Conference: "SEC",
+					Fpi: proto.Float64(22.8)},
+			})
+		},
+	},
+	{
+		Name: "InsertAdjustedTeamMetrics",
+		Rows: map[string]int64{"adjusted_team_metrics": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertAdjustedTeamMetrics(ctx,
+				[]*cfbd.AdjustedTeamMetrics{{
+					Year:                 2024,
+					TeamId:               61,
+					Team:                 "Georgia",
+					Conference:           "SEC",
+					Explosiveness:        1.2,
+					ExplosivenessAllowed: 0.9,
+				}})
+		},
+	},
+	{
+		Name: "InsertPlayerWeightedEPA",
+		Rows: map[string]int64{"player_weighted_epa": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertPlayerWeightedEPA(ctx,
+				[]*cfbd.PlayerWeightedEPA{{
+					Year:        2024,
+					AthleteId:   "4432577",
+					AthleteName: "Carson Beck",
+					Position:    "QB",
+					Team:        "Georgia",
+					Conference:  "SEC",
+					Wepa:        0.35,
+					Plays:       40,
+				}})
+		},
+	},
+	{
+		Name: "InsertKickerPAAR",
+		Rows: map[string]int64{"kicker_paar": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertKickerPAAR(ctx, []*cfbd.KickerPAAR{{
+				Year:        2024,
+				AthleteId:   "4686042",
+				AthleteName: "Peyton Woodring",
+				Team:        "Georgia",
+				Conference:  "SEC",
+				Paar:        1.4,
+				Attempts:    2,
+			}})
+		},
+	},
+	{
+		Name: "InsertReturningProduction",
+		Rows: map[string]int64{"returning_production": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertReturningProduction(ctx,
+				[]*cfbd.ReturningProduction{{
+					Season:      2024,
+					Team:        "Georgia",
+					Conference:  "SEC",
+					Total_PPA:   420.5,
+					Percent_PPA: 0.61,
+					Usage:       0.58,
+				}})
+		},
+	},
+	{
+		Name: "InsertPlayerTransfers",
+		Rows: map[string]int64{"player_transfers": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertPlayerTransfers(ctx,
+				[]*cfbd.PlayerTransfer{{
+					Season:       2024,
+					FirstName:    "Colbie",
+					LastName:     "Young",
+					Position:     "WR",
+					Origin:       "Miami",
+					Destination:  "Georgia",
+					TransferDate: kickoff,
+					Rating:       proto.Float64(0.89),
+					Stars:        proto.Int32(4),
+					Eligibility:  "Immediate",
+				}})
+		},
+	},
+	{
+		Name: "InsertPlayerStats",
+		Rows: map[string]int64{"player_stats": 1},
+		// Stats are upserted on their generated id, so they are written
+		// again.
+		Again: map[string]int64{"player_stats": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertPlayerStats(ctx, []*cfbd.PlayerStat{{
+				Season:     2024,
+				PlayerId:   "4432577",
+				Player:     "Carson Beck",
+				Position:   "QB",
+				Team:       "Georgia",
+				Conference: "SEC",
+				Category:   "passing",
+				StatType:   "YDS",
+				Stat:       "278",
+			}})
+		},
+	},
+	{
+		Name: "InsertTeamStats",
+		Rows: map[string]int64{"team_stats": 1},
+		// As for player stats.
+		Again: map[string]int64{"team_stats": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertTeamStats(ctx, []*cfbd.TeamStat{{
+				Season:     2024,
+				Team:       "Georgia",
+				Conference: "SEC",
+				StatName:   "totalYards",
+				StatValue:  structpb.NewNumberValue(412),
+			}})
+		},
+	},
+	{
+		Name: "InsertRankings",
+		Rows: map[string]int64{"poll_weeks": 1, "polls": 1, "poll_ranks": 2},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertRankings(ctx, []*cfbd.PollWeek{{
+				Season:     2024,
+				SeasonType: "regular",
+				Week:       1,
+				Polls: []*cfbd.Poll{{
+					Poll: "AP Top 25",
+					Ranks: []*cfbd.PollRank{
+						{Rank: proto.Int32(1), TeamId: proto.Int32(61),
+							School: "Georgia", Conference: "SEC",
+							FirstPlaceVotes: proto.Int32(60),
+							Points:          proto.Int32(1550)},
+						{Rank: proto.Int32(4), TeamId: proto.Int32(333),
+							School: "Alabama", Conference: "SEC",
+							Points: proto.Int32(1300)},
+					},
+				}},
+			}})
+		},
+	},
+	{
+		Name: "InsertRecruits",
+		Rows: map[string]int64{"recruits": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertRecruits(ctx, []*cfbd.Recruit{{
+				Id:            "95140",
+				AthleteId:     "5081375",
+				RecruitType:   "HighSchool",
+				Year:          2024,
+				Ranking:       proto.Int32(12),
+				Name:          "Ellis Robinson IV",
+				School:        "IMG Academy",
+				CommittedTo:   "Georgia",
+				Position:      "CB",
+				Height:        proto.Float64(73),
+				Weight:        proto.Int32(180),
+				Stars:         5,
+				Rating:        0.9934,
+				City:          "Bradenton",
+				StateProvince: "FL",
+				Country:       "USA",
+			}})
+		},
+	},
+	{
+		Name: "InsertTeamRecruitingRankings",
+		Rows: map[string]int64{"team_recruiting_rankings": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertTeamRecruitingRankings(ctx,
+				[]*cfbd.TeamRecruitingRanking{
+					{Year: 2024, Rank: 2, Team: "Georgia", Points: 318.2},
+				})
+		},
+	},
+	{
+		Name: "InsertAggregatedTeamRecruiting",
+		Rows: map[string]int64{"aggregated_team_recruiting": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertAggregatedTeamRecruiting(ctx,
+				[]*cfbd.AggregatedTeamRecruiting{{
+					Team:          "Georgia",
+					Conference:    "SEC",
+					PositionGroup: "Quarterback",
+					AverageRating: 0.93,
+					TotalRating:   4.65,
+					Commits:       5,
+					AverageStars:  4.2,
+				}})
+		},
+	},
+	{
+		Name: "InsertDraftPicks",
+		Rows: map[string]int64{"draft_picks": 1},
+		// As for player stats.
+		Again: map[string]int64{"draft_picks": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertDraftPicks(ctx, []*cfbd.DraftPick{{
+				CollegeAthleteId:  proto.Int32(4362081),
+				NflAthleteId:      proto.Int32(4362081),
+				CollegeId:         61,
+				CollegeTeam:       "Georgia",
+				CollegeConference: "SEC",
+				NflTeamId:         1,
+				NflTeam:           "Atlanta",
+				Year:              2024,
+				Overall:           8,
+				Round:             1,
+				Pick:              8,
+				Name:              "Brock Bowers",
+				Position:          "Tight End",
+			}})
+		},
+	},
+	{
+		Name: "InsertGameTeamStats",
+		Rows: map[string]int64{
+			"game_team_stats":            1,
+			"game_team_stats_teams":      1,
+			"game_team_stats_team_stats": 2,
+		},
+		// Games already written are kept, but their teams and stats have
+		// generated ids and are written again.
+		Again: map[string]int64{
+			"game_team_stats_teams":      1,
+			"game_team_stats_team_stats": 2,
+		},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertGameTeamStats(ctx, []*cfbd.GameTeamStats{{
+				Id: 401628319,
+				Teams: []*cfbd.GameTeamStatsTeam{{
+					TeamId:     61,
+					Team:       "Georgia",
+					Conference: "SEC",
+					HomeAway:   "home",
+					Points:     proto.Int32(34),
+					Stats: []*cfbd.GameTeamStatsTeamStat{
+						{Category: "totalYards", Stat: "412"},
+						{Category: "turnovers", Stat: "0"},
+					},
+				}},
+			}})
+		},
+	},
+	{
+		Name: "InsertGamePlayerStats",
+		Rows: map[string]int64{
+			"game_player_stats":           1,
+			"game_player_stats_teams":     1,
+			"game_player_stat_categories": 1,
+			"game_player_stat_types":      1,
+			"game_player_stat_players":    1,
+		},
+		// As for game team stats.
+		Again: map[string]int64{
+			"game_player_stats_teams":     1,
+			"game_player_stat_categories": 1,
+			"game_player_stat_types":      1,
+			"game_player_stat_players":    1,
+		},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertGamePlayerStats(ctx,
+				[]*cfbd.GamePlayerStats{{
+					Id: 401628319,
+					Teams: []*cfbd.GamePlayerStatsTeam{{
+						Team:       "Georgia",
+						Conference: "SEC",
+						HomeAway:   "home",
+						Points:     proto.Int32(34),
+						Categories: []*cfbd.GamePlayerStatCategories{{
+							Name: "passing",
+							Types: []*cfbd.GamePlayerStatTypes{{
+								Name: "YDS",
+								Athletes: []*cfbd.GamePlayerStatPlayer{
+									{Id: "4432577", Name: "Carson Beck",
+										Stat: "278"},
+								},
+							}},
+						}},
+					}},
+				}})
+		},
+	},
+	{
+		Name: "InsertPlayWinProbability",
+		Rows: map[string]int64{"play_win_probability": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertPlayWinProbability(ctx,
+				[]*cfbd.PlayWinProbability{{
+					GameId:             401628319,
+					PlayId:             "401628319101",
+					PlayText:           "Carson Beck run",
+					HomeId:             61,
+					Home:               "Georgia",
+					AwayId:             333,
+					Away:               "Alabama",
+					Spread:             -2.5,
+					HomeBall:           true,
+					YardLine:           25,
+					Down:               1,
+					Distance:           10,
+					HomeWinProbability: 0.6,
+					PlayNumber:         1,
+				}})
+		},
+	},
+	{
+		Name: "InsertAdvancedBoxScores",
+		Rows: map[string]int64{"advanced_box_scores": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertAdvancedBoxScores(ctx,
+				map[int32]*cfbd.AdvancedBoxScore{
+					401628319: {GameInfo: &cfbd.AdvancedBoxScoreGameInfo{
+						HomeTeam:    "Georgia",
+						HomePoints:  34,
+						HomeWinner:  true,
+						HomeWinProb: 0.98,
+						AwayTeam:    "Alabama",
+						AwayPoints:  3,
+						AwayWinProb: 0.02,
+						Excitement:  3.1,
+					}},
+				})
+		},
+	},
+	{
+		Name: "InsertScoreboard",
+		Rows: map[string]int64{"scoreboard": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			team := func(name string, points float64) *structpb.Struct {
+				return &structpb.Struct{Fields: map[string]*structpb.Value{
+					"name":   structpb.NewStringValue(name),
+					"points": structpb.NewNumberValue(points),
+				}}
+			}
+
+			return database.InsertScoreboard(ctx, []*cfbd.Scoreboard{
+				{Id: 401628319, StartDate: kickoff, Tv: "ABC",
+					Status: "completed", Period: proto.Int32(4),
+					HomeTeam: team("Georgia", 34),
+					AwayTeam: team("Alabama", 3)},
+				{Status: "no id"},
+			})
+		},
+	},
+	{
+		Name: "InsertLiveGame",
+		Rows: map[string]int64{
+			"live_games":       1,
+			"live_game_teams":  1,
+			"live_game_drives": 1,
+			"live_game_plays":  1,
+		},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertLiveGame(ctx, &cfbd.LiveGame{
+				Id:          401628319,
+				Status:      "in_progress",
+				Period:      proto.Int32(1),
+				Clock:       "14:00",
+				Possession:  "Georgia",
+				Down:        proto.Int32(2),
+				Distance:    proto.Int32(5),
+				YardsToGoal: proto.Int32(70),
+				Teams: []*cfbd.LiveGameTeam{{
+					TeamId:     61,
+					Team:       "Georgia",
+					HomeAway:   "home",
+					LineScores: []int32{0},
+					Plays:      1,
+				}},
+				Drives: []*cfbd.LiveGameDrive{{
+					Id:        "4016283191",
+					OffenseId: 61,
+					Offense:   "Georgia",
+					DefenseId: 333,
+					Defense:   "Alabama",
+					PlayCount: 1,
+					Yards:     5,
+					Plays: []*cfbd.LiveGamePlay{{
+						Id:          "401628319101",
+						Period:      1,
+						Clock:       "14:00",
+						WallClock:   kickoff,
+						TeamId:      61,
+						Team:        "Georgia",
+						Down:        1,
+						Distance:    10,
+						YardsToGoal: 75,
+						YardsGained: 5,
+						PlayTypeId:  5,
+						PlayType:    "Rush",
+						Epa:         proto.Float64(0.4),
+						Success:     true,
+						RushPass:    "rush",
+						PlayText:    "Carson Beck run",
+					}},
+				}},
+			})
+		},
+	},
+	{
+		Name:  "InsertUserInfo",
+		Rows:  map[string]int64{"user_info": 1},
+		Again: map[string]int64{"user_info": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertUserInfo(ctx, &cfbd.UserInfo{
+				PatronLevel:    1,
+				RemainingCalls: 4999,
+			}, "seed")
+		},
+	},
+	{
+		Name:  "InsertVerificationDiffs",
+		Rows:  map[string]int64{"verification_diffs": 1},
+		Again: map[string]int64{"verification_diffs": 1},
+		Insert: func(ctx context.Context, database *db.Database) error {
+			return database.InsertVerificationDiffs(ctx, []db.VerificationDiff{{
+				Season:        2024,
+				Week:          1,
+				SeasonType:    "regular",
+				Entity:        "game",
+				EntityID:      401628319,
+				Field:         "home_points",
+				StoredValue:   "31",
+				UpstreamValue: "34",
+				DetectedAt:    capturedAt,
+			}})
+		},
+	},
+}
+
+// gameWeather returns the weather fixture of the game fixture.
+func gameWeather() []*cfbd.GameWeather {
+	return []*cfbd.GameWeather{{
+		Id:             401628319,
+		Season:         2024,
+		Week:           1,
+		SeasonType:     "regular",
+		StartTime:      kickoff,
+		HomeTeam:       "Georgia",
+		HomeConference: "SEC",
+		AwayTeam:       "Alabama",
+		AwayConference: "SEC",
+		VenueId:        proto.Int32(3657),
+		Venue:          "Sanford Stadium",
+		Temperature:    proto.Float64(88),
+		Humidity:       proto.Float64(60),
+		WindSpeed:      proto.Float64(5),
+	}}
+}
+
+// bettingGames returns the betting lines fixture of the game fixture.
+func bettingGames() []*cfbd.BettingGame {
+	return []*cfbd.BettingGame{{
+		Id:             401628319,
+		Season:         2024,
+		SeasonType:     "regular",
+		Week:           1,
+		StartDate:      kickoff,
+		HomeTeamId:     61,
+		HomeTeam:       "Georgia",
+		HomeConference: "SEC",
+		HomeScore:      proto.Int32(34),
+		AwayTeamId:     333,
+		AwayTeam:       "Alabama",
+		AwayConference: "SEC",
+		AwayScore:      proto.Int32(3),
+		Lines: []*cfbd.GameLine{
+			{Provider: "DraftKings", Spread: proto.Float64(-2.5),
+				FormattedSpread: "Georgia -2.5",
+				OverUnder:       proto.Float64(49.5)},
+			{Provider: "ESPN Bet", Spread: proto.Float64(-3),
+				FormattedSpread: "Georgia -3",
+				OverUnder:       proto.Float64(50)},
+		},
+	}}
+}
+
+// checkInserts runs every insertCase against database, which must have an
+// initialized and otherwise empty schema. Each case is called twice, and
+// each call must write exactly its Rows, then its Again, to every table.
+func checkInserts(t *testing.T, database *db.Database) {
+	t.Helper()
+
+	tables, err := database.Tables()
+	if err != nil {
+		t.Fatalf("could not list tables; %v", err)
+	}
+
+	for _, tc := range insertCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+
+			before := countRows(t, database, tables)
+			if err := tc.Insert(ctx, database); err != nil {
+				t.Fatalf("first call failed; %v", err)
+			}
+			first := countRows(t, database, tables)
+			if err := tc.Insert(ctx, database); err != nil {
+				t.Fatalf("second call failed; %v", err)
+			}
+			second := countRows(t, database, tables)
+
+			for _, table := range tables {
+				if got, want := first[table]-before[table],
+					tc.Rows[table]; got != want {
+					t.Errorf("first call wrote %d rows to %s, want %d",
+						got, table, want)
+				}
+				if got, want := second[table]-first[table],
+					tc.Again[table]; got != want {
+					t.Errorf("second call wrote %d rows to %s, want %d",
+						got, table, want)
+				}
+			}
+		})
+	}
+}
+
+// countRows returns the number of rows in each of tables.
+func countRows(
+	t *testing.T,
+	database *db.Database,
+	tables []string,
+) map[string]int64 {
+	t.Helper()
+
+	counts := make(map[string]int64, len(tables))
+	for _, table := range tables {
+		var n int64
+		if err := database.Table(table).Count(&n).Error; err != nil {
+			t.Fatalf("could not count %s; %v", table, err)
+		}
+		counts[table] = n
+	}
+
+	return counts
+}
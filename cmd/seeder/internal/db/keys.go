@@ -0,0 +1,110 @@
+package db
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// naturalKey is the unique index over the columns identifying a row of a
+// table with an auto-increment ID, which inserts into the table upsert
+// against so that re-seeding updates rows instead of duplicating them.
+type naturalKey struct {
+	model   any
+	table   string
+	index   string
+	columns []string
+	// parent is the table the rows belong to, if any, and parentColumn the
+	// column referencing it.
+	parent       string
+	parentColumn string
+}
+
+// naturalKeys lists the natural keys of the auto-increment tables, parents
+// before their children.
+var naturalKeys = []naturalKey{
+	{
+		model:   &PollWeek{},
+		table:   (PollWeek{}).TableName(),
+		index:   "idx_poll_weeks_natural",
+		columns: []string{"season", "season_type", "week"},
+	},
+	{
+		model:        &Poll{},
+		table:        (Poll{}).TableName(),
+		index:        "idx_polls_natural",
+		columns:      []string{"poll_week_id", "poll"},
+		parent:       (PollWeek{}).TableName(),
+		parentColumn: "poll_week_id",
+	},
+	{
+		model:        &PollRank{},
+		table:        (PollRank{}).TableName(),
+		index:        "idx_poll_ranks_natural",
+		columns:      []string{"poll_id", "school"},
+		parent:       (Poll{}).TableName(),
+		parentColumn: "poll_id",
+	},
+	{
+		model:   &PlayStat{},
+		table:   (PlayStat{}).TableName(),
+		index:   "idx_play_stats_natural",
+		columns: []string{"play_id", "athlete_id", "stat_type", "season"},
+	},
+}
+
+// dedupeNaturalKeys removes the duplicate rows earlier runs left in tables
+// whose natural key index does not exist yet, so that the later migrations
+// can create it. The most recently written row of each key is kept, and rows of a
+// child table whose parent was removed go with it.
+func dedupeNaturalKeys(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	for _, key := range naturalKeys {
+		if !migrator.HasTable(key.model) ||
+			migrator.HasIndex(key.model, key.index) {
+			continue
+		}
+
+		if key.parent != "" {
+			orphans := tx.Exec(fmt.Sprintf(`
+				DELETE FROM %[1]s
+				WHERE NOT EXISTS (
+					SELECT 1 FROM %[2]s p WHERE p.id = %[1]s.%[3]s
+				)`, key.table, key.parent, key.parentColumn,
+			))
+			if orphans.Error != nil {
+				return fmt.Errorf("could not remove orphaned %s; %w",
+					key.table, orphans.Error)
+			}
+			if orphans.RowsAffected > 0 {
+				slog.Info("removed orphaned rows",
+					"table", key.table, "rows", orphans.RowsAffected)
+			}
+		}
+
+		dupes := tx.Exec(fmt.Sprintf(`
+			DELETE FROM %[1]s
+			WHERE id IN (
+				SELECT id FROM (
+					SELECT id, row_number() OVER (
+						PARTITION BY %[2]s ORDER BY id DESC
+					) AS n
+					FROM %[1]s
+				) ranked
+				WHERE n > 1
+			)`, key.table, strings.Join(key.columns, ", "),
+		))
+		if dupes.Error != nil {
+			return fmt.Errorf("could not remove duplicate %s; %w",
+				key.table, dupes.Error)
+		}
+		if dupes.RowsAffected > 0 {
+			slog.Info("removed duplicate rows",
+				"table", key.table, "rows", dupes.RowsAffected)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+)
+
+// TableUpdate is the payload of the NOTIFY announcing rows written to a
+// table: how many were written for a season and week.
+type TableUpdate struct {
+	Table string `json:"table"`
+	// Season is the season (or year) of the rows, if the table has one.
+	Season *int32 `json:"season,omitempty"`
+	// Week is the week of the rows, if the table has one.
+	Week *int32 `json:"week,omitempty"`
+	Rows int64  `json:"rows"`
+}
+
+// updateKey identifies the rows a TableUpdate counts.
+type updateKey struct {
+	table  string
+	season int32
+	week   int32
+}
+
+// pendingUpdates tallies the rows written by each running task until it is
+// done.
+type pendingUpdates struct {
+	mu     sync.Mutex
+	byTask map[string]map[updateKey]*TableUpdate
+}
+
+// UpdateChannel returns the channel NotifyUpdates announces the rows written
+// to table on, named for the database's schema, e.g. "cfbd_games_updated",
+// so that datasets sharing a database are announced apart.
+func (db *Database) UpdateChannel(table string) string {
+	return db.schema + "_" + table + "_updated"
+}
+
+// NotifyUpdates makes the rows each task writes be announced once the task
+// is done (see FlushUpdates), with a NOTIFY on the UpdateChannel of every
+// table it wrote and a TableUpdate payload per season and week, so that
+// dashboards can LISTEN and refresh without polling. Rows written outside a
+// task, e.g. by watch mode, are announced as soon as they are written. It
+// requires PostgreSQL.
+func (db *Database) NotifyUpdates() error {
+	if err := db.requirePostgres("update notifications"); err != nil {
+		return err
+	}
+
+	db.updates = &pendingUpdates{
+		byTask: make(map[string]map[updateKey]*TableUpdate),
+	}
+
+	return db.onRowChanges("cfbd:notify_updates", nil, db.tallyUpdates)
+}
+
+// tallyUpdates counts written rows against the task running under ctx, or
+// announces them right away outside a task.
+func (db *Database) tallyUpdates(ctx context.Context, changes []RowChange) {
+	task, ok := etl.TaskName(ctx)
+	if !ok {
+		updates := make(map[updateKey]*TableUpdate)
+		tally(updates, changes)
+		if err := db.notifyUpdates(ctx, updates); err != nil {
+			slog.Warn("could not notify updates", "err", err.Error())
+		}
+		return
+	}
+
+	db.updates.mu.Lock()
+	defer db.updates.mu.Unlock()
+
+	updates, ok := db.updates.byTask[task]
+	if !ok {
+		updates = make(map[updateKey]*TableUpdate)
+		db.updates.byTask[task] = updates
+	}
+	tally(updates, changes)
+}
+
+// FlushUpdates announces the rows the task has written since it started, if
+// NotifyUpdates is enabled.
+func (db *Database) FlushUpdates(ctx context.Context, task string) error {
+	if db.updates == nil {
+		return nil
+	}
+
+	db.updates.mu.Lock()
+	updates := db.updates.byTask[task]
+	delete(db.updates.byTask, task)
+	db.updates.mu.Unlock()
+
+	return db.notifyUpdates(ctx, updates)
+}
+
+// notifyUpdates sends a NOTIFY per update, ordered by table, season and
+// week.
+func (db *Database) notifyUpdates(
+	ctx context.Context,
+	updates map[updateKey]*TableUpdate,
+) error {
+	keys := make([]updateKey, 0, len(updates))
+	for key := range updates {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b updateKey) int {
+		return cmp.Or(
+			cmp.Compare(a.table, b.table),
+			cmp.Compare(a.season, b.season),
+			cmp.Compare(a.week, b.week),
+		)
+	})
+
+	for _, key := range keys {
+		payload, err := json.Marshal(updates[key])
+		if err != nil {
+			return fmt.Errorf("could not encode update; %w", err)
+		}
+		err = db.WithContext(ctx).
+			Exec("SELECT pg_notify(?, ?)",
+				db.UpdateChannel(key.table), string(payload)).
+			Error
+		if err != nil {
+			return fmt.Errorf("could not notify %s; %w",
+				db.UpdateChannel(key.table), err)
+		}
+	}
+
+	return nil
+}
+
+// tally counts each change against the update of its table, season and
+// week.
+func tally(updates map[updateKey]*TableUpdate, changes []RowChange) {
+	for _, change := range changes {
+		key := updateKey{table: change.Table}
+		if change.Season != nil {
+			key.season = *change.Season
+		}
+		if change.Week != nil {
+			key.week = *change.Week
+		}
+
+		update, ok := updates[key]
+		if !ok {
+			update = &TableUpdate{
+				Table:  change.Table,
+				Season: change.Season,
+				Week:   change.Week,
+			}
+			updates[key] = update
+		}
+		update.Rows++
+	}
+}
@@ -0,0 +1,242 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// LockImpact is how disruptive a schema change is to the table it alters.
+type LockImpact int
+
+const (
+	// ImpactNone changes create new tables and lock nothing in use.
+	ImpactNone LockImpact = iota
+	// ImpactBrief changes take a table lock only long enough to update the
+	// catalog.
+	ImpactBrief
+	// ImpactScan changes block writes (or reads and writes) while every row
+	// of the table is read, e.g. to build an index or validate NOT NULL.
+	ImpactScan
+	// ImpactRewrite changes block reads and writes while every row of the
+	// table is rewritten, e.g. to change a column's type.
+	ImpactRewrite
+)
+
+// String returns a short description of the impact.
+func (i LockImpact) String() string {
+	switch i {
+	case ImpactNone:
+		return "none"
+	case ImpactBrief:
+		return "brief"
+	case ImpactScan:
+		return "full scan"
+	case ImpactRewrite:
+		return "full rewrite"
+	default:
+		return "unknown"
+	}
+}
+
+// DDL is a schema change a migration would make.
+type DDL struct {
+	Table string
+	SQL   string
+	// Lock is the PostgreSQL lock mode the statement takes on Table.
+	Lock   string
+	Impact LockImpact
+	// Rows is the planner's estimate of the rows in Table.
+	Rows int64
+}
+
+// ddlTablePattern finds the table a DDL statement applies to.
+var ddlTablePattern = regexp.MustCompile(
+	`(?i)(?:\bTABLE(?: IF NOT EXISTS)?|\bON)\s+` +
+		`((?:"[^"]+"|\w+)(?:\.(?:"[^"]+"|\w+))?)`,
+)
+
+// PlanMigration reports the schema changes the pending migrations would
+// make, without making them, along with the lock each takes and the size of
+// the table it locks. Reads (e.g. of the existing columns) run as normal;
+// only DDL is held back.
+func (db *Database) PlanMigration(ctx context.Context) ([]DDL, error) {
+	pending, err := db.PendingMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.planDDL(ctx, func(planner *gorm.DB) error {
+		for _, m := range pending {
+			if err := m.up(planner); err != nil {
+				return fmt.Errorf("could not plan migration %d %s; %w",
+					m.Version, m.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// SchemaDrift reports the schema changes migrating the models would make to
+// the migrated schema, which are missing from the migrations.
+func (db *Database) SchemaDrift(ctx context.Context) ([]DDL, error) {
+	plan, err := db.planDDL(ctx, func(planner *gorm.DB) error {
+		for _, group := range db.migratedGroups() {
+			if err := planner.AutoMigrate(group.models...); err != nil {
+				return fmt.Errorf("could not plan %s; %w", group.name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Indexes deferred for a bulk load are missing on purpose.
+	deferred, err := db.deferredIndexNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	drift := make([]DDL, 0, len(plan))
+	for _, ddl := range plan {
+		match := createIndexPattern.FindStringSubmatch(ddl.SQL)
+		if match != nil && deferred[match[1]] {
+			continue
+		}
+		drift = append(drift, ddl)
+	}
+
+	return drift, nil
+}
+
+// planDDL runs migrate against a handle that holds back DDL, and returns
+// the DDL it would have run.
+func (db *Database) planDDL(
+	ctx context.Context,
+	migrate func(planner *gorm.DB) error,
+) ([]DDL, error) {
+	conn, err := db.DB.DB()
+	if err != nil {
+		return nil, fmt.Errorf("could not get connection pool; %w", err)
+	}
+
+	// A separate handle on the same pool keeps the planning callback away
+	// from every other statement.
+	planner, err := gorm.Open(
+		db.dialect.reopen(conn),
+		&gorm.Config{
+			Logger:                                   logger.Discard,
+			DisableForeignKeyConstraintWhenMigrating: true,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not open migration planner; %w", err)
+	}
+
+	if err = db.dialect.prepare(planner); err != nil {
+		return nil, err
+	}
+
+	var statements []string
+	err = planner.Callback().Raw().Before("gorm:raw").Register(
+		"cfbd:plan_ddl", func(tx *gorm.DB) {
+			statements = append(statements, tx.Dialector.Explain(
+				tx.Statement.SQL.String(), tx.Statement.Vars...,
+			))
+			tx.DryRun = true
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not register planning callback; %w", err)
+	}
+
+	if err = migrate(planner.WithContext(ctx)); err != nil {
+		return nil, err
+	}
+
+	rows := make(map[string]int64)
+	created := make(map[string]bool)
+	plan := make([]DDL, 0, len(statements))
+	for _, sql := range statements {
+		ddl := classifyDDL(sql)
+		switch {
+		case ddl.Impact == ImpactNone:
+			created[ddl.Table] = true
+		case created[ddl.Table]:
+			// Indexes and comments on a table created by the same
+			// migration have nothing to lock.
+			ddl.Lock, ddl.Impact = "", ImpactNone
+		case ddl.Table != "":
+			count, ok := rows[ddl.Table]
+			if !ok {
+				if count, err = db.estimateRows(ctx, ddl.Table); err != nil {
+					return nil, err
+				}
+				rows[ddl.Table] = count
+			}
+			ddl.Rows = count
+		}
+		plan = append(plan, ddl)
+	}
+
+	return plan, nil
+}
+
+// classifyDDL works out the table, lock and impact of a DDL statement.
+func classifyDDL(sql string) DDL {
+	ddl := DDL{SQL: sql, Lock: "ACCESS EXCLUSIVE", Impact: ImpactBrief}
+	if match := ddlTablePattern.FindStringSubmatch(sql); match != nil {
+		ddl.Table = strings.ReplaceAll(match[1], `"`, "")
+	}
+
+	upper := strings.ToUpper(sql)
+	switch {
+	case strings.HasPrefix(upper, "CREATE TABLE"):
+		ddl.Lock, ddl.Impact = "", ImpactNone
+	case strings.HasPrefix(upper, "CREATE INDEX"),
+		strings.HasPrefix(upper, "CREATE UNIQUE INDEX"):
+		ddl.Lock, ddl.Impact = "SHARE", ImpactScan
+	case strings.HasPrefix(upper, "COMMENT ON"):
+		ddl.Lock = "SHARE UPDATE EXCLUSIVE"
+	case strings.Contains(upper, "FOREIGN KEY"):
+		ddl.Lock, ddl.Impact = "SHARE ROW EXCLUSIVE", ImpactScan
+	case strings.Contains(upper, " TYPE "):
+		ddl.Impact = ImpactRewrite
+	case strings.Contains(upper, "SET NOT NULL"),
+		strings.Contains(upper, "ADD CONSTRAINT"):
+		ddl.Impact = ImpactScan
+	}
+
+	return ddl
+}
+
+// estimateRows returns the planner's row estimate for the table, summed
+// over its partitions if it is partitioned, which is instant where count(*)
+// on a large table is not.
+func (db *Database) estimateRows(
+	ctx context.Context,
+	table string,
+) (int64, error) {
+	rows, err := db.dialect.estimateRows(db.WithContext(ctx), table)
+	if err != nil {
+		return 0, fmt.Errorf("could not estimate rows of %s; %w", table, err)
+	}
+
+	return rows, nil
+}
+
+// RequiresApproval reports whether any change in the plan locks a table that
+// already exists.
+func RequiresApproval(plan []DDL) bool {
+	for _, ddl := range plan {
+		if ddl.Impact != ImpactNone {
+			return true
+		}
+	}
+
+	return false
+}
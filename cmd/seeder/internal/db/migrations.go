@@ -0,0 +1,610 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// noTransaction is the comment that, as the first line of a migration file,
+// runs its statements outside a transaction, as e.g. CREATE INDEX
+// CONCURRENTLY requires.
+const noTransaction = "-- cfbd:no-transaction"
+
+var (
+	// ErrDirtyMigration is returned when an earlier migration failed part way
+	// through, leaving the schema in an unknown state that must be repaired
+	// by hand.
+	ErrDirtyMigration = errors.New("schema is dirty")
+	// ErrIrreversible is returned when rolling back a migration that has no
+	// down migration.
+	ErrIrreversible = errors.New("migration cannot be rolled back")
+	// ErrNoMigration is returned when there is no applied migration to roll
+	// back.
+	ErrNoMigration = errors.New("no migration to roll back")
+	// ErrUnknownMigration is returned when forcing a version no migration
+	// has.
+	ErrUnknownMigration = errors.New("unknown migration version")
+)
+
+// Migration statuses, as reported by MigrationStatus.
+const (
+	MigrationApplied = "applied"
+	MigrationPending = "pending"
+	MigrationDirty   = "dirty"
+	// MigrationMissing migrations are recorded as applied but unknown to
+	// this build, e.g. because a newer seeder applied them.
+	MigrationMissing = "missing"
+)
+
+// MigrationState is the status of a migration in the database.
+type MigrationState struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Status    string     `json:"status"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+// migrationFiles holds the SQL migrations, named
+// <version>_<name>[.<driver>].up.sql and <version>_<name>[.<driver>].down.sql,
+// and the baseline schema of each driver.
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+// migrationFilePattern matches the name of a migration file, which may be
+// for a single driver.
+var migrationFilePattern = regexp.MustCompile(
+	`^(\d+)_(\w+)(?:\.(postgres|mysql|sqlite))?\.(up|down)\.sql$`,
+)
+
+// Migration is a versioned schema change.
+type Migration struct {
+	Version int64
+	Name    string
+	// up applies the migration, and down, if not nil, reverts it.
+	up   func(tx *gorm.DB) error
+	down func(tx *gorm.DB) error
+	// transactional migrations run in a transaction with their bookkeeping.
+	transactional bool
+	// sql migrations are read from the embedded migration files.
+	sql bool
+}
+
+// baseline is the first migration. It creates every table that existed
+// when versioned migrations were introduced, from the SQL frozen for each
+// driver in migrations/baseline; every later schema change is a migration
+// of its own.
+var baseline = Migration{
+	Version:       1,
+	Name:          "baseline",
+	up:            baselineUp,
+	down:          baselineDown,
+	transactional: true,
+}
+
+// baselineUp creates the baseline tables and indexes that do not exist yet,
+// first removing the duplicate rows that would keep the later migrations
+// from creating a natural key index.
+func baselineUp(tx *gorm.DB) error {
+	if err := dedupeNaturalKeys(tx); err != nil {
+		return err
+	}
+
+	return runBaseline(tx, "up")
+}
+
+// baselineDown drops every baseline table, dependents first.
+func baselineDown(tx *gorm.DB) error {
+	return runBaseline(tx, "down")
+}
+
+// runBaseline runs the baseline SQL of the database's driver in the given
+// direction.
+func runBaseline(tx *gorm.DB, direction string) error {
+	name := fmt.Sprintf("migrations/baseline/%s.%s.sql",
+		tx.Dialector.Name(), direction)
+	content, err := migrationFiles.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("could not read baseline; %w", err)
+	}
+
+	payload := payloadTypes[tx.Dialector.Name()]
+	sql := strings.ReplaceAll(string(content), "{{payload}}",
+		payload[compressPayloads.Load()])
+
+	return sqlMigration(sql)(tx)
+}
+
+// payloadTypes is the column type the baseline gives CompressedJSON
+// payloads on each driver, plain and compressed.
+var payloadTypes = map[string]map[bool]string{
+	DriverPostgres: {false: "jsonb", true: "bytea"},
+	DriverMySQL:    {false: "json", true: "longblob"},
+	DriverSQLite:   {false: "text", true: "blob"},
+}
+
+// goMigrations lists the migrations written in Go rather than SQL, for
+// changes that depend on what the database already holds.
+var goMigrations = []Migration{
+	baseline, partitionBySeason, addPlayFlags, addWeatherSource,
+}
+
+// Migrations returns every known migration, in version order.
+func Migrations() ([]Migration, error) {
+	migrations := make(map[int64]*Migration, len(goMigrations))
+	for _, m := range goMigrations {
+		migrations[m.Version] = &m
+	}
+
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("could not read migrations; %w", err)
+	}
+	ups := make(map[int64]map[string]string)
+	downs := make(map[int64]map[string]string)
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration %s; %w", entry.Name(), err)
+		}
+
+		content, err := migrationFiles.ReadFile("migrations/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s; %w", entry.Name(), err)
+		}
+
+		m, ok := migrations[version]
+		if ok && !m.sql {
+			return nil, fmt.Errorf("migration %s reuses the version of %s",
+				entry.Name(), m.Name)
+		}
+		if !ok {
+			m = &Migration{
+				Version: version, Name: match[2], transactional: true, sql: true,
+			}
+			migrations[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf(
+				"migration %d is named both %s and %s", version, m.Name, match[2],
+			)
+		}
+
+		files := ups
+		if match[4] == "up" {
+			if strings.HasPrefix(string(content), noTransaction) {
+				m.transactional = false
+			}
+		} else {
+			files = downs
+		}
+		if files[version] == nil {
+			files[version] = make(map[string]string)
+		}
+		files[version][match[3]] = string(content)
+	}
+	for version, m := range migrations {
+		if files, ok := ups[version]; ok {
+			m.up = driverMigration(files)
+		}
+		if files, ok := downs[version]; ok {
+			m.down = driverMigration(files)
+		}
+	}
+
+	sorted := make([]Migration, 0, len(migrations))
+	for _, m := range migrations {
+		if m.up == nil {
+			return nil, fmt.Errorf("migration %d has no up migration", m.Version)
+		}
+		sorted = append(sorted, *m)
+	}
+	slices.SortFunc(sorted, func(a, b Migration) int {
+		return int(a.Version - b.Version)
+	})
+
+	return sorted, nil
+}
+
+// driverMigration returns a migration step running the SQL file of the
+// database's driver, or else the file for every driver, keyed by "". A
+// migration with neither does nothing on that driver.
+func driverMigration(files map[string]string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		content, ok := files[tx.Dialector.Name()]
+		if !ok {
+			content = files[""]
+		}
+		return sqlMigration(content)(tx)
+	}
+}
+
+// sqlMigration returns a migration step running the statements of a SQL
+// file one at a time.
+func sqlMigration(content string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, statement := range splitStatements(content) {
+			if err := tx.Exec(statement).Error; err != nil {
+				return fmt.Errorf("could not run %q; %w", statement, err)
+			}
+		}
+		return nil
+	}
+}
+
+// splitStatements splits SQL into its statements at the semicolons that end
+// a line, except within dollar-quoted bodies.
+func splitStatements(content string) []string {
+	var (
+		statements []string
+		current    strings.Builder
+		quoted     bool
+	)
+	for line := range strings.Lines(content) {
+		current.WriteString(line)
+		quoted = quoted != (strings.Count(line, "$$")%2 == 1)
+
+		trimmed := strings.TrimSpace(line)
+		if quoted || !strings.HasSuffix(trimmed, ";") {
+			continue
+		}
+		if statement := strings.TrimSpace(current.String()); statement != ";" {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+	if statement := strings.TrimSpace(current.String()); statement != "" &&
+		!isComment(statement) {
+		statements = append(statements, statement)
+	}
+
+	return statements
+}
+
+// isComment reports whether every line of the SQL is a comment or blank.
+func isComment(sql string) bool {
+	for line := range strings.Lines(sql) {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "--") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AppliedMigrations returns the migrations recorded as applied, in version
+// order, or none if the database has never been migrated.
+func (db *Database) AppliedMigrations(
+	ctx context.Context,
+) ([]SchemaMigration, error) {
+	session := db.WithContext(ctx)
+	if !session.Migrator().HasTable(&SchemaMigration{}) {
+		return nil, nil
+	}
+
+	var applied []SchemaMigration
+	if err := session.Order("version").Find(&applied).Error; err != nil {
+		return nil, fmt.Errorf("could not list applied migrations; %w", err)
+	}
+
+	return applied, nil
+}
+
+// PendingMigrations returns the known migrations not yet applied, in the
+// order they will be.
+func (db *Database) PendingMigrations(
+	ctx context.Context,
+) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := db.AppliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(map[int64]bool, len(applied))
+	for _, m := range applied {
+		done[m.Version] = true
+	}
+
+	pending := make([]Migration, 0, len(migrations))
+	for _, m := range migrations {
+		if !done[m.Version] {
+			pending = append(pending, m)
+		}
+	}
+
+	return pending, nil
+}
+
+// MigrationStatus returns the status of every known migration, and of any
+// applied migration this build does not know, in version order.
+func (db *Database) MigrationStatus(
+	ctx context.Context,
+) ([]MigrationState, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := db.AppliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[int64]MigrationState, len(migrations)+len(applied))
+	for _, m := range migrations {
+		states[m.Version] = MigrationState{
+			Version: m.Version,
+			Name:    m.Name,
+			Status:  MigrationPending,
+		}
+	}
+	for _, record := range applied {
+		state, ok := states[record.Version]
+		switch {
+		case record.Dirty:
+			state.Status = MigrationDirty
+		case ok:
+			state.Status = MigrationApplied
+		default:
+			state.Status = MigrationMissing
+		}
+		at := record.AppliedAt
+		state.Version, state.Name = record.Version, record.Name
+		state.AppliedAt = &at
+		states[record.Version] = state
+	}
+
+	sorted := make([]MigrationState, 0, len(states))
+	for _, state := range states {
+		sorted = append(sorted, state)
+	}
+	slices.SortFunc(sorted, func(a, b MigrationState) int {
+		return int(a.Version - b.Version)
+	})
+
+	return sorted, nil
+}
+
+// SchemaVersion returns the version of the latest applied migration, or 0
+// if the database has never been migrated. It fails with ErrDirtyMigration
+// if a migration is dirty, as the schema is then in no known version.
+func (db *Database) SchemaVersion(ctx context.Context) (int64, error) {
+	applied, err := db.AppliedMigrations(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	for _, m := range applied {
+		if m.Dirty {
+			return 0, fmt.Errorf("%w: migration %d", ErrDirtyMigration,
+				m.Version)
+		}
+		version = max(version, m.Version)
+	}
+
+	return version, nil
+}
+
+// ForceVersion records the schema as migrated to exactly the given version
+// without running any migration: every known migration up to it is
+// recorded as applied and clean, and every later one as pending. It is how
+// a schema repaired by hand after a dirty migration is marked usable again.
+// Version 0 records nothing as applied.
+func (db *Database) ForceVersion(ctx context.Context, version int64) error {
+	migrations, err := Migrations()
+	if err != nil {
+		return err
+	}
+	known := slices.ContainsFunc(migrations, func(m Migration) bool {
+		return m.Version == version
+	})
+	if version != 0 && !known {
+		return fmt.Errorf("%w: %d", ErrUnknownMigration, version)
+	}
+
+	session := db.WithContext(ctx)
+	if err = session.AutoMigrate(&SchemaMigration{}); err != nil {
+		return fmt.Errorf("could not migrate schema migrations; %w", err)
+	}
+
+	return session.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("version > ?", version).
+			Delete(&SchemaMigration{}).Error
+		if err != nil {
+			return fmt.Errorf("could not clear later migrations; %w", err)
+		}
+
+		// Migrations already recorded keep the time they were applied.
+		now := time.Now()
+		for _, m := range migrations {
+			if m.Version > version {
+				break
+			}
+			err = tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "version"}},
+				DoUpdates: clause.AssignmentColumns([]string{"dirty"}),
+			}).Create(&SchemaMigration{
+				Version:   m.Version,
+				Name:      m.Name,
+				AppliedAt: now,
+			}).Error
+			if err != nil {
+				return fmt.Errorf("could not record migration %d; %w",
+					m.Version, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// MigrateUp applies every pending migration in version order. It refuses
+// to run while an earlier migration is dirty.
+func (db *Database) MigrateUp(ctx context.Context) error {
+	if err := db.prepareMigrations(ctx); err != nil {
+		return err
+	}
+
+	pending, err := db.PendingMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range pending {
+		started := time.Now()
+		record := SchemaMigration{
+			Version:   m.Version,
+			Name:      m.Name,
+			Dirty:     true,
+			AppliedAt: started,
+		}
+		err = db.runMigration(ctx, m, m.up, func(tx *gorm.DB) error {
+			return tx.Model(&record).Update("dirty", false).Error
+		}, &record)
+		if err != nil {
+			return fmt.Errorf("could not apply migration %d %s; %w",
+				m.Version, m.Name, err)
+		}
+		slog.Info("applied migration", "version", m.Version, "name", m.Name,
+			"elapsed", time.Since(started).Round(time.Millisecond))
+	}
+
+	return nil
+}
+
+// MigrateDown rolls back the most recently applied migration. It refuses
+// to run while a migration is dirty.
+func (db *Database) MigrateDown(ctx context.Context) error {
+	if err := db.prepareMigrations(ctx); err != nil {
+		return err
+	}
+
+	applied, err := db.AppliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		return ErrNoMigration
+	}
+	record := applied[len(applied)-1]
+
+	migrations, err := Migrations()
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(migrations, func(m Migration) bool {
+		return m.Version == record.Version
+	})
+	if i < 0 || migrations[i].down == nil {
+		return fmt.Errorf("%w: %d %s", ErrIrreversible,
+			record.Version, record.Name)
+	}
+	m := migrations[i]
+
+	record.Dirty = true
+	err = db.runMigration(ctx, m, m.down, func(tx *gorm.DB) error {
+		return tx.Delete(&record).Error
+	}, &record)
+	if err != nil {
+		return fmt.Errorf("could not roll back migration %d %s; %w",
+			m.Version, m.Name, err)
+	}
+	slog.Info("rolled back migration", "version", m.Version, "name", m.Name)
+
+	return nil
+}
+
+// prepareMigrations creates the migration table if needed and checks that
+// no migration is dirty.
+func (db *Database) prepareMigrations(ctx context.Context) error {
+	session := db.WithContext(ctx)
+	if err := session.AutoMigrate(&SchemaMigration{}); err != nil {
+		return fmt.Errorf("could not migrate schema migrations; %w", err)
+	}
+
+	var dirty []SchemaMigration
+	if err := session.Where("dirty").Find(&dirty).Error; err != nil {
+		return fmt.Errorf("could not check for dirty migrations; %w", err)
+	}
+	if len(dirty) > 0 {
+		return fmt.Errorf("%w: migration %d %s failed part way through",
+			ErrDirtyMigration, dirty[0].Version, dirty[0].Name)
+	}
+
+	return nil
+}
+
+// runMigration marks the migration dirty, runs step and then finish, which
+// clears the mark. Transactional migrations run both in one transaction, so
+// a failure leaves the schema and the mark as they were; others stay dirty
+// if they fail.
+func (db *Database) runMigration(
+	ctx context.Context,
+	m Migration,
+	step func(tx *gorm.DB) error,
+	finish func(tx *gorm.DB) error,
+	record *SchemaMigration,
+) error {
+	session := db.WithContext(ctx)
+	run := func(tx *gorm.DB) error {
+		if err := step(tx); err != nil {
+			return err
+		}
+		return finish(tx)
+	}
+
+	if m.transactional {
+		return session.Transaction(func(tx *gorm.DB) error {
+			if err := saveMigration(tx, record); err != nil {
+				return err
+			}
+			return run(tx)
+		})
+	}
+
+	if err := saveMigration(session, record); err != nil {
+		return err
+	}
+
+	return run(session)
+}
+
+// saveMigration records the migration, replacing any earlier record of it.
+func saveMigration(tx *gorm.DB, record *SchemaMigration) error {
+	err := tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "version"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"name",
+			"dirty",
+			"applied_at",
+		}),
+	}).Create(record).Error
+	if err != nil {
+		return fmt.Errorf("could not record migration; %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,9 @@
+-- Records the indexes dropped for a bulk load, for finalize to rebuild.
+
+CREATE TABLE IF NOT EXISTS "deferred_indexes" (
+    "name" text,
+    "table_name" text NOT NULL,
+    "definition" text NOT NULL,
+    "deferred_at" timestamptz NOT NULL,
+    PRIMARY KEY ("name")
+);
@@ -0,0 +1,14 @@
+-- Records the rows validation rejected or flagged.
+
+CREATE TABLE IF NOT EXISTS `validation_errors` (
+    `id` bigint AUTO_INCREMENT,
+    `table_name` varchar(191) NOT NULL,
+    `row_key` longtext,
+    `rule` longtext NOT NULL,
+    `mode` longtext NOT NULL,
+    `row` json NOT NULL,
+    `detected_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_validation_errors_table` (`table_name`),
+    INDEX `idx_validation_errors_detected_at` (`detected_at`)
+);
@@ -0,0 +1,14 @@
+-- Records the rows validation rejected or flagged.
+
+CREATE TABLE IF NOT EXISTS "validation_errors" (
+    "id" bigserial,
+    "table_name" text NOT NULL,
+    "row_key" text,
+    "rule" text NOT NULL,
+    "mode" text NOT NULL,
+    "row" JSONB NOT NULL,
+    "detected_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_validation_errors_detected_at" ON "validation_errors" ("detected_at");
+CREATE INDEX IF NOT EXISTS "idx_validation_errors_table" ON "validation_errors" ("table_name");
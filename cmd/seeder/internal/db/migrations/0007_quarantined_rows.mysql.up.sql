@@ -0,0 +1,12 @@
+-- Holds the orphaned rows the audit quarantined.
+
+CREATE TABLE IF NOT EXISTS `quarantined_rows` (
+    `id` bigint AUTO_INCREMENT,
+    `table_name` varchar(191) NOT NULL,
+    `reference` longtext NOT NULL,
+    `row` json NOT NULL,
+    `quarantined_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_quarantined_rows_table` (`table_name`),
+    INDEX `idx_quarantined_rows_quarantined_at` (`quarantined_at`)
+);
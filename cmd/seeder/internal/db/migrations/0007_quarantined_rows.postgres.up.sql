@@ -0,0 +1,12 @@
+-- Holds the orphaned rows the audit quarantined.
+
+CREATE TABLE IF NOT EXISTS "quarantined_rows" (
+    "id" bigserial,
+    "table_name" text NOT NULL,
+    "reference" text NOT NULL,
+    "row" JSONB NOT NULL,
+    "quarantined_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_quarantined_rows_quarantined_at" ON "quarantined_rows" ("quarantined_at");
+CREATE INDEX IF NOT EXISTS "idx_quarantined_rows_table" ON "quarantined_rows" ("table_name");
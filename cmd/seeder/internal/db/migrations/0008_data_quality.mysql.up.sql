@@ -0,0 +1,11 @@
+-- Holds the completeness metrics computed for each season.
+
+CREATE TABLE IF NOT EXISTS `data_quality` (
+    `season` int,
+    `metric` varchar(191),
+    `covered` bigint NOT NULL,
+    `total` bigint NOT NULL,
+    `completeness` double,
+    `computed_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`season`,`metric`)
+);
@@ -0,0 +1,11 @@
+-- Holds the completeness metrics computed for each season.
+
+CREATE TABLE IF NOT EXISTS "data_quality" (
+    "season" integer,
+    "metric" text,
+    "covered" bigint NOT NULL,
+    "total" bigint NOT NULL,
+    "completeness" decimal,
+    "computed_at" timestamptz NOT NULL,
+    PRIMARY KEY ("season","metric")
+);
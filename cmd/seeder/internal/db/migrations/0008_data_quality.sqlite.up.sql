@@ -0,0 +1,11 @@
+-- Holds the completeness metrics computed for each season.
+
+CREATE TABLE IF NOT EXISTS `data_quality` (
+    `season` integer,
+    `metric` text,
+    `covered` integer NOT NULL,
+    `total` integer NOT NULL,
+    `completeness` real,
+    `computed_at` datetime NOT NULL,
+    PRIMARY KEY (`season`,`metric`)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `poll_ranks_history`;
+DROP TABLE IF EXISTS `game_lines_history`;
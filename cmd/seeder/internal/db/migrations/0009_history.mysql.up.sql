@@ -0,0 +1,33 @@
+-- Keeps every version of the betting lines and poll ranks.
+
+CREATE TABLE IF NOT EXISTS `game_lines_history` (
+    `id` bigint AUTO_INCREMENT,
+    `game_id` int NOT NULL,
+    `provider` varchar(191) NOT NULL,
+    `spread` double,
+    `formatted_spread` longtext,
+    `spread_open` double,
+    `over_under` double,
+    `over_under_open` double,
+    `home_moneyline` double,
+    `away_moneyline` double,
+    `recorded_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_game_lines_history_line` (`game_id`,`provider`),
+    INDEX `idx_game_lines_history_recorded_at` (`recorded_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `poll_ranks_history` (
+    `id` bigint AUTO_INCREMENT,
+    `poll_id` bigint NOT NULL,
+    `school` varchar(191) NOT NULL,
+    `rank` int,
+    `team_id` int,
+    `conference` longtext,
+    `first_place_votes` int,
+    `points` int,
+    `recorded_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_poll_ranks_history_rank` (`poll_id`,`school`),
+    INDEX `idx_poll_ranks_history_recorded_at` (`recorded_at`)
+);
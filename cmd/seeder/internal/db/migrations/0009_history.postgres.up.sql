@@ -0,0 +1,33 @@
+-- Keeps every version of the betting lines and poll ranks.
+
+CREATE TABLE IF NOT EXISTS "game_lines_history" (
+    "id" bigserial,
+    "game_id" integer NOT NULL,
+    "provider" text NOT NULL,
+    "spread" decimal,
+    "formatted_spread" text,
+    "spread_open" decimal,
+    "over_under" decimal,
+    "over_under_open" decimal,
+    "home_moneyline" decimal,
+    "away_moneyline" decimal,
+    "recorded_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_game_lines_history_recorded_at" ON "game_lines_history" ("recorded_at");
+CREATE INDEX IF NOT EXISTS "idx_game_lines_history_line" ON "game_lines_history" ("game_id","provider");
+
+CREATE TABLE IF NOT EXISTS "poll_ranks_history" (
+    "id" bigserial,
+    "poll_id" bigint NOT NULL,
+    "school" text NOT NULL,
+    "rank" integer,
+    "team_id" integer,
+    "conference" text,
+    "first_place_votes" integer,
+    "points" integer,
+    "recorded_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_poll_ranks_history_recorded_at" ON "poll_ranks_history" ("recorded_at");
+CREATE INDEX IF NOT EXISTS "idx_poll_ranks_history_rank" ON "poll_ranks_history" ("poll_id","school");
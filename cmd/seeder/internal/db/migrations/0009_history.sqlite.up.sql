@@ -0,0 +1,33 @@
+-- Keeps every version of the betting lines and poll ranks.
+
+CREATE TABLE IF NOT EXISTS `game_lines_history` (
+    `id` integer,
+    `game_id` integer NOT NULL,
+    `provider` text NOT NULL,
+    `spread` real,
+    `formatted_spread` text,
+    `spread_open` real,
+    `over_under` real,
+    `over_under_open` real,
+    `home_moneyline` real,
+    `away_moneyline` real,
+    `recorded_at` datetime NOT NULL,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_game_lines_history_recorded_at` ON `game_lines_history`(`recorded_at`);
+CREATE INDEX IF NOT EXISTS `idx_game_lines_history_line` ON `game_lines_history`(`game_id`,`provider`);
+
+CREATE TABLE IF NOT EXISTS `poll_ranks_history` (
+    `id` integer,
+    `poll_id` integer NOT NULL,
+    `school` text NOT NULL,
+    `rank` integer,
+    `team_id` integer,
+    `conference` text,
+    `first_place_votes` integer,
+    `points` integer,
+    `recorded_at` datetime NOT NULL,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_poll_ranks_history_recorded_at` ON `poll_ranks_history`(`recorded_at`);
+CREATE INDEX IF NOT EXISTS `idx_poll_ranks_history_rank` ON `poll_ranks_history`(`poll_id`,`school`);
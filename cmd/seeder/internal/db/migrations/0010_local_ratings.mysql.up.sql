@@ -0,0 +1,27 @@
+-- Holds the Elo ratings computed from the seeded games.
+
+CREATE TABLE IF NOT EXISTS `local_game_elo` (
+    `game_id` int AUTO_INCREMENT,
+    `season` int NOT NULL,
+    `home_team` longtext NOT NULL,
+    `away_team` longtext NOT NULL,
+    `home_pregame_elo` double NOT NULL,
+    `away_pregame_elo` double NOT NULL,
+    `home_postgame_elo` double NOT NULL,
+    `away_postgame_elo` double NOT NULL,
+    `computed_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`game_id`),
+    INDEX `idx_local_game_elo_season` (`season`)
+);
+
+CREATE TABLE IF NOT EXISTS `local_team_ratings` (
+    `season` int,
+    `team` varchar(191),
+    `games` int NOT NULL,
+    `elo` double NOT NULL,
+    `margin` double NOT NULL,
+    `sos` double NOT NULL,
+    `srs` double NOT NULL,
+    `computed_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`season`,`team`)
+);
@@ -0,0 +1,27 @@
+-- Holds the Elo ratings computed from the seeded games.
+
+CREATE TABLE IF NOT EXISTS "local_game_elo" (
+    "game_id" serial,
+    "season" integer NOT NULL,
+    "home_team" text NOT NULL,
+    "away_team" text NOT NULL,
+    "home_pregame_elo" decimal NOT NULL,
+    "away_pregame_elo" decimal NOT NULL,
+    "home_postgame_elo" decimal NOT NULL,
+    "away_postgame_elo" decimal NOT NULL,
+    "computed_at" timestamptz NOT NULL,
+    PRIMARY KEY ("game_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_local_game_elo_season" ON "local_game_elo" ("season");
+
+CREATE TABLE IF NOT EXISTS "local_team_ratings" (
+    "season" integer,
+    "team" text,
+    "games" integer NOT NULL,
+    "elo" decimal NOT NULL,
+    "margin" decimal NOT NULL,
+    "sos" decimal NOT NULL,
+    "srs" decimal NOT NULL,
+    "computed_at" timestamptz NOT NULL,
+    PRIMARY KEY ("season","team")
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `local_team_ratings`;
+DROP TABLE IF EXISTS `local_game_elo`;
@@ -0,0 +1,27 @@
+-- Holds the Elo ratings computed from the seeded games.
+
+CREATE TABLE IF NOT EXISTS `local_game_elo` (
+    `game_id` integer,
+    `season` integer NOT NULL,
+    `home_team` text NOT NULL,
+    `away_team` text NOT NULL,
+    `home_pregame_elo` real NOT NULL,
+    `away_pregame_elo` real NOT NULL,
+    `home_postgame_elo` real NOT NULL,
+    `away_postgame_elo` real NOT NULL,
+    `computed_at` datetime NOT NULL,
+    PRIMARY KEY (`game_id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_local_game_elo_season` ON `local_game_elo`(`season`);
+
+CREATE TABLE IF NOT EXISTS `local_team_ratings` (
+    `season` integer,
+    `team` text,
+    `games` integer NOT NULL,
+    `elo` real NOT NULL,
+    `margin` real NOT NULL,
+    `sos` real NOT NULL,
+    `srs` real NOT NULL,
+    `computed_at` datetime NOT NULL,
+    PRIMARY KEY (`season`,`team`)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `local_play_win_probability`;
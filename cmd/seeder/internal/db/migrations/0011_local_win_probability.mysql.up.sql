@@ -0,0 +1,11 @@
+-- Holds the win probabilities estimated from the seeded plays.
+
+CREATE TABLE IF NOT EXISTS `local_play_win_probability` (
+    `game_id` int,
+    `play_id` varchar(191),
+    `season` int NOT NULL,
+    `home_win_probability` double NOT NULL,
+    `computed_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`game_id`,`play_id`),
+    INDEX `idx_local_play_win_probability_season` (`season`)
+);
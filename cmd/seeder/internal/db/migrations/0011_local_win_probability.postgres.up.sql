@@ -0,0 +1,11 @@
+-- Holds the win probabilities estimated from the seeded plays.
+
+CREATE TABLE IF NOT EXISTS "local_play_win_probability" (
+    "game_id" integer,
+    "play_id" text,
+    "season" integer NOT NULL,
+    "home_win_probability" decimal NOT NULL,
+    "computed_at" timestamptz NOT NULL,
+    PRIMARY KEY ("game_id","play_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_local_play_win_probability_season" ON "local_play_win_probability" ("season");
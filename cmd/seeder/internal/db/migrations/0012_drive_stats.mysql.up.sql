@@ -0,0 +1,17 @@
+-- Holds the rushing and passing aggregates of every drive.
+
+CREATE TABLE IF NOT EXISTS `drive_stats` (
+    `drive_id` varchar(191),
+    `season` int,
+    `game_id` int NOT NULL,
+    `offense` longtext,
+    `plays` int NOT NULL,
+    `successful_plays` int NOT NULL,
+    `explosive_plays` int NOT NULL,
+    `success_rate` double,
+    `epa` double,
+    `epa_per_play` double,
+    `computed_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`drive_id`,`season`),
+    INDEX `idx_drive_stats_game_id` (`game_id`)
+);
@@ -0,0 +1,17 @@
+-- Holds the rushing and passing aggregates of every drive.
+
+CREATE TABLE IF NOT EXISTS "drive_stats" (
+    "drive_id" text,
+    "season" integer,
+    "game_id" integer NOT NULL,
+    "offense" text,
+    "plays" integer NOT NULL,
+    "successful_plays" integer NOT NULL,
+    "explosive_plays" integer NOT NULL,
+    "success_rate" decimal,
+    "epa" decimal,
+    "epa_per_play" decimal,
+    "computed_at" timestamptz NOT NULL,
+    PRIMARY KEY ("drive_id","season")
+);
+CREATE INDEX IF NOT EXISTS "idx_drive_stats_game_id" ON "drive_stats" ("game_id");
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `drive_stats`;
@@ -0,0 +1,27 @@
+-- Holds the rushing and passing aggregates of every team week.
+
+CREATE TABLE IF NOT EXISTS `team_week_stats` (
+    `season` int,
+    `week` int,
+    `season_type` varchar(191),
+    `team` varchar(191),
+    `games` int NOT NULL,
+    `plays` int NOT NULL,
+    `plays_per_game` double NOT NULL,
+    `epa_per_play` double,
+    `success_rate` double,
+    `explosive_rate` double NOT NULL,
+    `explosiveness` double,
+    `rush_epa_per_play` double,
+    `pass_epa_per_play` double,
+    `standard_down_plays` int NOT NULL,
+    `standard_down_epa_per_play` double,
+    `standard_down_success_rate` double,
+    `standard_down_explosiveness` double,
+    `passing_down_plays` int NOT NULL,
+    `passing_down_epa_per_play` double,
+    `passing_down_success_rate` double,
+    `passing_down_explosiveness` double,
+    `computed_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`season`,`week`,`season_type`,`team`)
+);
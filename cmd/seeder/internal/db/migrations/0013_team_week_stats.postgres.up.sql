@@ -0,0 +1,27 @@
+-- Holds the rushing and passing aggregates of every team week.
+
+CREATE TABLE IF NOT EXISTS "team_week_stats" (
+    "season" integer,
+    "week" integer,
+    "season_type" text,
+    "team" text,
+    "games" integer NOT NULL,
+    "plays" integer NOT NULL,
+    "plays_per_game" decimal NOT NULL,
+    "epa_per_play" decimal,
+    "success_rate" decimal,
+    "explosive_rate" decimal NOT NULL,
+    "explosiveness" decimal,
+    "rush_epa_per_play" decimal,
+    "pass_epa_per_play" decimal,
+    "standard_down_plays" integer NOT NULL,
+    "standard_down_epa_per_play" decimal,
+    "standard_down_success_rate" decimal,
+    "standard_down_explosiveness" decimal,
+    "passing_down_plays" integer NOT NULL,
+    "passing_down_epa_per_play" decimal,
+    "passing_down_success_rate" decimal,
+    "passing_down_explosiveness" decimal,
+    "computed_at" timestamptz NOT NULL,
+    PRIMARY KEY ("season","week","season_type","team")
+);
@@ -0,0 +1,27 @@
+-- Holds the rushing and passing aggregates of every team week.
+
+CREATE TABLE IF NOT EXISTS `team_week_stats` (
+    `season` integer,
+    `week` integer,
+    `season_type` text,
+    `team` text,
+    `games` integer NOT NULL,
+    `plays` integer NOT NULL,
+    `plays_per_game` real NOT NULL,
+    `epa_per_play` real,
+    `success_rate` real,
+    `explosive_rate` real NOT NULL,
+    `explosiveness` real,
+    `rush_epa_per_play` real,
+    `pass_epa_per_play` real,
+    `standard_down_plays` integer NOT NULL,
+    `standard_down_epa_per_play` real,
+    `standard_down_success_rate` real,
+    `standard_down_explosiveness` real,
+    `passing_down_plays` integer NOT NULL,
+    `passing_down_epa_per_play` real,
+    `passing_down_success_rate` real,
+    `passing_down_explosiveness` real,
+    `computed_at` datetime NOT NULL,
+    PRIMARY KEY (`season`,`week`,`season_type`,`team`)
+);
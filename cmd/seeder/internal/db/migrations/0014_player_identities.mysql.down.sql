@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `player_crosswalk`;
+DROP TABLE IF EXISTS `player_identities`;
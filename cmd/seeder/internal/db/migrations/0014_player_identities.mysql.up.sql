@@ -0,0 +1,22 @@
+-- Holds the players resolved across sources and the crosswalk to them.
+
+CREATE TABLE IF NOT EXISTS `player_identities` (
+    `id` varchar(191),
+    `name` varchar(191) NOT NULL,
+    `position` longtext,
+    `team` varchar(191),
+    `updated_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_player_identities_name` (`name`),
+    INDEX `idx_player_identities_team` (`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `player_crosswalk` (
+    `source` varchar(191),
+    `source_id` varchar(191),
+    `player_id` varchar(191) NOT NULL,
+    `method` longtext NOT NULL,
+    `updated_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`source`,`source_id`),
+    INDEX `idx_player_crosswalk_player_id` (`player_id`)
+);
@@ -0,0 +1,22 @@
+-- Holds the players resolved across sources and the crosswalk to them.
+
+CREATE TABLE IF NOT EXISTS `player_identities` (
+    `id` text,
+    `name` text NOT NULL,
+    `position` text,
+    `team` text,
+    `updated_at` datetime NOT NULL,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_player_identities_team` ON `player_identities`(`team`);
+CREATE INDEX IF NOT EXISTS `idx_player_identities_name` ON `player_identities`(`name`);
+
+CREATE TABLE IF NOT EXISTS `player_crosswalk` (
+    `source` text,
+    `source_id` text,
+    `player_id` text NOT NULL,
+    `method` text NOT NULL,
+    `updated_at` datetime NOT NULL,
+    PRIMARY KEY (`source`,`source_id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_player_crosswalk_player_id` ON `player_crosswalk`(`player_id`);
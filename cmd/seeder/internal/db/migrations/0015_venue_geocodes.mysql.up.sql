@@ -0,0 +1,10 @@
+-- Holds the coordinates and elevation geocoded for each venue.
+
+CREATE TABLE IF NOT EXISTS `venue_geocodes` (
+    `venue_id` int AUTO_INCREMENT,
+    `latitude` double NOT NULL,
+    `longitude` double NOT NULL,
+    `elevation` double NOT NULL,
+    `geocoded_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`venue_id`)
+);
@@ -0,0 +1,10 @@
+-- Holds the coordinates and elevation geocoded for each venue.
+
+CREATE TABLE IF NOT EXISTS "venue_geocodes" (
+    "venue_id" serial,
+    "latitude" decimal NOT NULL,
+    "longitude" decimal NOT NULL,
+    "elevation" decimal NOT NULL,
+    "geocoded_at" timestamptz NOT NULL,
+    PRIMARY KEY ("venue_id")
+);
@@ -0,0 +1,10 @@
+-- Holds the coordinates and elevation geocoded for each venue.
+
+CREATE TABLE IF NOT EXISTS `venue_geocodes` (
+    `venue_id` integer,
+    `latitude` real NOT NULL,
+    `longitude` real NOT NULL,
+    `elevation` real NOT NULL,
+    `geocoded_at` datetime NOT NULL,
+    PRIMARY KEY (`venue_id`)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_venues_location;
+ALTER TABLE venues DROP COLUMN IF EXISTS location;
@@ -0,0 +1,31 @@
+-- Adds a PostGIS geography column, location, to venues, generated from their
+-- coordinates so it never goes stale, with a GiST index for distance
+-- queries. PostGIS is installed in public, like pg_trgm, for every schema to
+-- share. A server without PostGIS, or a role that may not install it, is
+-- left without the column; this file is safe to run again by hand once
+-- PostGIS is available.
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_available_extensions WHERE name = 'postgis'
+    ) THEN
+        RAISE NOTICE 'postgis is not available';
+        RETURN;
+    END IF;
+
+    CREATE EXTENSION IF NOT EXISTS postgis SCHEMA public;
+    ALTER TABLE venues ADD COLUMN IF NOT EXISTS location
+        geography(Point, 4326) GENERATED ALWAYS AS (
+            CASE WHEN latitude IS NOT NULL AND longitude IS NOT NULL
+                THEN ST_SetSRID(
+                    ST_MakePoint(longitude, latitude), 4326
+                )::geography
+            END
+        ) STORED;
+    CREATE INDEX IF NOT EXISTS idx_venues_location
+        ON venues USING gist (location);
+EXCEPTION WHEN insufficient_privilege THEN
+    RAISE NOTICE 'could not install postgis: %', SQLERRM;
+END
+$$;
@@ -0,0 +1,19 @@
+-- Holds the travel distance and rest days of each team in each game.
+
+CREATE TABLE IF NOT EXISTS `game_travel` (
+    `game_id` int,
+    `team` varchar(191),
+    `season` int NOT NULL,
+    `week` int NOT NULL,
+    `season_type` longtext NOT NULL,
+    `home_away` longtext NOT NULL,
+    `neutral_site` boolean NOT NULL,
+    `home_venue_id` int,
+    `venue_id` int,
+    `distance_miles` double,
+    `previous_game_id` int,
+    `rest_days` int,
+    `computed_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`game_id`,`team`),
+    INDEX `idx_game_travel_season` (`season`)
+);
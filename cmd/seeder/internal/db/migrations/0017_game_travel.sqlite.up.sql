@@ -0,0 +1,19 @@
+-- Holds the travel distance and rest days of each team in each game.
+
+CREATE TABLE IF NOT EXISTS `game_travel` (
+    `game_id` integer,
+    `team` text,
+    `season` integer NOT NULL,
+    `week` integer NOT NULL,
+    `season_type` text NOT NULL,
+    `home_away` text NOT NULL,
+    `neutral_site` numeric NOT NULL,
+    `home_venue_id` integer,
+    `venue_id` integer,
+    `distance_miles` real,
+    `previous_game_id` integer,
+    `rest_days` integer,
+    `computed_at` datetime NOT NULL,
+    PRIMARY KEY (`game_id`,`team`)
+);
+CREATE INDEX IF NOT EXISTS `idx_game_travel_season` ON `game_travel`(`season`);
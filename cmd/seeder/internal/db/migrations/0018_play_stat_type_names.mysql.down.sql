@@ -0,0 +1,3 @@
+DROP INDEX `idx_play_stat_types_name` ON `play_stat_types`;
+
+ALTER TABLE `play_stat_types` MODIFY `name` longtext NOT NULL;
@@ -0,0 +1,11 @@
+-- Keys play stat types on their name, which the API identifies them by, so
+-- that seeding them again no longer fails on the ids assigned by hand
+-- before. The first row of any name stored more than once is kept; nothing
+-- refers to a stat type by its id, as play_stats stores the name.
+
+DELETE a FROM `play_stat_types` a
+JOIN `play_stat_types` b ON a.`name` = b.`name` AND a.`id` > b.`id`;
+
+ALTER TABLE `play_stat_types` MODIFY `name` varchar(191) NOT NULL;
+
+CREATE UNIQUE INDEX `idx_play_stat_types_name` ON `play_stat_types` (`name`);
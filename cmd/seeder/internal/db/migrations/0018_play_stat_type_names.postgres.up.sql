@@ -0,0 +1,17 @@
+-- Keys play stat types on their name, which the API identifies them by, so
+-- that seeding them again no longer fails on the ids assigned by hand
+-- before. The first row of any name stored more than once is kept; nothing
+-- refers to a stat type by its id, as play_stats stores the name. The id
+-- sequence is moved past the ids assigned by hand.
+
+DELETE FROM "play_stat_types" a
+USING "play_stat_types" b
+WHERE a."name" = b."name" AND a."id" > b."id";
+
+SELECT setval(
+    pg_get_serial_sequence('play_stat_types', 'id'),
+    COALESCE(MAX("id"), 0) + 1,
+    false
+) FROM "play_stat_types";
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_play_stat_types_name" ON "play_stat_types" ("name");
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS `idx_play_stat_types_name`;
@@ -0,0 +1,9 @@
+-- Keys play stat types on their name, which the API identifies them by, so
+-- that seeding them again no longer fails on the ids assigned by hand
+-- before. The first row of any name stored more than once is kept; nothing
+-- refers to a stat type by its id, as play_stats stores the name.
+
+DELETE FROM `play_stat_types`
+WHERE `id` NOT IN (SELECT MIN(`id`) FROM `play_stat_types` GROUP BY `name`);
+
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_play_stat_types_name` ON `play_stat_types`(`name`);
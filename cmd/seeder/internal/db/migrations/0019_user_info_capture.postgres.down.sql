@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_user_info_captured_at";
+ALTER TABLE "user_info" DROP COLUMN IF EXISTS "captured_at";
+ALTER TABLE "user_info" DROP COLUMN IF EXISTS "stage";
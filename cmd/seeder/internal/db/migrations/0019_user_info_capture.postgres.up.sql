@@ -0,0 +1,8 @@
+-- Records the stage of the run and the time of each quota reading, so that
+-- user_info keeps a row per reading. Readings taken before are dated when
+-- this migration runs.
+
+ALTER TABLE "user_info" ADD COLUMN IF NOT EXISTS "stage" text;
+ALTER TABLE "user_info" ADD COLUMN IF NOT EXISTS "captured_at" timestamptz NOT NULL DEFAULT now();
+ALTER TABLE "user_info" ALTER COLUMN "captured_at" DROP DEFAULT;
+CREATE INDEX IF NOT EXISTS "idx_user_info_captured_at" ON "user_info" ("captured_at");
@@ -0,0 +1,18 @@
+-- Keeps a snapshot of each betting line every time it is seen, so that line
+-- movement can be followed up to kickoff.
+
+CREATE TABLE IF NOT EXISTS "game_line_snapshots" (
+    "id" bigserial,
+    "game_id" integer NOT NULL,
+    "provider" text NOT NULL,
+    "captured_at" timestamptz NOT NULL,
+    "spread" decimal,
+    "over_under" decimal,
+    "home_moneyline" decimal,
+    "away_moneyline" decimal,
+    "is_closing" boolean NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_game_line_snapshots_is_closing" ON "game_line_snapshots" ("is_closing");
+CREATE INDEX IF NOT EXISTS "idx_game_line_snapshots_captured_at" ON "game_line_snapshots" ("captured_at");
+CREATE INDEX IF NOT EXISTS "idx_game_line_snapshots_game_id" ON "game_line_snapshots" ("game_id");
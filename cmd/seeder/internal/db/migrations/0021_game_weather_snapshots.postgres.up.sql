@@ -0,0 +1,19 @@
+-- Keeps the forecast seen before each game next to the weather observed at
+-- kickoff.
+
+CREATE TABLE IF NOT EXISTS "game_weather_snapshots" (
+    "game_id" integer,
+    "kind" text,
+    "captured_at" timestamptz NOT NULL,
+    "temperature" decimal,
+    "dew_point" decimal,
+    "humidity" decimal,
+    "precipitation" decimal,
+    "snowfall" decimal,
+    "wind_direction" decimal,
+    "wind_speed" decimal,
+    "pressure" decimal,
+    "weather_condition_code" decimal,
+    "weather_condition" text,
+    PRIMARY KEY ("game_id","kind")
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "verification_diffs";
+DROP TABLE IF EXISTS "verification_cursors";
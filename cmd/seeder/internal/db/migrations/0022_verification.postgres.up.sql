@@ -0,0 +1,28 @@
+-- Records how far verification against the API has got and the differences
+-- it found.
+
+CREATE TABLE IF NOT EXISTS "verification_cursors" (
+    "name" text,
+    "season" integer NOT NULL,
+    "week" integer NOT NULL,
+    "season_type" text NOT NULL,
+    "verified_at" timestamptz NOT NULL,
+    PRIMARY KEY ("name")
+);
+
+CREATE TABLE IF NOT EXISTS "verification_diffs" (
+    "id" bigserial,
+    "season" integer NOT NULL,
+    "week" integer NOT NULL,
+    "season_type" text NOT NULL,
+    "entity" text NOT NULL,
+    "entity_id" integer NOT NULL,
+    "field" text NOT NULL,
+    "stored_value" text,
+    "upstream_value" text,
+    "detected_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_verification_diffs_detected_at" ON "verification_diffs" ("detected_at");
+CREATE INDEX IF NOT EXISTS "idx_verification_diffs_entity_id" ON "verification_diffs" ("entity_id");
+CREATE INDEX IF NOT EXISTS "idx_verification_diffs_season" ON "verification_diffs" ("season");
@@ -0,0 +1,16 @@
+-- Records the requests a seed could not complete, so that they can be
+-- retried later.
+
+CREATE TABLE IF NOT EXISTS "seed_failures" (
+    "id" bigserial,
+    "endpoint" text NOT NULL,
+    "params" JSONB NOT NULL,
+    "error" text NOT NULL,
+    "attempts" integer NOT NULL DEFAULT 1,
+    "failed_at" timestamptz NOT NULL,
+    "resolved_at" timestamptz,
+    "permanent_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_seed_failures_resolved_at" ON "seed_failures" ("resolved_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_seed_failures_unit" ON "seed_failures" ("endpoint","params");
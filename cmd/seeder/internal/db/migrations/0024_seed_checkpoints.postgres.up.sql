@@ -0,0 +1,8 @@
+-- Records the tasks an interrupted seed had left, so that it can resume.
+
+CREATE TABLE IF NOT EXISTS "seed_checkpoints" (
+    "command" text,
+    "tasks" JSONB NOT NULL,
+    "stopped_at" timestamptz NOT NULL,
+    PRIMARY KEY ("command")
+);
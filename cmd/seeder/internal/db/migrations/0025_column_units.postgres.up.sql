@@ -0,0 +1,8 @@
+-- Records the unit of each measured column.
+
+CREATE TABLE IF NOT EXISTS "column_units" (
+    "table_name" text,
+    "column_name" text,
+    "unit" text NOT NULL,
+    PRIMARY KEY ("table_name","column_name")
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_plays_play_text_search";
+ALTER TABLE "plays" DROP COLUMN IF EXISTS "play_text_search";
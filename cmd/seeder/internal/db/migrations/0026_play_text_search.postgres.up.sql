@@ -0,0 +1,5 @@
+-- Adds a full-text search vector of the play text to plays, generated so it
+-- never goes stale, with a GIN index for searching it.
+
+ALTER TABLE "plays" ADD COLUMN IF NOT EXISTS "play_text_search" tsvector GENERATED ALWAYS AS (to_tsvector('english', coalesce(play_text, ''))) STORED;
+CREATE INDEX IF NOT EXISTS "idx_plays_play_text_search" ON "plays" USING gin("play_text_search");
@@ -0,0 +1,9 @@
+-- Records a hash of each row written, so that seeding again can skip the
+-- rows that have not changed.
+
+CREATE TABLE IF NOT EXISTS "row_hashes" (
+    "table_name" text,
+    "row_key" text,
+    "hash" bytea NOT NULL,
+    PRIMARY KEY ("table_name","row_key")
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "table_manifest";
+DROP TABLE IF EXISTS "seed_runs";
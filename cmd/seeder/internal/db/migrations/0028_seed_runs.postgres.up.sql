@@ -0,0 +1,23 @@
+-- Records every seed run and when each table was last written.
+
+CREATE TABLE IF NOT EXISTS "seed_runs" (
+    "id" bigserial,
+    "command" text NOT NULL,
+    "status" text NOT NULL,
+    "error" text,
+    "rows_written" bigint NOT NULL,
+    "started_at" timestamptz NOT NULL,
+    "finished_at" timestamptz,
+    "summary" JSONB,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_seed_runs_started_at" ON "seed_runs" ("started_at");
+
+CREATE TABLE IF NOT EXISTS "table_manifest" (
+    "table_name" text,
+    "last_synced_at" timestamptz NOT NULL,
+    "rows_written" bigint NOT NULL,
+    "run_id" bigint,
+    PRIMARY KEY ("table_name")
+);
+CREATE INDEX IF NOT EXISTS "idx_table_manifest_run_id" ON "table_manifest" ("run_id");
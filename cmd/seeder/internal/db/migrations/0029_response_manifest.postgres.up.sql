@@ -0,0 +1,11 @@
+-- Records a hash of each API response, so that a sync can skip responses
+-- identical to the ones it already wrote.
+
+CREATE TABLE IF NOT EXISTS "response_manifest" (
+    "endpoint" text,
+    "params" JSONB,
+    "hash" bytea NOT NULL,
+    "fetched_at" timestamptz NOT NULL,
+    "run_id" bigint,
+    PRIMARY KEY ("endpoint","params")
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_poll_ranks_natural";
+DROP INDEX IF EXISTS "idx_polls_natural";
+DROP INDEX IF EXISTS "idx_poll_weeks_natural";
+DROP INDEX IF EXISTS "idx_play_stats_natural";
@@ -0,0 +1,8 @@
+-- Keys the child rows re-seeding rewrites on what identifies them upstream
+-- rather than on their auto-increment ids. The duplicates these indexes
+-- would reject were removed by the baseline migration.
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_play_stats_natural" ON "play_stats" ("play_id","athlete_id","stat_type","season");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_poll_weeks_natural" ON "poll_weeks" ("season","season_type","week");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_polls_natural" ON "polls" ("poll_week_id","poll");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_poll_ranks_natural" ON "poll_ranks" ("poll_id","school");
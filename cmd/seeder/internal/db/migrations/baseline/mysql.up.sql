@@ -0,0 +1,1353 @@
+-- The baseline schema on MySQL: every table as the models defined it when
+-- versioned migrations were introduced. This file is frozen; change the
+-- schema with a new migration instead. {{payload}} is the type of the
+-- CompressedJSON columns, which depends on Config.CompressPayloads.
+
+CREATE TABLE IF NOT EXISTS `venues` (
+    `id` int AUTO_INCREMENT,
+    `name` longtext NOT NULL,
+    `city` longtext,
+    `state` longtext,
+    `zip` longtext,
+    `country_code` longtext,
+    `timezone` longtext,
+    `latitude` double,
+    `longitude` double,
+    `elevation` longtext,
+    `capacity` int,
+    `construction_year` int,
+    `grass` boolean,
+    `dome` boolean,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `conferences` (
+    `id` int AUTO_INCREMENT,
+    `name` longtext NOT NULL,
+    `short_name` longtext,
+    `abbreviation` longtext,
+    `classification` longtext,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `teams` (
+    `id` int AUTO_INCREMENT,
+    `school` longtext NOT NULL,
+    `mascot` longtext,
+    `abbreviation` longtext,
+    `alternate_names` text,
+    `conference` longtext,
+    `division` longtext,
+    `classification` longtext,
+    `color` longtext,
+    `alternate_color` longtext,
+    `logos` text,
+    `twitter` longtext,
+    `venue_id` int,
+    PRIMARY KEY (`id`),
+    INDEX `idx_teams_venue_id` (`venue_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `games` (
+    `id` int AUTO_INCREMENT,
+    `season` int NOT NULL,
+    `week` int NOT NULL,
+    `season_type` varchar(191) NOT NULL,
+    `start_date` datetime(3) NULL,
+    `start_time_tbd` boolean NOT NULL,
+    `completed` boolean NOT NULL,
+    `neutral_site` boolean NOT NULL,
+    `conference_game` boolean NOT NULL,
+    `attendance` int,
+    `venue_id` int,
+    `venue` longtext,
+    `home_id` int,
+    `home_team` longtext,
+    `home_conference` longtext,
+    `home_classification` longtext,
+    `home_points` int,
+    `home_line_scores` text,
+    `home_postgame_win_probability` double,
+    `home_pregame_elo` int,
+    `home_postgame_elo` int,
+    `away_id` int,
+    `away_team` longtext,
+    `away_conference` longtext,
+    `away_classification` longtext,
+    `away_points` int,
+    `away_line_scores` text,
+    `away_postgame_win_probability` double,
+    `away_pregame_elo` int,
+    `away_postgame_elo` int,
+    `excitement_index` double,
+    `highlights` longtext,
+    `notes` longtext,
+    PRIMARY KEY (`id`),
+    INDEX `idx_games_season` (`season`),
+    INDEX `idx_games_week` (`week`),
+    INDEX `idx_games_season_type` (`season_type`),
+    INDEX `idx_games_start_date` (`start_date`),
+    INDEX `idx_games_completed` (`completed`),
+    INDEX `idx_games_venue_id` (`venue_id`),
+    INDEX `idx_games_home_id` (`home_id`),
+    INDEX `idx_games_away_id` (`away_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `matchups` (
+    `matchup_id` bigint AUTO_INCREMENT,
+    `team1` longtext NOT NULL,
+    `team2` longtext NOT NULL,
+    `start_year` bigint,
+    `end_year` bigint,
+    `team1_wins` bigint NOT NULL,
+    `team2_wins` bigint NOT NULL,
+    `ties` bigint NOT NULL,
+    PRIMARY KEY (`matchup_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `matchup_games` (
+    `id` bigint AUTO_INCREMENT,
+    `matchup_id` bigint NOT NULL,
+    `season` int NOT NULL,
+    `week` int NOT NULL,
+    `season_type` longtext NOT NULL,
+    `date` longtext,
+    `neutral_site` boolean NOT NULL,
+    `venue` longtext,
+    `home_team` longtext NOT NULL,
+    `home_score` int,
+    `away_team` longtext NOT NULL,
+    `away_score` int,
+    `winner` longtext,
+    PRIMARY KEY (`id`),
+    INDEX `idx_matchup_games_matchup_id` (`matchup_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `calendar_weeks` (
+    `season` int,
+    `week` int,
+    `season_type` varchar(191),
+    `start_date` datetime(3) NULL,
+    `end_date` datetime(3) NULL,
+    `first_game_start` datetime(3) NULL,
+    `last_game_start` datetime(3) NULL,
+    PRIMARY KEY (`season`,`week`,`season_type`)
+);
+
+CREATE TABLE IF NOT EXISTS `scoreboard` (
+    `id` int AUTO_INCREMENT,
+    `start_date` datetime(3) NULL,
+    `start_time_tbd` boolean NOT NULL,
+    `tv` longtext,
+    `neutral_site` boolean NOT NULL,
+    `conference_game` boolean NOT NULL,
+    `status` longtext,
+    `period` int,
+    `clock` longtext,
+    `situation` longtext,
+    `possession` longtext,
+    `last_play` longtext,
+    `venue` {{payload}},
+    `home_team` {{payload}},
+    `away_team` {{payload}},
+    `weather` {{payload}},
+    `betting` {{payload}},
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_records` (
+    `year` int,
+    `team` varchar(191),
+    `team_id` int,
+    `classification` longtext,
+    `conference` longtext,
+    `division` longtext,
+    `expected_wins` double,
+    `total_games` int NOT NULL,
+    `total_wins` int NOT NULL,
+    `total_losses` int NOT NULL,
+    `total_ties` int NOT NULL,
+    `conference_games_games` int NOT NULL,
+    `conference_games_wins` int NOT NULL,
+    `conference_games_losses` int NOT NULL,
+    `conference_games_ties` int NOT NULL,
+    `home_games_games` int NOT NULL,
+    `home_games_wins` int NOT NULL,
+    `home_games_losses` int NOT NULL,
+    `home_games_ties` int NOT NULL,
+    `away_games_games` int NOT NULL,
+    `away_games_wins` int NOT NULL,
+    `away_games_losses` int NOT NULL,
+    `away_games_ties` int NOT NULL,
+    `neutral_site_games_games` int NOT NULL,
+    `neutral_site_games_wins` int NOT NULL,
+    `neutral_site_games_losses` int NOT NULL,
+    `neutral_site_games_ties` int NOT NULL,
+    `regular_season_games` int NOT NULL,
+    `regular_season_wins` int NOT NULL,
+    `regular_season_losses` int NOT NULL,
+    `regular_season_ties` int NOT NULL,
+    `postseason_games` int NOT NULL,
+    `postseason_wins` int NOT NULL,
+    `postseason_losses` int NOT NULL,
+    `postseason_ties` int NOT NULL,
+    PRIMARY KEY (`year`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `play_types` (
+    `id` int AUTO_INCREMENT,
+    `text` longtext NOT NULL,
+    `abbreviation` longtext,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `play_stat_types` (
+    `id` int AUTO_INCREMENT,
+    `name` longtext NOT NULL,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `drives` (
+    `id` varchar(191),
+    `season` int,
+    `game_id` int NOT NULL,
+    `offense` longtext,
+    `offense_conference` longtext,
+    `defense` longtext,
+    `defense_conference` longtext,
+    `drive_number` int,
+    `scoring` boolean NOT NULL,
+    `start_period` int NOT NULL,
+    `start_yardline` int NOT NULL,
+    `start_yards_to_goal` int NOT NULL,
+    `start_time_minutes` int,
+    `start_time_seconds` int,
+    `end_period` int NOT NULL,
+    `end_yardline` int NOT NULL,
+    `end_yards_to_goal` int NOT NULL,
+    `end_time_minutes` int,
+    `end_time_seconds` int,
+    `elapsed_minutes` int,
+    `elapsed_seconds` int,
+    `plays` int NOT NULL,
+    `yards` int NOT NULL,
+    `drive_result` longtext,
+    `is_home_offense` boolean NOT NULL,
+    `start_offense_score` int NOT NULL,
+    `start_defense_score` int NOT NULL,
+    `end_offense_score` int NOT NULL,
+    `end_defense_score` int NOT NULL,
+    PRIMARY KEY (`id`,`season`),
+    INDEX `idx_drives_game_id` (`game_id`),
+    INDEX `idx_drives_drive_number` (`drive_number`)
+);
+
+CREATE TABLE IF NOT EXISTS `plays` (
+    `id` varchar(191),
+    `season` int,
+    `drive_id` varchar(191),
+    `game_id` int NOT NULL,
+    `drive_number` int,
+    `play_number` int,
+    `offense` varchar(191),
+    `offense_conference` longtext,
+    `offense_score` int NOT NULL,
+    `defense` varchar(191),
+    `home` longtext,
+    `away` longtext,
+    `defense_conference` longtext,
+    `defense_score` int NOT NULL,
+    `period` int NOT NULL,
+    `clock_minutes` int,
+    `clock_seconds` int,
+    `offense_timeouts` int,
+    `defense_timeouts` int,
+    `yardline` int NOT NULL,
+    `yards_to_goal` int NOT NULL,
+    `down` int NOT NULL,
+    `distance` int NOT NULL,
+    `yards_gained` int NOT NULL,
+    `scoring` boolean NOT NULL,
+    `play_type` varchar(191),
+    `play_text` longtext,
+    `ppa` double,
+    `wallclock` longtext,
+    `success` boolean,
+    `garbage_time` boolean,
+    `rush_pass` longtext,
+    `down_type` longtext,
+    PRIMARY KEY (`id`,`season`),
+    INDEX `idx_plays_drive_id` (`drive_id`),
+    INDEX `idx_plays_game_id` (`game_id`),
+    INDEX `idx_plays_play_number` (`play_number`),
+    INDEX `idx_plays_offense` (`offense`),
+    INDEX `idx_plays_defense` (`defense`),
+    INDEX `idx_plays_period` (`period`),
+    INDEX `idx_plays_down` (`down`),
+    INDEX `idx_plays_scoring` (`scoring`),
+    INDEX `idx_plays_play_type` (`play_type`)
+);
+
+CREATE TABLE IF NOT EXISTS `play_stats` (
+    `id` bigint AUTO_INCREMENT,
+    `game_id` double,
+    `season` double,
+    `week` double,
+    `team` varchar(191),
+    `conference` longtext,
+    `opponent` longtext,
+    `team_score` double,
+    `opponent_score` double,
+    `drive_id` varchar(191),
+    `play_id` varchar(191),
+    `period` double,
+    `clock_minutes` double,
+    `clock_seconds` double,
+    `yards_to_goal` double,
+    `down` double,
+    `distance` double,
+    `athlete_id` varchar(191),
+    `athlete_name` longtext,
+    `stat_type` varchar(191),
+    `stat` double,
+    PRIMARY KEY (`id`,`season`),
+    INDEX `idx_play_stats_game_id` (`game_id`),
+    INDEX `idx_play_stats_season` (`season`),
+    UNIQUE INDEX `idx_play_stats_natural` (`play_id`,`athlete_id`,`stat_type`,`season`),
+    INDEX `idx_play_stats_week` (`week`),
+    INDEX `idx_play_stats_team` (`team`),
+    INDEX `idx_play_stats_drive_id` (`drive_id`),
+    INDEX `idx_play_stats_play_id` (`play_id`),
+    INDEX `idx_play_stats_athlete_id` (`athlete_id`),
+    INDEX `idx_play_stats_stat_type` (`stat_type`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_team_stats` (
+    `id` int AUTO_INCREMENT,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_team_stats_teams` (
+    `id` bigint AUTO_INCREMENT,
+    `game_id` int NOT NULL,
+    `team_id` int NOT NULL,
+    `team` longtext NOT NULL,
+    `conference` longtext,
+    `home_away` longtext,
+    `points` int,
+    PRIMARY KEY (`id`),
+    INDEX `idx_game_team_stats_teams_game_id` (`game_id`),
+    INDEX `idx_game_team_stats_teams_team_id` (`team_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_team_stats_team_stats` (
+    `id` bigint AUTO_INCREMENT,
+    `team_row_id` bigint NOT NULL,
+    `category` varchar(191) NOT NULL,
+    `stat` longtext NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_game_team_stats_team_stats_team_row_id` (`team_row_id`),
+    INDEX `idx_game_team_stats_team_stats_category` (`category`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_player_stats` (
+    `id` int AUTO_INCREMENT,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_player_stats_teams` (
+    `id` bigint AUTO_INCREMENT,
+    `game_id` int NOT NULL,
+    `team` varchar(191) NOT NULL,
+    `conference` longtext,
+    `home_away` longtext,
+    `points` int,
+    PRIMARY KEY (`id`),
+    INDEX `idx_game_player_stats_teams_game_id` (`game_id`),
+    INDEX `idx_game_player_stats_teams_team` (`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_player_stat_categories` (
+    `id` bigint AUTO_INCREMENT,
+    `team_row_id` bigint NOT NULL,
+    `name` varchar(191) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_game_player_stat_categories_team_row_id` (`team_row_id`),
+    INDEX `idx_game_player_stat_categories_name` (`name`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_player_stat_types` (
+    `id` bigint AUTO_INCREMENT,
+    `category_row_id` bigint NOT NULL,
+    `name` varchar(191) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_game_player_stat_types_category_row_id` (`category_row_id`),
+    INDEX `idx_game_player_stat_types_name` (`name`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_player_stat_players` (
+    `id` bigint AUTO_INCREMENT,
+    `type_row_id` bigint NOT NULL,
+    `player_id` varchar(191) NOT NULL,
+    `name` longtext NOT NULL,
+    `stat` longtext NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_game_player_stat_players_type_row_id` (`type_row_id`),
+    INDEX `idx_game_player_stat_players_player_id` (`player_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `live_games` (
+    `id` int AUTO_INCREMENT,
+    `status` longtext,
+    `period` int,
+    `clock` longtext,
+    `possession` longtext,
+    `down` int,
+    `distance` int,
+    `yards_to_goal` int,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `live_game_teams` (
+    `id` bigint AUTO_INCREMENT,
+    `live_game_id` int NOT NULL,
+    `team_id` int NOT NULL,
+    `team` longtext NOT NULL,
+    `home_away` longtext,
+    `line_scores` text,
+    `points` int NOT NULL,
+    `drives` int NOT NULL,
+    `scoring_opportunities` int NOT NULL,
+    `points_per_opportunity` double NOT NULL,
+    `average_start_yard_line` double,
+    `plays` int NOT NULL,
+    `line_yards` double NOT NULL,
+    `line_yards_per_rush` double NOT NULL,
+    `second_level_yards` double NOT NULL,
+    `second_level_yards_per_rush` double NOT NULL,
+    `open_field_yards` double NOT NULL,
+    `open_field_yards_per_rush` double NOT NULL,
+    `epa_per_play` double NOT NULL,
+    `total_epa` double NOT NULL,
+    `passing_epa` double NOT NULL,
+    `epa_per_pass` double NOT NULL,
+    `rushing_epa` double NOT NULL,
+    `epa_per_rush` double NOT NULL,
+    `success_rate` double NOT NULL,
+    `standard_down_success_rate` double NOT NULL,
+    `passing_down_success_rate` double NOT NULL,
+    `explosiveness` double NOT NULL,
+    `deserve_to_win` double,
+    PRIMARY KEY (`id`),
+    INDEX `idx_live_game_teams_live_game_id` (`live_game_id`),
+    INDEX `idx_live_game_teams_team_id` (`team_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `live_game_drives` (
+    `id` varchar(191),
+    `live_game_id` int NOT NULL,
+    `offense_id` int,
+    `offense` longtext,
+    `defense_id` int,
+    `defense` longtext,
+    `play_count` int NOT NULL,
+    `yards` int NOT NULL,
+    `start_period` int NOT NULL,
+    `start_clock` longtext,
+    `start_yards_to_goal` int NOT NULL,
+    `end_period` int,
+    `end_clock` longtext,
+    `end_yards_to_goal` int,
+    `duration` longtext,
+    `scoring_opportunity` boolean NOT NULL,
+    `result` longtext,
+    `points_gained` int NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_live_game_drives_live_game_id` (`live_game_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `live_game_plays` (
+    `id` varchar(191),
+    `drive_id` varchar(191) NOT NULL,
+    `home_score` int NOT NULL,
+    `away_score` int NOT NULL,
+    `period` int NOT NULL,
+    `clock` longtext,
+    `wall_clock` datetime(3) NULL,
+    `team_id` int,
+    `team` longtext,
+    `down` int,
+    `distance` int,
+    `yards_to_goal` int,
+    `yards_gained` int,
+    `play_type_id` int,
+    `play_type` longtext,
+    `epa` double,
+    `garbage_time` boolean NOT NULL,
+    `success` boolean NOT NULL,
+    `rush_pass` longtext,
+    `down_type` longtext,
+    `play_text` longtext,
+    PRIMARY KEY (`id`),
+    INDEX `idx_live_game_plays_drive_id` (`drive_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_media` (
+    `id` int AUTO_INCREMENT,
+    `season` int,
+    `week` int,
+    `season_type` varchar(191),
+    `start_time` datetime(3) NULL,
+    `is_start_time_tbd` boolean NOT NULL,
+    `home_team` longtext,
+    `home_conference` longtext,
+    `away_team` longtext,
+    `away_conference` longtext,
+    `media_type` longtext,
+    `outlet` longtext,
+    PRIMARY KEY (`id`),
+    INDEX `idx_game_media_season` (`season`),
+    INDEX `idx_game_media_week` (`week`),
+    INDEX `idx_game_media_season_type` (`season_type`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_weather` (
+    `id` int AUTO_INCREMENT,
+    `season` int,
+    `week` int,
+    `season_type` varchar(191),
+    `start_time` datetime(3) NULL,
+    `game_indoors` boolean NOT NULL,
+    `home_team` longtext,
+    `home_conference` longtext,
+    `away_team` longtext,
+    `away_conference` longtext,
+    `venue_id` int,
+    `venue` longtext,
+    `temperature` double,
+    `dew_point` double,
+    `humidity` double,
+    `precipitation` double,
+    `snowfall` double,
+    `wind_direction` double,
+    `wind_speed` double,
+    `pressure` double,
+    `weather_condition_code` double,
+    `weather_condition` longtext,
+    `source` varchar(191) NOT NULL DEFAULT 'cfbd',
+    PRIMARY KEY (`id`),
+    INDEX `idx_game_weather_season` (`season`),
+    INDEX `idx_game_weather_week` (`week`),
+    INDEX `idx_game_weather_season_type` (`season_type`),
+    INDEX `idx_game_weather_venue_id` (`venue_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_weather_snapshots` (
+    `game_id` int,
+    `kind` varchar(191),
+    `captured_at` datetime(3) NOT NULL,
+    `temperature` double,
+    `dew_point` double,
+    `humidity` double,
+    `precipitation` double,
+    `snowfall` double,
+    `wind_direction` double,
+    `wind_speed` double,
+    `pressure` double,
+    `weather_condition_code` double,
+    `weather_condition` longtext,
+    PRIMARY KEY (`game_id`,`kind`)
+);
+
+CREATE TABLE IF NOT EXISTS `play_win_probability` (
+    `game_id` int,
+    `play_id` varchar(191),
+    `play_text` longtext,
+    `home_id` int,
+    `home` longtext,
+    `away_id` int,
+    `away` longtext,
+    `spread` double,
+    `home_ball` boolean NOT NULL,
+    `home_score` int NOT NULL,
+    `away_score` int NOT NULL,
+    `yard_line` int NOT NULL,
+    `down` int NOT NULL,
+    `distance` int NOT NULL,
+    `home_win_probability` double NOT NULL,
+    `play_number` int NOT NULL,
+    PRIMARY KEY (`game_id`,`play_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `pregame_win_probability` (
+    `game_id` int AUTO_INCREMENT,
+    `season` int,
+    `season_type` varchar(191),
+    `week` int,
+    `home_team` longtext,
+    `away_team` longtext,
+    `spread` double,
+    `home_win_probability` double,
+    PRIMARY KEY (`game_id`),
+    INDEX `idx_pregame_win_probability_season` (`season`),
+    INDEX `idx_pregame_win_probability_season_type` (`season_type`),
+    INDEX `idx_pregame_win_probability_week` (`week`)
+);
+
+CREATE TABLE IF NOT EXISTS `field_goal_ep` (
+    `yards_to_goal` int,
+    `distance` int,
+    `expected_points` double NOT NULL,
+    PRIMARY KEY (`yards_to_goal`,`distance`)
+);
+
+CREATE TABLE IF NOT EXISTS `predicted_points_values` (
+    `down` int,
+    `distance` int,
+    `yard_line` int,
+    `predicted_points` double NOT NULL,
+    PRIMARY KEY (`down`,`distance`,`yard_line`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_season_ppa` (
+    `season` int,
+    `conference` varchar(191),
+    `team` varchar(191),
+    `offense` json,
+    `defense` json,
+    PRIMARY KEY (`season`,`conference`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_game_ppa` (
+    `game_id` int AUTO_INCREMENT,
+    `season` int,
+    `week` int,
+    `season_type` varchar(191),
+    `team` varchar(191),
+    `conference` longtext,
+    `opponent` varchar(191),
+    `offense` json,
+    `defense` json,
+    PRIMARY KEY (`game_id`),
+    INDEX `idx_team_game_ppa_season` (`season`),
+    INDEX `idx_team_game_ppa_week` (`week`),
+    INDEX `idx_team_game_ppa_season_type` (`season_type`),
+    INDEX `idx_team_game_ppa_team` (`team`),
+    INDEX `idx_team_game_ppa_opponent` (`opponent`)
+);
+
+CREATE TABLE IF NOT EXISTS `player_game_ppa` (
+    `season` int,
+    `week` int,
+    `season_type` varchar(191),
+    `player_id` varchar(191),
+    `name` longtext,
+    `position` longtext,
+    `team` varchar(191),
+    `opponent` varchar(191),
+    `average_ppa` json,
+    PRIMARY KEY (`season`,`week`,`season_type`,`player_id`),
+    INDEX `idx_player_game_ppa_team` (`team`),
+    INDEX `idx_player_game_ppa_opponent` (`opponent`)
+);
+
+CREATE TABLE IF NOT EXISTS `player_season_ppa` (
+    `season` int,
+    `player_id` varchar(191),
+    `name` longtext,
+    `position` longtext,
+    `team` varchar(191),
+    `conference` longtext,
+    `average_ppa` json,
+    `total_ppa` json,
+    PRIMARY KEY (`season`,`player_id`),
+    INDEX `idx_player_season_ppa_team` (`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `advanced_box_scores` (
+    `game_id` int AUTO_INCREMENT,
+    `payload` {{payload}},
+    PRIMARY KEY (`game_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `roster_players` (
+    `id` varchar(191),
+    `first_name` longtext NOT NULL,
+    `last_name` longtext NOT NULL,
+    `team` varchar(191) NOT NULL,
+    `height` double,
+    `weight` int,
+    `jersey` int,
+    `position` longtext,
+    `home_city` longtext,
+    `home_state` longtext,
+    `home_country` longtext,
+    `home_latitude` double,
+    `home_longitude` double,
+    `home_county_fips` longtext,
+    `recruit_ids` text,
+    PRIMARY KEY (`id`),
+    INDEX `idx_roster_players_team` (`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `player_search_results` (
+    `id` varchar(191),
+    `team` varchar(191),
+    `name` longtext NOT NULL,
+    `first_name` longtext,
+    `last_name` longtext,
+    `weight` int,
+    `height` double,
+    `jersey` int,
+    `position` varchar(191),
+    `hometown` longtext,
+    `team_color` longtext,
+    `team_color_secondary` longtext,
+    PRIMARY KEY (`id`),
+    INDEX `idx_player_search_results_team` (`team`),
+    INDEX `idx_player_search_results_position` (`position`)
+);
+
+CREATE TABLE IF NOT EXISTS `player_usage_splits` (
+    `id` bigint AUTO_INCREMENT,
+    `passing_downs` double,
+    `standard_downs` double,
+    `third_down` double,
+    `second_down` double,
+    `first_down` double,
+    `rush` double,
+    `pass` double,
+    `overall` double,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `player_usage` (
+    `season` int,
+    `id` varchar(191),
+    `name` longtext NOT NULL,
+    `position` varchar(191),
+    `team` varchar(191),
+    `conference` longtext,
+    `usage_id` bigint,
+    PRIMARY KEY (`season`,`id`),
+    INDEX `idx_player_usage_position` (`position`),
+    INDEX `idx_player_usage_team` (`team`),
+    INDEX `idx_player_usage_usage_id` (`usage_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `returning_production` (
+    `season` int,
+    `team` varchar(191),
+    `conference` longtext,
+    `total_ppa` double NOT NULL,
+    `total_passing_ppa` double NOT NULL,
+    `total_receiving_ppa` double NOT NULL,
+    `total_rushing_ppa` double NOT NULL,
+    `percent_ppa` double NOT NULL,
+    `percent_passing_ppa` double NOT NULL,
+    `percent_receiving_ppa` double NOT NULL,
+    `percent_rushing_ppa` double NOT NULL,
+    `usage` double NOT NULL,
+    `passing_usage` double NOT NULL,
+    `receiving_usage` double NOT NULL,
+    `rushing_usage` double NOT NULL,
+    PRIMARY KEY (`season`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `player_transfers` (
+    `season` int,
+    `first_name` varchar(191),
+    `last_name` varchar(191),
+    `position` longtext,
+    `origin` longtext,
+    `destination` longtext,
+    `transfer_date` datetime(3) NULL,
+    `rating` double,
+    `stars` int,
+    `eligibility` longtext,
+    PRIMARY KEY (`season`,`first_name`,`last_name`)
+);
+
+CREATE TABLE IF NOT EXISTS `player_stats` (
+    `id` bigint AUTO_INCREMENT,
+    `season` int NOT NULL,
+    `player_id` varchar(191) NOT NULL,
+    `player` longtext,
+    `position` varchar(191),
+    `team` varchar(191),
+    `conference` longtext,
+    `category` varchar(191),
+    `stat_type` varchar(191),
+    `stat` longtext,
+    PRIMARY KEY (`id`),
+    INDEX `idx_player_stats_season` (`season`),
+    INDEX `idx_player_stats_player_id` (`player_id`),
+    INDEX `idx_player_stats_position` (`position`),
+    INDEX `idx_player_stats_team` (`team`),
+    INDEX `idx_player_stats_category` (`category`),
+    INDEX `idx_player_stats_stat_type` (`stat_type`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_stats` (
+    `id` bigint AUTO_INCREMENT,
+    `season` int NOT NULL,
+    `team` varchar(191) NOT NULL,
+    `conference` longtext,
+    `stat_name` varchar(191) NOT NULL,
+    `stat_value` json,
+    PRIMARY KEY (`id`),
+    INDEX `idx_team_stats_season` (`season`),
+    INDEX `idx_team_stats_team` (`team`),
+    INDEX `idx_team_stats_stat_name` (`stat_name`)
+);
+
+CREATE TABLE IF NOT EXISTS `recruit_hometown_info` (
+    `id` bigint AUTO_INCREMENT,
+    `fips_code` longtext,
+    `longitude` double,
+    `latitude` double,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `recruits` (
+    `id` varchar(191),
+    `athlete_id` varchar(191),
+    `recruit_type` varchar(191),
+    `year` int NOT NULL,
+    `ranking` int,
+    `name` longtext NOT NULL,
+    `school` longtext,
+    `committed_to` varchar(191),
+    `position` varchar(191),
+    `height` double,
+    `weight` int,
+    `stars` int NOT NULL,
+    `rating` double NOT NULL,
+    `city` longtext,
+    `state_province` longtext,
+    `country` longtext,
+    `hometown_info_id` bigint,
+    PRIMARY KEY (`id`),
+    INDEX `idx_recruits_athlete_id` (`athlete_id`),
+    INDEX `idx_recruits_recruit_type` (`recruit_type`),
+    INDEX `idx_recruits_year` (`year`),
+    INDEX `idx_recruits_committed_to` (`committed_to`),
+    INDEX `idx_recruits_position` (`position`),
+    INDEX `idx_recruits_hometown_info_id` (`hometown_info_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_recruiting_rankings` (
+    `year` int,
+    `team` varchar(191),
+    `rank` int NOT NULL,
+    `points` double NOT NULL,
+    PRIMARY KEY (`year`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `aggregated_team_recruiting` (
+    `team` varchar(191),
+    `conference` varchar(191),
+    `position_group` varchar(191),
+    `average_rating` double NOT NULL,
+    `total_rating` double NOT NULL,
+    `commits` int NOT NULL,
+    `average_stars` double NOT NULL,
+    PRIMARY KEY (`team`,`conference`,`position_group`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_sp` (
+    `year` int,
+    `team` varchar(191),
+    `conference` longtext,
+    `payload` json,
+    PRIMARY KEY (`year`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `conference_sp` (
+    `year` int,
+    `conference` varchar(191),
+    `payload` json,
+    PRIMARY KEY (`year`,`conference`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_srs` (
+    `year` int,
+    `team` varchar(191),
+    `conference` longtext,
+    `division` longtext,
+    `rating` double NOT NULL,
+    `ranking` int,
+    PRIMARY KEY (`year`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_elo` (
+    `year` int,
+    `team` varchar(191),
+    `conference` longtext,
+    `elo` int,
+    PRIMARY KEY (`year`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_fpi` (
+    `year` int,
+    `team` varchar(191),
+    `conference` longtext,
+    `payload` json,
+    PRIMARY KEY (`year`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `poll_weeks` (
+    `id` bigint AUTO_INCREMENT,
+    `season` int NOT NULL,
+    `season_type` varchar(191) NOT NULL,
+    `week` int NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_poll_weeks_season` (`season`),
+    UNIQUE INDEX `idx_poll_weeks_natural` (`season`,`season_type`,`week`),
+    INDEX `idx_poll_weeks_season_type` (`season_type`),
+    INDEX `idx_poll_weeks_week` (`week`)
+);
+
+CREATE TABLE IF NOT EXISTS `polls` (
+    `id` bigint AUTO_INCREMENT,
+    `poll_week_id` bigint NOT NULL,
+    `poll` varchar(191) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_polls_poll_week_id` (`poll_week_id`),
+    UNIQUE INDEX `idx_polls_natural` (`poll_week_id`,`poll`)
+);
+
+CREATE TABLE IF NOT EXISTS `poll_ranks` (
+    `id` bigint AUTO_INCREMENT,
+    `poll_id` bigint NOT NULL,
+    `rank` int,
+    `team_id` int,
+    `school` varchar(191) NOT NULL,
+    `conference` longtext,
+    `first_place_votes` int,
+    `points` int,
+    PRIMARY KEY (`id`),
+    INDEX `idx_poll_ranks_poll_id` (`poll_id`),
+    UNIQUE INDEX `idx_poll_ranks_natural` (`poll_id`,`school`)
+);
+
+CREATE TABLE IF NOT EXISTS `betting_games` (
+    `id` int AUTO_INCREMENT,
+    `season` int NOT NULL,
+    `season_type` varchar(191) NOT NULL,
+    `week` int NOT NULL,
+    `start_date` datetime(3) NULL,
+    `home_team_id` int,
+    `home_team` longtext,
+    `home_conference` longtext,
+    `home_classification` longtext,
+    `home_score` int,
+    `away_team_id` int,
+    `away_team` longtext,
+    `away_conference` longtext,
+    `away_classification` longtext,
+    `away_score` int,
+    PRIMARY KEY (`id`),
+    INDEX `idx_betting_games_season` (`season`),
+    INDEX `idx_betting_games_season_type` (`season_type`),
+    INDEX `idx_betting_games_week` (`week`),
+    INDEX `idx_betting_games_home_team_id` (`home_team_id`),
+    INDEX `idx_betting_games_away_team_id` (`away_team_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_lines` (
+    `game_id` int,
+    `provider` varchar(191),
+    `spread` double,
+    `formatted_spread` longtext,
+    `spread_open` double,
+    `over_under` double,
+    `over_under_open` double,
+    `home_moneyline` double,
+    `away_moneyline` double,
+    PRIMARY KEY (`game_id`,`provider`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_line_snapshots` (
+    `id` bigint AUTO_INCREMENT,
+    `game_id` int NOT NULL,
+    `provider` longtext NOT NULL,
+    `captured_at` datetime(3) NOT NULL,
+    `spread` double,
+    `over_under` double,
+    `home_moneyline` double,
+    `away_moneyline` double,
+    `is_closing` boolean NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_game_line_snapshots_game_id` (`game_id`),
+    INDEX `idx_game_line_snapshots_captured_at` (`captured_at`),
+    INDEX `idx_game_line_snapshots_is_closing` (`is_closing`)
+);
+
+CREATE TABLE IF NOT EXISTS `draft_teams` (
+    `id` bigint AUTO_INCREMENT,
+    `location` longtext,
+    `nickname` longtext,
+    `display_name` longtext,
+    `logo` longtext,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `draft_positions` (
+    `id` bigint AUTO_INCREMENT,
+    `name` longtext,
+    `abbreviation` longtext,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `draft_pick_hometown_info` (
+    `id` bigint AUTO_INCREMENT,
+    `county_fips` longtext,
+    `longitude` longtext,
+    `latitude` longtext,
+    `country` longtext,
+    `state` longtext,
+    `city` longtext,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `draft_picks` (
+    `id` bigint AUTO_INCREMENT,
+    `college_athlete_id` int,
+    `nfl_athlete_id` int,
+    `college_id` int NOT NULL,
+    `college_team` longtext,
+    `college_conference` longtext,
+    `nfl_team_id` int NOT NULL,
+    `nfl_team` longtext,
+    `year` int NOT NULL,
+    `overall` int NOT NULL,
+    `round` int NOT NULL,
+    `pick` int NOT NULL,
+    `name` longtext NOT NULL,
+    `position` longtext,
+    `height` double,
+    `weight` int,
+    `pre_draft_ranking` int,
+    `pre_draft_position_ranking` int,
+    `pre_draft_grade` int,
+    `hometown_info_id` bigint,
+    PRIMARY KEY (`id`),
+    INDEX `idx_draft_picks_college_id` (`college_id`),
+    INDEX `idx_draft_picks_nfl_team_id` (`nfl_team_id`),
+    INDEX `idx_draft_picks_year` (`year`),
+    INDEX `idx_draft_picks_hometown_info_id` (`hometown_info_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `coaches` (
+    `id` bigint AUTO_INCREMENT,
+    `first_name` longtext NOT NULL,
+    `last_name` longtext NOT NULL,
+    `hire_date` datetime(3) NULL,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `coach_seasons` (
+    `id` bigint AUTO_INCREMENT,
+    `coach_id` bigint NOT NULL,
+    `school` varchar(191) NOT NULL,
+    `year` int NOT NULL,
+    `games` int NOT NULL,
+    `wins` int NOT NULL,
+    `losses` int NOT NULL,
+    `ties` int NOT NULL,
+    `preseason_rank` int,
+    `postseason_rank` int,
+    `srs` double,
+    `sp_overall` double,
+    `sp_offense` double,
+    `sp_defense` double,
+    PRIMARY KEY (`id`),
+    INDEX `idx_coach_seasons_coach_id` (`coach_id`),
+    INDEX `idx_coach_seasons_school` (`school`),
+    INDEX `idx_coach_seasons_year` (`year`)
+);
+
+CREATE TABLE IF NOT EXISTS `adjusted_team_metrics` (
+    `year` int,
+    `team_id` int,
+    `team` longtext NOT NULL,
+    `conference` longtext,
+    `epa_rushing` double NOT NULL,
+    `epa_passing` double NOT NULL,
+    `epa_total` double NOT NULL,
+    `epa_allowed_rushing` double NOT NULL,
+    `epa_allowed_passing` double NOT NULL,
+    `epa_allowed_total` double NOT NULL,
+    `success_rate_passing_downs` double NOT NULL,
+    `success_rate_standard_downs` double NOT NULL,
+    `success_rate_total` double NOT NULL,
+    `success_rate_allowed_passing_downs` double NOT NULL,
+    `success_rate_allowed_standard_downs` double NOT NULL,
+    `success_rate_allowed_total` double NOT NULL,
+    `rushing_highlight_yards` double NOT NULL,
+    `rushing_open_field_yards` double NOT NULL,
+    `rushing_second_level_yards` double NOT NULL,
+    `rushing_line_yards` double NOT NULL,
+    `rushing_allowed_highlight_yards` double NOT NULL,
+    `rushing_allowed_open_field_yards` double NOT NULL,
+    `rushing_allowed_second_level_yards` double NOT NULL,
+    `rushing_allowed_line_yards` double NOT NULL,
+    `explosiveness` double NOT NULL,
+    `explosiveness_allowed` double NOT NULL,
+    PRIMARY KEY (`year`,`team_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `player_weighted_epa` (
+    `year` int,
+    `athlete_id` varchar(191),
+    `athlete_name` longtext NOT NULL,
+    `position` varchar(191),
+    `team` varchar(191),
+    `conference` longtext,
+    `wepa` double NOT NULL,
+    `plays` int NOT NULL,
+    PRIMARY KEY (`year`,`athlete_id`),
+    INDEX `idx_player_weighted_epa_position` (`position`),
+    INDEX `idx_player_weighted_epa_team` (`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `kicker_paar` (
+    `year` int,
+    `athlete_id` varchar(191),
+    `athlete_name` longtext NOT NULL,
+    `team` varchar(191),
+    `conference` longtext,
+    `paar` double NOT NULL,
+    `attempts` int NOT NULL,
+    PRIMARY KEY (`year`,`athlete_id`),
+    INDEX `idx_kicker_paar_team` (`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_ats` (
+    `year` int,
+    `team_id` int,
+    `team` longtext NOT NULL,
+    `conference` longtext,
+    `games` int,
+    `ats_wins` int NOT NULL,
+    `ats_losses` int NOT NULL,
+    `ats_pushes` int NOT NULL,
+    `avg_cover_margin` double,
+    PRIMARY KEY (`year`,`team_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_talent` (
+    `year` int,
+    `team` varchar(191),
+    `talent` double NOT NULL,
+    PRIMARY KEY (`year`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_havoc_stat_sides` (
+    `id` bigint AUTO_INCREMENT,
+    `db_havoc_rate` double NOT NULL,
+    `front_seven_havoc_rate` double NOT NULL,
+    `havoc_rate` double NOT NULL,
+    `db_havoc_events` double NOT NULL,
+    `front_seven_havoc_events` double NOT NULL,
+    `total_havoc_events` double NOT NULL,
+    `total_plays` double NOT NULL,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_havoc_stats` (
+    `game_id` int AUTO_INCREMENT,
+    `season` int,
+    `season_type` varchar(191),
+    `week` int,
+    `team` varchar(191),
+    `conference` longtext,
+    `opponent` varchar(191),
+    `opponent_conference` longtext,
+    `offense_id` bigint,
+    `defense_id` bigint,
+    PRIMARY KEY (`game_id`),
+    INDEX `idx_game_havoc_stats_season` (`season`),
+    INDEX `idx_game_havoc_stats_season_type` (`season_type`),
+    INDEX `idx_game_havoc_stats_week` (`week`),
+    INDEX `idx_game_havoc_stats_team` (`team`),
+    INDEX `idx_game_havoc_stats_opponent` (`opponent`),
+    INDEX `idx_game_havoc_stats_offense_id` (`offense_id`),
+    INDEX `idx_game_havoc_stats_defense_id` (`defense_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `advanced_rate_metrics` (
+    `id` bigint AUTO_INCREMENT,
+    `explosiveness` double,
+    `success_rate` double,
+    `total_ppa` double,
+    `ppa` double,
+    `rate` double,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `advanced_havoc` (
+    `id` bigint AUTO_INCREMENT,
+    `db` double,
+    `front_seven` double,
+    `total` double,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `advanced_field_position` (
+    `id` bigint AUTO_INCREMENT,
+    `average_predicted_points` double,
+    `average_start` double,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `advanced_season_stat_sides` (
+    `id` bigint AUTO_INCREMENT,
+    `payload` json,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `advanced_season_stats` (
+    `season` int,
+    `team` varchar(191),
+    `conference` longtext,
+    `offense_side_id` bigint,
+    `defense_side_id` bigint,
+    PRIMARY KEY (`season`,`team`),
+    INDEX `idx_advanced_season_stats_offense_side_id` (`offense_side_id`),
+    INDEX `idx_advanced_season_stats_defense_side_id` (`defense_side_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `advanced_game_stat_sides` (
+    `id` bigint AUTO_INCREMENT,
+    `payload` json,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `advanced_game_stats` (
+    `game_id` int AUTO_INCREMENT,
+    `season` int,
+    `season_type` varchar(191),
+    `week` int,
+    `team` varchar(191),
+    `opponent` varchar(191),
+    `offense_side_id` bigint,
+    `defense_side_id` bigint,
+    PRIMARY KEY (`game_id`),
+    INDEX `idx_advanced_game_stats_season` (`season`),
+    INDEX `idx_advanced_game_stats_season_type` (`season_type`),
+    INDEX `idx_advanced_game_stats_week` (`week`),
+    INDEX `idx_advanced_game_stats_team` (`team`),
+    INDEX `idx_advanced_game_stats_opponent` (`opponent`),
+    INDEX `idx_advanced_game_stats_offense_side_id` (`offense_side_id`),
+    INDEX `idx_advanced_game_stats_defense_side_id` (`defense_side_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `user_info` (
+    `id` bigint AUTO_INCREMENT,
+    `patron_level` double NOT NULL,
+    `remaining_calls` double NOT NULL,
+    `stage` longtext,
+    `captured_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_user_info_captured_at` (`captured_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `int32_lists` (
+    `id` bigint AUTO_INCREMENT,
+    `values` text,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `verification_cursors` (
+    `name` varchar(191),
+    `season` int NOT NULL,
+    `week` int NOT NULL,
+    `season_type` longtext NOT NULL,
+    `verified_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`name`)
+);
+
+CREATE TABLE IF NOT EXISTS `verification_diffs` (
+    `id` bigint AUTO_INCREMENT,
+    `season` int NOT NULL,
+    `week` int NOT NULL,
+    `season_type` longtext NOT NULL,
+    `entity` longtext NOT NULL,
+    `entity_id` int NOT NULL,
+    `field` longtext NOT NULL,
+    `stored_value` longtext,
+    `upstream_value` longtext,
+    `detected_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_verification_diffs_season` (`season`),
+    INDEX `idx_verification_diffs_entity_id` (`entity_id`),
+    INDEX `idx_verification_diffs_detected_at` (`detected_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `seed_failures` (
+    `id` bigint AUTO_INCREMENT,
+    `endpoint` varchar(191) NOT NULL,
+    `params` varchar(512) NOT NULL,
+    `error` longtext NOT NULL,
+    `attempts` int NOT NULL DEFAULT 1,
+    `failed_at` datetime(3) NOT NULL,
+    `resolved_at` datetime(3) NULL,
+    `permanent_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_seed_failures_unit` (`endpoint`,`params`),
+    INDEX `idx_seed_failures_resolved_at` (`resolved_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `response_manifest` (
+    `endpoint` varchar(191),
+    `params` varchar(512),
+    `hash` longblob NOT NULL,
+    `fetched_at` datetime(3) NOT NULL,
+    `run_id` bigint,
+    PRIMARY KEY (`endpoint`,`params`)
+);
+
+CREATE TABLE IF NOT EXISTS `seed_checkpoints` (
+    `command` varchar(191),
+    `tasks` json NOT NULL,
+    `stopped_at` datetime(3) NOT NULL,
+    PRIMARY KEY (`command`)
+);
+
+CREATE TABLE IF NOT EXISTS `row_hashes` (
+    `table_name` varchar(191),
+    `row_key` varchar(191),
+    `hash` longblob NOT NULL,
+    PRIMARY KEY (`table_name`,`row_key`)
+);
+
+CREATE TABLE IF NOT EXISTS `column_units` (
+    `table_name` varchar(191),
+    `column_name` varchar(191),
+    `unit` longtext NOT NULL,
+    PRIMARY KEY (`table_name`,`column_name`)
+);
+
+CREATE TABLE IF NOT EXISTS `seed_runs` (
+    `id` bigint AUTO_INCREMENT,
+    `command` longtext NOT NULL,
+    `status` longtext NOT NULL,
+    `error` longtext,
+    `rows_written` bigint NOT NULL,
+    `started_at` datetime(3) NOT NULL,
+    `finished_at` datetime(3) NULL,
+    `summary` json,
+    PRIMARY KEY (`id`),
+    INDEX `idx_seed_runs_started_at` (`started_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `table_manifest` (
+    `table_name` varchar(191),
+    `last_synced_at` datetime(3) NOT NULL,
+    `rows_written` bigint NOT NULL,
+    `run_id` bigint,
+    PRIMARY KEY (`table_name`),
+    INDEX `idx_table_manifest_run_id` (`run_id`)
+);
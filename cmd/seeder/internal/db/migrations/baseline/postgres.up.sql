@@ -0,0 +1,1215 @@
+-- The baseline schema on PostgreSQL: every table as the seeder created it
+-- with AutoMigrate before versioned migrations were introduced, so that it
+-- matches the databases that already exist. This file is frozen; change the
+-- schema with a new migration instead. {{payload}} is the type of the
+-- CompressedJSON columns, which depends on Config.CompressPayloads.
+
+CREATE TABLE IF NOT EXISTS "venues" (
+    "id" serial,
+    "name" text NOT NULL,
+    "city" text,
+    "state" text,
+    "zip" text,
+    "country_code" text,
+    "timezone" text,
+    "latitude" decimal,
+    "longitude" decimal,
+    "elevation" text,
+    "capacity" integer,
+    "construction_year" integer,
+    "grass" boolean,
+    "dome" boolean,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "conferences" (
+    "id" serial,
+    "name" text NOT NULL,
+    "short_name" text,
+    "abbreviation" text,
+    "classification" text,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "teams" (
+    "id" serial,
+    "school" text NOT NULL,
+    "mascot" text,
+    "abbreviation" text,
+    "alternate_names" text[],
+    "conference" text,
+    "division" text,
+    "classification" text,
+    "color" text,
+    "alternate_color" text,
+    "logos" text[],
+    "twitter" text,
+    "venue_id" integer,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_teams_venue_id" ON "teams" ("venue_id");
+
+CREATE TABLE IF NOT EXISTS "games" (
+    "id" serial,
+    "season" integer NOT NULL,
+    "week" integer NOT NULL,
+    "season_type" text NOT NULL,
+    "start_date" timestamptz,
+    "start_time_tbd" boolean NOT NULL,
+    "completed" boolean NOT NULL,
+    "neutral_site" boolean NOT NULL,
+    "conference_game" boolean NOT NULL,
+    "attendance" integer,
+    "venue_id" integer,
+    "venue" text,
+    "home_id" integer,
+    "home_team" text,
+    "home_conference" text,
+    "home_classification" text,
+    "home_points" integer,
+    "home_line_scores" int[],
+    "home_postgame_win_probability" decimal,
+    "home_pregame_elo" integer,
+    "home_postgame_elo" integer,
+    "away_id" integer,
+    "away_team" text,
+    "away_conference" text,
+    "away_classification" text,
+    "away_points" integer,
+    "away_line_scores" int[],
+    "away_postgame_win_probability" decimal,
+    "away_pregame_elo" integer,
+    "away_postgame_elo" integer,
+    "excitement_index" decimal,
+    "highlights" text,
+    "notes" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_games_away_id" ON "games" ("away_id");
+CREATE INDEX IF NOT EXISTS "idx_games_home_id" ON "games" ("home_id");
+CREATE INDEX IF NOT EXISTS "idx_games_venue_id" ON "games" ("venue_id");
+CREATE INDEX IF NOT EXISTS "idx_games_completed" ON "games" ("completed");
+CREATE INDEX IF NOT EXISTS "idx_games_start_date" ON "games" ("start_date");
+CREATE INDEX IF NOT EXISTS "idx_games_season_type" ON "games" ("season_type");
+CREATE INDEX IF NOT EXISTS "idx_games_week" ON "games" ("week");
+CREATE INDEX IF NOT EXISTS "idx_games_season" ON "games" ("season");
+
+CREATE TABLE IF NOT EXISTS "matchups" (
+    "matchup_id" bigserial,
+    "team1" text NOT NULL,
+    "team2" text NOT NULL,
+    "start_year" bigint,
+    "end_year" bigint,
+    "team1_wins" bigint NOT NULL,
+    "team2_wins" bigint NOT NULL,
+    "ties" bigint NOT NULL,
+    PRIMARY KEY ("matchup_id")
+);
+
+CREATE TABLE IF NOT EXISTS "matchup_games" (
+    "id" bigserial,
+    "matchup_id" bigint NOT NULL,
+    "season" integer NOT NULL,
+    "week" integer NOT NULL,
+    "season_type" text NOT NULL,
+    "date" text,
+    "neutral_site" boolean NOT NULL,
+    "venue" text,
+    "home_team" text NOT NULL,
+    "home_score" integer,
+    "away_team" text NOT NULL,
+    "away_score" integer,
+    "winner" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_matchup_games_matchup_id" ON "matchup_games" ("matchup_id");
+
+CREATE TABLE IF NOT EXISTS "calendar_weeks" (
+    "season" integer,
+    "week" integer,
+    "season_type" text,
+    "start_date" timestamptz,
+    "end_date" timestamptz,
+    "first_game_start" timestamptz,
+    "last_game_start" timestamptz,
+    PRIMARY KEY ("season","week","season_type")
+);
+
+CREATE TABLE IF NOT EXISTS "scoreboard" (
+    "id" serial,
+    "start_date" timestamptz,
+    "start_time_tbd" boolean NOT NULL,
+    "tv" text,
+    "neutral_site" boolean NOT NULL,
+    "conference_game" boolean NOT NULL,
+    "status" text,
+    "period" integer,
+    "clock" text,
+    "situation" text,
+    "possession" text,
+    "last_play" text,
+    "venue" {{payload}},
+    "home_team" {{payload}},
+    "away_team" {{payload}},
+    "weather" {{payload}},
+    "betting" {{payload}},
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "team_records" (
+    "year" integer,
+    "team" text,
+    "team_id" integer,
+    "classification" text,
+    "conference" text,
+    "division" text,
+    "expected_wins" decimal,
+    "total_games" integer NOT NULL,
+    "total_wins" integer NOT NULL,
+    "total_losses" integer NOT NULL,
+    "total_ties" integer NOT NULL,
+    "conference_games_games" integer NOT NULL,
+    "conference_games_wins" integer NOT NULL,
+    "conference_games_losses" integer NOT NULL,
+    "conference_games_ties" integer NOT NULL,
+    "home_games_games" integer NOT NULL,
+    "home_games_wins" integer NOT NULL,
+    "home_games_losses" integer NOT NULL,
+    "home_games_ties" integer NOT NULL,
+    "away_games_games" integer NOT NULL,
+    "away_games_wins" integer NOT NULL,
+    "away_games_losses" integer NOT NULL,
+    "away_games_ties" integer NOT NULL,
+    "neutral_site_games_games" integer NOT NULL,
+    "neutral_site_games_wins" integer NOT NULL,
+    "neutral_site_games_losses" integer NOT NULL,
+    "neutral_site_games_ties" integer NOT NULL,
+    "regular_season_games" integer NOT NULL,
+    "regular_season_wins" integer NOT NULL,
+    "regular_season_losses" integer NOT NULL,
+    "regular_season_ties" integer NOT NULL,
+    "postseason_games" integer NOT NULL,
+    "postseason_wins" integer NOT NULL,
+    "postseason_losses" integer NOT NULL,
+    "postseason_ties" integer NOT NULL,
+    PRIMARY KEY ("year","team")
+);
+
+CREATE TABLE IF NOT EXISTS "play_types" (
+    "id" serial,
+    "text" text NOT NULL,
+    "abbreviation" text,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "play_stat_types" (
+    "id" serial,
+    "name" text NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "drives" (
+    "id" text,
+    "game_id" integer NOT NULL,
+    "offense" text,
+    "offense_conference" text,
+    "defense" text,
+    "defense_conference" text,
+    "drive_number" integer,
+    "scoring" boolean NOT NULL,
+    "start_period" integer NOT NULL,
+    "start_yardline" integer NOT NULL,
+    "start_yards_to_goal" integer NOT NULL,
+    "start_time_minutes" integer,
+    "start_time_seconds" integer,
+    "end_period" integer NOT NULL,
+    "end_yardline" integer NOT NULL,
+    "end_yards_to_goal" integer NOT NULL,
+    "end_time_minutes" integer,
+    "end_time_seconds" integer,
+    "elapsed_minutes" integer,
+    "elapsed_seconds" integer,
+    "plays" integer NOT NULL,
+    "yards" integer NOT NULL,
+    "drive_result" text,
+    "is_home_offense" boolean NOT NULL,
+    "start_offense_score" integer NOT NULL,
+    "start_defense_score" integer NOT NULL,
+    "end_offense_score" integer NOT NULL,
+    "end_defense_score" integer NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_drives_drive_number" ON "drives" ("drive_number");
+CREATE INDEX IF NOT EXISTS "idx_drives_game_id" ON "drives" ("game_id");
+
+CREATE TABLE IF NOT EXISTS "plays" (
+    "id" text,
+    "drive_id" text,
+    "game_id" integer NOT NULL,
+    "drive_number" integer,
+    "play_number" integer,
+    "offense" text,
+    "offense_conference" text,
+    "offense_score" integer NOT NULL,
+    "defense" text,
+    "home" text,
+    "away" text,
+    "defense_conference" text,
+    "defense_score" integer NOT NULL,
+    "period" integer NOT NULL,
+    "clock_minutes" integer,
+    "clock_seconds" integer,
+    "offense_timeouts" integer,
+    "defense_timeouts" integer,
+    "yardline" integer NOT NULL,
+    "yards_to_goal" integer NOT NULL,
+    "down" integer NOT NULL,
+    "distance" integer NOT NULL,
+    "yards_gained" integer NOT NULL,
+    "scoring" boolean NOT NULL,
+    "play_type" text,
+    "play_text" text,
+    "ppa" decimal,
+    "wallclock" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_plays_play_type" ON "plays" ("play_type");
+CREATE INDEX IF NOT EXISTS "idx_plays_scoring" ON "plays" ("scoring");
+CREATE INDEX IF NOT EXISTS "idx_plays_down" ON "plays" ("down");
+CREATE INDEX IF NOT EXISTS "idx_plays_period" ON "plays" ("period");
+CREATE INDEX IF NOT EXISTS "idx_plays_defense" ON "plays" ("defense");
+CREATE INDEX IF NOT EXISTS "idx_plays_offense" ON "plays" ("offense");
+CREATE INDEX IF NOT EXISTS "idx_plays_play_number" ON "plays" ("play_number");
+CREATE INDEX IF NOT EXISTS "idx_plays_game_id" ON "plays" ("game_id");
+CREATE INDEX IF NOT EXISTS "idx_plays_drive_id" ON "plays" ("drive_id");
+
+CREATE TABLE IF NOT EXISTS "play_stats" (
+    "id" bigserial,
+    "game_id" decimal,
+    "season" decimal,
+    "week" decimal,
+    "team" text,
+    "conference" text,
+    "opponent" text,
+    "team_score" decimal,
+    "opponent_score" decimal,
+    "drive_id" text,
+    "play_id" text,
+    "period" decimal,
+    "clock_minutes" decimal,
+    "clock_seconds" decimal,
+    "yards_to_goal" decimal,
+    "down" decimal,
+    "distance" decimal,
+    "athlete_id" text,
+    "athlete_name" text,
+    "stat_type" text,
+    "stat" decimal,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_play_stats_stat_type" ON "play_stats" ("stat_type");
+CREATE INDEX IF NOT EXISTS "idx_play_stats_athlete_id" ON "play_stats" ("athlete_id");
+CREATE INDEX IF NOT EXISTS "idx_play_stats_play_id" ON "play_stats" ("play_id");
+CREATE INDEX IF NOT EXISTS "idx_play_stats_drive_id" ON "play_stats" ("drive_id");
+CREATE INDEX IF NOT EXISTS "idx_play_stats_team" ON "play_stats" ("team");
+CREATE INDEX IF NOT EXISTS "idx_play_stats_week" ON "play_stats" ("week");
+CREATE INDEX IF NOT EXISTS "idx_play_stats_season" ON "play_stats" ("season");
+CREATE INDEX IF NOT EXISTS "idx_play_stats_game_id" ON "play_stats" ("game_id");
+
+CREATE TABLE IF NOT EXISTS "game_team_stats" (
+    "id" serial,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "game_team_stats_teams" (
+    "id" bigserial,
+    "game_id" integer NOT NULL,
+    "team_id" integer NOT NULL,
+    "team" text NOT NULL,
+    "conference" text,
+    "home_away" text,
+    "points" integer,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_game_team_stats_teams_team_id" ON "game_team_stats_teams" ("team_id");
+CREATE INDEX IF NOT EXISTS "idx_game_team_stats_teams_game_id" ON "game_team_stats_teams" ("game_id");
+
+CREATE TABLE IF NOT EXISTS "game_team_stats_team_stats" (
+    "id" bigserial,
+    "team_row_id" bigint NOT NULL,
+    "category" text NOT NULL,
+    "stat" text NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_game_team_stats_team_stats_category" ON "game_team_stats_team_stats" ("category");
+CREATE INDEX IF NOT EXISTS "idx_game_team_stats_team_stats_team_row_id" ON "game_team_stats_team_stats" ("team_row_id");
+
+CREATE TABLE IF NOT EXISTS "game_player_stats" (
+    "id" serial,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "game_player_stats_teams" (
+    "id" bigserial,
+    "game_id" integer NOT NULL,
+    "team" text NOT NULL,
+    "conference" text,
+    "home_away" text,
+    "points" integer,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_game_player_stats_teams_team" ON "game_player_stats_teams" ("team");
+CREATE INDEX IF NOT EXISTS "idx_game_player_stats_teams_game_id" ON "game_player_stats_teams" ("game_id");
+
+CREATE TABLE IF NOT EXISTS "game_player_stat_categories" (
+    "id" bigserial,
+    "team_row_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_game_player_stat_categories_name" ON "game_player_stat_categories" ("name");
+CREATE INDEX IF NOT EXISTS "idx_game_player_stat_categories_team_row_id" ON "game_player_stat_categories" ("team_row_id");
+
+CREATE TABLE IF NOT EXISTS "game_player_stat_types" (
+    "id" bigserial,
+    "category_row_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_game_player_stat_types_name" ON "game_player_stat_types" ("name");
+CREATE INDEX IF NOT EXISTS "idx_game_player_stat_types_category_row_id" ON "game_player_stat_types" ("category_row_id");
+
+CREATE TABLE IF NOT EXISTS "game_player_stat_players" (
+    "id" bigserial,
+    "type_row_id" bigint NOT NULL,
+    "player_id" text NOT NULL,
+    "name" text NOT NULL,
+    "stat" text NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_game_player_stat_players_player_id" ON "game_player_stat_players" ("player_id");
+CREATE INDEX IF NOT EXISTS "idx_game_player_stat_players_type_row_id" ON "game_player_stat_players" ("type_row_id");
+
+CREATE TABLE IF NOT EXISTS "live_games" (
+    "id" serial,
+    "status" text,
+    "period" integer,
+    "clock" text,
+    "possession" text,
+    "down" integer,
+    "distance" integer,
+    "yards_to_goal" integer,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "live_game_teams" (
+    "id" bigserial,
+    "live_game_id" integer NOT NULL,
+    "team_id" integer NOT NULL,
+    "team" text NOT NULL,
+    "home_away" text,
+    "line_scores" int[],
+    "points" integer NOT NULL,
+    "drives" integer NOT NULL,
+    "scoring_opportunities" integer NOT NULL,
+    "points_per_opportunity" decimal NOT NULL,
+    "average_start_yard_line" decimal,
+    "plays" integer NOT NULL,
+    "line_yards" decimal NOT NULL,
+    "line_yards_per_rush" decimal NOT NULL,
+    "second_level_yards" decimal NOT NULL,
+    "second_level_yards_per_rush" decimal NOT NULL,
+    "open_field_yards" decimal NOT NULL,
+    "open_field_yards_per_rush" decimal NOT NULL,
+    "epa_per_play" decimal NOT NULL,
+    "total_epa" decimal NOT NULL,
+    "passing_epa" decimal NOT NULL,
+    "epa_per_pass" decimal NOT NULL,
+    "rushing_epa" decimal NOT NULL,
+    "epa_per_rush" decimal NOT NULL,
+    "success_rate" decimal NOT NULL,
+    "standard_down_success_rate" decimal NOT NULL,
+    "passing_down_success_rate" decimal NOT NULL,
+    "explosiveness" decimal NOT NULL,
+    "deserve_to_win" decimal,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_live_game_teams_team_id" ON "live_game_teams" ("team_id");
+CREATE INDEX IF NOT EXISTS "idx_live_game_teams_live_game_id" ON "live_game_teams" ("live_game_id");
+
+CREATE TABLE IF NOT EXISTS "live_game_drives" (
+    "id" text,
+    "live_game_id" integer NOT NULL,
+    "offense_id" integer,
+    "offense" text,
+    "defense_id" integer,
+    "defense" text,
+    "play_count" integer NOT NULL,
+    "yards" integer NOT NULL,
+    "start_period" integer NOT NULL,
+    "start_clock" text,
+    "start_yards_to_goal" integer NOT NULL,
+    "end_period" integer,
+    "end_clock" text,
+    "end_yards_to_goal" integer,
+    "duration" text,
+    "scoring_opportunity" boolean NOT NULL,
+    "result" text,
+    "points_gained" integer NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_live_game_drives_live_game_id" ON "live_game_drives" ("live_game_id");
+
+CREATE TABLE IF NOT EXISTS "live_game_plays" (
+    "id" text,
+    "drive_id" text NOT NULL,
+    "home_score" integer NOT NULL,
+    "away_score" integer NOT NULL,
+    "period" integer NOT NULL,
+    "clock" text,
+    "wall_clock" timestamptz,
+    "team_id" integer,
+    "team" text,
+    "down" integer,
+    "distance" integer,
+    "yards_to_goal" integer,
+    "yards_gained" integer,
+    "play_type_id" integer,
+    "play_type" text,
+    "epa" decimal,
+    "garbage_time" boolean NOT NULL,
+    "success" boolean NOT NULL,
+    "rush_pass" text,
+    "down_type" text,
+    "play_text" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_live_game_plays_drive_id" ON "live_game_plays" ("drive_id");
+
+CREATE TABLE IF NOT EXISTS "game_media" (
+    "id" serial,
+    "season" integer,
+    "week" integer,
+    "season_type" text,
+    "start_time" timestamptz,
+    "is_start_time_tbd" boolean NOT NULL,
+    "home_team" text,
+    "home_conference" text,
+    "away_team" text,
+    "away_conference" text,
+    "media_type" text,
+    "outlet" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_game_media_season_type" ON "game_media" ("season_type");
+CREATE INDEX IF NOT EXISTS "idx_game_media_week" ON "game_media" ("week");
+CREATE INDEX IF NOT EXISTS "idx_game_media_season" ON "game_media" ("season");
+
+CREATE TABLE IF NOT EXISTS "game_weather" (
+    "id" serial,
+    "season" integer,
+    "week" integer,
+    "season_type" text,
+    "start_time" timestamptz,
+    "game_indoors" boolean NOT NULL,
+    "home_team" text,
+    "home_conference" text,
+    "away_team" text,
+    "away_conference" text,
+    "venue_id" integer,
+    "venue" text,
+    "temperature" decimal,
+    "dew_point" decimal,
+    "humidity" decimal,
+    "precipitation" decimal,
+    "snowfall" decimal,
+    "wind_direction" decimal,
+    "wind_speed" decimal,
+    "pressure" decimal,
+    "weather_condition_code" decimal,
+    "weather_condition" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_game_weather_venue_id" ON "game_weather" ("venue_id");
+CREATE INDEX IF NOT EXISTS "idx_game_weather_season_type" ON "game_weather" ("season_type");
+CREATE INDEX IF NOT EXISTS "idx_game_weather_week" ON "game_weather" ("week");
+CREATE INDEX IF NOT EXISTS "idx_game_weather_season" ON "game_weather" ("season");
+
+CREATE TABLE IF NOT EXISTS "play_win_probability" (
+    "game_id" integer,
+    "play_id" text,
+    "play_text" text,
+    "home_id" integer,
+    "home" text,
+    "away_id" integer,
+    "away" text,
+    "spread" decimal,
+    "home_ball" boolean NOT NULL,
+    "home_score" integer NOT NULL,
+    "away_score" integer NOT NULL,
+    "yard_line" integer NOT NULL,
+    "down" integer NOT NULL,
+    "distance" integer NOT NULL,
+    "home_win_probability" decimal NOT NULL,
+    "play_number" integer NOT NULL,
+    PRIMARY KEY ("game_id","play_id")
+);
+
+CREATE TABLE IF NOT EXISTS "pregame_win_probability" (
+    "game_id" serial,
+    "season" integer,
+    "season_type" text,
+    "week" integer,
+    "home_team" text,
+    "away_team" text,
+    "spread" decimal,
+    "home_win_probability" decimal,
+    PRIMARY KEY ("game_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_pregame_win_probability_week" ON "pregame_win_probability" ("week");
+CREATE INDEX IF NOT EXISTS "idx_pregame_win_probability_season_type" ON "pregame_win_probability" ("season_type");
+CREATE INDEX IF NOT EXISTS "idx_pregame_win_probability_season" ON "pregame_win_probability" ("season");
+
+CREATE TABLE IF NOT EXISTS "field_goal_ep" (
+    "yards_to_goal" integer,
+    "distance" integer,
+    "expected_points" decimal NOT NULL,
+    PRIMARY KEY ("yards_to_goal","distance")
+);
+
+CREATE TABLE IF NOT EXISTS "predicted_points_values" (
+    "down" integer,
+    "distance" integer,
+    "yard_line" integer,
+    "predicted_points" decimal NOT NULL,
+    PRIMARY KEY ("down","distance","yard_line")
+);
+
+CREATE TABLE IF NOT EXISTS "team_season_ppa" (
+    "season" integer,
+    "conference" text,
+    "team" text,
+    "offense" JSONB,
+    "defense" JSONB,
+    PRIMARY KEY ("season","conference","team")
+);
+
+CREATE TABLE IF NOT EXISTS "team_game_ppa" (
+    "game_id" serial,
+    "season" integer,
+    "week" integer,
+    "season_type" text,
+    "team" text,
+    "conference" text,
+    "opponent" text,
+    "offense" JSONB,
+    "defense" JSONB,
+    PRIMARY KEY ("game_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_team_game_ppa_opponent" ON "team_game_ppa" ("opponent");
+CREATE INDEX IF NOT EXISTS "idx_team_game_ppa_team" ON "team_game_ppa" ("team");
+CREATE INDEX IF NOT EXISTS "idx_team_game_ppa_season_type" ON "team_game_ppa" ("season_type");
+CREATE INDEX IF NOT EXISTS "idx_team_game_ppa_week" ON "team_game_ppa" ("week");
+CREATE INDEX IF NOT EXISTS "idx_team_game_ppa_season" ON "team_game_ppa" ("season");
+
+CREATE TABLE IF NOT EXISTS "player_game_ppa" (
+    "season" integer,
+    "week" integer,
+    "season_type" text,
+    "player_id" text,
+    "name" text,
+    "position" text,
+    "team" text,
+    "opponent" text,
+    "average_ppa" JSONB,
+    PRIMARY KEY ("season","week","season_type","player_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_player_game_ppa_opponent" ON "player_game_ppa" ("opponent");
+CREATE INDEX IF NOT EXISTS "idx_player_game_ppa_team" ON "player_game_ppa" ("team");
+
+CREATE TABLE IF NOT EXISTS "player_season_ppa" (
+    "season" integer,
+    "player_id" text,
+    "name" text,
+    "position" text,
+    "team" text,
+    "conference" text,
+    "average_ppa" JSONB,
+    "total_ppa" JSONB,
+    PRIMARY KEY ("season","player_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_player_season_ppa_team" ON "player_season_ppa" ("team");
+
+CREATE TABLE IF NOT EXISTS "advanced_box_scores" (
+    "game_id" serial,
+    "payload" {{payload}},
+    PRIMARY KEY ("game_id")
+);
+
+CREATE TABLE IF NOT EXISTS "roster_players" (
+    "id" text,
+    "first_name" text NOT NULL,
+    "last_name" text NOT NULL,
+    "team" text NOT NULL,
+    "height" decimal,
+    "weight" integer,
+    "jersey" integer,
+    "position" text,
+    "home_city" text,
+    "home_state" text,
+    "home_country" text,
+    "home_latitude" decimal,
+    "home_longitude" decimal,
+    "home_county_fips" text,
+    "recruit_ids" text[],
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_roster_players_team" ON "roster_players" ("team");
+
+CREATE TABLE IF NOT EXISTS "player_search_results" (
+    "id" text,
+    "team" text,
+    "name" text NOT NULL,
+    "first_name" text,
+    "last_name" text,
+    "weight" integer,
+    "height" decimal,
+    "jersey" integer,
+    "position" text,
+    "hometown" text,
+    "team_color" text,
+    "team_color_secondary" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_player_search_results_position" ON "player_search_results" ("position");
+CREATE INDEX IF NOT EXISTS "idx_player_search_results_team" ON "player_search_results" ("team");
+
+CREATE TABLE IF NOT EXISTS "player_usage_splits" (
+    "id" bigserial,
+    "passing_downs" decimal,
+    "standard_downs" decimal,
+    "third_down" decimal,
+    "second_down" decimal,
+    "first_down" decimal,
+    "rush" decimal,
+    "pass" decimal,
+    "overall" decimal,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "player_usage" (
+    "season" integer,
+    "id" text,
+    "name" text NOT NULL,
+    "position" text,
+    "team" text,
+    "conference" text,
+    "usage_id" bigint,
+    PRIMARY KEY ("season","id")
+);
+CREATE INDEX IF NOT EXISTS "idx_player_usage_usage_id" ON "player_usage" ("usage_id");
+CREATE INDEX IF NOT EXISTS "idx_player_usage_team" ON "player_usage" ("team");
+CREATE INDEX IF NOT EXISTS "idx_player_usage_position" ON "player_usage" ("position");
+
+CREATE TABLE IF NOT EXISTS "returning_production" (
+    "season" integer,
+    "team" text,
+    "conference" text,
+    "total_ppa" decimal NOT NULL,
+    "total_passing_ppa" decimal NOT NULL,
+    "total_receiving_ppa" decimal NOT NULL,
+    "total_rushing_ppa" decimal NOT NULL,
+    "percent_ppa" decimal NOT NULL,
+    "percent_passing_ppa" decimal NOT NULL,
+    "percent_receiving_ppa" decimal NOT NULL,
+    "percent_rushing_ppa" decimal NOT NULL,
+    "usage" decimal NOT NULL,
+    "passing_usage" decimal NOT NULL,
+    "receiving_usage" decimal NOT NULL,
+    "rushing_usage" decimal NOT NULL,
+    PRIMARY KEY ("season","team")
+);
+
+CREATE TABLE IF NOT EXISTS "player_transfers" (
+    "season" integer,
+    "first_name" text,
+    "last_name" text,
+    "position" text,
+    "origin" text,
+    "destination" text,
+    "transfer_date" timestamptz,
+    "rating" decimal,
+    "stars" integer,
+    "eligibility" text,
+    PRIMARY KEY ("season","first_name","last_name")
+);
+
+CREATE TABLE IF NOT EXISTS "player_stats" (
+    "id" bigserial,
+    "season" integer NOT NULL,
+    "player_id" text NOT NULL,
+    "player" text,
+    "position" text,
+    "team" text,
+    "conference" text,
+    "category" text,
+    "stat_type" text,
+    "stat" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_player_stats_stat_type" ON "player_stats" ("stat_type");
+CREATE INDEX IF NOT EXISTS "idx_player_stats_category" ON "player_stats" ("category");
+CREATE INDEX IF NOT EXISTS "idx_player_stats_team" ON "player_stats" ("team");
+CREATE INDEX IF NOT EXISTS "idx_player_stats_position" ON "player_stats" ("position");
+CREATE INDEX IF NOT EXISTS "idx_player_stats_player_id" ON "player_stats" ("player_id");
+CREATE INDEX IF NOT EXISTS "idx_player_stats_season" ON "player_stats" ("season");
+
+CREATE TABLE IF NOT EXISTS "team_stats" (
+    "id" bigserial,
+    "season" integer NOT NULL,
+    "team" text NOT NULL,
+    "conference" text,
+    "stat_name" text NOT NULL,
+    "stat_value" JSONB,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_team_stats_stat_name" ON "team_stats" ("stat_name");
+CREATE INDEX IF NOT EXISTS "idx_team_stats_team" ON "team_stats" ("team");
+CREATE INDEX IF NOT EXISTS "idx_team_stats_season" ON "team_stats" ("season");
+
+CREATE TABLE IF NOT EXISTS "recruit_hometown_info" (
+    "id" bigserial,
+    "fips_code" text,
+    "longitude" decimal,
+    "latitude" decimal,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "recruits" (
+    "id" text,
+    "athlete_id" text,
+    "recruit_type" text,
+    "year" integer NOT NULL,
+    "ranking" integer,
+    "name" text NOT NULL,
+    "school" text,
+    "committed_to" text,
+    "position" text,
+    "height" decimal,
+    "weight" integer,
+    "stars" integer NOT NULL,
+    "rating" decimal NOT NULL,
+    "city" text,
+    "state_province" text,
+    "country" text,
+    "hometown_info_id" bigint,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_recruits_hometown_info_id" ON "recruits" ("hometown_info_id");
+CREATE INDEX IF NOT EXISTS "idx_recruits_position" ON "recruits" ("position");
+CREATE INDEX IF NOT EXISTS "idx_recruits_committed_to" ON "recruits" ("committed_to");
+CREATE INDEX IF NOT EXISTS "idx_recruits_year" ON "recruits" ("year");
+CREATE INDEX IF NOT EXISTS "idx_recruits_recruit_type" ON "recruits" ("recruit_type");
+CREATE INDEX IF NOT EXISTS "idx_recruits_athlete_id" ON "recruits" ("athlete_id");
+
+CREATE TABLE IF NOT EXISTS "team_recruiting_rankings" (
+    "year" integer,
+    "team" text,
+    "rank" integer NOT NULL,
+    "points" decimal NOT NULL,
+    PRIMARY KEY ("year","team")
+);
+
+CREATE TABLE IF NOT EXISTS "aggregated_team_recruiting" (
+    "team" text,
+    "conference" text,
+    "position_group" text,
+    "average_rating" decimal NOT NULL,
+    "total_rating" decimal NOT NULL,
+    "commits" integer NOT NULL,
+    "average_stars" decimal NOT NULL,
+    PRIMARY KEY ("team","conference","position_group")
+);
+
+CREATE TABLE IF NOT EXISTS "team_sp" (
+    "year" integer,
+    "team" text,
+    "conference" text,
+    "payload" JSONB,
+    PRIMARY KEY ("year","team")
+);
+
+CREATE TABLE IF NOT EXISTS "conference_sp" (
+    "year" integer,
+    "conference" text,
+    "payload" JSONB,
+    PRIMARY KEY ("year","conference")
+);
+
+CREATE TABLE IF NOT EXISTS "team_srs" (
+    "year" integer,
+    "team" text,
+    "conference" text,
+    "division" text,
+    "rating" decimal NOT NULL,
+    "ranking" integer,
+    PRIMARY KEY ("year","team")
+);
+
+CREATE TABLE IF NOT EXISTS "team_elo" (
+    "year" integer,
+    "team" text,
+    "conference" text,
+    "elo" integer,
+    PRIMARY KEY ("year","team")
+);
+
+CREATE TABLE IF NOT EXISTS "team_fpi" (
+    "year" integer,
+    "team" text,
+    "conference" text,
+    "payload" JSONB,
+    PRIMARY KEY ("year","team")
+);
+
+CREATE TABLE IF NOT EXISTS "poll_weeks" (
+    "id" bigserial,
+    "season" integer NOT NULL,
+    "season_type" text NOT NULL,
+    "week" integer NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_poll_weeks_week" ON "poll_weeks" ("week");
+CREATE INDEX IF NOT EXISTS "idx_poll_weeks_season_type" ON "poll_weeks" ("season_type");
+CREATE INDEX IF NOT EXISTS "idx_poll_weeks_season" ON "poll_weeks" ("season");
+
+CREATE TABLE IF NOT EXISTS "polls" (
+    "id" bigserial,
+    "poll_week_id" bigint NOT NULL,
+    "poll" text NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_polls_poll_week_id" ON "polls" ("poll_week_id");
+
+CREATE TABLE IF NOT EXISTS "poll_ranks" (
+    "id" bigserial,
+    "poll_id" bigint NOT NULL,
+    "rank" integer,
+    "team_id" integer,
+    "school" text NOT NULL,
+    "conference" text,
+    "first_place_votes" integer,
+    "points" integer,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_poll_ranks_poll_id" ON "poll_ranks" ("poll_id");
+
+CREATE TABLE IF NOT EXISTS "betting_games" (
+    "id" serial,
+    "season" integer NOT NULL,
+    "season_type" text NOT NULL,
+    "week" integer NOT NULL,
+    "start_date" timestamptz,
+    "home_team_id" integer,
+    "home_team" text,
+    "home_conference" text,
+    "home_classification" text,
+    "home_score" integer,
+    "away_team_id" integer,
+    "away_team" text,
+    "away_conference" text,
+    "away_classification" text,
+    "away_score" integer,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_betting_games_away_team_id" ON "betting_games" ("away_team_id");
+CREATE INDEX IF NOT EXISTS "idx_betting_games_home_team_id" ON "betting_games" ("home_team_id");
+CREATE INDEX IF NOT EXISTS "idx_betting_games_week" ON "betting_games" ("week");
+CREATE INDEX IF NOT EXISTS "idx_betting_games_season_type" ON "betting_games" ("season_type");
+CREATE INDEX IF NOT EXISTS "idx_betting_games_season" ON "betting_games" ("season");
+
+CREATE TABLE IF NOT EXISTS "game_lines" (
+    "game_id" integer,
+    "provider" text,
+    "spread" decimal,
+    "formatted_spread" text,
+    "spread_open" decimal,
+    "over_under" decimal,
+    "over_under_open" decimal,
+    "home_moneyline" decimal,
+    "away_moneyline" decimal,
+    PRIMARY KEY ("game_id","provider")
+);
+
+CREATE TABLE IF NOT EXISTS "draft_teams" (
+    "id" bigserial,
+    "location" text,
+    "nickname" text,
+    "display_name" text,
+    "logo" text,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "draft_positions" (
+    "id" bigserial,
+    "name" text,
+    "abbreviation" text,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "draft_pick_hometown_info" (
+    "id" bigserial,
+    "county_fips" text,
+    "longitude" text,
+    "latitude" text,
+    "country" text,
+    "state" text,
+    "city" text,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "draft_picks" (
+    "id" bigserial,
+    "college_athlete_id" integer,
+    "nfl_athlete_id" integer,
+    "college_id" integer NOT NULL,
+    "college_team" text,
+    "college_conference" text,
+    "nfl_team_id" integer NOT NULL,
+    "nfl_team" text,
+    "year" integer NOT NULL,
+    "overall" integer NOT NULL,
+    "round" integer NOT NULL,
+    "pick" integer NOT NULL,
+    "name" text NOT NULL,
+    "position" text,
+    "height" decimal,
+    "weight" integer,
+    "pre_draft_ranking" integer,
+    "pre_draft_position_ranking" integer,
+    "pre_draft_grade" integer,
+    "hometown_info_id" bigint,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_draft_picks_hometown_info_id" ON "draft_picks" ("hometown_info_id");
+CREATE INDEX IF NOT EXISTS "idx_draft_picks_year" ON "draft_picks" ("year");
+CREATE INDEX IF NOT EXISTS "idx_draft_picks_nfl_team_id" ON "draft_picks" ("nfl_team_id");
+CREATE INDEX IF NOT EXISTS "idx_draft_picks_college_id" ON "draft_picks" ("college_id");
+
+CREATE TABLE IF NOT EXISTS "coaches" (
+    "id" bigserial,
+    "first_name" text NOT NULL,
+    "last_name" text NOT NULL,
+    "hire_date" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "coach_seasons" (
+    "id" bigserial,
+    "coach_id" bigint NOT NULL,
+    "school" text NOT NULL,
+    "year" integer NOT NULL,
+    "games" integer NOT NULL,
+    "wins" integer NOT NULL,
+    "losses" integer NOT NULL,
+    "ties" integer NOT NULL,
+    "preseason_rank" integer,
+    "postseason_rank" integer,
+    "srs" decimal,
+    "sp_overall" decimal,
+    "sp_offense" decimal,
+    "sp_defense" decimal,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_coach_seasons_year" ON "coach_seasons" ("year");
+CREATE INDEX IF NOT EXISTS "idx_coach_seasons_school" ON "coach_seasons" ("school");
+CREATE INDEX IF NOT EXISTS "idx_coach_seasons_coach_id" ON "coach_seasons" ("coach_id");
+
+CREATE TABLE IF NOT EXISTS "adjusted_team_metrics" (
+    "year" integer,
+    "team_id" integer,
+    "team" text NOT NULL,
+    "conference" text,
+    "epa_rushing" decimal NOT NULL,
+    "epa_passing" decimal NOT NULL,
+    "epa_total" decimal NOT NULL,
+    "epa_allowed_rushing" decimal NOT NULL,
+    "epa_allowed_passing" decimal NOT NULL,
+    "epa_allowed_total" decimal NOT NULL,
+    "success_rate_passing_downs" decimal NOT NULL,
+    "success_rate_standard_downs" decimal NOT NULL,
+    "success_rate_total" decimal NOT NULL,
+    "success_rate_allowed_passing_downs" decimal NOT NULL,
+    "success_rate_allowed_standard_downs" decimal NOT NULL,
+    "success_rate_allowed_total" decimal NOT NULL,
+    "rushing_highlight_yards" decimal NOT NULL,
+    "rushing_open_field_yards" decimal NOT NULL,
+    "rushing_second_level_yards" decimal NOT NULL,
+    "rushing_line_yards" decimal NOT NULL,
+    "rushing_allowed_highlight_yards" decimal NOT NULL,
+    "rushing_allowed_open_field_yards" decimal NOT NULL,
+    "rushing_allowed_second_level_yards" decimal NOT NULL,
+    "rushing_allowed_line_yards" decimal NOT NULL,
+    "explosiveness" decimal NOT NULL,
+    "explosiveness_allowed" decimal NOT NULL,
+    PRIMARY KEY ("year","team_id")
+);
+
+CREATE TABLE IF NOT EXISTS "player_weighted_epa" (
+    "year" integer,
+    "athlete_id" text,
+    "athlete_name" text NOT NULL,
+    "position" text,
+    "team" text,
+    "conference" text,
+    "wepa" decimal NOT NULL,
+    "plays" integer NOT NULL,
+    PRIMARY KEY ("year","athlete_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_player_weighted_epa_team" ON "player_weighted_epa" ("team");
+CREATE INDEX IF NOT EXISTS "idx_player_weighted_epa_position" ON "player_weighted_epa" ("position");
+
+CREATE TABLE IF NOT EXISTS "kicker_paar" (
+    "year" integer,
+    "athlete_id" text,
+    "athlete_name" text NOT NULL,
+    "team" text,
+    "conference" text,
+    "paar" decimal NOT NULL,
+    "attempts" integer NOT NULL,
+    PRIMARY KEY ("year","athlete_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_kicker_paar_team" ON "kicker_paar" ("team");
+
+CREATE TABLE IF NOT EXISTS "team_ats" (
+    "year" integer,
+    "team_id" integer,
+    "team" text NOT NULL,
+    "conference" text,
+    "games" integer,
+    "ats_wins" integer NOT NULL,
+    "ats_losses" integer NOT NULL,
+    "ats_pushes" integer NOT NULL,
+    "avg_cover_margin" decimal,
+    PRIMARY KEY ("year","team_id")
+);
+
+CREATE TABLE IF NOT EXISTS "team_talent" (
+    "year" integer,
+    "team" text,
+    "talent" decimal NOT NULL,
+    PRIMARY KEY ("year","team")
+);
+
+CREATE TABLE IF NOT EXISTS "game_havoc_stat_sides" (
+    "id" bigserial,
+    "db_havoc_rate" decimal NOT NULL,
+    "front_seven_havoc_rate" decimal NOT NULL,
+    "havoc_rate" decimal NOT NULL,
+    "db_havoc_events" decimal NOT NULL,
+    "front_seven_havoc_events" decimal NOT NULL,
+    "total_havoc_events" decimal NOT NULL,
+    "total_plays" decimal NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "game_havoc_stats" (
+    "game_id" serial,
+    "season" integer,
+    "season_type" text,
+    "week" integer,
+    "team" text,
+    "conference" text,
+    "opponent" text,
+    "opponent_conference" text,
+    "offense_id" bigint,
+    "defense_id" bigint,
+    PRIMARY KEY ("game_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_game_havoc_stats_defense_id" ON "game_havoc_stats" ("defense_id");
+CREATE INDEX IF NOT EXISTS "idx_game_havoc_stats_offense_id" ON "game_havoc_stats" ("offense_id");
+CREATE INDEX IF NOT EXISTS "idx_game_havoc_stats_opponent" ON "game_havoc_stats" ("opponent");
+CREATE INDEX IF NOT EXISTS "idx_game_havoc_stats_team" ON "game_havoc_stats" ("team");
+CREATE INDEX IF NOT EXISTS "idx_game_havoc_stats_week" ON "game_havoc_stats" ("week");
+CREATE INDEX IF NOT EXISTS "idx_game_havoc_stats_season_type" ON "game_havoc_stats" ("season_type");
+CREATE INDEX IF NOT EXISTS "idx_game_havoc_stats_season" ON "game_havoc_stats" ("season");
+
+CREATE TABLE IF NOT EXISTS "advanced_rate_metrics" (
+    "id" bigserial,
+    "explosiveness" decimal,
+    "success_rate" decimal,
+    "total_ppa" decimal,
+    "ppa" decimal,
+    "rate" decimal,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "advanced_havoc" (
+    "id" bigserial,
+    "db" decimal,
+    "front_seven" decimal,
+    "total" decimal,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "advanced_field_position" (
+    "id" bigserial,
+    "average_predicted_points" decimal,
+    "average_start" decimal,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "advanced_season_stat_sides" (
+    "id" bigserial,
+    "payload" JSONB,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "advanced_season_stats" (
+    "season" integer,
+    "team" text,
+    "conference" text,
+    "offense_side_id" bigint,
+    "defense_side_id" bigint,
+    PRIMARY KEY ("season","team")
+);
+CREATE INDEX IF NOT EXISTS "idx_advanced_season_stats_defense_side_id" ON "advanced_season_stats" ("defense_side_id");
+CREATE INDEX IF NOT EXISTS "idx_advanced_season_stats_offense_side_id" ON "advanced_season_stats" ("offense_side_id");
+
+CREATE TABLE IF NOT EXISTS "advanced_game_stat_sides" (
+    "id" bigserial,
+    "payload" JSONB,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "advanced_game_stats" (
+    "game_id" serial,
+    "season" integer,
+    "season_type" text,
+    "week" integer,
+    "team" text,
+    "opponent" text,
+    "offense_side_id" bigint,
+    "defense_side_id" bigint,
+    PRIMARY KEY ("game_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_advanced_game_stats_defense_side_id" ON "advanced_game_stats" ("defense_side_id");
+CREATE INDEX IF NOT EXISTS "idx_advanced_game_stats_offense_side_id" ON "advanced_game_stats" ("offense_side_id");
+CREATE INDEX IF NOT EXISTS "idx_advanced_game_stats_opponent" ON "advanced_game_stats" ("opponent");
+CREATE INDEX IF NOT EXISTS "idx_advanced_game_stats_team" ON "advanced_game_stats" ("team");
+CREATE INDEX IF NOT EXISTS "idx_advanced_game_stats_week" ON "advanced_game_stats" ("week");
+CREATE INDEX IF NOT EXISTS "idx_advanced_game_stats_season_type" ON "advanced_game_stats" ("season_type");
+CREATE INDEX IF NOT EXISTS "idx_advanced_game_stats_season" ON "advanced_game_stats" ("season");
+
+CREATE TABLE IF NOT EXISTS "user_info" (
+    "id" bigserial,
+    "patron_level" decimal NOT NULL,
+    "remaining_calls" decimal NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "int32_lists" (
+    "id" bigserial,
+    "values" int[],
+    PRIMARY KEY ("id")
+);
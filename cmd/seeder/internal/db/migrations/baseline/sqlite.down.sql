@@ -0,0 +1,94 @@
+-- Drops the baseline schema on SQLite, dependents first.
+
+DROP TABLE IF EXISTS `table_manifest`;
+DROP TABLE IF EXISTS `seed_runs`;
+DROP TABLE IF EXISTS `column_units`;
+DROP TABLE IF EXISTS `row_hashes`;
+DROP TABLE IF EXISTS `seed_checkpoints`;
+DROP TABLE IF EXISTS `response_manifest`;
+DROP TABLE IF EXISTS `seed_failures`;
+DROP TABLE IF EXISTS `verification_diffs`;
+DROP TABLE IF EXISTS `verification_cursors`;
+DROP TABLE IF EXISTS `int32_lists`;
+DROP TABLE IF EXISTS `user_info`;
+DROP TABLE IF EXISTS `advanced_game_stats`;
+DROP TABLE IF EXISTS `advanced_game_stat_sides`;
+DROP TABLE IF EXISTS `advanced_season_stats`;
+DROP TABLE IF EXISTS `advanced_season_stat_sides`;
+DROP TABLE IF EXISTS `advanced_field_position`;
+DROP TABLE IF EXISTS `advanced_havoc`;
+DROP TABLE IF EXISTS `advanced_rate_metrics`;
+DROP TABLE IF EXISTS `game_havoc_stats`;
+DROP TABLE IF EXISTS `game_havoc_stat_sides`;
+DROP TABLE IF EXISTS `team_talent`;
+DROP TABLE IF EXISTS `team_ats`;
+DROP TABLE IF EXISTS `kicker_paar`;
+DROP TABLE IF EXISTS `player_weighted_epa`;
+DROP TABLE IF EXISTS `adjusted_team_metrics`;
+DROP TABLE IF EXISTS `coach_seasons`;
+DROP TABLE IF EXISTS `coaches`;
+DROP TABLE IF EXISTS `draft_picks`;
+DROP TABLE IF EXISTS `draft_pick_hometown_info`;
+DROP TABLE IF EXISTS `draft_positions`;
+DROP TABLE IF EXISTS `draft_teams`;
+DROP TABLE IF EXISTS `game_line_snapshots`;
+DROP TABLE IF EXISTS `game_lines`;
+DROP TABLE IF EXISTS `betting_games`;
+DROP TABLE IF EXISTS `poll_ranks`;
+DROP TABLE IF EXISTS `polls`;
+DROP TABLE IF EXISTS `poll_weeks`;
+DROP TABLE IF EXISTS `team_fpi`;
+DROP TABLE IF EXISTS `team_elo`;
+DROP TABLE IF EXISTS `team_srs`;
+DROP TABLE IF EXISTS `conference_sp`;
+DROP TABLE IF EXISTS `team_sp`;
+DROP TABLE IF EXISTS `aggregated_team_recruiting`;
+DROP TABLE IF EXISTS `team_recruiting_rankings`;
+DROP TABLE IF EXISTS `recruits`;
+DROP TABLE IF EXISTS `recruit_hometown_info`;
+DROP TABLE IF EXISTS `team_stats`;
+DROP TABLE IF EXISTS `player_stats`;
+DROP TABLE IF EXISTS `player_transfers`;
+DROP TABLE IF EXISTS `returning_production`;
+DROP TABLE IF EXISTS `player_usage`;
+DROP TABLE IF EXISTS `player_usage_splits`;
+DROP TABLE IF EXISTS `player_search_results`;
+DROP TABLE IF EXISTS `roster_players`;
+DROP TABLE IF EXISTS `advanced_box_scores`;
+DROP TABLE IF EXISTS `player_season_ppa`;
+DROP TABLE IF EXISTS `player_game_ppa`;
+DROP TABLE IF EXISTS `team_game_ppa`;
+DROP TABLE IF EXISTS `team_season_ppa`;
+DROP TABLE IF EXISTS `predicted_points_values`;
+DROP TABLE IF EXISTS `field_goal_ep`;
+DROP TABLE IF EXISTS `pregame_win_probability`;
+DROP TABLE IF EXISTS `play_win_probability`;
+DROP TABLE IF EXISTS `game_weather_snapshots`;
+DROP TABLE IF EXISTS `game_weather`;
+DROP TABLE IF EXISTS `game_media`;
+DROP TABLE IF EXISTS `live_game_plays`;
+DROP TABLE IF EXISTS `live_game_drives`;
+DROP TABLE IF EXISTS `live_game_teams`;
+DROP TABLE IF EXISTS `live_games`;
+DROP TABLE IF EXISTS `game_player_stat_players`;
+DROP TABLE IF EXISTS `game_player_stat_types`;
+DROP TABLE IF EXISTS `game_player_stat_categories`;
+DROP TABLE IF EXISTS `game_player_stats_teams`;
+DROP TABLE IF EXISTS `game_player_stats`;
+DROP TABLE IF EXISTS `game_team_stats_team_stats`;
+DROP TABLE IF EXISTS `game_team_stats_teams`;
+DROP TABLE IF EXISTS `game_team_stats`;
+DROP TABLE IF EXISTS `play_stats`;
+DROP TABLE IF EXISTS `plays`;
+DROP TABLE IF EXISTS `drives`;
+DROP TABLE IF EXISTS `play_stat_types`;
+DROP TABLE IF EXISTS `play_types`;
+DROP TABLE IF EXISTS `team_records`;
+DROP TABLE IF EXISTS `scoreboard`;
+DROP TABLE IF EXISTS `calendar_weeks`;
+DROP TABLE IF EXISTS `matchup_games`;
+DROP TABLE IF EXISTS `matchups`;
+DROP TABLE IF EXISTS `games`;
+DROP TABLE IF EXISTS `teams`;
+DROP TABLE IF EXISTS `conferences`;
+DROP TABLE IF EXISTS `venues`;
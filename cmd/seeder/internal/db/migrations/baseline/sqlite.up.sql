@@ -0,0 +1,1353 @@
+-- The baseline schema on SQLite: every table as the models defined it when
+-- versioned migrations were introduced. This file is frozen; change the
+-- schema with a new migration instead. {{payload}} is the type of the
+-- CompressedJSON columns, which depends on Config.CompressPayloads.
+
+CREATE TABLE IF NOT EXISTS `venues` (
+    `id` integer,
+    `name` text NOT NULL,
+    `city` text,
+    `state` text,
+    `zip` text,
+    `country_code` text,
+    `timezone` text,
+    `latitude` real,
+    `longitude` real,
+    `elevation` text,
+    `capacity` integer,
+    `construction_year` integer,
+    `grass` numeric,
+    `dome` numeric,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `conferences` (
+    `id` integer,
+    `name` text NOT NULL,
+    `short_name` text,
+    `abbreviation` text,
+    `classification` text,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `teams` (
+    `id` integer,
+    `school` text NOT NULL,
+    `mascot` text,
+    `abbreviation` text,
+    `alternate_names` text,
+    `conference` text,
+    `division` text,
+    `classification` text,
+    `color` text,
+    `alternate_color` text,
+    `logos` text,
+    `twitter` text,
+    `venue_id` integer,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_teams_venue_id` ON `teams`(`venue_id`);
+
+CREATE TABLE IF NOT EXISTS `games` (
+    `id` integer,
+    `season` integer NOT NULL,
+    `week` integer NOT NULL,
+    `season_type` text NOT NULL,
+    `start_date` datetime,
+    `start_time_tbd` numeric NOT NULL,
+    `completed` numeric NOT NULL,
+    `neutral_site` numeric NOT NULL,
+    `conference_game` numeric NOT NULL,
+    `attendance` integer,
+    `venue_id` integer,
+    `venue` text,
+    `home_id` integer,
+    `home_team` text,
+    `home_conference` text,
+    `home_classification` text,
+    `home_points` integer,
+    `home_line_scores` text,
+    `home_postgame_win_probability` real,
+    `home_pregame_elo` integer,
+    `home_postgame_elo` integer,
+    `away_id` integer,
+    `away_team` text,
+    `away_conference` text,
+    `away_classification` text,
+    `away_points` integer,
+    `away_line_scores` text,
+    `away_postgame_win_probability` real,
+    `away_pregame_elo` integer,
+    `away_postgame_elo` integer,
+    `excitement_index` real,
+    `highlights` text,
+    `notes` text,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_games_away_id` ON `games`(`away_id`);
+CREATE INDEX IF NOT EXISTS `idx_games_home_id` ON `games`(`home_id`);
+CREATE INDEX IF NOT EXISTS `idx_games_venue_id` ON `games`(`venue_id`);
+CREATE INDEX IF NOT EXISTS `idx_games_completed` ON `games`(`completed`);
+CREATE INDEX IF NOT EXISTS `idx_games_start_date` ON `games`(`start_date`);
+CREATE INDEX IF NOT EXISTS `idx_games_season_type` ON `games`(`season_type`);
+CREATE INDEX IF NOT EXISTS `idx_games_week` ON `games`(`week`);
+CREATE INDEX IF NOT EXISTS `idx_games_season` ON `games`(`season`);
+
+CREATE TABLE IF NOT EXISTS `matchups` (
+    `matchup_id` integer,
+    `team1` text NOT NULL,
+    `team2` text NOT NULL,
+    `start_year` integer,
+    `end_year` integer,
+    `team1_wins` integer NOT NULL,
+    `team2_wins` integer NOT NULL,
+    `ties` integer NOT NULL,
+    PRIMARY KEY (`matchup_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `matchup_games` (
+    `id` integer,
+    `matchup_id` integer NOT NULL,
+    `season` integer NOT NULL,
+    `week` integer NOT NULL,
+    `season_type` text NOT NULL,
+    `date` text,
+    `neutral_site` numeric NOT NULL,
+    `venue` text,
+    `home_team` text NOT NULL,
+    `home_score` integer,
+    `away_team` text NOT NULL,
+    `away_score` integer,
+    `winner` text,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_matchup_games_matchup_id` ON `matchup_games`(`matchup_id`);
+
+CREATE TABLE IF NOT EXISTS `calendar_weeks` (
+    `season` integer,
+    `week` integer,
+    `season_type` text,
+    `start_date` datetime,
+    `end_date` datetime,
+    `first_game_start` datetime,
+    `last_game_start` datetime,
+    PRIMARY KEY (`season`,`week`,`season_type`)
+);
+
+CREATE TABLE IF NOT EXISTS `scoreboard` (
+    `id` integer,
+    `start_date` datetime,
+    `start_time_tbd` numeric NOT NULL,
+    `tv` text,
+    `neutral_site` numeric NOT NULL,
+    `conference_game` numeric NOT NULL,
+    `status` text,
+    `period` integer,
+    `clock` text,
+    `situation` text,
+    `possession` text,
+    `last_play` text,
+    `venue` {{payload}},
+    `home_team` {{payload}},
+    `away_team` {{payload}},
+    `weather` {{payload}},
+    `betting` {{payload}},
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_records` (
+    `year` integer,
+    `team` text,
+    `team_id` integer,
+    `classification` text,
+    `conference` text,
+    `division` text,
+    `expected_wins` real,
+    `total_games` integer NOT NULL,
+    `total_wins` integer NOT NULL,
+    `total_losses` integer NOT NULL,
+    `total_ties` integer NOT NULL,
+    `conference_games_games` integer NOT NULL,
+    `conference_games_wins` integer NOT NULL,
+    `conference_games_losses` integer NOT NULL,
+    `conference_games_ties` integer NOT NULL,
+    `home_games_games` integer NOT NULL,
+    `home_games_wins` integer NOT NULL,
+    `home_games_losses` integer NOT NULL,
+    `home_games_ties` integer NOT NULL,
+    `away_games_games` integer NOT NULL,
+    `away_games_wins` integer NOT NULL,
+    `away_games_losses` integer NOT NULL,
+    `away_games_ties` integer NOT NULL,
+    `neutral_site_games_games` integer NOT NULL,
+    `neutral_site_games_wins` integer NOT NULL,
+    `neutral_site_games_losses` integer NOT NULL,
+    `neutral_site_games_ties` integer NOT NULL,
+    `regular_season_games` integer NOT NULL,
+    `regular_season_wins` integer NOT NULL,
+    `regular_season_losses` integer NOT NULL,
+    `regular_season_ties` integer NOT NULL,
+    `postseason_games` integer NOT NULL,
+    `postseason_wins` integer NOT NULL,
+    `postseason_losses` integer NOT NULL,
+    `postseason_ties` integer NOT NULL,
+    PRIMARY KEY (`year`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `play_types` (
+    `id` integer,
+    `text` text NOT NULL,
+    `abbreviation` text,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `play_stat_types` (
+    `id` integer,
+    `name` text NOT NULL,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `drives` (
+    `id` text,
+    `season` integer,
+    `game_id` integer NOT NULL,
+    `offense` text,
+    `offense_conference` text,
+    `defense` text,
+    `defense_conference` text,
+    `drive_number` integer,
+    `scoring` numeric NOT NULL,
+    `start_period` integer NOT NULL,
+    `start_yardline` integer NOT NULL,
+    `start_yards_to_goal` integer NOT NULL,
+    `start_time_minutes` integer,
+    `start_time_seconds` integer,
+    `end_period` integer NOT NULL,
+    `end_yardline` integer NOT NULL,
+    `end_yards_to_goal` integer NOT NULL,
+    `end_time_minutes` integer,
+    `end_time_seconds` integer,
+    `elapsed_minutes` integer,
+    `elapsed_seconds` integer,
+    `plays` integer NOT NULL,
+    `yards` integer NOT NULL,
+    `drive_result` text,
+    `is_home_offense` numeric NOT NULL,
+    `start_offense_score` integer NOT NULL,
+    `start_defense_score` integer NOT NULL,
+    `end_offense_score` integer NOT NULL,
+    `end_defense_score` integer NOT NULL,
+    PRIMARY KEY (`id`,`season`)
+);
+CREATE INDEX IF NOT EXISTS `idx_drives_drive_number` ON `drives`(`drive_number`);
+CREATE INDEX IF NOT EXISTS `idx_drives_game_id` ON `drives`(`game_id`);
+
+CREATE TABLE IF NOT EXISTS `plays` (
+    `id` text,
+    `season` integer,
+    `drive_id` text,
+    `game_id` integer NOT NULL,
+    `drive_number` integer,
+    `play_number` integer,
+    `offense` text,
+    `offense_conference` text,
+    `offense_score` integer NOT NULL,
+    `defense` text,
+    `home` text,
+    `away` text,
+    `defense_conference` text,
+    `defense_score` integer NOT NULL,
+    `period` integer NOT NULL,
+    `clock_minutes` integer,
+    `clock_seconds` integer,
+    `offense_timeouts` integer,
+    `defense_timeouts` integer,
+    `yardline` integer NOT NULL,
+    `yards_to_goal` integer NOT NULL,
+    `down` integer NOT NULL,
+    `distance` integer NOT NULL,
+    `yards_gained` integer NOT NULL,
+    `scoring` numeric NOT NULL,
+    `play_type` text,
+    `play_text` text,
+    `ppa` real,
+    `wallclock` text,
+    `success` numeric,
+    `garbage_time` numeric,
+    `rush_pass` text,
+    `down_type` text,
+    PRIMARY KEY (`id`,`season`)
+);
+CREATE INDEX IF NOT EXISTS `idx_plays_play_type` ON `plays`(`play_type`);
+CREATE INDEX IF NOT EXISTS `idx_plays_scoring` ON `plays`(`scoring`);
+CREATE INDEX IF NOT EXISTS `idx_plays_down` ON `plays`(`down`);
+CREATE INDEX IF NOT EXISTS `idx_plays_period` ON `plays`(`period`);
+CREATE INDEX IF NOT EXISTS `idx_plays_defense` ON `plays`(`defense`);
+CREATE INDEX IF NOT EXISTS `idx_plays_offense` ON `plays`(`offense`);
+CREATE INDEX IF NOT EXISTS `idx_plays_play_number` ON `plays`(`play_number`);
+CREATE INDEX IF NOT EXISTS `idx_plays_game_id` ON `plays`(`game_id`);
+CREATE INDEX IF NOT EXISTS `idx_plays_drive_id` ON `plays`(`drive_id`);
+
+CREATE TABLE IF NOT EXISTS `play_stats` (
+    `id` integer,
+    `game_id` real,
+    `season` real,
+    `week` real,
+    `team` text,
+    `conference` text,
+    `opponent` text,
+    `team_score` real,
+    `opponent_score` real,
+    `drive_id` text,
+    `play_id` text,
+    `period` real,
+    `clock_minutes` real,
+    `clock_seconds` real,
+    `yards_to_goal` real,
+    `down` real,
+    `distance` real,
+    `athlete_id` text,
+    `athlete_name` text,
+    `stat_type` text,
+    `stat` real,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_play_stats_stat_type` ON `play_stats`(`stat_type`);
+CREATE INDEX IF NOT EXISTS `idx_play_stats_athlete_id` ON `play_stats`(`athlete_id`);
+CREATE INDEX IF NOT EXISTS `idx_play_stats_play_id` ON `play_stats`(`play_id`);
+CREATE INDEX IF NOT EXISTS `idx_play_stats_drive_id` ON `play_stats`(`drive_id`);
+CREATE INDEX IF NOT EXISTS `idx_play_stats_team` ON `play_stats`(`team`);
+CREATE INDEX IF NOT EXISTS `idx_play_stats_week` ON `play_stats`(`week`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_play_stats_natural` ON `play_stats`(`play_id`,`athlete_id`,`stat_type`,`season`);
+CREATE INDEX IF NOT EXISTS `idx_play_stats_season` ON `play_stats`(`season`);
+CREATE INDEX IF NOT EXISTS `idx_play_stats_game_id` ON `play_stats`(`game_id`);
+
+CREATE TABLE IF NOT EXISTS `game_team_stats` (
+    `id` integer,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_team_stats_teams` (
+    `id` integer,
+    `game_id` integer NOT NULL,
+    `team_id` integer NOT NULL,
+    `team` text NOT NULL,
+    `conference` text,
+    `home_away` text,
+    `points` integer,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_game_team_stats_teams_team_id` ON `game_team_stats_teams`(`team_id`);
+CREATE INDEX IF NOT EXISTS `idx_game_team_stats_teams_game_id` ON `game_team_stats_teams`(`game_id`);
+
+CREATE TABLE IF NOT EXISTS `game_team_stats_team_stats` (
+    `id` integer,
+    `team_row_id` integer NOT NULL,
+    `category` text NOT NULL,
+    `stat` text NOT NULL,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_game_team_stats_team_stats_category` ON `game_team_stats_team_stats`(`category`);
+CREATE INDEX IF NOT EXISTS `idx_game_team_stats_team_stats_team_row_id` ON `game_team_stats_team_stats`(`team_row_id`);
+
+CREATE TABLE IF NOT EXISTS `game_player_stats` (
+    `id` integer,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_player_stats_teams` (
+    `id` integer,
+    `game_id` integer NOT NULL,
+    `team` text NOT NULL,
+    `conference` text,
+    `home_away` text,
+    `points` integer,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_game_player_stats_teams_team` ON `game_player_stats_teams`(`team`);
+CREATE INDEX IF NOT EXISTS `idx_game_player_stats_teams_game_id` ON `game_player_stats_teams`(`game_id`);
+
+CREATE TABLE IF NOT EXISTS `game_player_stat_categories` (
+    `id` integer,
+    `team_row_id` integer NOT NULL,
+    `name` text NOT NULL,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_game_player_stat_categories_name` ON `game_player_stat_categories`(`name`);
+CREATE INDEX IF NOT EXISTS `idx_game_player_stat_categories_team_row_id` ON `game_player_stat_categories`(`team_row_id`);
+
+CREATE TABLE IF NOT EXISTS `game_player_stat_types` (
+    `id` integer,
+    `category_row_id` integer NOT NULL,
+    `name` text NOT NULL,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_game_player_stat_types_name` ON `game_player_stat_types`(`name`);
+CREATE INDEX IF NOT EXISTS `idx_game_player_stat_types_category_row_id` ON `game_player_stat_types`(`category_row_id`);
+
+CREATE TABLE IF NOT EXISTS `game_player_stat_players` (
+    `id` integer,
+    `type_row_id` integer NOT NULL,
+    `player_id` text NOT NULL,
+    `name` text NOT NULL,
+    `stat` text NOT NULL,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_game_player_stat_players_player_id` ON `game_player_stat_players`(`player_id`);
+CREATE INDEX IF NOT EXISTS `idx_game_player_stat_players_type_row_id` ON `game_player_stat_players`(`type_row_id`);
+
+CREATE TABLE IF NOT EXISTS `live_games` (
+    `id` integer,
+    `status` text,
+    `period` integer,
+    `clock` text,
+    `possession` text,
+    `down` integer,
+    `distance` integer,
+    `yards_to_goal` integer,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `live_game_teams` (
+    `id` integer,
+    `live_game_id` integer NOT NULL,
+    `team_id` integer NOT NULL,
+    `team` text NOT NULL,
+    `home_away` text,
+    `line_scores` text,
+    `points` integer NOT NULL,
+    `drives` integer NOT NULL,
+    `scoring_opportunities` integer NOT NULL,
+    `points_per_opportunity` real NOT NULL,
+    `average_start_yard_line` real,
+    `plays` integer NOT NULL,
+    `line_yards` real NOT NULL,
+    `line_yards_per_rush` real NOT NULL,
+    `second_level_yards` real NOT NULL,
+    `second_level_yards_per_rush` real NOT NULL,
+    `open_field_yards` real NOT NULL,
+    `open_field_yards_per_rush` real NOT NULL,
+    `epa_per_play` real NOT NULL,
+    `total_epa` real NOT NULL,
+    `passing_epa` real NOT NULL,
+    `epa_per_pass` real NOT NULL,
+    `rushing_epa` real NOT NULL,
+    `epa_per_rush` real NOT NULL,
+    `success_rate` real NOT NULL,
+    `standard_down_success_rate` real NOT NULL,
+    `passing_down_success_rate` real NOT NULL,
+    `explosiveness` real NOT NULL,
+    `deserve_to_win` real,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_live_game_teams_team_id` ON `live_game_teams`(`team_id`);
+CREATE INDEX IF NOT EXISTS `idx_live_game_teams_live_game_id` ON `live_game_teams`(`live_game_id`);
+
+CREATE TABLE IF NOT EXISTS `live_game_drives` (
+    `id` text,
+    `live_game_id` integer NOT NULL,
+    `offense_id` integer,
+    `offense` text,
+    `defense_id` integer,
+    `defense` text,
+    `play_count` integer NOT NULL,
+    `yards` integer NOT NULL,
+    `start_period` integer NOT NULL,
+    `start_clock` text,
+    `start_yards_to_goal` integer NOT NULL,
+    `end_period` integer,
+    `end_clock` text,
+    `end_yards_to_goal` integer,
+    `duration` text,
+    `scoring_opportunity` numeric NOT NULL,
+    `result` text,
+    `points_gained` integer NOT NULL,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_live_game_drives_live_game_id` ON `live_game_drives`(`live_game_id`);
+
+CREATE TABLE IF NOT EXISTS `live_game_plays` (
+    `id` text,
+    `drive_id` text NOT NULL,
+    `home_score` integer NOT NULL,
+    `away_score` integer NOT NULL,
+    `period` integer NOT NULL,
+    `clock` text,
+    `wall_clock` datetime,
+    `team_id` integer,
+    `team` text,
+    `down` integer,
+    `distance` integer,
+    `yards_to_goal` integer,
+    `yards_gained` integer,
+    `play_type_id` integer,
+    `play_type` text,
+    `epa` real,
+    `garbage_time` numeric NOT NULL,
+    `success` numeric NOT NULL,
+    `rush_pass` text,
+    `down_type` text,
+    `play_text` text,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_live_game_plays_drive_id` ON `live_game_plays`(`drive_id`);
+
+CREATE TABLE IF NOT EXISTS `game_media` (
+    `id` integer,
+    `season` integer,
+    `week` integer,
+    `season_type` text,
+    `start_time` datetime,
+    `is_start_time_tbd` numeric NOT NULL,
+    `home_team` text,
+    `home_conference` text,
+    `away_team` text,
+    `away_conference` text,
+    `media_type` text,
+    `outlet` text,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_game_media_season_type` ON `game_media`(`season_type`);
+CREATE INDEX IF NOT EXISTS `idx_game_media_week` ON `game_media`(`week`);
+CREATE INDEX IF NOT EXISTS `idx_game_media_season` ON `game_media`(`season`);
+
+CREATE TABLE IF NOT EXISTS `game_weather` (
+    `id` integer,
+    `season` integer,
+    `week` integer,
+    `season_type` text,
+    `start_time` datetime,
+    `game_indoors` numeric NOT NULL,
+    `home_team` text,
+    `home_conference` text,
+    `away_team` text,
+    `away_conference` text,
+    `venue_id` integer,
+    `venue` text,
+    `temperature` real,
+    `dew_point` real,
+    `humidity` real,
+    `precipitation` real,
+    `snowfall` real,
+    `wind_direction` real,
+    `wind_speed` real,
+    `pressure` real,
+    `weather_condition_code` real,
+    `weather_condition` text,
+    `source` text NOT NULL DEFAULT "cfbd",
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_game_weather_venue_id` ON `game_weather`(`venue_id`);
+CREATE INDEX IF NOT EXISTS `idx_game_weather_season_type` ON `game_weather`(`season_type`);
+CREATE INDEX IF NOT EXISTS `idx_game_weather_week` ON `game_weather`(`week`);
+CREATE INDEX IF NOT EXISTS `idx_game_weather_season` ON `game_weather`(`season`);
+
+CREATE TABLE IF NOT EXISTS `game_weather_snapshots` (
+    `game_id` integer,
+    `kind` text,
+    `captured_at` datetime NOT NULL,
+    `temperature` real,
+    `dew_point` real,
+    `humidity` real,
+    `precipitation` real,
+    `snowfall` real,
+    `wind_direction` real,
+    `wind_speed` real,
+    `pressure` real,
+    `weather_condition_code` real,
+    `weather_condition` text,
+    PRIMARY KEY (`game_id`,`kind`)
+);
+
+CREATE TABLE IF NOT EXISTS `play_win_probability` (
+    `game_id` integer,
+    `play_id` text,
+    `play_text` text,
+    `home_id` integer,
+    `home` text,
+    `away_id` integer,
+    `away` text,
+    `spread` real,
+    `home_ball` numeric NOT NULL,
+    `home_score` integer NOT NULL,
+    `away_score` integer NOT NULL,
+    `yard_line` integer NOT NULL,
+    `down` integer NOT NULL,
+    `distance` integer NOT NULL,
+    `home_win_probability` real NOT NULL,
+    `play_number` integer NOT NULL,
+    PRIMARY KEY (`game_id`,`play_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `pregame_win_probability` (
+    `game_id` integer,
+    `season` integer,
+    `season_type` text,
+    `week` integer,
+    `home_team` text,
+    `away_team` text,
+    `spread` real,
+    `home_win_probability` real,
+    PRIMARY KEY (`game_id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_pregame_win_probability_week` ON `pregame_win_probability`(`week`);
+CREATE INDEX IF NOT EXISTS `idx_pregame_win_probability_season_type` ON `pregame_win_probability`(`season_type`);
+CREATE INDEX IF NOT EXISTS `idx_pregame_win_probability_season` ON `pregame_win_probability`(`season`);
+
+CREATE TABLE IF NOT EXISTS `field_goal_ep` (
+    `yards_to_goal` integer,
+    `distance` integer,
+    `expected_points` real NOT NULL,
+    PRIMARY KEY (`yards_to_goal`,`distance`)
+);
+
+CREATE TABLE IF NOT EXISTS `predicted_points_values` (
+    `down` integer,
+    `distance` integer,
+    `yard_line` integer,
+    `predicted_points` real NOT NULL,
+    PRIMARY KEY (`down`,`distance`,`yard_line`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_season_ppa` (
+    `season` integer,
+    `conference` text,
+    `team` text,
+    `offense` text,
+    `defense` text,
+    PRIMARY KEY (`season`,`conference`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_game_ppa` (
+    `game_id` integer,
+    `season` integer,
+    `week` integer,
+    `season_type` text,
+    `team` text,
+    `conference` text,
+    `opponent` text,
+    `offense` text,
+    `defense` text,
+    PRIMARY KEY (`game_id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_team_game_ppa_opponent` ON `team_game_ppa`(`opponent`);
+CREATE INDEX IF NOT EXISTS `idx_team_game_ppa_team` ON `team_game_ppa`(`team`);
+CREATE INDEX IF NOT EXISTS `idx_team_game_ppa_season_type` ON `team_game_ppa`(`season_type`);
+CREATE INDEX IF NOT EXISTS `idx_team_game_ppa_week` ON `team_game_ppa`(`week`);
+CREATE INDEX IF NOT EXISTS `idx_team_game_ppa_season` ON `team_game_ppa`(`season`);
+
+CREATE TABLE IF NOT EXISTS `player_game_ppa` (
+    `season` integer,
+    `week` integer,
+    `season_type` text,
+    `player_id` text,
+    `name` text,
+    `position` text,
+    `team` text,
+    `opponent` text,
+    `average_ppa` text,
+    PRIMARY KEY (`season`,`week`,`season_type`,`player_id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_player_game_ppa_opponent` ON `player_game_ppa`(`opponent`);
+CREATE INDEX IF NOT EXISTS `idx_player_game_ppa_team` ON `player_game_ppa`(`team`);
+
+CREATE TABLE IF NOT EXISTS `player_season_ppa` (
+    `season` integer,
+    `player_id` text,
+    `name` text,
+    `position` text,
+    `team` text,
+    `conference` text,
+    `average_ppa` text,
+    `total_ppa` text,
+    PRIMARY KEY (`season`,`player_id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_player_season_ppa_team` ON `player_season_ppa`(`team`);
+
+CREATE TABLE IF NOT EXISTS `advanced_box_scores` (
+    `game_id` integer,
+    `payload` {{payload}},
+    PRIMARY KEY (`game_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `roster_players` (
+    `id` text,
+    `first_name` text NOT NULL,
+    `last_name` text NOT NULL,
+    `team` text NOT NULL,
+    `height` real,
+    `weight` integer,
+    `jersey` integer,
+    `position` text,
+    `home_city` text,
+    `home_state` text,
+    `home_country` text,
+    `home_latitude` real,
+    `home_longitude` real,
+    `home_county_fips` text,
+    `recruit_ids` text,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_roster_players_team` ON `roster_players`(`team`);
+
+CREATE TABLE IF NOT EXISTS `player_search_results` (
+    `id` text,
+    `team` text,
+    `name` text NOT NULL,
+    `first_name` text,
+    `last_name` text,
+    `weight` integer,
+    `height` real,
+    `jersey` integer,
+    `position` text,
+    `hometown` text,
+    `team_color` text,
+    `team_color_secondary` text,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_player_search_results_position` ON `player_search_results`(`position`);
+CREATE INDEX IF NOT EXISTS `idx_player_search_results_team` ON `player_search_results`(`team`);
+
+CREATE TABLE IF NOT EXISTS `player_usage_splits` (
+    `id` integer,
+    `passing_downs` real,
+    `standard_downs` real,
+    `third_down` real,
+    `second_down` real,
+    `first_down` real,
+    `rush` real,
+    `pass` real,
+    `overall` real,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `player_usage` (
+    `season` integer,
+    `id` text,
+    `name` text NOT NULL,
+    `position` text,
+    `team` text,
+    `conference` text,
+    `usage_id` integer,
+    PRIMARY KEY (`season`,`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_player_usage_usage_id` ON `player_usage`(`usage_id`);
+CREATE INDEX IF NOT EXISTS `idx_player_usage_team` ON `player_usage`(`team`);
+CREATE INDEX IF NOT EXISTS `idx_player_usage_position` ON `player_usage`(`position`);
+
+CREATE TABLE IF NOT EXISTS `returning_production` (
+    `season` integer,
+    `team` text,
+    `conference` text,
+    `total_ppa` real NOT NULL,
+    `total_passing_ppa` real NOT NULL,
+    `total_receiving_ppa` real NOT NULL,
+    `total_rushing_ppa` real NOT NULL,
+    `percent_ppa` real NOT NULL,
+    `percent_passing_ppa` real NOT NULL,
+    `percent_receiving_ppa` real NOT NULL,
+    `percent_rushing_ppa` real NOT NULL,
+    `usage` real NOT NULL,
+    `passing_usage` real NOT NULL,
+    `receiving_usage` real NOT NULL,
+    `rushing_usage` real NOT NULL,
+    PRIMARY KEY (`season`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `player_transfers` (
+    `season` integer,
+    `first_name` text,
+    `last_name` text,
+    `position` text,
+    `origin` text,
+    `destination` text,
+    `transfer_date` datetime,
+    `rating` real,
+    `stars` integer,
+    `eligibility` text,
+    PRIMARY KEY (`season`,`first_name`,`last_name`)
+);
+
+CREATE TABLE IF NOT EXISTS `player_stats` (
+    `id` integer,
+    `season` integer NOT NULL,
+    `player_id` text NOT NULL,
+    `player` text,
+    `position` text,
+    `team` text,
+    `conference` text,
+    `category` text,
+    `stat_type` text,
+    `stat` text,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_player_stats_stat_type` ON `player_stats`(`stat_type`);
+CREATE INDEX IF NOT EXISTS `idx_player_stats_category` ON `player_stats`(`category`);
+CREATE INDEX IF NOT EXISTS `idx_player_stats_team` ON `player_stats`(`team`);
+CREATE INDEX IF NOT EXISTS `idx_player_stats_position` ON `player_stats`(`position`);
+CREATE INDEX IF NOT EXISTS `idx_player_stats_player_id` ON `player_stats`(`player_id`);
+CREATE INDEX IF NOT EXISTS `idx_player_stats_season` ON `player_stats`(`season`);
+
+CREATE TABLE IF NOT EXISTS `team_stats` (
+    `id` integer,
+    `season` integer NOT NULL,
+    `team` text NOT NULL,
+    `conference` text,
+    `stat_name` text NOT NULL,
+    `stat_value` text,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_team_stats_stat_name` ON `team_stats`(`stat_name`);
+CREATE INDEX IF NOT EXISTS `idx_team_stats_team` ON `team_stats`(`team`);
+CREATE INDEX IF NOT EXISTS `idx_team_stats_season` ON `team_stats`(`season`);
+
+CREATE TABLE IF NOT EXISTS `recruit_hometown_info` (
+    `id` integer,
+    `fips_code` text,
+    `longitude` real,
+    `latitude` real,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `recruits` (
+    `id` text,
+    `athlete_id` text,
+    `recruit_type` text,
+    `year` integer NOT NULL,
+    `ranking` integer,
+    `name` text NOT NULL,
+    `school` text,
+    `committed_to` text,
+    `position` text,
+    `height` real,
+    `weight` integer,
+    `stars` integer NOT NULL,
+    `rating` real NOT NULL,
+    `city` text,
+    `state_province` text,
+    `country` text,
+    `hometown_info_id` integer,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_recruits_hometown_info_id` ON `recruits`(`hometown_info_id`);
+CREATE INDEX IF NOT EXISTS `idx_recruits_position` ON `recruits`(`position`);
+CREATE INDEX IF NOT EXISTS `idx_recruits_committed_to` ON `recruits`(`committed_to`);
+CREATE INDEX IF NOT EXISTS `idx_recruits_year` ON `recruits`(`year`);
+CREATE INDEX IF NOT EXISTS `idx_recruits_recruit_type` ON `recruits`(`recruit_type`);
+CREATE INDEX IF NOT EXISTS `idx_recruits_athlete_id` ON `recruits`(`athlete_id`);
+
+CREATE TABLE IF NOT EXISTS `team_recruiting_rankings` (
+    `year` integer,
+    `team` text,
+    `rank` integer NOT NULL,
+    `points` real NOT NULL,
+    PRIMARY KEY (`year`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `aggregated_team_recruiting` (
+    `team` text,
+    `conference` text,
+    `position_group` text,
+    `average_rating` real NOT NULL,
+    `total_rating` real NOT NULL,
+    `commits` integer NOT NULL,
+    `average_stars` real NOT NULL,
+    PRIMARY KEY (`team`,`conference`,`position_group`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_sp` (
+    `year` integer,
+    `team` text,
+    `conference` text,
+    `payload` text,
+    PRIMARY KEY (`year`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `conference_sp` (
+    `year` integer,
+    `conference` text,
+    `payload` text,
+    PRIMARY KEY (`year`,`conference`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_srs` (
+    `year` integer,
+    `team` text,
+    `conference` text,
+    `division` text,
+    `rating` real NOT NULL,
+    `ranking` integer,
+    PRIMARY KEY (`year`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_elo` (
+    `year` integer,
+    `team` text,
+    `conference` text,
+    `elo` integer,
+    PRIMARY KEY (`year`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_fpi` (
+    `year` integer,
+    `team` text,
+    `conference` text,
+    `payload` text,
+    PRIMARY KEY (`year`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `poll_weeks` (
+    `id` integer,
+    `season` integer NOT NULL,
+    `season_type` text NOT NULL,
+    `week` integer NOT NULL,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_poll_weeks_week` ON `poll_weeks`(`week`);
+CREATE INDEX IF NOT EXISTS `idx_poll_weeks_season_type` ON `poll_weeks`(`season_type`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_poll_weeks_natural` ON `poll_weeks`(`season`,`season_type`,`week`);
+CREATE INDEX IF NOT EXISTS `idx_poll_weeks_season` ON `poll_weeks`(`season`);
+
+CREATE TABLE IF NOT EXISTS `polls` (
+    `id` integer,
+    `poll_week_id` integer NOT NULL,
+    `poll` text NOT NULL,
+    PRIMARY KEY (`id`)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_polls_natural` ON `polls`(`poll_week_id`,`poll`);
+CREATE INDEX IF NOT EXISTS `idx_polls_poll_week_id` ON `polls`(`poll_week_id`);
+
+CREATE TABLE IF NOT EXISTS `poll_ranks` (
+    `id` integer,
+    `poll_id` integer NOT NULL,
+    `rank` integer,
+    `team_id` integer,
+    `school` text NOT NULL,
+    `conference` text,
+    `first_place_votes` integer,
+    `points` integer,
+    PRIMARY KEY (`id`)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_poll_ranks_natural` ON `poll_ranks`(`poll_id`,`school`);
+CREATE INDEX IF NOT EXISTS `idx_poll_ranks_poll_id` ON `poll_ranks`(`poll_id`);
+
+CREATE TABLE IF NOT EXISTS `betting_games` (
+    `id` integer,
+    `season` integer NOT NULL,
+    `season_type` text NOT NULL,
+    `week` integer NOT NULL,
+    `start_date` datetime,
+    `home_team_id` integer,
+    `home_team` text,
+    `home_conference` text,
+    `home_classification` text,
+    `home_score` integer,
+    `away_team_id` integer,
+    `away_team` text,
+    `away_conference` text,
+    `away_classification` text,
+    `away_score` integer,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_betting_games_away_team_id` ON `betting_games`(`away_team_id`);
+CREATE INDEX IF NOT EXISTS `idx_betting_games_home_team_id` ON `betting_games`(`home_team_id`);
+CREATE INDEX IF NOT EXISTS `idx_betting_games_week` ON `betting_games`(`week`);
+CREATE INDEX IF NOT EXISTS `idx_betting_games_season_type` ON `betting_games`(`season_type`);
+CREATE INDEX IF NOT EXISTS `idx_betting_games_season` ON `betting_games`(`season`);
+
+CREATE TABLE IF NOT EXISTS `game_lines` (
+    `game_id` integer,
+    `provider` text,
+    `spread` real,
+    `formatted_spread` text,
+    `spread_open` real,
+    `over_under` real,
+    `over_under_open` real,
+    `home_moneyline` real,
+    `away_moneyline` real,
+    PRIMARY KEY (`game_id`,`provider`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_line_snapshots` (
+    `id` integer,
+    `game_id` integer NOT NULL,
+    `provider` text NOT NULL,
+    `captured_at` datetime NOT NULL,
+    `spread` real,
+    `over_under` real,
+    `home_moneyline` real,
+    `away_moneyline` real,
+    `is_closing` numeric NOT NULL,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_game_line_snapshots_is_closing` ON `game_line_snapshots`(`is_closing`);
+CREATE INDEX IF NOT EXISTS `idx_game_line_snapshots_captured_at` ON `game_line_snapshots`(`captured_at`);
+CREATE INDEX IF NOT EXISTS `idx_game_line_snapshots_game_id` ON `game_line_snapshots`(`game_id`);
+
+CREATE TABLE IF NOT EXISTS `draft_teams` (
+    `id` integer,
+    `location` text,
+    `nickname` text,
+    `display_name` text,
+    `logo` text,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `draft_positions` (
+    `id` integer,
+    `name` text,
+    `abbreviation` text,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `draft_pick_hometown_info` (
+    `id` integer,
+    `county_fips` text,
+    `longitude` text,
+    `latitude` text,
+    `country` text,
+    `state` text,
+    `city` text,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `draft_picks` (
+    `id` integer,
+    `college_athlete_id` integer,
+    `nfl_athlete_id` integer,
+    `college_id` integer NOT NULL,
+    `college_team` text,
+    `college_conference` text,
+    `nfl_team_id` integer NOT NULL,
+    `nfl_team` text,
+    `year` integer NOT NULL,
+    `overall` integer NOT NULL,
+    `round` integer NOT NULL,
+    `pick` integer NOT NULL,
+    `name` text NOT NULL,
+    `position` text,
+    `height` real,
+    `weight` integer,
+    `pre_draft_ranking` integer,
+    `pre_draft_position_ranking` integer,
+    `pre_draft_grade` integer,
+    `hometown_info_id` integer,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_draft_picks_hometown_info_id` ON `draft_picks`(`hometown_info_id`);
+CREATE INDEX IF NOT EXISTS `idx_draft_picks_year` ON `draft_picks`(`year`);
+CREATE INDEX IF NOT EXISTS `idx_draft_picks_nfl_team_id` ON `draft_picks`(`nfl_team_id`);
+CREATE INDEX IF NOT EXISTS `idx_draft_picks_college_id` ON `draft_picks`(`college_id`);
+
+CREATE TABLE IF NOT EXISTS `coaches` (
+    `id` integer,
+    `first_name` text NOT NULL,
+    `last_name` text NOT NULL,
+    `hire_date` datetime,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `coach_seasons` (
+    `id` integer,
+    `coach_id` integer NOT NULL,
+    `school` text NOT NULL,
+    `year` integer NOT NULL,
+    `games` integer NOT NULL,
+    `wins` integer NOT NULL,
+    `losses` integer NOT NULL,
+    `ties` integer NOT NULL,
+    `preseason_rank` integer,
+    `postseason_rank` integer,
+    `srs` real,
+    `sp_overall` real,
+    `sp_offense` real,
+    `sp_defense` real,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_coach_seasons_year` ON `coach_seasons`(`year`);
+CREATE INDEX IF NOT EXISTS `idx_coach_seasons_school` ON `coach_seasons`(`school`);
+CREATE INDEX IF NOT EXISTS `idx_coach_seasons_coach_id` ON `coach_seasons`(`coach_id`);
+
+CREATE TABLE IF NOT EXISTS `adjusted_team_metrics` (
+    `year` integer,
+    `team_id` integer,
+    `team` text NOT NULL,
+    `conference` text,
+    `epa_rushing` real NOT NULL,
+    `epa_passing` real NOT NULL,
+    `epa_total` real NOT NULL,
+    `epa_allowed_rushing` real NOT NULL,
+    `epa_allowed_passing` real NOT NULL,
+    `epa_allowed_total` real NOT NULL,
+    `success_rate_passing_downs` real NOT NULL,
+    `success_rate_standard_downs` real NOT NULL,
+    `success_rate_total` real NOT NULL,
+    `success_rate_allowed_passing_downs` real NOT NULL,
+    `success_rate_allowed_standard_downs` real NOT NULL,
+    `success_rate_allowed_total` real NOT NULL,
+    `rushing_highlight_yards` real NOT NULL,
+    `rushing_open_field_yards` real NOT NULL,
+    `rushing_second_level_yards` real NOT NULL,
+    `rushing_line_yards` real NOT NULL,
+    `rushing_allowed_highlight_yards` real NOT NULL,
+    `rushing_allowed_open_field_yards` real NOT NULL,
+    `rushing_allowed_second_level_yards` real NOT NULL,
+    `rushing_allowed_line_yards` real NOT NULL,
+    `explosiveness` real NOT NULL,
+    `explosiveness_allowed` real NOT NULL,
+    PRIMARY KEY (`year`,`team_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `player_weighted_epa` (
+    `year` integer,
+    `athlete_id` text,
+    `athlete_name` text NOT NULL,
+    `position` text,
+    `team` text,
+    `conference` text,
+    `wepa` real NOT NULL,
+    `plays` integer NOT NULL,
+    PRIMARY KEY (`year`,`athlete_id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_player_weighted_epa_team` ON `player_weighted_epa`(`team`);
+CREATE INDEX IF NOT EXISTS `idx_player_weighted_epa_position` ON `player_weighted_epa`(`position`);
+
+CREATE TABLE IF NOT EXISTS `kicker_paar` (
+    `year` integer,
+    `athlete_id` text,
+    `athlete_name` text NOT NULL,
+    `team` text,
+    `conference` text,
+    `paar` real NOT NULL,
+    `attempts` integer NOT NULL,
+    PRIMARY KEY (`year`,`athlete_id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_kicker_paar_team` ON `kicker_paar`(`team`);
+
+CREATE TABLE IF NOT EXISTS `team_ats` (
+    `year` integer,
+    `team_id` integer,
+    `team` text NOT NULL,
+    `conference` text,
+    `games` integer,
+    `ats_wins` integer NOT NULL,
+    `ats_losses` integer NOT NULL,
+    `ats_pushes` integer NOT NULL,
+    `avg_cover_margin` real,
+    PRIMARY KEY (`year`,`team_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `team_talent` (
+    `year` integer,
+    `team` text,
+    `talent` real NOT NULL,
+    PRIMARY KEY (`year`,`team`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_havoc_stat_sides` (
+    `id` integer,
+    `db_havoc_rate` real NOT NULL,
+    `front_seven_havoc_rate` real NOT NULL,
+    `havoc_rate` real NOT NULL,
+    `db_havoc_events` real NOT NULL,
+    `front_seven_havoc_events` real NOT NULL,
+    `total_havoc_events` real NOT NULL,
+    `total_plays` real NOT NULL,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `game_havoc_stats` (
+    `game_id` integer,
+    `season` integer,
+    `season_type` text,
+    `week` integer,
+    `team` text,
+    `conference` text,
+    `opponent` text,
+    `opponent_conference` text,
+    `offense_id` integer,
+    `defense_id` integer,
+    PRIMARY KEY (`game_id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_game_havoc_stats_defense_id` ON `game_havoc_stats`(`defense_id`);
+CREATE INDEX IF NOT EXISTS `idx_game_havoc_stats_offense_id` ON `game_havoc_stats`(`offense_id`);
+CREATE INDEX IF NOT EXISTS `idx_game_havoc_stats_opponent` ON `game_havoc_stats`(`opponent`);
+CREATE INDEX IF NOT EXISTS `idx_game_havoc_stats_team` ON `game_havoc_stats`(`team`);
+CREATE INDEX IF NOT EXISTS `idx_game_havoc_stats_week` ON `game_havoc_stats`(`week`);
+CREATE INDEX IF NOT EXISTS `idx_game_havoc_stats_season_type` ON `game_havoc_stats`(`season_type`);
+CREATE INDEX IF NOT EXISTS `idx_game_havoc_stats_season` ON `game_havoc_stats`(`season`);
+
+CREATE TABLE IF NOT EXISTS `advanced_rate_metrics` (
+    `id` integer,
+    `explosiveness` real,
+    `success_rate` real,
+    `total_ppa` real,
+    `ppa` real,
+    `rate` real,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `advanced_havoc` (
+    `id` integer,
+    `db` real,
+    `front_seven` real,
+    `total` real,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `advanced_field_position` (
+    `id` integer,
+    `average_predicted_points` real,
+    `average_start` real,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `advanced_season_stat_sides` (
+    `id` integer,
+    `payload` text,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `advanced_season_stats` (
+    `season` integer,
+    `team` text,
+    `conference` text,
+    `offense_side_id` integer,
+    `defense_side_id` integer,
+    PRIMARY KEY (`season`,`team`)
+);
+CREATE INDEX IF NOT EXISTS `idx_advanced_season_stats_defense_side_id` ON `advanced_season_stats`(`defense_side_id`);
+CREATE INDEX IF NOT EXISTS `idx_advanced_season_stats_offense_side_id` ON `advanced_season_stats`(`offense_side_id`);
+
+CREATE TABLE IF NOT EXISTS `advanced_game_stat_sides` (
+    `id` integer,
+    `payload` text,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `advanced_game_stats` (
+    `game_id` integer,
+    `season` integer,
+    `season_type` text,
+    `week` integer,
+    `team` text,
+    `opponent` text,
+    `offense_side_id` integer,
+    `defense_side_id` integer,
+    PRIMARY KEY (`game_id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_advanced_game_stats_defense_side_id` ON `advanced_game_stats`(`defense_side_id`);
+CREATE INDEX IF NOT EXISTS `idx_advanced_game_stats_offense_side_id` ON `advanced_game_stats`(`offense_side_id`);
+CREATE INDEX IF NOT EXISTS `idx_advanced_game_stats_opponent` ON `advanced_game_stats`(`opponent`);
+CREATE INDEX IF NOT EXISTS `idx_advanced_game_stats_team` ON `advanced_game_stats`(`team`);
+CREATE INDEX IF NOT EXISTS `idx_advanced_game_stats_week` ON `advanced_game_stats`(`week`);
+CREATE INDEX IF NOT EXISTS `idx_advanced_game_stats_season_type` ON `advanced_game_stats`(`season_type`);
+CREATE INDEX IF NOT EXISTS `idx_advanced_game_stats_season` ON `advanced_game_stats`(`season`);
+
+CREATE TABLE IF NOT EXISTS `user_info` (
+    `id` integer,
+    `patron_level` real NOT NULL,
+    `remaining_calls` real NOT NULL,
+    `stage` text,
+    `captured_at` datetime NOT NULL,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_user_info_captured_at` ON `user_info`(`captured_at`);
+
+CREATE TABLE IF NOT EXISTS `int32_lists` (
+    `id` integer,
+    `values` text,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `verification_cursors` (
+    `name` text,
+    `season` integer NOT NULL,
+    `week` integer NOT NULL,
+    `season_type` text NOT NULL,
+    `verified_at` datetime NOT NULL,
+    PRIMARY KEY (`name`)
+);
+
+CREATE TABLE IF NOT EXISTS `verification_diffs` (
+    `id` integer,
+    `season` integer NOT NULL,
+    `week` integer NOT NULL,
+    `season_type` text NOT NULL,
+    `entity` text NOT NULL,
+    `entity_id` integer NOT NULL,
+    `field` text NOT NULL,
+    `stored_value` text,
+    `upstream_value` text,
+    `detected_at` datetime NOT NULL,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_verification_diffs_detected_at` ON `verification_diffs`(`detected_at`);
+CREATE INDEX IF NOT EXISTS `idx_verification_diffs_entity_id` ON `verification_diffs`(`entity_id`);
+CREATE INDEX IF NOT EXISTS `idx_verification_diffs_season` ON `verification_diffs`(`season`);
+
+CREATE TABLE IF NOT EXISTS `seed_failures` (
+    `id` integer,
+    `endpoint` text NOT NULL,
+    `params` text NOT NULL,
+    `error` text NOT NULL,
+    `attempts` integer NOT NULL DEFAULT 1,
+    `failed_at` datetime NOT NULL,
+    `resolved_at` datetime,
+    `permanent_at` datetime,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_seed_failures_resolved_at` ON `seed_failures`(`resolved_at`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_seed_failures_unit` ON `seed_failures`(`endpoint`,`params`);
+
+CREATE TABLE IF NOT EXISTS `response_manifest` (
+    `endpoint` text,
+    `params` text,
+    `hash` blob NOT NULL,
+    `fetched_at` datetime NOT NULL,
+    `run_id` integer,
+    PRIMARY KEY (`endpoint`,`params`)
+);
+
+CREATE TABLE IF NOT EXISTS `seed_checkpoints` (
+    `command` text,
+    `tasks` text NOT NULL,
+    `stopped_at` datetime NOT NULL,
+    PRIMARY KEY (`command`)
+);
+
+CREATE TABLE IF NOT EXISTS `row_hashes` (
+    `table_name` text,
+    `row_key` text,
+    `hash` blob NOT NULL,
+    PRIMARY KEY (`table_name`,`row_key`)
+);
+
+CREATE TABLE IF NOT EXISTS `column_units` (
+    `table_name` text,
+    `column_name` text,
+    `unit` text NOT NULL,
+    PRIMARY KEY (`table_name`,`column_name`)
+);
+
+CREATE TABLE IF NOT EXISTS `seed_runs` (
+    `id` integer,
+    `command` text NOT NULL,
+    `status` text NOT NULL,
+    `error` text,
+    `rows_written` integer NOT NULL,
+    `started_at` datetime NOT NULL,
+    `finished_at` datetime,
+    `summary` text,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_seed_runs_started_at` ON `seed_runs`(`started_at`);
+
+CREATE TABLE IF NOT EXISTS `table_manifest` (
+    `table_name` text,
+    `last_synced_at` datetime NOT NULL,
+    `rows_written` integer NOT NULL,
+    `run_id` integer,
+    PRIMARY KEY (`table_name`)
+);
+CREATE INDEX IF NOT EXISTS `idx_table_manifest_run_id` ON `table_manifest`(`run_id`);
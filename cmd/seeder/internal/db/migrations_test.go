@@ -0,0 +1,139 @@
+package db_test
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+)
+
+// automigrated is the schema AutoMigrate created before versioned
+// migrations, one statement per line.
+const automigrated = "testdata/automigrate.postgres.sql"
+
+// automigratedRows are written to the schema in automigrated before it is
+// migrated: a play and its drive without a season, a play stat and a poll
+// week stored twice, and a quota reading without a capture time.
+var automigratedRows = []string{
+	`INSERT INTO games (id, season, week, season_type, start_time_tbd,
+		completed, neutral_site, conference_game)
+	VALUES (1, 2024, 1, 'regular', false, true, false, false)`,
+	`INSERT INTO drives (id, game_id, scoring, start_period, start_yardline,
+		start_yards_to_goal, end_period, end_yardline, end_yards_to_goal,
+		plays, yards, is_home_offense, start_offense_score,
+		start_defense_score, end_offense_score, end_defense_score)
+	VALUES ('1', 1, false, 1, 25, 75, 1, 40, 60, 1, 15, true, 0, 0, 0, 0)`,
+	`INSERT INTO plays (id, drive_id, game_id, offense_score, defense_score,
+		period, yardline, yards_to_goal, down, distance, yards_gained,
+		scoring, play_text)
+	VALUES ('1', '1', 1, 0, 0, 1, 25, 75, 1, 10, 15, false,
+		'Rush for 15 yards')`,
+	`INSERT INTO play_stats (game_id, season, play_id, athlete_id, stat_type)
+	VALUES (1, 2024, '1', '1', 'Rush'), (1, 2024, '1', '1', 'Rush')`,
+	`INSERT INTO poll_weeks (season, season_type, week)
+	VALUES (2024, 'regular', 1), (2024, 'regular', 1)`,
+	`INSERT INTO user_info (patron_level, remaining_calls) VALUES (1, 1000)`,
+}
+
+// TestMigrateAutoMigrated checks that the migrations bring a database
+// AutoMigrate created before versioned migrations to the schema they
+// create from scratch, keeping its rows. It starts a container with Docker,
+// so it is skipped in short mode and where Docker is not available.
+func TestMigrateAutoMigrated(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping database integration test in short mode")
+	}
+
+	ctx := context.Background()
+	dsn := startPostgres(t)
+
+	fresh := connect(t, dsn, "fresh")
+	if err := fresh.Initialize(); err != nil {
+		t.Fatalf("could not initialize fresh schema; %v", err)
+	}
+
+	content, err := os.ReadFile(automigrated)
+	if err != nil {
+		t.Fatalf("could not read %s; %v", automigrated, err)
+	}
+	statements := []string{"CREATE SCHEMA cfbd"}
+	for line := range strings.Lines(string(content)) {
+		if line = strings.TrimSpace(line); line != "" &&
+			!strings.HasPrefix(line, "--") {
+			statements = append(statements, line)
+		}
+	}
+	statements = append(statements, automigratedRows...)
+
+	old := connect(t, dsn, db.DefaultSchema)
+	for _, statement := range statements {
+		if err := old.Exec(statement).Error; err != nil {
+			t.Fatalf("could not create old schema; %v\n%s", err, statement)
+		}
+	}
+	if err := old.Initialize(); err != nil {
+		t.Fatalf("could not migrate old schema; %v", err)
+	}
+
+	want, err := fresh.SchemaDrift(ctx)
+	if err != nil {
+		t.Fatalf("could not check fresh schema; %v", err)
+	}
+	got, err := old.SchemaDrift(ctx)
+	if err != nil {
+		t.Fatalf("could not check migrated schema; %v", err)
+	}
+	sqls := func(drift []db.DDL) []string {
+		var sqls []string
+		for _, ddl := range drift {
+			sqls = append(sqls, ddl.SQL)
+		}
+		slices.Sort(sqls)
+		return sqls
+	}
+	if !slices.Equal(sqls(got), sqls(want)) {
+		t.Errorf("migrated schema drifts by\n%s\nwant\n%s",
+			strings.Join(sqls(got), "\n"), strings.Join(sqls(want), "\n"))
+	}
+
+	for table, want := range map[string]int64{
+		"drives":     1,
+		"plays":      1,
+		"play_stats": 1,
+		"poll_weeks": 1,
+		"user_info":  1,
+	} {
+		var got int64
+		if err := old.Table(table).Count(&got).Error; err != nil {
+			t.Fatalf("could not count %s; %v", table, err)
+		}
+		if got != want {
+			t.Errorf("%s has %d rows, want %d", table, got, want)
+		}
+	}
+
+	var season int64
+	err = old.Raw("SELECT season FROM plays WHERE id = '1'").
+		Scan(&season).Error
+	if err != nil {
+		t.Fatalf("could not read play; %v", err)
+	}
+	if season != 2024 {
+		t.Errorf("play has season %d, want 2024", season)
+	}
+
+	var matches int64
+	err = old.Raw(`
+		SELECT count(*) FROM plays
+		WHERE play_text_search @@ to_tsquery('english', 'rush')`,
+	).Scan(&matches).Error
+	if err != nil {
+		t.Fatalf("could not search plays; %v", err)
+	}
+	if matches != 1 {
+		t.Errorf("play text search found %d plays, want 1", matches)
+	}
+}
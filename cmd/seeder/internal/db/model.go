@@ -340,11 +340,11 @@ type Scoreboard struct {
 	Situation      string         `gorm:"column:situation"`
 	Possession     string         `gorm:"column:possession"`
 	LastPlay       string         `gorm:"column:last_play"`
-	Venue          datatypes.JSON `gorm:"column:venue;type:jsonb"`
-	HomeTeam       datatypes.JSON `gorm:"column:home_team;type:jsonb"`
-	AwayTeam       datatypes.JSON `gorm:"column:away_team;type:jsonb"`
-	Weather        datatypes.JSON `gorm:"column:weather;type:jsonb"`
-	Betting        datatypes.JSON `gorm:"column:betting;type:jsonb"`
+	Venue          CompressedJSON `gorm:"column:venue"`
+	HomeTeam       CompressedJSON `gorm:"column:home_team"`
+	AwayTeam       CompressedJSON `gorm:"column:away_team"`
+	Weather        CompressedJSON `gorm:"column:weather"`
+	Betting        CompressedJSON `gorm:"column:betting"`
 }
 
 func (Scoreboard) TableName() string { return "scoreboard" }
@@ -355,6 +355,7 @@ func (Scoreboard) TableName() string { return "scoreboard" }
 
 type Drive struct {
 	ID                string `gorm:"primaryKey;column:id"`
+	Season            int32  `gorm:"primaryKey;column:season"`
 	GameID            int32  `gorm:"column:game_id;index;not null"`
 	Offense           string `gorm:"column:offense"`
 	OffenseConference string `gorm:"column:offense_conference"`
@@ -388,6 +389,7 @@ func (Drive) TableName() string { return "drives" }
 
 type Play struct {
 	ID                string   `gorm:"primaryKey;column:id"`
+	Season            int32    `gorm:"primaryKey;column:season"`
 	DriveID           string   `gorm:"column:drive_id;index"`
 	GameID            int32    `gorm:"column:game_id;index;not null"`
 	DriveNumber       *int32   `gorm:"column:drive_number"`
@@ -415,6 +417,16 @@ type Play struct {
 	PlayText          string   `gorm:"column:play_text"`
 	PPA               *float64 `gorm:"column:ppa"`
 	Wallclock         string   `gorm:"column:wallclock"`
+	// Success, GarbageTime, RushPass and DownType are derived from the other
+	// columns by ComputePlayFlags rather than loaded from the API, so they
+	// are read-only to GORM. They are null until first computed.
+	Success     *bool   `gorm:"->;column:success"`
+	GarbageTime *bool   `gorm:"->;column:garbage_time"`
+	RushPass    *string `gorm:"->;column:rush_pass"`
+	DownType    *string `gorm:"->;column:down_type"`
+	// PlayTextSearch is the full-text search vector of PlayText. Postgres
+	// maintains it on every insert, so it is read-only to GORM.
+	PlayTextSearch string `gorm:"->;column:play_text_search;type:tsvector GENERATED ALWAYS AS (to_tsvector('english', coalesce(play_text, ''))) STORED;index:idx_plays_play_text_search,type:gin"` //nolint:lll
 }
 
 func (Play) TableName() string { return "plays" }
@@ -432,9 +444,9 @@ func (PlayType) TableName() string { return "play_types" }
 // ============================================================
 
 type PlayStat struct {
-	ID            int64    `gorm:"primaryKey;column:id"`
+	ID            int64    `gorm:"primaryKey;autoIncrement;column:id"`
 	GameID        float64  `gorm:"column:game_id;index"`
-	Season        float64  `gorm:"column:season;index"`
+	Season        float64  `gorm:"primaryKey;column:season;index;uniqueIndex:idx_play_stats_natural,priority:4"` //nolint:lll
 	Week          float64  `gorm:"column:week;index"`
 	Team          string   `gorm:"column:team;index"`
 	Conference    string   `gorm:"column:conference"`
@@ -442,16 +454,16 @@ type PlayStat struct {
 	TeamScore     float64  `gorm:"column:team_score"`
 	OpponentScore float64  `gorm:"column:opponent_score"`
 	DriveID       string   `gorm:"column:drive_id;index"`
-	PlayID        string   `gorm:"column:play_id;index"`
+	PlayID        string   `gorm:"column:play_id;index;uniqueIndex:idx_play_stats_natural,priority:1"` //nolint:lll
 	Period        float64  `gorm:"column:period"`
 	ClockMinutes  *float64 `gorm:"column:clock_minutes"`
 	ClockSeconds  *float64 `gorm:"column:clock_seconds"`
 	YardsToGoal   float64  `gorm:"column:yards_to_goal"`
 	Down          float64  `gorm:"column:down"`
 	Distance      float64  `gorm:"column:distance"`
-	AthleteID     string   `gorm:"column:athlete_id;index"`
+	AthleteID     string   `gorm:"column:athlete_id;index;uniqueIndex:idx_play_stats_natural,priority:2"` //nolint:lll
 	AthleteName   string   `gorm:"column:athlete_name"`
-	StatType      string   `gorm:"column:stat_type;index"`
+	StatType      string   `gorm:"column:stat_type;index;uniqueIndex:idx_play_stats_natural,priority:3"` //nolint:lll
 	Stat          float64  `gorm:"column:stat"`
 }
 
@@ -459,7 +471,7 @@ func (PlayStat) TableName() string { return "play_stats" }
 
 type PlayStatType struct {
 	ID   int32  `gorm:"primaryKey;column:id"`
-	Name string `gorm:"column:name;not null"`
+	Name string `gorm:"column:name;not null;uniqueIndex"`
 }
 
 func (PlayStatType) TableName() string { return "play_stat_types" }
@@ -821,9 +833,9 @@ func (TeamFPI) TableName() string { return "team_fpi" }
 
 type PollWeek struct {
 	ID         int64  `gorm:"primaryKey;column:id"`
-	Season     int32  `gorm:"column:season;index;not null"`
-	SeasonType string `gorm:"column:season_type;index;not null"`
-	Week       int32  `gorm:"column:week;index;not null"`
+	Season     int32  `gorm:"column:season;index;not null;uniqueIndex:idx_poll_weeks_natural,priority:1"`      //nolint:lll
+	SeasonType string `gorm:"column:season_type;index;not null;uniqueIndex:idx_poll_weeks_natural,priority:2"` //nolint:lll
+	Week       int32  `gorm:"column:week;index;not null;uniqueIndex:idx_poll_weeks_natural,priority:3"`        //nolint:lll
 
 	Polls []Poll `gorm:"foreignKey:PollWeekID;references:ID"`
 }
@@ -832,8 +844,8 @@ func (PollWeek) TableName() string { return "poll_weeks" }
 
 type Poll struct {
 	ID         int64  `gorm:"primaryKey;column:id"`
-	PollWeekID int64  `gorm:"column:poll_week_id;index;not null"`
-	Poll       string `gorm:"column:poll;not null"`
+	PollWeekID int64  `gorm:"column:poll_week_id;index;not null;uniqueIndex:idx_polls_natural,priority:1"` //nolint:lll
+	Poll       string `gorm:"column:poll;not null;uniqueIndex:idx_polls_natural,priority:2"`               //nolint:lll
 
 	Ranks []PollRank `gorm:"foreignKey:PollID;references:ID"`
 }
@@ -842,10 +854,10 @@ func (Poll) TableName() string { return "polls" }
 
 type PollRank struct {
 	ID              int64  `gorm:"primaryKey;column:id"`
-	PollID          int64  `gorm:"column:poll_id;index;not null"`
+	PollID          int64  `gorm:"column:poll_id;index;not null;uniqueIndex:idx_poll_ranks_natural,priority:1"` //nolint:lll
 	Rank            *int32 `gorm:"column:rank"`
 	TeamID          *int32 `gorm:"column:team_id"`
-	School          string `gorm:"column:school;not null"`
+	School          string `gorm:"column:school;not null;uniqueIndex:idx_poll_ranks_natural,priority:2"` //nolint:lll
 	Conference      string `gorm:"column:conference"`
 	FirstPlaceVotes *int32 `gorm:"column:first_place_votes"`
 	Points          *int32 `gorm:"column:points"`
@@ -853,6 +865,23 @@ type PollRank struct {
 
 func (PollRank) TableName() string { return "poll_ranks" }
 
+// PollRankHistory is a version of a school's rank in a poll, appended
+// whenever a write changes it, so that the evolution of a poll stays
+// queryable after poll_ranks is overwritten.
+type PollRankHistory struct {
+	ID              int64     `gorm:"primaryKey;column:id"`
+	PollID          int64     `gorm:"column:poll_id;index:idx_poll_ranks_history_rank,priority:1;not null"` //nolint:lll
+	School          string    `gorm:"column:school;index:idx_poll_ranks_history_rank,priority:2;not null"`  //nolint:lll
+	Rank            *int32    `gorm:"column:rank"`
+	TeamID          *int32    `gorm:"column:team_id"`
+	Conference      string    `gorm:"column:conference"`
+	FirstPlaceVotes *int32    `gorm:"column:first_place_votes"`
+	Points          *int32    `gorm:"column:points"`
+	RecordedAt      time.Time `gorm:"column:recorded_at;index;not null"`
+}
+
+func (PollRankHistory) TableName() string { return "poll_ranks_history" }
+
 // ============================================================
 // Betting / lines
 // ============================================================
@@ -893,6 +922,43 @@ type GameLine struct {
 
 func (GameLine) TableName() string { return "game_lines" }
 
+// GameLineHistory is a version of a provider's line for a game, appended
+// whenever a write changes the line, so that line movement stays queryable
+// after game_lines is overwritten.
+type GameLineHistory struct {
+	ID              int64     `gorm:"primaryKey;column:id"`
+	GameID          int32     `gorm:"column:game_id;index:idx_game_lines_history_line,priority:1;not null"`  //nolint:lll
+	Provider        string    `gorm:"column:provider;index:idx_game_lines_history_line,priority:2;not null"` //nolint:lll
+	Spread          *float64  `gorm:"column:spread"`
+	FormattedSpread string    `gorm:"column:formatted_spread"`
+	SpreadOpen      *float64  `gorm:"column:spread_open"`
+	OverUnder       *float64  `gorm:"column:over_under"`
+	OverUnderOpen   *float64  `gorm:"column:over_under_open"`
+	HomeMoneyline   *float64  `gorm:"column:home_moneyline"`
+	AwayMoneyline   *float64  `gorm:"column:away_moneyline"`
+	RecordedAt      time.Time `gorm:"column:recorded_at;index;not null"`
+}
+
+func (GameLineHistory) TableName() string { return "game_lines_history" }
+
+// GameLineSnapshot is a point-in-time capture of a provider's line for a
+// game. Snapshots are appended as kickoff approaches, whenever the line has
+// moved since the last one; the last one taken before kickoff is flagged as
+// the closing line.
+type GameLineSnapshot struct {
+	ID            int64     `gorm:"primaryKey;column:id"`
+	GameID        int32     `gorm:"column:game_id;index;not null"`
+	Provider      string    `gorm:"column:provider;not null"`
+	CapturedAt    time.Time `gorm:"column:captured_at;index;not null"`
+	Spread        *float64  `gorm:"column:spread"`
+	OverUnder     *float64  `gorm:"column:over_under"`
+	HomeMoneyline *float64  `gorm:"column:home_moneyline"`
+	AwayMoneyline *float64  `gorm:"column:away_moneyline"`
+	IsClosing     bool      `gorm:"column:is_closing;index;not null"`
+}
+
+func (GameLineSnapshot) TableName() string { return "game_line_snapshots" }
+
 // ============================================================
 // Media & Weather
 // ============================================================
@@ -937,10 +1003,47 @@ type GameWeather struct {
 	Pressure             *float64   `gorm:"column:pressure"`
 	WeatherConditionCode *float64   `gorm:"column:weather_condition_code"`
 	WeatherCondition     string     `gorm:"column:weather_condition"`
+	// Source is WeatherSourceCFBD for weather from the API, or the name of
+	// the provider that filled it in.
+	Source string `gorm:"column:source;not null;default:cfbd"`
 }
 
 func (GameWeather) TableName() string { return "game_weather" }
 
+// WeatherSourceCFBD is the source of game weather from the API.
+const WeatherSourceCFBD = "cfbd"
+
+// Weather snapshot kinds.
+const (
+	// WeatherForecast is the last weather captured before kickoff.
+	WeatherForecast = "forecast"
+	// WeatherActual is the weather captured after the game completed.
+	WeatherActual = "actual"
+)
+
+// GameWeatherSnapshot holds the weather for a game as it was known at a
+// point in time, so forecasts can be compared against what actually
+// happened.
+type GameWeatherSnapshot struct {
+	GameID               int32     `gorm:"primaryKey;column:game_id"`
+	Kind                 string    `gorm:"primaryKey;column:kind"`
+	CapturedAt           time.Time `gorm:"column:captured_at;not null"`
+	Temperature          *float64  `gorm:"column:temperature"`
+	DewPoint             *float64  `gorm:"column:dew_point"`
+	Humidity             *float64  `gorm:"column:humidity"`
+	Precipitation        *float64  `gorm:"column:precipitation"`
+	Snowfall             *float64  `gorm:"column:snowfall"`
+	WindDirection        *float64  `gorm:"column:wind_direction"`
+	WindSpeed            *float64  `gorm:"column:wind_speed"`
+	Pressure             *float64  `gorm:"column:pressure"`
+	WeatherConditionCode *float64  `gorm:"column:weather_condition_code"`
+	WeatherCondition     string    `gorm:"column:weather_condition"`
+}
+
+func (GameWeatherSnapshot) TableName() string {
+	return "game_weather_snapshots"
+}
+
 // ============================================================
 // Game team stats (box score)
 //
@@ -1278,12 +1381,12 @@ type FieldGoalEP struct {
 func (FieldGoalEP) TableName() string { return "field_goal_ep" }
 
 // ============================================================
-// Advanced box score (nested & wide) stored as jsonb payload
+// Advanced box score (nested & wide) stored as a json payload
 // ============================================================
 
 type AdvancedBoxScore struct {
 	GameID  int32          `gorm:"primaryKey;column:game_id"`
-	Payload datatypes.JSON `gorm:"column:payload;type:jsonb"`
+	Payload CompressedJSON `gorm:"column:payload"`
 }
 
 func (AdvancedBoxScore) TableName() string { return "advanced_box_scores" }
@@ -1453,10 +1556,360 @@ func (KickerPAAR) TableName() string { return "kicker_paar" }
 // Misc endpoints
 // ============================================================
 
+// VerificationCursor records the last week re-verified by a named sweep, so
+// successive runs walk the historical weeks round-robin.
+type VerificationCursor struct {
+	Name       string    `gorm:"primaryKey;column:name"`
+	Season     int32     `gorm:"column:season;not null"`
+	Week       int32     `gorm:"column:week;not null"`
+	SeasonType string    `gorm:"column:season_type;not null"`
+	VerifiedAt time.Time `gorm:"column:verified_at;not null"`
+}
+
+func (VerificationCursor) TableName() string { return "verification_cursors" }
+
+// VerificationDiff is a field whose stored value no longer matches what the
+// API returns, i.e. a silent upstream correction.
+type VerificationDiff struct {
+	ID            int64     `gorm:"primaryKey;column:id"`
+	Season        int32     `gorm:"column:season;index;not null"`
+	Week          int32     `gorm:"column:week;not null"`
+	SeasonType    string    `gorm:"column:season_type;not null"`
+	Entity        string    `gorm:"column:entity;not null"`
+	EntityID      int32     `gorm:"column:entity_id;index;not null"`
+	Field         string    `gorm:"column:field;not null"`
+	StoredValue   string    `gorm:"column:stored_value"`
+	UpstreamValue string    `gorm:"column:upstream_value"`
+	DetectedAt    time.Time `gorm:"column:detected_at;index;not null"`
+}
+
+func (VerificationDiff) TableName() string { return "verification_diffs" }
+
+// SeedFailure is a unit of work (such as one game's win probability) whose
+// fetch failed during seeding, kept so that it can be re-attempted later.
+// Params identifies the unit and is unique per endpoint. A unit that keeps
+// failing, or that the API rejects outright, is marked permanent and no
+// longer retried.
+type SeedFailure struct {
+	ID          int64          `gorm:"primaryKey;column:id"`
+	Endpoint    string         `gorm:"column:endpoint;not null;uniqueIndex:idx_seed_failures_unit"`
+	Params      datatypes.JSON `gorm:"column:params;type:jsonb;not null;uniqueIndex:idx_seed_failures_unit"`
+	Error       string         `gorm:"column:error;not null"`
+	Attempts    int32          `gorm:"column:attempts;not null;default:1"`
+	FailedAt    time.Time      `gorm:"column:failed_at;not null"`
+	ResolvedAt  *time.Time     `gorm:"column:resolved_at;index"`
+	PermanentAt *time.Time     `gorm:"column:permanent_at"`
+}
+
+func (SeedFailure) TableName() string { return "seed_failures" }
+
+// SeedCheckpoint lists the seed functions completed by a run of the command
+// that was stopped by a signal before it finished, kept so that the next
+// run can resume after them. There is at most one per command.
+type SeedCheckpoint struct {
+	Command   string         `gorm:"primaryKey;column:command"`
+	Tasks     datatypes.JSON `gorm:"column:tasks;type:jsonb;not null"`
+	StoppedAt time.Time      `gorm:"column:stopped_at;not null"`
+}
+
+func (SeedCheckpoint) TableName() string { return "seed_checkpoints" }
+
+// ColumnUnit documents the unit a measured column is stored in, so values
+// can be interpreted without knowing how the seeder was configured.
+type ColumnUnit struct {
+	Table  string `gorm:"primaryKey;column:table_name"`
+	Column string `gorm:"primaryKey;column:column_name"`
+	Unit   string `gorm:"column:unit;not null"`
+}
+
+func (ColumnUnit) TableName() string { return "column_units" }
+
+// RowHash is the content hash of an upserted row as of its last write,
+// keyed by table and primary key, so unchanged rows can be skipped on later
+// runs.
+type RowHash struct {
+	Table string `gorm:"primaryKey;column:table_name"`
+	Key   string `gorm:"primaryKey;column:row_key"`
+	Hash  []byte `gorm:"column:hash;type:bytea;not null"`
+}
+
+func (RowHash) TableName() string { return "row_hashes" }
+
+// ValidationError is a row that broke a validation rule when it was written,
+// with what was done with it: fail, skip or log.
+type ValidationError struct {
+	ID         int64          `gorm:"primaryKey;column:id"`
+	Table      string         `gorm:"column:table_name;index;not null"`
+	Key        string         `gorm:"column:row_key"`
+	Rule       string         `gorm:"column:rule;not null"`
+	Mode       string         `gorm:"column:mode;not null"`
+	Row        datatypes.JSON `gorm:"column:row;type:jsonb;not null"`
+	DetectedAt time.Time      `gorm:"column:detected_at;index;not null"`
+}
+
+func (ValidationError) TableName() string { return "validation_errors" }
+
+// QuarantinedRow is a row moved out of its table by AuditReferences because
+// the row it references does not exist.
+type QuarantinedRow struct {
+	ID            int64          `gorm:"primaryKey;column:id"`
+	Table         string         `gorm:"column:table_name;index;not null"`
+	Reference     string         `gorm:"column:reference;not null"`
+	Row           datatypes.JSON `gorm:"column:row;type:jsonb;not null"`
+	QuarantinedAt time.Time      `gorm:"column:quarantined_at;index;not null"`
+}
+
+func (QuarantinedRow) TableName() string { return "quarantined_rows" }
+
+// DataQuality is how completely one kind of data is populated for a season,
+// as of when it was computed: Covered of Total rows have it.
+type DataQuality struct {
+	Season  int32  `gorm:"primaryKey;column:season"`
+	Metric  string `gorm:"primaryKey;column:metric"`
+	Covered int64  `gorm:"column:covered;not null"`
+	Total   int64  `gorm:"column:total;not null"`
+	// Completeness is Covered as a fraction of Total, or nil if there are
+	// no rows.
+	Completeness *float64  `gorm:"column:completeness"`
+	ComputedAt   time.Time `gorm:"column:computed_at;not null"`
+}
+
+func (DataQuality) TableName() string { return "data_quality" }
+
+// LocalGameElo is both teams' Elo ratings before and after a game, as
+// computed locally from game results, in parallel to the API's ratings on
+// games.
+type LocalGameElo struct {
+	GameID          int32     `gorm:"primaryKey;column:game_id"`
+	Season          int32     `gorm:"column:season;index;not null"`
+	HomeTeam        string    `gorm:"column:home_team;not null"`
+	AwayTeam        string    `gorm:"column:away_team;not null"`
+	HomePregameElo  float64   `gorm:"column:home_pregame_elo;not null"`
+	AwayPregameElo  float64   `gorm:"column:away_pregame_elo;not null"`
+	HomePostgameElo float64   `gorm:"column:home_postgame_elo;not null"`
+	AwayPostgameElo float64   `gorm:"column:away_postgame_elo;not null"`
+	ComputedAt      time.Time `gorm:"column:computed_at;not null"`
+}
+
+func (LocalGameElo) TableName() string { return "local_game_elo" }
+
+// LocalTeamRating is a team's Elo and SRS ratings after the completed games
+// of a season, as computed locally from game results.
+type LocalTeamRating struct {
+	Season int32   `gorm:"primaryKey;column:season"`
+	Team   string  `gorm:"primaryKey;column:team"`
+	Games  int32   `gorm:"column:games;not null"`
+	Elo    float64 `gorm:"column:elo;not null"`
+	// Margin is the team's average margin of victory.
+	Margin float64 `gorm:"column:margin;not null"`
+	// SOS is the average SRS of the team's opponents.
+	SOS        float64   `gorm:"column:sos;not null"`
+	SRS        float64   `gorm:"column:srs;not null"`
+	ComputedAt time.Time `gorm:"column:computed_at;not null"`
+}
+
+func (LocalTeamRating) TableName() string { return "local_team_ratings" }
+
+// LocalPlayWinProbability is the home team's chance of winning before a play,
+// as estimated by the local model for games the API has no win probability
+// for, in parallel to play_win_probability.
+type LocalPlayWinProbability struct {
+	GameID             int32     `gorm:"primaryKey;column:game_id"`
+	PlayID             string    `gorm:"primaryKey;column:play_id"`
+	Season             int32     `gorm:"column:season;index;not null"`
+	HomeWinProbability float64   `gorm:"column:home_win_probability;not null"`
+	ComputedAt         time.Time `gorm:"column:computed_at;not null"`
+}
+
+func (LocalPlayWinProbability) TableName() string {
+	return "local_play_win_probability"
+}
+
+// DriveStats is the aggregate of a drive's rushes and passes, as computed
+// from its plays.
+type DriveStats struct {
+	DriveID string `gorm:"primaryKey;column:drive_id"`
+	Season  int32  `gorm:"primaryKey;column:season"`
+	GameID  int32  `gorm:"column:game_id;index;not null"`
+	Offense string `gorm:"column:offense"`
+	// Plays counts the drive's rushes and passes.
+	Plays           int32 `gorm:"column:plays;not null"`
+	SuccessfulPlays int32 `gorm:"column:successful_plays;not null"`
+	ExplosivePlays  int32 `gorm:"column:explosive_plays;not null"`
+	// SuccessRate is the share of the plays that succeeded, and EPA and
+	// EPAPerPlay the sum and average of their PPA; each is nil when none of
+	// the plays has a success flag or PPA.
+	SuccessRate *float64  `gorm:"column:success_rate"`
+	EPA         *float64  `gorm:"column:epa"`
+	EPAPerPlay  *float64  `gorm:"column:epa_per_play"`
+	ComputedAt  time.Time `gorm:"column:computed_at;not null"`
+}
+
+func (DriveStats) TableName() string { return "drive_stats" }
+
+// TeamWeekStats is the aggregate of a team's rushes and passes outside
+// garbage time in the games of a week, as computed from the plays. Averages
+// are nil when none of the plays has a value.
+type TeamWeekStats struct {
+	Season     int32  `gorm:"primaryKey;column:season"`
+	Week       int32  `gorm:"primaryKey;column:week"`
+	SeasonType string `gorm:"primaryKey;column:season_type"`
+	Team       string `gorm:"primaryKey;column:team"`
+	Games      int32  `gorm:"column:games;not null"`
+	Plays      int32  `gorm:"column:plays;not null"`
+	// PlaysPerGame is the team's pace.
+	PlaysPerGame  float64  `gorm:"column:plays_per_game;not null"`
+	EPAPerPlay    *float64 `gorm:"column:epa_per_play"`
+	SuccessRate   *float64 `gorm:"column:success_rate"`
+	ExplosiveRate float64  `gorm:"column:explosive_rate;not null"`
+	// Explosiveness is the average EPA of the successful plays.
+	Explosiveness  *float64 `gorm:"column:explosiveness"`
+	RushEPAPerPlay *float64 `gorm:"column:rush_epa_per_play"`
+	PassEPAPerPlay *float64 `gorm:"column:pass_epa_per_play"`
+
+	StandardDownPlays         int32    `gorm:"column:standard_down_plays;not null"`
+	StandardDownEPAPerPlay    *float64 `gorm:"column:standard_down_epa_per_play"`
+	StandardDownSuccessRate   *float64 `gorm:"column:standard_down_success_rate"`
+	StandardDownExplosiveness *float64 `gorm:"column:standard_down_explosiveness"`
+	PassingDownPlays          int32    `gorm:"column:passing_down_plays;not null"`
+	PassingDownEPAPerPlay     *float64 `gorm:"column:passing_down_epa_per_play"`
+	PassingDownSuccessRate    *float64 `gorm:"column:passing_down_success_rate"`
+	PassingDownExplosiveness  *float64 `gorm:"column:passing_down_explosiveness"`
+
+	ComputedAt time.Time `gorm:"column:computed_at;not null"`
+}
+
+func (TeamWeekStats) TableName() string { return "team_week_stats" }
+
+// PlayerIdentity is an athlete resolved across the tables that record them,
+// identified by their athlete ID.
+type PlayerIdentity struct {
+	ID        string    `gorm:"primaryKey;column:id"`
+	Name      string    `gorm:"column:name;index;not null"`
+	Position  string    `gorm:"column:position"`
+	Team      string    `gorm:"column:team;index"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (PlayerIdentity) TableName() string { return "player_identities" }
+
+// PlayerCrosswalk maps a row of a table that records athletes to its player
+// in player_identities, and records how it was matched.
+type PlayerCrosswalk struct {
+	// Source is the table of the row, and SourceID its ID there.
+	Source    string    `gorm:"primaryKey;column:source"`
+	SourceID  string    `gorm:"primaryKey;column:source_id"`
+	PlayerID  string    `gorm:"column:player_id;index;not null"`
+	Method    string    `gorm:"column:method;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (PlayerCrosswalk) TableName() string { return "player_crosswalk" }
+
+// VenueGeocode is the location a geocoding provider gave for a venue the
+// API has no coordinates or elevation for. It is kept so that the venue can
+// be filled in again after a re-seed without another lookup.
+type VenueGeocode struct {
+	VenueID    int32     `gorm:"primaryKey;column:venue_id"`
+	Latitude   float64   `gorm:"column:latitude;not null"`
+	Longitude  float64   `gorm:"column:longitude;not null"`
+	Elevation  float64   `gorm:"column:elevation;not null"`
+	GeocodedAt time.Time `gorm:"column:geocoded_at;not null"`
+}
+
+func (VenueGeocode) TableName() string { return "venue_geocodes" }
+
+// GameTravel is how far a team travelled to a game from its home venue,
+// and how many days it had off since its previous game of the season.
+// Distance is nil when either venue has no coordinates, and rest days when
+// the game or the team's previous game has no start date.
+type GameTravel struct {
+	GameID      int32  `gorm:"primaryKey;column:game_id"`
+	Team        string `gorm:"primaryKey;column:team"`
+	Season      int32  `gorm:"column:season;index;not null"`
+	Week        int32  `gorm:"column:week;not null"`
+	SeasonType  string `gorm:"column:season_type;not null"`
+	HomeAway    string `gorm:"column:home_away;not null"`
+	NeutralSite bool   `gorm:"column:neutral_site;not null"`
+	HomeVenueID *int32 `gorm:"column:home_venue_id"`
+	VenueID     *int32 `gorm:"column:venue_id"`
+	// DistanceMiles is the great-circle distance between the venues.
+	DistanceMiles  *float64 `gorm:"column:distance_miles"`
+	PreviousGameID *int32   `gorm:"column:previous_game_id"`
+	RestDays       *int32   `gorm:"column:rest_days"`
+
+	ComputedAt time.Time `gorm:"column:computed_at;not null"`
+}
+
+func (GameTravel) TableName() string { return "game_travel" }
+
+// SeedRun is one invocation of the seeder, kept as run history.
+type SeedRun struct {
+	ID          int64      `gorm:"primaryKey;column:id"`
+	Command     string     `gorm:"column:command;not null"`
+	Status      string     `gorm:"column:status;not null"`
+	Error       string     `gorm:"column:error"`
+	RowsWritten int64      `gorm:"column:rows_written;not null"`
+	StartedAt   time.Time  `gorm:"column:started_at;not null;index"`
+	FinishedAt  *time.Time `gorm:"column:finished_at"`
+	// Summary is the run's JSON summary, kept when requested.
+	Summary datatypes.JSON `gorm:"column:summary;type:jsonb"`
+}
+
+func (SeedRun) TableName() string { return "seed_runs" }
+
+// SchemaMigration records a migration applied to the database. A dirty
+// migration failed part way through.
+type SchemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false;column:version"`
+	Name      string    `gorm:"column:name;not null"`
+	Dirty     bool      `gorm:"column:dirty;not null"`
+	AppliedAt time.Time `gorm:"column:applied_at;not null"`
+}
+
+func (SchemaMigration) TableName() string { return "schema_migrations" }
+
+// DeferredIndex records a secondary index dropped for a bulk load, with the
+// definition it is rebuilt from once the load finishes.
+type DeferredIndex struct {
+	Name       string    `gorm:"primaryKey;column:name"`
+	Table      string    `gorm:"column:table_name;not null"`
+	Definition string    `gorm:"column:definition;not null"`
+	DeferredAt time.Time `gorm:"column:deferred_at;not null"`
+}
+
+func (DeferredIndex) TableName() string { return "deferred_indexes" }
+
+// TableManifest records when each table was last written by the seeder and
+// by which run, so data freshness can be checked without scanning tables.
+type TableManifest struct {
+	Table        string    `gorm:"primaryKey;column:table_name"`
+	LastSyncedAt time.Time `gorm:"column:last_synced_at;not null"`
+	RowsWritten  int64     `gorm:"column:rows_written;not null"`
+	RunID        int64     `gorm:"column:run_id;index"`
+}
+
+func (TableManifest) TableName() string { return "table_manifest" }
+
+// ResponseManifest records the content hash of the last response an
+// endpoint returned for a set of request parameters, so a sync can tell
+// when a response is identical to the one it already wrote.
+type ResponseManifest struct {
+	Endpoint  string         `gorm:"primaryKey;column:endpoint"`
+	Params    datatypes.JSON `gorm:"primaryKey;column:params;type:jsonb"`
+	Hash      []byte         `gorm:"column:hash;type:bytea;not null"`
+	FetchedAt time.Time      `gorm:"column:fetched_at;not null"`
+	RunID     int64          `gorm:"column:run_id"`
+}
+
+func (ResponseManifest) TableName() string { return "response_manifest" }
+
 type UserInfo struct {
-	ID             int64   `gorm:"primaryKey;column:id"`
-	PatronLevel    float64 `gorm:"column:patron_level;not null"`
-	RemainingCalls float64 `gorm:"column:remaining_calls;not null"`
+	ID             int64     `gorm:"primaryKey;column:id"`
+	PatronLevel    float64   `gorm:"column:patron_level;not null"`
+	RemainingCalls float64   `gorm:"column:remaining_calls;not null"`
+	Stage          string    `gorm:"column:stage"`
+	CapturedAt     time.Time `gorm:"column:captured_at;index;not null"`
 }
 
 func (UserInfo) TableName() string { return "user_info" }
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	mysqldriver "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// mysqlDialect keeps every table in the database named by the DSN. The
+// Postgres column types of the models are mapped to their closest MySQL
+// equivalents (see mysqlColumnType), and the generated full-text search
+// column of plays is left out.
+type mysqlDialect struct{}
+
+func (mysqlDialect) open(dsn string) (gorm.Dialector, error) {
+	conf, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mysql dsn; %w", err)
+	}
+	// Timestamps are scanned into time.Time.
+	conf.ParseTime = true
+
+	return newMySQLDialector(mysqldriver.Config{DSNConfig: conf}), nil
+}
+
+func (mysqlDialect) reopen(conn gorm.ConnPool) gorm.Dialector {
+	return newMySQLDialector(mysqldriver.Config{Conn: conn})
+}
+
+func (mysqlDialect) prepare(gdb *gorm.DB) error {
+	return ignoreTextSearch(gdb)
+}
+
+func (mysqlDialect) createSchema(*gorm.DB) error {
+	return nil
+}
+
+// dropSchema drops every table of the database named by the DSN, which is
+// left in place to connect to.
+func (mysqlDialect) dropSchema(tx *gorm.DB) error {
+	return dropTables(tx)
+}
+
+// hasSchema is always true: the database named by the DSN must exist to
+// connect to it.
+func (mysqlDialect) hasSchema(*gorm.DB) (bool, error) {
+	return true, nil
+}
+
+func (mysqlDialect) upsert(_ string, columns []string) string {
+	set := make([]string, len(columns))
+	for i, column := range columns {
+		set[i] = column + " = VALUES(" + column + ")"
+	}
+
+	return "ON DUPLICATE KEY UPDATE " + strings.Join(set, ", ")
+}
+
+func (mysqlDialect) estimateRows(tx *gorm.DB, table string) (int64, error) {
+	var rows int64
+	err := tx.Raw(`
+		SELECT COALESCE(MAX(table_rows), 0) FROM information_schema.tables
+		WHERE table_schema = DATABASE() AND table_name = ?`, table,
+	).Scan(&rows).Error
+
+	return rows, err
+}
+
+// mysqlDialector is the GORM MySQL dialector with a migrator that maps the
+// Postgres column types of the models.
+type mysqlDialector struct {
+	*mysqldriver.Dialector
+}
+
+func newMySQLDialector(conf mysqldriver.Config) mysqlDialector {
+	dialector, _ := mysqldriver.New(conf).(*mysqldriver.Dialector)
+	return mysqlDialector{Dialector: dialector}
+}
+
+func (d mysqlDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	m, _ := d.Dialector.Migrator(db).(mysqldriver.Migrator)
+	m.Migrator.Config.Dialector = d
+	return mysqlMigrator{Migrator: m}
+}
+
+// mysqlMigrator creates columns with the types mysqlColumnType maps them to.
+type mysqlMigrator struct {
+	mysqldriver.Migrator
+}
+
+func (m mysqlMigrator) FullDataTypeOf(field *schema.Field) clause.Expr {
+	expr := m.Migrator.FullDataTypeOf(field)
+	declared := m.Migrator.Migrator.DataTypeOf(field)
+	if columnType, ok := mysqlColumnType(field, declared); ok {
+		expr.SQL = columnType + strings.TrimPrefix(expr.SQL, declared)
+	}
+
+	return expr
+}
+
+// mysqlColumnType maps a Postgres-only column type to MySQL. JSON and text
+// cannot be indexed in full, so keys and indexed columns get a bounded
+// varchar instead.
+func mysqlColumnType(field *schema.Field, declared string) (string, bool) {
+	keyed := field.PrimaryKey || field.TagSettings["INDEX"] != "" ||
+		field.TagSettings["UNIQUEINDEX"] != ""
+
+	switch strings.ToLower(declared) {
+	case "jsonb", "json":
+		if keyed {
+			return "varchar(512)", true
+		}
+		return "json", true
+	case "bytea":
+		return "longblob", true
+	case "text[]", "int[]":
+		// Stored in the Postgres array literal format pq reads back.
+		return "text", true
+	case "longtext":
+		if keyed {
+			return "varchar(191)", true
+		}
+	}
+
+	return "", false
+}
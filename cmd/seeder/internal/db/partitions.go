@@ -0,0 +1,260 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// duplicateTable is the SQLSTATE of creating a table that already exists.
+const duplicateTable = "42P07"
+
+// seasonPartition is a table range partitioned by season, one partition per
+// season, named <table>_<season>.
+type seasonPartition struct {
+	model any
+	table string
+	// fromGames marks a table that had no season column before it was
+	// partitioned; its rows without one take the season of their game.
+	fromGames bool
+}
+
+// seasonPartitions lists the tables partitioned by season. Their rows are
+// only ever read a season at a time, and a season's rows are rewritten
+// together, so each season gets a table of its own.
+var seasonPartitions = []seasonPartition{
+	{model: &Drive{}, table: (Drive{}).TableName(), fromGames: true},
+	{model: &Play{}, table: (Play{}).TableName(), fromGames: true},
+	{model: &PlayStat{}, table: (PlayStat{}).TableName()},
+}
+
+// partitionBySeason converts the tables in seasonPartitions to tables range
+// partitioned by season.
+var partitionBySeason = Migration{
+	Version:       2,
+	Name:          "partition_by_season",
+	up:            partitionBySeasonUp,
+	transactional: true,
+}
+
+// partitionBySeasonUp rebuilds each table in seasonPartitions that is not
+// partitioned yet as a partitioned table, moving its rows into a partition
+// per season. Rows whose season cannot be determined are dropped, since no
+// partition can hold them. Tables are only partitioned on PostgreSQL.
+func partitionBySeasonUp(tx *gorm.DB) error {
+	if tx.Dialector.Name() != DriverPostgres {
+		return nil
+	}
+
+	for _, p := range seasonPartitions {
+		if err := partitionTable(tx, p); err != nil {
+			return fmt.Errorf("could not partition %s; %w", p.table, err)
+		}
+	}
+
+	return nil
+}
+
+// partitionTable rebuilds a single table as a partitioned table. Everything
+// it needs to know is read before the first change, so that the migration
+// can be planned without running it.
+func partitionTable(tx *gorm.DB, p seasonPartition) error {
+	var partitioned bool
+	err := tx.Raw(`
+		SELECT EXISTS (
+			SELECT 1 FROM pg_partitioned_table
+			WHERE partrelid = to_regclass(?)
+		)`, p.table,
+	).Scan(&partitioned).Error
+	if err != nil {
+		return fmt.Errorf("could not check partitioning; %w", err)
+	}
+	if partitioned {
+		return nil
+	}
+
+	hasSeason := tx.Migrator().HasColumn(p.model, "season")
+	if !hasSeason && !p.fromGames {
+		return errors.New("table has no season column")
+	}
+
+	// Rows of a table that takes the season of their game may have been
+	// written before the season column was.
+	season, join := "t.season", ""
+	switch {
+	case !hasSeason:
+		season, join = "g.season", "JOIN games g ON g.id = t.game_id"
+	case p.fromGames:
+		season = "COALESCE(t.season, g.season)"
+		join = "LEFT JOIN games g ON g.id = t.game_id"
+	}
+	seasonsQuery := fmt.Sprintf(`
+		SELECT DISTINCT %[1]s::bigint FROM %[2]s t %[3]s
+		WHERE %[1]s IS NOT NULL ORDER BY 1`, season, p.table, join,
+	)
+	var seasons []int64
+	if err = tx.Raw(seasonsQuery).Scan(&seasons).Error; err != nil {
+		return fmt.Errorf("could not list seasons; %w", err)
+	}
+
+	var columns []string
+	err = tx.Raw(`
+		SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = ?
+			AND is_generated = 'NEVER'
+		ORDER BY ordinal_position`, p.table,
+	).Scan(&columns).Error
+	if err != nil {
+		return fmt.Errorf("could not list columns; %w", err)
+	}
+	if !hasSeason {
+		columns = append(columns, "season")
+	}
+	for i, column := range columns {
+		columns[i] = pgx.Identifier{column}.Sanitize()
+	}
+	list := strings.Join(columns, ", ")
+
+	// An auto-increment ID's sequence belongs to the old table and would be
+	// dropped with it.
+	var sequence *string
+	err = tx.Raw("SELECT pg_get_serial_sequence(?, 'id')", p.table).
+		Scan(&sequence).Error
+	if err != nil {
+		return fmt.Errorf("could not find id sequence; %w", err)
+	}
+
+	old := p.table + "_unpartitioned"
+	var steps []string
+	if !hasSeason {
+		steps = append(steps,
+			fmt.Sprintf("ALTER TABLE %s ADD COLUMN season integer", p.table),
+		)
+	}
+	if p.fromGames {
+		steps = append(steps, fmt.Sprintf(`
+			UPDATE %[1]s SET season = g.season FROM games g
+			WHERE g.id = %[1]s.game_id AND %[1]s.season IS NULL`, p.table,
+		))
+	}
+	steps = append(steps,
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", p.table, old),
+		fmt.Sprintf(`
+			CREATE TABLE %s (
+				LIKE %s INCLUDING DEFAULTS INCLUDING GENERATED
+			) PARTITION BY RANGE (season)`, p.table, old,
+		),
+	)
+	for _, step := range steps {
+		if err := tx.Exec(step).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, season := range seasons {
+		if err := createSeasonPartition(tx, p.table, season); err != nil {
+			return err
+		}
+	}
+
+	dropped := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE season IS NULL", old))
+	if dropped.Error != nil {
+		return fmt.Errorf("could not remove rows without a season; %w",
+			dropped.Error)
+	}
+	if dropped.RowsAffected > 0 {
+		slog.Warn("removed rows without a season",
+			"table", p.table, "rows", dropped.RowsAffected)
+	}
+
+	steps = []string{
+		fmt.Sprintf(
+			"INSERT INTO %s (%s) SELECT %s FROM %s", p.table, list, list, old,
+		),
+	}
+	if sequence != nil {
+		steps = append(steps,
+			fmt.Sprintf("ALTER SEQUENCE %s OWNED BY %s.id", *sequence, p.table),
+		)
+	}
+	steps = append(steps,
+		fmt.Sprintf("DROP TABLE %s", old),
+		fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (id, season)", p.table),
+	)
+	for _, step := range steps {
+		if err := tx.Exec(step).Error; err != nil {
+			return err
+		}
+	}
+
+	// The indexes went with the old table.
+	if err := tx.AutoMigrate(p.model); err != nil {
+		return fmt.Errorf("could not recreate indexes; %w", err)
+	}
+
+	slog.Info("partitioned table by season",
+		"table", p.table, "seasons", len(seasons))
+
+	return nil
+}
+
+// createSeasonPartition creates the partition of table holding season, if
+// it does not exist yet.
+func createSeasonPartition(tx *gorm.DB, table string, season int64) error {
+	err := tx.Exec(fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %[1]s_%[2]d PARTITION OF %[1]s
+		FOR VALUES FROM (%[2]d) TO (%[3]d)`, table, season, season+1,
+	)).Error
+	if err != nil {
+		return fmt.Errorf("could not create %s partition for season %d; %w",
+			table, season, err)
+	}
+
+	return nil
+}
+
+// ensurePartitions creates the partitions of table holding seasons that do
+// not exist yet, so that inserts of a new season find a partition to go to.
+// It runs outside of any transaction, before the rows are written: creating
+// a partition waits for every transaction writing to the table to finish.
+func (db *Database) ensurePartitions(
+	ctx context.Context,
+	table string,
+	seasons []int64,
+) error {
+	if db.requirePostgres("partitioning") != nil {
+		return nil
+	}
+
+	slices.Sort(seasons)
+	for _, season := range slices.Compact(seasons) {
+		key := fmt.Sprintf("%s_%d", table, season)
+		if _, ok := db.partitions.Load(key); ok {
+			continue
+		}
+
+		err := createSeasonPartition(db.WithContext(ctx), table, season)
+		// Concurrent inserts of a new season race to create its partition.
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) &&
+			(pgErr.Code == duplicateTable || pgErr.Code == uniqueViolation) {
+			err = nil
+		}
+		if err != nil {
+			slog.Error("could not create partition",
+				"table", table, "season", season, "err", err.Error())
+			return err
+		}
+
+		db.partitions.Store(key, struct{}{})
+	}
+
+	return nil
+}
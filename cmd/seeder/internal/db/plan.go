@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// CountCalendarWeeks returns the number of seeded calendar weeks, across
+// season types, for the season, limited to the season types if any are
+// given.
+func (db *Database) CountCalendarWeeks(
+	ctx context.Context,
+	season int32,
+	seasonTypes ...string,
+) (int64, error) {
+	var count int64
+	query := db.WithContext(ctx).Model(&CalendarWeek{})
+	err := bySeasonType(query, seasonTypes).
+		Where("season = ?", season).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("could not count calendar weeks; %w", err)
+	}
+
+	return count, nil
+}
+
+// CountGames returns the number of seeded games for the season that match
+// the filter.
+func (db *Database) CountGames(
+	ctx context.Context,
+	season int32,
+	filter GameFilter,
+) (int64, error) {
+	var count int64
+	err := filter.apply(db.WithContext(ctx).Model(&Game{})).
+		Where("season = ?", season).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("could not count games; %w", err)
+	}
+
+	return count, nil
+}
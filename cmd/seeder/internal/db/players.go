@@ -0,0 +1,192 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// PlayerRecord is an athlete as one of the tables that record athletes has
+// them.
+type PlayerRecord struct {
+	// Source is the table, and SourceID the row's ID there.
+	Source   string
+	SourceID string
+	// AthleteID is the athlete's ID, if the table records it.
+	AthleteID string
+	Name      string
+	Position  string
+	// Teams are the teams the athlete was with, most relevant first.
+	Teams []string
+	// Year is a season the athlete was with Teams, or zero if unknown.
+	Year int32
+	// RecruitIDs are the recruit IDs of a roster player.
+	RecruitIDs []string
+}
+
+// GetPlayerRecords returns every athlete recorded by the rosters, player
+// search, season player stats, recruits, draft picks and transfer portal,
+// in that order. Season player stats give one record per season and team.
+// A transfer's source ID is "season:first_name:last_name".
+func (db *Database) GetPlayerRecords(
+	ctx context.Context,
+) ([]PlayerRecord, error) {
+	session := db.WithContext(ctx)
+	var records []PlayerRecord
+
+	var roster []RosterPlayer
+	err := session.Select(
+		"id", "first_name", "last_name", "team", "position", "recruit_ids",
+	).Order("id").Find(&roster).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not get roster players; %w", err)
+	}
+	for _, p := range roster {
+		records = append(records, PlayerRecord{
+			Source:     (RosterPlayer{}).TableName(),
+			SourceID:   p.ID,
+			AthleteID:  p.ID,
+			Name:       p.FirstName + " " + p.LastName,
+			Position:   p.Position,
+			Teams:      []string{p.Team},
+			RecruitIDs: p.RecruitIDs,
+		})
+	}
+
+	var search []PlayerSearchResult
+	err = session.Select("id", "name", "team", "position").
+		Order("id").Find(&search).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not get player search results; %w", err)
+	}
+	for _, p := range search {
+		records = append(records, PlayerRecord{
+			Source:    (PlayerSearchResult{}).TableName(),
+			SourceID:  p.ID,
+			AthleteID: p.ID,
+			Name:      p.Name,
+			Position:  p.Position,
+			Teams:     []string{p.Team},
+		})
+	}
+
+	var stats []PlayerStat
+	err = session.
+		Distinct("season", "player_id", "player", "position", "team").
+		Where("player_id <> ''").
+		Order("player_id, season").Find(&stats).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not get season player stats; %w", err)
+	}
+	for _, p := range stats {
+		records = append(records, PlayerRecord{
+			Source:    (PlayerStat{}).TableName(),
+			SourceID:  p.PlayerID,
+			AthleteID: p.PlayerID,
+			Name:      p.Player,
+			Position:  p.Position,
+			Teams:     []string{p.Team},
+			Year:      p.Season,
+		})
+	}
+
+	var recruits []Recruit
+	err = session.Select(
+		"id", "athlete_id", "name", "position", "committed_to", "year",
+	).Order("id").Find(&recruits).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not get recruits; %w", err)
+	}
+	for _, r := range recruits {
+		records = append(records, PlayerRecord{
+			Source:    (Recruit{}).TableName(),
+			SourceID:  r.ID,
+			AthleteID: r.AthleteID,
+			Name:      r.Name,
+			Position:  r.Position,
+			Teams:     []string{r.CommittedTo},
+			// A recruit's class plays its first season that year.
+			Year: r.Year,
+		})
+	}
+
+	var picks []DraftPick
+	err = session.Select(
+		"id", "college_athlete_id", "name", "position", "college_team", "year",
+	).Order("id").Find(&picks).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not get draft picks; %w", err)
+	}
+	for _, p := range picks {
+		record := PlayerRecord{
+			Source:   (DraftPick{}).TableName(),
+			SourceID: strconv.FormatInt(p.ID, 10),
+			Name:     p.Name,
+			Position: p.Position,
+			Teams:    []string{p.CollegeTeam},
+			// A pick's last college season is the one before the draft.
+			Year: p.Year - 1,
+		}
+		if p.CollegeAthleteID != nil {
+			record.AthleteID = strconv.Itoa(int(*p.CollegeAthleteID))
+		}
+		records = append(records, record)
+	}
+
+	var transfers []PlayerTransfer
+	err = session.Order("season, last_name, first_name").
+		Find(&transfers).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not get transfers; %w", err)
+	}
+	for _, t := range transfers {
+		records = append(records, PlayerRecord{
+			Source: (PlayerTransfer{}).TableName(),
+			SourceID: fmt.Sprintf(
+				"%d:%s:%s", t.Season, t.FirstName, t.LastName,
+			),
+			Name:     t.FirstName + " " + t.LastName,
+			Position: t.Position,
+			Teams:    []string{t.Origin, t.Destination},
+			Year:     t.Season,
+		})
+	}
+
+	return records, nil
+}
+
+// ReplacePlayerIdentities stores the resolved players and the crosswalk of
+// every source row to them, replacing the previous ones in one
+// transaction.
+func (db *Database) ReplacePlayerIdentities(
+	ctx context.Context,
+	players []PlayerIdentity,
+	crosswalk []PlayerCrosswalk,
+) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		all := tx.Session(&gorm.Session{AllowGlobalUpdate: true})
+		err := all.Delete(&PlayerCrosswalk{}).Error
+		if err != nil {
+			return fmt.Errorf("could not clear player crosswalk; %w", err)
+		}
+		err = all.Delete(&PlayerIdentity{}).Error
+		if err != nil {
+			return fmt.Errorf("could not clear player identities; %w", err)
+		}
+
+		if len(players) > 0 {
+			if err = tx.CreateInBatches(players, 1000).Error; err != nil {
+				return fmt.Errorf("could not insert player identities; %w", err)
+			}
+		}
+		if len(crosswalk) > 0 {
+			if err = tx.CreateInBatches(crosswalk, 1000).Error; err != nil {
+				return fmt.Errorf("could not insert player crosswalk; %w", err)
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Values of Play.RushPass and Play.DownType.
+const (
+	PlayRush         = "rush"
+	PlayPass         = "pass"
+	PlayOther        = "other"
+	DownTypeStandard = "standard"
+	DownTypePassing  = "passing"
+)
+
+var (
+	// rushPlayTypes and passPlayTypes are the play types of designed runs
+	// and passes. Sacks count as passes.
+	rushPlayTypes = []string{"Rush", "Rushing Touchdown"}
+	passPlayTypes = []string{
+		"Pass", "Pass Completion", "Pass Reception", "Pass Incompletion",
+		"Passing Touchdown", "Sack", "Pass Interception",
+		"Pass Interception Return", "Interception",
+		"Interception Return Touchdown",
+	}
+	// touchdownPlayTypes always succeed, and turnoverPlayTypes never do,
+	// whatever the yardage.
+	touchdownPlayTypes = []string{"Rushing Touchdown", "Passing Touchdown"}
+	turnoverPlayTypes  = []string{
+		"Pass Interception", "Pass Interception Return", "Interception",
+		"Interception Return Touchdown",
+	}
+)
+
+// playFlagColumns are the Play fields addPlayFlags adds, in order.
+var playFlagColumns = []string{"Success", "GarbageTime", "RushPass", "DownType"}
+
+// addPlayFlags adds the derived flag columns to plays.
+var addPlayFlags = Migration{
+	Version:       3,
+	Name:          "add_play_flags",
+	up:            addPlayFlagsUp,
+	down:          addPlayFlagsDown,
+	transactional: true,
+}
+
+// addPlayFlagsUp adds each flag column plays does not have yet. A database
+// created since the columns were added to the model already has them from
+// the baseline, and on PostgreSQL partitioning plays adds them too.
+func addPlayFlagsUp(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	for _, column := range playFlagColumns {
+		if migrator.HasColumn(&Play{}, column) {
+			continue
+		}
+		if err := migrator.AddColumn(&Play{}, column); err != nil {
+			return fmt.Errorf("could not add plays column %s; %w", column, err)
+		}
+	}
+
+	return nil
+}
+
+// addPlayFlagsDown drops the flag columns.
+func addPlayFlagsDown(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	for _, column := range playFlagColumns {
+		if !migrator.HasColumn(&Play{}, column) {
+			continue
+		}
+		if err := migrator.DropColumn(&Play{}, column); err != nil {
+			return fmt.Errorf("could not drop plays column %s; %w", column, err)
+		}
+	}
+
+	return nil
+}
+
+// ComputePlayFlags derives the flag columns of every play of a season from
+// its other columns, as the API does for live plays, and returns how many
+// plays it updated:
+//
+//   - rush_pass classifies the play type as a rush, a pass or other.
+//   - down_type is passing on 2nd and 8 or more, and 3rd or 4th and 5 or
+//     more, and standard on other downs.
+//   - success is set on rushes and passes: a play succeeds when it gains
+//     half the distance on 1st down, 70% of it on 2nd down and all of it on
+//     3rd and 4th down, or scores, unless it is a turnover.
+//   - garbage_time marks plays with the margin above 38 points in the 2nd
+//     quarter, 28 in the 3rd and 22 in the 4th.
+func (db *Database) ComputePlayFlags(
+	ctx context.Context,
+	season int32,
+) (int64, error) {
+	result := db.WithContext(ctx).Exec(`
+		UPDATE plays SET
+			rush_pass = CASE
+				WHEN play_type IN @rush THEN @rushValue
+				WHEN play_type IN @pass THEN @passValue
+				ELSE @otherValue
+			END,
+			down_type = CASE
+				WHEN down NOT BETWEEN 1 AND 4 THEN NULL
+				WHEN (down = 2 AND distance >= 8)
+					OR (down >= 3 AND distance >= 5) THEN @passing
+				ELSE @standard
+			END,
+			success = CASE
+				WHEN play_type NOT IN @rush AND play_type NOT IN @pass
+					THEN NULL
+				WHEN play_type IN @touchdown THEN TRUE
+				WHEN play_type IN @turnover THEN FALSE
+				WHEN down = 1 THEN yards_gained >= 0.5 * distance
+				WHEN down = 2 THEN yards_gained >= 0.7 * distance
+				ELSE yards_gained >= distance
+			END,
+			garbage_time = CASE period
+				WHEN 2 THEN ABS(offense_score - defense_score) > 38
+				WHEN 3 THEN ABS(offense_score - defense_score) > 28
+				WHEN 4 THEN ABS(offense_score - defense_score) > 22
+				ELSE FALSE
+			END
+		WHERE season = @season`,
+		map[string]any{
+			"rush":       rushPlayTypes,
+			"pass":       passPlayTypes,
+			"touchdown":  touchdownPlayTypes,
+			"turnover":   turnoverPlayTypes,
+			"rushValue":  PlayRush,
+			"passValue":  PlayPass,
+			"otherValue": PlayOther,
+			"passing":    DownTypePassing,
+			"standard":   DownTypeStandard,
+			"season":     season,
+		},
+	)
+	if result.Error != nil {
+		return 0, fmt.Errorf("could not compute play flags; %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// DefaultSchema is the schema every table is created in unless
+// Config.Schema names another.
+const DefaultSchema = "cfbd"
+
+// schemaPattern matches the schema names accepted by Config.Schema: unquoted
+// lowercase identifiers, which PostgreSQL truncates past 63 bytes.
+var schemaPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// ErrInvalidSchema is returned for a schema name that is not a lowercase
+// identifier.
+var ErrInvalidSchema = errors.New("invalid schema name")
+
+// ErrMissingPrivilege is returned by CheckPrivileges when the connected role
+// cannot create the seeder's tables.
+var ErrMissingPrivilege = errors.New("missing database privilege")
+
+// CheckPrivileges verifies that the connected role may create the seeder's
+// tables: USAGE and CREATE on its schema if it exists, or CREATE on the
+// database if Initialize still has to create the schema. It returns a short
+// description of what was found.
+func (db *Database) CheckPrivileges(ctx context.Context) (string, error) {
+	if db.requirePostgres("privilege checks") != nil {
+		return "privileges not checked on " + db.Dialector.Name(), nil
+	}
+
+	var role struct {
+		Role         string
+		SchemaExists bool
+		CreateSchema bool
+	}
+	err := db.WithContext(ctx).Raw(`
+		SELECT current_user AS role,
+		       EXISTS (
+		           SELECT 1 FROM pg_namespace WHERE nspname = ?
+		       ) AS schema_exists,
+		       has_database_privilege(current_database(), 'CREATE')
+		           AS create_schema`, db.schema,
+	).Scan(&role).Error
+	if err != nil {
+		return "", fmt.Errorf("could not check database privileges; %w", err)
+	}
+
+	if !role.SchemaExists {
+		if !role.CreateSchema {
+			return "", fmt.Errorf(
+				"%w: role %s may not create schema %s",
+				ErrMissingPrivilege, role.Role, db.schema,
+			)
+		}
+		return fmt.Sprintf(
+			"role %s may create schema %s", role.Role, db.schema,
+		), nil
+	}
+
+	var schema struct {
+		Usage  bool
+		Create bool
+	}
+	err = db.WithContext(ctx).Raw(`
+		SELECT has_schema_privilege(?, 'USAGE') AS usage,
+		       has_schema_privilege(?, 'CREATE') AS create`,
+		db.schema, db.schema,
+	).Scan(&schema).Error
+	if err != nil {
+		return "", fmt.Errorf("could not check schema privileges; %w", err)
+	}
+
+	if !schema.Usage || !schema.Create {
+		return "", fmt.Errorf(
+			"%w: role %s needs USAGE and CREATE on schema %s",
+			ErrMissingPrivilege, role.Role, db.schema,
+		)
+	}
+
+	return fmt.Sprintf(
+		"role %s may create tables in schema %s", role.Role, db.schema,
+	), nil
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// qualityMetric is a completeness metric of data_quality: of the rows of a
+// season in table matching where, the share also matching covered.
+type qualityMetric struct {
+	name    string
+	table   string
+	where   string
+	covered string
+}
+
+// qualityMetrics lists the metrics ComputeDataQuality records.
+var qualityMetrics = []qualityMetric{
+	{
+		// Only completed games have been scored.
+		name:    "games_with_line_scores",
+		table:   (Game{}).TableName(),
+		where:   "completed",
+		covered: "home_line_scores IS NOT NULL AND home_line_scores <> '{}'",
+	},
+	{
+		name:    "plays_with_ppa",
+		table:   (Play{}).TableName(),
+		covered: "ppa IS NOT NULL",
+	},
+	{
+		name:  "games_with_weather",
+		table: (Game{}).TableName(),
+		covered: fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM %s w WHERE w.id = %s.id)",
+			(GameWeather{}).TableName(), (Game{}).TableName(),
+		),
+	},
+	{
+		name:  "games_with_lines",
+		table: (Game{}).TableName(),
+		covered: fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM %s l WHERE l.game_id = %s.id)",
+			(GameLine{}).TableName(), (Game{}).TableName(),
+		),
+	},
+}
+
+// ComputeDataQuality computes every completeness metric of the season and
+// stores it in data_quality, replacing the season's previous values.
+func (db *Database) ComputeDataQuality(
+	ctx context.Context,
+	season int32,
+) error {
+	session := db.WithContext(ctx)
+	now := time.Now().UTC()
+	rows := make([]DataQuality, 0, len(qualityMetrics))
+	for _, metric := range qualityMetrics {
+		var counts struct {
+			Covered int64 `gorm:"column:covered"`
+			Total   int64 `gorm:"column:total"`
+		}
+		query := session.Table(metric.table).
+			Select(fmt.Sprintf(
+				"COUNT(*) AS total, "+
+					"COUNT(CASE WHEN %s THEN 1 END) AS covered",
+				metric.covered,
+			)).
+			Where("season = ?", season)
+		if metric.where != "" {
+			query = query.Where(metric.where)
+		}
+		if err := query.Scan(&counts).Error; err != nil {
+			return fmt.Errorf("could not compute %s; %w", metric.name, err)
+		}
+
+		row := DataQuality{
+			Season:     season,
+			Metric:     metric.name,
+			Covered:    counts.Covered,
+			Total:      counts.Total,
+			ComputedAt: now,
+		}
+
+		if row.Total > 0 {
+			completeness := float64(row.Covered) / float64(row.Total)
+			row.Completeness = &completeness
+		}
+		rows = append(rows, row)
+	}
+
+	err := session.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "season"}, {Name: "metric"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"covered", "total", "completeness", "computed_at",
+		}),
+	}).Create(&rows).Error
+	if err != nil {
+		return fmt.Errorf("could not store data quality; %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// GetRatedGames returns the completed games of a season that have a final
+// score, in the order they were played.
+func (db *Database) GetRatedGames(
+	ctx context.Context,
+	season int32,
+) ([]Game, error) {
+	var games []Game
+	if err := db.WithContext(ctx).
+		Select(
+			"id", "season", "week", "season_type", "start_date",
+			"neutral_site", "home_team", "home_classification",
+			"home_points", "away_team", "away_classification", "away_points",
+		).
+		Where("season = ? AND completed", season).
+		Where("home_points IS NOT NULL AND away_points IS NOT NULL").
+		Order("start_date, id").
+		Find(&games).Error; err != nil {
+		return nil, fmt.Errorf("could not get rated games; %w", err)
+	}
+
+	return games, nil
+}
+
+// GetLocalElo returns every team's locally computed Elo rating after the
+// completed games of a season, keyed by team, or nothing if the season has
+// not been rated.
+func (db *Database) GetLocalElo(
+	ctx context.Context,
+	season int32,
+) (map[string]float64, error) {
+	var ratings []LocalTeamRating
+	if err := db.WithContext(ctx).Where("season = ?", season).
+		Find(&ratings).Error; err != nil {
+		return nil, fmt.Errorf("could not get local ratings; %w", err)
+	}
+
+	elo := make(map[string]float64, len(ratings))
+	for _, r := range ratings {
+		elo[r.Team] = r.Elo
+	}
+
+	return elo, nil
+}
+
+// ReplaceLocalRatings stores the locally computed ratings of a season,
+// replacing the season's previous ratings in one transaction.
+func (db *Database) ReplaceLocalRatings(
+	ctx context.Context,
+	season int32,
+	games []LocalGameElo,
+	teams []LocalTeamRating,
+) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("season = ?", season).Delete(&LocalGameElo{}).Error
+		if err != nil {
+			return fmt.Errorf("could not clear local game elo; %w", err)
+		}
+		err = tx.Where("season = ?", season).Delete(&LocalTeamRating{}).Error
+		if err != nil {
+			return fmt.Errorf("could not clear local team ratings; %w", err)
+		}
+
+		if len(games) > 0 {
+			if err = tx.CreateInBatches(games, 500).Error; err != nil {
+				return fmt.Errorf("could not insert local game elo; %w", err)
+			}
+		}
+		if len(teams) > 0 {
+			if err = tx.CreateInBatches(teams, 500).Error; err != nil {
+				return fmt.Errorf("could not insert local team ratings; %w", err)
+			}
+		}
+
+		return nil
+	})
+}
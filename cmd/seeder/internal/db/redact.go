@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// ErrInvalidRedaction is returned when a redacted column does not exist on
+// its table or is part of the table's primary key.
+var ErrInvalidRedaction = errors.New("invalid column redaction")
+
+// Redact registers a callback that clears the given columns, keyed by table
+// name, on every row before it is created or upserted. Pointer columns are
+// stored as NULL and other columns as their zero value, so deployments can
+// keep fields such as hometown coordinates out of the database entirely.
+func (db *Database) Redact(columns map[string][]string) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	err := db.Callback().Create().Before("gorm:create").Register(
+		"cfbd:redact",
+		func(tx *gorm.DB) {
+			if tx.Error != nil || tx.Statement.Schema == nil {
+				return
+			}
+
+			for _, column := range columns[tx.Statement.Table] {
+				field := tx.Statement.Schema.LookUpField(column)
+				if field == nil || field.PrimaryKey {
+					_ = tx.AddError(fmt.Errorf(
+						"%w: %s.%s", ErrInvalidRedaction,
+						tx.Statement.Table, column,
+					))
+					return
+				}
+				clearField(tx.Statement.Context, tx.Statement.ReflectValue, field)
+			}
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("could not register redaction callback; %w", err)
+	}
+
+	return nil
+}
+
+// clearField zeroes field on the row, or on every row of a batch.
+func clearField(ctx context.Context, value reflect.Value, field *schema.Field) {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range value.Len() {
+			clearField(ctx, reflect.Indirect(value.Index(i)), field)
+		}
+	case reflect.Struct:
+		fieldValue := field.ReflectValueOf(ctx, value)
+		fieldValue.Set(reflect.Zero(fieldValue.Type()))
+	default:
+	}
+}
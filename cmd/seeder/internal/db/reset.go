@@ -0,0 +1,364 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUnknownTable is returned by PlanReset, Reset and ImportRows for a
+	// table the seeder does not create.
+	ErrUnknownTable = errors.New("unknown table")
+	// ErrNoSeasonColumn is returned when resetting seasons of a table whose
+	// rows have no season, which would otherwise be emptied entirely.
+	ErrNoSeasonColumn = errors.New("table has no season column")
+	// ErrUnscopedReset is returned by PlanReset and Reset for a scope that
+	// names neither tables nor seasons without setting All.
+	ErrUnscopedReset = errors.New("reset names no tables or seasons")
+)
+
+// ResetScope selects the rows a reset deletes.
+type ResetScope struct {
+	// Tables are the tables reset. Every seeded table is reset if it is
+	// empty.
+	Tables []string
+	// Years are the seasons deleted, from tables with a season or year
+	// column and from the tables whose rows belong to theirs. A table is
+	// emptied entirely if it is empty.
+	Years []int32
+	// All must be set to empty every seeded table, with neither Tables nor
+	// Years given.
+	All bool
+}
+
+// ResetResult is the outcome of resetting one table.
+type ResetResult struct {
+	Table string `json:"table"`
+	Rows  int64  `json:"rows"`
+	// Truncated reports whether the whole table was emptied rather than
+	// the rows of some seasons.
+	Truncated bool `json:"truncated"`
+}
+
+// PlanReset counts the rows Reset would delete from each table of the
+// scope, without deleting anything. Tables that have not been created are
+// left out.
+func (db *Database) PlanReset(
+	ctx context.Context,
+	scope ResetScope,
+) ([]ResetResult, error) {
+	return db.reset(db.WithContext(ctx), scope, false)
+}
+
+// Reset deletes the rows of the scope's seasons from its tables, or empties
+// the tables entirely if no seasons are given, so that a corrupted load can
+// be redone without dropping the schema. Resetting seasons deletes the rows
+// of child tables without a season of their own, such as game lines and
+// poll ranks, along with the rows they belong to. Tables are reset in the
+// reverse of the order they are seeded, so that rows are deleted before the
+// rows they reference, and all in one transaction. On Postgres, whole
+// tables are truncated together, which fails rather than cascading if a
+// table outside the scope references one of them. The response hashes syncs use to skip
+// unchanged responses are cleared for the tables and seasons reset too, so
+// their rows are written again by the next sync.
+func (db *Database) Reset(
+	ctx context.Context,
+	scope ResetScope,
+) ([]ResetResult, error) {
+	var results []ResetResult
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		results, err = db.reset(tx, scope, true)
+		return err
+	})
+
+	return results, err
+}
+
+// reset counts the rows of the scope in each of its tables, deleting them
+// if apply is set.
+func (db *Database) reset(
+	tx *gorm.DB,
+	scope ResetScope,
+	apply bool,
+) ([]ResetResult, error) {
+	if len(scope.Tables) == 0 && len(scope.Years) == 0 && !scope.All {
+		return nil, ErrUnscopedReset
+	}
+
+	tables, err := db.resetTables(tx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ResetResult, 0, len(tables))
+	var truncate, names []string
+	for _, table := range tables {
+		names = append(names, table.name)
+		result := ResetResult{
+			Table: table.name, Truncated: len(scope.Years) == 0,
+		}
+		query := tx.Table(table.name)
+		var args []any
+		if !result.Truncated {
+			// Every subquery of the condition selects the same seasons.
+			for range strings.Count(table.seasons, "?") {
+				args = append(args, scope.Years)
+			}
+			query = query.Where(table.seasons, args...)
+		}
+		if err = query.Count(&result.Rows).Error; err != nil {
+			return nil, fmt.Errorf("could not count %s; %w", table.name, err)
+		}
+		results = append(results, result)
+
+		if !apply || result.Rows == 0 {
+			continue
+		}
+		if result.Truncated && tx.Dialector.Name() == DriverPostgres {
+			truncate = append(truncate, table.name)
+			continue
+		}
+		if result.Truncated {
+			err = tx.Exec("DELETE FROM " + table.name).Error
+		} else {
+			err = tx.Exec(
+				"DELETE FROM "+table.name+" WHERE "+table.seasons, args...,
+			).Error
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not delete from %s; %w",
+				table.name, err)
+		}
+	}
+	if !apply {
+		return results, nil
+	}
+
+	if len(truncate) > 0 {
+		err = tx.Exec("TRUNCATE TABLE " + strings.Join(truncate, ", ")).Error
+		if err != nil {
+			return nil, fmt.Errorf("could not truncate tables; %w", err)
+		}
+	}
+	if err = clearResponseHashes(tx, names, scope.Years); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// clearResponseHashes deletes the response hashes of the endpoints writing
+// any of the tables, for the requests of the given seasons, or of every
+// season if there are none.
+func clearResponseHashes(tx *gorm.DB, tables []string, years []int32) error {
+	if !tx.Migrator().HasTable(&ResponseManifest{}) {
+		return nil
+	}
+
+	var entries []ResponseManifest
+	err := tx.Select("endpoint", "params").Find(&entries).Error
+	if err != nil {
+		return fmt.Errorf("could not get response hashes; %w", err)
+	}
+
+	for _, entry := range entries {
+		written := responseTables[entry.Endpoint]
+		if !slices.ContainsFunc(written, func(table string) bool {
+			return slices.Contains(tables, table)
+		}) {
+			continue
+		}
+		if len(years) > 0 {
+			var params struct{ Year int32 }
+			if err = json.Unmarshal(entry.Params, &params); err != nil {
+				return fmt.Errorf("could not decode response params; %w", err)
+			}
+			if !slices.Contains(years, params.Year) {
+				continue
+			}
+		}
+
+		err = tx.Where(
+			"endpoint = ? AND params = ?", entry.Endpoint, entry.Params,
+		).Delete(&ResponseManifest{}).Error
+		if err != nil {
+			return fmt.Errorf("could not clear response hashes; %w", err)
+		}
+	}
+
+	return nil
+}
+
+// seasonLink ties the rows of a table without a season of its own to the
+// rows of another table they belong to: a row is in the seasons of the rows
+// of parent whose key its column holds.
+type seasonLink struct {
+	column string
+	parent string
+	key    string
+}
+
+// seasonLinks lists the links of each table whose rows belong to rows of
+// another table but have no season of their own. A row with several links
+// belongs to the seasons of each.
+var seasonLinks = map[string][]seasonLink{
+	"game_team_stats":       {{"id", "games", "id"}},
+	"game_team_stats_teams": {{"game_id", "games", "id"}},
+	"game_team_stats_team_stats": {
+		{"team_row_id", "game_team_stats_teams", "id"},
+	},
+	"game_player_stats":       {{"id", "games", "id"}},
+	"game_player_stats_teams": {{"game_id", "games", "id"}},
+	"game_player_stat_categories": {
+		{"team_row_id", "game_player_stats_teams", "id"},
+	},
+	"game_player_stat_types": {
+		{"category_row_id", "game_player_stat_categories", "id"},
+	},
+	"game_player_stat_players": {
+		{"type_row_id", "game_player_stat_types", "id"},
+	},
+	"game_weather_snapshots": {{"game_id", "games", "id"}},
+	"play_win_probability":   {{"game_id", "games", "id"}},
+	"advanced_box_scores":    {{"game_id", "games", "id"}},
+	"player_usage_splits":    {{"id", "player_usage", "usage_id"}},
+	"recruit_hometown_info":  {{"id", "recruits", "hometown_info_id"}},
+	"polls":                  {{"poll_week_id", "poll_weeks", "id"}},
+	"poll_ranks":             {{"poll_id", "polls", "id"}},
+	"game_lines":             {{"game_id", "betting_games", "id"}},
+	"game_line_snapshots":    {{"game_id", "games", "id"}},
+	"draft_pick_hometown_info": {
+		{"id", "draft_picks", "hometown_info_id"},
+	},
+	"game_havoc_stat_sides": {
+		{"id", "game_havoc_stats", "offense_id"},
+		{"id", "game_havoc_stats", "defense_id"},
+	},
+	"advanced_season_stat_sides": {
+		{"id", "advanced_season_stats", "offense_side_id"},
+		{"id", "advanced_season_stats", "defense_side_id"},
+	},
+	"advanced_game_stat_sides": {
+		{"id", "advanced_game_stats", "offense_side_id"},
+		{"id", "advanced_game_stats", "defense_side_id"},
+	},
+}
+
+// seasonCondition returns the condition selecting the rows of table in the
+// seasons given for each of its placeholders, or "" if its rows have no
+// season.
+func seasonCondition(migrator gorm.Migrator, table string) string {
+	for _, column := range []string{"season", "year"} {
+		if migrator.HasColumn(table, column) {
+			return column + " IN ?"
+		}
+	}
+
+	var conditions []string
+	for _, link := range seasonLinks[table] {
+		parent := seasonCondition(migrator, link.parent)
+		if parent == "" {
+			continue
+		}
+		conditions = append(conditions, fmt.Sprintf(
+			"%s IN (SELECT %s FROM %s WHERE %s)",
+			link.column, link.key, link.parent, parent,
+		))
+	}
+
+	return strings.Join(conditions, " OR ")
+}
+
+// resetTable is a table of a reset scope, with the condition selecting the
+// rows of the scope's seasons if it has any.
+type resetTable struct {
+	name    string
+	seasons string
+}
+
+// resetTables returns the existing tables of the scope, in the reverse of
+// the order they are seeded. Resetting seasons of some tables resets the
+// tables whose rows belong to theirs too, and those are reset before any
+// table with a season of its own, as their rows are found through it.
+func (db *Database) resetTables(
+	tx *gorm.DB,
+	scope ResetScope,
+) ([]resetTable, error) {
+	seeded, err := db.Tables()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range scope.Tables {
+		if !slices.Contains(seeded, name) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownTable, name)
+		}
+	}
+
+	named := slices.Clone(scope.Tables)
+	if len(named) > 0 && len(scope.Years) > 0 {
+		// Add the children of the tables named, then theirs, until there
+		// are no more.
+		for added := true; added; {
+			added = false
+			for _, table := range seeded {
+				if slices.Contains(named, table) {
+					continue
+				}
+				if slices.ContainsFunc(seasonLinks[table],
+					func(link seasonLink) bool {
+						return slices.Contains(named, link.parent)
+					}) {
+					named = append(named, table)
+					added = true
+				}
+			}
+		}
+	}
+
+	migrator := tx.Migrator()
+	var tables []resetTable
+	for _, table := range slices.Backward(seeded) {
+		if len(named) > 0 && !slices.Contains(named, table) {
+			continue
+		}
+		if !migrator.HasTable(table) {
+			continue
+		}
+		reset := resetTable{name: table}
+		if len(scope.Years) > 0 {
+			reset.seasons = seasonCondition(migrator, table)
+			if reset.seasons == "" {
+				if len(scope.Tables) == 0 {
+					// Resetting seasons of every table leaves the rest
+					// alone.
+					continue
+				}
+				return nil, fmt.Errorf("%w: %q", ErrNoSeasonColumn, table)
+			}
+		}
+		tables = append(tables, reset)
+	}
+	if len(scope.Years) == 0 {
+		return tables, nil
+	}
+	slices.SortStableFunc(tables, func(a, b resetTable) int {
+		_, aLinked := seasonLinks[a.name]
+		_, bLinked := seasonLinks[b.name]
+		switch {
+		case aLinked && !bLinked:
+			return -1
+		case bLinked && !aLinked:
+			return 1
+		}
+		return 0
+	})
+
+	return tables, nil
+}
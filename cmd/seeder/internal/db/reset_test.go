@@ -0,0 +1,185 @@
+package db_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+)
+
+// seasonRows are written once for each season of TestResetYears, with the
+// season as the id of every row but the two sides of the advanced stats.
+// Only games, poll weeks, betting games, advanced game stats and team SP
+// ratings have a season or year of their own; every other row belongs to
+// one of theirs.
+var seasonRows = []string{
+	`INSERT INTO games (id, season, week, season_type, start_time_tbd,
+		completed, neutral_site, conference_game)
+	VALUES (%[1]d, %[1]d, 1, 'regular', false, true, false, false)`,
+	`INSERT INTO game_team_stats (id) VALUES (%[1]d)`,
+	`INSERT INTO game_team_stats_teams (id, game_id, team_id, team)
+	VALUES (%[1]d, %[1]d, 1, 'Texas')`,
+	`INSERT INTO game_team_stats_team_stats (id, team_row_id, category, stat)
+	VALUES (%[1]d, %[1]d, 'totalYards', '400')`,
+	`INSERT INTO game_player_stats (id) VALUES (%[1]d)`,
+	`INSERT INTO game_player_stats_teams (id, game_id, team)
+	VALUES (%[1]d, %[1]d, 'Texas')`,
+	`INSERT INTO game_player_stat_categories (id, team_row_id, name)
+	VALUES (%[1]d, %[1]d, 'passing')`,
+	`INSERT INTO game_player_stat_types (id, category_row_id, name)
+	VALUES (%[1]d, %[1]d, 'YDS')`,
+	`INSERT INTO game_player_stat_players (id, type_row_id, player_id, name,
+		stat)
+	VALUES (%[1]d, %[1]d, '1', 'Quinn Ewers', '300')`,
+	`INSERT INTO advanced_box_scores (game_id) VALUES (%[1]d)`,
+	`INSERT INTO play_win_probability (game_id, play_id, home_ball,
+		home_score, away_score, yard_line, down, distance,
+		home_win_probability, play_number)
+	VALUES (%[1]d, '%[1]d', true, 0, 0, 25, 1, 10, 0.5, 1)`,
+	`INSERT INTO poll_weeks (id, season, season_type, week)
+	VALUES (%[1]d, %[1]d, 'regular', 1)`,
+	`INSERT INTO polls (id, poll_week_id, poll)
+	VALUES (%[1]d, %[1]d, 'AP Top 25')`,
+	`INSERT INTO poll_ranks (id, poll_id, school)
+	VALUES (%[1]d, %[1]d, 'Texas')`,
+	`INSERT INTO betting_games (id, season, season_type, week)
+	VALUES (%[1]d, %[1]d, 'regular', 1)`,
+	`INSERT INTO game_lines (game_id, provider)
+	VALUES (%[1]d, 'consensus')`,
+	`INSERT INTO advanced_game_stat_sides (id)
+	VALUES (%[1]d * 2), (%[1]d * 2 + 1)`,
+	`INSERT INTO advanced_game_stats (game_id, season, offense_side_id,
+		defense_side_id)
+	VALUES (%[1]d, %[1]d, %[1]d * 2, %[1]d * 2 + 1)`,
+	`INSERT INTO team_sp (year, team) VALUES (%[1]d, 'Texas')`,
+}
+
+// seasonChildren are the links of the rows in seasonRows without a season
+// of their own to the rows they belong to, as child, its column, parent and
+// the parent's key.
+var seasonChildren = [][4]string{
+	{"game_team_stats", "id", "games", "id"},
+	{"game_team_stats_teams", "game_id", "games", "id"},
+	{"game_team_stats_team_stats", "team_row_id", "game_team_stats_teams",
+		"id"},
+	{"game_player_stats", "id", "games", "id"},
+	{"game_player_stats_teams", "game_id", "games", "id"},
+	{"game_player_stat_categories", "team_row_id", "game_player_stats_teams",
+		"id"},
+	{"game_player_stat_types", "category_row_id",
+		"game_player_stat_categories", "id"},
+	{"game_player_stat_players", "type_row_id", "game_player_stat_types",
+		"id"},
+	{"advanced_box_scores", "game_id", "games", "id"},
+	{"play_win_probability", "game_id", "games", "id"},
+	{"polls", "poll_week_id", "poll_weeks", "id"},
+	{"poll_ranks", "poll_id", "polls", "id"},
+	{"game_lines", "game_id", "betting_games", "id"},
+}
+
+// TestResetYears checks that resetting a season deletes the rows of its
+// child tables with it and leaves every other season alone, on a SQLite
+// database.
+func TestResetYears(t *testing.T) {
+	ctx := context.Background()
+	database, err := db.NewDatabase(db.Config{
+		Driver: db.DriverSQLite,
+		DSN:    filepath.Join(t.TempDir(), "cfbd.db"),
+	})
+	if err != nil {
+		t.Fatalf("could not open database; %v", err)
+	}
+	if err = database.Initialize(); err != nil {
+		t.Fatalf("could not initialize schema; %v", err)
+	}
+
+	for _, season := range []int{2023, 2024} {
+		for _, row := range seasonRows {
+			err = database.Exec(fmt.Sprintf(row, season)).Error
+			if err != nil {
+				t.Fatalf("could not write %d rows; %v", season, err)
+			}
+		}
+	}
+
+	for _, scope := range []db.ResetScope{
+		{Years: []int32{2024}},
+		{Tables: []string{"games", "poll_weeks"}, Years: []int32{2022}},
+	} {
+		if _, err = database.Reset(ctx, scope); err != nil {
+			t.Fatalf("could not reset %v; %v", scope, err)
+		}
+	}
+	checkOrphans(t, database)
+	for _, link := range seasonChildren {
+		checkCount(t, database, link[0], 1)
+	}
+	checkCount(t, database, "advanced_game_stat_sides", 2)
+	checkCount(t, database, "team_sp", 1)
+
+	// Resetting the seasons of a parent resets its children's too.
+	_, err = database.Reset(ctx, db.ResetScope{
+		Tables: []string{"games"}, Years: []int32{2023},
+	})
+	if err != nil {
+		t.Fatalf("could not reset games; %v", err)
+	}
+	checkOrphans(t, database)
+	for table, want := range map[string]int64{
+		"games":                      0,
+		"game_team_stats_team_stats": 0,
+		"game_player_stat_players":   0,
+		"play_win_probability":       0,
+		"poll_ranks":                 1,
+		"game_lines":                 1,
+	} {
+		checkCount(t, database, table, want)
+	}
+}
+
+// checkOrphans fails the test for every row of the seasonChildren, and of
+// the sides of the advanced game stats, whose parent is gone.
+func checkOrphans(t *testing.T, database *db.Database) {
+	t.Helper()
+
+	queries := map[string]string{
+		"advanced_game_stat_sides": `
+			SELECT count(*) FROM advanced_game_stat_sides s
+			WHERE NOT EXISTS (
+				SELECT 1 FROM advanced_game_stats g
+				WHERE s.id IN (g.offense_side_id, g.defense_side_id)
+			)`,
+	}
+	for _, link := range seasonChildren {
+		queries[link[0]] = fmt.Sprintf(`
+			SELECT count(*) FROM %[1]s c
+			WHERE NOT EXISTS (
+				SELECT 1 FROM %[3]s p WHERE p.%[4]s = c.%[2]s
+			)`, link[0], link[1], link[2], link[3])
+	}
+
+	for table, query := range queries {
+		var orphans int64
+		if err := database.Raw(query).Scan(&orphans).Error; err != nil {
+			t.Fatalf("could not count orphaned %s; %v", table, err)
+		}
+		if orphans > 0 {
+			t.Errorf("%s has %d orphaned rows", table, orphans)
+		}
+	}
+}
+
+// checkCount fails the test unless table has want rows.
+func checkCount(t *testing.T, database *db.Database, table string, want int64) {
+	t.Helper()
+
+	var got int64
+	if err := database.Table(table).Count(&got).Error; err != nil {
+		t.Fatalf("could not count %s; %v", table, err)
+	}
+	if got != want {
+		t.Errorf("%s has %d rows, want %d", table, got, want)
+	}
+}
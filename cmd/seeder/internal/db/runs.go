@@ -0,0 +1,171 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm/clause"
+)
+
+// Seed run statuses.
+const (
+	RunRunning   = "running"
+	RunSucceeded = "succeeded"
+	RunFailed    = "failed"
+	// RunStopped is a run wound down by a signal before it finished.
+	RunStopped = "stopped"
+)
+
+// StartSeedRun records the start of a run of the command and returns its
+// ID.
+func (db *Database) StartSeedRun(
+	ctx context.Context,
+	command string,
+	at time.Time,
+) (int64, error) {
+	run := SeedRun{
+		Command:   command,
+		Status:    RunRunning,
+		StartedAt: at,
+	}
+	if err := db.WithContext(ctx).Create(&run).Error; err != nil {
+		return 0, fmt.Errorf("could not record seed run; %w", err)
+	}
+
+	return run.ID, nil
+}
+
+// FinishSeedRun marks the run as succeeded, or failed if runErr is not
+// nil, keeping its JSON summary if one is given.
+func (db *Database) FinishSeedRun(
+	ctx context.Context,
+	id int64,
+	rows int64,
+	runErr error,
+	at time.Time,
+	summary []byte,
+) error {
+	updates := map[string]any{
+		"status":       RunSucceeded,
+		"rows_written": rows,
+		"finished_at":  at,
+	}
+	if runErr != nil {
+		updates["status"] = RunFailed
+		updates["error"] = runErr.Error()
+	}
+	if summary != nil {
+		updates["summary"] = datatypes.JSON(summary)
+	}
+
+	err := db.WithContext(ctx).
+		Model(&SeedRun{}).
+		Where("id = ?", id).
+		Updates(updates).Error
+	if err != nil {
+		return fmt.Errorf("could not finish seed run; %w", err)
+	}
+
+	return nil
+}
+
+// StopSeedRun marks the run as stopped by a signal before it finished,
+// keeping its JSON summary if one is given.
+func (db *Database) StopSeedRun(
+	ctx context.Context,
+	id int64,
+	rows int64,
+	at time.Time,
+	summary []byte,
+) error {
+	updates := map[string]any{
+		"status":       RunStopped,
+		"rows_written": rows,
+		"finished_at":  at,
+	}
+	if summary != nil {
+		updates["summary"] = datatypes.JSON(summary)
+	}
+
+	err := db.WithContext(ctx).
+		Model(&SeedRun{}).
+		Where("id = ?", id).
+		Updates(updates).Error
+	if err != nil {
+		return fmt.Errorf("could not stop seed run; %w", err)
+	}
+
+	return nil
+}
+
+// UpsertTableManifest records the last write time and rows written by the
+// run for each table.
+func (db *Database) UpsertTableManifest(
+	ctx context.Context,
+	runID int64,
+	lastSync map[string]time.Time,
+	rows map[string]int64,
+) error {
+	if len(lastSync) == 0 {
+		return nil
+	}
+
+	models := make([]TableManifest, 0, len(lastSync))
+	for table, at := range lastSync {
+		models = append(models, TableManifest{
+			Table:        table,
+			LastSyncedAt: at,
+			RowsWritten:  rows[table],
+			RunID:        runID,
+		})
+	}
+
+	err := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "table_name"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"last_synced_at",
+			"rows_written",
+			"run_id",
+		}),
+	}).Create(&models).Error
+	if err != nil {
+		return fmt.Errorf("could not upsert table manifest; %w", err)
+	}
+
+	return nil
+}
+
+// GetSeedRuns returns the most recent runs, newest first.
+func (db *Database) GetSeedRuns(
+	ctx context.Context,
+	limit int,
+) ([]SeedRun, error) {
+	var runs []SeedRun
+	err := db.WithContext(ctx).
+		Order("started_at DESC, id DESC").
+		Limit(limit).
+		Find(&runs).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not get seed runs; %w", err)
+	}
+
+	return runs, nil
+}
+
+// GetTableManifest returns every table the seeder has written, least
+// recently synced first.
+func (db *Database) GetTableManifest(
+	ctx context.Context,
+) ([]TableManifest, error) {
+	var manifest []TableManifest
+	err := db.WithContext(ctx).
+		Order("last_synced_at, table_name").
+		Find(&manifest).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not get table manifest; %w", err)
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// GameFilter limits queries of stored games to a targeted seed's games.
+// The zero value matches every game.
+type GameFilter struct {
+	// SeasonTypes are the season types of the games, e.g. "regular".
+	SeasonTypes []string
+	// Teams and Conferences select the games either team of which is one
+	// of Teams or plays in one of Conferences, by name or abbreviation.
+	// A game matching either is selected.
+	Teams       []string
+	Conferences []string
+	// Classification is the classification either team of the games is
+	// of, e.g. "fbs".
+	Classification string
+}
+
+// apply adds the filter's conditions to a query of games.
+func (f GameFilter) apply(tx *gorm.DB) *gorm.DB {
+	tx = bySeasonType(tx, f.SeasonTypes)
+	if f.Classification != "" {
+		tx = tx.Where(
+			"home_classification = @c OR away_classification = @c",
+			map[string]any{"c": f.Classification},
+		)
+	}
+	if len(f.Teams) == 0 && len(f.Conferences) == 0 {
+		return tx
+	}
+
+	// The API takes a conference's abbreviation where games record its
+	// name, so both are matched.
+	return tx.Where(`
+		home_team IN @teams OR away_team IN @teams
+		OR home_conference IN @conferences
+		OR away_conference IN @conferences
+		OR home_conference IN (SELECT name FROM conferences
+			WHERE abbreviation IN @conferences)
+		OR away_conference IN (SELECT name FROM conferences
+			WHERE abbreviation IN @conferences)`,
+		map[string]any{"teams": f.Teams, "conferences": f.Conferences},
+	)
+}
+
+// bySeasonType limits a query to rows of the season types, if any are
+// given.
+func bySeasonType(tx *gorm.DB, seasonTypes []string) *gorm.DB {
+	if len(seasonTypes) == 0 {
+		return tx
+	}
+
+	return tx.Where("season_type IN ?", seasonTypes)
+}
+
+// GetConferenceClassifications returns the classification of each stored
+// conference, by both its name and its abbreviation.
+func (db *Database) GetConferenceClassifications(
+	ctx context.Context,
+) (map[string]string, error) {
+	var conferences []Conference
+	err := db.WithContext(ctx).
+		Select("name", "abbreviation", "classification").
+		Find(&conferences).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not get conferences; %w", err)
+	}
+
+	classifications := make(map[string]string, 2*len(conferences))
+	for _, c := range conferences {
+		if c.Abbreviation != "" {
+			classifications[c.Abbreviation] = c.Classification
+		}
+		classifications[c.Name] = c.Classification
+	}
+
+	return classifications, nil
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm/clause"
+)
+
+// DefaultSearchLimit caps the number of results returned by a search when
+// no limit is given.
+const DefaultSearchLimit = 100
+
+// PlaySearch describes a full-text search over play descriptions.
+type PlaySearch struct {
+	// Text uses web search syntax: words are ANDed, "quoted phrases" match
+	// in order and -word excludes, e.g. `"fake punt" -penalty`. Words are
+	// stemmed, so "punts" also matches "punt".
+	Text string
+	// Season restricts results to one season when non-zero.
+	Season int32
+	// Team restricts results to plays where the team is on offense or
+	// defense when non-empty.
+	Team string
+	// Limit caps the number of results; zero means DefaultSearchLimit.
+	Limit int
+}
+
+// SearchPlays returns the plays whose text matches the search, best matches
+// first. It is served by the GIN index on plays.play_text_search.
+func (db *Database) SearchPlays(
+	ctx context.Context,
+	search PlaySearch,
+) ([]Play, error) {
+	if err := db.requirePostgres("play search"); err != nil {
+		return nil, err
+	}
+
+	limit := search.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	tx := db.WithContext(ctx).
+		Model(&Play{}).
+		Where(
+			"plays.play_text_search @@ websearch_to_tsquery('english', ?)",
+			search.Text,
+		)
+
+	if search.Season != 0 {
+		// Scans only the season's partition.
+		tx = tx.Where("plays.season = ?", search.Season)
+	}
+	if search.Team != "" {
+		tx = tx.Where(
+			"plays.offense = ? OR plays.defense = ?", search.Team, search.Team,
+		)
+	}
+
+	var plays []Play
+	err := tx.
+		Select("plays.*").
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL: "ts_rank(plays.play_text_search, " +
+				"websearch_to_tsquery('english', ?)) DESC, plays.id",
+			Vars:               []any{search.Text},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Find(&plays).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not search plays; %w", err)
+	}
+
+	return plays, nil
+}
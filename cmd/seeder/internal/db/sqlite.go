@@ -0,0 +1,232 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+)
+
+// sqliteDriverName is the database/sql driver SQLite files are opened with:
+// go-sqlite3 with the functions the seeder's queries use that SQLite lacks.
+const sqliteDriverName = "sqlite3_cfbd"
+
+// registerSQLite registers sqliteDriverName once.
+var registerSQLite sync.Once
+
+// sqliteDialect keeps every table in a single SQLite file, for analysis on a
+// laptop without a database server. SQLite stores any column type, but only
+// text keeps JSON and arrays as written, so the Postgres column types of the
+// models are mapped to text and blob (see sqliteColumnType). It needs a
+// build with cgo enabled.
+type sqliteDialect struct{}
+
+func (sqliteDialect) open(dsn string) (gorm.Dialector, error) {
+	registerSQLite.Do(func() {
+		sql.Register(sqliteDriverName, &sqlite3.SQLiteDriver{
+			ConnectHook: registerSQLiteFunctions,
+		})
+	})
+
+	// Seeding writes from many goroutines at once, which SQLite serializes;
+	// writers wait their turn instead of failing with "database is locked".
+	if !strings.Contains(dsn, "?") {
+		dsn += "?_busy_timeout=30000&_journal_mode=WAL"
+	}
+
+	return sqliteDialector{Dialector: &sqlite.Dialector{
+		DriverName: sqliteDriverName,
+		DSN:        dsn,
+	}}, nil
+}
+
+func (sqliteDialect) reopen(conn gorm.ConnPool) gorm.Dialector {
+	return sqliteDialector{Dialector: &sqlite.Dialector{
+		DriverName: sqliteDriverName,
+		Conn:       conn,
+	}}
+}
+
+// prepare also leaves play stats keyed by their ID alone: SQLite only
+// generates the IDs of a single-column integer primary key, and play stats
+// are not partitioned there.
+func (sqliteDialect) prepare(gdb *gorm.DB) error {
+	if err := ignoreTextSearch(gdb); err != nil {
+		return err
+	}
+
+	stmt := &gorm.Statement{DB: gdb}
+	if err := stmt.Parse(&PlayStat{}); err != nil {
+		return fmt.Errorf("could not parse play stats; %w", err)
+	}
+
+	primary := make([]*schema.Field, 0, 1)
+	names := make([]string, 0, 1)
+	for _, field := range stmt.Schema.PrimaryFields {
+		if field.DBName != "id" {
+			field.PrimaryKey = false
+			continue
+		}
+		primary = append(primary, field)
+		names = append(names, field.DBName)
+	}
+	stmt.Schema.PrimaryFields, stmt.Schema.PrimaryFieldDBNames = primary, names
+	if len(primary) == 1 {
+		stmt.Schema.PrioritizedPrimaryField = primary[0]
+	}
+
+	return nil
+}
+
+func (sqliteDialect) createSchema(*gorm.DB) error {
+	return nil
+}
+
+// dropSchema drops every table, as the file is the schema.
+func (sqliteDialect) dropSchema(tx *gorm.DB) error {
+	return dropTables(tx)
+}
+
+// hasSchema is always true: a SQLite file is a single schema of its own.
+func (sqliteDialect) hasSchema(*gorm.DB) (bool, error) {
+	return true, nil
+}
+
+func (sqliteDialect) upsert(target string, columns []string) string {
+	set := make([]string, len(columns))
+	for i, column := range columns {
+		set[i] = column + " = excluded." + column
+	}
+
+	return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s",
+		target, strings.Join(set, ", "))
+}
+
+// estimateRows counts the rows, as SQLite keeps no estimate; a local file is
+// small enough to count.
+func (sqliteDialect) estimateRows(tx *gorm.DB, table string) (int64, error) {
+	var rows int64
+	err := tx.Table(table).Count(&rows).Error
+
+	return rows, err
+}
+
+// registerSQLiteFunctions adds concat and concat_ws, which the SQLite
+// bundled with go-sqlite3 predates, with the PostgreSQL semantics of
+// skipping NULL arguments.
+func registerSQLiteFunctions(conn *sqlite3.SQLiteConn) error {
+	concatWS := func(separator string, args ...any) string {
+		parts := make([]string, 0, len(args))
+		for _, arg := range args {
+			// go-sqlite3 passes NULL as a nil []byte.
+			switch v := arg.(type) {
+			case nil:
+			case []byte:
+				if v != nil {
+					parts = append(parts, string(v))
+				}
+			default:
+				parts = append(parts, fmt.Sprint(v))
+			}
+		}
+		return strings.Join(parts, separator)
+	}
+
+	if err := conn.RegisterFunc("concat_ws", concatWS, true); err != nil {
+		return err
+	}
+
+	return conn.RegisterFunc("concat", func(args ...any) string {
+		return concatWS("", args...)
+	}, true)
+}
+
+// sqliteDialector is the GORM SQLite dialector with a migrator that maps the
+// Postgres column types of the models.
+type sqliteDialector struct {
+	*sqlite.Dialector
+}
+
+func (d sqliteDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	m, _ := d.Dialector.Migrator(db).(sqlite.Migrator)
+	m.Migrator.Config.Dialector = d
+	return sqliteMigrator{Migrator: m}
+}
+
+// sqliteMigrator creates columns with the types sqliteColumnType maps them
+// to.
+type sqliteMigrator struct {
+	sqlite.Migrator
+}
+
+func (m sqliteMigrator) FullDataTypeOf(field *schema.Field) clause.Expr {
+	expr := m.Migrator.FullDataTypeOf(field)
+	declared := m.Migrator.Migrator.DataTypeOf(field)
+	if columnType, ok := sqliteColumnType(declared); ok {
+		expr.SQL = columnType + strings.TrimPrefix(expr.SQL, declared)
+	}
+
+	return expr
+}
+
+// ColumnTypes corrects the nullability of columns declared without NULL or
+// NOT NULL, which the GORM SQLite driver reads as NOT NULL. Left as read,
+// every migration would rebuild every table to make them nullable.
+func (m sqliteMigrator) ColumnTypes(value any) ([]gorm.ColumnType, error) {
+	columnTypes, err := m.Migrator.ColumnTypes(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []struct {
+		Name    string `gorm:"column:name"`
+		NotNull bool   `gorm:"column:notnull"`
+	}
+	err = m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Raw(
+			"SELECT name, \"notnull\" FROM pragma_table_info(?)", stmt.Table,
+		).Scan(&columns).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	notNull := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		notNull[column.Name] = column.NotNull
+	}
+	for i, columnType := range columnTypes {
+		if c, ok := columnType.(migrator.ColumnType); ok {
+			c.NullableValue = sql.NullBool{
+				Bool: !notNull[c.Name()], Valid: true,
+			}
+			columnTypes[i] = c
+		}
+	}
+
+	return columnTypes, nil
+}
+
+// sqliteColumnType maps a Postgres-only column type to SQLite. Declared as
+// is, jsonb and int[] would give their columns numeric affinity, and JSON
+// numbers or arrays would not read back as written.
+func sqliteColumnType(declared string) (string, bool) {
+	switch strings.ToLower(declared) {
+	case "jsonb", "json":
+		return "text", true
+	case "bytea":
+		return "blob", true
+	case "text[]", "int[]":
+		// Stored in the Postgres array literal format pq reads back.
+		return "text", true
+	}
+
+	return "", false
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetLatestStartedWeek returns the calendar week in progress at now or, when
+// between weeks, the most recent one to have started. The boolean is false
+// when no seeded week has started yet.
+func (db *Database) GetLatestStartedWeek(
+	ctx context.Context,
+	now time.Time,
+) (CalendarWeek, bool, error) {
+	var week CalendarWeek
+	err := db.WithContext(ctx).
+		Where("start_date <= ?", now).
+		Order("start_date DESC").
+		First(&week).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return CalendarWeek{}, false, nil
+	}
+	if err != nil {
+		return CalendarWeek{}, false, fmt.Errorf(
+			"could not get latest calendar week; %w", err,
+		)
+	}
+
+	return week, true, nil
+}
+
+// GetCalendarWeeks returns the seeded calendar weeks of the season in
+// the order they are played.
+func (db *Database) GetCalendarWeeks(
+	ctx context.Context,
+	season int32,
+) ([]CalendarWeek, error) {
+	var weeks []CalendarWeek
+	err := db.WithContext(ctx).
+		Where("season = ?", season).
+		Order("start_date NULLS LAST, week").
+		Find(&weeks).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not get calendar weeks; %w", err)
+	}
+
+	return weeks, nil
+}
+
+// responseTables are the tables written from the responses of each endpoint
+// a sync records the hash of, so a reset can clear the hashes of the
+// responses it deletes the rows of.
+var responseTables = map[string][]string{
+	"/games":         {"games"},
+	"/plays":         {"plays"},
+	"/plays/stats":   {"play_stats"},
+	"/lines":         {"betting_games", "game_lines"},
+	"/rankings":      {"poll_weeks", "polls", "poll_ranks"},
+	"/games/media":   {"game_media"},
+	"/games/weather": {"game_weather"},
+}
+
+// GetResponseHash returns the content hash of the last response the
+// endpoint returned for params, or reports false if none was recorded.
+func (db *Database) GetResponseHash(
+	ctx context.Context,
+	endpoint string,
+	params []byte,
+) ([]byte, bool, error) {
+	var entry ResponseManifest
+	err := db.WithContext(ctx).
+		Where("endpoint = ? AND params = ?", endpoint, datatypes.JSON(params)).
+		Take(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("could not get response hash; %w", err)
+	}
+
+	return entry.Hash, true, nil
+}
+
+// UpsertResponseHash records the content hash of the response the endpoint
+// returned for params, as written by the run.
+func (db *Database) UpsertResponseHash(
+	ctx context.Context,
+	runID int64,
+	endpoint string,
+	params []byte,
+	hash []byte,
+	at time.Time,
+) error {
+	entry := ResponseManifest{
+		Endpoint:  endpoint,
+		Params:    datatypes.JSON(params),
+		Hash:      hash,
+		FetchedAt: at,
+		RunID:     runID,
+	}
+
+	err := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "endpoint"},
+			{Name: "params"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"hash",
+			"fetched_at",
+			"run_id",
+		}),
+	}).Create(&entry).Error
+	if err != nil {
+		return fmt.Errorf("could not upsert response hash; %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// insertSpanKey holds the span of an in-flight create statement.
+const insertSpanKey = "cfbd:insert_span"
+
+// tracer records a span per batch insert. Spans are discarded unless a
+// tracer provider has been installed (see tracing.Start).
+var tracer = otel.Tracer(
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db",
+)
+
+// Trace records every create statement (one per insert batch) as a span
+// carrying its table and row count, under the span of the context it runs
+// with.
+func (db *Database) Trace() error {
+	callbacks := db.Callback().Create()
+
+	err := callbacks.Before("gorm:begin_transaction").Register(
+		"cfbd:start_insert_span", startInsertSpan,
+	)
+	if err != nil {
+		return fmt.Errorf("could not register insert span callback; %w", err)
+	}
+
+	err = callbacks.After("gorm:commit_or_rollback_transaction").Register(
+		"cfbd:end_insert_span", endInsertSpan,
+	)
+	if err != nil {
+		return fmt.Errorf("could not register insert span callback; %w", err)
+	}
+
+	return nil
+}
+
+// startInsertSpan starts the span of a create statement. Nested creates,
+// such as a row's associations, become its children.
+func startInsertSpan(tx *gorm.DB) {
+	stmt := tx.Statement
+	if stmt.Context == nil {
+		return
+	}
+
+	ctx, span := tracer.Start(stmt.Context, "insert "+stmt.Table,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.table", stmt.Table),
+			attribute.Int64("cfbd.batch_rows", rowCount(stmt.ReflectValue)),
+		),
+	)
+	stmt.Context = ctx
+	tx.InstanceSet(insertSpanKey, span)
+}
+
+// endInsertSpan ends the span of a create statement with the rows it wrote.
+func endInsertSpan(tx *gorm.DB) {
+	value, ok := tx.InstanceGet(insertSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("cfbd.rows", tx.RowsAffected))
+	if tx.Error != nil {
+		span.RecordError(tx.Error)
+		span.SetStatus(codes.Error, tx.Error.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TravelGame is a game with the coordinates of its venue and of each
+// team's home venue, where known.
+type TravelGame struct {
+	ID          int32
+	Week        int32
+	SeasonType  string
+	StartDate   *time.Time
+	NeutralSite bool
+	HomeTeam    string
+	AwayTeam    string
+
+	VenueID        *int32
+	VenueLatitude  *float64
+	VenueLongitude *float64
+
+	HomeVenueID        *int32
+	HomeVenueLatitude  *float64
+	HomeVenueLongitude *float64
+
+	AwayVenueID        *int32
+	AwayVenueLatitude  *float64
+	AwayVenueLongitude *float64
+}
+
+// GetTravelGames returns a season's games with their venues' coordinates,
+// ordered by start date.
+func (db *Database) GetTravelGames(
+	ctx context.Context,
+	season int32,
+) ([]TravelGame, error) {
+	var games []TravelGame
+	if err := db.WithContext(ctx).Raw(`
+		SELECT g.id, g.week, g.season_type, g.start_date, g.neutral_site,
+			g.home_team, g.away_team,
+			g.venue_id, v.latitude AS venue_latitude,
+			v.longitude AS venue_longitude,
+			ht.venue_id AS home_venue_id,
+			hv.latitude AS home_venue_latitude,
+			hv.longitude AS home_venue_longitude,
+			aw.venue_id AS away_venue_id,
+			av.latitude AS away_venue_latitude,
+			av.longitude AS away_venue_longitude
+		FROM games g
+		LEFT JOIN venues v ON v.id = g.venue_id
+		LEFT JOIN teams ht ON ht.id = g.home_id
+		LEFT JOIN venues hv ON hv.id = ht.venue_id
+		LEFT JOIN teams aw ON aw.id = g.away_id
+		LEFT JOIN venues av ON av.id = aw.venue_id
+		WHERE g.season = ?
+		ORDER BY g.start_date, g.id`, season,
+	).Scan(&games).Error; err != nil {
+		return nil, fmt.Errorf("could not get travel games; %w", err)
+	}
+
+	return games, nil
+}
+
+// ReplaceGameTravel stores a season's travel distances and rest days,
+// replacing the season's previous ones in one transaction.
+func (db *Database) ReplaceGameTravel(
+	ctx context.Context,
+	season int32,
+	travel []GameTravel,
+) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("season = ?", season).Delete(&GameTravel{}).Error
+		if err != nil {
+			return fmt.Errorf("could not clear game travel; %w", err)
+		}
+
+		if len(travel) > 0 {
+			if err = tx.CreateInBatches(travel, 1000).Error; err != nil {
+				return fmt.Errorf("could not insert game travel; %w", err)
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// maxTxAttempts is how many times a transaction that hits a
+	// serialization failure or deadlock is tried before giving up.
+	maxTxAttempts = 3
+	// txRetryDelay is the pause before the first retry; each further retry
+	// waits as long again.
+	txRetryDelay = 100 * time.Millisecond
+	// serializationFailure is the SQLSTATE of a serialization failure.
+	serializationFailure = "40001"
+	// deadlockDetected is the SQLSTATE of a detected deadlock.
+	deadlockDetected = "40P01"
+	// mysqlDeadlock is the MySQL error number of a detected deadlock.
+	mysqlDeadlock = 1213
+	// mysqlLockWaitTimeout is the MySQL error number of a lock wait that
+	// timed out.
+	mysqlLockWaitTimeout = 1205
+)
+
+// createUnits creates each unit of a nested graph (a game and its team
+// stats) together with its children with writeUnits.
+func createUnits[T any](
+	ctx context.Context,
+	gdb *gorm.DB,
+	onConflict clause.OnConflict,
+	units []T,
+) error {
+	return writeUnits(ctx, gdb, units, func(tx *gorm.DB, unit *T) error {
+		return tx.Clauses(onConflict).Create(unit).Error
+	})
+}
+
+// writeUnits writes each unit of a nested graph (a poll week and its polls
+// and ranks, or a game and its team stats) in a transaction of its own, so
+// that a failure can never leave a parent written without its children, or
+// children of a half-written parent. A unit whose transaction hits a
+// serialization failure or deadlock is retried from scratch.
+func writeUnits[T any](
+	ctx context.Context,
+	gdb *gorm.DB,
+	units []T,
+	write func(tx *gorm.DB, unit *T) error,
+) error {
+	for i := range units {
+		err := withTxRetry(ctx, func() error {
+			return gdb.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				return write(tx, &units[i])
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("could not write unit %d of %d; %w",
+				i+1, len(units), err)
+		}
+	}
+
+	return nil
+}
+
+// withTxRetry runs fn, running it again after a short pause while it fails
+// with a serialization failure or deadlock, up to maxTxAttempts times.
+func withTxRetry(ctx context.Context, fn func() error) error {
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		if err = fn(); !retryableTxError(err) || attempt == maxTxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("could not retry transaction; %w", ctx.Err())
+		case <-time.After(time.Duration(attempt) * txRetryDelay):
+		}
+	}
+
+	return err
+}
+
+// retryableTxError reports whether err aborted a transaction that can
+// succeed if simply run again.
+func retryableTxError(err error) bool {
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		return myErr.Number == mysqlDeadlock ||
+			myErr.Number == mysqlLockWaitTimeout
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+}
@@ -0,0 +1,155 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/utils"
+	"gorm.io/gorm/clause"
+)
+
+// UnitSystem is the measurement convention measured values are stored in.
+type UnitSystem string
+
+const (
+	// UnitsImperial stores measurements as the CFBD API returns them:
+	// degrees Fahrenheit, inches, miles per hour and pounds.
+	UnitsImperial UnitSystem = "imperial"
+	// UnitsMetric converts measurements at insert time to degrees Celsius,
+	// millimeters (precipitation), centimeters (height), kilometers per hour
+	// and kilograms.
+	UnitsMetric UnitSystem = "metric"
+)
+
+var (
+	// ErrUnknownUnitSystem is returned for a unit system other than imperial
+	// or metric.
+	ErrUnknownUnitSystem = errors.New("unknown unit system")
+	// ErrUnitMismatch is returned when the database already holds values in
+	// a different unit system than the one configured.
+	ErrUnitMismatch = errors.New("unit system does not match database")
+)
+
+// columnUnit is the unit a measured column is stored in under each system.
+type columnUnit struct {
+	table, column    string
+	imperial, metric string
+}
+
+// measuredColumns lists every column whose unit depends on the configured
+// unit system. Distances on the field stay in yards under both systems.
+var measuredColumns = []columnUnit{
+	{"game_weather", "temperature", "°F", "°C"},
+	{"game_weather", "dew_point", "°F", "°C"},
+	{"game_weather", "precipitation", "in", "mm"},
+	{"game_weather", "snowfall", "in", "mm"},
+	{"game_weather", "wind_speed", "mph", "km/h"},
+	{"game_weather_snapshots", "temperature", "°F", "°C"},
+	{"game_weather_snapshots", "dew_point", "°F", "°C"},
+	{"game_weather_snapshots", "precipitation", "in", "mm"},
+	{"game_weather_snapshots", "snowfall", "in", "mm"},
+	{"game_weather_snapshots", "wind_speed", "mph", "km/h"},
+	{"roster_players", "height", "in", "cm"},
+	{"roster_players", "weight", "lb", "kg"},
+	{"recruits", "height", "in", "cm"},
+	{"recruits", "weight", "lb", "kg"},
+	{"draft_picks", "height", "in", "cm"},
+	{"draft_picks", "weight", "lb", "kg"},
+}
+
+// EnforceUnits records the unit of every measured column in column_units
+// for the configured unit system. It fails with ErrUnitMismatch if the
+// database was previously seeded in the other system, since mixing the two
+// would silently corrupt the stored values.
+func (db *Database) EnforceUnits(ctx context.Context) error {
+	var stored []ColumnUnit
+	if err := db.WithContext(ctx).Find(&stored).Error; err != nil {
+		return fmt.Errorf("could not get column units; %w", err)
+	}
+
+	expected := make(map[string]string, len(measuredColumns))
+	models := make([]ColumnUnit, 0, len(measuredColumns))
+	for _, c := range measuredColumns {
+		unit := c.imperial
+		if db.units == UnitsMetric {
+			unit = c.metric
+		}
+		expected[c.table+"."+c.column] = unit
+		models = append(models, ColumnUnit{
+			Table:  c.table,
+			Column: c.column,
+			Unit:   unit,
+		})
+	}
+
+	for _, s := range stored {
+		unit, ok := expected[s.Table+"."+s.Column]
+		if ok && unit != s.Unit {
+			return fmt.Errorf(
+				"%w: %s.%s is stored in %s, not %s",
+				ErrUnitMismatch, s.Table, s.Column, s.Unit, unit,
+			)
+		}
+	}
+
+	err := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "table_name"},
+			{Name: "column_name"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"unit",
+		}),
+	}).Create(&models).Error
+	if err != nil {
+		return fmt.Errorf("could not record column units; %w", err)
+	}
+
+	return nil
+}
+
+// Units returns the unit system measured values are stored in.
+func (db *Database) Units() UnitSystem {
+	return db.units
+}
+
+// temperature converts a Fahrenheit value to the configured unit system.
+func (db *Database) temperature(f *float64) *float64 {
+	if db.units != UnitsMetric {
+		return f
+	}
+	return utils.ConvertFloat(f, utils.FahrenheitToCelsius)
+}
+
+// precipitation converts an inch value to the configured unit system.
+func (db *Database) precipitation(in *float64) *float64 {
+	if db.units != UnitsMetric {
+		return in
+	}
+	return utils.ConvertFloat(in, utils.InchesToMillimeters)
+}
+
+// windSpeed converts a miles per hour value to the configured unit system.
+func (db *Database) windSpeed(mph *float64) *float64 {
+	if db.units != UnitsMetric {
+		return mph
+	}
+	return utils.ConvertFloat(mph, utils.MilesPerHourToKilometersPerHour)
+}
+
+// height converts a height in inches to the configured unit system.
+func (db *Database) height(in *float64) *float64 {
+	if db.units != UnitsMetric {
+		return in
+	}
+	return utils.ConvertFloat(in, utils.InchesToCentimeters)
+}
+
+// weight converts a weight in pounds to the configured unit system.
+func (db *Database) weight(lb *int32) *int32 {
+	if db.units != UnitsMetric {
+		return lb
+	}
+	return utils.ConvertInt32(lb, utils.PoundsToKilograms)
+}
@@ -0,0 +1,308 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ValidationMode is what happens to a row that breaks a validation rule.
+// Every mode but ValidationOff records the row in validation_errors.
+type ValidationMode string
+
+const (
+	// ValidationFail fails the insert the row is part of.
+	ValidationFail ValidationMode = "fail"
+	// ValidationSkip drops the row from its insert.
+	ValidationSkip ValidationMode = "skip"
+	// ValidationLog inserts the row anyway.
+	ValidationLog ValidationMode = "log"
+	// ValidationOff does not check the rows at all.
+	ValidationOff ValidationMode = "off"
+)
+
+// minSeason is the first college football season.
+const minSeason = 1869
+
+// validationErrorsKey holds the rows of a create statement that broke a
+// rule, to be recorded once the statement has finished.
+const validationErrorsKey = "cfbd:validation_errors"
+
+var (
+	// ErrUnknownValidationMode is returned for a validation mode other than
+	// fail, skip, log or off.
+	ErrUnknownValidationMode = errors.New("unknown validation mode")
+	// ErrInvalidRow is returned by inserts failed by ValidationFail.
+	ErrInvalidRow = errors.New("row failed validation")
+)
+
+// ParseValidationMode returns the validation mode named s.
+func ParseValidationMode(s string) (ValidationMode, error) {
+	switch mode := ValidationMode(s); mode {
+	case ValidationFail, ValidationSkip, ValidationLog, ValidationOff:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownValidationMode, s)
+	}
+}
+
+// validationRule is a check every row written to a table must pass.
+type validationRule struct {
+	name  string
+	valid func(row any) bool
+}
+
+// rowRule returns a rule checking the rows of model T.
+func rowRule[T any](name string, valid func(*T) bool) validationRule {
+	return validationRule{
+		name: name,
+		valid: func(row any) bool {
+			r, ok := row.(*T)
+			return !ok || valid(r)
+		},
+	}
+}
+
+// validationRules lists the rules of each table, by table name.
+var validationRules = map[string][]validationRule{
+	"games": {
+		rowRule("season in range", func(g *Game) bool {
+			return validSeason(float64(g.Season))
+		}),
+		rowRule("scores non-negative", func(g *Game) bool {
+			return nonNegative(g.HomePoints) && nonNegative(g.AwayPoints)
+		}),
+		rowRule("team names set", func(g *Game) bool {
+			return g.HomeTeam != "" && g.AwayTeam != ""
+		}),
+	},
+	"betting_games": {
+		rowRule("season in range", func(g *BettingGame) bool {
+			return validSeason(float64(g.Season))
+		}),
+		rowRule("scores non-negative", func(g *BettingGame) bool {
+			return nonNegative(g.HomeScore) && nonNegative(g.AwayScore)
+		}),
+		rowRule("team names set", func(g *BettingGame) bool {
+			return g.HomeTeam != "" && g.AwayTeam != ""
+		}),
+	},
+	"drives": {
+		rowRule("season in range", func(d *Drive) bool {
+			return validSeason(float64(d.Season))
+		}),
+		rowRule("yards to goal in range", func(d *Drive) bool {
+			return validYardsToGoal(float64(d.StartYardsToGoal)) &&
+				validYardsToGoal(float64(d.EndYardsToGoal))
+		}),
+		rowRule("team names set", func(d *Drive) bool {
+			return d.Offense != "" && d.Defense != ""
+		}),
+	},
+	"plays": {
+		rowRule("season in range", func(p *Play) bool {
+			return validSeason(float64(p.Season))
+		}),
+		rowRule("yards to goal in range", func(p *Play) bool {
+			return validYardsToGoal(float64(p.YardsToGoal))
+		}),
+	},
+	"play_stats": {
+		rowRule("season in range", func(s *PlayStat) bool {
+			return validSeason(s.Season)
+		}),
+		rowRule("yards to goal in range", func(s *PlayStat) bool {
+			return validYardsToGoal(s.YardsToGoal)
+		}),
+	},
+	"teams": {
+		rowRule("school set", func(t *Team) bool {
+			return t.School != ""
+		}),
+	},
+}
+
+// validSeason reports whether the season has been or is about to be played.
+func validSeason(season float64) bool {
+	return season >= minSeason && season <= float64(time.Now().Year()+1)
+}
+
+// validYardsToGoal reports whether the distance lies on the field.
+func validYardsToGoal(yards float64) bool {
+	return yards >= 0 && yards <= 100
+}
+
+// nonNegative reports whether the optional value is unset or not negative.
+func nonNegative(v *int32) bool {
+	return v == nil || *v >= 0
+}
+
+// Validate registers a callback that checks every row written to a table
+// against the table's rules before it is created or upserted, handling rows
+// that break one as mode says. tables overrides mode for the tables named.
+// Rows that break a rule are recorded in validation_errors once their
+// insert has finished; those of a failed insert in a caller's transaction
+// are rolled back with it.
+//
+// Rows dropped by ValidationSkip are left out of a copy of the batch, so,
+// as with SkipUnchanged, they and the rows kept do not receive generated IDs
+// back. It must be called after BulkCopy and before SkipUnchanged and
+// DisableWrites.
+func (db *Database) Validate(
+	ctx context.Context,
+	mode ValidationMode,
+	tables map[string]ValidationMode,
+) error {
+	modes := slices.AppendSeq([]ValidationMode{mode}, maps.Values(tables))
+	for _, m := range modes {
+		if _, err := ParseValidationMode(string(m)); err != nil {
+			return err
+		}
+	}
+
+	if err := skipMarkedCreates(db.DB); err != nil {
+		return err
+	}
+
+	callbacks := db.Callback().Create()
+	err := callbacks.Before("gorm:save_before_associations").Register(
+		"cfbd:validate",
+		func(tx *gorm.DB) {
+			tableMode, ok := tables[tx.Statement.Table]
+			if !ok {
+				tableMode = mode
+			}
+			validate(tx, tableMode)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("could not register validation callback; %w", err)
+	}
+
+	err = callbacks.After("gorm:commit_or_rollback_transaction").Register(
+		"cfbd:record_validation_errors", recordValidationErrors,
+	)
+	if err != nil {
+		return fmt.Errorf("could not register validation error callback; %w",
+			err)
+	}
+
+	return nil
+}
+
+// validate checks the rows of a create statement against the rules of its
+// table, handling those that break one as mode says.
+func validate(tx *gorm.DB, mode ValidationMode) {
+	stmt := tx.Statement
+	rules := validationRules[stmt.Table]
+	if tx.Error != nil || stmt.Schema == nil || len(rules) == 0 ||
+		mode == ValidationOff {
+		return
+	}
+
+	rows := stmt.ReflectValue
+	batch := rows.Kind() == reflect.Slice
+	if !batch {
+		rows = reflect.Append(
+			reflect.MakeSlice(reflect.SliceOf(rows.Type()), 0, 1), rows,
+		)
+	}
+
+	kept := reflect.MakeSlice(rows.Type(), 0, rows.Len())
+	var rejected []ValidationError
+	for i := range rows.Len() {
+		row := reflect.Indirect(rows.Index(i))
+		if !row.CanAddr() {
+			kept = reflect.Append(kept, rows.Index(i))
+			continue
+		}
+
+		broken := brokenRule(rules, row.Addr().Interface())
+		if broken == "" {
+			kept = reflect.Append(kept, rows.Index(i))
+			continue
+		}
+
+		key, _ := rowKey(stmt.Context, stmt.Schema, row)
+		payload, err := json.Marshal(row.Interface())
+		if err != nil {
+			_ = tx.AddError(fmt.Errorf("could not encode invalid row; %w", err))
+			return
+		}
+		slog.Warn("row failed validation",
+			"table", stmt.Table, "rule", broken, "row", key, "mode", mode)
+		rejected = append(rejected, ValidationError{
+			Table:      stmt.Table,
+			Key:        key,
+			Rule:       broken,
+			Mode:       string(mode),
+			Row:        datatypes.JSON(payload),
+			DetectedAt: time.Now().UTC(),
+		})
+		if mode != ValidationSkip {
+			kept = reflect.Append(kept, rows.Index(i))
+		}
+	}
+	if len(rejected) == 0 {
+		return
+	}
+	tx.InstanceSet(validationErrorsKey, rejected)
+
+	if mode == ValidationFail {
+		_ = tx.AddError(fmt.Errorf("%w: %d %s rows, e.g. %s",
+			ErrInvalidRow, len(rejected), stmt.Table, rejected[0].Rule))
+		return
+	}
+	if kept.Len() == rows.Len() {
+		return
+	}
+	if batch {
+		stmt.ReflectValue = kept
+	}
+	if kept.Len() == 0 {
+		tx.InstanceSet(skipCreateKey, true)
+	}
+}
+
+// brokenRule returns the name of the first rule the row breaks, or "" if it
+// passes them all.
+func brokenRule(rules []validationRule, row any) string {
+	for _, rule := range rules {
+		if !rule.valid(row) {
+			return rule.name
+		}
+	}
+
+	return ""
+}
+
+// recordValidationErrors records the rows a create statement found invalid.
+// It runs once the statement's own transaction has finished, so that the
+// rows of a failed insert are kept.
+func recordValidationErrors(tx *gorm.DB) {
+	value, ok := tx.InstanceGet(validationErrorsKey)
+	if !ok {
+		return
+	}
+	rejected, _ := value.([]ValidationError)
+	if len(rejected) == 0 {
+		return
+	}
+
+	session := tx.Session(&gorm.Session{NewDB: true})
+	session.Error = nil
+	if err := session.Create(&rejected).Error; err != nil {
+		slog.Error("could not record validation errors", "err", err.Error())
+		_ = tx.AddError(fmt.Errorf("could not record validation errors; %w",
+			err))
+	}
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetVenuesMissingLocation returns the venues without coordinates or
+// elevation, ordered by ID.
+func (db *Database) GetVenuesMissingLocation(
+	ctx context.Context,
+) ([]Venue, error) {
+	var venues []Venue
+	if err := db.WithContext(ctx).
+		Where("latitude IS NULL OR longitude IS NULL").
+		Or("elevation IS NULL OR elevation = ''").
+		Order("id").
+		Find(&venues).Error; err != nil {
+		return nil, fmt.Errorf("could not get venues missing location; %w", err)
+	}
+
+	return venues, nil
+}
+
+// GetVenueGeocodes returns every stored venue geocode, keyed by venue ID.
+func (db *Database) GetVenueGeocodes(
+	ctx context.Context,
+) (map[int32]VenueGeocode, error) {
+	var geocodes []VenueGeocode
+	if err := db.WithContext(ctx).Find(&geocodes).Error; err != nil {
+		return nil, fmt.Errorf("could not get venue geocodes; %w", err)
+	}
+
+	byVenue := make(map[int32]VenueGeocode, len(geocodes))
+	for _, g := range geocodes {
+		byVenue[g.VenueID] = g
+	}
+
+	return byVenue, nil
+}
+
+// FillVenueLocation stores a venue's geocode and fills in whichever of the
+// venue's coordinates and elevation are missing from it, in one
+// transaction. Values the API provides are never replaced.
+func (db *Database) FillVenueLocation(
+	ctx context.Context,
+	geocode VenueGeocode,
+) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "venue_id"}},
+			UpdateAll: true,
+		}).Create(&geocode).Error
+		if err != nil {
+			return fmt.Errorf("could not store venue geocode; %w", err)
+		}
+
+		err = tx.Model(&Venue{}).
+			Where("id = ?", geocode.VenueID).
+			Updates(map[string]any{
+				"latitude": gorm.Expr(
+					"COALESCE(latitude, ?)", geocode.Latitude,
+				),
+				"longitude": gorm.Expr(
+					"COALESCE(longitude, ?)", geocode.Longitude,
+				),
+				"elevation": gorm.Expr(
+					"COALESCE(NULLIF(elevation, ''), ?)",
+					strconv.FormatFloat(geocode.Elevation, 'f', -1, 64),
+				),
+			}).Error
+		if err != nil {
+			return fmt.Errorf("could not fill venue location; %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,332 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/clintrovert/cfbd-go/cfbd"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// nullValue is how an absent value is rendered in a VerificationDiff.
+const nullValue = "null"
+
+// gameFields are the game columns compared when re-verifying a week. They
+// cover the values upstream is known to correct after the fact.
+var gameFields = []struct {
+	name  string
+	value func(Game) string
+}{
+	{"week", func(g Game) string { return fmt.Sprint(g.Week) }},
+	{"start_date", func(g Game) string { return formatPtr(g.StartDate) }},
+	{"neutral_site", func(g Game) string { return fmt.Sprint(g.NeutralSite) }},
+	{"home_classification", func(g Game) string {
+		return g.HomeClassification
+	}},
+	{"away_classification", func(g Game) string {
+		return g.AwayClassification
+	}},
+	{"completed", func(g Game) string { return fmt.Sprint(g.Completed) }},
+	{"attendance", func(g Game) string { return formatPtr(g.Attendance) }},
+	{"venue_id", func(g Game) string { return formatPtr(g.VenueID) }},
+	{"home_points", func(g Game) string { return formatPtr(g.HomePoints) }},
+	{"away_points", func(g Game) string { return formatPtr(g.AwayPoints) }},
+	{"home_line_scores", func(g Game) string {
+		return fmt.Sprint([]int64(g.HomeLineScores))
+	}},
+	{"away_line_scores", func(g Game) string {
+		return fmt.Sprint([]int64(g.AwayLineScores))
+	}},
+	{"home_postgame_elo", func(g Game) string {
+		return formatPtr(g.HomePostgameElo)
+	}},
+	{"away_postgame_elo", func(g Game) string {
+		return formatPtr(g.AwayPostgameElo)
+	}},
+	{"excitement_index", func(g Game) string {
+		return formatPtr(g.ExcitementIndex)
+	}},
+}
+
+// NextVerificationWeek returns the calendar week following the named sweep's
+// cursor, wrapping back to the first week once the last one is reached. Only
+// weeks that have already ended are considered. The boolean is false when
+// there are no historical weeks to verify.
+func (db *Database) NextVerificationWeek(
+	ctx context.Context,
+	name string,
+) (CalendarWeek, bool, error) {
+	now := time.Now().UTC()
+	order := "season, season_type, week"
+
+	var cursor VerificationCursor
+	err := db.WithContext(ctx).First(&cursor, "name = ?", name).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return CalendarWeek{}, false, fmt.Errorf(
+			"could not get verification cursor; %w", err,
+		)
+	}
+
+	var week CalendarWeek
+	if err == nil {
+		err = db.WithContext(ctx).
+			Where("end_date < ?", now).
+			Where(
+				"(season, season_type, week) > (?, ?, ?)",
+				cursor.Season, cursor.SeasonType, cursor.Week,
+			).
+			Order(order).
+			First(&week).Error
+		if err == nil {
+			return week, true, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return CalendarWeek{}, false, fmt.Errorf(
+				"could not get next verification week; %w", err,
+			)
+		}
+	}
+
+	// No cursor yet, or the cursor is on the last week: start over.
+	err = db.WithContext(ctx).
+		Where("end_date < ?", now).
+		Order(order).
+		First(&week).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return CalendarWeek{}, false, nil
+	}
+	if err != nil {
+		return CalendarWeek{}, false, fmt.Errorf(
+			"could not get first verification week; %w", err,
+		)
+	}
+
+	return week, true, nil
+}
+
+// SetVerificationCursor moves the named sweep's cursor to the given week.
+func (db *Database) SetVerificationCursor(
+	ctx context.Context,
+	name string,
+	week CalendarWeek,
+) error {
+	return db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"season",
+			"week",
+			"season_type",
+			"verified_at",
+		}),
+	}).Create(&VerificationCursor{
+		Name:       name,
+		Season:     week.Season,
+		Week:       week.Week,
+		SeasonType: week.SeasonType,
+		VerifiedAt: time.Now().UTC(),
+	}).Error
+}
+
+// lineFields are the columns of a provider's line compared by DiffLines.
+var lineFields = []struct {
+	name  string
+	value func(GameLine) string
+}{
+	{"spread", func(l GameLine) string { return formatPtr(l.Spread) }},
+	{"spread_open", func(l GameLine) string { return formatPtr(l.SpreadOpen) }},
+	{"over_under", func(l GameLine) string { return formatPtr(l.OverUnder) }},
+	{"over_under_open", func(l GameLine) string {
+		return formatPtr(l.OverUnderOpen)
+	}},
+	{"home_moneyline", func(l GameLine) string {
+		return formatPtr(l.HomeMoneyline)
+	}},
+	{"away_moneyline", func(l GameLine) string {
+		return formatPtr(l.AwayMoneyline)
+	}},
+}
+
+// inWeek narrows a query to the rows of the week, or of its whole season if
+// the week is zero.
+func inWeek(tx *gorm.DB, week CalendarWeek) *gorm.DB {
+	tx = tx.Where("season = ?", week.Season)
+	if week.Week != 0 {
+		tx = tx.Where("week = ? AND season_type = ?", week.Week, week.SeasonType)
+	}
+
+	return tx
+}
+
+// DiffGames compares freshly fetched games with the stored rows for the
+// same week, or the whole season if the week is zero, and returns a diff for
+// every field that changed upstream. Games missing from the database are
+// reported with a "row" field.
+func (db *Database) DiffGames(
+	ctx context.Context,
+	week CalendarWeek,
+	games []*cfbd.Game,
+) ([]VerificationDiff, error) {
+	var stored []Game
+	if err := inWeek(db.WithContext(ctx), week).
+		Find(&stored).Error; err != nil {
+		return nil, fmt.Errorf("could not get stored games; %w", err)
+	}
+
+	byID := make(map[int32]Game, len(stored))
+	for _, g := range stored {
+		byID[g.ID] = g
+	}
+
+	detectedAt := time.Now().UTC()
+	var diffs []VerificationDiff
+	for _, g := range games {
+		if g == nil || g.GetId() == 0 {
+			continue
+		}
+
+		fresh := newGameModel(g)
+		diff := func(field, was, now string) VerificationDiff {
+			return VerificationDiff{
+				Season:        fresh.Season,
+				Week:          fresh.Week,
+				SeasonType:    fresh.SeasonType,
+				Entity:        "game",
+				EntityID:      fresh.ID,
+				Field:         field,
+				StoredValue:   was,
+				UpstreamValue: now,
+				DetectedAt:    detectedAt,
+			}
+		}
+
+		old, ok := byID[fresh.ID]
+		if !ok {
+			diffs = append(diffs, diff("row", "missing", "present"))
+			continue
+		}
+
+		for _, f := range gameFields {
+			was, now := f.value(old), f.value(fresh)
+			if was != now {
+				diffs = append(diffs, diff(f.name, was, now))
+			}
+		}
+	}
+
+	return diffs, nil
+}
+
+// DiffLines compares freshly fetched betting lines with the stored lines of
+// the same games, for the week or the whole season if the week is zero, and
+// returns a diff for every field of a provider's line that changed
+// upstream, named <provider>.<field>. A provider's line missing from the
+// database is reported with the provider alone as the field.
+func (db *Database) DiffLines(
+	ctx context.Context,
+	week CalendarWeek,
+	games []*cfbd.BettingGame,
+) ([]VerificationDiff, error) {
+	var stored []BettingGame
+	if err := inWeek(db.WithContext(ctx), week).
+		Preload("Lines").
+		Find(&stored).Error; err != nil {
+		return nil, fmt.Errorf("could not get stored lines; %w", err)
+	}
+
+	byGame := make(map[int32]map[string]GameLine, len(stored))
+	for _, g := range stored {
+		byGame[g.ID] = make(map[string]GameLine, len(g.Lines))
+		for _, l := range g.Lines {
+			byGame[g.ID][l.Provider] = l
+		}
+	}
+
+	detectedAt := time.Now().UTC()
+	var diffs []VerificationDiff
+	for _, g := range games {
+		if g == nil || g.GetId() == 0 {
+			continue
+		}
+
+		diff := func(field, was, now string) VerificationDiff {
+			return VerificationDiff{
+				Season:        g.GetSeason(),
+				Week:          g.GetWeek(),
+				SeasonType:    g.GetSeasonType(),
+				Entity:        "line",
+				EntityID:      g.GetId(),
+				Field:         field,
+				StoredValue:   was,
+				UpstreamValue: now,
+				DetectedAt:    detectedAt,
+			}
+		}
+
+		for _, l := range g.GetLines() {
+			if l == nil {
+				continue
+			}
+
+			fresh := GameLine{
+				GameID:        g.GetId(),
+				Provider:      l.GetProvider(),
+				Spread:        l.Spread,
+				SpreadOpen:    l.SpreadOpen,
+				OverUnder:     l.OverUnder,
+				OverUnderOpen: l.OverUnderOpen,
+				HomeMoneyline: l.HomeMoneyline,
+				AwayMoneyline: l.AwayMoneyline,
+			}
+			old, ok := byGame[fresh.GameID][fresh.Provider]
+			if !ok {
+				diffs = append(diffs, diff(fresh.Provider, "missing", "present"))
+				continue
+			}
+
+			for _, f := range lineFields {
+				was, now := f.value(old), f.value(fresh)
+				if was != now {
+					diffs = append(diffs, diff(fresh.Provider+"."+f.name, was, now))
+				}
+			}
+		}
+	}
+
+	return diffs, nil
+}
+
+// InsertVerificationDiffs records detected upstream corrections.
+func (db *Database) InsertVerificationDiffs(
+	ctx context.Context,
+	diffs []VerificationDiff,
+) error {
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	if err := db.WithContext(ctx).
+		CreateInBatches(diffs, 500).Error; err != nil {
+		slog.Error("could not insert verification diffs", "err", err.Error())
+		return fmt.Errorf("could not insert verification diffs; %w", err)
+	}
+
+	return nil
+}
+
+// formatPtr renders an optional value for comparison, using UTC for times
+// so that stored and fetched timestamps compare equal.
+func formatPtr[T any](p *T) string {
+	if p == nil {
+		return nullValue
+	}
+
+	if t, ok := any(*p).(time.Time); ok {
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	return fmt.Sprint(*p)
+}
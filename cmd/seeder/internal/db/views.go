@@ -0,0 +1,195 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Outcomes of refreshing an analytics view.
+const (
+	ViewCreated   = "created"
+	ViewRefreshed = "refreshed"
+)
+
+// view is a materialized view derived from the seeded tables. Its key
+// columns identify a row, so that the view can be refreshed concurrently.
+type view struct {
+	name  string
+	key   []string
+	query string
+}
+
+// views lists the analytics views RefreshViews maintains.
+var views = []view{
+	{
+		// Offensive EPA of a team's plays and the EPA it allowed on defense.
+		name: "team_season_epa",
+		key:  []string{"season", "team"},
+		query: `
+			WITH offense AS (
+				SELECT season, offense AS team, COUNT(*) AS plays,
+					SUM(ppa) AS epa, AVG(ppa) AS epa_per_play
+				FROM plays
+				WHERE ppa IS NOT NULL AND offense <> ''
+				GROUP BY season, offense
+			), defense AS (
+				SELECT season, defense AS team, COUNT(*) AS plays,
+					SUM(ppa) AS epa, AVG(ppa) AS epa_per_play
+				FROM plays
+				WHERE ppa IS NOT NULL AND defense <> ''
+				GROUP BY season, defense
+			)
+			SELECT COALESCE(o.season, d.season) AS season,
+				COALESCE(o.team, d.team) AS team,
+				COALESCE(o.plays, 0) AS offense_plays,
+				o.epa AS offense_epa,
+				o.epa_per_play AS offense_epa_per_play,
+				COALESCE(d.plays, 0) AS defense_plays,
+				d.epa AS defense_epa,
+				d.epa_per_play AS defense_epa_per_play
+			FROM offense o
+			FULL JOIN defense d ON d.season = o.season AND d.team = o.team`,
+	},
+	{
+		// A passer's season line per team, from the stats of each play.
+		name: "qb_season_passing",
+		key:  []string{"season", "athlete_id", "team"},
+		query: `
+			SELECT season::int AS season, athlete_id, team,
+				MAX(athlete_name) AS athlete_name,
+				COUNT(*) FILTER (WHERE stat_type = 'Completion') AS completions,
+				COUNT(*) FILTER (
+					WHERE stat_type IN (
+						'Completion', 'Incompletion', 'Interception Thrown'
+					)
+				) AS attempts,
+				COALESCE(
+					SUM(stat) FILTER (WHERE stat_type = 'Completion'), 0
+				) AS passing_yards,
+				COUNT(*) FILTER (
+					WHERE stat_type = 'Interception Thrown'
+				) AS interceptions,
+				COUNT(*) FILTER (WHERE stat_type = 'Sack Taken') AS sacks
+			FROM play_stats
+			WHERE athlete_id <> '' AND stat_type IN (
+				'Completion', 'Incompletion', 'Interception Thrown',
+				'Sack Taken'
+			)
+			GROUP BY season, athlete_id, team
+			HAVING COUNT(*) FILTER (
+				WHERE stat_type IN (
+					'Completion', 'Incompletion', 'Interception Thrown'
+				)
+			) > 0`,
+	},
+	{
+		// How each completed game finished against every provider's line.
+		// Spreads are from the home team's side, so the home team covers
+		// when its margin plus the spread is positive.
+		name: "game_ats_results",
+		key:  []string{"game_id", "provider"},
+		query: `
+			SELECT g.id AS game_id, g.season, g.week, g.season_type,
+				l.provider, g.home_team, g.away_team,
+				g.home_points, g.away_points, l.spread, l.over_under,
+				g.home_points - g.away_points + l.spread AS home_ats_margin,
+				CASE
+					WHEN l.spread IS NULL THEN NULL
+					WHEN g.home_points - g.away_points + l.spread > 0
+						THEN 'home'
+					WHEN g.home_points - g.away_points + l.spread < 0
+						THEN 'away'
+					ELSE 'push'
+				END AS ats_winner,
+				g.home_points + g.away_points AS total_points,
+				CASE
+					WHEN l.over_under IS NULL THEN NULL
+					WHEN g.home_points + g.away_points > l.over_under
+						THEN 'over'
+					WHEN g.home_points + g.away_points < l.over_under
+						THEN 'under'
+					ELSE 'push'
+				END AS total_result
+			FROM games g
+			JOIN game_lines l ON l.game_id = g.id
+			WHERE g.completed
+				AND g.home_points IS NOT NULL
+				AND g.away_points IS NOT NULL`,
+	},
+}
+
+// ViewResult is the outcome of refreshing one analytics view.
+type ViewResult struct {
+	View       string `json:"view"`
+	Status     string `json:"status"`
+	Rows       int64  `json:"rows"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// RefreshViews brings the analytics views up to date with the seeded
+// tables: team season EPA, quarterback season lines and results against
+// the spread. A view that does not exist yet is created, along with the
+// unique index its key needs; the others are refreshed concurrently, so
+// they can still be read while it runs. A view whose definition has changed
+// is only recreated once it has been dropped.
+func (db *Database) RefreshViews(ctx context.Context) ([]ViewResult, error) {
+	if err := db.requirePostgres("analytics views"); err != nil {
+		return nil, err
+	}
+
+	session := db.WithContext(ctx)
+	results := make([]ViewResult, 0, len(views))
+	for _, v := range views {
+		start := time.Now()
+		result := ViewResult{View: v.name, Status: ViewRefreshed}
+
+		var exists bool
+		err := session.Raw(
+			"SELECT to_regclass(?) IS NOT NULL", v.name,
+		).Scan(&exists).Error
+		if err != nil {
+			return nil, fmt.Errorf("could not check view %s; %w", v.name, err)
+		}
+
+		if exists {
+			err = session.Exec(
+				"REFRESH MATERIALIZED VIEW CONCURRENTLY " + v.name,
+			).Error
+		} else {
+			// Created together with its index, since a view without one
+			// cannot be refreshed concurrently.
+			result.Status = ViewCreated
+			err = session.Transaction(func(tx *gorm.DB) error {
+				err := tx.Exec(fmt.Sprintf(
+					"CREATE MATERIALIZED VIEW %s AS %s", v.name, v.query,
+				)).Error
+				if err != nil {
+					return err
+				}
+				return tx.Exec(fmt.Sprintf(
+					"CREATE UNIQUE INDEX idx_%s_key ON %s (%s)",
+					v.name, v.name, strings.Join(v.key, ", "),
+				)).Error
+			})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not refresh view %s; %w", v.name, err)
+		}
+
+		err = session.Table(v.name).Count(&result.Rows).Error
+		if err != nil {
+			return nil, fmt.Errorf("could not count view %s; %w", v.name, err)
+		}
+		result.DurationMS = time.Since(start).Milliseconds()
+		slog.Info("refreshed analytics view",
+			"view", v.name, "status", result.Status, "rows", result.Rows)
+		results = append(results, result)
+	}
+
+	return results, nil
+}
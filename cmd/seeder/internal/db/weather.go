@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// addWeatherSource adds the source column to game_weather.
+var addWeatherSource = Migration{
+	Version:       4,
+	Name:          "add_weather_source",
+	up:            addWeatherSourceUp,
+	down:          addWeatherSourceDown,
+	transactional: true,
+}
+
+// addWeatherSourceUp adds the source column unless the baseline already
+// created it. Every existing row came from the API, which the column's
+// default records.
+func addWeatherSourceUp(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	if migrator.HasColumn(&GameWeather{}, "Source") {
+		return nil
+	}
+	if err := migrator.AddColumn(&GameWeather{}, "Source"); err != nil {
+		return fmt.Errorf("could not add game_weather column source; %w", err)
+	}
+
+	return nil
+}
+
+// addWeatherSourceDown drops the source column.
+func addWeatherSourceDown(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	if !migrator.HasColumn(&GameWeather{}, "Source") {
+		return nil
+	}
+	if err := migrator.DropColumn(&GameWeather{}, "Source"); err != nil {
+		return fmt.Errorf("could not drop game_weather column source; %w", err)
+	}
+
+	return nil
+}
+
+// WeatherGap is a game played at a located venue that game_weather has no
+// temperature for.
+type WeatherGap struct {
+	ID             int32
+	Season         int32
+	Week           int32
+	SeasonType     string
+	StartDate      time.Time
+	HomeTeam       string
+	HomeConference string
+	AwayTeam       string
+	AwayConference string
+	VenueID        int32
+	Venue          string
+	Dome           *bool
+	Latitude       float64
+	Longitude      float64
+}
+
+// GetWeatherGaps returns a season's games that kicked off before now at a
+// venue with coordinates and that have no weather, or weather without a
+// temperature, ordered by start date.
+func (db *Database) GetWeatherGaps(
+	ctx context.Context,
+	season int32,
+	now time.Time,
+) ([]WeatherGap, error) {
+	var gaps []WeatherGap
+	if err := db.WithContext(ctx).Raw(`
+		SELECT g.id, g.season, g.week, g.season_type, g.start_date,
+			g.home_team, g.home_conference, g.away_team, g.away_conference,
+			g.venue_id, v.name AS venue, v.dome, v.latitude, v.longitude
+		FROM games g
+		JOIN venues v ON v.id = g.venue_id
+		LEFT JOIN game_weather w ON w.id = g.id
+		WHERE g.season = ? AND g.start_date < ?
+			AND v.latitude IS NOT NULL AND v.longitude IS NOT NULL
+			AND w.temperature IS NULL
+		ORDER BY g.start_date, g.id`, season, now,
+	).Scan(&gaps).Error; err != nil {
+		return nil, fmt.Errorf("could not get weather gaps; %w", err)
+	}
+
+	return gaps, nil
+}
+
+// FillGameWeather upserts weather from a provider other than the API. The
+// measurements are in the API's imperial units and are converted to the
+// configured unit system like the API's.
+func (db *Database) FillGameWeather(
+	ctx context.Context,
+	weather []GameWeather,
+) error {
+	if len(weather) == 0 {
+		return nil
+	}
+
+	models := make([]GameWeather, 0, len(weather))
+	for _, w := range weather {
+		w.Temperature = db.temperature(w.Temperature)
+		w.DewPoint = db.temperature(w.DewPoint)
+		w.Precipitation = db.precipitation(w.Precipitation)
+		w.Snowfall = db.precipitation(w.Snowfall)
+		w.WindSpeed = db.windSpeed(w.WindSpeed)
+		models = append(models, w)
+	}
+
+	err := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"temperature",
+			"dew_point",
+			"humidity",
+			"precipitation",
+			"snowfall",
+			"wind_direction",
+			"wind_speed",
+			"pressure",
+			"weather_condition_code",
+			"weather_condition",
+			"source",
+		}),
+	}).CreateInBatches(models, 100).Error
+	if err != nil {
+		return fmt.Errorf("could not fill game weather; %w", err)
+	}
+
+	return nil
+}
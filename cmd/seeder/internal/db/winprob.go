@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// WinProbabilityPlay is a play of a completed game, with what the local win
+// probability model needs to know about it and the game.
+type WinProbabilityPlay struct {
+	GameID       int32
+	PlayID       string
+	Period       int32
+	ClockMinutes *int32
+	ClockSeconds *int32
+	HomeBall     bool
+	HomeScore    int32
+	AwayScore    int32
+	YardsToGoal  int32
+	HomePoints   int32
+	AwayPoints   int32
+	// Spread is the providers' average spread for the game, from the home
+	// team's side, or nil if it has no lines.
+	Spread *float64
+	// Covered reports whether the API has win probabilities for the game.
+	Covered bool
+}
+
+// GetWinProbabilityPlays returns the plays of a season's completed games
+// that have a final score, ordered by game.
+func (db *Database) GetWinProbabilityPlays(
+	ctx context.Context,
+	season int32,
+) ([]WinProbabilityPlay, error) {
+	var plays []WinProbabilityPlay
+	if err := db.WithContext(ctx).Raw(`
+		SELECT p.game_id, p.id AS play_id, p.period,
+			p.clock_minutes, p.clock_seconds,
+			p.offense = g.home_team AS home_ball,
+			CASE WHEN p.offense = g.home_team
+				THEN p.offense_score ELSE p.defense_score END AS home_score,
+			CASE WHEN p.offense = g.home_team
+				THEN p.defense_score ELSE p.offense_score END AS away_score,
+			p.yards_to_goal, g.home_points, g.away_points,
+			(SELECT AVG(l.spread) FROM game_lines l
+				WHERE l.game_id = g.id AND l.spread IS NOT NULL) AS spread,
+			EXISTS (SELECT 1 FROM play_win_probability w
+				WHERE w.game_id = g.id) AS covered
+		FROM plays p
+		JOIN games g ON g.id = p.game_id
+		WHERE p.season = ? AND g.completed
+			AND g.home_points IS NOT NULL AND g.away_points IS NOT NULL
+		ORDER BY p.game_id, p.id`, season,
+	).Scan(&plays).Error; err != nil {
+		return nil, fmt.Errorf("could not get win probability plays; %w", err)
+	}
+
+	return plays, nil
+}
+
+// ReplaceLocalWinProbability stores the locally estimated win probabilities
+// of a season, replacing the season's previous estimates in one
+// transaction.
+func (db *Database) ReplaceLocalWinProbability(
+	ctx context.Context,
+	season int32,
+	plays []LocalPlayWinProbability,
+) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("season = ?", season).
+			Delete(&LocalPlayWinProbability{}).Error
+		if err != nil {
+			return fmt.Errorf("could not clear local win probability; %w", err)
+		}
+
+		if len(plays) > 0 {
+			if err = tx.CreateInBatches(plays, 1000).Error; err != nil {
+				return fmt.Errorf(
+					"could not insert local win probability; %w", err,
+				)
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,151 @@
+package etl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBreakerThreshold is the number of consecutive failures after
+	// which an endpoint's circuit opens.
+	DefaultBreakerThreshold = 10
+	// DefaultBreakerCooldown is how long an open circuit waits before letting
+	// a probe request through.
+	DefaultBreakerCooldown = time.Minute
+	// breakerPoll is how often waiters re-check a circuit while a half-open
+	// probe is in flight.
+	breakerPoll = time.Second
+)
+
+// BreakerPolicy configures the per-endpoint circuit breaker.
+type BreakerPolicy struct {
+	// Threshold is the number of consecutive retryable failures that opens
+	// the circuit. Zero disables the breaker.
+	Threshold int
+	// Cooldown is how long the circuit stays open before a probe.
+	Cooldown time.Duration
+}
+
+// breakerState is the circuit for a single endpoint.
+type breakerState struct {
+	failures   int
+	openUntil  time.Time
+	probing    bool
+	probeStart time.Time
+}
+
+// CircuitBreaker pauses requests to an endpoint after it fails consistently,
+// instead of spending quota on requests that are bound to fail. Once the
+// cooldown passes, a single probe request is let through (half-open): if it
+// succeeds the circuit closes, otherwise it opens for another cooldown.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	policy    BreakerPolicy
+	endpoints map[string]*breakerState
+}
+
+// NewCircuitBreaker returns a breaker with every circuit closed.
+func NewCircuitBreaker(policy BreakerPolicy) *CircuitBreaker {
+	return &CircuitBreaker{
+		policy:    policy,
+		endpoints: make(map[string]*breakerState),
+	}
+}
+
+// DefaultBreakerPolicy returns the policy sources use unless configured
+// otherwise.
+func DefaultBreakerPolicy() BreakerPolicy {
+	return BreakerPolicy{
+		Threshold: DefaultBreakerThreshold,
+		Cooldown:  DefaultBreakerCooldown,
+	}
+}
+
+// Wait blocks while the endpoint's circuit is open, returning once a request
+// may be made or ctx is done.
+func (b *CircuitBreaker) Wait(ctx context.Context, endpoint string) error {
+	if b.policy.Threshold <= 0 {
+		return nil
+	}
+
+	for {
+		delay, ok := b.admit(endpoint)
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("circuit breaker wait failed; %w", ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// admit reports whether a request to the endpoint may proceed, and if not,
+// how long to wait before asking again.
+func (b *CircuitBreaker) admit(endpoint string) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.endpoints[endpoint]
+	if !ok || state.openUntil.IsZero() {
+		return 0, true
+	}
+
+	if remaining := time.Until(state.openUntil); remaining > 0 {
+		return remaining, false
+	}
+
+	// A probe that never reported back (e.g. its context was cancelled) is
+	// replaced after a cooldown so the circuit cannot stay stuck half-open.
+	if state.probing && time.Since(state.probeStart) < b.policy.Cooldown {
+		return breakerPoll, false
+	}
+
+	state.probing = true
+	state.probeStart = time.Now()
+	slog.Info("circuit half-open; probing endpoint", "endpoint", endpoint)
+	return 0, true
+}
+
+// Record updates the endpoint's circuit with the outcome of a request. Only
+// retryable failures (429, 5xx, network) count against the circuit.
+func (b *CircuitBreaker) Record(endpoint string, err error) {
+	if b.policy.Threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.endpoints[endpoint]
+	if !ok {
+		state = &breakerState{}
+		b.endpoints[endpoint] = state
+	}
+
+	if err == nil || !IsRetryable(err) {
+		if !state.openUntil.IsZero() {
+			slog.Info("circuit closed", "endpoint", endpoint)
+		}
+		*state = breakerState{}
+		return
+	}
+
+	state.failures++
+	if state.probing || state.failures >= b.policy.Threshold {
+		state.probing = false
+		state.openUntil = time.Now().Add(b.policy.Cooldown)
+		slog.Warn(
+			"circuit open; pausing endpoint",
+			"endpoint", endpoint,
+			"failures", state.failures,
+			"cooldown", b.policy.Cooldown.String(),
+			"err", err,
+		)
+	}
+}
@@ -0,0 +1,31 @@
+// Package etl holds the machinery a sports data source is seeded through,
+// independent of where the data comes from: the task graph and the runner
+// that executes it phase by phase, progress and usage tracking, request
+// throttling, retries and circuit breaking, and recording runs and the
+// tables they wrote in a Store.
+//
+// CFBD (see package seed) is the first source. A new source implements
+// Source with its own fetch and insert functions, paces and retries its
+// requests through a Requester, and records its runs with a RunRecorder.
+package etl
+
+import "context"
+
+// Source is a provider of sports data that can be seeded through this
+// package.
+type Source interface {
+	// Name identifies the source in logs.
+	Name() string
+	// SeedTasks returns the tasks of a full seed, each with the tasks whose
+	// rows it depends on, to be run by a PhaseRunner.
+	SeedTasks() []Task
+	// Progress returns the tracker the source's tasks report to.
+	Progress() *Progress
+	// StartRun records the start of a run of the command.
+	StartRun(ctx context.Context, command string) error
+	// RunSummary returns what the run has done so far.
+	RunSummary(runErr error) RunSummary
+	// FinishRun marks the run as succeeded, or failed if runErr is not nil,
+	// keeping the summary if one is given.
+	FinishRun(ctx context.Context, runErr error, summary *RunSummary) error
+}
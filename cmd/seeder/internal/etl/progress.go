@@ -0,0 +1,410 @@
+package etl
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Progress tracks what a source is currently doing: the active phase, the
+// tasks running within it, and the last time each table was written. It is
+// safe for concurrent use.
+type Progress struct {
+	mu             sync.Mutex
+	phase          string
+	phaseStarted   time.Time
+	total          int
+	completed      int
+	failed         int
+	running        map[string]time.Time
+	tables         map[string]time.Time
+	tableRows      map[string]int64
+	rowsWritten    int64
+	quotaRemaining *int64
+	lastBeat       time.Time
+	// done holds the tasks that completed, or were skipped as already
+	// completed, by name.
+	done map[string]bool
+	// units holds the work units of running tasks that report them.
+	units map[string]*taskUnits
+	// stats holds what each task tracked so far did, for the run summary.
+	stats map[string]*taskStats
+	// taskDone holds the functions called as each tracked task returns.
+	taskDone []func(ctx context.Context, task string)
+}
+
+// taskUnits counts the units of work (weeks, games) a task has finished out
+// of those it expects to do.
+type taskUnits struct {
+	done    int64
+	total   int64
+	started time.Time
+}
+
+// taskNameKey is the context key holding the name of the tracked task.
+type taskNameKey struct{}
+
+// TaskProgress is the unit progress of a running task.
+type TaskProgress struct {
+	Task  string `json:"task"`
+	Done  int64  `json:"done"`
+	Total int64  `json:"total"`
+	// ETASeconds is the projected time left, extrapolated from the pace so
+	// far; zero until the first unit is done.
+	ETASeconds int64 `json:"eta_seconds,omitempty"`
+}
+
+// ETA returns the projected time left as a duration.
+func (t TaskProgress) ETA() time.Duration {
+	return time.Duration(t.ETASeconds) * time.Second
+}
+
+// ProgressSnapshot is a point-in-time copy of Progress, suitable for
+// encoding as JSON.
+type ProgressSnapshot struct {
+	Phase          string               `json:"phase"`
+	PhaseStarted   *time.Time           `json:"phase_started,omitempty"`
+	Running        []string             `json:"running"`
+	Total          int                  `json:"total"`
+	Completed      int                  `json:"completed"`
+	Failed         int                  `json:"failed"`
+	LastSync       map[string]time.Time `json:"last_sync"`
+	RowsWritten    int64                `json:"rows_written"`
+	TableRows      map[string]int64     `json:"table_rows"`
+	QuotaRemaining *int64               `json:"quota_remaining,omitempty"`
+	Tasks          []TaskProgress       `json:"tasks"`
+}
+
+// NewProgress returns an idle Progress.
+func NewProgress() *Progress {
+	return &Progress{
+		phase:     "idle",
+		running:   make(map[string]time.Time),
+		tables:    make(map[string]time.Time),
+		tableRows: make(map[string]int64),
+		units:     make(map[string]*taskUnits),
+		stats:     make(map[string]*taskStats),
+		done:      make(map[string]bool),
+	}
+}
+
+// StartPhase marks the beginning of a new phase and resets the per-phase
+// seed function counters.
+func (p *Progress) StartPhase(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.phase = name
+	p.phaseStarted = time.Now()
+	p.total = 0
+	p.completed = 0
+	p.failed = 0
+}
+
+// Track wraps a task so that it is reported as running under its name while
+// it executes and counted as completed or failed once it returns. The task's
+// context carries its name, so the units it reports with SetUnits and
+// UnitDone are attributed to it, as are the requests and writes counted in
+// its TaskSummaries entry. Each run is traced as a root span, under which
+// its API calls and inserts are recorded. A task passed to SetCompleted is
+// counted as completed without being run.
+func (p *Progress) Track(task Task) func(context.Context) error {
+	name := task.Name
+
+	p.mu.Lock()
+	p.total++
+	p.mu.Unlock()
+
+	return func(ctx context.Context) error {
+		started := time.Now()
+		p.mu.Lock()
+		if p.done[name] {
+			p.completed++
+			p.mu.Unlock()
+			return nil
+		}
+		p.running[name] = started
+		p.mu.Unlock()
+
+		ctx, end := StartSpan(ctx, name,
+			trace.WithNewRoot(),
+			trace.WithAttributes(attribute.String("etl.task", name)),
+		)
+		stats := p.statsFor(name)
+		ctx = context.WithValue(ctx, taskStatsKey{}, stats)
+		err := task.Run(context.WithValue(ctx, taskNameKey{}, name))
+		end(err)
+		stats.finish(time.Since(started), err)
+
+		p.mu.Lock()
+		delete(p.running, name)
+		delete(p.units, name)
+		if err != nil {
+			p.failed++
+		} else {
+			p.completed++
+			p.done[name] = true
+		}
+		done := p.taskDone
+		p.mu.Unlock()
+
+		// A task that timed out or was cancelled still wrote what it wrote.
+		doneCtx := context.WithoutCancel(ctx)
+		for _, fn := range done {
+			fn(doneCtx, name)
+		}
+
+		return err
+	}
+}
+
+// OnTaskDone registers fn to be called with the task's name whenever a
+// tracked task returns, whether it succeeded or not.
+func (p *Progress) OnTaskDone(fn func(ctx context.Context, task string)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.taskDone = append(p.taskDone, fn)
+}
+
+// TaskName returns the name of the tracked task running under ctx, and
+// whether ctx belongs to one.
+func TaskName(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(taskNameKey{}).(string)
+	return name, ok
+}
+
+// SetUnits sets how many units of work (e.g. weeks or games) the task
+// running under ctx expects to do. It does nothing outside a tracked task.
+func (p *Progress) SetUnits(ctx context.Context, total int64) {
+	name, ok := ctx.Value(taskNameKey{}).(string)
+	if !ok {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	units, ok := p.units[name]
+	if !ok {
+		units = &taskUnits{started: time.Now()}
+		p.units[name] = units
+	}
+	units.total = total
+}
+
+// UnitDone counts one unit of work as done for the task running under ctx.
+// A task that does more units than it expected raises its total to match.
+func (p *Progress) UnitDone(ctx context.Context) {
+	name, ok := ctx.Value(taskNameKey{}).(string)
+	if !ok {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	units, ok := p.units[name]
+	if !ok {
+		units = &taskUnits{started: time.Now()}
+		p.units[name] = units
+	}
+	units.done++
+	units.total = max(units.total, units.done)
+}
+
+// SetCompleted marks the named tasks as already completed, e.g. by the
+// stopped run being resumed, so that Track skips them.
+func (p *Progress) SetCompleted(names []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, name := range names {
+		p.done[name] = true
+	}
+}
+
+// Completed returns the names of the tasks that have completed, including
+// those marked by SetCompleted, sorted.
+func (p *Progress) Completed() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	names := make([]string, 0, len(p.done))
+	for name := range p.done {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// RecordTableSync notes that rows were successfully written to the table at
+// the given time, counting them against the task running under ctx.
+func (p *Progress) RecordTableSync(
+	ctx context.Context,
+	table string,
+	rows WriteCounts,
+	at time.Time,
+) {
+	countWrites(ctx, rows)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.tables[table] = at
+	p.tableRows[table] += rows.Written()
+	p.rowsWritten += rows.Written()
+}
+
+// SetQuotaRemaining records the number of API calls remaining for the
+// current key.
+func (p *Progress) SetQuotaRemaining(remaining int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.quotaRemaining = &remaining
+}
+
+// Beat records that a long-running loop (such as the scoreboard watcher) is
+// still making progress.
+func (p *Progress) Beat() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lastBeat = time.Now()
+}
+
+// LastBeat returns the time of the most recent Beat, or the zero time if no
+// long-running loop has started.
+func (p *Progress) LastBeat() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.lastBeat
+}
+
+// Snapshot returns a copy of the current progress.
+func (p *Progress) Snapshot() ProgressSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	snap := ProgressSnapshot{
+		Phase:     p.phase,
+		Running:   make([]string, 0, len(p.running)),
+		Total:     p.total,
+		Completed: p.completed,
+		Failed:    p.failed,
+		LastSync:  make(map[string]time.Time, len(p.tables)),
+
+		RowsWritten: p.rowsWritten,
+		TableRows:   make(map[string]int64, len(p.tableRows)),
+	}
+
+	if !p.phaseStarted.IsZero() {
+		started := p.phaseStarted
+		snap.PhaseStarted = &started
+	}
+
+	if p.quotaRemaining != nil {
+		remaining := *p.quotaRemaining
+		snap.QuotaRemaining = &remaining
+	}
+
+	for name := range p.running {
+		snap.Running = append(snap.Running, name)
+	}
+	sort.Strings(snap.Running)
+
+	for table, at := range p.tables {
+		snap.LastSync[table] = at
+	}
+
+	for table, rows := range p.tableRows {
+		snap.TableRows[table] = rows
+	}
+
+	now := time.Now()
+	snap.Tasks = make([]TaskProgress, 0, len(p.units))
+	for name, units := range p.units {
+		task := TaskProgress{Task: name, Done: units.done, Total: units.total}
+		if units.done > 0 {
+			perUnit := now.Sub(units.started) / time.Duration(units.done)
+			left := perUnit * time.Duration(units.total-units.done)
+			task.ETASeconds = int64(left.Round(time.Second).Seconds())
+		}
+		snap.Tasks = append(snap.Tasks, task)
+	}
+	sort.Slice(snap.Tasks, func(i, j int) bool {
+		return snap.Tasks[i].Task < snap.Tasks[j].Task
+	})
+
+	return snap
+}
+
+// LogEvery logs the unit progress and ETA of every running task that reports
+// units, every interval until ctx is done, so long phases are visible in
+// plain logs too.
+func (p *Progress) LogEvery(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, task := range p.Snapshot().Tasks {
+			slog.Info(
+				"task progress",
+				"task", task.Task,
+				"done", task.Done,
+				"total", task.Total,
+				"eta", task.ETA().String(),
+			)
+		}
+	}
+}
+
+// LogTableSummary writes the number of rows written to each table during the
+// run to the log, in table order.
+func (p *Progress) LogTableSummary() {
+	snap := p.Snapshot()
+
+	tables := make([]string, 0, len(snap.TableRows))
+	for table := range snap.TableRows {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	for _, table := range tables {
+		slog.Info("table rows", "table", table, "rows", snap.TableRows[table])
+	}
+	slog.Info("table rows summary", "total_rows", snap.RowsWritten)
+}
+
+// FuncName returns the bare method name of a task function value, e.g.
+// "SeedVenues" for seeder.SeedVenues.
+func FuncName(fn func(context.Context) error) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	name = strings.TrimSuffix(name, "-fm")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return name
+}
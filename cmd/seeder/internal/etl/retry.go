@@ -0,0 +1,214 @@
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"reflect"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultMaxRetries is how many times a failed request is retried before
+	// the error is surfaced.
+	DefaultMaxRetries = 5
+	// DefaultRetryBaseDelay is the backoff before the first retry; it doubles
+	// on every subsequent attempt.
+	DefaultRetryBaseDelay = time.Second
+	// DefaultRetryMaxDelay caps the backoff between attempts.
+	DefaultRetryMaxDelay = time.Minute
+)
+
+// RetryPolicy configures how failed API requests are retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the initial attempt.
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff, including any server-provided Retry-After.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the policy sources use unless configured
+// otherwise.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: DefaultMaxRetries,
+		BaseDelay:  DefaultRetryBaseDelay,
+		MaxDelay:   DefaultRetryMaxDelay,
+	}
+}
+
+// Requester makes a source's API requests, throttled per endpoint, held
+// while the endpoint's circuit is open, retried with backoff and counted
+// in usage.
+type Requester struct {
+	Throttler *Throttler
+	Breaker   *CircuitBreaker
+	Usage     *UsageTracker
+	Policy    RetryPolicy
+}
+
+// Retry calls fn, retrying rate limited (429), server (5xx) and transient
+// network failures with exponential backoff and jitter. Retry-After is
+// honored when the error carries it. Each retry waits on the throttle, so
+// retries count against the request rate like any other request. Requests
+// to an endpoint whose circuit is open are held until it is probed again.
+// The call, retries included, is traced as a span carrying attrs and the
+// number of records returned.
+func Retry[T any](
+	ctx context.Context,
+	r *Requester,
+	endpoint string,
+	fn func(context.Context) (T, error),
+	attrs ...attribute.KeyValue,
+) (T, error) {
+	attrs = append(attrs, attribute.String("etl.endpoint", endpoint))
+	ctx, end := StartSpan(ctx, endpoint, trace.WithAttributes(attrs...))
+
+	result, err := retryAttempts(ctx, r, endpoint, fn)
+	if err == nil {
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.Int("etl.records", recordCount(result)),
+		)
+	}
+	end(err)
+
+	return result, err
+}
+
+// retryAttempts makes the attempts of a Retry call.
+func retryAttempts[T any](
+	ctx context.Context,
+	r *Requester,
+	endpoint string,
+	fn func(context.Context) (T, error),
+) (T, error) {
+	policy := r.Policy
+
+	for attempt := 0; ; attempt++ {
+		if err := r.Breaker.Wait(ctx, endpoint); err != nil {
+			var zero T
+			return zero, err
+		}
+
+		result, err := fn(ctx)
+		countRequest(ctx, err)
+		r.Breaker.Record(endpoint, err)
+		if err == nil {
+			return result, nil
+		}
+		r.Usage.RecordError(endpoint)
+
+		if attempt >= policy.MaxRetries || !IsRetryable(err) {
+			return result, err
+		}
+
+		delay := backoff(policy, attempt, err)
+		slog.Warn(
+			"api request failed; retrying",
+			"attempt", attempt+1,
+			"delay", delay.String(),
+			"err", err,
+		)
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, fmt.Errorf("retry aborted; %w", ctx.Err())
+		case <-time.After(delay):
+		}
+
+		if err = r.Throttler.Wait(ctx, endpoint); err != nil {
+			var zero T
+			return zero, fmt.Errorf("failed to wait for rate limit; %w", err)
+		}
+	}
+}
+
+// IsRetryable reports whether err is a rate limit, server or transient
+// network failure worth retrying.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if code, ok := StatusCode(err); ok {
+		return code == http.StatusTooManyRequests ||
+			code >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// StatusCode returns the HTTP status code carried by err, through a
+// StatusCode method or, failing that, the StatusCode field of an error in
+// its chain, as on the cfbd client's API errors, whose type is unexported.
+func StatusCode(err error) (int, bool) {
+	var coder interface{ StatusCode() int }
+	if errors.As(err, &coder) {
+		return coder.StatusCode(), true
+	}
+
+	return statusField(err)
+}
+
+// statusField returns the StatusCode field of the first error in err's
+// chain that has one.
+func statusField(err error) (int, bool) {
+	if err == nil {
+		return 0, false
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(err))
+	if v.Kind() == reflect.Struct {
+		if field := v.FieldByName("StatusCode"); field.IsValid() &&
+			field.CanInt() {
+			return int(field.Int()), true
+		}
+	}
+
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		return statusField(wrapped.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, inner := range wrapped.Unwrap() {
+			if code, ok := statusField(inner); ok {
+				return code, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// backoff returns the delay before the next attempt: the server's
+// Retry-After when provided, otherwise exponential backoff with full jitter.
+func backoff(policy RetryPolicy, attempt int, err error) time.Duration {
+	var after interface{ RetryAfter() time.Duration }
+	if errors.As(err, &after) && after.RetryAfter() > 0 {
+		return min(after.RetryAfter(), policy.MaxDelay)
+	}
+
+	delay := min(policy.BaseDelay<<attempt, policy.MaxDelay)
+	if delay <= 0 {
+		return policy.MaxDelay
+	}
+
+	//nolint:gosec // jitter does not need a cryptographic source
+	return delay/2 + rand.N(delay/2+1)
+}
@@ -0,0 +1,51 @@
+package etl_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+)
+
+// apiError mirrors the cfbd client's API error, which carries the status
+// in an exported field of an unexported type.
+type apiError struct {
+	StatusCode int
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("cfbd api error: status=%d", e.StatusCode)
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"rate limited", &apiError{StatusCode: 429}, true},
+		{"server error", &apiError{StatusCode: 503}, true},
+		{"client error", &apiError{StatusCode: 404}, false},
+		{
+			"wrapped server error",
+			fmt.Errorf("failed to request /games; %w", &apiError{StatusCode: 502}),
+			true,
+		},
+		{
+			"joined server error",
+			errors.Join(errors.New("first"), &apiError{StatusCode: 500}),
+			true,
+		},
+		{"status in message", errors.New("could not insert 500 rows"), false},
+		{"port in message", errors.New("dial tcp 10.0.0.1:5432: refused"), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := etl.IsRetryable(tc.err); got != tc.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
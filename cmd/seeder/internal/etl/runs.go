@@ -0,0 +1,191 @@
+package etl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Store persists the run history and table manifest of a source's runs.
+type Store interface {
+	// StartSeedRun records the start of a run of the command and returns
+	// its ID.
+	StartSeedRun(ctx context.Context, command string, at time.Time) (int64, error)
+	// FinishSeedRun marks the run as succeeded, or failed if runErr is not
+	// nil, keeping its JSON summary if one is given.
+	FinishSeedRun(
+		ctx context.Context,
+		id int64,
+		rows int64,
+		runErr error,
+		at time.Time,
+		summary []byte,
+	) error
+	// StopSeedRun marks the run as stopped before it finished, keeping its
+	// JSON summary if one is given.
+	StopSeedRun(
+		ctx context.Context,
+		id int64,
+		rows int64,
+		at time.Time,
+		summary []byte,
+	) error
+	// SaveSeedCheckpoint records the tasks completed by the stopped run of
+	// the command, for the next run to resume from.
+	SaveSeedCheckpoint(
+		ctx context.Context,
+		command string,
+		tasks []string,
+		at time.Time,
+	) error
+	// ClearSeedCheckpoint removes the command's checkpoint, if any.
+	ClearSeedCheckpoint(ctx context.Context, command string) error
+	// UpsertTableManifest records the last write time and rows written by
+	// the run for each table.
+	UpsertTableManifest(
+		ctx context.Context,
+		runID int64,
+		lastSync map[string]time.Time,
+		rows map[string]int64,
+	) error
+}
+
+// RunRecorder records a single run, and the tables it wrote as reported to
+// its Progress, in a Store.
+type RunRecorder struct {
+	store    Store
+	progress *Progress
+	// id identifies the run in the run history; zero until Start is called.
+	id int64
+	// command is the command of the run, which names its checkpoint.
+	command string
+	started time.Time
+}
+
+// NewRunRecorder returns a recorder for a run reporting to progress.
+func NewRunRecorder(store Store, progress *Progress) *RunRecorder {
+	return &RunRecorder{store: store, progress: progress}
+}
+
+// ID returns the ID of the run in the run history, or zero if it was not
+// started.
+func (r *RunRecorder) ID() int64 {
+	return r.id
+}
+
+// Start records the start of a run of the command in the run history.
+func (r *RunRecorder) Start(ctx context.Context, command string) error {
+	id, err := r.store.StartSeedRun(ctx, command, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to start run; %w", err)
+	}
+	r.id, r.command, r.started = id, command, time.Now()
+
+	return nil
+}
+
+// SaveManifest records the last write time and rows written by this run for
+// every table written so far.
+func (r *RunRecorder) SaveManifest(ctx context.Context) error {
+	if r.id == 0 {
+		return nil
+	}
+
+	// The run may be finishing because its context was cancelled, which must
+	// not stop its outcome from being recorded.
+	ctx = context.WithoutCancel(ctx)
+	snap := r.progress.Snapshot()
+	err := r.store.UpsertTableManifest(ctx, r.id, snap.LastSync, snap.TableRows)
+	if err != nil {
+		return fmt.Errorf("failed to save table manifest; %w", err)
+	}
+
+	return nil
+}
+
+// Summary returns what the run has done so far, per task and in total,
+// with the outcome given by runErr.
+func (r *RunRecorder) Summary(runErr error) RunSummary {
+	summary := RunSummary{
+		RunID:     r.id,
+		Command:   r.command,
+		Status:    StatusSucceeded,
+		StartedAt: r.started,
+		Tasks:     r.progress.TaskSummaries(),
+	}
+	if !r.started.IsZero() {
+		summary.DurationMS = time.Since(r.started).Milliseconds()
+	}
+	switch {
+	case errors.Is(runErr, ErrStopped):
+		summary.Status = StatusStopped
+	case runErr != nil:
+		summary.Status, summary.Error = StatusFailed, runErr.Error()
+	}
+
+	for _, task := range summary.Tasks {
+		summary.Requests += task.Requests
+		summary.RequestErrors += task.RequestErrors
+		summary.Rows.Add(task.WriteCounts)
+	}
+
+	return summary
+}
+
+// Finish saves the table manifest and marks the run as succeeded, stopped
+// if runErr wraps ErrStopped, or failed if runErr is not nil. The summary,
+// if not nil, is kept with the run. A stopped run also checkpoints the
+// tasks it completed, for the next run to resume from; any other run
+// clears the checkpoint, as it left nothing to resume.
+func (r *RunRecorder) Finish(
+	ctx context.Context,
+	runErr error,
+	summary *RunSummary,
+) error {
+	if r.id == 0 {
+		return nil
+	}
+
+	if err := r.SaveManifest(ctx); err != nil {
+		return err
+	}
+
+	var encoded []byte
+	if summary != nil {
+		var err error
+		if encoded, err = json.Marshal(summary); err != nil {
+			return fmt.Errorf("failed to encode run summary; %w", err)
+		}
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	rows := r.progress.Snapshot().RowsWritten
+	stopped := errors.Is(runErr, ErrStopped)
+
+	var err error
+	if stopped {
+		err = r.store.SaveSeedCheckpoint(
+			ctx, r.command, r.progress.Completed(), time.Now().UTC(),
+		)
+	} else {
+		err = r.store.ClearSeedCheckpoint(ctx, r.command)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update checkpoint; %w", err)
+	}
+
+	if stopped {
+		err = r.store.StopSeedRun(ctx, r.id, rows, time.Now(), encoded)
+	} else {
+		err = r.store.FinishSeedRun(
+			ctx, r.id, rows, runErr, time.Now(), encoded,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to finish run; %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package etl
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrStopped is returned once a run is asked to stop, e.g. by a Throttler
+// that was stopped, so that its tasks wind down without starting new work.
+var ErrStopped = errors.New("stopped")
+
+// stopKey is the context key of the channel WithStop attaches.
+type stopKey struct{}
+
+// WithStop returns a copy of ctx carrying stop, a channel closed once the
+// run is asked to stop. Unlike cancelling ctx, closing it lets the work in
+// progress finish; tasks that make no API requests, and so are never held
+// up by a stopped Throttler, check it with Err between units of work.
+func WithStop(ctx context.Context, stop <-chan struct{}) context.Context {
+	return context.WithValue(ctx, stopKey{}, stop)
+}
+
+// Err returns ctx.Err() if ctx is done, or else ErrStopped once the stop
+// channel WithStop attached to ctx is closed.
+func Err(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	stop, _ := ctx.Value(stopKey{}).(<-chan struct{})
+	select {
+	case <-stop:
+		return ErrStopped
+	default:
+		return nil
+	}
+}
+
+// Drainer lets the goroutines of an errgroup stop without cancelling each
+// other: ErrStopped is caught rather than returned to the group, so the
+// goroutines still running finish their work, and is reported once the
+// group has drained. It is safe for concurrent use.
+type Drainer struct {
+	stopped atomic.Bool
+}
+
+// Catch returns err, unless it wraps ErrStopped, which is recorded instead.
+func (d *Drainer) Catch(err error) error {
+	if errors.Is(err, ErrStopped) {
+		d.stopped.Store(true)
+		return nil
+	}
+
+	return err
+}
+
+// Err returns the group's err, or ErrStopped if there is none and a
+// goroutine was stopped.
+func (d *Drainer) Err(err error) error {
+	if err == nil && d.stopped.Load() {
+		return ErrStopped
+	}
+
+	return err
+}
@@ -0,0 +1,54 @@
+package etl
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Stream runs a producer/consumer pipeline: produce fetches batches and
+// hands each to emit, and consume writes them in order as they arrive. At
+// most buffer batches wait between the two, so memory stays bounded however
+// many batches there are, and writes overlap with the fetches after them.
+// The first error from either side stops both and is returned, except
+// ErrStopped from produce: the batches already fetched are still written
+// before it is returned.
+func Stream[T any](
+	ctx context.Context,
+	buffer int,
+	produce func(ctx context.Context, emit func(batch []T) error) error,
+	consume func(ctx context.Context, batch []T) error,
+) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+	batches := make(chan []T, buffer)
+	var stop Drainer
+
+	group.Go(func() error {
+		defer close(batches)
+
+		return stop.Catch(produce(groupCtx, func(batch []T) error {
+			select {
+			case batches <- batch:
+				return nil
+			case <-groupCtx.Done():
+				return fmt.Errorf("stream stopped; %w", groupCtx.Err())
+			}
+		}))
+	})
+
+	group.Go(func() error {
+		for batch := range batches {
+			if err := consume(groupCtx, batch); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err := stop.Err(group.Wait()); err != nil {
+		return fmt.Errorf("stream failed; %w", err)
+	}
+
+	return nil
+}
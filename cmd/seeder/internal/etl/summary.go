@@ -0,0 +1,164 @@
+package etl
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Run and task statuses reported in a RunSummary.
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	// StatusStopped is a run or task wound down by ErrStopped.
+	StatusStopped = "stopped"
+)
+
+// WriteCounts is how the rows handed to inserts were written. Upserted rows
+// may have been inserted or updated; rows are only counted as updated when
+// the store knows they were written before (e.g. from stored row hashes).
+type WriteCounts struct {
+	Inserted int64 `json:"inserted"`
+	Updated  int64 `json:"updated"`
+	Upserted int64 `json:"upserted"`
+	// Skipped rows were left untouched, either because they were unchanged
+	// or because they conflicted with an existing row.
+	Skipped int64 `json:"skipped"`
+}
+
+// Add adds o to the counts.
+func (c *WriteCounts) Add(o WriteCounts) {
+	c.Inserted += o.Inserted
+	c.Updated += o.Updated
+	c.Upserted += o.Upserted
+	c.Skipped += o.Skipped
+}
+
+// Written returns the number of rows inserted, updated or upserted.
+func (c WriteCounts) Written() int64 {
+	return c.Inserted + c.Updated + c.Upserted
+}
+
+// TaskSummary is what a task did over a run. A task run more than once (as
+// in daemon mode) is summed over its runs and reports its last status.
+type TaskSummary struct {
+	Task          string `json:"task"`
+	Status        string `json:"status"`
+	Error         string `json:"error,omitempty"`
+	Runs          int    `json:"runs"`
+	DurationMS    int64  `json:"duration_ms"`
+	Requests      int64  `json:"requests"`
+	RequestErrors int64  `json:"request_errors"`
+	WriteCounts
+}
+
+// RunSummary is a machine-readable account of a run, for comparing runs
+// with each other.
+type RunSummary struct {
+	RunID         int64         `json:"run_id,omitempty"`
+	Command       string        `json:"command"`
+	Status        string        `json:"status"`
+	Error         string        `json:"error,omitempty"`
+	StartedAt     time.Time     `json:"started_at"`
+	DurationMS    int64         `json:"duration_ms"`
+	Requests      int64         `json:"requests"`
+	RequestErrors int64         `json:"request_errors"`
+	Rows          WriteCounts   `json:"rows"`
+	Tasks         []TaskSummary `json:"tasks"`
+}
+
+// taskStatsKey is the context key holding the stats of the tracked task.
+type taskStatsKey struct{}
+
+// taskStats accumulates what a task did across its runs. It is shared with
+// the requests and inserts the task makes, so it is safe for concurrent use.
+type taskStats struct {
+	mu      sync.Mutex
+	summary TaskSummary
+}
+
+// finish records the outcome of one run of the task.
+func (t *taskStats) finish(elapsed time.Duration, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.summary.Runs++
+	t.summary.DurationMS += elapsed.Milliseconds()
+	t.summary.Status, t.summary.Error = StatusSucceeded, ""
+	switch {
+	case errors.Is(err, ErrStopped):
+		t.summary.Status = StatusStopped
+	case err != nil:
+		t.summary.Status, t.summary.Error = StatusFailed, err.Error()
+	}
+}
+
+// statsFor returns the stats of the named task, creating them on first
+// use.
+func (p *Progress) statsFor(name string) *taskStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats, ok := p.stats[name]
+	if !ok {
+		stats = &taskStats{summary: TaskSummary{Task: name}}
+		p.stats[name] = stats
+	}
+
+	return stats
+}
+
+// countRequest counts a request attempt, and whether it failed, against the
+// task running under ctx. It does nothing outside a tracked task.
+func countRequest(ctx context.Context, err error) {
+	stats, ok := ctx.Value(taskStatsKey{}).(*taskStats)
+	if !ok {
+		return
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.summary.Requests++
+	if err != nil {
+		stats.summary.RequestErrors++
+	}
+}
+
+// countWrites counts rows written by the task running under ctx. It does
+// nothing outside a tracked task.
+func countWrites(ctx context.Context, rows WriteCounts) {
+	stats, ok := ctx.Value(taskStatsKey{}).(*taskStats)
+	if !ok {
+		return
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.summary.Add(rows)
+}
+
+// TaskSummaries returns what every task tracked so far did, by task name.
+func (p *Progress) TaskSummaries() []TaskSummary {
+	p.mu.Lock()
+	stats := make([]*taskStats, 0, len(p.stats))
+	for _, s := range p.stats {
+		stats = append(stats, s)
+	}
+	p.mu.Unlock()
+
+	summaries := make([]TaskSummary, 0, len(stats))
+	for _, s := range stats {
+		s.mu.Lock()
+		summaries = append(summaries, s.summary)
+		s.mu.Unlock()
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Task < summaries[j].Task
+	})
+
+	return summaries
+}
@@ -0,0 +1,196 @@
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ErrInvalidTaskGraph is returned when tasks name a prerequisite that is not
+// among them, share a name, or depend on each other in a cycle.
+var ErrInvalidTaskGraph = errors.New("invalid task graph")
+
+// Task is a named unit of work run by a PhaseRunner.
+type Task struct {
+	// Name identifies the task in logs and progress, e.g. "SeedVenues".
+	Name string
+	// After names the tasks that must succeed before this one starts, e.g.
+	// "SeedTeams" for a task that inserts rows referencing teams.
+	After []string
+	// Timeout, if positive, cancels the task once it has run this long. Zero
+	// uses the runner's TaskTimeout.
+	Timeout time.Duration
+	// Run performs the task and should return promptly once ctx is done.
+	Run func(ctx context.Context) error
+}
+
+// NewTask returns a task named after the seed method fn, e.g. "SeedVenues"
+// for seeder.SeedVenues, that runs once every task in after has succeeded.
+func NewTask(
+	fn func(context.Context) error,
+	after ...func(context.Context) error,
+) Task {
+	task := Task{Name: FuncName(fn), Run: fn}
+	for _, prereq := range after {
+		task.After = append(task.After, FuncName(prereq))
+	}
+
+	return task
+}
+
+// Levels sorts tasks topologically into levels: the first level holds the
+// tasks without prerequisites, and every later level the tasks whose
+// prerequisites all sit in earlier levels. Tasks keep their relative order
+// within a level.
+func Levels(tasks []Task) ([][]Task, error) {
+	byName := make(map[string]int, len(tasks))
+	for i, task := range tasks {
+		if _, ok := byName[task.Name]; ok {
+			return nil, fmt.Errorf(
+				"%w: duplicate task %s", ErrInvalidTaskGraph, task.Name,
+			)
+		}
+		byName[task.Name] = i
+	}
+
+	waiting := make([]int, len(tasks))
+	dependents := make([][]int, len(tasks))
+	for i, task := range tasks {
+		for _, prereq := range task.After {
+			j, ok := byName[prereq]
+			if !ok {
+				return nil, fmt.Errorf(
+					"%w: %s depends on unknown task %s",
+					ErrInvalidTaskGraph, task.Name, prereq,
+				)
+			}
+			waiting[i]++
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	var ready []int
+	for i := range tasks {
+		if waiting[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	var levels [][]Task
+	placed := 0
+	for len(ready) > 0 {
+		level := make([]Task, 0, len(ready))
+		var next []int
+		for _, i := range ready {
+			level = append(level, tasks[i])
+			for _, j := range dependents[i] {
+				waiting[j]--
+				if waiting[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		sort.Ints(next)
+
+		levels = append(levels, level)
+		placed += len(level)
+		ready = next
+	}
+
+	if placed != len(tasks) {
+		var stuck []string
+		for i, task := range tasks {
+			if waiting[i] > 0 {
+				stuck = append(stuck, task.Name)
+			}
+		}
+		return nil, fmt.Errorf(
+			"%w: dependency cycle among %s",
+			ErrInvalidTaskGraph, strings.Join(stuck, ", "),
+		)
+	}
+
+	return levels, nil
+}
+
+// PhaseRunner runs tasks in dependency order, one level of the task graph
+// per phase, reporting them to a Progress.
+type PhaseRunner struct {
+	progress *Progress
+	// TaskTimeout, if positive, cancels any task without its own Timeout
+	// once it has run this long.
+	TaskTimeout time.Duration
+	// MaxConcurrent, if positive, caps how many tasks of a phase run at
+	// once; the rest start as earlier ones finish.
+	MaxConcurrent int
+	// PhaseConcurrent overrides MaxConcurrent for individual phases, keyed
+	// by phase number.
+	PhaseConcurrent map[int]int
+}
+
+// NewPhaseRunner returns a PhaseRunner reporting to progress.
+func NewPhaseRunner(progress *Progress) *PhaseRunner {
+	return &PhaseRunner{progress: progress}
+}
+
+// Run runs the tasks level by level (see Levels), each level as a phase. The
+// first task to fail cancels the rest of its phase, and its error is returned
+// without starting later phases. A task stopped by the seeder's Stop lets the
+// rest of its phase drain instead, and ErrStopped is returned once it has.
+func (r *PhaseRunner) Run(ctx context.Context, tasks []Task) error {
+	levels, err := Levels(tasks)
+	if err != nil {
+		return err
+	}
+
+	for i, level := range levels {
+		phase := i + 1
+		slog.Info(fmt.Sprintf("Starting Phase %d...", phase))
+		r.progress.StartPhase(fmt.Sprintf("phase %d", phase))
+
+		group, groupCtx := errgroup.WithContext(ctx)
+		var stop Drainer
+		limit := r.MaxConcurrent
+		if phaseLimit, ok := r.PhaseConcurrent[phase]; ok {
+			limit = phaseLimit
+		}
+		if limit > 0 {
+			group.SetLimit(limit)
+		}
+		for _, task := range level {
+			run := r.progress.Track(task)
+			timeout := task.Timeout
+			if timeout <= 0 {
+				timeout = r.TaskTimeout
+			}
+
+			group.Go(func() error {
+				taskCtx := groupCtx
+				if timeout > 0 {
+					var cancel context.CancelFunc
+					taskCtx, cancel = context.WithTimeout(groupCtx, timeout)
+					defer cancel()
+				}
+
+				if err := stop.Catch(run(taskCtx)); err != nil {
+					return fmt.Errorf("%s failed; %w", task.Name, err)
+				}
+				return nil
+			})
+		}
+
+		if err = stop.Err(group.Wait()); err != nil {
+			return fmt.Errorf("phase %d seeding tables failed; %w", phase, err)
+		}
+
+		slog.Info(fmt.Sprintf("Phase %d Complete.", phase))
+	}
+
+	return nil
+}
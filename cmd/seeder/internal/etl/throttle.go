@@ -0,0 +1,143 @@
+package etl
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// throttleTimeout caps how long a request waits on the rate limiters.
+const throttleTimeout = 30 * time.Second
+
+// EndpointLimiters looks up the rate limiter that applies to an endpoint on
+// top of the shared one, e.g. one per class of endpoints.
+type EndpointLimiters interface {
+	// Get returns the endpoint's limiter, or nil if it has none.
+	Get(endpoint string) *rate.Limiter
+}
+
+// Throttler paces a source's requests: every request waits on a shared
+// limiter, then on its endpoint's limiter if it has one. Requests may also
+// be paused outright, e.g. while an API quota recovers, or stopped for good
+// when the process is asked to shut down. It is safe for concurrent use.
+type Throttler struct {
+	mu      sync.Mutex
+	limiter *rate.Limiter
+	// resume is non-nil while requests are paused; it is closed once they
+	// may continue.
+	resume    chan struct{}
+	endpoints EndpointLimiters
+	// stopped is closed by Stop.
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewThrottler returns a throttler pacing requests with the shared limiter
+// and, where they have one, the endpoint limiters.
+func NewThrottler(
+	limiter *rate.Limiter,
+	endpoints EndpointLimiters,
+) *Throttler {
+	return &Throttler{
+		limiter:   limiter,
+		endpoints: endpoints,
+		stopped:   make(chan struct{}),
+	}
+}
+
+// Wait blocks until a request to the endpoint may be made, or ctx is done.
+// Once the throttler is stopped it returns ErrStopped instead.
+func (t *Throttler) Wait(ctx context.Context, endpoint string) error {
+	if t.Stopped() {
+		return ErrStopped
+	}
+
+	t.mu.Lock()
+	limiter := t.limiter
+	resume := t.resume
+	t.mu.Unlock()
+
+	// Hold requests while paused.
+	if resume != nil {
+		select {
+		case <-resume:
+		case <-t.stopped:
+			return ErrStopped
+		case <-ctx.Done():
+			return fmt.Errorf("rate limiter wait failed: %w", ctx.Err())
+		}
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, throttleTimeout)
+	defer cancel()
+
+	if err := limiter.Wait(waitCtx); err != nil {
+		return fmt.Errorf("rate limiter wait failed: %w", err)
+	}
+
+	if limiter := t.endpoints.Get(endpoint); limiter != nil {
+		if err := limiter.Wait(waitCtx); err != nil {
+			return fmt.Errorf("rate limiter wait failed: %w", err)
+		}
+	}
+
+	// A request that waited past Stop is not made either.
+	if t.Stopped() {
+		return ErrStopped
+	}
+
+	return nil
+}
+
+// Stop makes every request not yet made fail with ErrStopped, including
+// those waiting. Requests already made are left to finish, so that their
+// rows are written.
+func (t *Throttler) Stop() {
+	t.stopOnce.Do(func() { close(t.stopped) })
+}
+
+// Done returns a channel that is closed once Stop is called, for WithStop.
+func (t *Throttler) Done() <-chan struct{} {
+	return t.stopped
+}
+
+// Stopped reports whether Stop was called.
+func (t *Throttler) Stopped() bool {
+	select {
+	case <-t.stopped:
+		return true
+	default:
+		return false
+	}
+}
+
+// Limit returns the shared limiter's current rate.
+func (t *Throttler) Limit() rate.Limit {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.limiter.Limit()
+}
+
+// SetRate changes the shared limiter's rate and pauses or resumes requests,
+// reporting whether paused requests were resumed.
+func (t *Throttler) SetRate(limit rate.Limit, paused bool) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.limiter.SetLimit(limit)
+
+	switch {
+	case paused && t.resume == nil:
+		t.resume = make(chan struct{})
+	case !paused && t.resume != nil:
+		close(t.resume)
+		t.resume = nil
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+package etl
+
+import (
+	"context"
+	"reflect"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer records a span per task and per API call. Spans are discarded
+// unless a tracer provider has been installed (see tracing.Start).
+var tracer = otel.Tracer(
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl",
+)
+
+// StartSpan starts a span named name under ctx and returns the context
+// carrying it, along with a function that ends it, marked failed if the
+// error passed is not nil.
+func StartSpan(
+	ctx context.Context,
+	name string,
+	opts ...trace.SpanStartOption,
+) (context.Context, func(error)) {
+	ctx, span := tracer.Start(ctx, name, opts...)
+
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// recordCount returns the number of records in an API response: the length
+// of a slice or map, otherwise one for anything but nil.
+func recordCount(response any) int {
+	v := reflect.ValueOf(response)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len()
+	case reflect.Invalid:
+		return 0
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return 1
+	default:
+		return 1
+	}
+}
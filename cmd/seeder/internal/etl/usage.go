@@ -0,0 +1,164 @@
+package etl
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// EndpointUsage is the accumulated request, error and payload totals for a
+// single endpoint over the lifetime of a run.
+type EndpointUsage struct {
+	Endpoint string
+	Requests int64
+	Errors   int64
+	Bytes    int64
+}
+
+// UsageTracker accounts for the number of requests made and the size of the
+// payloads returned by each endpoint. It is safe for concurrent use.
+//
+// Payload sizes are the response body bytes read through the transport
+// returned by Transport, including those of attempts that are retried.
+type UsageTracker struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointUsage
+}
+
+// NewUsageTracker returns an empty UsageTracker.
+func NewUsageTracker() *UsageTracker {
+	return &UsageTracker{
+		endpoints: make(map[string]*EndpointUsage),
+	}
+}
+
+// Record adds a single request against the endpoint.
+func (u *UsageTracker) Record(endpoint string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.endpoint(endpoint).Requests++
+}
+
+// Transport returns a RoundTripper making requests through next that adds
+// the body bytes of every response from host, as they are read, to the
+// endpoint named by the request path, e.g. "/games".
+func (u *UsageTracker) Transport(
+	host string,
+	next http.RoundTripper,
+) http.RoundTripper {
+	return &usageTransport{usage: u, host: host, next: next}
+}
+
+// addBytes adds n response bytes to the endpoint.
+func (u *UsageTracker) addBytes(endpoint string, n int64) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.endpoint(endpoint).Bytes += n
+}
+
+// usageTransport is the RoundTripper returned by UsageTracker.Transport.
+type usageTransport struct {
+	usage *UsageTracker
+	host  string
+	next  http.RoundTripper
+}
+
+func (t *usageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || req.URL.Host != t.host {
+		return resp, err
+	}
+
+	resp.Body = &countingBody{
+		ReadCloser: resp.Body,
+		usage:      t.usage,
+		endpoint:   req.URL.Path,
+	}
+	return resp, nil
+}
+
+// countingBody is a response body adding the bytes read from it to an
+// endpoint's usage.
+type countingBody struct {
+	io.ReadCloser
+	usage    *UsageTracker
+	endpoint string
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.usage.addBytes(b.endpoint, int64(n))
+	}
+	return n, err
+}
+
+// RecordError counts a failed request against the endpoint, including
+// attempts that are later retried successfully.
+func (u *UsageTracker) RecordError(endpoint string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.endpoint(endpoint).Errors++
+}
+
+// endpoint returns the usage for the endpoint, creating it if needed. The
+// caller must hold u.mu.
+func (u *UsageTracker) endpoint(endpoint string) *EndpointUsage {
+	usage, ok := u.endpoints[endpoint]
+	if !ok {
+		usage = &EndpointUsage{Endpoint: endpoint}
+		u.endpoints[endpoint] = usage
+	}
+
+	return usage
+}
+
+// Snapshot returns the usage for every endpoint seen so far, ordered by the
+// number of bytes downloaded (largest first).
+func (u *UsageTracker) Snapshot() []EndpointUsage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	out := make([]EndpointUsage, 0, len(u.endpoints))
+	for _, usage := range u.endpoints {
+		out = append(out, *usage)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Bytes == out[j].Bytes {
+			return out[i].Endpoint < out[j].Endpoint
+		}
+		return out[i].Bytes > out[j].Bytes
+	})
+
+	return out
+}
+
+// LogSummary writes the per-endpoint usage and run totals to the log.
+func (u *UsageTracker) LogSummary() {
+	var totalRequests, totalErrors, totalBytes int64
+	for _, usage := range u.Snapshot() {
+		totalRequests += usage.Requests
+		totalErrors += usage.Errors
+		totalBytes += usage.Bytes
+		slog.Info(
+			"endpoint usage",
+			"endpoint", usage.Endpoint,
+			"requests", usage.Requests,
+			"errors", usage.Errors,
+			"bytes", usage.Bytes,
+		)
+	}
+
+	slog.Info(
+		"api usage summary",
+		"total_requests", totalRequests,
+		"total_errors", totalErrors,
+		"total_bytes", totalBytes,
+	)
+}
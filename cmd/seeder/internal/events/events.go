@@ -0,0 +1,162 @@
+// Package events publishes the rows a seed writes as compact change events
+// to Kafka or NATS, so downstream services can react to new games, line
+// movements and rankings as they are ingested.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/nats-io/nats.go"
+	"github.com/segmentio/kafka-go"
+)
+
+// defaultTopic is the Kafka topic, or NATS subject prefix, of a URL without
+// one.
+const defaultTopic = "cfbd"
+
+// Publisher sends change events to an event bus.
+type Publisher interface {
+	// Publish sends an event per change. Events may be buffered until
+	// Close.
+	Publish(ctx context.Context, changes []db.RowChange) error
+	// Close sends any buffered events and disconnects.
+	Close() error
+}
+
+// Open returns the publisher for dest: kafka://broker[,broker...]/topic,
+// which publishes every event to the topic keyed by its table and primary
+// key, or nats://host[:port]/subject, which publishes every event to
+// <subject>.<table>. The topic and subject default to "cfbd".
+func Open(dest string) (Publisher, error) {
+	u, err := url.Parse(dest)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid event bus url %q", dest)
+	}
+	topic := strings.Trim(u.Path, "/")
+	if topic == "" {
+		topic = defaultTopic
+	}
+
+	switch u.Scheme {
+	case "kafka":
+		return newKafkaPublisher(strings.Split(u.Host, ","), topic), nil
+	case "nats":
+		return newNATSPublisher(u.Scheme+"://"+u.Host, topic)
+	default:
+		return nil, fmt.Errorf("unsupported event bus %q", u.Scheme)
+	}
+}
+
+// kafkaPublisher publishes events to a Kafka topic.
+type kafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+// newKafkaPublisher returns a publisher writing to topic on brokers. Events
+// with the same key are hashed to the same partition, so the changes of a
+// row are consumed in order.
+func newKafkaPublisher(brokers []string, topic string) *kafkaPublisher {
+	return &kafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Topic:    topic,
+			Balancer: &kafka.Hash{},
+			// Writes are batched in the background so that inserts never
+			// wait on the brokers.
+			Async: true,
+			Completion: func(messages []kafka.Message, err error) {
+				if err != nil {
+					slog.Warn(
+						"failed to publish events",
+						"topic", topic,
+						"events", len(messages),
+						"err", err,
+					)
+				}
+			},
+		},
+	}
+}
+
+func (p *kafkaPublisher) Publish(
+	ctx context.Context,
+	changes []db.RowChange,
+) error {
+	messages := make([]kafka.Message, 0, len(changes))
+	for _, change := range changes {
+		value, err := json.Marshal(change)
+		if err != nil {
+			return fmt.Errorf("could not encode event; %w", err)
+		}
+		key, err := json.Marshal(change.Key)
+		if err != nil {
+			return fmt.Errorf("could not encode event key; %w", err)
+		}
+		messages = append(messages, kafka.Message{
+			Key:   append([]byte(change.Table+":"), key...),
+			Value: value,
+		})
+	}
+
+	if err := p.writer.WriteMessages(ctx, messages...); err != nil {
+		return fmt.Errorf("could not publish events; %w", err)
+	}
+
+	return nil
+}
+
+func (p *kafkaPublisher) Close() error {
+	if err := p.writer.Close(); err != nil {
+		return fmt.Errorf("could not flush events; %w", err)
+	}
+
+	return nil
+}
+
+// natsPublisher publishes events to NATS subjects.
+type natsPublisher struct {
+	conn   *nats.Conn
+	prefix string
+}
+
+// newNATSPublisher connects to the NATS server at addr.
+func newNATSPublisher(addr string, prefix string) (*natsPublisher, error) {
+	conn, err := nats.Connect(addr, nats.Name("cfbd-seeder"))
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to nats; %w", err)
+	}
+
+	return &natsPublisher{conn: conn, prefix: prefix}, nil
+}
+
+func (p *natsPublisher) Publish(
+	_ context.Context,
+	changes []db.RowChange,
+) error {
+	for _, change := range changes {
+		data, err := json.Marshal(change)
+		if err != nil {
+			return fmt.Errorf("could not encode event; %w", err)
+		}
+		if err = p.conn.Publish(p.prefix+"."+change.Table, data); err != nil {
+			return fmt.Errorf("could not publish event; %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *natsPublisher) Close() error {
+	defer p.conn.Close()
+	if err := p.conn.Flush(); err != nil {
+		return fmt.Errorf("could not flush events; %w", err)
+	}
+
+	return nil
+}
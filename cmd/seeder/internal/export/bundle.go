@@ -0,0 +1,307 @@
+package export
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"log/slog"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/storage"
+)
+
+const (
+	// ManifestName is the file of a bundle that lists its tables.
+	ManifestName = "manifest.json"
+	// bundleExtension ends the name of every table file of a bundle.
+	bundleExtension = ".ndjson.gz"
+)
+
+var (
+	// ErrNewerBundle is returned when importing a bundle exported from a
+	// schema newer than the database's, whose columns it may not have.
+	ErrNewerBundle = errors.New("bundle is from a newer schema")
+	// ErrIncompleteBundle is returned when a table file of a bundle does
+	// not hold the rows its manifest lists.
+	ErrIncompleteBundle = errors.New("bundle is incomplete")
+)
+
+// Manifest describes a bundle: the schema it was exported from and its
+// files.
+type Manifest struct {
+	// SchemaVersion is the version of the latest migration applied to the
+	// database the bundle was exported from.
+	SchemaVersion int64 `json:"schema_version"`
+	// Units is the unit system the bundle's measured values are in.
+	Units     db.UnitSystem `json:"units"`
+	CreatedAt time.Time     `json:"created_at"`
+	// Years are the seasons exported, or none if every season was.
+	Years  []int32       `json:"years,omitempty"`
+	Tables []BundleTable `json:"tables"`
+}
+
+// BundleTable is a table file of a bundle.
+type BundleTable struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+	Rows int64  `json:"rows"`
+	// Binary are the columns of bytes, which are written base64-encoded.
+	Binary []string `json:"binary,omitempty"`
+}
+
+// ImportResult is the outcome of importing one table of a bundle.
+type ImportResult struct {
+	Table string `json:"table"`
+	Rows  int64  `json:"rows"`
+	// Inserted is how many of the rows were not already stored.
+	Inserted int64 `json:"inserted"`
+}
+
+// newManifest returns the manifest of a bundle of the seasons of database.
+func newManifest(
+	ctx context.Context,
+	database *db.Database,
+	years []int32,
+) (*Manifest, error) {
+	version, err := database.SchemaVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not read schema version; %w", err)
+	}
+
+	return &Manifest{
+		SchemaVersion: version,
+		Units:         database.Units(),
+		CreatedAt:     time.Now().UTC(),
+		Years:         years,
+	}, nil
+}
+
+// addToManifest lists a table written to the bundle in its manifest.
+func (e *Exporter) addToManifest(
+	ctx context.Context,
+	table string,
+	rows int64,
+) error {
+	types, err := e.db.WithContext(ctx).Migrator().ColumnTypes(table)
+	if err != nil {
+		return fmt.Errorf("could not read columns of %s; %w", table, err)
+	}
+
+	entry := BundleTable{Name: table, File: e.fileName(table), Rows: rows}
+	for _, t := range types {
+		if kindOf(t.DatabaseTypeName()) == kindBytes {
+			entry.Binary = append(entry.Binary, t.Name())
+		}
+	}
+	e.manifest.Tables = append(e.manifest.Tables, entry)
+
+	return nil
+}
+
+// writeManifest writes the manifest of the bundle.
+func (e *Exporter) writeManifest(ctx context.Context) error {
+	file, err := e.store.Create(ctx, ManifestName)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(file)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(e.manifest); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("could not encode manifest; %w", err)
+	}
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("could not write %s; %w", ManifestName, err)
+	}
+
+	return nil
+}
+
+// bundleWriter writes rows to a gzip-compressed NDJSON file, with bytes
+// columns base64-encoded so that they survive being read back.
+type bundleWriter struct {
+	*ndjsonWriter
+	gzip *gzip.Writer
+}
+
+func newBundleWriter(w io.Writer, columns []column) *bundleWriter {
+	zw := gzip.NewWriter(w)
+	rows := newNDJSONWriter(zw, columns)
+	rows.base64 = true
+
+	return &bundleWriter{ndjsonWriter: rows, gzip: zw}
+}
+
+func (w *bundleWriter) close() error {
+	if err := w.ndjsonWriter.close(); err != nil {
+		return err
+	}
+
+	return w.gzip.Close()
+}
+
+// Import loads the bundle at source, a local directory or an s3:// or
+// gs:// URL, into the database, whichever supported backend it is. Tables
+// are loaded in the order they were exported, which is the order they are
+// seeded, so that rows are loaded after the rows they reference. The
+// bundle must not be from a newer schema than the database's; one from an
+// older schema is loaded, leaving out any column that no longer exists.
+// Rows already stored are kept, so an interrupted import can be run again.
+// Measured values are not converted, so the bundle must be in the
+// database's unit system.
+func Import(
+	ctx context.Context,
+	database *db.Database,
+	source string,
+) ([]ImportResult, error) {
+	store, err := storage.Open(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := ReadManifest(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := database.SchemaVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not read schema version; %w", err)
+	}
+	if manifest.SchemaVersion > version {
+		return nil, fmt.Errorf("%w: bundle version %d, database version %d",
+			ErrNewerBundle, manifest.SchemaVersion, version)
+	}
+	if manifest.Units != database.Units() {
+		return nil, fmt.Errorf("%w: bundle is %s, database is %s",
+			db.ErrUnitMismatch, manifest.Units, database.Units())
+	}
+
+	results := make([]ImportResult, 0, len(manifest.Tables))
+	for _, table := range manifest.Tables {
+		if err = ctx.Err(); err != nil {
+			return results, err
+		}
+
+		start := time.Now()
+		result, err := importTable(ctx, database, store, table)
+		if err != nil {
+			slog.Error("could not import table",
+				"table", table.Name, "err", err.Error())
+			return results, fmt.Errorf("could not import %s; %w",
+				table.Name, err)
+		}
+		results = append(results, result)
+
+		slog.Info("imported table",
+			"table", table.Name,
+			"rows", result.Rows,
+			"inserted", result.Inserted,
+			"duration", time.Since(start).String(),
+		)
+	}
+
+	return results, nil
+}
+
+// ReadManifest reads the manifest of the bundle in store.
+func ReadManifest(ctx context.Context, store storage.Store) (*Manifest, error) {
+	file, err := store.Open(ctx, ManifestName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var manifest Manifest
+	if err = json.NewDecoder(file).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("could not decode manifest; %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// importTable loads a table file of a bundle into the database.
+func importTable(
+	ctx context.Context,
+	database *db.Database,
+	store storage.Store,
+	table BundleTable,
+) (ImportResult, error) {
+	result := ImportResult{Table: table.Name}
+
+	file, err := store.Open(ctx, table.File)
+	if err != nil {
+		return result, err
+	}
+	defer file.Close()
+
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		return result, fmt.Errorf("could not decompress %s; %w",
+			table.File, err)
+	}
+	defer zr.Close()
+
+	result.Inserted, err = database.ImportRows(
+		ctx, table.Name, readRows(zr, table.Binary, &result.Rows),
+	)
+	if err != nil {
+		return result, err
+	}
+	if result.Rows != table.Rows {
+		return result, fmt.Errorf("%w: %s holds %d rows, not %d",
+			ErrIncompleteBundle, table.File, result.Rows, table.Rows)
+	}
+
+	return result, nil
+}
+
+// readRows returns the rows of an NDJSON file, with numbers kept as
+// json.Number and the binary columns decoded, counting them into count as
+// they are read.
+func readRows(
+	r io.Reader,
+	binary []string,
+	count *int64,
+) iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		dec := json.NewDecoder(r)
+		dec.UseNumber()
+		for {
+			var row map[string]any
+			err := dec.Decode(&row)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, fmt.Errorf("could not decode row; %w", err))
+				return
+			}
+
+			for _, name := range binary {
+				encoded, ok := row[name].(string)
+				if !ok {
+					continue
+				}
+				decoded, err := base64.StdEncoding.DecodeString(encoded)
+				if err != nil {
+					yield(nil, fmt.Errorf("could not decode %s; %w", name, err))
+					return
+				}
+				row[name] = decoded
+			}
+
+			*count++
+			if !yield(row, nil) {
+				return
+			}
+		}
+	}
+}
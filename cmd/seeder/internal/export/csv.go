@@ -0,0 +1,75 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// csvWriter writes rows to a CSV file, under a header of the column names.
+// NULL is written as an empty field.
+type csvWriter struct {
+	writer  *csv.Writer
+	columns []column
+	record  []string
+}
+
+func newCSVWriter(w io.Writer, columns []column) (*csvWriter, error) {
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.name
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("could not write header; %w", err)
+	}
+
+	return &csvWriter{
+		writer:  writer,
+		columns: columns,
+		record:  make([]string, len(columns)),
+	}, nil
+}
+
+func (w *csvWriter) write(values []any) error {
+	for i, v := range values {
+		field, err := textValue(w.columns[i].kind, v)
+		if err != nil {
+			return fmt.Errorf("could not convert column %s; %w",
+				w.columns[i].name, err)
+		}
+		w.record[i] = field
+	}
+
+	return w.writer.Write(w.record)
+}
+
+func (w *csvWriter) close() error {
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+// textValue converts a scanned value to its text, as written to a CSV
+// field.
+func textValue(k kind, v any) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+
+	switch k {
+	case kindInt:
+		n, err := toInt(v)
+		return strconv.FormatInt(n, 10), err
+	case kindFloat:
+		f, err := toFloat(v)
+		return strconv.FormatFloat(f, 'f', -1, 64), err
+	case kindBool:
+		b, err := toBool(v)
+		return strconv.FormatBool(b), err
+	default:
+		return string(toBytes(v)), nil
+	}
+}
@@ -0,0 +1,487 @@
+// Package export writes the seeded tables to files, locally or in object
+// storage, so they can be analyzed with Spark, DuckDB or Athena without a
+// live database, or bundled to be imported into another database.
+package export
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/storage"
+	"gorm.io/gorm"
+)
+
+// Formats of the exported files.
+const (
+	// FormatParquet writes Snappy-compressed Parquet files, partitioned by
+	// season.
+	FormatParquet = "parquet"
+	// FormatCSV writes a CSV file per table, with a header of column names.
+	FormatCSV = "csv"
+	// FormatNDJSON writes a file per table of one JSON object per row.
+	FormatNDJSON = "ndjson"
+	// FormatBundle writes a gzip-compressed NDJSON file per table and a
+	// manifest of them, which Import loads into any supported database.
+	FormatBundle = "bundle"
+)
+
+// seasonColumn is the column tables are partitioned by.
+const seasonColumn = "season"
+
+// nullPartition names the partition of rows without a season, as Hive does.
+const nullPartition = "__HIVE_DEFAULT_PARTITION__"
+
+var (
+	// ErrUnknownFormat is returned for an export format that is not
+	// supported.
+	ErrUnknownFormat = errors.New("unknown export format")
+	// ErrUnknownTable is returned when a table to export is not one the
+	// seeder creates.
+	ErrUnknownTable = errors.New("unknown table")
+)
+
+// Options configures an export.
+type Options struct {
+	// Format is the file format written: FormatParquet, FormatCSV,
+	// FormatNDJSON or FormatBundle.
+	Format string
+	// Out is a local directory or an s3:// or gs:// URL; see storage.Open.
+	Out string
+	// Tables are the tables exported. Every seeded table is exported if it
+	// is empty.
+	Tables []string
+	// Years are the seasons exported of tables with a season column. Every
+	// season is exported if it is empty.
+	Years []int32
+}
+
+// Exporter writes the seeded tables to a store.
+type Exporter struct {
+	db     *db.Database
+	store  storage.Store
+	format string
+	tables []string
+	years  []int32
+	// manifest lists the files of a bundle as they are written.
+	manifest *Manifest
+}
+
+// New returns an exporter writing the tables of the database as opts
+// describe.
+func New(
+	ctx context.Context,
+	database *db.Database,
+	opts Options,
+) (*Exporter, error) {
+	switch opts.Format {
+	case FormatParquet, FormatCSV, FormatNDJSON, FormatBundle:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, opts.Format)
+	}
+	if strings.TrimSpace(opts.Out) == "" {
+		return nil, errors.New("export destination is required")
+	}
+
+	tables, err := database.Tables()
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Tables) > 0 {
+		tables, err = selectTables(tables, opts.Tables)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	store, err := storage.Open(ctx, opts.Out)
+	if err != nil {
+		return nil, err
+	}
+
+	exporter := &Exporter{
+		db:     database,
+		store:  store,
+		format: opts.Format,
+		tables: tables,
+		years:  opts.Years,
+	}
+	if opts.Format == FormatBundle {
+		exporter.manifest, err = newManifest(ctx, database, opts.Years)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return exporter, nil
+}
+
+// selectTables returns the seeded tables named, in the order they are
+// seeded.
+func selectTables(seeded []string, names []string) ([]string, error) {
+	for _, name := range names {
+		if !slices.Contains(seeded, name) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownTable, name)
+		}
+	}
+
+	var tables []string
+	for _, table := range seeded {
+		if slices.Contains(names, table) {
+			tables = append(tables, table)
+		}
+	}
+
+	return tables, nil
+}
+
+// Run exports every selected table that exists. In Parquet, a table with a
+// season column is written as a file per season, under
+// <table>/season=<season>/, which Spark, DuckDB and Athena read as a
+// partitioned table, and every other table as a single file under <table>/.
+// In CSV and NDJSON every table is written as a single file, <table>.csv or
+// <table>.ndjson. A bundle writes every table as <table>.ndjson.gz and ends
+// with its manifest.json, which is written last so that an incomplete
+// bundle cannot be imported.
+func (e *Exporter) Run(ctx context.Context) error {
+	for _, table := range e.tables {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !e.db.WithContext(ctx).Migrator().HasTable(table) {
+			continue
+		}
+
+		start := time.Now()
+		rows, files, err := e.exportTable(ctx, table)
+		if err != nil {
+			slog.Error("could not export table",
+				"table", table, "err", err.Error())
+			return fmt.Errorf("could not export %s; %w", table, err)
+		}
+		slog.Info("exported table",
+			"table", table,
+			"rows", rows,
+			"files", files,
+			"duration", time.Since(start).String(),
+		)
+
+		if e.manifest != nil {
+			if err = e.addToManifest(ctx, table, rows); err != nil {
+				return err
+			}
+		}
+	}
+
+	if e.manifest != nil {
+		return e.writeManifest(ctx)
+	}
+
+	return nil
+}
+
+// exportTable writes a table's files and returns how many rows and files it
+// wrote.
+func (e *Exporter) exportTable(
+	ctx context.Context,
+	table string,
+) (int64, int, error) {
+	session := e.db.WithContext(ctx)
+	hasSeason := session.Migrator().HasColumn(table, seasonColumn)
+
+	if e.format != FormatParquet {
+		query := session.Table(table)
+		if hasSeason && len(e.years) > 0 {
+			query = query.Where(seasonColumn+" IN ?", e.years)
+		}
+		rows, err := e.writeFile(ctx, e.fileName(table), query, "")
+		return rows, 1, err
+	}
+
+	if !hasSeason {
+		rows, err := e.writeFile(ctx,
+			path.Join(table, e.fileName(table)), session.Table(table), "")
+		return rows, 1, err
+	}
+
+	seasonQuery := session.Table(table).Distinct(seasonColumn)
+	if len(e.years) > 0 {
+		seasonQuery = seasonQuery.Where(seasonColumn+" IN ?", e.years)
+	}
+	var seasons []*int64
+	err := seasonQuery.Order(seasonColumn).Pluck(seasonColumn, &seasons).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not list seasons; %w", err)
+	}
+
+	var total int64
+	for _, season := range seasons {
+		partition := nullPartition
+		query := session.Table(table).Where(seasonColumn + " IS NULL")
+		if season != nil {
+			partition = strconv.FormatInt(*season, 10)
+			query = session.Table(table).Where(seasonColumn+" = ?", *season)
+		}
+
+		name := path.Join(
+			table, seasonColumn+"="+partition, e.fileName(table),
+		)
+		rows, err := e.writeFile(ctx, name, query, seasonColumn)
+		if err != nil {
+			return total, 0, err
+		}
+		total += rows
+	}
+
+	return total, len(seasons), nil
+}
+
+// fileName returns the name of the file a table is written to.
+func (e *Exporter) fileName(table string) string {
+	if e.format == FormatBundle {
+		return table + bundleExtension
+	}
+	return table + "." + e.format
+}
+
+// writeFile writes the rows of query to the file at name, leaving out the
+// partition column, whose value the file's path already holds.
+func (e *Exporter) writeFile(
+	ctx context.Context,
+	name string,
+	query *gorm.DB,
+	partition string,
+) (int64, error) {
+	rows, err := query.Rows()
+	if err != nil {
+		return 0, fmt.Errorf("could not query rows; %w", err)
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, fmt.Errorf("could not read columns; %w", err)
+	}
+
+	columns := make([]column, 0, len(types))
+	keep := make([]bool, len(types))
+	for i, t := range types {
+		if t.Name() == partition {
+			continue
+		}
+		keep[i] = true
+		columns = append(columns, column{
+			name: t.Name(),
+			kind: kindOf(t.DatabaseTypeName()),
+		})
+	}
+
+	file, err := e.store.Create(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+
+	w, err := e.newWriter(file, columns)
+	if err != nil {
+		_ = file.Close()
+		return 0, err
+	}
+
+	count, err := copyRows(rows, w, keep)
+	if err != nil {
+		_ = file.Close()
+		return count, err
+	}
+	if err = file.Close(); err != nil {
+		return count, fmt.Errorf("could not write %s; %w", name, err)
+	}
+
+	return count, nil
+}
+
+// rowWriter writes rows to a file in an export format.
+type rowWriter interface {
+	// write writes a row, holding a value for every column of the file.
+	write(values []any) error
+	// close writes whatever the format buffers and ends the file, without
+	// closing the writer under it.
+	close() error
+}
+
+// newWriter returns the writer of the export format to a file of the
+// columns.
+func (e *Exporter) newWriter(
+	w io.Writer,
+	columns []column,
+) (rowWriter, error) {
+	switch e.format {
+	case FormatCSV:
+		return newCSVWriter(w, columns)
+	case FormatNDJSON:
+		return newNDJSONWriter(w, columns), nil
+	case FormatBundle:
+		return newBundleWriter(w, columns), nil
+	default:
+		return newParquetWriter(w, columns)
+	}
+}
+
+// copyRows writes the kept values of every row with the writer.
+func copyRows(rows *sql.Rows, w rowWriter, keep []bool) (int64, error) {
+	values := make([]any, len(keep))
+	pointers := make([]any, len(keep))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	kept := make([]any, 0, len(keep))
+
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return count, fmt.Errorf("could not scan row; %w", err)
+		}
+
+		kept = kept[:0]
+		for i, v := range values {
+			if keep[i] {
+				kept = append(kept, v)
+			}
+		}
+		if err := w.write(kept); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("could not read rows; %w", err)
+	}
+
+	return count, w.close()
+}
+
+// kind is how a column's values are written, whatever the database called
+// its type.
+type kind int
+
+const (
+	kindString kind = iota
+	kindInt
+	kindFloat
+	kindBool
+	kindTime
+	kindBytes
+	kindJSON
+)
+
+// column is a column written to a file.
+type column struct {
+	name string
+	kind kind
+}
+
+// kindOf returns the kind of a column from its database type name, as
+// reported by the PostgreSQL, MySQL or SQLite driver. Arrays and anything
+// else unrecognized are written as text.
+func kindOf(databaseType string) kind {
+	t := strings.ToUpper(databaseType)
+	switch {
+	case strings.HasPrefix(t, "_"):
+		// PostgreSQL array types, e.g. _TEXT.
+		return kindString
+	case strings.Contains(t, "INT"):
+		return kindInt
+	case t == "FLOAT4", t == "FLOAT8", t == "FLOAT", t == "DOUBLE",
+		t == "REAL", t == "NUMERIC", t == "DECIMAL":
+		return kindFloat
+	case t == "BOOL", t == "BOOLEAN":
+		return kindBool
+	case strings.Contains(t, "TIMESTAMP"), t == "DATETIME", t == "DATE":
+		return kindTime
+	case t == "BYTEA", strings.HasSuffix(t, "BLOB"):
+		return kindBytes
+	case t == "JSON", t == "JSONB":
+		return kindJSON
+	default:
+		return kindString
+	}
+}
+
+// toInt converts a scanned integer to int64.
+func toInt(v any) (int64, error) {
+	switch n := v.(type) {
+	case int64:
+		return n, nil
+	case int32:
+		return int64(n), nil
+	case int16:
+		return int64(n), nil
+	case int:
+		return int64(n), nil
+	case float64:
+		return int64(n), nil
+	case []byte:
+		return strconv.ParseInt(string(n), 10, 64)
+	case string:
+		return strconv.ParseInt(n, 10, 64)
+	default:
+		return 0, fmt.Errorf("unexpected integer %T", v)
+	}
+}
+
+// toFloat converts a scanned number to float64.
+func toFloat(v any) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case []byte:
+		return strconv.ParseFloat(string(n), 64)
+	case string:
+		return strconv.ParseFloat(n, 64)
+	default:
+		return 0, fmt.Errorf("unexpected number %T", v)
+	}
+}
+
+// toBool converts a scanned boolean, which MySQL and SQLite store as an
+// integer, to bool.
+func toBool(v any) (bool, error) {
+	switch b := v.(type) {
+	case bool:
+		return b, nil
+	case int64:
+		return b != 0, nil
+	case []byte:
+		return strconv.ParseBool(string(b))
+	case string:
+		return strconv.ParseBool(b)
+	default:
+		return false, fmt.Errorf("unexpected boolean %T", v)
+	}
+}
+
+// toBytes converts any other scanned value to its text or bytes.
+func toBytes(v any) []byte {
+	switch s := v.(type) {
+	case []byte:
+		return s
+	case string:
+		return []byte(s)
+	case time.Time:
+		return []byte(s.Format(time.RFC3339Nano))
+	default:
+		return []byte(fmt.Sprint(s))
+	}
+}
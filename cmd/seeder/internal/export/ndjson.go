@@ -0,0 +1,110 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ndjsonWriter writes rows to a newline-delimited JSON file, as an object
+// per row keyed by column name, in column order. JSON columns are embedded
+// as compacted JSON rather than as strings.
+type ndjsonWriter struct {
+	writer  *bufio.Writer
+	columns []column
+	// keys holds the encoded name of each column.
+	keys [][]byte
+	// base64 writes bytes columns base64-encoded rather than as text.
+	base64 bool
+}
+
+func newNDJSONWriter(w io.Writer, columns []column) *ndjsonWriter {
+	keys := make([][]byte, len(columns))
+	for i, c := range columns {
+		// Marshaling a string cannot fail.
+		keys[i], _ = json.Marshal(c.name)
+	}
+
+	return &ndjsonWriter{
+		writer:  bufio.NewWriter(w),
+		columns: columns,
+		keys:    keys,
+	}
+}
+
+func (w *ndjsonWriter) write(values []any) error {
+	_ = w.writer.WriteByte('{')
+	for i, v := range values {
+		var value []byte
+		var err error
+		if w.base64 && w.columns[i].kind == kindBytes && v != nil {
+			value, err = json.Marshal(toBytes(v))
+		} else {
+			value, err = jsonValue(w.columns[i].kind, v)
+		}
+		if err != nil {
+			return fmt.Errorf("could not convert column %s; %w",
+				w.columns[i].name, err)
+		}
+
+		if i > 0 {
+			_ = w.writer.WriteByte(',')
+		}
+		_, _ = w.writer.Write(w.keys[i])
+		_ = w.writer.WriteByte(':')
+		_, _ = w.writer.Write(value)
+	}
+	_, err := w.writer.WriteString("}\n")
+
+	return err
+}
+
+func (w *ndjsonWriter) close() error {
+	return w.writer.Flush()
+}
+
+// jsonValue encodes a scanned value as JSON. NaN and infinite numbers,
+// which JSON cannot hold, are written as null.
+func jsonValue(k kind, v any) ([]byte, error) {
+	if v == nil {
+		return []byte("null"), nil
+	}
+
+	var value any
+	switch k {
+	case kindInt:
+		n, err := toInt(v)
+		if err != nil {
+			return nil, err
+		}
+		value = n
+	case kindFloat:
+		f, err := toFloat(v)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return []byte("null"), nil
+		}
+		value = f
+	case kindBool:
+		b, err := toBool(v)
+		if err != nil {
+			return nil, err
+		}
+		value = b
+	case kindJSON:
+		var compact bytes.Buffer
+		if json.Compact(&compact, toBytes(v)) == nil {
+			return compact.Bytes(), nil
+		}
+		value = string(toBytes(v))
+	default:
+		value = string(toBytes(v))
+	}
+
+	return json.Marshal(value)
+}
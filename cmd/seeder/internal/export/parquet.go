@@ -0,0 +1,135 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetBatchSize is how many rows are handed to the parquet writer at
+// once.
+const parquetBatchSize = 1024
+
+// parquetWriter writes rows to a Parquet file with a flat schema of
+// optional columns, compressed with Snappy.
+type parquetWriter struct {
+	writer  *parquet.Writer
+	columns []column
+	// leaves maps each column to its index in the Parquet schema, which
+	// orders columns by name.
+	leaves []int
+	batch  []parquet.Row
+}
+
+func newParquetWriter(w io.Writer, columns []column) (*parquetWriter, error) {
+	group := make(parquet.Group, len(columns))
+	for _, c := range columns {
+		group[c.name] = parquet.Optional(parquetNode(c.kind))
+	}
+	schema := parquet.NewSchema("row", group)
+
+	leaves := make([]int, len(columns))
+	for i, c := range columns {
+		leaf, ok := schema.Lookup(c.name)
+		if !ok {
+			return nil, fmt.Errorf("could not find column %s in schema", c.name)
+		}
+		leaves[i] = leaf.ColumnIndex
+	}
+
+	return &parquetWriter{
+		writer:  parquet.NewWriter(w, schema, parquet.Compression(&parquet.Snappy)),
+		columns: columns,
+		leaves:  leaves,
+		batch:   make([]parquet.Row, 0, parquetBatchSize),
+	}, nil
+}
+
+// parquetNode returns the Parquet type a column of the kind is stored as.
+func parquetNode(k kind) parquet.Node {
+	switch k {
+	case kindInt:
+		return parquet.Int(64)
+	case kindFloat:
+		return parquet.Leaf(parquet.DoubleType)
+	case kindBool:
+		return parquet.Leaf(parquet.BooleanType)
+	case kindTime:
+		return parquet.Timestamp(parquet.Microsecond)
+	case kindBytes:
+		return parquet.Leaf(parquet.ByteArrayType)
+	default:
+		return parquet.String()
+	}
+}
+
+func (w *parquetWriter) write(values []any) error {
+	row := make(parquet.Row, len(values))
+	for i, v := range values {
+		value, err := parquetValue(w.columns[i].kind, v)
+		if err != nil {
+			return fmt.Errorf("could not convert column %s; %w",
+				w.columns[i].name, err)
+		}
+
+		definition := 1
+		if value.IsNull() {
+			definition = 0
+		}
+		row[w.leaves[i]] = value.Level(0, definition, w.leaves[i])
+	}
+
+	w.batch = append(w.batch, row)
+	if len(w.batch) < parquetBatchSize {
+		return nil
+	}
+
+	return w.flush()
+}
+
+// flush writes the rows batched so far.
+func (w *parquetWriter) flush() error {
+	if _, err := w.writer.WriteRows(w.batch); err != nil {
+		return err
+	}
+	w.batch = w.batch[:0]
+
+	return nil
+}
+
+func (w *parquetWriter) close() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	return w.writer.Close()
+}
+
+// parquetValue converts a scanned value to a Parquet value of the kind.
+func parquetValue(k kind, v any) (parquet.Value, error) {
+	if v == nil {
+		return parquet.NullValue(), nil
+	}
+
+	switch k {
+	case kindInt:
+		n, err := toInt(v)
+		return parquet.Int64Value(n), err
+	case kindFloat:
+		f, err := toFloat(v)
+		return parquet.DoubleValue(f), err
+	case kindBool:
+		b, err := toBool(v)
+		return parquet.BooleanValue(b), err
+	case kindTime:
+		t, ok := v.(time.Time)
+		if !ok {
+			return parquet.Value{}, fmt.Errorf("unexpected time %T", v)
+		}
+		return parquet.Int64Value(t.UnixMicro()), nil
+	default:
+		return parquet.ByteArrayValue(toBytes(v)), nil
+	}
+}
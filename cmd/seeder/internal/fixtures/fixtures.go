@@ -0,0 +1,186 @@
+// Package fixtures records CFBD API responses to files and serves them back
+// from a mock API, so that seeding can be exercised offline without an API
+// key or its quota.
+//
+// Recording and redirecting to the mock are transports wrapping another,
+// installed on a single cfbd client with seed.WrapClientTransport:
+//
+//	srv := fixtures.NewServer(os.DirFS("testdata"))
+//	defer srv.Close()
+//	base, _ := url.Parse(srv.URL)
+//
+//	api, _ := cfbd.New("fixtures")
+//	_ = seed.WrapClientTransport(api,
+//		func(next http.RoundTripper) http.RoundTripper {
+//			return fixtures.Redirect(base, next)
+//		},
+//	)
+//	seeder, _ := seed.NewSeeder(database, api, limiter)
+package fixtures
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Host is the host of the CFBD API whose responses are recorded and mocked.
+const Host = "api.collegefootballdata.com"
+
+// Path returns the slash-separated path of the fixture of an API request:
+// the request path followed by its query parameters in key order, e.g.
+// games/seasonType=regular_week=1_year=2024.json. Characters other than
+// letters, digits and ._=- are replaced with -, so "Ohio State" is stored
+// as "Ohio-State".
+func Path(u *url.URL) string {
+	query := u.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	params := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, value := range query[key] {
+			params = append(params, sanitize(key)+"="+sanitize(value))
+		}
+	}
+
+	name := strings.Trim(path.Clean(u.Path), "/")
+	if name == "" || name == "." {
+		name = "index"
+	}
+	if len(params) > 0 {
+		name += "/" + strings.Join(params, "_")
+	}
+
+	return name + ".json"
+}
+
+// sanitize replaces the characters of s that are not safe in a file name.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '.', r == '_', r == '-':
+			return r
+		default:
+			return '-'
+		}
+	}, s)
+}
+
+// NewServer starts a mock CFBD API answering each request with the fixture
+// of its Path in fixtures, or a 404 where there is none. Requests to the
+// root, as made by the seeder's reachability check, succeed.
+func NewServer(fixtures fs.FS) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+
+			name := Path(r.URL)
+			body, err := fs.ReadFile(fixtures, name)
+			if errors.Is(err, fs.ErrNotExist) {
+				http.Error(w, "no fixture "+name, http.StatusNotFound)
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			if _, err = w.Write(body); err != nil {
+				slog.Warn("could not write fixture", "err", err)
+			}
+		},
+	))
+}
+
+// Redirect returns a transport making requests through next, which sends
+// those to the CFBD API to the base URL instead, e.g. of a NewServer.
+func Redirect(base *url.URL, next http.RoundTripper) http.RoundTripper {
+	return &redirector{base: base, next: next}
+}
+
+// Record returns a transport making requests through next, which writes
+// the body of every successful CFBD API response to the fixture of its
+// Path under dir, replacing any already there.
+func Record(dir string, next http.RoundTripper) http.RoundTripper {
+	return &recorder{dir: dir, next: next}
+}
+
+// redirector sends requests to the CFBD API to a mock at base.
+type redirector struct {
+	base *url.URL
+	next http.RoundTripper
+}
+
+func (t *redirector) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != Host {
+		return t.next.RoundTrip(req)
+	}
+
+	redirected := req.Clone(req.Context())
+	redirected.URL.Scheme = t.base.Scheme
+	redirected.URL.Host = t.base.Host
+	redirected.URL.Path = path.Join("/", t.base.Path, req.URL.Path)
+	redirected.Host = t.base.Host
+
+	return t.next.RoundTrip(redirected)
+}
+
+// recorder saves the responses of the CFBD API as fixtures.
+type recorder struct {
+	dir  string
+	next http.RoundTripper
+}
+
+func (t *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || req.URL.Host != Host || req.Method != http.MethodGet ||
+		resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("could not read response; %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err = t.save(Path(req.URL), body); err != nil {
+		slog.Warn("could not record fixture", "err", err)
+	}
+
+	return resp, nil
+}
+
+// save writes a fixture under the recorder's directory.
+func (t *recorder) save(name string, body []byte) error {
+	file := filepath.Join(t.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(file), 0o750); err != nil {
+		return fmt.Errorf("could not create %s; %w", filepath.Dir(file), err)
+	}
+	if err := os.WriteFile(file, body, 0o600); err != nil {
+		return fmt.Errorf("could not write %s; %w", file, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+// Package geocode locates places by name and looks up the elevation of
+// coordinates with the Open-Meteo geocoding and elevation APIs, which need
+// no API key, and measures the distance between places.
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultSearchURL is the Open-Meteo geocoding API.
+	DefaultSearchURL = "https://geocoding-api.open-meteo.com/v1/search"
+	// DefaultElevationURL is the Open-Meteo elevation API.
+	DefaultElevationURL = "https://api.open-meteo.com/v1/elevation"
+
+	// requestTimeout bounds how long a single lookup may take.
+	requestTimeout = 10 * time.Second
+	// requestInterval spaces out requests, to stay within the free tier's
+	// fair use.
+	requestInterval = 200 * time.Millisecond
+	// searchResults is how many places named like the city a search
+	// returns to pick the one in the right state from.
+	searchResults = 10
+)
+
+// ErrNotFound is returned by Locate when no place matches.
+var ErrNotFound = errors.New("place not found")
+
+// usStates maps the postal abbreviations the API uses for venue states to
+// the state names the geocoder reports.
+var usStates = map[string]string{
+	"AL": "Alabama", "AK": "Alaska", "AZ": "Arizona", "AR": "Arkansas",
+	"CA": "California", "CO": "Colorado", "CT": "Connecticut",
+	"DE": "Delaware", "DC": "District of Columbia", "FL": "Florida",
+	"GA": "Georgia", "HI": "Hawaii", "ID": "Idaho", "IL": "Illinois",
+	"IN": "Indiana", "IA": "Iowa", "KS": "Kansas", "KY": "Kentucky",
+	"LA": "Louisiana", "ME": "Maine", "MD": "Maryland",
+	"MA": "Massachusetts", "MI": "Michigan", "MN": "Minnesota",
+	"MS": "Mississippi", "MO": "Missouri", "MT": "Montana",
+	"NE": "Nebraska", "NV": "Nevada", "NH": "New Hampshire",
+	"NJ": "New Jersey", "NM": "New Mexico", "NY": "New York",
+	"NC": "North Carolina", "ND": "North Dakota", "OH": "Ohio",
+	"OK": "Oklahoma", "OR": "Oregon", "PA": "Pennsylvania",
+	"RI": "Rhode Island", "SC": "South Carolina", "SD": "South Dakota",
+	"TN": "Tennessee", "TX": "Texas", "UT": "Utah", "VT": "Vermont",
+	"VA": "Virginia", "WA": "Washington", "WV": "West Virginia",
+	"WI": "Wisconsin", "WY": "Wyoming", "PR": "Puerto Rico",
+}
+
+// Location is a point and its elevation in meters.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+	Elevation float64
+}
+
+// Client looks places up with the geocoding and elevation APIs.
+type Client struct {
+	searchURL    string
+	elevationURL string
+	client       *http.Client
+	limiter      *rate.Limiter
+}
+
+// New returns a client of the geocoding API at searchURL and the elevation
+// API at elevationURL.
+func New(searchURL, elevationURL string) (*Client, error) {
+	for _, raw := range []string{searchURL, elevationURL} {
+		if u, err := url.Parse(raw); err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid geocoding url %q", raw)
+		}
+	}
+
+	return &Client{
+		searchURL:    searchURL,
+		elevationURL: elevationURL,
+		client:       &http.Client{Timeout: requestTimeout},
+		limiter:      rate.NewLimiter(rate.Every(requestInterval), 1),
+	}, nil
+}
+
+// Locate returns the location of a city, narrowed to a state (a US postal
+// abbreviation or a name) and an ISO country code when they are given. The
+// location is the city's, not a venue's within it.
+func (c *Client) Locate(
+	ctx context.Context,
+	city, state, country string,
+) (Location, error) {
+	city = strings.TrimSpace(city)
+	if city == "" {
+		return Location{}, ErrNotFound
+	}
+
+	query := url.Values{
+		"name":     {city},
+		"count":    {strconv.Itoa(searchResults)},
+		"language": {"en"},
+		"format":   {"json"},
+	}
+	if country != "" {
+		query.Set("countryCode", country)
+	}
+
+	var response struct {
+		Results []struct {
+			Latitude  float64 `json:"latitude"`
+			Longitude float64 `json:"longitude"`
+			Elevation float64 `json:"elevation"`
+			Admin1    string  `json:"admin1"`
+		} `json:"results"`
+	}
+	if err := c.get(ctx, c.searchURL, query, &response); err != nil {
+		return Location{}, err
+	}
+
+	if name, ok := usStates[strings.ToUpper(state)]; ok {
+		state = name
+	}
+	for _, r := range response.Results {
+		if state == "" || strings.EqualFold(r.Admin1, state) {
+			return Location{
+				Latitude:  r.Latitude,
+				Longitude: r.Longitude,
+				Elevation: r.Elevation,
+			}, nil
+		}
+	}
+
+	return Location{}, ErrNotFound
+}
+
+// Elevation returns the elevation in meters of a point.
+func (c *Client) Elevation(
+	ctx context.Context,
+	latitude, longitude float64,
+) (float64, error) {
+	query := url.Values{
+		"latitude":  {strconv.FormatFloat(latitude, 'f', -1, 64)},
+		"longitude": {strconv.FormatFloat(longitude, 'f', -1, 64)},
+	}
+
+	var response struct {
+		Elevation []float64 `json:"elevation"`
+	}
+	if err := c.get(ctx, c.elevationURL, query, &response); err != nil {
+		return 0, err
+	}
+	if len(response.Elevation) == 0 {
+		return 0, ErrNotFound
+	}
+
+	return response.Elevation[0], nil
+}
+
+// get decodes the JSON response to a GET of endpoint with the query.
+func (c *Client) get(
+	ctx context.Context,
+	endpoint string,
+	query url.Values,
+	into any,
+) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil,
+	)
+	if err != nil {
+		return fmt.Errorf("could not build geocoding request; %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not reach geocoding api; %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("geocoding api returned status %d", resp.StatusCode)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(into); err != nil {
+		return fmt.Errorf("could not decode geocoding response; %w", err)
+	}
+
+	return nil
+}
+
+// earthRadiusMiles is the mean radius of the Earth.
+const earthRadiusMiles = 3958.8
+
+// Miles returns the great-circle distance in miles between two points.
+func Miles(from, to Location) float64 {
+	lat1 := from.Latitude * math.Pi / 180
+	lat2 := to.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (to.Longitude - from.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMiles * math.Asin(math.Sqrt(math.Min(h, 1)))
+}
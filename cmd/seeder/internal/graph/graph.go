@@ -0,0 +1,228 @@
+// Package graph serves the seeded tables over GraphQL, so consumers can
+// traverse related rows, e.g. games -> drives -> plays -> play_stats, in a
+// single query. The schema is generated from the gorm models.
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// defaultLimit is how many rows a list returns without a limit.
+	defaultLimit = 100
+	// maxLimit caps how many rows a list returns.
+	maxLimit = 1000
+	// maxRequestBytes caps the size of a query request body.
+	maxRequestBytes = 1 << 20
+)
+
+// jsonScalar carries the columns GraphQL has no type for (JSON documents
+// and arrays) as their decoded JSON value, or else as text.
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "A JSON document or array column.",
+	Serialize: func(value any) any {
+		var raw []byte
+		switch v := value.(type) {
+		case []byte:
+			raw = v
+		case string:
+			raw = []byte(v)
+		default:
+			return v
+		}
+
+		var decoded any
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return string(raw)
+		}
+
+		return decoded
+	},
+	ParseValue:   func(value any) any { return value },
+	ParseLiteral: func(ast.Value) any { return nil },
+})
+
+// table is a GraphQL object type generated from a gorm model.
+type table struct {
+	model  *schema.Schema
+	object *graphql.Object
+	fields graphql.Fields
+}
+
+// relation is a foreign key between two tables, e.g. drives.game_id
+// referencing games.id.
+type relation struct {
+	parent, child *table
+	column        string
+}
+
+// NewSchema returns a GraphQL schema with a query field per table, named
+// after it, listing its rows. Every table is an object type with a field
+// per column. A column named after another table's model with an _id
+// suffix, e.g. drives.game_id, is taken as a reference to that table's id:
+// the referencing table gets a field to the row it references (drive.game)
+// and the referenced table a field listing the rows that reference it
+// (game.drives), suffixed with _ref and _list respectively where a column
+// already has the name (games.venue_ref, drives.plays_list). Lists are
+// paginated with limit and offset, in primary key order, and lists of
+// tables with season or week columns can be filtered by them.
+func NewSchema(database *db.Database) (graphql.Schema, error) {
+	models, err := database.Models()
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+
+	tables := make([]*table, 0, len(models))
+	for _, model := range models {
+		t := &table{model: model, fields: columnFields(model)}
+		t.object = graphql.NewObject(graphql.ObjectConfig{
+			Name:   model.Name,
+			Fields: graphql.FieldsThunk(func() graphql.Fields { return t.fields }),
+		})
+		tables = append(tables, t)
+	}
+
+	for _, rel := range relations(database, tables) {
+		parentName := strings.TrimSuffix(rel.column, "_id")
+		addField(rel.child.fields, parentName, "_ref",
+			parentField(database, rel))
+		addField(rel.parent.fields, rel.child.model.Table, "_list",
+			childField(database, rel))
+	}
+
+	query := graphql.Fields{}
+	for _, t := range tables {
+		query[t.model.Table] = listField(database, t)
+	}
+
+	sch, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: query,
+		}),
+	})
+	if err != nil {
+		return graphql.Schema{}, fmt.Errorf("could not build schema; %w", err)
+	}
+
+	return sch, nil
+}
+
+// addField adds field to fields under name, or under name with suffix if a
+// column already has the name, e.g. games.venue_ref next to games.venue.
+func addField(
+	fields graphql.Fields,
+	name string,
+	suffix string,
+	field *graphql.Field,
+) {
+	if _, ok := fields[name]; ok {
+		name += suffix
+	}
+	if _, ok := fields[name]; !ok {
+		fields[name] = field
+	}
+}
+
+// columnFields returns a field per column of the model.
+func columnFields(model *schema.Schema) graphql.Fields {
+	fields := graphql.Fields{}
+	for _, name := range model.DBNames {
+		fields[name] = &graphql.Field{
+			Type: columnType(model.FieldsByDBName[name]),
+		}
+	}
+
+	return fields
+}
+
+// columnType returns the GraphQL type of a column.
+func columnType(field *schema.Field) graphql.Output {
+	switch field.DataType {
+	case schema.Bool:
+		return graphql.Boolean
+	case schema.Int, schema.Uint:
+		return graphql.Int
+	case schema.Float:
+		return graphql.Float
+	case schema.String:
+		return graphql.String
+	case schema.Time:
+		return graphql.DateTime
+	default:
+		return jsonScalar
+	}
+}
+
+// relations returns every reference between the tables: a column of one
+// named <model>_id after another table's model that has an id column.
+func relations(database *db.Database, tables []*table) []relation {
+	var rels []relation
+	for _, parent := range tables {
+		if parent.model.LookUpField("id") == nil {
+			continue
+		}
+		column := database.NamingStrategy.ColumnName("", parent.model.Name) +
+			"_id"
+		for _, child := range tables {
+			if child != parent && child.model.FieldsByDBName[column] != nil {
+				rels = append(rels, relation{
+					parent: parent,
+					child:  child,
+					column: column,
+				})
+			}
+		}
+	}
+
+	return rels
+}
+
+// Handler returns an HTTP handler executing GraphQL queries against sch,
+// sent as a JSON body by POST or as the query parameter by GET.
+func Handler(sch graphql.Schema) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query         string         `json:"query"`
+			OperationName string         `json:"operationName"`
+			Variables     map[string]any `json:"variables"`
+		}
+		switch r.Method {
+		case http.MethodGet:
+			req.Query = r.URL.Query().Get("query")
+		case http.MethodPost:
+			body := http.MaxBytesReader(w, r.Body, maxRequestBytes)
+			if err := json.NewDecoder(body).Decode(&req); err != nil {
+				http.Error(w, "invalid request body", http.StatusBadRequest)
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:         sch,
+			RequestString:  req.Query,
+			OperationName:  req.OperationName,
+			VariableValues: req.Variables,
+			Context:        r.Context(),
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			slog.Warn("could not encode response", "err", err)
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package graph
+
+import (
+	"fmt"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/graphql-go/graphql"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// filterColumns are the columns lists can be filtered by, where the table
+// has them.
+var filterColumns = []string{"season", "week"}
+
+// listField returns the query field listing a table's rows.
+func listField(database *db.Database, t *table) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewList(t.object),
+		Args: listArgs(t.model),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return list(database, t.model, p, nil)
+		},
+	}
+}
+
+// childField returns the field of a parent row listing the rows of the
+// child table that reference it.
+func childField(database *db.Database, rel relation) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewList(rel.child.object),
+		Args: listArgs(rel.child.model),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			row, _ := p.Source.(map[string]any)
+			if row["id"] == nil {
+				return nil, nil
+			}
+
+			return list(database, rel.child.model, p,
+				clause.Eq{Column: rel.column, Value: row["id"]})
+		},
+	}
+}
+
+// parentField returns the field of a child row resolving the parent row it
+// references.
+func parentField(database *db.Database, rel relation) *graphql.Field {
+	return &graphql.Field{
+		Type: rel.parent.object,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			row, _ := p.Source.(map[string]any)
+			if row[rel.column] == nil {
+				return nil, nil
+			}
+
+			var parents []map[string]any
+			err := database.WithContext(p.Context).
+				Table(rel.parent.model.Table).
+				Where(clause.Eq{Column: "id", Value: row[rel.column]}).
+				Limit(1).
+				Find(&parents).Error
+			if err != nil {
+				return nil, fmt.Errorf("could not get %s; %w",
+					rel.parent.model.Table, err)
+			}
+			if len(parents) == 0 {
+				return nil, nil
+			}
+
+			return parents[0], nil
+		},
+	}
+}
+
+// listArgs returns the pagination arguments of a list of the model's rows,
+// and its filters.
+func listArgs(model *schema.Schema) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{
+		"limit": &graphql.ArgumentConfig{
+			Type:         graphql.Int,
+			DefaultValue: defaultLimit,
+			Description: fmt.Sprintf(
+				"How many rows to return, at most %d.", maxLimit,
+			),
+		},
+		"offset": &graphql.ArgumentConfig{
+			Type:         graphql.Int,
+			DefaultValue: 0,
+			Description:  "How many rows to skip.",
+		},
+	}
+	for _, column := range filterColumns {
+		if model.FieldsByDBName[column] != nil {
+			args[column] = &graphql.ArgumentConfig{Type: graphql.Int}
+		}
+	}
+
+	return args
+}
+
+// list returns a page of the model's rows matching the list's filters and
+// where, if given, in primary key order.
+func list(
+	database *db.Database,
+	model *schema.Schema,
+	p graphql.ResolveParams,
+	where clause.Expression,
+) ([]map[string]any, error) {
+	limit, _ := p.Args["limit"].(int)
+	if limit <= 0 || limit > maxLimit {
+		limit = maxLimit
+	}
+	offset, _ := p.Args["offset"].(int)
+
+	query := database.WithContext(p.Context).Table(model.Table)
+	if where != nil {
+		query = query.Where(where)
+	}
+	for _, column := range filterColumns {
+		if value, ok := p.Args[column]; ok {
+			query = query.Where(clause.Eq{Column: column, Value: value})
+		}
+	}
+	for _, field := range model.PrimaryFields {
+		query = query.Order(clause.OrderByColumn{
+			Column: clause.Column{Name: field.DBName},
+		})
+	}
+
+	var rows []map[string]any
+	err := query.Limit(limit).Offset(max(offset, 0)).Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not list %s; %w", model.Table, err)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,240 @@
+// Package identity resolves the athletes that the API's endpoints record
+// under different IDs, or under no ID at all, to one player each.
+package identity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// How a source record was linked to its player.
+const (
+	// MethodID records carry the player's athlete ID themselves.
+	MethodID = "id"
+	// MethodRecruitID records are listed among a roster player's recruit
+	// IDs.
+	MethodRecruitID = "recruit_id"
+	// MethodNameTeamYear records share their name and team with exactly one
+	// player seen with that team within yearWindow seasons.
+	MethodNameTeamYear = "name_team_year"
+	// MethodNameTeam records share their name and team with exactly one
+	// player, and no season rules the player out.
+	MethodNameTeam = "name_team"
+)
+
+// yearWindow is how many seasons apart a record and a player seen with the
+// same team may be for a name match.
+const yearWindow = 5
+
+// nameSuffixes are dropped when comparing names, since endpoints disagree
+// on whether to include them.
+var nameSuffixes = map[string]bool{
+	"jr": true, "sr": true, "ii": true, "iii": true, "iv": true, "v": true,
+}
+
+// Record is an athlete as one source table records them.
+type Record struct {
+	Source   string
+	SourceID string
+	// PlayerID and Method are set when the source identifies the player
+	// itself; the other records are matched to these by name.
+	PlayerID string
+	Method   string
+	Name     string
+	Position string
+	// Teams are the teams the athlete was with, most relevant first.
+	Teams []string
+	// Year is a season the athlete was with Teams, or zero if unknown.
+	Year int32
+}
+
+// Player is a resolved athlete, described by the first record of theirs.
+type Player struct {
+	ID       string
+	Name     string
+	Position string
+	Team     string
+}
+
+// Link maps a source record to its player.
+type Link struct {
+	Source   string
+	SourceID string
+	PlayerID string
+	Method   string
+}
+
+// Result is the outcome of Resolve.
+type Result struct {
+	Players []Player
+	Links   []Link
+	// Unresolved counts, per source, the records that matched no player or
+	// more than one.
+	Unresolved map[string]int
+}
+
+// candidate is a player a name and team may refer to, with the seasons the
+// player was seen with that team.
+type candidate struct {
+	id    string
+	years []int32
+}
+
+// Resolve links every record to a player. Records that identify their
+// player are linked as they are; the others are linked by their normalized
+// name and any of their teams to the single such player, ruling out players
+// only seen with the team more than yearWindow seasons from the record's
+// year. Records are given in order of preference, since a player is
+// described by their first record, and each source record is linked once.
+func Resolve(records []Record) Result {
+	result := Result{Unresolved: make(map[string]int)}
+	players := make(map[string]bool)
+	linked := make(map[[2]string]bool)
+	index := make(map[[2]string][]*candidate)
+
+	link := func(r Record, playerID, method string) {
+		key := [2]string{r.Source, r.SourceID}
+		if linked[key] {
+			return
+		}
+		linked[key] = true
+		result.Links = append(result.Links, Link{
+			Source:   r.Source,
+			SourceID: r.SourceID,
+			PlayerID: playerID,
+			Method:   method,
+		})
+	}
+
+	for _, r := range records {
+		if r.PlayerID == "" {
+			continue
+		}
+		if !players[r.PlayerID] {
+			players[r.PlayerID] = true
+			player := Player{ID: r.PlayerID, Name: r.Name, Position: r.Position}
+			if len(r.Teams) > 0 {
+				player.Team = r.Teams[0]
+			}
+			result.Players = append(result.Players, player)
+		}
+		link(r, r.PlayerID, r.Method)
+
+		name := normalize(r.Name)
+		for _, team := range r.Teams {
+			key := [2]string{name, normalize(team)}
+			c := findCandidate(index[key], r.PlayerID)
+			if c == nil {
+				c = &candidate{id: r.PlayerID}
+				index[key] = append(index[key], c)
+			}
+			if r.Year != 0 {
+				c.years = append(c.years, r.Year)
+			}
+		}
+	}
+
+	for _, r := range records {
+		if r.PlayerID != "" || linked[[2]string{r.Source, r.SourceID}] {
+			continue
+		}
+
+		playerID, method := match(r, index)
+		if playerID == "" {
+			result.Unresolved[r.Source]++
+			continue
+		}
+		link(r, playerID, method)
+	}
+
+	return result
+}
+
+// match returns the single player the record's name and teams refer to,
+// and how it was matched, or nothing if there is none or more than one.
+func match(r Record, index map[[2]string][]*candidate) (string, string) {
+	name := normalize(r.Name)
+	if name == "" {
+		return "", ""
+	}
+
+	var candidates []*candidate
+	for _, team := range r.Teams {
+		for _, c := range index[[2]string{name, normalize(team)}] {
+			if findCandidate(candidates, c.id) == nil {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+
+	if r.Year != 0 {
+		// Players seen only in other eras are ruled out, and players never
+		// seen with a season are a fallback.
+		var dated, undated []*candidate
+		for _, c := range candidates {
+			switch {
+			case len(c.years) == 0:
+				undated = append(undated, c)
+			case near(c.years, r.Year):
+				dated = append(dated, c)
+			}
+		}
+		if len(dated) > 0 {
+			if len(dated) == 1 {
+				return dated[0].id, MethodNameTeamYear
+			}
+			return "", ""
+		}
+		candidates = undated
+	}
+
+	if len(candidates) == 1 {
+		return candidates[0].id, MethodNameTeam
+	}
+
+	return "", ""
+}
+
+// findCandidate returns the candidate with the ID, or nil.
+func findCandidate(candidates []*candidate, id string) *candidate {
+	for _, c := range candidates {
+		if c.id == id {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// near reports whether any of the years is within yearWindow of year.
+func near(years []int32, year int32) bool {
+	for _, y := range years {
+		if y >= year-yearWindow && y <= year+yearWindow {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalize folds a name for comparison: lower case, without punctuation or
+// generational suffixes, and single spaced.
+func normalize(name string) string {
+	folded := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		case unicode.IsSpace(r) || r == '-':
+			return ' '
+		default:
+			return -1
+		}
+	}, name)
+
+	words := strings.Fields(folded)
+	for len(words) > 1 && nameSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,134 @@
+// Package notify posts a summary of each finished run to a chat or webhook
+// URL, so long backfills can be left unattended.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+)
+
+// postTimeout bounds how long a notification may take to deliver.
+const postTimeout = 10 * time.Second
+
+// maxFailedTasks caps how many failed tasks a message names.
+const maxFailedTasks = 5
+
+// Notifier posts run summaries to a webhook URL. Slack and Discord webhooks
+// receive a chat message; any other URL receives the RunSummary as JSON with
+// the same message in its "text" field.
+type Notifier struct {
+	url    string
+	client *http.Client
+}
+
+// New returns a notifier posting to the webhook URL.
+func New(webhookURL string) (*Notifier, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook url %q", webhookURL)
+	}
+
+	return &Notifier{
+		url:    webhookURL,
+		client: &http.Client{Timeout: postTimeout},
+	}, nil
+}
+
+// Notify posts the summary of a finished run. It runs even if ctx has been
+// cancelled, since a run interrupted by a signal is worth reporting too.
+func (n *Notifier) Notify(ctx context.Context, summary etl.RunSummary) error {
+	body, err := json.Marshal(n.payload(summary))
+	if err != nil {
+		return fmt.Errorf("could not encode notification; %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), postTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, n.url, bytes.NewReader(body),
+	)
+	if err != nil {
+		return fmt.Errorf("could not build notification request; %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not post notification; %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("notification rejected with status %d",
+			resp.StatusCode)
+	}
+
+	return nil
+}
+
+// payload returns the request body for the webhook's service.
+func (n *Notifier) payload(summary etl.RunSummary) any {
+	text := Message(summary)
+
+	switch {
+	case strings.Contains(n.url, "hooks.slack.com"):
+		return map[string]string{"text": text}
+	case strings.Contains(n.url, "discord.com/api/webhooks"),
+		strings.Contains(n.url, "discordapp.com/api/webhooks"):
+		return map[string]string{"content": text}
+	default:
+		return struct {
+			Text string `json:"text"`
+			etl.RunSummary
+		}{Text: text, RunSummary: summary}
+	}
+}
+
+// Message renders the summary as a short chat message: the outcome, rows
+// written, requests made and the tasks that failed.
+func Message(summary etl.RunSummary) string {
+	var b strings.Builder
+
+	elapsed := (time.Duration(summary.DurationMS) * time.Millisecond).
+		Round(time.Second)
+	fmt.Fprintf(&b, "cfbd seeder %s %s in %s", summary.Command,
+		summary.Status, elapsed)
+	if summary.RunID != 0 {
+		fmt.Fprintf(&b, " (run %d)", summary.RunID)
+	}
+	if summary.Error != "" {
+		fmt.Fprintf(&b, ": %s", summary.Error)
+	}
+
+	var failed []etl.TaskSummary
+	for _, task := range summary.Tasks {
+		if task.Status == etl.StatusFailed {
+			failed = append(failed, task)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n%d tasks run, %d failed; %d rows written, %d skipped",
+		len(summary.Tasks), len(failed), summary.Rows.Written(),
+		summary.Rows.Skipped)
+	fmt.Fprintf(&b, "; %d requests, %d failed",
+		summary.Requests, summary.RequestErrors)
+
+	for i, task := range failed {
+		if i == maxFailedTasks {
+			fmt.Fprintf(&b, "\n…and %d more", len(failed)-maxFailedTasks)
+			break
+		}
+		fmt.Fprintf(&b, "\n• %s: %s", task.Task, task.Error)
+	}
+
+	return b.String()
+}
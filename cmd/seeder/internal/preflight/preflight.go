@@ -0,0 +1,96 @@
+// Package preflight runs a set of checks before any seeding starts and
+// reports which passed, so misconfiguration is caught with a clear message
+// instead of failing deep into a run.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// checkTimeout bounds each check.
+const checkTimeout = 15 * time.Second
+
+// Check outcomes.
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+	StatusSkip = "skip"
+)
+
+// ErrSkipped is returned (wrapped) by a check that could not run because a
+// check it depends on failed.
+var ErrSkipped = errors.New("skipped")
+
+// Check is one preflight check. Fn returns a short description of what it
+// found, or an error explaining why the check failed.
+type Check struct {
+	Name string
+	Fn   func(ctx context.Context) (string, error)
+}
+
+// Result is the outcome of a single check.
+type Result struct {
+	Check  string `json:"check"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// Report is the outcome of every check, in the order they ran.
+type Report struct {
+	Passed  bool     `json:"passed"`
+	Results []Result `json:"results"`
+}
+
+// Run runs the checks in order. Later checks may depend on state set up by
+// earlier ones, so a check that cannot run should return ErrSkipped.
+func Run(ctx context.Context, checks []Check) Report {
+	report := Report{Passed: true, Results: make([]Result, 0, len(checks))}
+
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		detail, err := check.Fn(checkCtx)
+		cancel()
+
+		result := Result{Check: check.Name, Status: StatusPass, Detail: detail}
+		switch {
+		case errors.Is(err, ErrSkipped):
+			result.Status, result.Detail = StatusSkip, err.Error()
+			report.Passed = false
+		case err != nil:
+			result.Status, result.Detail = StatusFail, err.Error()
+			report.Passed = false
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
+
+// Write prints the report as an aligned table followed by the overall
+// outcome.
+func (r Report) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "check\tstatus\tdetail")
+	for _, result := range r.Results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n",
+			result.Check, result.Status, result.Detail)
+	}
+
+	outcome := "preflight passed"
+	if !r.Passed {
+		outcome = "preflight failed"
+	}
+	fmt.Fprintf(tw, "\n%s\n", outcome)
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write preflight report; %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,275 @@
+// Package ratings derives Elo and SRS team ratings from game results, so
+// that ratings can be kept current between the API's own updates.
+package ratings
+
+import (
+	"math"
+	"slices"
+)
+
+const (
+	// DefaultK is how far one game moves an Elo rating.
+	DefaultK = 20
+	// DefaultHomeAdvantage is the Elo points the home team is given outside
+	// neutral site games.
+	DefaultHomeAdvantage = 55
+	// DefaultRegression is the share of the distance to the mean Elo that a
+	// team's rating gives back between seasons.
+	DefaultRegression = 1.0 / 3
+	// DefaultInitial is the Elo rating of an FBS team with no previous
+	// rating.
+	DefaultInitial = 1500
+	// DefaultInitialFCS is the Elo rating of a team below FBS with no
+	// previous rating.
+	DefaultInitialFCS = 1200
+
+	// srsIterations bounds the iterations solving for SRS, which converges
+	// slowly when few games connect the teams.
+	srsIterations = 10000
+	// srsTolerance is the largest change in any rating at which SRS is
+	// considered solved.
+	srsTolerance = 1e-6
+	// fbs is the classification whose teams start at Params.Initial.
+	fbs = "fbs"
+)
+
+// Params configures the rating models. Zero values fall back to the
+// defaults.
+type Params struct {
+	K             float64
+	HomeAdvantage float64
+	Regression    float64
+	Initial       float64
+	InitialFCS    float64
+	// MarginCap caps the margin of victory SRS credits a game with, so that
+	// running up the score does not inflate a rating; zero leaves margins
+	// uncapped.
+	MarginCap float64
+}
+
+// DefaultParams returns the parameters used unless configured otherwise.
+func DefaultParams() Params {
+	return Params{
+		K:             DefaultK,
+		HomeAdvantage: DefaultHomeAdvantage,
+		Regression:    DefaultRegression,
+		Initial:       DefaultInitial,
+		InitialFCS:    DefaultInitialFCS,
+	}
+}
+
+// withDefaults returns p with its zero values replaced by the defaults.
+func (p Params) withDefaults() Params {
+	d := DefaultParams()
+	if p.K == 0 {
+		p.K = d.K
+	}
+	if p.HomeAdvantage == 0 {
+		p.HomeAdvantage = d.HomeAdvantage
+	}
+	if p.Regression == 0 {
+		p.Regression = d.Regression
+	}
+	if p.Initial == 0 {
+		p.Initial = d.Initial
+	}
+	if p.InitialFCS == 0 {
+		p.InitialFCS = d.InitialFCS
+	}
+
+	return p
+}
+
+// Game is the result of a completed game, in the order it was played.
+type Game struct {
+	ID                 int32
+	HomeTeam           string
+	AwayTeam           string
+	HomeClassification string
+	AwayClassification string
+	HomePoints         int32
+	AwayPoints         int32
+	NeutralSite        bool
+}
+
+// GameElo is both teams' Elo ratings before and after a game.
+type GameElo struct {
+	GameID      int32
+	HomePregame float64
+	AwayPregame float64
+	HomePost    float64
+	AwayPost    float64
+}
+
+// TeamRating is a team's ratings after the games of a season so far.
+type TeamRating struct {
+	Team  string
+	Games int
+	Elo   float64
+	// Margin is the team's average margin of victory.
+	Margin float64
+	// SOS is the strength of schedule: the average SRS of its opponents.
+	SOS float64
+	// SRS is the margin adjusted for the schedule, in points better than
+	// an average team.
+	SRS float64
+}
+
+// Season rates the games of a season, in the order they were played, and
+// returns every game's Elo ratings and every team's ratings after the last
+// game. Teams start from their rating in previous, regressed toward the
+// mean between seasons, or from the initial rating of their
+// classification.
+func Season(
+	games []Game,
+	previous map[string]float64,
+	params Params,
+) ([]GameElo, []TeamRating) {
+	params = params.withDefaults()
+
+	elo := make(map[string]float64)
+	rating := func(team, classification string) float64 {
+		if r, ok := elo[team]; ok {
+			return r
+		}
+		r, ok := previous[team]
+		switch {
+		case ok:
+			r -= (r - params.Initial) * params.Regression
+		case classification == fbs:
+			r = params.Initial
+		default:
+			r = params.InitialFCS
+		}
+		elo[team] = r
+
+		return r
+	}
+
+	gameElo := make([]GameElo, 0, len(games))
+	for _, g := range games {
+		home := rating(g.HomeTeam, g.HomeClassification)
+		away := rating(g.AwayTeam, g.AwayClassification)
+		delta := eloChange(home, away, g, params)
+		elo[g.HomeTeam], elo[g.AwayTeam] = home+delta, away-delta
+
+		gameElo = append(gameElo, GameElo{
+			GameID:      g.ID,
+			HomePregame: home,
+			AwayPregame: away,
+			HomePost:    home + delta,
+			AwayPost:    away - delta,
+		})
+	}
+
+	teams := srs(games, params.MarginCap)
+	for i := range teams {
+		teams[i].Elo = elo[teams[i].Team]
+	}
+
+	return gameElo, teams
+}
+
+// eloChange returns the points the home team gains from the game, and the
+// away team loses. The change grows with the margin of victory, less so for
+// a heavy favorite that wins big, as in FiveThirtyEight's model.
+func eloChange(home, away float64, g Game, params Params) float64 {
+	diff := home - away
+	if !g.NeutralSite {
+		diff += params.HomeAdvantage
+	}
+	expected := 1 / (1 + math.Pow(10, -diff/400))
+
+	margin := float64(g.HomePoints - g.AwayPoints)
+	result, multiplier := 0.5, 1.0
+	if margin != 0 {
+		result = 0.0
+		winnerDiff := -diff
+		if margin > 0 {
+			result, winnerDiff = 1.0, diff
+		}
+		multiplier = math.Log(math.Abs(margin)+1) *
+			2.2 / (winnerDiff*0.001 + 2.2)
+	}
+
+	return params.K * multiplier * (result - expected)
+}
+
+// srs solves for every team's simple rating system rating: its average
+// margin plus the average rating of its opponents, centered so that the
+// average team is zero.
+func srs(games []Game, marginCap float64) []TeamRating {
+	margins := make(map[string][]float64)
+	opponents := make(map[string][]string)
+	for _, g := range games {
+		margin := float64(g.HomePoints - g.AwayPoints)
+		if marginCap > 0 {
+			margin = math.Max(-marginCap, math.Min(marginCap, margin))
+		}
+		margins[g.HomeTeam] = append(margins[g.HomeTeam], margin)
+		margins[g.AwayTeam] = append(margins[g.AwayTeam], -margin)
+		opponents[g.HomeTeam] = append(opponents[g.HomeTeam], g.AwayTeam)
+		opponents[g.AwayTeam] = append(opponents[g.AwayTeam], g.HomeTeam)
+	}
+
+	teams := make([]string, 0, len(margins))
+	average := make(map[string]float64, len(margins))
+	for team, m := range margins {
+		teams = append(teams, team)
+		average[team] = mean(m)
+	}
+	slices.Sort(teams)
+
+	rating := make(map[string]float64, len(teams))
+	for team := range average {
+		rating[team] = average[team]
+	}
+	for range srsIterations {
+		next := make(map[string]float64, len(teams))
+		var total float64
+		for _, team := range teams {
+			var sos float64
+			for _, opponent := range opponents[team] {
+				sos += rating[opponent]
+			}
+			// Moving halfway to the new rating keeps the iteration from
+			// oscillating between teams that only play each other.
+			solved := average[team] + sos/float64(len(opponents[team]))
+			next[team] = (rating[team] + solved) / 2
+			total += next[team]
+		}
+
+		var change float64
+		for _, team := range teams {
+			next[team] -= total / float64(len(teams))
+			change = math.Max(change, math.Abs(next[team]-rating[team]))
+		}
+		rating = next
+		if change < srsTolerance {
+			break
+		}
+	}
+
+	ratings := make([]TeamRating, 0, len(teams))
+	for _, team := range teams {
+		ratings = append(ratings, TeamRating{
+			Team:   team,
+			Games:  len(opponents[team]),
+			Margin: average[team],
+			SOS:    rating[team] - average[team],
+			SRS:    rating[team],
+		})
+	}
+
+	return ratings
+}
+
+// mean returns the average of values, which must not be empty.
+func mean(values []float64) float64 {
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+
+	return total / float64(len(values))
+}
@@ -0,0 +1,182 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/utils"
+	"github.com/clintrovert/cfbd-go/cfbd"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// gameMessage returns the cfbd message of a games row.
+func gameMessage(g *db.Game) *cfbd.Game {
+	var startDate *timestamppb.Timestamp
+	if g.StartDate != nil {
+		startDate = timestamppb.New(*g.StartDate)
+	}
+
+	return &cfbd.Game{
+		Id:                         g.ID,
+		Season:                     g.Season,
+		Week:                       g.Week,
+		SeasonType:                 g.SeasonType,
+		StartDate:                  startDate,
+		StartTime_TBD:              g.StartTimeTBD,
+		Completed:                  g.Completed,
+		NeutralSite:                g.NeutralSite,
+		ConferenceGame:             g.ConferenceGame,
+		Attendance:                 g.Attendance,
+		VenueId:                    g.VenueID,
+		Venue:                      g.Venue,
+		HomeId:                     g.HomeID,
+		HomeTeam:                   g.HomeTeam,
+		HomeConference:             g.HomeConference,
+		HomeClassification:         g.HomeClassification,
+		HomePoints:                 g.HomePoints,
+		HomeLineScores:             utils.Int64ArrayToInt32Slice(g.HomeLineScores),
+		HomePostgameWinProbability: g.HomePostWinProbability,
+		HomePregameElo:             g.HomePregameElo,
+		HomePostgameElo:            g.HomePostgameElo,
+		AwayId:                     g.AwayID,
+		AwayTeam:                   g.AwayTeam,
+		AwayConference:             g.AwayConference,
+		AwayClassification:         g.AwayClassification,
+		AwayPoints:                 g.AwayPoints,
+		AwayLineScores:             utils.Int64ArrayToInt32Slice(g.AwayLineScores),
+		AwayPostgameWinProbability: g.AwayPostWinProbability,
+		AwayPregameElo:             g.AwayPregameElo,
+		AwayPostgameElo:            g.AwayPostgameElo,
+		ExcitementIndex:            g.ExcitementIndex,
+		Highlights:                 g.Highlights,
+		Notes:                      g.Notes,
+	}
+}
+
+// playMessage returns the cfbd message of a plays row.
+func playMessage(p *db.Play) *cfbd.Play {
+	var clock *cfbd.ClockInt32
+	if p.ClockMinutes != nil || p.ClockSeconds != nil {
+		clock = &cfbd.ClockInt32{
+			Minutes: p.ClockMinutes,
+			Seconds: p.ClockSeconds,
+		}
+	}
+
+	return &cfbd.Play{
+		Id:                p.ID,
+		DriveId:           p.DriveID,
+		GameId:            p.GameID,
+		DriveNumber:       p.DriveNumber,
+		PlayNumber:        p.PlayNumber,
+		Offense:           p.Offense,
+		OffenseConference: p.OffenseConference,
+		OffenseScore:      p.OffenseScore,
+		Defense:           p.Defense,
+		Home:              p.Home,
+		Away:              p.Away,
+		DefenseConference: p.DefenseConference,
+		DefenseScore:      p.DefenseScore,
+		Period:            p.Period,
+		Clock:             clock,
+		OffenseTimeouts:   p.OffenseTimeouts,
+		DefenseTimeouts:   p.DefenseTimeouts,
+		Yardline:          p.Yardline,
+		YardsToGoal:       p.YardsToGoal,
+		Down:              p.Down,
+		Distance:          p.Distance,
+		YardsGained:       p.YardsGained,
+		Scoring:           p.Scoring,
+		PlayType:          p.PlayType,
+		PlayText:          p.PlayText,
+		Ppa:               p.PPA,
+		Wallclock:         p.Wallclock,
+	}
+}
+
+// bettingGameMessage returns the cfbd message of a betting_games row and
+// its game_lines.
+func bettingGameMessage(g *db.BettingGame) *cfbd.BettingGame {
+	var startDate *timestamppb.Timestamp
+	if g.StartDate != nil {
+		startDate = timestamppb.New(*g.StartDate)
+	}
+
+	lines := make([]*cfbd.GameLine, 0, len(g.Lines))
+	for _, l := range g.Lines {
+		lines = append(lines, &cfbd.GameLine{
+			Provider:        l.Provider,
+			Spread:          l.Spread,
+			FormattedSpread: l.FormattedSpread,
+			SpreadOpen:      l.SpreadOpen,
+			OverUnder:       l.OverUnder,
+			OverUnderOpen:   l.OverUnderOpen,
+			HomeMoneyline:   l.HomeMoneyline,
+			AwayMoneyline:   l.AwayMoneyline,
+		})
+	}
+
+	return &cfbd.BettingGame{
+		Id:                 g.ID,
+		Season:             g.Season,
+		SeasonType:         g.SeasonType,
+		Week:               g.Week,
+		StartDate:          startDate,
+		HomeTeamId:         g.HomeTeamID,
+		HomeTeam:           g.HomeTeam,
+		HomeConference:     g.HomeConference,
+		HomeClassification: g.HomeClassification,
+		HomeScore:          g.HomeScore,
+		AwayTeamId:         g.AwayTeamID,
+		AwayTeam:           g.AwayTeam,
+		AwayConference:     g.AwayConference,
+		AwayClassification: g.AwayClassification,
+		AwayScore:          g.AwayScore,
+		Lines:              lines,
+	}
+}
+
+// srsMessage returns the cfbd message of a team_srs row.
+func srsMessage(r *db.TeamSRS) *cfbd.TeamSRS {
+	return &cfbd.TeamSRS{
+		Year:       r.Year,
+		Team:       r.Team,
+		Conference: r.Conference,
+		Division:   r.Division,
+		Rating:     r.Rating,
+		Ranking:    r.Ranking,
+	}
+}
+
+// eloMessage returns the cfbd message of a team_elo row.
+func eloMessage(r *db.TeamElo) *cfbd.TeamElo {
+	return &cfbd.TeamElo{
+		Year:       r.Year,
+		Team:       r.Team,
+		Conference: r.Conference,
+		Elo:        r.Elo,
+	}
+}
+
+// spMessage returns the cfbd message of a team_sp row, decoded from its
+// payload.
+func spMessage(r *db.TeamSP) (*cfbd.TeamSP, error) {
+	var sp cfbd.TeamSP
+	if err := json.Unmarshal(r.Payload, &sp); err != nil {
+		return nil, fmt.Errorf("could not decode sp+ of %s; %w", r.Team, err)
+	}
+
+	return &sp, nil
+}
+
+// fpiMessage returns the cfbd message of a team_fpi row, decoded from its
+// payload.
+func fpiMessage(r *db.TeamFPI) (*cfbd.TeamFPI, error) {
+	var fpi cfbd.TeamFPI
+	if err := json.Unmarshal(r.Payload, &fpi); err != nil {
+		return nil, fmt.Errorf("could not decode fpi of %s; %w", r.Team, err)
+	}
+
+	return &fpi, nil
+}
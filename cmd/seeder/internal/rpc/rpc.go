@@ -0,0 +1,314 @@
+// Package rpc serves the key read paths of the seeded database (games, plays,
+// ratings and betting lines) over gRPC for other services, answering with
+// the cfbd protobuf types of the client library. The service is defined in
+// pb/seeder.proto.
+package rpc
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/rpc/pb"
+	"github.com/clintrovert/cfbd-go/cfbd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// batchSize is how many rows are read from the database at a time while
+// streaming them.
+const batchSize = 500
+
+// service implements the Seeder gRPC service against the database.
+type service struct {
+	pb.UnimplementedSeederServer
+
+	database *db.Database
+}
+
+// NewServer returns a gRPC server serving the Seeder service against the
+// database, along with server reflection so that tools like grpcurl can
+// discover it.
+func NewServer(database *db.Database) *grpc.Server {
+	srv := grpc.NewServer()
+	pb.RegisterSeederServer(srv, &service{database: database})
+	reflection.Register(srv)
+
+	return srv
+}
+
+// GetGames streams the games matching the request, in id order.
+func (s *service) GetGames(
+	req *pb.GetGamesRequest,
+	stream grpc.ServerStreamingServer[cfbd.Game],
+) error {
+	if req.GetYear() == 0 && req.GetGameId() == 0 {
+		return status.Error(codes.InvalidArgument,
+			"year or game_id is required")
+	}
+
+	query := s.gameQuery(stream, req.GetGameId(), req.GetYear(),
+		req.GetWeek(), req.GetSeasonType())
+	if team := req.GetTeam(); team != "" {
+		query = query.Where(s.database.
+			Where(clause.Eq{Column: "home_team", Value: team}).
+			Or(clause.Eq{Column: "away_team", Value: team}))
+	}
+
+	var games []db.Game
+	err := query.FindInBatches(&games, batchSize,
+		func(*gorm.DB, int) error {
+			for i := range games {
+				if err := stream.Send(gameMessage(&games[i])); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return status.Errorf(codes.Internal, "could not list games; %v", err)
+	}
+
+	return nil
+}
+
+// GetPlays streams the plays of the games matching the request, in id
+// order.
+func (s *service) GetPlays(
+	req *pb.GetPlaysRequest,
+	stream grpc.ServerStreamingServer[cfbd.Play],
+) error {
+	if req.GetYear() == 0 && req.GetGameId() == 0 {
+		return status.Error(codes.InvalidArgument,
+			"year or game_id is required")
+	}
+
+	// Plays carry a season but no week or season type, so they are matched
+	// to the games requested by id.
+	query := s.database.WithContext(stream.Context()).Model(&db.Play{})
+	if req.GetYear() != 0 {
+		query = query.Where(clause.Eq{Column: "season", Value: req.GetYear()})
+	}
+	if req.GetWeek() != 0 || req.GetSeasonType() != "" {
+		games := s.gameQuery(stream, 0, req.GetYear(), req.GetWeek(),
+			req.GetSeasonType()).Select("id")
+		query = query.Where("game_id IN (?)", games)
+	}
+	if gameID := req.GetGameId(); gameID != 0 {
+		query = query.Where(clause.Eq{Column: "game_id", Value: gameID})
+	}
+	if team := req.GetTeam(); team != "" {
+		query = query.Where(s.database.
+			Where(clause.Eq{Column: "offense", Value: team}).
+			Or(clause.Eq{Column: "defense", Value: team}))
+	}
+
+	var plays []db.Play
+	err := query.FindInBatches(&plays, batchSize,
+		func(*gorm.DB, int) error {
+			for i := range plays {
+				if err := stream.Send(playMessage(&plays[i])); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return status.Errorf(codes.Internal, "could not list plays; %v", err)
+	}
+
+	return nil
+}
+
+// GetLines streams the betting lines of the games matching the request, in
+// game id order.
+func (s *service) GetLines(
+	req *pb.GetLinesRequest,
+	stream grpc.ServerStreamingServer[cfbd.BettingGame],
+) error {
+	if req.GetYear() == 0 && req.GetGameId() == 0 {
+		return status.Error(codes.InvalidArgument,
+			"year or game_id is required")
+	}
+
+	query := s.database.WithContext(stream.Context()).
+		Model(&db.BettingGame{}).
+		Preload("Lines", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("provider")
+		})
+	if gameID := req.GetGameId(); gameID != 0 {
+		query = query.Where(clause.Eq{Column: "id", Value: gameID})
+	}
+	query = seasonFilter(query, req.GetYear(), req.GetWeek(),
+		req.GetSeasonType())
+	if team := req.GetTeam(); team != "" {
+		query = query.Where(s.database.
+			Where(clause.Eq{Column: "home_team", Value: team}).
+			Or(clause.Eq{Column: "away_team", Value: team}))
+	}
+
+	var games []db.BettingGame
+	err := query.FindInBatches(&games, batchSize,
+		func(*gorm.DB, int) error {
+			for i := range games {
+				err := stream.Send(bettingGameMessage(&games[i]))
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return status.Errorf(codes.Internal, "could not list lines; %v", err)
+	}
+
+	return nil
+}
+
+// GetRatings streams the SP+, SRS, Elo and FPI ratings of the teams
+// matching the request, in team order.
+func (s *service) GetRatings(
+	req *pb.GetRatingsRequest,
+	stream grpc.ServerStreamingServer[pb.TeamRatings],
+) error {
+	if req.GetYear() == 0 {
+		return status.Error(codes.InvalidArgument, "year is required")
+	}
+
+	ratings := make(map[string]*pb.TeamRatings)
+	team := func(year int32, name, conference string) *pb.TeamRatings {
+		r, ok := ratings[name]
+		if !ok {
+			r = &pb.TeamRatings{Year: year, Team: name}
+			ratings[name] = r
+		}
+		if r.Conference == "" {
+			r.Conference = conference
+		}
+		return r
+	}
+
+	var srs []db.TeamSRS
+	if err := s.ratingQuery(stream, req).Find(&srs).Error; err != nil {
+		return status.Errorf(codes.Internal, "could not list srs; %v", err)
+	}
+	for i := range srs {
+		team(srs[i].Year, srs[i].Team, srs[i].Conference).Srs =
+			srsMessage(&srs[i])
+	}
+
+	var elo []db.TeamElo
+	if err := s.ratingQuery(stream, req).Find(&elo).Error; err != nil {
+		return status.Errorf(codes.Internal, "could not list elo; %v", err)
+	}
+	for i := range elo {
+		team(elo[i].Year, elo[i].Team, elo[i].Conference).Elo =
+			eloMessage(&elo[i])
+	}
+
+	var sp []db.TeamSP
+	if err := s.ratingQuery(stream, req).Find(&sp).Error; err != nil {
+		return status.Errorf(codes.Internal, "could not list sp+; %v", err)
+	}
+	for i := range sp {
+		if len(sp[i].Payload) == 0 {
+			continue
+		}
+		msg, err := spMessage(&sp[i])
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		team(sp[i].Year, sp[i].Team, sp[i].Conference).Sp = msg
+	}
+
+	var fpi []db.TeamFPI
+	if err := s.ratingQuery(stream, req).Find(&fpi).Error; err != nil {
+		return status.Errorf(codes.Internal, "could not list fpi; %v", err)
+	}
+	for i := range fpi {
+		if len(fpi[i].Payload) == 0 {
+			continue
+		}
+		msg, err := fpiMessage(&fpi[i])
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		team(fpi[i].Year, fpi[i].Team, fpi[i].Conference).Fpi = msg
+	}
+
+	teams := make([]*pb.TeamRatings, 0, len(ratings))
+	for _, r := range ratings {
+		teams = append(teams, r)
+	}
+	slices.SortFunc(teams, func(a, b *pb.TeamRatings) int {
+		return cmp.Compare(a.GetTeam(), b.GetTeam())
+	})
+
+	for _, r := range teams {
+		if err := stream.Send(r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// gameQuery returns a query of the games with the id, or else of the
+// season, week and season type, each ignored where zero.
+func (s *service) gameQuery(
+	stream grpc.ServerStream,
+	gameID int32,
+	year int32,
+	week int32,
+	seasonType string,
+) *gorm.DB {
+	query := s.database.WithContext(stream.Context()).Model(&db.Game{})
+	if gameID != 0 {
+		query = query.Where(clause.Eq{Column: "id", Value: gameID})
+	}
+
+	return seasonFilter(query, year, week, seasonType)
+}
+
+// ratingQuery returns a query of the ratings of the requested year, team
+// and conference, for a ratings table given by Find.
+func (s *service) ratingQuery(
+	stream grpc.ServerStream,
+	req *pb.GetRatingsRequest,
+) *gorm.DB {
+	query := s.database.WithContext(stream.Context()).
+		Where(clause.Eq{Column: "year", Value: req.GetYear()})
+	if team := req.GetTeam(); team != "" {
+		query = query.Where(clause.Eq{Column: "team", Value: team})
+	}
+	if conference := req.GetConference(); conference != "" {
+		query = query.Where(clause.Eq{Column: "conference", Value: conference})
+	}
+
+	return query
+}
+
+// seasonFilter filters query by season, week and season type, each ignored
+// where zero.
+func seasonFilter(
+	query *gorm.DB,
+	year int32,
+	week int32,
+	seasonType string,
+) *gorm.DB {
+	if year != 0 {
+		query = query.Where(clause.Eq{Column: "season", Value: year})
+	}
+	if week != 0 {
+		query = query.Where(clause.Eq{Column: "week", Value: week})
+	}
+	if seasonType != "" {
+		query = query.Where(clause.Eq{Column: "season_type", Value: seasonType})
+	}
+
+	return query
+}
@@ -0,0 +1,204 @@
+// Package schedule runs tasks on cron schedules for the seeder's daemon
+// mode.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCron is returned when a cron expression cannot be parsed.
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+// maxSearchYears bounds how far ahead Next looks for a matching time, so
+// that expressions which can never match (e.g. February 30th) terminate.
+const maxSearchYears = 5
+
+// cronField is the allowed range of one field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	domField    = cronField{name: "day of month", min: 1, max: 31}
+	monthField  = cronField{
+		name: "month", min: 1, max: 12, names: map[string]int{
+			"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+			"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+		},
+	}
+	// Both 0 and 7 are Sunday.
+	dowField = cronField{
+		name: "day of week", min: 0, max: 7, names: map[string]int{
+			"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5,
+			"sat": 6,
+		},
+	}
+)
+
+// Cron is a parsed five-field cron expression: minute, hour, day of month,
+// month and day of week. Each field accepts *, numbers, names (jan, sat),
+// ranges (1-5), lists (1,15) and steps (*/2, 0-30/10). As in standard cron,
+// when both day fields are restricted a time matches if either does.
+type Cron struct {
+	expr                          string
+	minute, hour, dom, month, dow uint64
+	domRestricted, dowRestricted  bool
+}
+
+// ParseCron parses a five-field cron expression, e.g. "*/2 * * * sat" for
+// every two minutes on Saturdays.
+func ParseCron(expr string) (Cron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Cron{}, fmt.Errorf(
+			"%w: %q must have 5 fields", ErrInvalidCron, expr,
+		)
+	}
+
+	c := Cron{expr: expr}
+	var err error
+	if c.minute, err = parseField(fields[0], minuteField); err != nil {
+		return Cron{}, err
+	}
+	if c.hour, err = parseField(fields[1], hourField); err != nil {
+		return Cron{}, err
+	}
+	if c.dom, err = parseField(fields[2], domField); err != nil {
+		return Cron{}, err
+	}
+	if c.month, err = parseField(fields[3], monthField); err != nil {
+		return Cron{}, err
+	}
+	if c.dow, err = parseField(fields[4], dowField); err != nil {
+		return Cron{}, err
+	}
+
+	// Sunday may be written as 7; fold it onto 0.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+
+	c.domRestricted = !strings.HasPrefix(fields[2], "*")
+	c.dowRestricted = !strings.HasPrefix(fields[4], "*")
+
+	return c, nil
+}
+
+// String returns the expression the Cron was parsed from.
+func (c Cron) String() string {
+	return c.expr
+}
+
+// Next returns the first time after t, truncated to the minute, that the
+// expression matches. It returns the zero time if nothing matches within
+// the next few years.
+func (c Cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if !has(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(c.hour, t.Hour()) {
+			t = time.Date(
+				t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location(),
+			)
+			continue
+		}
+		if !has(c.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (c Cron) dayMatches(t time.Time) bool {
+	dom := has(c.dom, t.Day())
+	dow := has(c.dow, int(t.Weekday()))
+
+	if c.domRestricted && c.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
+
+// parseField parses one comma separated cron field into a bit set of the
+// values it matches.
+func parseField(spec string, field cronField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(spec, ",") {
+		rangeSpec, stepSpec, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepSpec)
+			if err != nil || step <= 0 {
+				return 0, invalidField(field, part)
+			}
+		}
+
+		lo, hi := field.min, field.max
+		if rangeSpec != "*" {
+			first, last, isRange := strings.Cut(rangeSpec, "-")
+			var err error
+			if lo, err = field.value(first); err != nil {
+				return 0, invalidField(field, part)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = field.value(last); err != nil || hi < lo {
+					return 0, invalidField(field, part)
+				}
+			} else if hasStep {
+				// "5/15" means every 15 starting at 5.
+				hi = field.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
+
+// value parses a single number or name within the field's range.
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, ErrInvalidCron
+	}
+
+	return v, nil
+}
+
+func invalidField(field cronField, part string) error {
+	return fmt.Errorf("%w: bad %s %q", ErrInvalidCron, field.name, part)
+}
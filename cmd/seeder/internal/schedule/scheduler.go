@@ -0,0 +1,98 @@
+package schedule
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Job is a named task run whenever its cron expression matches. Run is
+// cancelled when the scheduler stops.
+type Job struct {
+	Name string
+	Cron Cron
+	Run  func(ctx context.Context) error
+}
+
+// Scheduler runs jobs on their cron schedules until its context is done.
+//
+// A job never overlaps itself: if it is still running when its next time
+// comes around, that run is skipped rather than queued, so a slow refresh
+// cannot pile up behind itself. Different jobs may run concurrently.
+type Scheduler struct {
+	jobs []Job
+	// OnTick, if set, is called every time a job comes due, whether or not
+	// it runs, so health checks can tell the scheduler is still alive.
+	OnTick func()
+}
+
+// New returns a Scheduler for the given jobs.
+func New(jobs []Job) *Scheduler {
+	return &Scheduler{jobs: jobs}
+}
+
+// Run schedules every job and blocks until ctx is done and all running jobs
+// have returned. Job failures are logged and do not stop the scheduler.
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.loop(ctx, job, &wg)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// loop waits for each of the job's fire times and starts a run unless the
+// previous one is still going.
+func (s *Scheduler) loop(ctx context.Context, job Job, wg *sync.WaitGroup) {
+	var running atomic.Bool
+
+	for {
+		next := job.Cron.Next(time.Now())
+		if next.IsZero() {
+			slog.Warn("schedule never fires", "task", job.Name,
+				"cron", job.Cron.String())
+			return
+		}
+		slog.Info("task scheduled", "task", job.Name, "next", next)
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if s.OnTick != nil {
+			s.OnTick()
+		}
+
+		if !running.CompareAndSwap(false, true) {
+			slog.Warn("previous run still in progress; skipping",
+				"task", job.Name)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer running.Store(false)
+
+			started := time.Now()
+			if err := job.Run(ctx); err != nil {
+				slog.Error("scheduled task failed", "task", job.Name,
+					"err", err)
+				return
+			}
+			slog.Info("scheduled task complete", "task", job.Name,
+				"elapsed", time.Since(started).Round(time.Millisecond))
+		}()
+	}
+}
@@ -0,0 +1,85 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+)
+
+// ComputeDriveStats aggregates the explosive plays, success rate and EPA of
+// every drive of each selected season from its plays into drive_stats, so
+// that drive analysis does not have to scan the plays again. It makes no
+// API requests.
+func (s *Seeder) ComputeDriveStats(ctx context.Context) error {
+	var total int64
+	for _, year := range s.years {
+		if err := etl.Err(ctx); err != nil {
+			return err
+		}
+
+		stored, err := s.db.ComputeDriveStats(ctx, year)
+		if err != nil {
+			slog.Error(
+				"failed to compute drive stats",
+				"year", int32ToString(year),
+				"err", err,
+			)
+			return fmt.Errorf(
+				"failed to compute drive stats for %d; %w", year, err,
+			)
+		}
+		total += stored
+	}
+
+	slog.Info("computed drive stats", "years", len(s.years), "drives", total)
+	return nil
+}
+
+// ComputeTeamWeekStats aggregates every team's EPA per play, success rate,
+// pace and explosiveness, split by down type, in each week of each selected
+// season from the plays into team_week_stats. It makes no API requests.
+func (s *Seeder) ComputeTeamWeekStats(ctx context.Context) error {
+	var total int64
+	for _, year := range s.years {
+		if err := etl.Err(ctx); err != nil {
+			return err
+		}
+
+		stored, err := s.db.ComputeTeamWeekStats(ctx, year)
+		if err != nil {
+			slog.Error(
+				"failed to compute team week stats",
+				"year", int32ToString(year),
+				"err", err,
+			)
+			return fmt.Errorf(
+				"failed to compute team week stats for %d; %w", year, err,
+			)
+		}
+		total += stored
+	}
+
+	slog.Info("computed team week stats",
+		"years", len(s.years), "team_weeks", total)
+	return nil
+}
+
+// refreshPlayAggregates recomputes the play flags of a season, and the
+// drive and team week aggregates derived from them, after its plays have
+// been loaded.
+func (s *Seeder) refreshPlayAggregates(
+	ctx context.Context,
+	season int32,
+) error {
+	if _, err := s.db.ComputePlayFlags(ctx, season); err != nil {
+		return err
+	}
+	if _, err := s.db.ComputeDriveStats(ctx, season); err != nil {
+		return err
+	}
+	_, err := s.db.ComputeTeamWeekStats(ctx, season)
+
+	return err
+}
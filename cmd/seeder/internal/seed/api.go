@@ -0,0 +1,173 @@
+package seed
+
+import (
+	"context"
+
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+//go:generate mockgen -source=api.go -destination=mocks/api.go -package=mocks
+
+// CFBDAPI is the part of the cfbd client the seeder calls, implemented by
+// *cfbd.Client. Tests substitute the generated mocks.MockCFBDAPI to control
+// responses without a key or quota.
+type CFBDAPI interface {
+	GetAdvancedBoxScore(
+		ctx context.Context,
+		request cfbd.GetAdvancedBoxScoreRequest,
+	) (*cfbd.AdvancedBoxScore, error)
+	GetBettingLines(
+		ctx context.Context,
+		request cfbd.GetBettingLinesRequest,
+	) ([]*cfbd.BettingGame, error)
+	GetCalendar(
+		ctx context.Context,
+		request cfbd.GetCalendarRequest,
+	) ([]*cfbd.CalendarWeek, error)
+	GetConferenceSPPlusRatings(
+		ctx context.Context,
+		request cfbd.GetConferenceSPPlusRatingsRequest,
+	) ([]*cfbd.ConferenceSP, error)
+	GetConferences(ctx context.Context) ([]*cfbd.Conference, error)
+	GetDraftPicks(
+		ctx context.Context,
+		request cfbd.GetDraftPicksRequest,
+	) ([]*cfbd.DraftPick, error)
+	GetDraftPositions(ctx context.Context) ([]*cfbd.DraftPosition, error)
+	GetDraftTeams(ctx context.Context) ([]*cfbd.DraftTeam, error)
+	GetDrives(
+		ctx context.Context,
+		request cfbd.GetDrivesRequest,
+	) ([]*cfbd.Drive, error)
+	GetEloRatings(
+		ctx context.Context,
+		request cfbd.GetEloRatingsRequest,
+	) ([]*cfbd.TeamElo, error)
+	GetFPIRatings(
+		ctx context.Context,
+		request cfbd.GetFPIRatingsRequest,
+	) ([]*cfbd.TeamFPI, error)
+	GetFieldGoalExpectedPoints(ctx context.Context) ([]*cfbd.FieldGoalEP, error)
+	GetGameMedia(
+		ctx context.Context,
+		request cfbd.GetGameMediaRequest,
+	) ([]*cfbd.GameMedia, error)
+	GetGamePlayers(
+		ctx context.Context,
+		request cfbd.GetGamePlayersRequest,
+	) ([]*cfbd.GamePlayerStats, error)
+	GetGameTeams(
+		ctx context.Context,
+		request cfbd.GetGameTeamsRequest,
+	) ([]*cfbd.GameTeamStats, error)
+	GetGameWeather(
+		ctx context.Context,
+		request cfbd.GetGameWeatherRequest,
+	) ([]*cfbd.GameWeather, error)
+	GetGames(
+		ctx context.Context,
+		request cfbd.GetGamesRequest,
+	) ([]*cfbd.Game, error)
+	GetInfo(ctx context.Context) (*cfbd.UserInfo, error)
+	GetLivePlays(
+		ctx context.Context,
+		request cfbd.GetLivePlaysRequest,
+	) (*cfbd.LiveGame, error)
+	GetPlayStats(
+		ctx context.Context,
+		request cfbd.GetPlayStatsRequest,
+	) ([]*cfbd.PlayStat, error)
+	GetPlayTypes(ctx context.Context) ([]*cfbd.PlayType, error)
+	GetPlayerKickingWEPA(
+		ctx context.Context,
+		request cfbd.GetWepaPlayersKickingRequest,
+	) ([]*cfbd.KickerPAAR, error)
+	GetPlayerPassingWEPA(
+		ctx context.Context,
+		request cfbd.GetPlayerWEPARequest,
+	) ([]*cfbd.PlayerWeightedEPA, error)
+	GetPlayerRecruitingRankings(
+		ctx context.Context,
+		request cfbd.GetPlayersRecruitingRankingsRequest,
+	) ([]*cfbd.Recruit, error)
+	GetPlayerRushingWEPA(
+		ctx context.Context,
+		request cfbd.GetPlayerWEPARequest,
+	) ([]*cfbd.PlayerWeightedEPA, error)
+	GetPlayerSeasonStats(
+		ctx context.Context,
+		request cfbd.GetPlayerSeasonStatsRequest,
+	) ([]*cfbd.PlayerStat, error)
+	GetPlays(
+		ctx context.Context,
+		request cfbd.GetPlaysRequest,
+	) ([]*cfbd.Play, error)
+	GetRankings(
+		ctx context.Context,
+		request cfbd.GetRankingsRequest,
+	) ([]*cfbd.PollWeek, error)
+	GetReturningProduction(
+		ctx context.Context,
+		request cfbd.GetReturningProductionRequest,
+	) ([]*cfbd.ReturningProduction, error)
+	GetRoster(
+		ctx context.Context,
+		request cfbd.GetRosterRequest,
+	) ([]*cfbd.RosterPlayer, error)
+	GetSRSRatings(
+		ctx context.Context,
+		request cfbd.GetSRSRatingsRequest,
+	) ([]*cfbd.TeamSRS, error)
+	GetScoreboard(
+		ctx context.Context,
+		request cfbd.GetScoreboardRequest,
+	) ([]*cfbd.Scoreboard, error)
+	GetStatCategories(ctx context.Context) ([]string, error)
+	GetTeamATS(
+		ctx context.Context,
+		request cfbd.GetTeamATSRequest,
+	) ([]*cfbd.TeamATS, error)
+	GetTeamPositionGroupRecruitingRankings(
+		ctx context.Context,
+		request cfbd.GetTeamPositionGroupRecruitingRankingsRequest,
+	) ([]*cfbd.AggregatedTeamRecruiting, error)
+	GetTeamRecords(
+		ctx context.Context,
+		request cfbd.GetTeamRecordsRequest,
+	) ([]*cfbd.TeamRecords, error)
+	GetTeamRecruitingRankings(
+		ctx context.Context,
+		request cfbd.GetTeamRecruitingRankingsRequest,
+	) ([]*cfbd.TeamRecruitingRanking, error)
+	GetTeamSPPlusRatings(
+		ctx context.Context,
+		request cfbd.GetSPPlusRatingsRequest,
+	) ([]*cfbd.TeamSP, error)
+	GetTeamSeasonStats(
+		ctx context.Context,
+		request cfbd.GetTeamSeasonStatsRequest,
+	) ([]*cfbd.TeamStat, error)
+	GetTeamSeasonWEPA(
+		ctx context.Context,
+		request cfbd.GetTeamSeasonWEPARequest,
+	) ([]*cfbd.AdjustedTeamMetrics, error)
+	GetTeamTalentComposite(
+		ctx context.Context,
+		request cfbd.GetTalentCompositeRequest,
+	) ([]*cfbd.TeamTalent, error)
+	GetTeams(
+		ctx context.Context,
+		request cfbd.GetTeamsRequest,
+	) ([]*cfbd.Team, error)
+	GetTransferPortalPlayers(
+		ctx context.Context,
+		request cfbd.GetTransferPortalPlayersRequest,
+	) ([]*cfbd.PlayerTransfer, error)
+	GetVenues(ctx context.Context) ([]*cfbd.Venue, error)
+	GetWinProbability(
+		ctx context.Context,
+		request cfbd.GetWinProbabilityRequest,
+	) ([]*cfbd.PlayWinProbability, error)
+}
+
+var _ CFBDAPI = (*cfbd.Client)(nil)
@@ -0,0 +1,71 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+// keyCheckTTL is how long a successful API key check is trusted before the
+// key is checked again, so frequent readiness probes cost few requests.
+const keyCheckTTL = 10 * time.Minute
+
+// ErrInvalidAPIKey is returned by CheckAPIKey when the CFBD API rejects the
+// configured key.
+var ErrInvalidAPIKey = errors.New("cfbd api key rejected")
+
+// keyCheck caches the outcome of the last successful API key check.
+type keyCheck struct {
+	mu         sync.Mutex
+	validUntil time.Time
+}
+
+// CheckAPIKey checks that the CFBD API accepts the configured key by
+// fetching the key's user info. A successful check is cached for
+// keyCheckTTL; failures are never cached, so a fixed key is seen at once.
+func (s *Seeder) CheckAPIKey(ctx context.Context) error {
+	s.keyCheck.mu.Lock()
+	defer s.keyCheck.mu.Unlock()
+
+	if time.Now().Before(s.keyCheck.validUntil) {
+		return nil
+	}
+
+	_, err := VerifyAPIKey(ctx, s.api)
+	if err != nil {
+		return err
+	}
+
+	s.usage.Record(endpointUserInfo)
+	s.keyCheck.validUntil = time.Now().Add(keyCheckTTL)
+
+	return nil
+}
+
+// VerifyAPIKey fetches the user info of the client's key, returning
+// ErrInvalidAPIKey if the API rejects the key.
+func VerifyAPIKey(
+	ctx context.Context,
+	api CFBDAPI,
+) (*cfbd.UserInfo, error) {
+	info, err := api.GetInfo(ctx)
+	if err != nil {
+		code, ok := etl.StatusCode(err)
+		if ok && (code == http.StatusUnauthorized ||
+			code == http.StatusForbidden) {
+			return nil, fmt.Errorf("%w: status %d", ErrInvalidAPIKey, code)
+		}
+		return nil, fmt.Errorf("failed to get user info; %w", err)
+	}
+	if info == nil {
+		return nil, fmt.Errorf("failed to get user info; %w", ErrAPIUnavailable)
+	}
+
+	return info, nil
+}
@@ -0,0 +1,220 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/storage"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// archiveTimeFormat names archived responses by the time they were fetched,
+// so they sort in fetch order.
+const archiveTimeFormat = "20060102T150405.000000000Z"
+
+// noParams names the archived responses of requests without parameters.
+const noParams = "_"
+
+// messageType is the interface of protobuf messages, which the API's
+// responses are.
+var messageType = reflect.TypeFor[proto.Message]()
+
+// ArchivedResponse is a raw API response as archived, with the request it
+// answered.
+type ArchivedResponse struct {
+	// Endpoint is the CFBD endpoint path, e.g. "/games".
+	Endpoint string `json:"endpoint"`
+	// Params is the request struct the endpoint was called with, or null
+	// for endpoints without parameters.
+	Params json.RawMessage `json:"params"`
+	// FetchedAt is when the response was received.
+	FetchedAt time.Time `json:"fetched_at"`
+	// Payload is the decoded response, encoded back to JSON (see
+	// encodePayload).
+	Payload json.RawMessage `json:"payload"`
+}
+
+// SetArchive makes every successful API response be written to store,
+// before it is transformed or inserted, under
+// <endpoint>/<params>/<fetched at>.json. The archive is a raw layer that
+// schema and transform changes can be re-processed from without spending
+// API quota again. A nil store disables archiving.
+func (s *Seeder) SetArchive(store storage.Store) {
+	s.archive = store
+}
+
+// archiveResponse writes a response to the archive, if one is set. A
+// response that fails to archive is logged but does not fail the request,
+// which has already spent its quota.
+func archiveResponse(
+	s *Seeder,
+	ctx context.Context,
+	endpoint string,
+	req any,
+	response any,
+) {
+	if s.archive == nil {
+		return
+	}
+
+	if err := writeArchive(
+		ctx, s.archive, endpoint, req, response, time.Now().UTC(),
+	); err != nil {
+		slog.Warn("failed to archive response", "endpoint", endpoint, "err", err)
+	}
+}
+
+// writeArchive writes a single response to the store.
+func writeArchive(
+	ctx context.Context,
+	store storage.Store,
+	endpoint string,
+	req any,
+	response any,
+	fetchedAt time.Time,
+) error {
+	params, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode request; %w", err)
+	}
+	payload, err := encodePayload(response)
+	if err != nil {
+		return fmt.Errorf("failed to encode response; %w", err)
+	}
+	data, err := json.Marshal(ArchivedResponse{
+		Endpoint:  endpoint,
+		Params:    params,
+		FetchedAt: fetchedAt,
+		Payload:   payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode archived response; %w", err)
+	}
+
+	name := path.Join(
+		strings.Trim(endpoint, "/"),
+		archiveParams(params),
+		fetchedAt.Format(archiveTimeFormat)+".json",
+	)
+	file, err := store.Create(ctx, name)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write %s; %w", name, err)
+	}
+
+	return file.Close()
+}
+
+// archiveParams returns the path segment of a request's parameters, its
+// set fields as a sorted query string, e.g. "SeasonType=regular&Year=2024".
+func archiveParams(params json.RawMessage) string {
+	var fields map[string]any
+	if err := json.Unmarshal(params, &fields); err != nil {
+		return noParams
+	}
+
+	values := url.Values{}
+	for key, value := range fields {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			if v != "" {
+				values.Set(key, v)
+			}
+		case float64:
+			if v != 0 {
+				values.Set(key, fmt.Sprint(v))
+			}
+		case bool:
+			if v {
+				values.Set(key, "true")
+			}
+		default:
+			encoded, _ := json.Marshal(v)
+			values.Set(key, string(encoded))
+		}
+	}
+	if len(values) == 0 {
+		return noParams
+	}
+
+	return values.Encode()
+}
+
+// encodePayload encodes a response, a protobuf message or a slice of them,
+// back to JSON with protojson, as encoding/json cannot decode the
+// well-known types (timestamps, structs) it would encode them to.
+func encodePayload(response any) ([]byte, error) {
+	if message, ok := response.(proto.Message); ok {
+		return protojson.Marshal(message)
+	}
+
+	list := reflect.ValueOf(response)
+	if list.Kind() != reflect.Slice ||
+		!list.Type().Elem().Implements(messageType) {
+		return json.Marshal(response)
+	}
+
+	items := make([]json.RawMessage, 0, list.Len())
+	for i := range list.Len() {
+		message, _ := list.Index(i).Interface().(proto.Message)
+		item, err := protojson.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return json.Marshal(items)
+}
+
+// decodePayload decodes an archived payload into out, a pointer to a
+// protobuf message pointer or to a slice of them, as encoded by
+// encodePayload.
+func decodePayload(payload json.RawMessage, out any) error {
+	target := reflect.ValueOf(out).Elem()
+	switch {
+	case target.Type().Implements(messageType):
+		message, err := decodeMessage(payload, target.Type())
+		if err != nil {
+			return err
+		}
+		target.Set(message)
+	case target.Kind() == reflect.Slice &&
+		target.Type().Elem().Implements(messageType):
+		var items []json.RawMessage
+		if err := json.Unmarshal(payload, &items); err != nil {
+			return err
+		}
+		for _, item := range items {
+			message, err := decodeMessage(item, target.Type().Elem())
+			if err != nil {
+				return err
+			}
+			target.Set(reflect.Append(target, message))
+		}
+	default:
+		return json.Unmarshal(payload, out)
+	}
+
+	return nil
+}
+
+// decodeMessage decodes a protobuf message of the pointer type typ.
+func decodeMessage(data []byte, typ reflect.Type) (reflect.Value, error) {
+	message := reflect.New(typ.Elem())
+	err := protojson.Unmarshal(data, message.Interface().(proto.Message))
+
+	return message, err
+}
@@ -0,0 +1,78 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// ErrNoWeeks is returned by BackfillWeeks when none of the weeks are in
+// the season's calendar.
+var ErrNoWeeks = errors.New("no calendar weeks to backfill")
+
+// backfilledTasks are the week tasks a backfill runs.
+var backfilledTasks = []string{
+	"SeedGames", "SeedPlays", "SeedPlayStats", "SeedBettingLines",
+	"SeedRankings", "SeedGameMedia", "SeedGameWeather",
+}
+
+// BackfillWeeks refreshes the games, plays, play stats, betting lines,
+// rankings, media and weather of the given weeks of a season, and then the
+// play flags and aggregates of the season, to catch up on weeks a sync
+// missed mid-season without reseeding the whole season. A week number
+// covers every selected season type with a week of that number in the
+// calendar, e.g. both the regular season's week 1 and the postseason's.
+// Weeks are backfilled one at a time, each week's tasks concurrently.
+func (s *Seeder) BackfillWeeks(
+	ctx context.Context,
+	year int32,
+	weeks []int32,
+) error {
+	calendar, err := s.calendar(ctx, year)
+	if err != nil {
+		return fmt.Errorf("failed to get calendar for backfill; %w", err)
+	}
+
+	var backfilled int
+	for _, week := range calendar {
+		if !slices.Contains(weeks, week.Week) ||
+			!s.keepSeasonType(week.SeasonType) {
+			continue
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		slog.Info(
+			"backfilling week",
+			"season", week.Season,
+			"week", week.Week,
+			"season_type", week.SeasonType,
+		)
+		if err = s.runWeekTasks(ctx, week, backfilledTasks); err != nil {
+			slog.Error(
+				"failed to backfill week",
+				"year", int32ToString(year),
+				"week", int32ToString(week.Week),
+				"season_type", week.SeasonType,
+				"err", err,
+			)
+			return fmt.Errorf("failed to backfill week %d of %d; %w",
+				week.Week, year, err)
+		}
+		backfilled++
+	}
+	if backfilled == 0 {
+		return fmt.Errorf("%w: weeks %v of %d", ErrNoWeeks, weeks, year)
+	}
+
+	if err = s.refreshPlayAggregates(ctx, year); err != nil {
+		return fmt.Errorf("failed to refresh play aggregates of %d; %w",
+			year, err)
+	}
+
+	slog.Info("weeks backfilled", "season", year, "weeks", backfilled)
+	return nil
+}
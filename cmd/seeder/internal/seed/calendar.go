@@ -0,0 +1,114 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+// calendarCache resolves each season's calendar weeks once per run, so the
+// per-week tasks share one lookup per season and agree on its weeks.
+type calendarCache struct {
+	mu      sync.Mutex
+	seasons map[int32]*calendarEntry
+}
+
+// calendarEntry is a season's weeks, or the lookup of them in flight; ready
+// is closed once weeks and err are set.
+type calendarEntry struct {
+	ready chan struct{}
+	weeks []db.CalendarWeek
+	err   error
+}
+
+// calendar returns the calendar weeks of the season. The weeks SeedCalendar
+// stored are used when there are any, and the API is asked otherwise. Each
+// season is looked up once per run, however many tasks ask for it at once;
+// a failed lookup is retried by the next caller.
+func (s *Seeder) calendar(
+	ctx context.Context,
+	year int32,
+) ([]db.CalendarWeek, error) {
+	s.calendars.mu.Lock()
+	entry, ok := s.calendars.seasons[year]
+	if !ok {
+		entry = &calendarEntry{ready: make(chan struct{})}
+		s.calendars.seasons[year] = entry
+	}
+	s.calendars.mu.Unlock()
+
+	if ok {
+		select {
+		case <-entry.ready:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to wait for calendar; %w", ctx.Err())
+		}
+		if entry.err != nil {
+			return s.calendar(ctx, year)
+		}
+		return entry.weeks, nil
+	}
+
+	entry.weeks, entry.err = s.lookupCalendar(ctx, year)
+	if entry.err != nil {
+		s.calendars.mu.Lock()
+		delete(s.calendars.seasons, year)
+		s.calendars.mu.Unlock()
+	}
+	close(entry.ready)
+
+	return entry.weeks, entry.err
+}
+
+// lookupCalendar reads the season's calendar weeks from the database,
+// falling back to the API if none were seeded.
+func (s *Seeder) lookupCalendar(
+	ctx context.Context,
+	year int32,
+) ([]db.CalendarWeek, error) {
+	weeks, err := s.db.GetCalendarWeeks(ctx, year)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar weeks; %w", err)
+	}
+	if len(weeks) > 0 {
+		return weeks, nil
+	}
+
+	if err = s.throttle(ctx, endpointCalendar); err != nil {
+		return nil, fmt.Errorf("failed to wait for rate limit; %w", err)
+	}
+
+	fetched, err := retryReq(
+		s, ctx, endpointCalendar, s.api.GetCalendar,
+		cfbd.GetCalendarRequest{Year: year},
+	)
+	if err != nil {
+		slog.Error(
+			"failed to get calendar",
+			"year", int32ToString(year),
+			"err", err,
+		)
+		return nil, fmt.Errorf("failed to get calendar for year %d; %w", year, err)
+	}
+
+	s.usage.Record(endpointCalendar)
+
+	weeks = make([]db.CalendarWeek, 0, len(fetched))
+	for _, w := range fetched {
+		if w == nil {
+			continue
+		}
+		weeks = append(weeks, db.CalendarWeek{
+			Season:     year,
+			Week:       w.GetWeek(),
+			SeasonType: strings.TrimSpace(w.GetSeasonType()),
+		})
+	}
+
+	return weeks, nil
+}
@@ -0,0 +1,115 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Classifications a seed can be limited to, as the API names them.
+const (
+	ClassificationFBS  = "fbs"
+	ClassificationFCS  = "fcs"
+	ClassificationDII  = "ii"
+	ClassificationDIII = "iii"
+)
+
+// ErrUnknownClassification is returned for a classification other than
+// FBS, FCS, Division II or Division III.
+var ErrUnknownClassification = errors.New("unknown classification")
+
+// SetClassification limits the seed to the teams of one classification:
+// fbs, fcs, ii (Division II) or iii (Division III), case-insensitively,
+// with dii and diii accepted for the last two. Requests that take a
+// classification (games, game team stats, drives, plays, the scoreboard,
+// rosters and recruits) ask for it; the teams, records, lines, play,
+// game and season stats, weather and media the API returns for every
+// classification are filtered after they are fetched, keeping the games
+// either team of which is of the classification; and per-game tasks skip
+// other stored games. With none, which is the default, nothing is filtered.
+func (s *Seeder) SetClassification(classification string) error {
+	switch c := strings.ToLower(strings.TrimSpace(classification)); c {
+	case "", ClassificationFBS, ClassificationFCS, ClassificationDII,
+		ClassificationDIII:
+		s.classification = c
+	case "dii":
+		s.classification = ClassificationDII
+	case "diii":
+		s.classification = ClassificationDIII
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownClassification, classification)
+	}
+
+	return nil
+}
+
+// byClassification returns the records that involve a team of the selected
+// classification, given the classifications of each record's teams. Every
+// record is kept if the seed is not limited to a classification.
+func byClassification[T any](
+	s *Seeder,
+	records []T,
+	classifications func(T) []string,
+) []T {
+	if s.classification == "" {
+		return records
+	}
+
+	return slices.DeleteFunc(records, func(record T) bool {
+		return !slices.ContainsFunc(classifications(record), func(c string) bool {
+			return strings.EqualFold(c, s.classification)
+		})
+	})
+}
+
+// byConferenceClassification is byClassification for records that only
+// name their teams' conferences, which are classified by the stored
+// conferences. Records of conferences that are not stored are dropped.
+func byConferenceClassification[T any](
+	s *Seeder,
+	ctx context.Context,
+	records []T,
+	conferences func(T) []string,
+) ([]T, error) {
+	if s.classification == "" {
+		return records, nil
+	}
+
+	classified, err := s.db.GetConferenceClassifications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to classify conferences; %w", err)
+	}
+
+	return byClassification(s, records, func(record T) []string {
+		names := conferences(record)
+		classifications := make([]string, len(names))
+		for i, name := range names {
+			classifications[i] = classified[name]
+		}
+		return classifications
+	}), nil
+}
+
+// teamClassification returns the classification of a team's record.
+func teamClassification[T interface{ GetClassification() string }](
+	record T,
+) []string {
+	return []string{record.GetClassification()}
+}
+
+// teamConference returns the conference of a team's record.
+func teamConference[T interface{ GetConference() string }](
+	record T,
+) []string {
+	return []string{record.GetConference()}
+}
+
+// gameConferences returns the conferences of the teams of a game's record.
+func gameConferences[T interface {
+	GetHomeConference() string
+	GetAwayConference() string
+}](record T) []string {
+	return []string{record.GetHomeConference(), record.GetAwayConference()}
+}
@@ -0,0 +1,255 @@
+package seed
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+// Checks of VerifyCounts.
+const (
+	checkGamesPerWeek  = "games per week"
+	checkDrivesPerGame = "drives per game"
+	checkRanksPerPoll  = "ranks per poll"
+)
+
+// CountCheck is the outcome of one comparison of VerifyCounts.
+type CountCheck struct {
+	Check  string `json:"check"`
+	Groups int    `json:"groups"`
+	Gaps   int    `json:"gaps"`
+}
+
+// CountGap is a group whose stored row count differs from the API's, e.g.
+// a week missing games or a game missing drives.
+type CountGap struct {
+	Check    string `json:"check"`
+	Group    string `json:"group"`
+	Expected int64  `json:"expected"`
+	Stored   int64  `json:"stored"`
+}
+
+// VerifyReport is the outcome of VerifyCounts.
+type VerifyReport struct {
+	Year   int32        `json:"year"`
+	Passed bool         `json:"passed"`
+	Checks []CountCheck `json:"checks"`
+	Gaps   []CountGap   `json:"gaps"`
+}
+
+// weekKey identifies a week of a season.
+type weekKey struct {
+	seasonType string
+	week       int32
+}
+
+func (k weekKey) String() string {
+	return fmt.Sprintf("%s week %d", k.seasonType, k.week)
+}
+
+func compareWeeks(a, b weekKey) int {
+	return cmp.Or(
+		cmp.Compare(a.seasonType, b.seasonType),
+		cmp.Compare(a.week, b.week),
+	)
+}
+
+// pollKey identifies a poll of a week.
+type pollKey struct {
+	weekKey
+	poll string
+}
+
+func (k pollKey) String() string {
+	return fmt.Sprintf("%s, %s", k.poll, k.weekKey)
+}
+
+func comparePolls(a, b pollKey) int {
+	return cmp.Or(compareWeeks(a.weekKey, b.weekKey), cmp.Compare(a.poll, b.poll))
+}
+
+// countCheck holds the expected and stored row counts of one check by group.
+type countCheck[K comparable] struct {
+	name     string
+	expected map[K]int64
+	stored   map[K]int64
+}
+
+func newCountCheck[K comparable](name string) countCheck[K] {
+	return countCheck[K]{
+		name:     name,
+		expected: make(map[K]int64),
+		stored:   make(map[K]int64),
+	}
+}
+
+// addTo adds the outcome of the check to the report. Only the groups the API
+// returned are compared, so rows stored from other requests, e.g. of a
+// season type the API leaves out by default, are not reported.
+func (c countCheck[K]) addTo(
+	report *VerifyReport,
+	label func(K) string,
+	compare func(a, b K) int,
+) {
+	check := CountCheck{Check: c.name, Groups: len(c.expected)}
+	for _, group := range slices.SortedFunc(maps.Keys(c.expected), compare) {
+		if c.stored[group] == c.expected[group] {
+			continue
+		}
+		check.Gaps++
+		report.Gaps = append(report.Gaps, CountGap{
+			Check:    c.name,
+			Group:    label(group),
+			Expected: c.expected[group],
+			Stored:   c.stored[group],
+		})
+	}
+
+	report.Checks = append(report.Checks, check)
+	if check.Gaps > 0 {
+		report.Passed = false
+	}
+}
+
+// VerifyCounts cross-checks the stored rows of a season against fresh API
+// responses, comparing games per week, drives per game and ranks per poll,
+// so that a load that silently came up short is caught. The responses go
+// through the registered transforms, as when seeding. Any group whose counts
+// differ is reported as a gap; nothing is written. Only the selected season
+// types are checked.
+func (s *Seeder) VerifyCounts(
+	ctx context.Context,
+	year int32,
+) (VerifyReport, error) {
+	report := VerifyReport{Year: year, Passed: true}
+
+	games, err := verifyFetch(s, ctx, endpointGames, s.api.GetGames,
+		cfbd.GetGamesRequest{Year: year, SeasonType: s.seasonType})
+	if err != nil {
+		return VerifyReport{}, err
+	}
+	weekCounts, err := s.db.CountGamesPerWeek(ctx, year)
+	if err != nil {
+		return VerifyReport{}, fmt.Errorf("failed to count games; %w", err)
+	}
+	gamesPerWeek := newCountCheck[weekKey](checkGamesPerWeek)
+	for _, game := range games {
+		gamesPerWeek.expected[weekKey{game.GetSeasonType(), game.GetWeek()}]++
+	}
+	for _, count := range weekCounts {
+		gamesPerWeek.stored[weekKey{count.SeasonType, count.Week}] = count.Rows
+	}
+	gamesPerWeek.addTo(&report, weekKey.String, compareWeeks)
+
+	drives, err := verifyFetch(s, ctx, endpointDrives, s.api.GetDrives,
+		cfbd.GetDrivesRequest{Year: year, SeasonType: s.seasonType})
+	if err != nil {
+		return VerifyReport{}, err
+	}
+	gameCounts, err := s.db.CountDrivesPerGame(ctx, year)
+	if err != nil {
+		return VerifyReport{}, fmt.Errorf("failed to count drives; %w", err)
+	}
+	drivesPerGame := newCountCheck[int32](checkDrivesPerGame)
+	for _, drive := range drives {
+		drivesPerGame.expected[drive.GetGameId()]++
+	}
+	for _, count := range gameCounts {
+		drivesPerGame.stored[count.GameID] = count.Rows
+	}
+	drivesPerGame.addTo(&report, func(id int32) string {
+		return fmt.Sprintf("game %d", id)
+	}, cmp.Compare[int32])
+
+	weeks, err := verifyFetch(s, ctx, endpointRankings, s.api.GetRankings,
+		cfbd.GetRankingsRequest{Year: year, SeasonType: s.seasonType})
+	if err != nil {
+		return VerifyReport{}, err
+	}
+	pollCounts, err := s.db.CountRanksPerPoll(ctx, year)
+	if err != nil {
+		return VerifyReport{}, fmt.Errorf("failed to count ranks; %w", err)
+	}
+	ranksPerPoll := newCountCheck[pollKey](checkRanksPerPoll)
+	for _, week := range weeks {
+		for _, poll := range week.GetPolls() {
+			key := pollKey{
+				weekKey{week.GetSeasonType(), week.GetWeek()}, poll.GetPoll(),
+			}
+			ranksPerPoll.expected[key] += int64(len(poll.GetRanks()))
+		}
+	}
+	for _, count := range pollCounts {
+		key := pollKey{weekKey{count.SeasonType, count.Week}, count.Poll}
+		ranksPerPoll.stored[key] = count.Rows
+	}
+	ranksPerPoll.addTo(&report, pollKey.String, comparePolls)
+
+	slog.Info(
+		"verified row counts",
+		"year", int32ToString(year),
+		"gaps", len(report.Gaps),
+	)
+	return report, nil
+}
+
+// verifyFetch fetches the API's records to check the stored rows against,
+// transformed as they would be before being inserted.
+func verifyFetch[R, T any](
+	s *Seeder,
+	ctx context.Context,
+	endpoint string,
+	fetch func(context.Context, R) ([]T, error),
+	req R,
+) ([]T, error) {
+	if err := s.throttle(ctx, endpoint); err != nil {
+		return nil, fmt.Errorf("failed to wait for rate limit; %w", err)
+	}
+
+	records, err := retryReq(s, ctx, endpoint, fetch, req)
+	if err != nil {
+		slog.Error("failed to fetch", "endpoint", endpoint, "err", err)
+		return nil, fmt.Errorf("failed to fetch %s; %w", endpoint, err)
+	}
+
+	s.usage.Record(endpoint)
+	return transform(s, endpoint, records), nil
+}
+
+// Write prints the checks as an aligned table, followed by every gap and the
+// overall outcome.
+func (r VerifyReport) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "check\tgroups\tgaps")
+	for _, check := range r.Checks {
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", check.Check, check.Groups, check.Gaps)
+	}
+
+	if len(r.Gaps) > 0 {
+		fmt.Fprintln(tw, "\ncheck\tgroup\texpected\tstored")
+		for _, gap := range r.Gaps {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\n",
+				gap.Check, gap.Group, gap.Expected, gap.Stored)
+		}
+	}
+
+	outcome := fmt.Sprintf("verify passed for %d", r.Year)
+	if !r.Passed {
+		outcome = fmt.Sprintf("verify found %d gaps for %d", len(r.Gaps), r.Year)
+	}
+	fmt.Fprintf(tw, "\n%s\n", outcome)
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write verify report; %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package seed
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+// Entities Diff and Refresh can compare.
+const (
+	DiffGames = "games"
+	DiffLines = "lines"
+)
+
+// ErrUnknownEntity is returned when Diff or Refresh is asked for an entity
+// they cannot compare.
+var ErrUnknownEntity = errors.New("unknown diff entity")
+
+// DiffSpec identifies the rows Diff compares with the API, or Refresh
+// refreshes.
+type DiffSpec struct {
+	// Entity is what to compare: DiffGames or DiffLines.
+	Entity string
+	// Year is the season to compare.
+	Year int32
+	// Week narrows the comparison to one week of Year; zero compares every
+	// week.
+	Week int32
+	// SeasonType is the season type of the rows to fetch, e.g. "regular" or
+	// "postseason".
+	SeasonType string
+}
+
+// FieldDiff is a field whose stored value differs from the API's.
+type FieldDiff struct {
+	Entity     string `json:"entity"`
+	EntityID   int32  `json:"entity_id"`
+	Season     int32  `json:"season"`
+	Week       int32  `json:"week"`
+	SeasonType string `json:"season_type"`
+	Field      string `json:"field"`
+	Stored     string `json:"stored"`
+	Upstream   string `json:"upstream"`
+}
+
+// DiffReport is the outcome of Diff or Refresh.
+type DiffReport struct {
+	Entity string `json:"entity"`
+	Year   int32  `json:"year"`
+	Week   int32  `json:"week,omitempty"`
+	// Written is the number of changed rows Refresh wrote; Diff writes none.
+	Written int64       `json:"written,omitempty"`
+	Diffs   []FieldDiff `json:"diffs"`
+}
+
+// Diff re-fetches the spec's rows from the API and reports every field
+// whose stored value differs, so that upstream corrections, e.g. a changed
+// score or a reclassified game, can be spotted before deciding to refresh.
+// The responses go through the registered transforms, as when seeding.
+// Nothing is written.
+func (s *Seeder) Diff(ctx context.Context, spec DiffSpec) (DiffReport, error) {
+	return s.diff(ctx, spec, false)
+}
+
+// Refresh re-fetches the spec's rows from the API like Diff, but then
+// upserts them with the database's change detection, which leaves alone the
+// rows whose content hash matches the one stored in row_hashes, and records
+// every changed field in verification_diffs. The database must skip
+// unchanged rows (see db.Database.SkipUnchanged); rows without a stored hash
+// yet are written and hashed.
+func (s *Seeder) Refresh(
+	ctx context.Context,
+	spec DiffSpec,
+) (DiffReport, error) {
+	return s.diff(ctx, spec, true)
+}
+
+// diff compares the spec's rows with the API and, if write is set, upserts
+// them and records their changed fields. The rows written are counted from
+// the table syncs the progress tracker records, since the rows the database
+// skips as unchanged are never written.
+func (s *Seeder) diff(
+	ctx context.Context,
+	spec DiffSpec,
+	write bool,
+) (DiffReport, error) {
+	week := db.CalendarWeek{
+		Season:     spec.Year,
+		Week:       spec.Week,
+		SeasonType: spec.SeasonType,
+	}
+
+	var (
+		diffs []db.VerificationDiff
+		err   error
+	)
+	before := s.progress.Snapshot().RowsWritten
+	switch spec.Entity {
+	case DiffGames:
+		var games []*cfbd.Game
+		games, err = verifyFetch(s, ctx, endpointGames, s.api.GetGames,
+			cfbd.GetGamesRequest{
+				Year:       spec.Year,
+				Week:       spec.Week,
+				SeasonType: spec.SeasonType,
+			})
+		if err != nil {
+			return DiffReport{}, err
+		}
+		diffs, err = s.db.DiffGames(ctx, week, games)
+		if err == nil && write {
+			err = s.db.InsertGames(ctx, games)
+		}
+	case DiffLines:
+		var lines []*cfbd.BettingGame
+		lines, err = verifyFetch(s, ctx, endpointBettingLines,
+			s.api.GetBettingLines, cfbd.GetBettingLinesRequest{
+				Year:       spec.Year,
+				Week:       spec.Week,
+				SeasonType: spec.SeasonType,
+			})
+		if err != nil {
+			return DiffReport{}, err
+		}
+		diffs, err = s.db.DiffLines(ctx, week, lines)
+		if err == nil && write {
+			err = s.db.InsertBettingLines(ctx, lines)
+		}
+	default:
+		return DiffReport{}, fmt.Errorf("%w: %q", ErrUnknownEntity, spec.Entity)
+	}
+	if err != nil {
+		slog.Error("failed to diff", "entity", spec.Entity, "err", err)
+		return DiffReport{}, fmt.Errorf("failed to diff %s; %w", spec.Entity, err)
+	}
+	written := s.progress.Snapshot().RowsWritten - before
+	if write {
+		if err = s.db.InsertVerificationDiffs(ctx, diffs); err != nil {
+			return DiffReport{}, fmt.Errorf(
+				"failed to record verification diffs; %w", err,
+			)
+		}
+	}
+
+	report := DiffReport{
+		Entity:  spec.Entity,
+		Year:    spec.Year,
+		Week:    spec.Week,
+		Written: written,
+		Diffs:   make([]FieldDiff, 0, len(diffs)),
+	}
+	for _, d := range diffs {
+		report.Diffs = append(report.Diffs, FieldDiff{
+			Entity:     d.Entity,
+			EntityID:   d.EntityID,
+			Season:     d.Season,
+			Week:       d.Week,
+			SeasonType: d.SeasonType,
+			Field:      d.Field,
+			Stored:     d.StoredValue,
+			Upstream:   d.UpstreamValue,
+		})
+	}
+	slices.SortStableFunc(report.Diffs, func(a, b FieldDiff) int {
+		return cmp.Or(
+			compareWeeks(
+				weekKey{a.SeasonType, a.Week}, weekKey{b.SeasonType, b.Week},
+			),
+			cmp.Compare(a.EntityID, b.EntityID),
+		)
+	})
+
+	slog.Info(
+		"diffed against api",
+		"entity", spec.Entity,
+		"year", int32ToString(spec.Year),
+		"diffs", len(report.Diffs),
+		"written", written,
+	)
+	return report, nil
+}
+
+// Write prints every differing field as an aligned table, followed by the
+// number found and, after a refresh, the number of rows written.
+func (r DiffReport) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if len(r.Diffs) > 0 {
+		fmt.Fprintln(tw, "week\tid\tfield\tstored\tupstream")
+		for _, d := range r.Diffs {
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n",
+				weekKey{d.SeasonType, d.Week}, d.EntityID, d.Field,
+				d.Stored, d.Upstream)
+		}
+		fmt.Fprintln(tw)
+	}
+
+	fmt.Fprintf(tw, "%d differences in %s for %d\n",
+		len(r.Diffs), r.Entity, r.Year)
+	if r.Written > 0 {
+		fmt.Fprintf(tw, "%d changed rows written\n", r.Written)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write diff report; %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package seed
+
+// CFBD endpoint paths, used to label per-endpoint accounting.
+const (
+	endpointPlayTypes           = "/plays/types"
+	endpointConferences         = "/conferences"
+	endpointVenues              = "/venues"
+	endpointStatCategories      = "/stats/categories"
+	endpointDraftTeams          = "/draft/teams"
+	endpointDraftPositions      = "/draft/positions"
+	endpointFieldGoalEP         = "/metrics/fg/ep"
+	endpointTeams               = "/teams"
+	endpointRoster              = "/roster"
+	endpointCalendar            = "/calendar"
+	endpointGames               = "/games"
+	endpointDrives              = "/drives"
+	endpointPlays               = "/plays"
+	endpointPlayStats           = "/plays/stats"
+	endpointGameTeams           = "/games/teams"
+	endpointGamePlayers         = "/games/players"
+	endpointWinProbability      = "/metrics/wp"
+	endpointAdvancedBoxScore    = "/game/box/advanced"
+	endpointGameWeather         = "/games/weather"
+	endpointGameMedia           = "/games/media"
+	endpointBettingLines        = "/lines"
+	endpointTeamRecords         = "/records"
+	endpointTalent              = "/talent"
+	endpointTeamATS             = "/teams/ats"
+	endpointSPPlus              = "/ratings/sp"
+	endpointConferenceSPPlus    = "/ratings/sp/conferences"
+	endpointSRS                 = "/ratings/srs"
+	endpointElo                 = "/ratings/elo"
+	endpointFPI                 = "/ratings/fpi"
+	endpointWepaTeamSeason      = "/wepa/team/season"
+	endpointWepaPassing         = "/wepa/players/passing"
+	endpointWepaRushing         = "/wepa/players/rushing"
+	endpointWepaKicking         = "/wepa/players/kicking"
+	endpointReturningProduction = "/player/returning"
+	endpointTransferPortal      = "/player/portal"
+	endpointPlayerSeasonStats   = "/stats/player/season"
+	endpointTeamSeasonStats     = "/stats/season"
+	endpointRankings            = "/rankings"
+	endpointRecruitingPlayers   = "/recruiting/players"
+	endpointRecruitingTeams     = "/recruiting/teams"
+	endpointRecruitingGroups    = "/recruiting/groups"
+	endpointScoreboard          = "/scoreboard"
+	endpointLivePlays           = "/live/plays"
+	endpointDraftPicks          = "/draft/picks"
+	endpointUserInfo            = "/info"
+)
@@ -0,0 +1,172 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+// DefaultMaxFailureAttempts is how many times a unit may fail before
+// RetryFailed gives up on it.
+const DefaultMaxFailureAttempts = 5
+
+// SetMaxFailureAttempts sets how many times a unit may fail before
+// RetryFailed marks it permanent. Values below one are ignored.
+func (s *Seeder) SetMaxFailureAttempts(attempts int) {
+	if attempts > 0 {
+		s.maxAttempts = attempts
+	}
+}
+
+// failureParams identifies the unit of work a recorded seed failure refers
+// to, so that exactly that unit can be re-attempted.
+type failureParams struct {
+	Year   int32 `json:"year"`
+	GameID int32 `json:"game_id"`
+}
+
+// recordFailure persists a failed per-game fetch to the failure ledger. A
+// ledger write that fails is logged rather than failing the seed, matching
+// how the fetch failure itself is handled. Fetches that fail once the
+// seeder is stopped are left out, as they were cut short rather than
+// failing.
+func (s *Seeder) recordFailure(
+	ctx context.Context,
+	endpoint string,
+	params failureParams,
+	cause error,
+) {
+	if s.isStopped() {
+		return
+	}
+
+	payload, err := json.Marshal(params)
+	if err == nil {
+		err = s.db.RecordSeedFailure(
+			ctx, endpoint, payload, cause, time.Now().UTC(),
+		)
+	}
+	if err != nil {
+		slog.Warn(
+			"failed to record seed failure",
+			"endpoint", endpoint,
+			"game_id", params.GameID,
+			"err", err,
+		)
+	}
+}
+
+// RetryFailed re-attempts every unresolved unit in the failure ledger. It
+// runs at the end of every full seed, so units left failing by one run are
+// picked up by the next. Units that succeed are marked resolved; units that
+// fail again stay in the ledger with their attempt count incremented, until
+// they reach the maximum attempts or the API rejects the request outright,
+// at which point they are marked permanent and no longer retried.
+func (s *Seeder) RetryFailed(ctx context.Context) error {
+	failures, err := s.db.GetUnresolvedSeedFailures(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get seed failures; %w", err)
+	}
+
+	slog.Info("retrying failed fetches", "count", len(failures))
+
+	var resolved, remaining, permanent int
+	for _, failure := range failures {
+		var params failureParams
+		if err = json.Unmarshal(failure.Params, &params); err != nil {
+			return fmt.Errorf("failed to decode seed failure %d; %w",
+				failure.ID, err)
+		}
+
+		if err = s.throttle(ctx, failure.Endpoint); err != nil {
+			return fmt.Errorf("failed to wait for rate limit; %w", err)
+		}
+
+		switch failure.Endpoint {
+		case endpointWinProbability:
+			var plays []*cfbd.PlayWinProbability
+			plays, err = s.fetchWinProbability(ctx, params.GameID)
+			if err == nil {
+				err = s.db.InsertPlayWinProbability(ctx, plays)
+			}
+		case endpointAdvancedBoxScore:
+			var score *cfbd.AdvancedBoxScore
+			score, err = s.fetchAdvancedBoxScore(ctx, params.GameID)
+			if err == nil {
+				err = s.db.InsertAdvancedBoxScores(
+					ctx, map[int32]*cfbd.AdvancedBoxScore{params.GameID: score},
+				)
+			}
+		default:
+			slog.Warn(
+				"no retry available for failed endpoint",
+				"endpoint", failure.Endpoint,
+				"id", failure.ID,
+			)
+			remaining++
+			continue
+		}
+
+		if err != nil {
+			slog.Warn(
+				"failed fetch is still failing",
+				"endpoint", failure.Endpoint,
+				"game_id", params.GameID,
+				"err", err,
+			)
+			s.recordFailure(ctx, failure.Endpoint, params, err)
+			attempts := int(failure.Attempts) + 1
+			if attempts < s.maxAttempts && !isPermanent(err) {
+				remaining++
+				continue
+			}
+
+			slog.Warn(
+				"giving up on failed fetch",
+				"endpoint", failure.Endpoint,
+				"game_id", params.GameID,
+				"attempts", attempts,
+			)
+			if err = s.db.MarkSeedFailurePermanent(
+				ctx, failure.ID, time.Now().UTC(),
+			); err != nil {
+				return fmt.Errorf(
+					"failed to mark seed failure permanent; %w", err,
+				)
+			}
+			permanent++
+			continue
+		}
+
+		if err = s.db.ResolveSeedFailure(
+			ctx, failure.ID, time.Now().UTC(),
+		); err != nil {
+			return fmt.Errorf("failed to resolve seed failure; %w", err)
+		}
+		resolved++
+	}
+
+	slog.Info(
+		"retried failed fetches",
+		"resolved", resolved,
+		"remaining", remaining,
+		"permanent", permanent,
+	)
+
+	return nil
+}
+
+// isPermanent reports whether err is a client error that retrying the same
+// request cannot fix, such as a game the API does not know.
+func isPermanent(err error) bool {
+	code, ok := etl.StatusCode(err)
+	return ok && code >= http.StatusBadRequest &&
+		code < http.StatusInternalServerError &&
+		code != http.StatusTooManyRequests
+}
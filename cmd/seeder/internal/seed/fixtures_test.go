@@ -0,0 +1,73 @@
+package seed_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/fixtures"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/seed"
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+// TestSeedFromFixtures seeds the conferences and venues from the fixtures
+// in testdata, served by the mock API, into a SQLite database.
+func TestSeedFromFixtures(t *testing.T) {
+	defaultTransport := http.DefaultTransport
+	srv := fixtures.NewServer(os.DirFS(filepath.Join("testdata", "fixtures")))
+	t.Cleanup(srv.Close)
+	base, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api, err := cfbd.New("fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = seed.WrapClientTransport(api,
+		func(next http.RoundTripper) http.RoundTripper {
+			return fixtures.Redirect(base, next)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seeder, database := newTestSeeder(t, api)
+	if err = seeder.CountResponseBytes(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err = seeder.SeedConferences(ctx); err != nil {
+		t.Fatalf("SeedConferences: %v", err)
+	}
+	if err = seeder.SeedVenues(ctx); err != nil {
+		t.Fatalf("SeedVenues: %v", err)
+	}
+
+	assertRows(t, database, "conferences", 2)
+	assertRows(t, database, "venues", 1)
+
+	// Only the client's own transport is wrapped.
+	if http.DefaultTransport != defaultTransport {
+		t.Error("http.DefaultTransport was replaced")
+	}
+	for _, usage := range seeder.Usage().Snapshot() {
+		if usage.Bytes == 0 {
+			t.Errorf("no response bytes counted for %s", usage.Endpoint)
+		}
+	}
+
+	var venue db.Venue
+	if err = database.Take(&venue, "id = ?", 3768).Error; err != nil {
+		t.Fatal(err)
+	}
+	if venue.Name != "Michigan Stadium" {
+		t.Errorf("venue name = %q, want %q", venue.Name, "Michigan Stadium")
+	}
+}
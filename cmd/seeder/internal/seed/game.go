@@ -0,0 +1,214 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+// ErrGameNotFound is returned by RefreshGame for a game the API does not
+// know.
+var ErrGameNotFound = errors.New("game not found")
+
+// RefreshGame re-fetches everything stored about one game and upserts it,
+// in dependency order: the game itself, its drives, plays and play stats,
+// its team and player box scores, advanced box score and win probability,
+// and its weather, media and betting lines. The season's play flags and
+// aggregates are refreshed after, as plays may have changed. It takes
+// eleven requests, which makes it the cheap way to pick up a stat
+// correction to a single game. Drives, plays and media cannot be requested
+// by game, so the home team's week is requested and the game's rows are
+// kept.
+func (s *Seeder) RefreshGame(ctx context.Context, gameID int32) error {
+	games, err := fetchForGame(s, ctx, endpointGames, s.api.GetGames,
+		cfbd.GetGamesRequest{GameID: gameID}, nil)
+	if err != nil {
+		return err
+	}
+	if len(games) == 0 {
+		return fmt.Errorf("%w: %d", ErrGameNotFound, gameID)
+	}
+	game := games[0]
+	if err = s.db.InsertGames(ctx, games); err != nil {
+		return fmt.Errorf("failed to insert game; %w", err)
+	}
+
+	slog.Info(
+		"refreshing game",
+		"game_id", gameID,
+		"season", game.GetSeason(),
+		"week", game.GetWeek(),
+		"home_team", game.GetHomeTeam(),
+		"away_team", game.GetAwayTeam(),
+	)
+
+	season := game.GetSeason()
+	steps := []func(context.Context) error{
+		func(ctx context.Context) error {
+			drives, err := fetchForGame(s, ctx, endpointDrives, s.api.GetDrives,
+				cfbd.GetDrivesRequest{
+					Year:       season,
+					Week:       game.GetWeek(),
+					SeasonType: game.GetSeasonType(),
+					Team:       game.GetHomeTeam(),
+				},
+				func(d *cfbd.Drive) bool { return d.GetGameId() == gameID },
+			)
+			if err != nil {
+				return err
+			}
+			return s.db.InsertDrives(ctx, season, drives)
+		},
+		func(ctx context.Context) error {
+			plays, err := fetchForGame(s, ctx, endpointPlays, s.api.GetPlays,
+				cfbd.GetPlaysRequest{
+					Year:       season,
+					Week:       game.GetWeek(),
+					SeasonType: game.GetSeasonType(),
+					Team:       game.GetHomeTeam(),
+				},
+				func(p *cfbd.Play) bool { return p.GetGameId() == gameID },
+			)
+			if err != nil {
+				return err
+			}
+			return s.db.InsertPlays(ctx, season, plays)
+		},
+		func(ctx context.Context) error {
+			stats, err := fetchForGame(
+				s, ctx, endpointPlayStats, s.api.GetPlayStats,
+				cfbd.GetPlayStatsRequest{Year: season, GameID: gameID}, nil,
+			)
+			if err != nil {
+				return err
+			}
+			return s.db.InsertPlayStats(ctx, stats)
+		},
+		func(ctx context.Context) error {
+			stats, err := fetchForGame(
+				s, ctx, endpointGameTeams, s.api.GetGameTeams,
+				cfbd.GetGameTeamsRequest{Year: season, GameID: gameID}, nil,
+			)
+			if err != nil {
+				return err
+			}
+			return s.db.InsertGameTeamStats(ctx, stats)
+		},
+		func(ctx context.Context) error {
+			stats, err := fetchForGame(
+				s, ctx, endpointGamePlayers, s.api.GetGamePlayers,
+				cfbd.GetGamePlayersRequest{Year: season, GameID: gameID}, nil,
+			)
+			if err != nil {
+				return err
+			}
+			return s.db.InsertGamePlayerStats(ctx, stats)
+		},
+		func(ctx context.Context) error {
+			if err := s.throttle(ctx, endpointAdvancedBoxScore); err != nil {
+				return fmt.Errorf("failed to wait for rate limit; %w", err)
+			}
+			score, err := s.fetchAdvancedBoxScore(ctx, gameID)
+			if err != nil {
+				return err
+			}
+			return s.db.InsertAdvancedBoxScores(
+				ctx, map[int32]*cfbd.AdvancedBoxScore{gameID: score},
+			)
+		},
+		func(ctx context.Context) error {
+			if err := s.throttle(ctx, endpointWinProbability); err != nil {
+				return fmt.Errorf("failed to wait for rate limit; %w", err)
+			}
+			plays, err := s.fetchWinProbability(ctx, gameID)
+			if err != nil {
+				return err
+			}
+			return s.db.InsertPlayWinProbability(ctx, plays)
+		},
+		func(ctx context.Context) error {
+			weather, err := fetchForGame(
+				s, ctx, endpointGameWeather, s.api.GetGameWeather,
+				cfbd.GetGameWeatherRequest{Year: season, GameID: gameID}, nil,
+			)
+			if err != nil {
+				return err
+			}
+			return s.db.InsertGameWeather(ctx, weather)
+		},
+		func(ctx context.Context) error {
+			media, err := fetchForGame(
+				s, ctx, endpointGameMedia, s.api.GetGameMedia,
+				cfbd.GetGameMediaRequest{
+					Year:       season,
+					Week:       game.GetWeek(),
+					SeasonType: game.GetSeasonType(),
+					Team:       game.GetHomeTeam(),
+				},
+				func(m *cfbd.GameMedia) bool { return m.GetId() == gameID },
+			)
+			if err != nil {
+				return err
+			}
+			return s.db.InsertGameMedia(ctx, media)
+		},
+		func(ctx context.Context) error {
+			lines, err := fetchForGame(
+				s, ctx, endpointBettingLines, s.api.GetBettingLines,
+				cfbd.GetBettingLinesRequest{Year: season, GameID: gameID}, nil,
+			)
+			if err != nil {
+				return err
+			}
+			return s.db.InsertBettingLines(ctx, lines)
+		},
+	}
+	for _, step := range steps {
+		if err = step(ctx); err != nil {
+			slog.Error("failed to refresh game", "game_id", gameID, "err", err)
+			return fmt.Errorf("failed to refresh game %d; %w", gameID, err)
+		}
+	}
+
+	if err = s.refreshPlayAggregates(ctx, season); err != nil {
+		return fmt.Errorf("failed to refresh play aggregates of %d; %w",
+			season, err)
+	}
+
+	slog.Info("game refreshed", "game_id", gameID)
+	return nil
+}
+
+// fetchForGame fetches one endpoint's records for a game, transformed as
+// they would be before being inserted. If keep is set, only the records it
+// keeps are returned, for endpoints that cannot be requested by game.
+func fetchForGame[R, T any](
+	s *Seeder,
+	ctx context.Context,
+	endpoint string,
+	fetch func(context.Context, R) ([]T, error),
+	req R,
+	keep func(T) bool,
+) ([]T, error) {
+	if err := s.throttle(ctx, endpoint); err != nil {
+		return nil, fmt.Errorf("failed to wait for rate limit; %w", err)
+	}
+
+	records, err := retryReq(s, ctx, endpoint, fetch, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s; %w", endpoint, err)
+	}
+
+	s.usage.Record(endpoint)
+	if keep != nil {
+		records = slices.DeleteFunc(records, func(record T) bool {
+			return !keep(record)
+		})
+	}
+
+	return transform(s, endpoint, records), nil
+}
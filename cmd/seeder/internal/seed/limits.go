@@ -0,0 +1,105 @@
+package seed
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// EndpointClass groups CFBD endpoints with similar cost so they can be
+// rate limited together.
+type EndpointClass string
+
+const (
+	// ClassReference covers small lookup endpoints fetched once per run.
+	ClassReference EndpointClass = "reference"
+	// ClassBulk covers season and week level endpoints. Endpoints without
+	// an explicit class fall into it.
+	ClassBulk EndpointClass = "bulk"
+	// ClassPerGame covers endpoints requested once per game, which make up
+	// the bulk of a full seed and are the most sensitive upstream.
+	ClassPerGame EndpointClass = "per_game"
+	// ClassLive covers endpoints polled during games in watch mode.
+	ClassLive EndpointClass = "live"
+)
+
+// endpointClasses assigns endpoints to classes; anything not listed is
+// ClassBulk.
+var endpointClasses = map[string]EndpointClass{
+	endpointPlayTypes:        ClassReference,
+	endpointConferences:      ClassReference,
+	endpointVenues:           ClassReference,
+	endpointStatCategories:   ClassReference,
+	endpointDraftTeams:       ClassReference,
+	endpointDraftPositions:   ClassReference,
+	endpointFieldGoalEP:      ClassReference,
+	endpointTeams:            ClassReference,
+	endpointUserInfo:         ClassReference,
+	endpointWinProbability:   ClassPerGame,
+	endpointAdvancedBoxScore: ClassPerGame,
+	endpointScoreboard:       ClassLive,
+	endpointLivePlays:        ClassLive,
+}
+
+// EndpointClasses lists every class that can be configured.
+var EndpointClasses = []EndpointClass{
+	ClassReference, ClassBulk, ClassPerGame, ClassLive,
+}
+
+// ClassOf returns the class the endpoint is rate limited under.
+func ClassOf(endpoint string) EndpointClass {
+	if class, ok := endpointClasses[endpoint]; ok {
+		return class
+	}
+	return ClassBulk
+}
+
+// LimiterRegistry holds an optional rate limiter per endpoint class. It is
+// safe for concurrent use.
+type LimiterRegistry struct {
+	mu       sync.RWMutex
+	limiters map[EndpointClass]*rate.Limiter
+}
+
+// NewLimiterRegistry returns a registry with no class limiters, so only the
+// shared limiter applies.
+func NewLimiterRegistry() *LimiterRegistry {
+	return &LimiterRegistry{
+		limiters: make(map[EndpointClass]*rate.Limiter),
+	}
+}
+
+// Set registers the limiter for the class, replacing any existing one.
+func (r *LimiterRegistry) Set(
+	class EndpointClass,
+	limiter *rate.Limiter,
+) error {
+	if !validClass(class) {
+		return fmt.Errorf("%w: %q", ErrUnknownEndpointClass, class)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.limiters[class] = limiter
+	return nil
+}
+
+// Get returns the limiter for the endpoint's class, or nil if the class has
+// no dedicated limiter.
+func (r *LimiterRegistry) Get(endpoint string) *rate.Limiter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.limiters[ClassOf(endpoint)]
+}
+
+func validClass(class EndpointClass) bool {
+	for _, c := range EndpointClasses {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+// lineMovementWindow is how far ahead SnapshotWeekLines looks for games
+// whose lines to snapshot: a game week.
+const lineMovementWindow = 7 * 24 * time.Hour
+
+// SnapshotWeekLines snapshots the lines of every game kicking off within
+// the next week into game_line_snapshots, skipping lines that have not moved
+// since their last snapshot, and refreshes the current lines. Scheduled every
+// few hours in daemon mode, it records how spreads and totals move over the
+// week, where WatchClosingLines only follows them near kickoff.
+func (s *Seeder) SnapshotWeekLines(ctx context.Context) error {
+	return s.SnapshotUpcomingLines(ctx, lineMovementWindow)
+}
+
+// WatchClosingLines snapshots betting lines for games kicking off within the
+// window, every interval, until ctx is done. After each pass, the last
+// snapshot taken before kickoff of every game that has started is marked as
+// its closing line. Failures are logged and retried on the next tick.
+func (s *Seeder) WatchClosingLines(
+	ctx context.Context,
+	window, interval time.Duration,
+) error {
+	if window <= 0 {
+		return fmt.Errorf("invalid closing line window %s", window)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("invalid closing line interval %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SnapshotUpcomingLines(ctx, window); err != nil {
+			slog.Warn("closing line snapshot failed", "err", err)
+		}
+
+		marked, err := s.db.MarkClosingLines(ctx)
+		if err != nil {
+			slog.Warn("failed to mark closing lines", "err", err)
+		} else if marked > 0 {
+			slog.Info("marked closing lines", "count", marked)
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("closing line watch stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// SnapshotUpcomingLines fetches the current lines for every game kicking off
+// within the window, appends those that moved to the snapshots table and
+// refreshes the current lines. Lines are requested once per week rather than per game.
+func (s *Seeder) SnapshotUpcomingLines(
+	ctx context.Context,
+	window time.Duration,
+) error {
+	ids, weeks, err := s.db.GetGamesKickingOffWithin(ctx, window)
+	if err != nil {
+		slog.Error("failed to get upcoming games", "err", err)
+		return fmt.Errorf("failed to get upcoming games; %w", err)
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	upcoming := make(map[int32]struct{}, len(ids))
+	for _, id := range ids {
+		upcoming[id] = struct{}{}
+	}
+
+	capturedAt := time.Now().UTC()
+	var all []*cfbd.BettingGame
+	for _, week := range weeks {
+		if err = s.throttle(ctx, endpointBettingLines); err != nil {
+			return fmt.Errorf("failed to wait for rate limit; %w", err)
+		}
+
+		lines, err := retryReq(
+			s, ctx, endpointBettingLines, s.api.GetBettingLines,
+			cfbd.GetBettingLinesRequest{
+				Year:       week.Season,
+				Week:       week.Week,
+				SeasonType: week.SeasonType,
+			},
+		)
+		if err != nil {
+			slog.Error(
+				"failed to get betting lines",
+				"year", int32ToString(week.Season),
+				"week", int32ToString(week.Week),
+				"err", err,
+			)
+			return fmt.Errorf(
+				"failed to get betting lines for %d week %d; %w",
+				week.Season, week.Week, err,
+			)
+		}
+
+		s.usage.Record(endpointBettingLines)
+		lines = transform(s, endpointBettingLines, lines)
+
+		for _, l := range lines {
+			if l == nil {
+				continue
+			}
+			if _, ok := upcoming[l.Id]; ok {
+				all = append(all, l)
+			}
+		}
+	}
+
+	if err = s.db.InsertGameLineSnapshots(ctx, all, capturedAt); err != nil {
+		slog.Error("failed to insert line snapshots", "err", err)
+		return fmt.Errorf("failed to insert line snapshots; %w", err)
+	}
+
+	if err = s.db.InsertBettingLines(ctx, all); err != nil {
+		slog.Error("failed to insert betting lines", "err", err)
+		return fmt.Errorf("failed to insert betting lines; %w", err)
+	}
+
+	slog.Info("snapshotted upcoming lines", "games", len(all))
+	return nil
+}
@@ -0,0 +1,269 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+)
+
+// planScope is what a seed function issues one request per.
+type planScope int
+
+const (
+	// perRun tasks make a single request regardless of the years seeded.
+	perRun planScope = iota
+	// perYear tasks make one request per season.
+	perYear
+	// perWeek tasks make one request per calendar week of each season.
+	perWeek
+	// perGame tasks make one request per game in each season.
+	perGame
+	// perFailure tasks make one request per unresolved unit in the failure
+	// ledger.
+	perFailure
+	// offline tasks make no requests.
+	offline
+)
+
+// planCost is the endpoint a seed function calls and what it calls it per.
+type planCost struct {
+	endpoint string
+	scope    planScope
+}
+
+// planTasks holds the projected cost of every task in Seeder.SeedTasks.
+var planTasks = map[string]planCost{
+	"SeedVenues":                   {endpointVenues, perRun},
+	"SeedPlayTypes":                {endpointPlayTypes, perRun},
+	"SeedStatTypes":                {endpointStatCategories, perRun},
+	"SeedDraftTeams":               {endpointDraftTeams, perRun},
+	"SeedConferences":              {endpointConferences, perRun},
+	"SeedFieldGoalEP":              {endpointFieldGoalEP, perRun},
+	"SeedDraftPositions":           {endpointDraftPositions, perRun},
+	"SeedTeams":                    {endpointTeams, perRun},
+	"SeedCalendar":                 {endpointCalendar, perYear},
+	"SeedGames":                    {endpointGames, perYear},
+	"SeedScoreboard":               {endpointScoreboard, perRun},
+	"SeedPlayerSearch":             {endpointRoster, perYear},
+	"SeedDrives":                   {endpointDrives, perYear},
+	"SeedPlays":                    {endpointPlays, perWeek},
+	"SeedPlayStats":                {endpointPlayStats, perWeek},
+	"SeedGameTeamStats":            {endpointGameTeams, perYear},
+	"SeedGamePlayerStats":          {endpointGamePlayers, perYear},
+	"SeedAdvancedBoxScore":         {endpointAdvancedBoxScore, perGame},
+	"SeedGameWeather":              {endpointGameWeather, perYear},
+	"SeedGameMedia":                {endpointGameMedia, perYear},
+	"SeedBettingLines":             {endpointBettingLines, perYear},
+	"SeedWinProbability":           {endpointWinProbability, perGame},
+	"SeedTeamRecords":              {endpointTeamRecords, perYear},
+	"SeedTeamTalentComposite":      {endpointTalent, perYear},
+	"SeedTeamATS":                  {endpointTeamATS, perYear},
+	"SeedTeamSPPlus":               {endpointSPPlus, perYear},
+	"SeedConferenceSPPlus":         {endpointConferenceSPPlus, perYear},
+	"SeedTeamSRSRankings":          {endpointSRS, perYear},
+	"SeedTeamEloRankings":          {endpointElo, perYear},
+	"SeedTeamFPIRankings":          {endpointFPI, perYear},
+	"SeedWepaTeamSeason":           {endpointWepaTeamSeason, perYear},
+	"SeedWepaPassing":              {endpointWepaPassing, perYear},
+	"SeedWepaRushing":              {endpointWepaRushing, perYear},
+	"SeedWepaKicking":              {endpointWepaKicking, perYear},
+	"SeedReturningProduction":      {endpointReturningProduction, perYear},
+	"SeedPortalPlayers":            {endpointTransferPortal, perYear},
+	"SeedSeasonPlayerStats":        {endpointPlayerSeasonStats, perYear},
+	"SeedSeasonTeamStats":          {endpointTeamSeasonStats, perYear},
+	"SeedRankings":                 {endpointRankings, perYear},
+	"SeedRecruits":                 {endpointRecruitingPlayers, perYear},
+	"SeedRecruitingRankings":       {endpointRecruitingTeams, perYear},
+	"SeedAggregatedTeamRecruiting": {endpointRecruitingGroups, perRun},
+	"SeedDraftPicks":               {endpointDraftPicks, perYear},
+	// RetryFailed calls whichever per-game endpoint each unit failed on.
+	"RetryFailed":          {"", perFailure},
+	"ComputeDataQuality":   {"", offline},
+	"ComputePlayFlags":     {"", offline},
+	"ComputeDriveStats":    {"", offline},
+	"ComputeTeamWeekStats": {"", offline},
+	"ComputeRatings":       {"", offline},
+	"ResolvePlayers":       {"", offline},
+	"ComputeTravel":        {"", offline},
+	// EnrichVenues calls the geocoder, not the API.
+	"EnrichVenues": {"", offline},
+	// BackfillWeather calls the weather provider, not the API.
+	"BackfillWeather": {"", offline},
+}
+
+// TaskPlan is the projected number of API requests for one seed function.
+type TaskPlan struct {
+	Phase    int
+	Task     string
+	Endpoint string
+	Requests int64
+	// Exact is false when the count depends on calendar weeks or games that
+	// have not been seeded for some year, in which case Requests is a lower
+	// bound.
+	Exact bool
+}
+
+// Plan is the projected API cost of a full seed.
+type Plan struct {
+	Years []int32
+	Tasks []TaskPlan
+	// Remaining is the number of API calls left for the key, or nil if it
+	// could not be fetched.
+	Remaining *int64
+}
+
+// Total returns the projected number of requests across every task.
+func (p Plan) Total() int64 {
+	var total int64
+	for _, task := range p.Tasks {
+		total += task.Requests
+	}
+	return total
+}
+
+// Plan computes how many API requests a full seed of the configured years
+// would make, per seed function. Per-week and per-game counts come from the
+// calendar weeks and games already in the database, and the retry pass from
+// the failures already in the ledger. Apart from a single
+// quota lookup it makes no API requests.
+func (s *Seeder) Plan(ctx context.Context) (Plan, error) {
+	plan := Plan{Years: s.years}
+
+	weeks := make(map[int32]int64, len(s.years))
+	games := make(map[int32]int64, len(s.years))
+	for _, year := range s.years {
+		count, err := s.countWeeks(ctx, year)
+		if err != nil {
+			return Plan{}, fmt.Errorf("failed to count weeks; %w", err)
+		}
+		weeks[year] = count
+
+		if count, err = s.countGames(ctx, year); err != nil {
+			return Plan{}, fmt.Errorf("failed to count games; %w", err)
+		}
+		games[year] = count
+	}
+
+	failures, err := s.db.GetUnresolvedSeedFailures(ctx)
+	if err != nil {
+		return Plan{}, fmt.Errorf("failed to get seed failures; %w", err)
+	}
+
+	levels, err := etl.Levels(s.SeedTasks())
+	if err != nil {
+		return Plan{}, err
+	}
+
+	years := int64(len(s.years))
+	for i, level := range levels {
+		for _, seedTask := range level {
+			plan.Tasks = append(plan.Tasks, s.planTask(
+				i+1, seedTask.Name, years, weeks, games, int64(len(failures)),
+			))
+		}
+	}
+
+	if remaining, err := s.remainingCalls(ctx); err == nil {
+		plan.Remaining = &remaining
+	}
+
+	return plan, nil
+}
+
+// planTask projects the requests of one task from the per-year week and game
+// counts and the number of unresolved failures. A task without a known cost
+// is reported as an inexact zero.
+func (s *Seeder) planTask(
+	phase int,
+	name string,
+	years int64,
+	weeks, games map[int32]int64,
+	failures int64,
+) TaskPlan {
+	t, ok := planTasks[name]
+	task := TaskPlan{
+		Phase:    phase,
+		Task:     name,
+		Endpoint: t.endpoint,
+		Exact:    ok,
+	}
+	if !ok {
+		return task
+	}
+
+	switch t.scope {
+	case perRun:
+		task.Requests = 1
+	case perYear:
+		task.Requests = years
+	case perWeek:
+		// One request per week; the weeks come from the calendar that
+		// SeedCalendar stores earlier in the run.
+		for _, year := range s.years {
+			task.Requests += weeks[year]
+			task.Exact = task.Exact && weeks[year] > 0
+		}
+	case perGame:
+		for _, year := range s.years {
+			task.Requests += games[year]
+			task.Exact = task.Exact && games[year] > 0
+		}
+	case perFailure:
+		task.Requests = failures
+	}
+	if scopedEndpoints[t.endpoint] {
+		task.Requests *= int64(len(s.scopes()))
+	}
+
+	return task
+}
+
+// Write prints the plan as a table of per-task request counts, followed by
+// per-phase and overall totals and how they compare to the remaining quota.
+func (p Plan) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Phase\tTask\tEndpoint\tRequests\t\n")
+
+	phaseTotals := make(map[int]int64)
+	inexact := false
+	for _, task := range p.Tasks {
+		requests := fmt.Sprint(task.Requests)
+		if !task.Exact {
+			requests = ">=" + requests
+			inexact = true
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t\n",
+			task.Phase, task.Task, task.Endpoint, requests)
+		phaseTotals[task.Phase] += task.Requests
+	}
+
+	fmt.Fprintf(tw, "\t\t\t\t\n")
+	for phase := 1; phase <= len(phaseTotals); phase++ {
+		fmt.Fprintf(tw, "%d\tphase total\t\t%d\t\n", phase, phaseTotals[phase])
+	}
+
+	total := p.Total()
+	fmt.Fprintf(tw, "\ttotal\t\t%d\t\n", total)
+	if p.Remaining != nil {
+		fmt.Fprintf(tw, "\tremaining quota\t\t%d\t\n", *p.Remaining)
+		fmt.Fprintf(tw, "\tafter seeding\t\t%d\t\n", *p.Remaining-total)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write plan; %w", err)
+	}
+
+	if inexact {
+		fmt.Fprintln(w, "\n>= marks tasks whose counts depend on calendar "+
+			"weeks or games not yet seeded for some years.")
+	}
+	if p.Remaining != nil && *p.Remaining < total {
+		fmt.Fprintln(w, "\nThe remaining quota does not cover this plan.")
+	}
+
+	return nil
+}
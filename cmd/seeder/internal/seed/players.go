@@ -0,0 +1,96 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/identity"
+)
+
+// ResolvePlayers rebuilds player_identities, one row per athlete, and
+// player_crosswalk, which maps the rows of every table that records
+// athletes to them, from everything stored so far. Roster, player search
+// and season stats rows, and recruits and draft picks with an athlete ID,
+// identify their player; recruits listed on a roster player are linked to
+// them; and the remaining recruits, draft picks and transfers are matched
+// by name, team and season. It makes no API requests.
+func (s *Seeder) ResolvePlayers(ctx context.Context) error {
+	stored, err := s.db.GetPlayerRecords(ctx)
+	if err != nil {
+		slog.Error("failed to get player records", "err", err)
+		return fmt.Errorf("failed to get player records; %w", err)
+	}
+
+	// Recruits a roster player lists are theirs, even without an athlete
+	// ID of their own.
+	recruitPlayers := make(map[string]string)
+	for _, r := range stored {
+		for _, id := range r.RecruitIDs {
+			recruitPlayers[id] = r.AthleteID
+		}
+	}
+
+	records := make([]identity.Record, 0, len(stored))
+	for _, r := range stored {
+		record := identity.Record{
+			Source:   r.Source,
+			SourceID: r.SourceID,
+			Name:     r.Name,
+			Position: r.Position,
+			Teams:    r.Teams,
+			Year:     r.Year,
+		}
+		switch {
+		case r.AthleteID != "":
+			record.PlayerID, record.Method = r.AthleteID, identity.MethodID
+		case r.Source == (db.Recruit{}).TableName() &&
+			recruitPlayers[r.SourceID] != "":
+			record.PlayerID = recruitPlayers[r.SourceID]
+			record.Method = identity.MethodRecruitID
+		}
+		records = append(records, record)
+	}
+
+	result := identity.Resolve(records)
+
+	now := time.Now().UTC()
+	players := make([]db.PlayerIdentity, 0, len(result.Players))
+	for _, p := range result.Players {
+		players = append(players, db.PlayerIdentity{
+			ID:        p.ID,
+			Name:      p.Name,
+			Position:  p.Position,
+			Team:      p.Team,
+			UpdatedAt: now,
+		})
+	}
+	crosswalk := make([]db.PlayerCrosswalk, 0, len(result.Links))
+	for _, l := range result.Links {
+		crosswalk = append(crosswalk, db.PlayerCrosswalk{
+			Source:    l.Source,
+			SourceID:  l.SourceID,
+			PlayerID:  l.PlayerID,
+			Method:    l.Method,
+			UpdatedAt: now,
+		})
+	}
+
+	err = s.db.ReplacePlayerIdentities(ctx, players, crosswalk)
+	if err != nil {
+		slog.Error("failed to store player identities", "err", err)
+		return fmt.Errorf("failed to store player identities; %w", err)
+	}
+
+	for source, count := range result.Unresolved {
+		slog.Warn("unresolved player records", "source", source, "count", count)
+	}
+	slog.Info(
+		"resolved players",
+		"players", len(players),
+		"links", len(crosswalk),
+	)
+	return nil
+}
@@ -0,0 +1,37 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+)
+
+// ComputePlayFlags derives the success, garbage_time, rush_pass and
+// down_type columns of every stored play of each selected season, which the
+// API only reports for live plays. It makes no API requests.
+func (s *Seeder) ComputePlayFlags(ctx context.Context) error {
+	var total int64
+	for _, year := range s.years {
+		if err := etl.Err(ctx); err != nil {
+			return err
+		}
+
+		updated, err := s.db.ComputePlayFlags(ctx, year)
+		if err != nil {
+			slog.Error(
+				"failed to compute play flags",
+				"year", int32ToString(year),
+				"err", err,
+			)
+			return fmt.Errorf(
+				"failed to compute play flags for %d; %w", year, err,
+			)
+		}
+		total += updated
+	}
+
+	slog.Info("computed play flags", "years", len(s.years), "plays", total)
+	return nil
+}
@@ -0,0 +1,35 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+)
+
+// ComputeDataQuality records how completely each selected season is
+// populated (games with line scores, plays with PPA, and games with weather
+// and betting lines) in data_quality, so consumers can tell which seasons
+// are reliable. It makes no API requests.
+func (s *Seeder) ComputeDataQuality(ctx context.Context) error {
+	for _, year := range s.years {
+		if err := etl.Err(ctx); err != nil {
+			return err
+		}
+
+		if err := s.db.ComputeDataQuality(ctx, year); err != nil {
+			slog.Error(
+				"failed to compute data quality",
+				"year", int32ToString(year),
+				"err", err,
+			)
+			return fmt.Errorf(
+				"failed to compute data quality for %d; %w", year, err,
+			)
+		}
+	}
+
+	slog.Info("computed data quality", "years", len(s.years))
+	return nil
+}
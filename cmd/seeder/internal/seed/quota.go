@@ -0,0 +1,117 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// minQuotaRate is the slowest rate the quota governor will throttle to before
+// pausing outright.
+const minQuotaRate = rate.Limit(1)
+
+// QuotaPolicy configures how the seeder reacts to a shrinking API quota.
+type QuotaPolicy struct {
+	// CheckEvery is how often the remaining quota is polled.
+	CheckEvery time.Duration
+	// SlowBelow is the remaining-call count below which the request rate is
+	// scaled down proportionally to the quota left.
+	SlowBelow int64
+	// PauseBelow is the remaining-call count below which seeding is paused
+	// until the quota recovers (e.g. when the monthly cap resets).
+	PauseBelow int64
+}
+
+// GovernQuota polls the remaining API quota until ctx is done, adjusting the
+// seeder's request rate to stay under the monthly cap. Above SlowBelow the
+// original rate is used; between PauseBelow and SlowBelow the rate is scaled
+// down linearly; below PauseBelow every request is held until a later poll
+// finds the quota safe again.
+func (s *Seeder) GovernQuota(ctx context.Context, policy QuotaPolicy) error {
+	if policy.CheckEvery <= 0 {
+		return fmt.Errorf("invalid quota check interval %s", policy.CheckEvery)
+	}
+	if policy.PauseBelow >= policy.SlowBelow {
+		return fmt.Errorf(
+			"quota pause threshold %d must be below slow threshold %d",
+			policy.PauseBelow, policy.SlowBelow,
+		)
+	}
+
+	baseRate := s.requests.Throttler.Limit()
+	defer s.setQuotaRate(baseRate, false)
+
+	ticker := time.NewTicker(policy.CheckEvery)
+	defer ticker.Stop()
+
+	for {
+		if remaining, err := s.remainingCalls(ctx); err != nil {
+			slog.Warn("failed to check api quota", "err", err)
+		} else {
+			s.applyQuotaPolicy(policy, baseRate, remaining)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// remainingCalls fetches the number of API calls left for the current key.
+// It bypasses the throttle so that it still runs while seeding is paused.
+func (s *Seeder) remainingCalls(ctx context.Context) (int64, error) {
+	info, err := s.api.GetInfo(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user info; %w", err)
+	}
+
+	s.usage.Record(endpointUserInfo)
+
+	if info == nil {
+		return 0, fmt.Errorf("failed to get user info; %w", ErrAPIUnavailable)
+	}
+
+	remaining := int64(info.RemainingCalls)
+	s.progress.SetQuotaRemaining(remaining)
+	return remaining, nil
+}
+
+// applyQuotaPolicy picks the request rate for the remaining quota.
+func (s *Seeder) applyQuotaPolicy(
+	policy QuotaPolicy,
+	baseRate rate.Limit,
+	remaining int64,
+) {
+	switch {
+	case remaining < policy.PauseBelow:
+		s.setQuotaRate(baseRate, true)
+		slog.Warn(
+			"api quota nearly exhausted; pausing seeding",
+			"remaining_calls", remaining,
+		)
+	case remaining < policy.SlowBelow:
+		headroom := float64(remaining-policy.PauseBelow) /
+			float64(policy.SlowBelow-policy.PauseBelow)
+		limit := max(rate.Limit(float64(baseRate)*headroom), minQuotaRate)
+		s.setQuotaRate(limit, false)
+		slog.Warn(
+			"api quota running low; slowing seeding",
+			"remaining_calls", remaining,
+			"rate", float64(limit),
+		)
+	default:
+		s.setQuotaRate(baseRate, false)
+	}
+}
+
+// setQuotaRate updates the throttle's rate and pauses or resumes requests.
+func (s *Seeder) setQuotaRate(limit rate.Limit, paused bool) {
+	if s.requests.Throttler.SetRate(limit, paused) {
+		slog.Info("api quota recovered; resuming seeding")
+	}
+}
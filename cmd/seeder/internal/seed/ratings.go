@@ -0,0 +1,102 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/ratings"
+)
+
+// SetRatingParams replaces the parameters ComputeRatings rates games with.
+// Zero values fall back to the defaults.
+func (s *Seeder) SetRatingParams(params ratings.Params) {
+	s.ratingParams = params
+}
+
+// ComputeRatings derives Elo and SRS ratings for each selected season from
+// the stored game results, into local_game_elo and local_team_ratings, so
+// that ratings are current as soon as scores are, rather than when the API
+// next updates its own. Seasons are rated in order, each starting from the
+// locally computed ratings the season before ended with, if any. It makes
+// no API requests.
+func (s *Seeder) ComputeRatings(ctx context.Context) error {
+	for _, year := range s.years {
+		if err := etl.Err(ctx); err != nil {
+			return err
+		}
+
+		if err := s.computeSeasonRatings(ctx, year); err != nil {
+			slog.Error(
+				"failed to compute ratings",
+				"year", int32ToString(year),
+				"err", err,
+			)
+			return fmt.Errorf("failed to compute ratings for %d; %w", year, err)
+		}
+	}
+
+	slog.Info("computed ratings", "years", len(s.years))
+	return nil
+}
+
+// computeSeasonRatings rates the completed games of a season.
+func (s *Seeder) computeSeasonRatings(ctx context.Context, year int32) error {
+	stored, err := s.db.GetRatedGames(ctx, year)
+	if err != nil {
+		return err
+	}
+	previous, err := s.db.GetLocalElo(ctx, year-1)
+	if err != nil {
+		return err
+	}
+
+	games := make([]ratings.Game, 0, len(stored))
+	for _, g := range stored {
+		games = append(games, ratings.Game{
+			ID:                 g.ID,
+			HomeTeam:           g.HomeTeam,
+			AwayTeam:           g.AwayTeam,
+			HomeClassification: g.HomeClassification,
+			AwayClassification: g.AwayClassification,
+			HomePoints:         *g.HomePoints,
+			AwayPoints:         *g.AwayPoints,
+			NeutralSite:        g.NeutralSite,
+		})
+	}
+	gameElo, teams := ratings.Season(games, previous, s.ratingParams)
+
+	now := time.Now().UTC()
+	gameRows := make([]db.LocalGameElo, 0, len(gameElo))
+	for i, e := range gameElo {
+		gameRows = append(gameRows, db.LocalGameElo{
+			GameID:          e.GameID,
+			Season:          year,
+			HomeTeam:        games[i].HomeTeam,
+			AwayTeam:        games[i].AwayTeam,
+			HomePregameElo:  e.HomePregame,
+			AwayPregameElo:  e.AwayPregame,
+			HomePostgameElo: e.HomePost,
+			AwayPostgameElo: e.AwayPost,
+			ComputedAt:      now,
+		})
+	}
+	teamRows := make([]db.LocalTeamRating, 0, len(teams))
+	for _, t := range teams {
+		teamRows = append(teamRows, db.LocalTeamRating{
+			Season:     year,
+			Team:       t.Team,
+			Games:      int32(t.Games), //nolint:gosec // a season's games fit
+			Elo:        t.Elo,
+			Margin:     t.Margin,
+			SOS:        t.SOS,
+			SRS:        t.SRS,
+			ComputedAt: now,
+		})
+	}
+
+	return s.db.ReplaceLocalRatings(ctx, year, gameRows, teamRows)
+}
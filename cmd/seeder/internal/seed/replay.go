@@ -0,0 +1,276 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/storage"
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+// replayer inserts the archived responses of one endpoint.
+type replayer struct {
+	endpoint string
+	insert   func(ctx context.Context, response ArchivedResponse) error
+}
+
+// replayRecords returns a replayer inserting the records of an endpoint's
+// responses with insert, after running the endpoint's transforms over them,
+// as a seed would.
+func replayRecords[T any](
+	s *Seeder,
+	endpoint string,
+	insert func(context.Context, []T) error,
+) replayer {
+	return replayRequest(s, endpoint,
+		func(ctx context.Context, _ struct{}, records []T) error {
+			return insert(ctx, records)
+		},
+	)
+}
+
+// replayRequest is replayRecords for inserts that also need the request the
+// records were fetched with, e.g. for the season of plays.
+func replayRequest[R, T any](
+	s *Seeder,
+	endpoint string,
+	insert func(context.Context, R, []T) error,
+) replayer {
+	return replayer{
+		endpoint: endpoint,
+		insert: func(ctx context.Context, response ArchivedResponse) error {
+			var req R
+			if len(response.Params) > 0 && string(response.Params) != "null" {
+				if err := json.Unmarshal(response.Params, &req); err != nil {
+					return fmt.Errorf("failed to decode request; %w", err)
+				}
+			}
+
+			var records []T
+			if err := decodePayload(response.Payload, &records); err != nil {
+				return fmt.Errorf("failed to decode payload; %w", err)
+			}
+
+			return insert(ctx, req, transform(s, endpoint, records))
+		},
+	}
+}
+
+// replayers returns a replayer for every archived endpoint that seeds a
+// table, in the order a full seed inserts them, so rows are inserted after
+// the rows they reference.
+func (s *Seeder) replayers() []replayer {
+	return []replayer{
+		// Global lookups
+		replayRecords(s, endpointVenues, s.db.InsertVenues),
+		replayRecords(s, endpointPlayTypes, s.db.InsertPlayTypes),
+		replayRecords(s, endpointStatCategories, s.db.InsertPlayStatTypes),
+		replayRecords(s, endpointDraftTeams, s.db.InsertDraftTeams),
+		replayRecords(s, endpointConferences, s.db.InsertConferences),
+		replayRecords(s, endpointFieldGoalEP, s.db.InsertFieldGoalEP),
+		replayRecords(s, endpointDraftPositions, s.db.InsertDraftPositions),
+
+		// Teams
+		replayRecords(s, endpointTeams, s.db.InsertTeams),
+
+		// Calendars and games
+		replayRecords(s, endpointCalendar, s.db.InsertCalendarWeeks),
+		replayRecords(s, endpointGames, s.db.InsertGames),
+		replayRecords(s, endpointScoreboard, s.db.InsertScoreboard),
+		replayRecords(s, endpointRoster, s.db.InsertRosterPlayers),
+
+		// Week and game stats
+		replayRequest(s, endpointDrives,
+			func(
+				ctx context.Context,
+				req cfbd.GetDrivesRequest,
+				drives []*cfbd.Drive,
+			) error {
+				return s.db.InsertDrives(ctx, req.Year, drives)
+			},
+		),
+		replayRequest(s, endpointPlays,
+			func(
+				ctx context.Context,
+				req cfbd.GetPlaysRequest,
+				plays []*cfbd.Play,
+			) error {
+				return s.db.InsertPlays(ctx, req.Year, plays)
+			},
+		),
+		replayRecords(s, endpointPlayStats, s.db.InsertPlayStats),
+		replayRecords(s, endpointGameTeams, s.db.InsertGameTeamStats),
+		replayRecords(s, endpointGamePlayers, s.db.InsertGamePlayerStats),
+		replayAdvancedBoxScore(s),
+		replayRecords(s, endpointGameWeather, s.db.InsertGameWeather),
+		replayRecords(s, endpointGameMedia, s.db.InsertGameMedia),
+		replayRecords(s, endpointBettingLines, s.db.InsertBettingLines),
+		replayRecords(s, endpointWinProbability, s.db.InsertPlayWinProbability),
+		replayLiveGame(s),
+
+		// Season stats
+		replayRecords(s, endpointTeamRecords, s.db.InsertTeamRecords),
+		replayRecords(s, endpointTalent, s.db.InsertTeamTalent),
+		replayRecords(s, endpointTeamATS, s.db.InsertTeamATS),
+		replayRecords(s, endpointSPPlus, s.db.InsertTeamSP),
+		replayRecords(s, endpointConferenceSPPlus, s.db.InsertConferenceSP),
+		replayRecords(s, endpointSRS, s.db.InsertTeamSRS),
+		replayRecords(s, endpointElo, s.db.InsertTeamElo),
+		replayRecords(s, endpointFPI, s.db.InsertTeamFPI),
+		replayRecords(s, endpointWepaTeamSeason, s.db.InsertAdjustedTeamMetrics),
+		replayRecords(s, endpointWepaPassing, s.db.InsertPlayerWeightedEPA),
+		replayRecords(s, endpointWepaRushing, s.db.InsertPlayerWeightedEPA),
+		replayRecords(s, endpointWepaKicking, s.db.InsertKickerPAAR),
+		replayRecords(
+			s, endpointReturningProduction, s.db.InsertReturningProduction,
+		),
+		replayRecords(s, endpointTransferPortal, s.db.InsertPlayerTransfers),
+		replayRecords(s, endpointPlayerSeasonStats, s.db.InsertPlayerStats),
+		replayRecords(s, endpointTeamSeasonStats, s.db.InsertTeamStats),
+		replayRecords(s, endpointRankings, s.db.InsertRankings),
+
+		// Recruiting and draft
+		replayRecords(s, endpointRecruitingPlayers, s.db.InsertRecruits),
+		replayRecords(
+			s, endpointRecruitingTeams, s.db.InsertTeamRecruitingRankings,
+		),
+		replayRecords(s, endpointRecruitingGroups,
+			func(
+				ctx context.Context,
+				groups []*cfbd.AggregatedTeamRecruiting,
+			) error {
+				return s.db.InsertAggregatedTeamRecruiting(
+					ctx, s.filterPositionGroups(groups),
+				)
+			},
+		),
+		replayRecords(s, endpointDraftPicks, s.db.InsertDraftPicks),
+	}
+}
+
+// replayAdvancedBoxScore returns the replayer of advanced box scores, which
+// the API returns one game at a time.
+func replayAdvancedBoxScore(s *Seeder) replayer {
+	return replayer{
+		endpoint: endpointAdvancedBoxScore,
+		insert: func(ctx context.Context, response ArchivedResponse) error {
+			var req cfbd.GetAdvancedBoxScoreRequest
+			if err := json.Unmarshal(response.Params, &req); err != nil {
+				return fmt.Errorf("failed to decode request; %w", err)
+			}
+			var score *cfbd.AdvancedBoxScore
+			if err := decodePayload(response.Payload, &score); err != nil {
+				return fmt.Errorf("failed to decode payload; %w", err)
+			}
+
+			return s.db.InsertAdvancedBoxScores(ctx,
+				map[int32]*cfbd.AdvancedBoxScore{req.GameID: score})
+		},
+	}
+}
+
+// replayLiveGame returns the replayer of live play-by-play, which the API
+// returns one game at a time.
+func replayLiveGame(s *Seeder) replayer {
+	return replayer{
+		endpoint: endpointLivePlays,
+		insert: func(ctx context.Context, response ArchivedResponse) error {
+			var game *cfbd.LiveGame
+			if err := decodePayload(response.Payload, &game); err != nil {
+				return fmt.Errorf("failed to decode payload; %w", err)
+			}
+
+			return s.db.InsertLiveGame(ctx, game)
+		},
+	}
+}
+
+// Replay seeds the database from the responses archived in source (see
+// SetArchive) instead of the API, feeding them through the same transforms
+// and inserts as a seed. Only the latest response archived for each request
+// is replayed, and endpoints are replayed in the order a full seed inserts
+// them. Responses of endpoints that seed no table, such as the API key's
+// quota, are skipped. No API request is made, so the seeder may be created
+// without an API key.
+func (s *Seeder) Replay(ctx context.Context, source storage.Store) error {
+	names, err := source.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list archived responses; %w", err)
+	}
+
+	// Archived responses are named <endpoint>/<params>/<fetched at>.json,
+	// so the last name of every directory is its request's latest response.
+	latest := make(map[string]string)
+	for _, name := range names {
+		if path.Ext(name) == ".json" {
+			latest[path.Dir(name)] = name
+		}
+	}
+	byEndpoint := make(map[string][]string)
+	for _, name := range names {
+		if latest[path.Dir(name)] != name {
+			continue
+		}
+		endpoint := "/" + path.Dir(path.Dir(name))
+		byEndpoint[endpoint] = append(byEndpoint[endpoint], name)
+	}
+
+	replayed := 0
+	for _, r := range s.replayers() {
+		for _, name := range byEndpoint[r.endpoint] {
+			if err = s.replayFile(ctx, source, r, name); err != nil {
+				slog.Error("failed to replay response", "name", name, "err", err)
+				return fmt.Errorf("failed to replay %s; %w", name, err)
+			}
+		}
+		if n := len(byEndpoint[r.endpoint]); n > 0 {
+			slog.Info("replayed", "endpoint", r.endpoint, "responses", n)
+			replayed += n
+		}
+		delete(byEndpoint, r.endpoint)
+	}
+
+	for endpoint, files := range byEndpoint {
+		slog.Info(
+			"skipped archived responses",
+			"endpoint", endpoint,
+			"responses", len(files),
+		)
+	}
+
+	if err = s.db.RefreshPlayerSearch(ctx); err != nil {
+		slog.Error("failed to refresh player search", "err", err)
+		return fmt.Errorf("failed to refresh player search; %w", err)
+	}
+
+	slog.Info("replay complete", "responses", replayed)
+	return nil
+}
+
+// replayFile inserts the archived response in the file.
+func (s *Seeder) replayFile(
+	ctx context.Context,
+	source storage.Store,
+	r replayer,
+	name string,
+) error {
+	file, err := source.Open(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var response ArchivedResponse
+	if err = json.NewDecoder(file).Decode(&response); err != nil {
+		return fmt.Errorf("failed to decode archived response; %w", err)
+	}
+	if response.Endpoint != r.endpoint {
+		return fmt.Errorf("archived response is of %s, not %s",
+			response.Endpoint, r.endpoint)
+	}
+
+	return r.insert(ctx, response)
+}
@@ -0,0 +1,60 @@
+package seed
+
+import (
+	"context"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SetRetryPolicy replaces the policy used to retry failed API requests. It
+// must be called before seeding starts.
+func (s *Seeder) SetRetryPolicy(policy etl.RetryPolicy) {
+	s.requests.Policy = policy
+}
+
+// SetBreakerPolicy replaces the circuit breaker policy. It must be called
+// before seeding starts.
+func (s *Seeder) SetBreakerPolicy(policy etl.BreakerPolicy) {
+	s.requests.Breaker = etl.NewCircuitBreaker(policy)
+}
+
+// retry calls fn through the seeder's requester; see etl.Retry. The
+// response is archived if an archive is set.
+func retry[T any](
+	s *Seeder,
+	ctx context.Context,
+	endpoint string,
+	fn func(context.Context) (T, error),
+	attrs ...attribute.KeyValue,
+) (T, error) {
+	response, err := etl.Retry(ctx, s.requests, endpoint, fn, attrs...)
+	if err == nil {
+		archiveResponse(s, ctx, endpoint, nil, response)
+	}
+
+	return response, err
+}
+
+// retryReq is retry for API calls that take a request struct, which is
+// archived along with the response.
+func retryReq[R, T any](
+	s *Seeder,
+	ctx context.Context,
+	endpoint string,
+	fn func(context.Context, R) (T, error),
+	req R,
+) (T, error) {
+	response, err := etl.Retry(
+		ctx, s.requests, endpoint,
+		func(ctx context.Context) (T, error) {
+			return fn(ctx, req)
+		},
+		requestAttributes(req)...,
+	)
+	if err == nil {
+		archiveResponse(s, ctx, endpoint, req, response)
+	}
+
+	return response, err
+}
@@ -0,0 +1,37 @@
+package seed
+
+import (
+	"context"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+)
+
+// StartRun records the start of a run of the command in the run history.
+func (s *Seeder) StartRun(ctx context.Context, command string) error {
+	return s.run.Start(ctx, command)
+}
+
+// SaveManifest records the last write time and rows written by this run for
+// every table written so far.
+func (s *Seeder) SaveManifest(ctx context.Context) error {
+	return s.run.SaveManifest(ctx)
+}
+
+// RunSummary returns what the run has done so far, per task and in total,
+// with the outcome given by runErr.
+func (s *Seeder) RunSummary(runErr error) etl.RunSummary {
+	return s.run.Summary(runErr)
+}
+
+// FinishRun saves the table manifest and marks the run as succeeded,
+// stopped if runErr wraps etl.ErrStopped, or failed if runErr is not nil.
+// The summary, if not nil, is kept with the run. A stopped run also
+// checkpoints the tasks it completed, for the next run to resume from; any
+// other run clears the checkpoint, as it left nothing to resume.
+func (s *Seeder) FinishRun(
+	ctx context.Context,
+	runErr error,
+	summary *etl.RunSummary,
+) error {
+	return s.run.Finish(ctx, runErr, summary)
+}
@@ -0,0 +1,194 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+)
+
+var (
+	// ErrUnknownTask is returned when a task name does not match any task.
+	ErrUnknownTask = errors.New("unknown task")
+	// ErrInvalidTaskScope is returned when a task cannot be narrowed to the
+	// requested year or week.
+	ErrInvalidTaskScope = errors.New("invalid task scope")
+)
+
+// TaskSpec identifies a single unit of work for RunTask.
+type TaskSpec struct {
+	// Name is the task to run, either as its method name ("SeedPlays") or in
+	// snake case ("seed_plays").
+	Name string
+	// Year narrows the task to one season; zero runs the configured years.
+	Year int32
+	// Week narrows the task to one week of Year; zero runs every week. Only
+	// week-scoped tasks (see Seeder.WeekTaskNames) accept a week.
+	Week int32
+	// SeasonType is the season type of Week, e.g. "regular" or "postseason".
+	SeasonType string
+}
+
+// TaskResult is the machine-readable outcome of RunTask.
+type TaskResult struct {
+	Task          string           `json:"task"`
+	Year          int32            `json:"year,omitempty"`
+	Week          int32            `json:"week,omitempty"`
+	SeasonType    string           `json:"season_type,omitempty"`
+	Status        string           `json:"status"`
+	Error         string           `json:"error,omitempty"`
+	StartedAt     time.Time        `json:"started_at"`
+	DurationMS    int64            `json:"duration_ms"`
+	Requests      int64            `json:"requests"`
+	RequestErrors int64            `json:"request_errors"`
+	RowsWritten   int64            `json:"rows_written"`
+	TableRows     map[string]int64 `json:"table_rows"`
+}
+
+// RunTask runs exactly one task, narrowed to the spec's year and week, so
+// an external orchestrator can own the DAG while reusing the seeder's fetch
+// and insert logic. An invalid spec is returned as an error; a task that
+// runs and fails is reported in the result, along with the requests it made
+// and rows it wrote.
+func (s *Seeder) RunTask(
+	ctx context.Context,
+	spec TaskSpec,
+) (TaskResult, error) {
+	name, err := s.resolveTask(spec.Name)
+	if err != nil {
+		return TaskResult{}, err
+	}
+
+	var task func(context.Context) error
+	switch {
+	case spec.Week != 0:
+		if spec.Year == 0 {
+			return TaskResult{}, fmt.Errorf(
+				"%w: a week requires a year", ErrInvalidTaskScope,
+			)
+		}
+		task = s.weekTasks(db.CalendarWeek{
+			Season:     spec.Year,
+			Week:       spec.Week,
+			SeasonType: spec.SeasonType,
+		})[name]
+		if task == nil {
+			return TaskResult{}, fmt.Errorf(
+				"%w: %s cannot run for a single week; week tasks are %s",
+				ErrInvalidTaskScope, name, strings.Join(s.WeekTaskNames(), ", "),
+			)
+		}
+	case spec.Year != 0:
+		if err = s.SetYears([]int32{spec.Year}); err != nil {
+			return TaskResult{}, err
+		}
+		task = s.Tasks()[name]
+	default:
+		task = s.Tasks()[name]
+	}
+
+	result := TaskResult{
+		Task:      spec.Name,
+		Year:      spec.Year,
+		Week:      spec.Week,
+		Status:    db.RunSucceeded,
+		StartedAt: time.Now(),
+	}
+	if spec.Week != 0 {
+		result.SeasonType = spec.SeasonType
+	}
+
+	err = s.progress.Track(etl.Task{Name: name, Run: task})(ctx)
+	if err != nil {
+		result.Status = db.RunFailed
+		result.Error = err.Error()
+	}
+	result.DurationMS = time.Since(result.StartedAt).Milliseconds()
+
+	for _, usage := range s.usage.Snapshot() {
+		result.Requests += usage.Requests
+		result.RequestErrors += usage.Errors
+	}
+	snap := s.progress.Snapshot()
+	result.RowsWritten = snap.RowsWritten
+	result.TableRows = snap.TableRows
+
+	return result, nil
+}
+
+// resolveTask returns the method name of the task, matching snake case and
+// method names alike.
+func (s *Seeder) resolveTask(name string) (string, error) {
+	key := taskKey(name)
+	for _, task := range s.TaskNames() {
+		if taskKey(task) == key {
+			return task, nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"%w: %q; must be one of %s",
+		ErrUnknownTask, name, strings.Join(s.TaskNames(), ", "),
+	)
+}
+
+// taskKey normalizes a task name so "seed_plays" matches "SeedPlays".
+func taskKey(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// WeekTaskNames returns the names of the tasks that can be narrowed to a
+// single week, sorted.
+func (s *Seeder) WeekTaskNames() []string {
+	tasks := s.weekTasks(db.CalendarWeek{})
+	names := make([]string, 0, len(tasks))
+	for name := range tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Write prints the result as an aligned table of fields.
+func (r TaskResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "task\t%s\n", r.Task)
+	if r.Year != 0 {
+		fmt.Fprintf(tw, "year\t%d\n", r.Year)
+	}
+	if r.Week != 0 {
+		fmt.Fprintf(tw, "week\t%d (%s)\n", r.Week, r.SeasonType)
+	}
+	fmt.Fprintf(tw, "status\t%s\n", r.Status)
+	if r.Error != "" {
+		fmt.Fprintf(tw, "error\t%s\n", r.Error)
+	}
+	fmt.Fprintf(tw, "duration\t%s\n",
+		(time.Duration(r.DurationMS) * time.Millisecond).String())
+	fmt.Fprintf(tw, "requests\t%d (%d errors)\n", r.Requests, r.RequestErrors)
+	fmt.Fprintf(tw, "rows written\t%d\n", r.RowsWritten)
+
+	tables := make([]string, 0, len(r.TableRows))
+	for table := range r.TableRows {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		fmt.Fprintf(tw, "  %s\t%d\n", table, r.TableRows[table])
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write task result; %w", err)
+	}
+
+	return nil
+}
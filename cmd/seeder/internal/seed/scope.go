@@ -0,0 +1,143 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"google.golang.org/protobuf/proto"
+)
+
+// seedScope is the team or conference one request of a targeted seed asks
+// for. The zero value asks for everything.
+type seedScope struct {
+	team       string
+	conference string
+}
+
+// SetScope targets the seed at the games of the teams and conferences,
+// such as one conference's games for a focused study. The game-level
+// endpoints (games, drives, plays, play and game stats, weather, media,
+// lines, records and season stats) are requested once per team and per
+// conference instead of once for everything, and the per-game tasks only
+// fetch the stored games either team of which is selected. With neither,
+// which is the default, nothing is targeted. Conferences are given as the
+// API abbreviates them, e.g. "SEC" or "B1G".
+func (s *Seeder) SetScope(teams, conferences []string) {
+	s.teams = trimmed(teams)
+	s.conferences = trimmed(conferences)
+}
+
+// scopedEndpoints are the endpoints requested once per scope.
+var scopedEndpoints = map[string]bool{
+	endpointGames:             true,
+	endpointDrives:            true,
+	endpointPlays:             true,
+	endpointPlayStats:         true,
+	endpointGameTeams:         true,
+	endpointGamePlayers:       true,
+	endpointGameWeather:       true,
+	endpointGameMedia:         true,
+	endpointBettingLines:      true,
+	endpointTeamRecords:       true,
+	endpointPlayerSeasonStats: true,
+	endpointTeamSeasonStats:   true,
+}
+
+// scopes returns the scope of each request a targeted endpoint makes per
+// season: one per team and one per conference, or a single unscoped one.
+func (s *Seeder) scopes() []seedScope {
+	if len(s.teams) == 0 && len(s.conferences) == 0 {
+		return []seedScope{{}}
+	}
+
+	scopes := make([]seedScope, 0, len(s.teams)+len(s.conferences))
+	for _, team := range s.teams {
+		scopes = append(scopes, seedScope{team: team})
+	}
+	for _, conference := range s.conferences {
+		scopes = append(scopes, seedScope{conference: conference})
+	}
+
+	return scopes
+}
+
+// gameFilter returns the filter selecting the stored games a targeted seed
+// covers.
+func (s *Seeder) gameFilter() db.GameFilter {
+	return db.GameFilter{
+		SeasonTypes:    s.seasonTypes(),
+		Teams:          s.teams,
+		Conferences:    s.conferences,
+		Classification: s.classification,
+	}
+}
+
+// countGames counts the season's stored games the seed covers.
+func (s *Seeder) countGames(ctx context.Context, year int32) (int64, error) {
+	return s.db.CountGames(ctx, year, s.gameFilter())
+}
+
+// retryScoped is retryReq for the endpoints a seed can be targeted with:
+// it throttles and makes the request built for each of the seed's scopes,
+// records its usage, and returns the records of every response. Records
+// that more than one scope returned, such as a game between two selected
+// teams, are kept once, so that they can be upserted together.
+func retryScoped[R any, T proto.Message](
+	s *Seeder,
+	ctx context.Context,
+	endpoint string,
+	fn func(context.Context, R) ([]T, error),
+	request func(seedScope) R,
+) ([]T, error) {
+	scopes := s.scopes()
+	var (
+		records []T
+		seen    map[string]bool
+	)
+	if len(scopes) > 1 {
+		seen = make(map[string]bool)
+	}
+
+	for _, scope := range scopes {
+		if err := s.throttle(ctx, endpoint); err != nil {
+			return nil, fmt.Errorf("failed to wait for rate limit; %w", err)
+		}
+
+		response, err := retryReq(s, ctx, endpoint, fn, request(scope))
+		if err != nil {
+			return nil, err
+		}
+		s.usage.Record(endpoint)
+
+		if seen == nil {
+			return response, nil
+		}
+		for _, record := range response {
+			key, err := proto.MarshalOptions{Deterministic: true}.
+				Marshal(record)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode record; %w", err)
+			}
+			if !seen[string(key)] {
+				seen[string(key)] = true
+				records = append(records, record)
+			}
+		}
+	}
+
+	return records, nil
+}
+
+// trimmed returns the non-empty values, trimmed of spaces.
+func trimmed(values []string) []string {
+	var kept []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			kept = append(kept, v)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,82 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Season types a seed can be limited to.
+const (
+	SeasonTypeRegular    = "regular"
+	SeasonTypePostseason = "postseason"
+	// SeasonTypeBoth is the API's season type for the regular season and
+	// the postseason together.
+	SeasonTypeBoth = "both"
+)
+
+// ErrUnknownSeasonType is returned for a season type other than regular,
+// postseason or both.
+var ErrUnknownSeasonType = errors.New("unknown season type")
+
+// SetSeasonTypes limits the seed to the games of the given season types:
+// regular, postseason, or both. Requests that take a season type ask for
+// it, per-week tasks skip the calendar weeks of other season types, and
+// per-game tasks skip other season types' stored games. With none, which
+// is the default, nothing is filtered and requests leave the season type
+// to the API.
+func (s *Seeder) SetSeasonTypes(types []string) error {
+	selected := make(map[string]bool, 2)
+	for _, t := range types {
+		switch t = strings.ToLower(strings.TrimSpace(t)); t {
+		case SeasonTypeRegular, SeasonTypePostseason:
+			selected[t] = true
+		case SeasonTypeBoth:
+			selected[SeasonTypeRegular] = true
+			selected[SeasonTypePostseason] = true
+		case "":
+		default:
+			return fmt.Errorf("%w %q", ErrUnknownSeasonType, t)
+		}
+	}
+
+	switch {
+	case selected[SeasonTypeRegular] && selected[SeasonTypePostseason]:
+		s.seasonType = SeasonTypeBoth
+	case selected[SeasonTypeRegular]:
+		s.seasonType = SeasonTypeRegular
+	case selected[SeasonTypePostseason]:
+		s.seasonType = SeasonTypePostseason
+	default:
+		s.seasonType = ""
+	}
+
+	return nil
+}
+
+// seasonTypes returns the season types stored rows are limited to, or nil
+// if they are not.
+func (s *Seeder) seasonTypes() []string {
+	switch s.seasonType {
+	case "":
+		return nil
+	case SeasonTypeBoth:
+		return []string{SeasonTypeRegular, SeasonTypePostseason}
+	default:
+		return []string{s.seasonType}
+	}
+}
+
+// keepSeasonType reports whether rows of the season type are seeded.
+func (s *Seeder) keepSeasonType(seasonType string) bool {
+	types := s.seasonTypes()
+	return types == nil || slices.Contains(types, seasonType)
+}
+
+// countWeeks counts the season's stored calendar weeks of the selected
+// season types.
+func (s *Seeder) countWeeks(ctx context.Context, year int32) (int64, error) {
+	return s.db.CountCalendarWeeks(ctx, year, s.seasonTypes()...)
+}
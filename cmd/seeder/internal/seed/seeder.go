@@ -2,13 +2,21 @@ package seed
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/ratings"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/storage"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/weather"
 	"github.com/clintrovert/cfbd-go/cfbd"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
@@ -21,67 +29,271 @@ import (
 
 var supportedYears = []int32{2024, 2025}
 
+// streamBuffer is how many fetched seasons may wait to be inserted by a
+// streaming task before it stops fetching more.
+const streamBuffer = 1
+
+// apiHost is the CFBD API base address used for reachability checks.
+const apiHost = "https://" + apiHostName
+
+// apiHostName is the host every CFBD API request is made to.
+const apiHostName = "api.collegefootballdata.com"
+
+// ErrAPIUnavailable is returned by PingAPI when the CFBD API responds with a
+// server error.
+var ErrAPIUnavailable = errors.New("cfbd api unavailable")
+
+// ErrNoYears is returned when the seeder is configured without any seasons.
+var ErrNoYears = errors.New("at least one year is required")
+
+// ErrUnknownEndpointClass is returned when a rate limit is configured for an
+// endpoint class that does not exist.
+var ErrUnknownEndpointClass = errors.New("unknown endpoint class")
+
+// Seeder is the CFBD source: it fetches every CFBD endpoint and writes the
+// results to the database, using the etl package for its task graph,
+// request pacing and run bookkeeping.
 type Seeder struct {
-	db           *db.Database
-	api          *cfbd.Client
-	ctx          context.Context
-	throttler    *rate.Limiter
-	throttleLock sync.Mutex
+	db       *db.Database
+	api      CFBDAPI
+	requests *etl.Requester
+	usage    *etl.UsageTracker
+	progress *etl.Progress
+	run      *etl.RunRecorder
+
+	years          []int32
+	positionGroups []string
+	quotaWarnAt    int64
+	limiters       *LimiterRegistry
+	transforms     *transformRegistry
+	workers        map[string]int
+	maxAttempts    int
+	skipIdentical  bool
+	keyCheck       keyCheck
+	calendars      calendarCache
+	archive        storage.Store
+	ratingParams   ratings.Params
+	geocoder       Geocoder
+	weather        weather.Provider
+	seasonType     string
+	teams          []string
+	conferences    []string
+	classification string
 }
 
-// NewSeeder todo:describe.
+var _ etl.Source = (*Seeder)(nil)
+
+// NewSeeder returns a Seeder writing to db and pacing its requests to api
+// with the shared throttle, on top of any per-class limiters configured
+// later through Limiters.
 func NewSeeder(
 	db *db.Database,
-	api *cfbd.Client,
+	api CFBDAPI,
 	throttle *rate.Limiter,
 ) (*Seeder, error) {
+	usage := etl.NewUsageTracker()
+	progress := etl.NewProgress()
+	limiters := NewLimiterRegistry()
+
 	return &Seeder{
-		db:        db,
-		api:       api,
-		throttler: throttle,
+		db:  db,
+		api: api,
+		requests: &etl.Requester{
+			Throttler: etl.NewThrottler(throttle, limiters),
+			Breaker:   etl.NewCircuitBreaker(etl.DefaultBreakerPolicy()),
+			Usage:     usage,
+			Policy:    etl.DefaultRetryPolicy(),
+		},
+		usage:       usage,
+		progress:    progress,
+		run:         etl.NewRunRecorder(db, progress),
+		years:       supportedYears,
+		limiters:    limiters,
+		transforms:  newTransformRegistry(),
+		workers:     make(map[string]int),
+		maxAttempts: DefaultMaxFailureAttempts,
+		calendars: calendarCache{
+			seasons: make(map[int32]*calendarEntry),
+		},
 	}, nil
 }
 
-// throttle waits for the rate limiter to allow a request.
+// Name identifies the source in logs.
+func (s *Seeder) Name() string {
+	return "cfbd"
+}
+
+// Usage returns the tracker accounting for requests and payload bytes
+// per CFBD endpoint during this run.
+func (s *Seeder) Usage() *etl.UsageTracker {
+	return s.usage
+}
+
+// CountResponseBytes has the usage tracker count the body bytes of every
+// CFBD API response, by wrapping the transport of the seeder's cfbd
+// client. A seeder calling anything else, such as a mock, makes no HTTP
+// requests and is left as it is.
+func (s *Seeder) CountResponseBytes() error {
+	client, ok := s.api.(*cfbd.Client)
+	if !ok {
+		return nil
+	}
+
+	return WrapClientTransport(client,
+		func(next http.RoundTripper) http.RoundTripper {
+			return s.usage.Transport(apiHostName, next)
+		},
+	)
+}
+
+// Progress returns the tracker reporting the active phase, running seed
+// functions and per-table sync times.
+func (s *Seeder) Progress() *etl.Progress {
+	return s.progress
+}
+
+// Limiters returns the registry of per-endpoint-class rate limiters applied
+// on top of the shared limiter.
+func (s *Seeder) Limiters() *LimiterRegistry {
+	return s.limiters
+}
+
+// PingAPI checks that the CFBD API host is reachable without spending any
+// request quota.
+func (s *Seeder) PingAPI(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, apiHost, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build api ping request; %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach api; %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: status %d", ErrAPIUnavailable, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// throttle waits for the rate limiter to allow a request to the endpoint.
+// Requests wait on the shared limiter and then on the limiter registered
+// for the endpoint's class, if any. Once the seeder is stopped it returns
+// etl.ErrStopped instead.
 // This should be called before making any API request.
-func (s *Seeder) throttle(ctx context.Context) error {
-	s.throttleLock.Lock()
-	throttle := s.throttler
-	s.throttleLock.Unlock()
+func (s *Seeder) throttle(ctx context.Context, endpoint string) error {
+	return s.requests.Throttler.Wait(ctx, endpoint)
+}
+
+// Stop winds the seeder down: requests not yet made fail with
+// etl.ErrStopped, including those waiting on a rate limiter, while those in
+// flight finish and have their rows written. It is safe to call from a
+// signal handler's goroutine.
+func (s *Seeder) Stop() {
+	s.requests.Throttler.Stop()
+}
 
-	waitCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
+// StopContext returns a copy of ctx carrying the seeder's stop, so that the
+// tasks making no API requests, which Stop does not hold up, wind down at
+// their next unit of work too (see etl.Err).
+func (s *Seeder) StopContext(ctx context.Context) context.Context {
+	return etl.WithStop(ctx, s.requests.Throttler.Done())
+}
+
+// isStopped reports whether Stop was called.
+func (s *Seeder) isStopped() bool {
+	return s.requests.Throttler.Stopped()
+}
 
-	if err := throttle.Wait(waitCtx); err != nil {
-		return fmt.Errorf("rate limiter wait failed: %w", err)
+// SetYears replaces the seasons that are seeded, which default to
+// supportedYears. The years are sorted and must not be empty.
+func (s *Seeder) SetYears(years []int32) error {
+	if len(years) == 0 {
+		return ErrNoYears
 	}
 
+	s.years = slices.Clone(years)
+	slices.Sort(s.years)
 	return nil
 }
 
-// SetExecutionContext allows the seeder's context to be mutable. This is
-// technically an antipattern and the context should be passed to the individual
-// seed functions, but errgroup's Go() function wants an empty function
-// signature and doing this makes the code in main.go a lot more concise.
-//
-// This function must be called prior to any of the Seed-ing functions below.
-func (s *Seeder) SetExecutionContext(ctx context.Context) {
-	s.ctx = ctx
+// SetPositionGroups restricts SeedAggregatedTeamRecruiting to the given
+// position groups (e.g. "Quarterback", "Defensive Back"). Matching is case
+// insensitive. An empty list seeds every position group.
+func (s *Seeder) SetPositionGroups(groups []string) {
+	s.positionGroups = groups
+}
+
+// SetQuotaWarningThreshold makes SnapshotQuota log a warning once the number
+// of remaining API calls drops below threshold. Zero disables the warning.
+func (s *Seeder) SetQuotaWarningThreshold(threshold int64) {
+	s.quotaWarnAt = threshold
+}
+
+// SnapshotQuota fetches the API key's patron level and remaining calls and
+// persists them, labelled with the stage of the run they were taken at.
+func (s *Seeder) SnapshotQuota(ctx context.Context, stage string) error {
+	if err := s.throttle(ctx, endpointUserInfo); err != nil {
+		return fmt.Errorf("failed to wait for rate limit; %w", err)
+	}
+
+	info, err := retry(s, ctx, endpointUserInfo, s.api.GetInfo)
+	if err != nil {
+		slog.Error("failed to get user info", "err", err)
+		return fmt.Errorf("failed to get user info; %w", err)
+	}
+
+	s.usage.Record(endpointUserInfo)
+
+	if err = s.db.InsertUserInfo(ctx, info, stage); err != nil {
+		slog.Error("failed to insert user info", "err", err)
+		return fmt.Errorf("failed to insert user info; %w", err)
+	}
+
+	if info == nil {
+		return nil
+	}
+
+	remaining := int64(info.RemainingCalls)
+	s.progress.SetQuotaRemaining(remaining)
+
+	slog.Info(
+		"api quota",
+		"stage", stage,
+		"patron_level", info.PatronLevel,
+		"remaining_calls", remaining,
+	)
+
+	if s.quotaWarnAt > 0 && remaining < s.quotaWarnAt {
+		slog.Warn(
+			"api quota running low",
+			"remaining_calls", remaining,
+			"threshold", s.quotaWarnAt,
+		)
+	}
+
+	return nil
 }
 
 // SeedPlayTypes todo:describe.
-func (s *Seeder) SeedPlayTypes() error {
-	if err := s.throttle(s.ctx); err != nil {
+func (s *Seeder) SeedPlayTypes(ctx context.Context) error {
+	if err := s.throttle(ctx, endpointPlayTypes); err != nil {
 		return fmt.Errorf("failed to wait for rate limit; %w", err)
 	}
 
-	playTypes, err := s.api.GetPlayTypes(s.ctx)
+	playTypes, err := retry(s, ctx, endpointPlayTypes, s.api.GetPlayTypes)
 	if err != nil {
 		slog.Error("failed to get play types", "err", err)
 		return fmt.Errorf("failed to get play types; %w", err)
 	}
 
-	if err = s.db.InsertPlayTypes(s.ctx, playTypes); err != nil {
+	s.usage.Record(endpointPlayTypes)
+	playTypes = transform(s, endpointPlayTypes, playTypes)
+
+	if err = s.db.InsertPlayTypes(ctx, playTypes); err != nil {
 		slog.Error("failed to upsert play types", "err", err)
 		return fmt.Errorf("failed to upsert play types; %w", err)
 	}
@@ -91,18 +303,23 @@ func (s *Seeder) SeedPlayTypes() error {
 }
 
 // SeedConferences todo:describe.
-func (s *Seeder) SeedConferences() error {
-	if err := s.throttle(s.ctx); err != nil {
+func (s *Seeder) SeedConferences(ctx context.Context) error {
+	if err := s.throttle(ctx, endpointConferences); err != nil {
 		return fmt.Errorf("failed to wait for rate limit; %w", err)
 	}
 
-	conferences, err := s.api.GetConferences(s.ctx)
+	conferences, err := retry(
+		s, ctx, endpointConferences, s.api.GetConferences,
+	)
 	if err != nil {
 		slog.Error("failed to get conferences", "err", err)
 		return fmt.Errorf("failed to get conferences; %w", err)
 	}
 
-	if err = s.db.InsertConferences(s.ctx, conferences); err != nil {
+	s.usage.Record(endpointConferences)
+	conferences = transform(s, endpointConferences, conferences)
+
+	if err = s.db.InsertConferences(ctx, conferences); err != nil {
 		slog.Error("failed to upsert conferences", "err", err)
 		return fmt.Errorf("failed to upset conferences; %w", err)
 	}
@@ -112,18 +329,21 @@ func (s *Seeder) SeedConferences() error {
 }
 
 // SeedVenues todo:describe.
-func (s *Seeder) SeedVenues() error {
-	if err := s.throttle(s.ctx); err != nil {
+func (s *Seeder) SeedVenues(ctx context.Context) error {
+	if err := s.throttle(ctx, endpointVenues); err != nil {
 		return fmt.Errorf("failed to wait for rate limit; %w", err)
 	}
 
-	venues, err := s.api.GetVenues(s.ctx)
+	venues, err := retry(s, ctx, endpointVenues, s.api.GetVenues)
 	if err != nil {
 		slog.Error("failed to get venues", "err", err)
 		return fmt.Errorf("failed to get venues; %w", err)
 	}
 
-	if err = s.db.InsertVenues(s.ctx, venues); err != nil {
+	s.usage.Record(endpointVenues)
+	venues = transform(s, endpointVenues, venues)
+
+	if err = s.db.InsertVenues(ctx, venues); err != nil {
 		slog.Error("failed to upsert venues", "err", err)
 		return fmt.Errorf("failed to upsert venues; %w", err)
 	}
@@ -133,18 +353,23 @@ func (s *Seeder) SeedVenues() error {
 }
 
 // SeedStatTypes todo:describe.
-func (s *Seeder) SeedStatTypes() error {
-	if err := s.throttle(s.ctx); err != nil {
+func (s *Seeder) SeedStatTypes(ctx context.Context) error {
+	if err := s.throttle(ctx, endpointStatCategories); err != nil {
 		return fmt.Errorf("failed to wait for rate limit; %w", err)
 	}
 
-	statCats, err := s.api.GetStatCategories(s.ctx)
+	statCats, err := retry(
+		s, ctx, endpointStatCategories, s.api.GetStatCategories,
+	)
 	if err != nil {
 		slog.Error("failed to get play types", "err", err)
 		return fmt.Errorf("failed to get play types; %w", err)
 	}
 
-	if err = s.db.InsertPlayStatTypes(s.ctx, statCats); err != nil {
+	s.usage.Record(endpointStatCategories)
+	statCats = transform(s, endpointStatCategories, statCats)
+
+	if err = s.db.InsertPlayStatTypes(ctx, statCats); err != nil {
 		slog.Error("failed to upsert play types", "err", err)
 		return fmt.Errorf("failed to upsert play types; %w", err)
 	}
@@ -154,18 +379,21 @@ func (s *Seeder) SeedStatTypes() error {
 }
 
 // SeedDraftTeams todo:describe.
-func (s *Seeder) SeedDraftTeams() error {
-	if err := s.throttle(s.ctx); err != nil {
+func (s *Seeder) SeedDraftTeams(ctx context.Context) error {
+	if err := s.throttle(ctx, endpointDraftTeams); err != nil {
 		return fmt.Errorf("failed to wait for rate limit; %w", err)
 	}
 
-	teams, err := s.api.GetDraftTeams(s.ctx)
+	teams, err := retry(s, ctx, endpointDraftTeams, s.api.GetDraftTeams)
 	if err != nil {
 		slog.Error("failed to get draft teams", "err", err)
 		return fmt.Errorf("failed to get draft teams; %w", err)
 	}
 
-	if err = s.db.InsertDraftTeams(s.ctx, teams); err != nil {
+	s.usage.Record(endpointDraftTeams)
+	teams = transform(s, endpointDraftTeams, teams)
+
+	if err = s.db.InsertDraftTeams(ctx, teams); err != nil {
 		slog.Error("failed to upsert draft teams", "err", err)
 		return fmt.Errorf("failed to upsert draft teams; %w", err)
 	}
@@ -175,18 +403,23 @@ func (s *Seeder) SeedDraftTeams() error {
 }
 
 // SeedDraftPositions todo:describe.
-func (s *Seeder) SeedDraftPositions() error {
-	if err := s.throttle(s.ctx); err != nil {
+func (s *Seeder) SeedDraftPositions(ctx context.Context) error {
+	if err := s.throttle(ctx, endpointDraftPositions); err != nil {
 		return fmt.Errorf("failed to wait for rate limit; %w", err)
 	}
 
-	positions, err := s.api.GetDraftPositions(s.ctx)
+	positions, err := retry(
+		s, ctx, endpointDraftPositions, s.api.GetDraftPositions,
+	)
 	if err != nil {
 		slog.Error("failed to get draft positions", "err", err)
 		return fmt.Errorf("failed to get draft positions; %w", err)
 	}
 
-	if err = s.db.InsertDraftPositions(s.ctx, positions); err != nil {
+	s.usage.Record(endpointDraftPositions)
+	positions = transform(s, endpointDraftPositions, positions)
+
+	if err = s.db.InsertDraftPositions(ctx, positions); err != nil {
 		slog.Error("failed to upsert draft teams", "err", err)
 		return fmt.Errorf("failed to upsert draft teams; %w", err)
 	}
@@ -196,18 +429,23 @@ func (s *Seeder) SeedDraftPositions() error {
 }
 
 // SeedFieldGoalEP todo:describe.
-func (s *Seeder) SeedFieldGoalEP() error {
-	if err := s.throttle(s.ctx); err != nil {
+func (s *Seeder) SeedFieldGoalEP(ctx context.Context) error {
+	if err := s.throttle(ctx, endpointFieldGoalEP); err != nil {
 		return fmt.Errorf("failed to wait for rate limit; %w", err)
 	}
 
-	eps, err := s.api.GetFieldGoalExpectedPoints(s.ctx)
+	eps, err := retry(
+		s, ctx, endpointFieldGoalEP, s.api.GetFieldGoalExpectedPoints,
+	)
 	if err != nil {
 		slog.Error("failed to get field goal ep", "err", err)
 		return fmt.Errorf("failed to get field goal ep; %w", err)
 	}
 
-	if err = s.db.InsertFieldGoalEP(s.ctx, eps); err != nil {
+	s.usage.Record(endpointFieldGoalEP)
+	eps = transform(s, endpointFieldGoalEP, eps)
+
+	if err = s.db.InsertFieldGoalEP(ctx, eps); err != nil {
 		slog.Error("failed to insert field goal ep", "err", err)
 		return fmt.Errorf("failed to insert field goal ep; %w", err)
 	}
@@ -216,18 +454,25 @@ func (s *Seeder) SeedFieldGoalEP() error {
 	return nil
 }
 
-func (s *Seeder) SeedTeams() error {
-	if err := s.throttle(s.ctx); err != nil {
+func (s *Seeder) SeedTeams(ctx context.Context) error {
+	if err := s.throttle(ctx, endpointTeams); err != nil {
 		return fmt.Errorf("failed to wait for rate limit; %w", err)
 	}
 
-	teams, err := s.api.GetTeams(s.ctx, cfbd.GetTeamsRequest{})
+	teams, err := retryReq(
+		s, ctx, endpointTeams, s.api.GetTeams,
+		cfbd.GetTeamsRequest{},
+	)
 	if err != nil {
 		slog.Error("failed to get teams", "err", err)
 		return fmt.Errorf("failed to get teams; %w", err)
 	}
 
-	if err = s.db.InsertTeams(s.ctx, teams); err != nil {
+	s.usage.Record(endpointTeams)
+	teams = byClassification(s, teams, teamClassification)
+	teams = transform(s, endpointTeams, teams)
+
+	if err = s.db.InsertTeams(ctx, teams); err != nil {
 		slog.Error("failed to insert teams", "err", err)
 		return fmt.Errorf("failed to insert teams; %w", err)
 	}
@@ -236,151 +481,350 @@ func (s *Seeder) SeedTeams() error {
 	return nil
 }
 
-func (s *Seeder) SeedCalendar() error {
-	var all []*cfbd.CalendarWeek
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+// SeedCalendar seeds the calendar of every season, inserting each season's
+// weeks while the next season is fetched.
+func (s *Seeder) SeedCalendar(ctx context.Context) error {
+	return etl.Stream(ctx, streamBuffer,
+		func(ctx context.Context, emit func([]*cfbd.CalendarWeek) error) error {
+			for _, year := range s.years {
+				if err := s.throttle(ctx, endpointCalendar); err != nil {
+					return fmt.Errorf("failed to wait for rate limit; %w", err)
+				}
+
+				weeks, err := retryReq(
+					s, ctx, endpointCalendar, s.api.GetCalendar,
+					cfbd.GetCalendarRequest{Year: year},
+				)
+				if err != nil {
+					slog.Error(
+						"failed to get calendar",
+						"year", int32ToString(year),
+						"err", err,
+					)
+					return fmt.Errorf(
+						"failed to get calendar for year %d; %w", year, err,
+					)
+				}
+
+				s.usage.Record(endpointCalendar)
+				if err = emit(transform(s, endpointCalendar, weeks)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		func(ctx context.Context, weeks []*cfbd.CalendarWeek) error {
+			if err := s.db.InsertCalendarWeeks(ctx, weeks); err != nil {
+				slog.Error("failed to insert calendar", "err", err)
+				return fmt.Errorf("failed to insert calendar; %w", err)
+			}
+			return nil
+		},
+	)
+}
+
+// SeedPlayerSearch seeds the roster for every supported year and then
+// rebuilds the player search table from it, so downstream applications can
+// look players up by name without calling the API.
+func (s *Seeder) SeedPlayerSearch(ctx context.Context) error {
+	totalInserted := 0
+
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointRoster); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		weeks, err := s.api.GetCalendar(
-			s.ctx, cfbd.GetCalendarRequest{Year: year},
+		players, err := retryReq(
+			s, ctx, endpointRoster, s.api.GetRoster,
+			cfbd.GetRosterRequest{
+				Year:           year,
+				Classification: s.classification,
+			},
 		)
 		if err != nil {
 			slog.Error(
-				"failed to get calendar",
+				"failed to get roster",
 				"year", int32ToString(year),
 				"err", err,
 			)
-			return fmt.Errorf("failed to get calendar for year %d; %w", year, err)
+			return fmt.Errorf("failed to get roster for year %d; %w", year, err)
 		}
 
-		all = append(all, weeks...)
+		s.usage.Record(endpointRoster)
+		players = transform(s, endpointRoster, players)
+
+		if err = s.db.InsertRosterPlayers(ctx, players); err != nil {
+			slog.Error("failed to insert roster players", "err", err)
+			return fmt.Errorf("failed to insert roster players; %w", err)
+		}
+
+		totalInserted += len(players)
+		slog.Info(
+			"inserted roster players",
+			"year", int32ToString(year),
+			"count", len(players),
+			"total", totalInserted,
+		)
 	}
 
-	if err := s.db.InsertCalendarWeeks(s.ctx, all); err != nil {
-		slog.Error("failed to insert calendar", "err", err)
-		return fmt.Errorf("failed to insert calendar; %w", err)
+	if err := s.db.RefreshPlayerSearch(ctx); err != nil {
+		slog.Error("failed to refresh player search", "err", err)
+		return fmt.Errorf("failed to refresh player search; %w", err)
 	}
 
+	slog.Info("player search successfully refreshed")
 	return nil
 }
 
-func (s *Seeder) SeedGames() error {
-	var all []*cfbd.Game
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
-			return fmt.Errorf("failed to wait for rate limit; %w", err)
-		}
+// SeedGames seeds the games of every season, inserting each season's games
+// while the next season is fetched.
+func (s *Seeder) SeedGames(ctx context.Context) error {
+	return etl.Stream(ctx, streamBuffer,
+		func(ctx context.Context, emit func([]*cfbd.Game) error) error {
+			for _, year := range s.years {
+				games, err := retryScoped(
+					s, ctx, endpointGames, s.api.GetGames,
+					func(scope seedScope) cfbd.GetGamesRequest {
+						return cfbd.GetGamesRequest{
+							Year:           year,
+							SeasonType:     s.seasonType,
+							Team:           scope.team,
+							Conference:     scope.conference,
+							Classification: s.classification,
+						}
+					},
+				)
+				if err != nil {
+					slog.Error(
+						"failed to get games",
+						"year", int32ToString(year),
+						"err", err,
+					)
+					return fmt.Errorf(
+						"failed to get games for year %d; %w", year, err,
+					)
+				}
 
-		weeks, err := s.api.GetGames(
-			s.ctx, cfbd.GetGamesRequest{Year: year},
-		)
-		if err != nil {
-			slog.Error(
-				"failed to get games",
-				"year", int32ToString(year),
-				"err", err,
+				if err = emit(transform(s, endpointGames, games)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		func(ctx context.Context, games []*cfbd.Game) error {
+			if err := s.db.InsertGames(ctx, games); err != nil {
+				slog.Error("failed to insert games", "err", err)
+				return fmt.Errorf("failed to insert games; %w", err)
+			}
+			return nil
+		},
+	)
+}
+
+// SeedScoreboard snapshots the current CFBD scoreboard into
+// cfbd.scoreboard. Each call overwrites the previous snapshot of a game.
+func (s *Seeder) SeedScoreboard(ctx context.Context) error {
+	if err := s.throttle(ctx, endpointScoreboard); err != nil {
+		return fmt.Errorf("failed to wait for rate limit; %w", err)
+	}
+
+	games, err := retryReq(
+		s, ctx, endpointScoreboard, s.api.GetScoreboard,
+		cfbd.GetScoreboardRequest{Classification: s.classification},
+	)
+	if err != nil {
+		slog.Error("failed to get scoreboard", "err", err)
+		return fmt.Errorf("failed to get scoreboard; %w", err)
+	}
+
+	s.usage.Record(endpointScoreboard)
+	games = transform(s, endpointScoreboard, games)
+
+	if err = s.db.InsertScoreboard(ctx, games); err != nil {
+		slog.Error("failed to insert scoreboard", "err", err)
+		return fmt.Errorf("failed to insert scoreboard; %w", err)
+	}
+
+	slog.Info("scoreboard successfully inserted", "count", len(games))
+	return nil
+}
+
+// SeedLiveGames fetches live play-by-play for every game the latest
+// scoreboard snapshot reports as in progress and upserts the nested live
+// game graph. SeedScoreboard should run first so the active games are known.
+func (s *Seeder) SeedLiveGames(ctx context.Context) error {
+	gameIDs, err := s.db.GetActiveScoreboardGameIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get active scoreboard games; %w", err)
+	}
+
+	if len(gameIDs) == 0 {
+		slog.Info("no live games in progress")
+		return nil
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(s.taskWorkers("SeedLiveGames"))
+	var stop etl.Drainer
+
+	for _, gameID := range gameIDs {
+		gid := gameID
+		group.Go(func() error {
+			if err := s.throttle(groupCtx, endpointLivePlays); err != nil {
+				return stop.Catch(err)
+			}
+
+			game, err := retryReq(
+				s, groupCtx, endpointLivePlays, s.api.GetLivePlays,
+				cfbd.GetLivePlaysRequest{GameID: gid},
 			)
-			return fmt.Errorf("failed to get games for year %d; %w", year, err)
-		}
+			if err != nil {
+				slog.Warn("failed to get live plays", "game_id", gid, "err", err)
+				return nil // A single game should not stall the others
+			}
 
-		all = append(all, weeks...)
+			s.usage.Record(endpointLivePlays)
+
+			return s.db.InsertLiveGame(groupCtx, game)
+		})
 	}
 
-	if err := s.db.InsertGames(s.ctx, all); err != nil {
-		slog.Error("failed to insert games", "err", err)
-		return fmt.Errorf("failed to insert games; %w", err)
+	if err = stop.Err(group.Wait()); err != nil {
+		return fmt.Errorf("error waiting for live game seeding; %w", err)
 	}
 
+	slog.Info("live games successfully inserted", "count", len(gameIDs))
 	return nil
 }
 
-func (s *Seeder) SeedDrives() error {
-	totalInserted := 0
+// WatchScoreboard refreshes the scoreboard, followed by the live
+// play-by-play of in-progress games, every interval until ctx is
+// cancelled. Failed refreshes are logged and retried on the next
+// tick rather than ending the watch.
+func (s *Seeder) WatchScoreboard(
+	ctx context.Context,
+	interval time.Duration,
+) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid scoreboard watch interval %s", interval)
+	}
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
-			return fmt.Errorf("failed to wait for rate limit; %w", err)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.progress.Beat()
+
+		if err := s.SeedScoreboard(ctx); err != nil {
+			slog.Warn("scoreboard refresh failed", "err", err)
+		} else if err = s.SeedLiveGames(ctx); err != nil {
+			slog.Warn("live game refresh failed", "err", err)
 		}
 
-		drives, err := s.api.GetDrives(s.ctx, cfbd.GetDrivesRequest{Year: year})
-		if err != nil {
-			slog.Error(
-				"failed to get drives",
-				"year", int32ToString(year),
-				"err", err,
-			)
-			return fmt.Errorf("failed to get drives for year %d; %w", year, err)
+		select {
+		case <-ctx.Done():
+			slog.Info("scoreboard watch stopped")
+			return nil
+		case <-ticker.C:
 		}
+	}
+}
 
-		if len(drives) > 0 {
-			if err := s.db.InsertDrives(s.ctx, drives); err != nil {
-				slog.Error("failed to insert drives", "err", err)
-				return fmt.Errorf("failed to insert drives; %w", err)
-			}
-			totalInserted += len(drives)
-			slog.Info("inserted drives for year",
-				"year", int32ToString(year),
-				"count", len(drives),
-				"total", totalInserted,
+func (s *Seeder) SeedDrives(ctx context.Context) error {
+	totalInserted, err := s.eachYear(ctx, "SeedDrives",
+		func(ctx context.Context, year int32) (int, error) {
+			drives, err := retryScoped(
+				s, ctx, endpointDrives, s.api.GetDrives,
+				func(scope seedScope) cfbd.GetDrivesRequest {
+					return cfbd.GetDrivesRequest{
+						Year:           year,
+						SeasonType:     s.seasonType,
+						Team:           scope.team,
+						Conference:     scope.conference,
+						Classification: s.classification,
+					}
+				},
 			)
-		}
+			if err != nil {
+				slog.Error(
+					"failed to get drives",
+					"year", int32ToString(year),
+					"err", err,
+				)
+				return 0, fmt.Errorf("failed to get drives for year %d; %w", year, err)
+			}
+
+			drives = transform(s, endpointDrives, drives)
+
+			if len(drives) > 0 {
+				if err := s.db.InsertDrives(ctx, year, drives); err != nil {
+					slog.Error("failed to insert drives", "err", err)
+					return 0, fmt.Errorf("failed to insert drives; %w", err)
+				}
+				slog.Info("inserted drives for year",
+					"year", int32ToString(year),
+					"count", len(drives),
+				)
+			}
+
+			return len(drives), nil
+		},
+	)
+	if err != nil {
+		return err
 	}
 
 	slog.Info("all drives successfully inserted", "total_count", totalInserted)
 	return nil
 }
 
-func (s *Seeder) SeedPlays() error {
+func (s *Seeder) SeedPlays(ctx context.Context) error {
 	totalInserted := 0
+	s.expectUnits(ctx, s.countWeeks)
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
-			return fmt.Errorf("failed to wait for rate limit; %w", err)
-		}
-
-		// GetPlays requires both a year and a week to be specified.
-		// We must query GetCalendar first to get the available weeks
-		// for each year.
-		weeks, err := s.api.GetCalendar(
-			s.ctx, cfbd.GetCalendarRequest{Year: year},
-		)
+	for _, year := range s.years {
+		// GetPlays requires both a year and a week to be specified, so the
+		// season's calendar is resolved first.
+		weeks, err := s.calendar(ctx, year)
 		if err != nil {
-			slog.Error(
-				"failed to get calendar for plays",
-				"year", int32ToString(year),
-				"err", err,
-			)
-			return fmt.Errorf("failed to get calendar for year %d; %w", year, err)
+			return fmt.Errorf("failed to get calendar for plays; %w", err)
 		}
 
 		for _, week := range weeks {
-			if err = s.throttle(s.ctx); err != nil {
-				return fmt.Errorf("failed to wait for rate limit; %w", err)
+			if !s.keepSeasonType(week.SeasonType) {
+				continue
 			}
-
-			plays, err := s.api.GetPlays(s.ctx, cfbd.GetPlaysRequest{
-				Year:       year,
-				Week:       week.GetWeek(),
-				SeasonType: week.GetSeasonType(),
-			})
+			plays, err := retryScoped(
+				s, ctx, endpointPlays, s.api.GetPlays,
+				func(scope seedScope) cfbd.GetPlaysRequest {
+					return cfbd.GetPlaysRequest{
+						Year:           year,
+						Week:           week.Week,
+						SeasonType:     week.SeasonType,
+						Team:           scope.team,
+						Conference:     scope.conference,
+						Classification: s.classification,
+					}
+				},
+			)
 			if err != nil {
 				slog.Error(
 					"failed to get plays",
 					"year", int32ToString(year),
-					"week", int32ToString(week.GetWeek()),
-					"season_type", week.GetSeasonType(),
+					"week", int32ToString(week.Week),
+					"season_type", week.SeasonType,
 					"err", err,
 				)
 				return fmt.Errorf(
 					"failed to get plays for year %d, week %d, season_type %s; %w",
-					year, week.GetWeek(), week.GetSeasonType(), err,
+					year, week.Week, week.SeasonType, err,
 				)
 			}
 
+			plays = transform(s, endpointPlays, plays)
+
 			if len(plays) > 0 {
-				if err := s.db.InsertPlays(s.ctx, plays); err != nil {
+				if err := s.db.InsertPlays(ctx, year, plays); err != nil {
 					slog.Error("failed to insert plays", "err", err)
 					return fmt.Errorf("failed to insert plays; %w", err)
 				}
@@ -388,12 +832,13 @@ func (s *Seeder) SeedPlays() error {
 				totalInserted += len(plays)
 				slog.Info("inserted plays",
 					"year", int32ToString(year),
-					"week", int32ToString(week.GetWeek()),
-					"season_type", week.GetSeasonType(),
+					"week", int32ToString(week.Week),
+					"season_type", week.SeasonType,
 					"count", len(plays),
 					"total", totalInserted,
 				)
 			}
+			s.progress.UnitDone(ctx)
 		}
 	}
 
@@ -401,55 +846,68 @@ func (s *Seeder) SeedPlays() error {
 	return nil
 }
 
-func (s *Seeder) SeedPlayStats() error {
+func (s *Seeder) SeedPlayStats(ctx context.Context) error {
 	totalInserted := 0
+	s.expectUnits(ctx, s.countWeeks)
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
-			return fmt.Errorf("failed to wait for rate limit; %w", err)
-		}
-
-		// GetPlayStats requires both a year and a week to be specified.
-		// We must query GetCalendar first to get the available weeks
-		// for each year.
-		calendarWeeks, err := s.api.GetCalendar(
-			s.ctx, cfbd.GetCalendarRequest{Year: year},
-		)
+	for _, year := range s.years {
+		// GetPlayStats requires both a year and a week to be specified, so
+		// the season's calendar is resolved first.
+		calendarWeeks, err := s.calendar(ctx, year)
 		if err != nil {
-			slog.Error(
-				"failed to get calendar for play stats",
-				"year", int32ToString(year),
-				"err", err,
-			)
-			return fmt.Errorf("failed to get calendar for year %d; %w", year, err)
+			return fmt.Errorf("failed to get calendar for play stats; %w", err)
 		}
 
 		for _, week := range calendarWeeks {
-			if err = s.throttle(s.ctx); err != nil {
-				return fmt.Errorf("failed to wait for rate limit; %w", err)
+			if !s.keepSeasonType(week.SeasonType) {
+				continue
 			}
-
-			playStats, err := s.api.GetPlayStats(s.ctx, cfbd.GetPlayStatsRequest{
-				Year:       year,
-				Week:       week.GetWeek(),
-				SeasonType: week.GetSeasonType(),
-			})
+			playStats, err := retryScoped(
+				s, ctx, endpointPlayStats, s.api.GetPlayStats,
+				func(scope seedScope) cfbd.GetPlayStatsRequest {
+					return cfbd.GetPlayStatsRequest{
+						Year:       year,
+						Week:       week.Week,
+						SeasonType: week.SeasonType,
+						Team:       scope.team,
+						Conference: scope.conference,
+					}
+				},
+			)
 			if err != nil {
 				slog.Error(
 					"failed to get play stats",
 					"year", int32ToString(year),
-					"week", int32ToString(week.GetWeek()),
-					"season_type", week.GetSeasonType(),
+					"week", int32ToString(week.Week),
+					"season_type", week.SeasonType,
 					"err", err,
 				)
 				return fmt.Errorf(
 					"failed to get playstats for year %d, week %d, szntype %s; %w",
-					year, week.GetWeek(), week.GetSeasonType(), err,
+					year, week.Week, week.SeasonType, err,
+				)
+			}
+
+			playStats, err = byConferenceClassification(
+				s, ctx, playStats, teamConference,
+			)
+			if err != nil {
+				slog.Error(
+					"failed to filter play stats by classification",
+					"year", int32ToString(year),
+					"week", int32ToString(week.Week),
+					"season_type", week.SeasonType,
+					"err", err,
+				)
+				return fmt.Errorf(
+					"failed to filter play stats for year %d, week %d; %w",
+					year, week.Week, err,
 				)
 			}
+			playStats = transform(s, endpointPlayStats, playStats)
 
 			if len(playStats) > 0 {
-				if err = s.db.InsertPlayStats(s.ctx, playStats); err != nil {
+				if err = s.db.InsertPlayStats(ctx, playStats); err != nil {
 					slog.Error("failed to insert play stats", "err", err)
 					return fmt.Errorf("failed to insert play stats; %w", err)
 				}
@@ -457,12 +915,13 @@ func (s *Seeder) SeedPlayStats() error {
 				totalInserted += len(playStats)
 				slog.Info("inserted play stats",
 					"year", int32ToString(year),
-					"week", int32ToString(week.GetWeek()),
-					"season_type", week.GetSeasonType(),
+					"week", int32ToString(week.Week),
+					"season_type", week.SeasonType,
 					"count", len(playStats),
 					"total", totalInserted,
 				)
 			}
+			s.progress.UnitDone(ctx)
 		}
 	}
 
@@ -470,40 +929,50 @@ func (s *Seeder) SeedPlayStats() error {
 	return nil
 }
 
-func (s *Seeder) SeedGameTeamStats() error {
-	totalInserted := 0
-
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
-			return fmt.Errorf("failed to wait for rate limit; %w", err)
-		}
-
-		stats, err := s.api.GetGameTeams(
-			s.ctx, cfbd.GetGameTeamsRequest{Year: year},
-		)
-		if err != nil {
-			slog.Error(
-				"failed to get game team stats",
-				"year", int32ToString(year),
-				"err", err,
-			)
-			return fmt.Errorf(
-				"failed to get game team stats for year %d; %w", year, err,
+func (s *Seeder) SeedGameTeamStats(ctx context.Context) error {
+	totalInserted, err := s.eachYear(ctx, "SeedGameTeamStats",
+		func(ctx context.Context, year int32) (int, error) {
+			stats, err := retryScoped(
+				s, ctx, endpointGameTeams, s.api.GetGameTeams,
+				func(scope seedScope) cfbd.GetGameTeamsRequest {
+					return cfbd.GetGameTeamsRequest{
+						Year:           year,
+						SeasonType:     s.seasonType,
+						Team:           scope.team,
+						Conference:     scope.conference,
+						Classification: s.classification,
+					}
+				},
 			)
-		}
+			if err != nil {
+				slog.Error(
+					"failed to get game team stats",
+					"year", int32ToString(year),
+					"err", err,
+				)
+				return 0, fmt.Errorf(
+					"failed to get game team stats for year %d; %w", year, err,
+				)
+			}
 
-		if len(stats) > 0 {
-			if err := s.db.InsertGameTeamStats(s.ctx, stats); err != nil {
-				slog.Error("failed to insert game team stats", "err", err)
-				return fmt.Errorf("failed to insert game team stats; %w", err)
+			stats = transform(s, endpointGameTeams, stats)
+
+			if len(stats) > 0 {
+				if err := s.db.InsertGameTeamStats(ctx, stats); err != nil {
+					slog.Error("failed to insert game team stats", "err", err)
+					return 0, fmt.Errorf("failed to insert game team stats; %w", err)
+				}
+				slog.Info("inserted game team stats",
+					"year", int32ToString(year),
+					"count", len(stats),
+				)
 			}
-			totalInserted += len(stats)
-			slog.Info("inserted game team stats",
-				"year", int32ToString(year),
-				"count", len(stats),
-				"total", totalInserted,
-			)
-		}
+
+			return len(stats), nil
+		},
+	)
+	if err != nil {
+		return err
 	}
 
 	slog.Info(
@@ -513,40 +982,68 @@ func (s *Seeder) SeedGameTeamStats() error {
 	return nil
 }
 
-func (s *Seeder) SeedGamePlayerStats() error {
-	totalInserted := 0
-
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
-			return fmt.Errorf("failed to wait for rate limit; %w", err)
-		}
-
-		stats, err := s.api.GetGamePlayers(
-			s.ctx, cfbd.GetGamePlayersRequest{Year: year},
-		)
-		if err != nil {
-			slog.Error(
-				"failed to get game player stats",
-				"year", int32ToString(year),
-				"err", err,
+func (s *Seeder) SeedGamePlayerStats(ctx context.Context) error {
+	totalInserted, err := s.eachYear(ctx, "SeedGamePlayerStats",
+		func(ctx context.Context, year int32) (int, error) {
+			stats, err := retryScoped(
+				s, ctx, endpointGamePlayers, s.api.GetGamePlayers,
+				func(scope seedScope) cfbd.GetGamePlayersRequest {
+					return cfbd.GetGamePlayersRequest{
+						Year:       year,
+						SeasonType: s.seasonType,
+						Team:       scope.team,
+						Conference: scope.conference,
+					}
+				},
 			)
-			return fmt.Errorf(
-				"failed to get game player stats for year %d; %w", year, err,
+			if err != nil {
+				slog.Error(
+					"failed to get game player stats",
+					"year", int32ToString(year),
+					"err", err,
+				)
+				return 0, fmt.Errorf(
+					"failed to get game player stats for year %d; %w", year, err,
+				)
+			}
+
+			stats, err = byConferenceClassification(s, ctx, stats,
+				func(game *cfbd.GamePlayerStats) []string {
+					var conferences []string
+					for _, team := range game.GetTeams() {
+						conferences = append(conferences, team.GetConference())
+					}
+					return conferences
+				},
 			)
-		}
+			if err != nil {
+				slog.Error(
+					"failed to filter game player stats by classification",
+					"year", int32ToString(year),
+					"err", err,
+				)
+				return 0, fmt.Errorf(
+					"failed to filter game player stats for year %d; %w", year, err,
+				)
+			}
+			stats = transform(s, endpointGamePlayers, stats)
 
-		if len(stats) > 0 {
-			if err := s.db.InsertGamePlayerStats(s.ctx, stats); err != nil {
-				slog.Error("failed to insert game player stats", "err", err)
-				return fmt.Errorf("failed to insert game player stats; %w", err)
+			if len(stats) > 0 {
+				if err := s.db.InsertGamePlayerStats(ctx, stats); err != nil {
+					slog.Error("failed to insert game player stats", "err", err)
+					return 0, fmt.Errorf("failed to insert game player stats; %w", err)
+				}
+				slog.Info("inserted game player stats",
+					"year", int32ToString(year),
+					"count", len(stats),
+				)
 			}
-			totalInserted += len(stats)
-			slog.Info("inserted game player stats",
-				"year", int32ToString(year),
-				"count", len(stats),
-				"total", totalInserted,
-			)
-		}
+
+			return len(stats), nil
+		},
+	)
+	if err != nil {
+		return err
 	}
 
 	slog.Info(
@@ -555,11 +1052,12 @@ func (s *Seeder) SeedGamePlayerStats() error {
 	return nil
 }
 
-func (s *Seeder) SeedWinProbability() error {
-	for _, year := range supportedYears {
+func (s *Seeder) SeedWinProbability(ctx context.Context) error {
+	s.expectUnits(ctx, s.countGames)
+	for _, year := range s.years {
 		slog.Info("seeding win probability", "year", year)
 
-		gameIDs, err := s.db.GetGameIDs(s.ctx, int(year))
+		gameIDs, err := s.db.GetGameIDs(ctx, int(year), s.gameFilter())
 		if err != nil {
 			return fmt.Errorf("failed to get game IDs for year %d: %w", year, err)
 		}
@@ -593,18 +1091,18 @@ func (s *Seeder) SeedWinProbability() error {
 		// To be safe and quick, I'll write the iteration logic assuming per-game
 		// fetch for now, but check filtering support first.
 
-		group, ctx := errgroup.WithContext(s.ctx)
-		group.SetLimit(10) // Limit concurrency
+		group, groupCtx := errgroup.WithContext(ctx)
+		group.SetLimit(s.taskWorkers("SeedWinProbability"))
+		var stop etl.Drainer
 
 		for _, gameID := range gameIDs {
 			gid := gameID
 			group.Go(func() error {
-				if err := s.throttle(ctx); err != nil {
-					return err
+				if err := s.throttle(groupCtx, endpointWinProbability); err != nil {
+					return stop.Catch(err)
 				}
-				plays, err := s.api.GetWinProbability(
-					ctx, cfbd.GetWinProbabilityRequest{GameID: gid},
-				)
+				plays, err := s.fetchWinProbability(groupCtx, gid)
+				s.progress.UnitDone(ctx)
 				if err != nil {
 					slog.Warn(
 						"failed to get win probability",
@@ -612,6 +1110,12 @@ func (s *Seeder) SeedWinProbability() error {
 						"game_id", gid,
 						"err", err,
 					)
+					s.recordFailure(
+						ctx,
+						endpointWinProbability,
+						failureParams{Year: year, GameID: gid},
+						err,
+					)
 					return nil // Continue despite error
 				}
 
@@ -619,22 +1123,23 @@ func (s *Seeder) SeedWinProbability() error {
 					return nil
 				}
 
-				return s.db.InsertPlayWinProbability(ctx, plays)
+				return s.db.InsertPlayWinProbability(groupCtx, plays)
 			})
 		}
 
-		if err := group.Wait(); err != nil {
+		if err := stop.Err(group.Wait()); err != nil {
 			return fmt.Errorf("error waiting for play win probability seeding: %w", err)
 		}
 	}
 	return nil
 }
 
-func (s *Seeder) SeedAdvancedBoxScore() error {
-	for _, year := range supportedYears {
+func (s *Seeder) SeedAdvancedBoxScore(ctx context.Context) error {
+	s.expectUnits(ctx, s.countGames)
+	for _, year := range s.years {
 		slog.Info("seeding advanced box scores", "year", year)
 
-		gameIDs, err := s.db.GetGameIDs(s.ctx, int(year))
+		gameIDs, err := s.db.GetGameIDs(ctx, int(year), s.gameFilter())
 		if err != nil {
 			return fmt.Errorf("failed to get game IDs for year %d: %w", year, err)
 		}
@@ -643,23 +1148,29 @@ func (s *Seeder) SeedAdvancedBoxScore() error {
 		var mu sync.Mutex
 		batch := make(map[int32]*cfbd.AdvancedBoxScore)
 
-		group, ctx := errgroup.WithContext(s.ctx)
-		group.SetLimit(10)
+		group, groupCtx := errgroup.WithContext(ctx)
+		group.SetLimit(s.taskWorkers("SeedAdvancedBoxScore"))
+		var stop etl.Drainer
 
 		for _, gameID := range gameIDs {
 			gid := gameID
 			group.Go(func() error {
-				if err := s.throttle(ctx); err != nil {
-					return err
+				if err := s.throttle(groupCtx, endpointAdvancedBoxScore); err != nil {
+					return stop.Catch(err)
 				}
-				score, err := s.api.GetAdvancedBoxScore(
-					ctx, cfbd.GetAdvancedBoxScoreRequest{GameID: gid},
-				)
+				score, err := s.fetchAdvancedBoxScore(groupCtx, gid)
+				s.progress.UnitDone(ctx)
 				if err != nil {
 					slog.Warn(
 						"failed to get advanced box score",
 						"year", year, "game_id", gid, "err", err,
 					)
+					s.recordFailure(
+						ctx,
+						endpointAdvancedBoxScore,
+						failureParams{Year: year, GameID: gid},
+						err,
+					)
 					return nil
 				}
 
@@ -670,37 +1181,84 @@ func (s *Seeder) SeedAdvancedBoxScore() error {
 					params := batch
 					batch = make(map[int32]*cfbd.AdvancedBoxScore)
 					mu.Unlock()
-					return s.db.InsertAdvancedBoxScores(ctx, params)
+					return s.db.InsertAdvancedBoxScores(groupCtx, params)
 				}
 				mu.Unlock()
 				return nil
 			})
 		}
 
-		if err := group.Wait(); err != nil {
-			return fmt.Errorf("error waiting for play win probability seeding: %w", err)
+		waitErr := stop.Err(group.Wait())
+		if waitErr != nil && !errors.Is(waitErr, etl.ErrStopped) {
+			return fmt.Errorf(
+				"error waiting for play win probability seeding: %w", waitErr,
+			)
 		}
 
-		// Flush remaining
+		// Flush remaining, even when stopped, so no fetched score is lost
 		if len(batch) > 0 {
-			if err := s.db.InsertAdvancedBoxScores(s.ctx, batch); err != nil {
+			if err := s.db.InsertAdvancedBoxScores(ctx, batch); err != nil {
 				return fmt.Errorf("error inserting advanced box scores: %w", err)
 			}
 		}
+		if waitErr != nil {
+			return waitErr
+		}
 	}
 	return nil
 }
 
-func (s *Seeder) SeedGameWeather() error {
-	totalInserted := 0
+// fetchWinProbability fetches the play-by-play win probability for a single
+// game. The caller is responsible for waiting on the throttle.
+func (s *Seeder) fetchWinProbability(
+	ctx context.Context,
+	gameID int32,
+) ([]*cfbd.PlayWinProbability, error) {
+	plays, err := retryReq(
+		s, ctx, endpointWinProbability, s.api.GetWinProbability,
+		cfbd.GetWinProbabilityRequest{GameID: gameID},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get win probability; %w", err)
+	}
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
-			return fmt.Errorf("failed to wait for rate limit; %w", err)
-		}
+	s.usage.Record(endpointWinProbability)
+	plays = transform(s, endpointWinProbability, plays)
+	return plays, nil
+}
 
-		weather, err := s.api.GetGameWeather(
-			s.ctx, cfbd.GetGameWeatherRequest{Year: year},
+// fetchAdvancedBoxScore fetches the advanced box score for a single game.
+// The caller is responsible for waiting on the throttle.
+func (s *Seeder) fetchAdvancedBoxScore(
+	ctx context.Context,
+	gameID int32,
+) (*cfbd.AdvancedBoxScore, error) {
+	score, err := retryReq(
+		s, ctx, endpointAdvancedBoxScore, s.api.GetAdvancedBoxScore,
+		cfbd.GetAdvancedBoxScoreRequest{GameID: gameID},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get advanced box score; %w", err)
+	}
+
+	s.usage.Record(endpointAdvancedBoxScore)
+	return score, nil
+}
+
+func (s *Seeder) SeedGameWeather(ctx context.Context) error {
+	totalInserted := 0
+
+	for _, year := range s.years {
+		weather, err := retryScoped(
+			s, ctx, endpointGameWeather, s.api.GetGameWeather,
+			func(scope seedScope) cfbd.GetGameWeatherRequest {
+				return cfbd.GetGameWeatherRequest{
+					Year:       year,
+					SeasonType: s.seasonType,
+					Team:       scope.team,
+					Conference: scope.conference,
+				}
+			},
 		)
 		if err != nil {
 			slog.Error(
@@ -712,8 +1270,21 @@ func (s *Seeder) SeedGameWeather() error {
 			return fmt.Errorf("failed to get game weather for year %d; %w", year, err)
 		}
 
+		weather, err = byConferenceClassification(s, ctx, weather, gameConferences)
+		if err != nil {
+			slog.Error(
+				"failed to filter game weather by classification",
+				"year", int32ToString(year),
+				"err", err,
+			)
+			return fmt.Errorf(
+				"failed to filter game weather for year %d; %w", year, err,
+			)
+		}
+		weather = transform(s, endpointGameWeather, weather)
+
 		if len(weather) > 0 {
-			if err := s.db.InsertGameWeather(s.ctx, weather); err != nil {
+			if err := s.db.InsertGameWeather(ctx, weather); err != nil {
 				slog.Error("failed to insert game weather", "err", err)
 				return fmt.Errorf("failed to insert game weather; %w", err)
 			}
@@ -731,16 +1302,20 @@ func (s *Seeder) SeedGameWeather() error {
 	return nil
 }
 
-func (s *Seeder) SeedGameMedia() error {
+func (s *Seeder) SeedGameMedia(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
-			return fmt.Errorf("failed to wait for rate limit; %w", err)
-		}
-
-		media, err := s.api.GetGameMedia(
-			s.ctx, cfbd.GetGameMediaRequest{Year: year},
+	for _, year := range s.years {
+		media, err := retryScoped(
+			s, ctx, endpointGameMedia, s.api.GetGameMedia,
+			func(scope seedScope) cfbd.GetGameMediaRequest {
+				return cfbd.GetGameMediaRequest{
+					Year:       year,
+					SeasonType: s.seasonType,
+					Team:       scope.team,
+					Conference: scope.conference,
+				}
+			},
 		)
 		if err != nil {
 			slog.Error(
@@ -752,8 +1327,21 @@ func (s *Seeder) SeedGameMedia() error {
 			return fmt.Errorf("failed to get game media for year %d; %w", year, err)
 		}
 
+		media, err = byConferenceClassification(s, ctx, media, gameConferences)
+		if err != nil {
+			slog.Error(
+				"failed to filter game media by classification",
+				"year", int32ToString(year),
+				"err", err,
+			)
+			return fmt.Errorf(
+				"failed to filter game media for year %d; %w", year, err,
+			)
+		}
+		media = transform(s, endpointGameMedia, media)
+
 		if len(media) > 0 {
-			if err := s.db.InsertGameMedia(s.ctx, media); err != nil {
+			if err := s.db.InsertGameMedia(ctx, media); err != nil {
 				slog.Error("failed to insert game media", "err", err)
 				return fmt.Errorf("failed to insert game media; %w", err)
 			}
@@ -771,58 +1359,78 @@ func (s *Seeder) SeedGameMedia() error {
 	return nil
 }
 
-func (s *Seeder) SeedBettingLines() error {
-	totalInserted := 0
-
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
-			return fmt.Errorf("failed to wait for rate limit; %w", err)
-		}
-
-		lines, err := s.api.GetBettingLines(
-			s.ctx, cfbd.GetBettingLinesRequest{Year: year},
-		)
-		if err != nil {
-			slog.Error(
-				"failed to get betting lines",
-				"year", int32ToString(year),
-				"err", err,
+func (s *Seeder) SeedBettingLines(ctx context.Context) error {
+	totalInserted, err := s.eachYear(ctx, "SeedBettingLines",
+		func(ctx context.Context, year int32) (int, error) {
+			lines, err := retryScoped(
+				s, ctx, endpointBettingLines, s.api.GetBettingLines,
+				func(scope seedScope) cfbd.GetBettingLinesRequest {
+					return cfbd.GetBettingLinesRequest{
+						Year:       year,
+						SeasonType: s.seasonType,
+						Team:       scope.team,
+						Conference: scope.conference,
+					}
+				},
 			)
+			if err != nil {
+				slog.Error(
+					"failed to get betting lines",
+					"year", int32ToString(year),
+					"err", err,
+				)
 
-			return fmt.Errorf(
-				"failed to get betting lines for year %d; %w", year, err,
+				return 0, fmt.Errorf(
+					"failed to get betting lines for year %d; %w", year, err,
+				)
+			}
+
+			lines = byClassification(s, lines,
+				func(line *cfbd.BettingGame) []string {
+					return []string{
+						line.GetHomeClassification(),
+						line.GetAwayClassification(),
+					}
+				},
 			)
-		}
+			lines = transform(s, endpointBettingLines, lines)
 
-		if len(lines) > 0 {
-			if err := s.db.InsertBettingLines(s.ctx, lines); err != nil {
-				slog.Error("failed to insert betting lines", "err", err)
-				return fmt.Errorf("failed to insert betting lines; %w", err)
+			if len(lines) > 0 {
+				if err := s.db.InsertBettingLines(ctx, lines); err != nil {
+					slog.Error("failed to insert betting lines", "err", err)
+					return 0, fmt.Errorf("failed to insert betting lines; %w", err)
+				}
+				slog.Info(
+					"inserted betting lines",
+					"year", int32ToString(year),
+					"count", len(lines),
+				)
 			}
-			totalInserted += len(lines)
-			slog.Info(
-				"inserted betting lines",
-				"year", int32ToString(year),
-				"count", len(lines),
-				"total", totalInserted,
-			)
-		}
+
+			return len(lines), nil
+		},
+	)
+	if err != nil {
+		return err
 	}
 
 	slog.Info("betting lines successfully inserted", "total_count", totalInserted)
 	return nil
 }
 
-func (s *Seeder) SeedTeamRecords() error {
+func (s *Seeder) SeedTeamRecords(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
-			return fmt.Errorf("failed to wait for rate limit; %w", err)
-		}
-
-		records, err := s.api.GetTeamRecords(
-			s.ctx, cfbd.GetTeamRecordsRequest{Year: year},
+	for _, year := range s.years {
+		records, err := retryScoped(
+			s, ctx, endpointTeamRecords, s.api.GetTeamRecords,
+			func(scope seedScope) cfbd.GetTeamRecordsRequest {
+				return cfbd.GetTeamRecordsRequest{
+					Year:       year,
+					Team:       scope.team,
+					Conference: scope.conference,
+				}
+			},
 		)
 		if err != nil {
 			slog.Error(
@@ -836,8 +1444,11 @@ func (s *Seeder) SeedTeamRecords() error {
 			)
 		}
 
+		records = byClassification(s, records, teamClassification)
+		records = transform(s, endpointTeamRecords, records)
+
 		if len(records) > 0 {
-			if err := s.db.InsertTeamRecords(s.ctx, records); err != nil {
+			if err := s.db.InsertTeamRecords(ctx, records); err != nil {
 				slog.Error(
 					"failed to insert team records",
 					"year", int32ToString(year),
@@ -866,16 +1477,17 @@ func (s *Seeder) SeedTeamRecords() error {
 	return nil
 }
 
-func (s *Seeder) SeedTeamTalentComposite() error {
+func (s *Seeder) SeedTeamTalentComposite(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointTalent); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		talent, err := s.api.GetTeamTalentComposite(
-			s.ctx, cfbd.GetTalentCompositeRequest{Year: year},
+		talent, err := retryReq(
+			s, ctx, endpointTalent, s.api.GetTeamTalentComposite,
+			cfbd.GetTalentCompositeRequest{Year: year},
 		)
 		if err != nil {
 			slog.Error(
@@ -889,8 +1501,11 @@ func (s *Seeder) SeedTeamTalentComposite() error {
 			)
 		}
 
+		s.usage.Record(endpointTalent)
+		talent = transform(s, endpointTalent, talent)
+
 		if len(talent) > 0 {
-			if err := s.db.InsertTeamTalent(s.ctx, talent); err != nil {
+			if err := s.db.InsertTeamTalent(ctx, talent); err != nil {
 				slog.Error(
 					"failed to insert team talent",
 					"year", int32ToString(year),
@@ -916,15 +1531,18 @@ func (s *Seeder) SeedTeamTalentComposite() error {
 	return nil
 }
 
-func (s *Seeder) SeedTeamATS() error {
+func (s *Seeder) SeedTeamATS(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointTeamATS); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		ats, err := s.api.GetTeamATS(s.ctx, cfbd.GetTeamATSRequest{Year: year})
+		ats, err := retryReq(
+			s, ctx, endpointTeamATS, s.api.GetTeamATS,
+			cfbd.GetTeamATSRequest{Year: year},
+		)
 		if err != nil {
 			slog.Error(
 				"failed to get team ATS",
@@ -937,8 +1555,11 @@ func (s *Seeder) SeedTeamATS() error {
 			)
 		}
 
+		s.usage.Record(endpointTeamATS)
+		ats = transform(s, endpointTeamATS, ats)
+
 		if len(ats) > 0 {
-			if err := s.db.InsertTeamATS(s.ctx, ats); err != nil {
+			if err := s.db.InsertTeamATS(ctx, ats); err != nil {
 				slog.Error("failed to insert team ATS", "err", err)
 				return fmt.Errorf("failed to insert team ATS; %w", err)
 			}
@@ -957,16 +1578,17 @@ func (s *Seeder) SeedTeamATS() error {
 	return nil
 }
 
-func (s *Seeder) SeedTeamSPPlus() error {
+func (s *Seeder) SeedTeamSPPlus(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointSPPlus); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		ratings, err := s.api.GetTeamSPPlusRatings(
-			s.ctx, cfbd.GetSPPlusRatingsRequest{Year: year},
+		ratings, err := retryReq(
+			s, ctx, endpointSPPlus, s.api.GetTeamSPPlusRatings,
+			cfbd.GetSPPlusRatingsRequest{Year: year},
 		)
 		if err != nil {
 			slog.Error(
@@ -980,8 +1602,11 @@ func (s *Seeder) SeedTeamSPPlus() error {
 			)
 		}
 
+		s.usage.Record(endpointSPPlus)
+		ratings = transform(s, endpointSPPlus, ratings)
+
 		if len(ratings) > 0 {
-			if err := s.db.InsertTeamSP(s.ctx, ratings); err != nil {
+			if err := s.db.InsertTeamSP(ctx, ratings); err != nil {
 				slog.Error("failed to insert team SP+", "err", err)
 				return fmt.Errorf("failed to insert team SP+; %w", err)
 			}
@@ -1003,16 +1628,17 @@ func (s *Seeder) SeedTeamSPPlus() error {
 	return nil
 }
 
-func (s *Seeder) SeedConferenceSPPlus() error {
+func (s *Seeder) SeedConferenceSPPlus(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointConferenceSPPlus); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		ratings, err := s.api.GetConferenceSPPlusRatings(
-			s.ctx, cfbd.GetConferenceSPPlusRatingsRequest{Year: year},
+		ratings, err := retryReq(
+			s, ctx, endpointConferenceSPPlus, s.api.GetConferenceSPPlusRatings,
+			cfbd.GetConferenceSPPlusRatingsRequest{Year: year},
 		)
 		if err != nil {
 			slog.Error(
@@ -1026,8 +1652,11 @@ func (s *Seeder) SeedConferenceSPPlus() error {
 			)
 		}
 
+		s.usage.Record(endpointConferenceSPPlus)
+		ratings = transform(s, endpointConferenceSPPlus, ratings)
+
 		if len(ratings) > 0 {
-			if err := s.db.InsertConferenceSP(s.ctx, ratings); err != nil {
+			if err := s.db.InsertConferenceSP(ctx, ratings); err != nil {
 				slog.Error("failed to insert conference SP+", "err", err)
 				return fmt.Errorf("failed to insert conference SP+; %w", err)
 			}
@@ -1050,16 +1679,17 @@ func (s *Seeder) SeedConferenceSPPlus() error {
 	return nil
 }
 
-func (s *Seeder) SeedTeamSRSRankings() error {
+func (s *Seeder) SeedTeamSRSRankings(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointSRS); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		ratings, err := s.api.GetSRSRatings(
-			s.ctx, cfbd.GetSRSRatingsRequest{Year: year},
+		ratings, err := retryReq(
+			s, ctx, endpointSRS, s.api.GetSRSRatings,
+			cfbd.GetSRSRatingsRequest{Year: year},
 		)
 		if err != nil {
 			slog.Error(
@@ -1073,8 +1703,11 @@ func (s *Seeder) SeedTeamSRSRankings() error {
 			)
 		}
 
+		s.usage.Record(endpointSRS)
+		ratings = transform(s, endpointSRS, ratings)
+
 		if len(ratings) > 0 {
-			if err := s.db.InsertTeamSRS(s.ctx, ratings); err != nil {
+			if err := s.db.InsertTeamSRS(ctx, ratings); err != nil {
 				slog.Error("failed to insert team SRS", "err", err)
 				return fmt.Errorf("failed to insert team SRS; %w", err)
 			}
@@ -1095,16 +1728,19 @@ func (s *Seeder) SeedTeamSRSRankings() error {
 	return nil
 }
 
-func (s *Seeder) SeedTeamEloRankings() error {
+func (s *Seeder) SeedTeamEloRankings(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointElo); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		ratings, err := s.api.GetEloRatings(
-			s.ctx, cfbd.GetEloRatingsRequest{Year: year},
+		ratings, err := retryReq(
+			s, ctx, endpointElo, s.api.GetEloRatings,
+			cfbd.GetEloRatingsRequest{
+				Year: year, SeasonType: s.seasonType,
+			},
 		)
 		if err != nil {
 			slog.Error(
@@ -1118,8 +1754,11 @@ func (s *Seeder) SeedTeamEloRankings() error {
 			)
 		}
 
+		s.usage.Record(endpointElo)
+		ratings = transform(s, endpointElo, ratings)
+
 		if len(ratings) > 0 {
-			if err := s.db.InsertTeamElo(s.ctx, ratings); err != nil {
+			if err := s.db.InsertTeamElo(ctx, ratings); err != nil {
 				slog.Error("failed to insert team Elo", "err", err)
 				return fmt.Errorf("failed to insert team Elo; %w", err)
 			}
@@ -1141,16 +1780,17 @@ func (s *Seeder) SeedTeamEloRankings() error {
 	return nil
 }
 
-func (s *Seeder) SeedTeamFPIRankings() error {
+func (s *Seeder) SeedTeamFPIRankings(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointFPI); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		ratings, err := s.api.GetFPIRatings(
-			s.ctx, cfbd.GetFPIRatingsRequest{Year: year},
+		ratings, err := retryReq(
+			s, ctx, endpointFPI, s.api.GetFPIRatings,
+			cfbd.GetFPIRatingsRequest{Year: year},
 		)
 		if err != nil {
 			slog.Error(
@@ -1164,8 +1804,11 @@ func (s *Seeder) SeedTeamFPIRankings() error {
 			)
 		}
 
+		s.usage.Record(endpointFPI)
+		ratings = transform(s, endpointFPI, ratings)
+
 		if len(ratings) > 0 {
-			if err := s.db.InsertTeamFPI(s.ctx, ratings); err != nil {
+			if err := s.db.InsertTeamFPI(ctx, ratings); err != nil {
 				slog.Error("failed to insert team FPI", "err", err)
 				return fmt.Errorf("failed to insert team FPI; %w", err)
 			}
@@ -1186,16 +1829,17 @@ func (s *Seeder) SeedTeamFPIRankings() error {
 	return nil
 }
 
-func (s *Seeder) SeedWepaTeamSeason() error {
+func (s *Seeder) SeedWepaTeamSeason(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointWepaTeamSeason); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		metrics, err := s.api.GetTeamSeasonWEPA(
-			s.ctx, cfbd.GetTeamSeasonWEPARequest{Year: year},
+		metrics, err := retryReq(
+			s, ctx, endpointWepaTeamSeason, s.api.GetTeamSeasonWEPA,
+			cfbd.GetTeamSeasonWEPARequest{Year: year},
 		)
 		if err != nil {
 			slog.Error(
@@ -1209,8 +1853,11 @@ func (s *Seeder) SeedWepaTeamSeason() error {
 			)
 		}
 
+		s.usage.Record(endpointWepaTeamSeason)
+		metrics = transform(s, endpointWepaTeamSeason, metrics)
+
 		if len(metrics) > 0 {
-			if err := s.db.InsertAdjustedTeamMetrics(s.ctx, metrics); err != nil {
+			if err := s.db.InsertAdjustedTeamMetrics(ctx, metrics); err != nil {
 				slog.Error("failed to insert team season WEPA", "err", err)
 				return fmt.Errorf("failed to insert team season WEPA; %w", err)
 			}
@@ -1232,16 +1879,17 @@ func (s *Seeder) SeedWepaTeamSeason() error {
 	return nil
 }
 
-func (s *Seeder) SeedWepaPassing() error {
+func (s *Seeder) SeedWepaPassing(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointWepaPassing); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		wepa, err := s.api.GetPlayerPassingWEPA(
-			s.ctx, cfbd.GetPlayerWEPARequest{Year: year},
+		wepa, err := retryReq(
+			s, ctx, endpointWepaPassing, s.api.GetPlayerPassingWEPA,
+			cfbd.GetPlayerWEPARequest{Year: year},
 		)
 		if err != nil {
 			slog.Error(
@@ -1255,8 +1903,11 @@ func (s *Seeder) SeedWepaPassing() error {
 			)
 		}
 
+		s.usage.Record(endpointWepaPassing)
+		wepa = transform(s, endpointWepaPassing, wepa)
+
 		if len(wepa) > 0 {
-			if err := s.db.InsertPlayerWeightedEPA(s.ctx, wepa); err != nil {
+			if err := s.db.InsertPlayerWeightedEPA(ctx, wepa); err != nil {
 				slog.Error("failed to insert passing WEPA", "err", err)
 				return fmt.Errorf("failed to insert passing WEPA; %w", err)
 			}
@@ -1278,16 +1929,17 @@ func (s *Seeder) SeedWepaPassing() error {
 	return nil
 }
 
-func (s *Seeder) SeedWepaRushing() error {
+func (s *Seeder) SeedWepaRushing(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointWepaRushing); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		wepa, err := s.api.GetPlayerRushingWEPA(
-			s.ctx, cfbd.GetPlayerWEPARequest{Year: year},
+		wepa, err := retryReq(
+			s, ctx, endpointWepaRushing, s.api.GetPlayerRushingWEPA,
+			cfbd.GetPlayerWEPARequest{Year: year},
 		)
 		if err != nil {
 			slog.Error(
@@ -1301,8 +1953,11 @@ func (s *Seeder) SeedWepaRushing() error {
 			)
 		}
 
+		s.usage.Record(endpointWepaRushing)
+		wepa = transform(s, endpointWepaRushing, wepa)
+
 		if len(wepa) > 0 {
-			if err := s.db.InsertPlayerWeightedEPA(s.ctx, wepa); err != nil {
+			if err := s.db.InsertPlayerWeightedEPA(ctx, wepa); err != nil {
 				slog.Error("failed to insert rushing WEPA", "err", err)
 				return fmt.Errorf("failed to insert rushing WEPA; %w", err)
 			}
@@ -1321,16 +1976,17 @@ func (s *Seeder) SeedWepaRushing() error {
 	return nil
 }
 
-func (s *Seeder) SeedWepaKicking() error {
+func (s *Seeder) SeedWepaKicking(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointWepaKicking); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		paar, err := s.api.GetPlayerKickingWEPA(
-			s.ctx, cfbd.GetWepaPlayersKickingRequest{Year: year},
+		paar, err := retryReq(
+			s, ctx, endpointWepaKicking, s.api.GetPlayerKickingWEPA,
+			cfbd.GetWepaPlayersKickingRequest{Year: year},
 		)
 		if err != nil {
 			slog.Error(
@@ -1344,8 +2000,11 @@ func (s *Seeder) SeedWepaKicking() error {
 			)
 		}
 
+		s.usage.Record(endpointWepaKicking)
+		paar = transform(s, endpointWepaKicking, paar)
+
 		if len(paar) > 0 {
-			if err := s.db.InsertKickerPAAR(s.ctx, paar); err != nil {
+			if err := s.db.InsertKickerPAAR(ctx, paar); err != nil {
 				slog.Error("failed to insert kicking PAAR", "err", err)
 				return fmt.Errorf("failed to insert kicking PAAR; %w", err)
 			}
@@ -1364,16 +2023,17 @@ func (s *Seeder) SeedWepaKicking() error {
 	return nil
 }
 
-func (s *Seeder) SeedReturningProduction() error {
+func (s *Seeder) SeedReturningProduction(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointReturningProduction); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		production, err := s.api.GetReturningProduction(
-			s.ctx, cfbd.GetReturningProductionRequest{Year: year},
+		production, err := retryReq(
+			s, ctx, endpointReturningProduction, s.api.GetReturningProduction,
+			cfbd.GetReturningProductionRequest{Year: year},
 		)
 		if err != nil {
 			slog.Error(
@@ -1387,8 +2047,11 @@ func (s *Seeder) SeedReturningProduction() error {
 			)
 		}
 
+		s.usage.Record(endpointReturningProduction)
+		production = transform(s, endpointReturningProduction, production)
+
 		if len(production) > 0 {
-			if err := s.db.InsertReturningProduction(s.ctx, production); err != nil {
+			if err := s.db.InsertReturningProduction(ctx, production); err != nil {
 				slog.Error("failed to insert returning production", "err", err)
 				return fmt.Errorf("failed to insert returning production; %w", err)
 			}
@@ -1409,16 +2072,17 @@ func (s *Seeder) SeedReturningProduction() error {
 	return nil
 }
 
-func (s *Seeder) SeedPortalPlayers() error {
+func (s *Seeder) SeedPortalPlayers(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointTransferPortal); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		players, err := s.api.GetTransferPortalPlayers(
-			s.ctx, cfbd.GetTransferPortalPlayersRequest{Year: year},
+		players, err := retryReq(
+			s, ctx, endpointTransferPortal, s.api.GetTransferPortalPlayers,
+			cfbd.GetTransferPortalPlayersRequest{Year: year},
 		)
 		if err != nil {
 			slog.Error(
@@ -1432,8 +2096,11 @@ func (s *Seeder) SeedPortalPlayers() error {
 			)
 		}
 
+		s.usage.Record(endpointTransferPortal)
+		players = transform(s, endpointTransferPortal, players)
+
 		if len(players) > 0 {
-			if err := s.db.InsertPlayerTransfers(s.ctx, players); err != nil {
+			if err := s.db.InsertPlayerTransfers(ctx, players); err != nil {
 				slog.Error("failed to insert transfer portal players", "err", err)
 				return fmt.Errorf("failed to insert transfer portal players; %w", err)
 			}
@@ -1456,16 +2123,20 @@ func (s *Seeder) SeedPortalPlayers() error {
 	return nil
 }
 
-func (s *Seeder) SeedSeasonPlayerStats() error {
+func (s *Seeder) SeedSeasonPlayerStats(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
-			return fmt.Errorf("failed to wait for rate limit; %w", err)
-		}
-
-		stats, err := s.api.GetPlayerSeasonStats(
-			s.ctx, cfbd.GetPlayerSeasonStatsRequest{Year: year},
+	for _, year := range s.years {
+		stats, err := retryScoped(
+			s, ctx, endpointPlayerSeasonStats, s.api.GetPlayerSeasonStats,
+			func(scope seedScope) cfbd.GetPlayerSeasonStatsRequest {
+				return cfbd.GetPlayerSeasonStatsRequest{
+					Year:       year,
+					SeasonType: s.seasonType,
+					Team:       scope.team,
+					Conference: scope.conference,
+				}
+			},
 		)
 		if err != nil {
 			slog.Error(
@@ -1479,8 +2150,21 @@ func (s *Seeder) SeedSeasonPlayerStats() error {
 			)
 		}
 
+		stats, err = byConferenceClassification(s, ctx, stats, teamConference)
+		if err != nil {
+			slog.Error(
+				"failed to filter player season stats by classification",
+				"year", int32ToString(year),
+				"err", err,
+			)
+			return fmt.Errorf(
+				"failed to filter player season stats for year %d; %w", year, err,
+			)
+		}
+		stats = transform(s, endpointPlayerSeasonStats, stats)
+
 		if len(stats) > 0 {
-			if err := s.db.InsertPlayerStats(s.ctx, stats); err != nil {
+			if err := s.db.InsertPlayerStats(ctx, stats); err != nil {
 				slog.Error("failed to insert player season stats", "err", err)
 				return fmt.Errorf("failed to insert player season stats; %w", err)
 			}
@@ -1503,16 +2187,19 @@ func (s *Seeder) SeedSeasonPlayerStats() error {
 	return nil
 }
 
-func (s *Seeder) SeedSeasonTeamStats() error {
+func (s *Seeder) SeedSeasonTeamStats(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
-			return fmt.Errorf("failed to wait for rate limit; %w", err)
-		}
-
-		stats, err := s.api.GetTeamSeasonStats(
-			s.ctx, cfbd.GetTeamSeasonStatsRequest{Year: year},
+	for _, year := range s.years {
+		stats, err := retryScoped(
+			s, ctx, endpointTeamSeasonStats, s.api.GetTeamSeasonStats,
+			func(scope seedScope) cfbd.GetTeamSeasonStatsRequest {
+				return cfbd.GetTeamSeasonStatsRequest{
+					Year:       year,
+					Team:       scope.team,
+					Conference: scope.conference,
+				}
+			},
 		)
 		if err != nil {
 			slog.Error(
@@ -1526,8 +2213,21 @@ func (s *Seeder) SeedSeasonTeamStats() error {
 			)
 		}
 
+		stats, err = byConferenceClassification(s, ctx, stats, teamConference)
+		if err != nil {
+			slog.Error(
+				"failed to filter team season stats by classification",
+				"year", int32ToString(year),
+				"err", err,
+			)
+			return fmt.Errorf(
+				"failed to filter team season stats for year %d; %w", year, err,
+			)
+		}
+		stats = transform(s, endpointTeamSeasonStats, stats)
+
 		if len(stats) > 0 {
-			if err := s.db.InsertTeamStats(s.ctx, stats); err != nil {
+			if err := s.db.InsertTeamStats(ctx, stats); err != nil {
 				slog.Error("failed to insert team season stats", "err", err)
 				return fmt.Errorf("failed to insert team season stats; %w", err)
 			}
@@ -1550,16 +2250,19 @@ func (s *Seeder) SeedSeasonTeamStats() error {
 	return nil
 }
 
-func (s *Seeder) SeedRankings() error {
+func (s *Seeder) SeedRankings(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointRankings); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		rankings, err := s.api.GetRankings(
-			s.ctx, cfbd.GetRankingsRequest{Year: year},
+		rankings, err := retryReq(
+			s, ctx, endpointRankings, s.api.GetRankings,
+			cfbd.GetRankingsRequest{
+				Year: year, SeasonType: s.seasonType,
+			},
 		)
 		if err != nil {
 			slog.Error(
@@ -1573,8 +2276,11 @@ func (s *Seeder) SeedRankings() error {
 			)
 		}
 
+		s.usage.Record(endpointRankings)
+		rankings = transform(s, endpointRankings, rankings)
+
 		if len(rankings) > 0 {
-			if err := s.db.InsertRankings(s.ctx, rankings); err != nil {
+			if err := s.db.InsertRankings(ctx, rankings); err != nil {
 				slog.Error("failed to insert rankings", "err", err)
 				return fmt.Errorf("failed to insert rankings; %w", err)
 			}
@@ -1593,16 +2299,17 @@ func (s *Seeder) SeedRankings() error {
 	return nil
 }
 
-func (s *Seeder) SeedRecruits() error {
+func (s *Seeder) SeedRecruits(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointRecruitingPlayers); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		recruits, err := s.api.GetPlayerRecruitingRankings(
-			s.ctx, cfbd.GetPlayersRecruitingRankingsRequest{Year: year},
+		recruits, err := retryReq(
+			s, ctx, endpointRecruitingPlayers, s.api.GetPlayerRecruitingRankings,
+			cfbd.GetPlayersRecruitingRankingsRequest{Year: year},
 		)
 		if err != nil {
 			slog.Error(
@@ -1616,8 +2323,11 @@ func (s *Seeder) SeedRecruits() error {
 			)
 		}
 
+		s.usage.Record(endpointRecruitingPlayers)
+		recruits = transform(s, endpointRecruitingPlayers, recruits)
+
 		if len(recruits) > 0 {
-			if err := s.db.InsertRecruits(s.ctx, recruits); err != nil {
+			if err := s.db.InsertRecruits(ctx, recruits); err != nil {
 				slog.Error("failed to insert recruits", "err", err)
 				return fmt.Errorf("failed to insert recruits; %w", err)
 			}
@@ -1636,16 +2346,17 @@ func (s *Seeder) SeedRecruits() error {
 	return nil
 }
 
-func (s *Seeder) SeedRecruitingRankings() error {
+func (s *Seeder) SeedRecruitingRankings(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointRecruitingTeams); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		rankings, err := s.api.GetTeamRecruitingRankings(
-			s.ctx, cfbd.GetTeamRecruitingRankingsRequest{Year: year},
+		rankings, err := retryReq(
+			s, ctx, endpointRecruitingTeams, s.api.GetTeamRecruitingRankings,
+			cfbd.GetTeamRecruitingRankingsRequest{Year: year},
 		)
 		if err != nil {
 			slog.Error(
@@ -1659,8 +2370,11 @@ func (s *Seeder) SeedRecruitingRankings() error {
 			)
 		}
 
+		s.usage.Record(endpointRecruitingTeams)
+		rankings = transform(s, endpointRecruitingTeams, rankings)
+
 		if len(rankings) > 0 {
-			if err := s.db.InsertTeamRecruitingRankings(s.ctx, rankings); err != nil {
+			if err := s.db.InsertTeamRecruitingRankings(ctx, rankings); err != nil {
 				slog.Error("failed to insert recruiting rankings", "err", err)
 				return fmt.Errorf("failed to insert recruiting rankings; %w", err)
 			}
@@ -1682,16 +2396,86 @@ func (s *Seeder) SeedRecruitingRankings() error {
 	return nil
 }
 
-func (s *Seeder) SeedDraftPicks() error {
+// SeedAggregatedTeamRecruiting seeds recruiting ratings aggregated by team
+// and position group across every supported year, optionally limited to the
+// position groups configured via SetPositionGroups.
+func (s *Seeder) SeedAggregatedTeamRecruiting(ctx context.Context) error {
+	if err := s.throttle(ctx, endpointRecruitingGroups); err != nil {
+		return fmt.Errorf("failed to wait for rate limit; %w", err)
+	}
+
+	groups, err := retryReq(
+		s, ctx, endpointRecruitingGroups, s.api.GetTeamPositionGroupRecruitingRankings,
+		cfbd.GetTeamPositionGroupRecruitingRankingsRequest{
+			StartYear: s.years[0],
+			EndYear:   s.years[len(s.years)-1],
+		},
+	)
+	if err != nil {
+		slog.Error("failed to get aggregated team recruiting", "err", err)
+		return fmt.Errorf(
+			"failed to get aggregated team recruiting; %w", err,
+		)
+	}
+
+	s.usage.Record(endpointRecruitingGroups)
+	groups = transform(s, endpointRecruitingGroups, groups)
+	groups = s.filterPositionGroups(groups)
+
+	if err := s.db.InsertAggregatedTeamRecruiting(ctx, groups); err != nil {
+		slog.Error("failed to insert aggregated team recruiting", "err", err)
+		return fmt.Errorf(
+			"failed to insert aggregated team recruiting; %w", err,
+		)
+	}
+
+	slog.Info(
+		"aggregated team recruiting successfully inserted",
+		"total_count", len(groups),
+	)
+	return nil
+}
+
+// filterPositionGroups returns the groups whose position group passes the
+// configured filter, or every group without one.
+func (s *Seeder) filterPositionGroups(
+	groups []*cfbd.AggregatedTeamRecruiting,
+) []*cfbd.AggregatedTeamRecruiting {
+	if len(s.positionGroups) == 0 {
+		return groups
+	}
+
+	filtered := make([]*cfbd.AggregatedTeamRecruiting, 0, len(groups))
+	for _, g := range groups {
+		if g != nil && s.includesPositionGroup(g.PositionGroup) {
+			filtered = append(filtered, g)
+		}
+	}
+	return filtered
+}
+
+// includesPositionGroup reports whether the position group passes the
+// configured filter.
+func (s *Seeder) includesPositionGroup(group string) bool {
+	for _, g := range s.positionGroups {
+		if strings.EqualFold(strings.TrimSpace(g), group) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Seeder) SeedDraftPicks(ctx context.Context) error {
 	totalInserted := 0
 
-	for _, year := range supportedYears {
-		if err := s.throttle(s.ctx); err != nil {
+	for _, year := range s.years {
+		if err := s.throttle(ctx, endpointDraftPicks); err != nil {
 			return fmt.Errorf("failed to wait for rate limit; %w", err)
 		}
 
-		picks, err := s.api.GetDraftPicks(
-			s.ctx, cfbd.GetDraftPicksRequest{Year: year},
+		picks, err := retryReq(
+			s, ctx, endpointDraftPicks, s.api.GetDraftPicks,
+			cfbd.GetDraftPicksRequest{Year: year},
 		)
 		if err != nil {
 			slog.Error(
@@ -1703,8 +2487,11 @@ func (s *Seeder) SeedDraftPicks() error {
 			return fmt.Errorf("failed to get draft picks for year %d; %w", year, err)
 		}
 
+		s.usage.Record(endpointDraftPicks)
+		picks = transform(s, endpointDraftPicks, picks)
+
 		if len(picks) > 0 {
-			if err := s.db.InsertDraftPicks(s.ctx, picks); err != nil {
+			if err := s.db.InsertDraftPicks(ctx, picks); err != nil {
 				slog.Error("failed to insert draft picks", "err", err)
 				return fmt.Errorf("failed to insert draft picks; %w", err)
 			}
@@ -1723,6 +2510,26 @@ func (s *Seeder) SeedDraftPicks() error {
 	return nil
 }
 
+// expectUnits sets the units of the task running under ctx to the sum of
+// count over the configured years, e.g. the games already seeded for each.
+// A failed count only costs the ETA, so it is logged and skipped.
+func (s *Seeder) expectUnits(
+	ctx context.Context,
+	count func(context.Context, int32) (int64, error),
+) {
+	var total int64
+	for _, year := range s.years {
+		n, err := count(ctx, year)
+		if err != nil {
+			slog.Warn("failed to count task units", "year", year, "err", err)
+			return
+		}
+		total += n
+	}
+
+	s.progress.SetUnits(ctx, total)
+}
+
 func int32ToString(val int32) string {
 	return strconv.FormatInt(int64(val), 10)
 }
@@ -0,0 +1,109 @@
+package seed_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/seed"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/seed/mocks"
+	"github.com/clintrovert/cfbd-go/cfbd"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/time/rate"
+)
+
+// newTestDatabase returns a migrated SQLite database in a temporary
+// directory.
+func newTestDatabase(t *testing.T) *db.Database {
+	t.Helper()
+
+	database, err := db.NewDatabase(db.Config{
+		Driver: db.DriverSQLite,
+		DSN:    filepath.Join(t.TempDir(), "cfbd.db"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = database.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+
+	return database
+}
+
+// newTestSeeder returns a seeder over a new test database calling the mock
+// API, with no rate limit.
+func newTestSeeder(t *testing.T, api seed.CFBDAPI) (*seed.Seeder, *db.Database) {
+	t.Helper()
+
+	database := newTestDatabase(t)
+	seeder, err := seed.NewSeeder(database, api, rate.NewLimiter(rate.Inf, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return seeder, database
+}
+
+func TestSeedTeamsAPIError(t *testing.T) {
+	api := mocks.NewMockCFBDAPI(gomock.NewController(t))
+	apiErr := errors.New("401 Unauthorized")
+	api.EXPECT().
+		GetTeams(gomock.Any(), cfbd.GetTeamsRequest{}).
+		Return(nil, apiErr)
+
+	seeder, database := newTestSeeder(t, api)
+	err := seeder.SeedTeams(context.Background())
+	if !errors.Is(err, apiErr) {
+		t.Fatalf("SeedTeams error = %v, want %v", err, apiErr)
+	}
+
+	assertRows(t, database, "teams", 0)
+}
+
+func TestSeedTeamsEmpty(t *testing.T) {
+	api := mocks.NewMockCFBDAPI(gomock.NewController(t))
+	api.EXPECT().
+		GetTeams(gomock.Any(), cfbd.GetTeamsRequest{}).
+		Return([]*cfbd.Team{}, nil)
+
+	seeder, database := newTestSeeder(t, api)
+	if err := seeder.SeedTeams(context.Background()); err != nil {
+		t.Fatalf("SeedTeams: %v", err)
+	}
+
+	assertRows(t, database, "teams", 0)
+}
+
+func TestSeedTeams(t *testing.T) {
+	api := mocks.NewMockCFBDAPI(gomock.NewController(t))
+	api.EXPECT().
+		GetTeams(gomock.Any(), cfbd.GetTeamsRequest{}).
+		Return([]*cfbd.Team{
+			{Id: 130, School: "Michigan", Classification: "fbs"},
+			{Id: 194, School: "Ohio State", Classification: "fbs"},
+			{Id: 130, School: "Michigan", Classification: "fbs"},
+		}, nil)
+
+	seeder, database := newTestSeeder(t, api)
+	if err := seeder.SeedTeams(context.Background()); err != nil {
+		t.Fatalf("SeedTeams: %v", err)
+	}
+
+	assertRows(t, database, "teams", 2)
+}
+
+// assertRows fails the test unless the table has want rows.
+func assertRows(t *testing.T, database *db.Database, table string, want int64) {
+	t.Helper()
+
+	var got int64
+	if err := database.Table(table).Count(&got).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("%s has %d rows, want %d", table, got, want)
+	}
+}
@@ -0,0 +1,280 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+// DefaultStreamBuffer is the number of records a stream holds for a slow
+// consumer before it stops fetching.
+const DefaultStreamBuffer = 1000
+
+// StreamOptions narrows what a stream fetches.
+type StreamOptions struct {
+	// Years are the seasons to fetch; empty uses the seeder's years.
+	Years []int32
+	// SeasonType keeps only weeks of this season type, e.g. "regular";
+	// empty keeps every week. Only per-week streams use it.
+	SeasonType string
+	// Weeks keeps only these weeks of each season; empty keeps every week.
+	// Only per-week streams use it.
+	Weeks []int32
+	// Buffer is the capacity of the stream's channel; zero uses
+	// DefaultStreamBuffer.
+	Buffer int
+}
+
+// Stream delivers records fetched in the background. C is closed once every
+// record has been sent, the fetch fails or the context is done; Err then
+// reports why it stopped.
+type Stream[T any] struct {
+	C <-chan T
+
+	mu  sync.Mutex
+	err error
+}
+
+// Err returns the error that ended the stream, or nil if every record was
+// delivered. It is only meaningful once C has been closed.
+func (s *Stream[T]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// StreamPlays fetches the plays of every calendar week of the selected
+// seasons, one request per week, and sends them on the stream as they
+// arrive. Requests are throttled and retried like a regular seed, but
+// nothing is written to the database, so the seeder may be created with a
+// nil database.
+func (s *Seeder) StreamPlays(
+	ctx context.Context,
+	opts StreamOptions,
+) (*Stream[*cfbd.Play], error) {
+	return streamWeeks(s, ctx, opts, endpointPlays,
+		func(ctx context.Context, week weekRequest) ([]*cfbd.Play, error) {
+			return retryReq(s, ctx, endpointPlays, s.api.GetPlays,
+				cfbd.GetPlaysRequest{
+					Year:       week.year,
+					Week:       week.week,
+					SeasonType: week.seasonType,
+				},
+			)
+		},
+	)
+}
+
+// StreamPlayStats is StreamPlays for player stats by play.
+func (s *Seeder) StreamPlayStats(
+	ctx context.Context,
+	opts StreamOptions,
+) (*Stream[*cfbd.PlayStat], error) {
+	return streamWeeks(s, ctx, opts, endpointPlayStats,
+		func(ctx context.Context, week weekRequest) ([]*cfbd.PlayStat, error) {
+			return retryReq(s, ctx, endpointPlayStats, s.api.GetPlayStats,
+				cfbd.GetPlayStatsRequest{
+					Year:       week.year,
+					Week:       week.week,
+					SeasonType: week.seasonType,
+				},
+			)
+		},
+	)
+}
+
+// StreamGames fetches the games of the selected seasons, one request per
+// season, and sends them on the stream. See StreamPlays.
+func (s *Seeder) StreamGames(
+	ctx context.Context,
+	opts StreamOptions,
+) (*Stream[*cfbd.Game], error) {
+	return streamYears(s, ctx, opts, endpointGames,
+		func(ctx context.Context, year int32) ([]*cfbd.Game, error) {
+			return retryReq(s, ctx, endpointGames, s.api.GetGames,
+				cfbd.GetGamesRequest{Year: year},
+			)
+		},
+	)
+}
+
+// StreamDrives fetches the drives of the selected seasons, one request per
+// season, and sends them on the stream. See StreamPlays.
+func (s *Seeder) StreamDrives(
+	ctx context.Context,
+	opts StreamOptions,
+) (*Stream[*cfbd.Drive], error) {
+	return streamYears(s, ctx, opts, endpointDrives,
+		func(ctx context.Context, year int32) ([]*cfbd.Drive, error) {
+			return retryReq(s, ctx, endpointDrives, s.api.GetDrives,
+				cfbd.GetDrivesRequest{Year: year},
+			)
+		},
+	)
+}
+
+// weekRequest is the calendar week a per-week stream fetches.
+type weekRequest struct {
+	year       int32
+	week       int32
+	seasonType string
+}
+
+// streamYears runs fetch once per selected season in the background and
+// sends the records it returns on a new stream.
+func streamYears[T any](
+	s *Seeder,
+	ctx context.Context,
+	opts StreamOptions,
+	endpoint string,
+	fetch func(context.Context, int32) ([]T, error),
+) (*Stream[T], error) {
+	years, err := s.streamSeasons(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return startStream(ctx, opts, func(send func([]T) error) error {
+		for _, year := range years {
+			if err := s.throttle(ctx, endpoint); err != nil {
+				return fmt.Errorf("failed to wait for rate limit; %w", err)
+			}
+
+			records, err := fetch(ctx, year)
+			if err != nil {
+				return fmt.Errorf(
+					"failed to get %s for year %d; %w", endpoint, year, err,
+				)
+			}
+
+			s.usage.Record(endpoint)
+			if err = send(transform(s, endpoint, records)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}), nil
+}
+
+// streamWeeks fetches the calendar of each selected season, then runs fetch
+// once per selected week in the background and sends the records it returns
+// on a new stream.
+func streamWeeks[T any](
+	s *Seeder,
+	ctx context.Context,
+	opts StreamOptions,
+	endpoint string,
+	fetch func(context.Context, weekRequest) ([]T, error),
+) (*Stream[T], error) {
+	years, err := s.streamSeasons(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return startStream(ctx, opts, func(send func([]T) error) error {
+		for _, year := range years {
+			weeks, err := s.calendar(ctx, year)
+			if err != nil {
+				return err
+			}
+
+			for _, week := range weeks {
+				if !opts.includesWeek(week) {
+					continue
+				}
+
+				if err = s.throttle(ctx, endpoint); err != nil {
+					return fmt.Errorf("failed to wait for rate limit; %w", err)
+				}
+
+				records, err := fetch(ctx, weekRequest{
+					year:       year,
+					week:       week.Week,
+					seasonType: week.SeasonType,
+				})
+				if err != nil {
+					return fmt.Errorf(
+						"failed to get %s for year %d, week %d, "+
+							"season_type %s; %w",
+						endpoint, year, week.Week, week.SeasonType, err,
+					)
+				}
+
+				s.usage.Record(endpoint)
+				if err = send(transform(s, endpoint, records)); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}), nil
+}
+
+// streamSeasons returns the seasons a stream covers.
+func (s *Seeder) streamSeasons(opts StreamOptions) ([]int32, error) {
+	if opts.Buffer < 0 {
+		return nil, fmt.Errorf("invalid stream buffer %d", opts.Buffer)
+	}
+	if len(opts.Years) == 0 {
+		return slices.Clone(s.years), nil
+	}
+
+	years := slices.Clone(opts.Years)
+	slices.Sort(years)
+	return years, nil
+}
+
+// includesWeek reports whether the options select the calendar week.
+func (o StreamOptions) includesWeek(week db.CalendarWeek) bool {
+	if o.SeasonType != "" && week.SeasonType != o.SeasonType {
+		return false
+	}
+
+	return len(o.Weeks) == 0 || slices.Contains(o.Weeks, week.Week)
+}
+
+// startStream runs produce in the background, handing it a send function
+// that delivers records until ctx is done, and closes the stream once
+// produce returns.
+func startStream[T any](
+	ctx context.Context,
+	opts StreamOptions,
+	produce func(send func([]T) error) error,
+) *Stream[T] {
+	buffer := opts.Buffer
+	if buffer == 0 {
+		buffer = DefaultStreamBuffer
+	}
+
+	ch := make(chan T, buffer)
+	stream := &Stream[T]{C: ch}
+
+	send := func(records []T) error {
+		for _, record := range records {
+			select {
+			case ch <- record:
+			case <-ctx.Done():
+				return fmt.Errorf("stream stopped; %w", ctx.Err())
+			}
+		}
+		return nil
+	}
+
+	go func() {
+		err := produce(send)
+
+		stream.mu.Lock()
+		stream.err = err
+		stream.mu.Unlock()
+		close(ch)
+	}()
+
+	return stream
+}
@@ -0,0 +1,228 @@
+package seed
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"github.com/clintrovert/cfbd-go/cfbd"
+	"golang.org/x/sync/errgroup"
+)
+
+// SetSkipIdentical sets whether a sync skips writing a response whose
+// content hash matches the last one written for the same request, which
+// makes syncing a week without changes nearly free on the database side.
+func (s *Seeder) SetSkipIdentical(skip bool) {
+	s.skipIdentical = skip
+}
+
+// SyncLatestWeek refreshes the games, plays, play stats, betting lines and
+// rankings of the calendar week in progress, or of the most recent week
+// when between weeks, and then the play flags and aggregates of its season.
+// It costs a handful of requests, which makes it cheap enough to run from a
+// weekly (or daily) cron in season. The calendar must have been seeded by a
+// regular run.
+func (s *Seeder) SyncLatestWeek(ctx context.Context) error {
+	week, ok, err := s.db.GetLatestStartedWeek(ctx, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to get latest week; %w", err)
+	}
+	if !ok {
+		slog.Info("no calendar week has started; nothing to sync")
+		return nil
+	}
+
+	slog.Info(
+		"syncing week",
+		"season", week.Season,
+		"week", week.Week,
+		"season_type", week.SeasonType,
+	)
+
+	if err = s.runWeekTasks(ctx, week, syncedTasks); err != nil {
+		return fmt.Errorf("failed to sync week %d of %d; %w",
+			week.Week, week.Season, err)
+	}
+
+	if err = s.refreshPlayAggregates(ctx, week.Season); err != nil {
+		return fmt.Errorf("failed to refresh play aggregates of %d; %w",
+			week.Season, err)
+	}
+
+	slog.Info("week synced", "season", week.Season, "week", week.Week)
+	return nil
+}
+
+// syncedTasks are the week tasks a sync runs.
+var syncedTasks = []string{
+	"SeedGames", "SeedPlays", "SeedPlayStats", "SeedBettingLines",
+	"SeedRankings",
+}
+
+// runWeekTasks runs the named week tasks for the week concurrently.
+func (s *Seeder) runWeekTasks(
+	ctx context.Context,
+	week db.CalendarWeek,
+	names []string,
+) error {
+	tasks := s.weekTasks(week)
+	group, groupCtx := errgroup.WithContext(ctx)
+	var stop etl.Drainer
+	for _, name := range names {
+		task := tasks[name]
+		group.Go(func() error { return stop.Catch(task(groupCtx)) })
+	}
+
+	return stop.Err(group.Wait())
+}
+
+// weekTasks returns the tasks that can be run for a single calendar week,
+// keyed by the name of the seed method they narrow (e.g. "SeedPlays").
+func (s *Seeder) weekTasks(
+	week db.CalendarWeek,
+) map[string]func(context.Context) error {
+	return map[string]func(context.Context) error{
+		"SeedGames": func(ctx context.Context) error {
+			return syncWeek(
+				s, ctx, endpointGames, s.api.GetGames,
+				cfbd.GetGamesRequest{
+					Year: week.Season, Week: week.Week, SeasonType: week.SeasonType,
+				},
+				s.db.InsertGames,
+			)
+		},
+		"SeedPlays": func(ctx context.Context) error {
+			return syncWeek(
+				s, ctx, endpointPlays, s.api.GetPlays,
+				cfbd.GetPlaysRequest{
+					Year: week.Season, Week: week.Week, SeasonType: week.SeasonType,
+				},
+				func(ctx context.Context, plays []*cfbd.Play) error {
+					return s.db.InsertPlays(ctx, week.Season, plays)
+				},
+			)
+		},
+		"SeedPlayStats": func(ctx context.Context) error {
+			return syncWeek(
+				s, ctx, endpointPlayStats, s.api.GetPlayStats,
+				cfbd.GetPlayStatsRequest{
+					Year: week.Season, Week: week.Week, SeasonType: week.SeasonType,
+				},
+				s.db.InsertPlayStats,
+			)
+		},
+		"SeedBettingLines": func(ctx context.Context) error {
+			return syncWeek(
+				s, ctx, endpointBettingLines, s.api.GetBettingLines,
+				cfbd.GetBettingLinesRequest{
+					Year: week.Season, Week: week.Week, SeasonType: week.SeasonType,
+				},
+				s.db.InsertBettingLines,
+			)
+		},
+		"SeedRankings": func(ctx context.Context) error {
+			return syncWeek(
+				s, ctx, endpointRankings, s.api.GetRankings,
+				cfbd.GetRankingsRequest{
+					Year:       week.Season,
+					Week:       float64(week.Week),
+					SeasonType: week.SeasonType,
+				},
+				s.db.InsertRankings,
+			)
+		},
+		"SeedGameMedia": func(ctx context.Context) error {
+			return syncWeek(
+				s, ctx, endpointGameMedia, s.api.GetGameMedia,
+				cfbd.GetGameMediaRequest{
+					Year: week.Season, Week: week.Week, SeasonType: week.SeasonType,
+				},
+				s.db.InsertGameMedia,
+			)
+		},
+		"SeedGameWeather": func(ctx context.Context) error {
+			return syncWeek(
+				s, ctx, endpointGameWeather, s.api.GetGameWeather,
+				cfbd.GetGameWeatherRequest{
+					Year: week.Season, Week: week.Week, SeasonType: week.SeasonType,
+				},
+				s.db.InsertGameWeather,
+			)
+		},
+	}
+}
+
+// syncWeek fetches one endpoint's records for a week and upserts them.
+func syncWeek[R, T any](
+	s *Seeder,
+	ctx context.Context,
+	endpoint string,
+	fetch func(context.Context, R) ([]T, error),
+	req R,
+	insert func(context.Context, []T) error,
+) error {
+	if err := s.throttle(ctx, endpoint); err != nil {
+		return fmt.Errorf("failed to wait for rate limit; %w", err)
+	}
+
+	records, err := retryReq(s, ctx, endpoint, fetch, req)
+	if err != nil {
+		slog.Error("failed to fetch week", "endpoint", endpoint, "err", err)
+		return fmt.Errorf("failed to fetch %s; %w", endpoint, err)
+	}
+
+	s.usage.Record(endpoint)
+	records = transform(s, endpoint, records)
+
+	// The hash covers the transformed records, so a change to the
+	// transforms is written even when the response itself is unchanged.
+	params, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request; %w", endpoint, err)
+	}
+	payload, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to hash %s response; %w", endpoint, err)
+	}
+	sum := sha256.Sum256(payload)
+
+	if s.skipIdentical {
+		previous, ok, err := s.db.GetResponseHash(ctx, endpoint, params)
+		if err != nil {
+			return fmt.Errorf("failed to get %s response hash; %w", endpoint, err)
+		}
+		if ok && bytes.Equal(previous, sum[:]) {
+			slog.Info(
+				"unchanged since last sync",
+				"endpoint", endpoint,
+				"count", len(records),
+			)
+			return nil
+		}
+	}
+
+	if err = insert(ctx, records); err != nil {
+		slog.Error("failed to insert week", "endpoint", endpoint, "err", err)
+		return fmt.Errorf("failed to insert %s; %w", endpoint, err)
+	}
+
+	// A hash that fails to save only costs a rewrite on the next sync.
+	if err = s.db.UpsertResponseHash(
+		ctx, s.run.ID(), endpoint, params, sum[:], time.Now().UTC(),
+	); err != nil {
+		slog.Warn(
+			"failed to save response hash",
+			"endpoint", endpoint,
+			"err", err,
+		)
+	}
+
+	slog.Info("synced", "endpoint", endpoint, "count", len(records))
+	return nil
+}
@@ -0,0 +1,188 @@
+package seed
+
+import (
+	"context"
+	"sort"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+)
+
+// Tasks returns every self-contained unit of work the seeder can run on
+// its own, keyed by method name (e.g. "SeedRankings"). Each task stops
+// early once its context is done.
+func (s *Seeder) Tasks() map[string]func(context.Context) error {
+	fns := []func(context.Context) error{
+		s.SeedPlayTypes,
+		s.SeedConferences,
+		s.SeedVenues,
+		s.SeedStatTypes,
+		s.SeedDraftTeams,
+		s.SeedDraftPositions,
+		s.SeedFieldGoalEP,
+		s.SeedTeams,
+		s.SeedCalendar,
+		s.SeedPlayerSearch,
+		s.SeedGames,
+		s.SeedScoreboard,
+		s.SeedLiveGames,
+		s.SeedDrives,
+		s.SeedPlays,
+		s.SeedPlayStats,
+		s.SeedGameTeamStats,
+		s.SeedGamePlayerStats,
+		s.SeedWinProbability,
+		s.SeedAdvancedBoxScore,
+		s.SeedGameWeather,
+		s.SeedGameMedia,
+		s.SeedBettingLines,
+		s.SeedTeamRecords,
+		s.SeedTeamTalentComposite,
+		s.SeedTeamATS,
+		s.SeedTeamSPPlus,
+		s.SeedConferenceSPPlus,
+		s.SeedTeamSRSRankings,
+		s.SeedTeamEloRankings,
+		s.SeedTeamFPIRankings,
+		s.SeedWepaTeamSeason,
+		s.SeedWepaPassing,
+		s.SeedWepaRushing,
+		s.SeedWepaKicking,
+		s.SeedReturningProduction,
+		s.SeedPortalPlayers,
+		s.SeedSeasonPlayerStats,
+		s.SeedSeasonTeamStats,
+		s.SeedRankings,
+		s.SeedRecruits,
+		s.SeedRecruitingRankings,
+		s.SeedAggregatedTeamRecruiting,
+		s.SeedDraftPicks,
+		s.SnapshotActualWeather,
+		s.SnapshotWeekLines,
+		s.SyncLatestWeek,
+		s.RetryFailed,
+		s.VerifyNextWeek,
+		s.ComputeDataQuality,
+		s.ComputePlayFlags,
+		s.ComputeDriveStats,
+		s.ComputeTeamWeekStats,
+		s.ResolvePlayers,
+		s.EnrichVenues,
+		s.ComputeTravel,
+		s.BackfillWeather,
+		s.ComputeRatings,
+		s.BackfillWinProbability,
+	}
+
+	tasks := make(map[string]func(context.Context) error, len(fns))
+	for _, fn := range fns {
+		tasks[etl.FuncName(fn)] = fn
+	}
+
+	return tasks
+}
+
+// SeedTasks returns the tasks of a full seed, each with the tasks whose rows
+// it depends on. The runner derives the phases from these prerequisites, so
+// adding a seed function to a full seed only takes a line here (and one in
+// planTasks for its projected cost).
+func (s *Seeder) SeedTasks() []etl.Task {
+	return []etl.Task{
+		// Global lookups
+		etl.NewTask(s.SeedVenues),
+		etl.NewTask(s.SeedPlayTypes),
+		etl.NewTask(s.SeedStatTypes),
+		etl.NewTask(s.SeedDraftTeams),
+		etl.NewTask(s.SeedConferences),
+		etl.NewTask(s.SeedFieldGoalEP),
+		etl.NewTask(s.SeedDraftPositions),
+
+		// Teams
+		etl.NewTask(s.SeedTeams, s.SeedVenues, s.SeedConferences),
+
+		// Calendars and games
+		etl.NewTask(s.SeedCalendar, s.SeedTeams),
+		etl.NewTask(s.SeedGames, s.SeedTeams),
+		etl.NewTask(s.SeedScoreboard, s.SeedTeams),
+		etl.NewTask(s.SeedPlayerSearch, s.SeedTeams),
+
+		// Week and game stats
+		etl.NewTask(s.SeedDrives, s.SeedGames),
+		etl.NewTask(s.SeedPlays, s.SeedGames, s.SeedPlayTypes),
+		etl.NewTask(s.SeedPlayStats, s.SeedGames, s.SeedStatTypes),
+		etl.NewTask(s.SeedGameTeamStats, s.SeedGames),
+		etl.NewTask(s.SeedGamePlayerStats, s.SeedGames),
+		// TODO: Introduce rate limiter to mitigate request bursts
+		etl.NewTask(s.SeedAdvancedBoxScore, s.SeedGames),
+		etl.NewTask(s.SeedGameWeather, s.SeedGames),
+		etl.NewTask(s.SeedGameMedia, s.SeedGames),
+		etl.NewTask(s.SeedBettingLines, s.SeedGames),
+		etl.NewTask(s.SeedWinProbability, s.SeedGames),
+
+		// Season stats
+		etl.NewTask(s.SeedTeamRecords, s.SeedGames),
+		etl.NewTask(s.SeedTeamTalentComposite, s.SeedGames),
+		etl.NewTask(s.SeedTeamATS, s.SeedGames),
+		etl.NewTask(s.SeedTeamSPPlus, s.SeedGames),
+		etl.NewTask(s.SeedConferenceSPPlus, s.SeedGames),
+		etl.NewTask(s.SeedTeamSRSRankings, s.SeedGames),
+		etl.NewTask(s.SeedTeamEloRankings, s.SeedGames),
+		etl.NewTask(s.SeedTeamFPIRankings, s.SeedGames),
+		etl.NewTask(s.SeedWepaTeamSeason, s.SeedGames),
+		etl.NewTask(s.SeedWepaPassing, s.SeedGames),
+		etl.NewTask(s.SeedWepaRushing, s.SeedGames),
+		etl.NewTask(s.SeedWepaKicking, s.SeedGames),
+		etl.NewTask(s.SeedReturningProduction, s.SeedGames),
+		etl.NewTask(s.SeedPortalPlayers, s.SeedGames),
+		etl.NewTask(s.SeedSeasonPlayerStats, s.SeedGames),
+		etl.NewTask(s.SeedSeasonTeamStats, s.SeedGames),
+		etl.NewTask(s.SeedRankings, s.SeedGames),
+
+		// Recruiting and draft
+		etl.NewTask(s.SeedRecruits, s.SeedTeams),
+		etl.NewTask(s.SeedRecruitingRankings, s.SeedTeams),
+		etl.NewTask(s.SeedAggregatedTeamRecruiting, s.SeedTeams),
+		etl.NewTask(s.SeedDraftPicks,
+			s.SeedTeams, s.SeedDraftTeams, s.SeedDraftPositions),
+
+		// Player identities, once every table that records athletes is in
+		etl.NewTask(s.ResolvePlayers,
+			s.SeedPlayerSearch, s.SeedSeasonPlayerStats, s.SeedRecruits,
+			s.SeedDraftPicks, s.SeedPortalPlayers),
+
+		// Dead letters, once the per-game fetches that record them are done
+		etl.NewTask(s.RetryFailed, s.SeedWinProbability, s.SeedAdvancedBoxScore),
+
+		// Play flags and the drive and team week aggregates, from the plays
+		etl.NewTask(s.ComputePlayFlags, s.SeedPlays),
+		etl.NewTask(s.ComputeDriveStats, s.ComputePlayFlags),
+		etl.NewTask(s.ComputeTeamWeekStats, s.ComputePlayFlags),
+
+		// Completeness, once the data it measures is in
+		etl.NewTask(s.ComputeDataQuality,
+			s.SeedGames, s.SeedPlays, s.SeedGameWeather, s.SeedBettingLines),
+
+		// Local ratings, from the game results
+		etl.NewTask(s.ComputeRatings, s.SeedGames),
+
+		// Venue locations the API lacks, from the geocoder if one is set
+		etl.NewTask(s.EnrichVenues, s.SeedVenues),
+
+		// Travel and rest, once games are in and venues are located
+		etl.NewTask(s.ComputeTravel, s.SeedGames, s.SeedTeams, s.EnrichVenues),
+
+		// Weather the API lacks, from the weather provider if one is set
+		etl.NewTask(s.BackfillWeather, s.SeedGameWeather, s.EnrichVenues),
+	}
+}
+
+// TaskNames returns the names of every task in Tasks, sorted.
+func (s *Seeder) TaskNames() []string {
+	tasks := s.Tasks()
+	names := make([]string, 0, len(tasks))
+	for name := range tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
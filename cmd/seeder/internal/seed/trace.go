@@ -0,0 +1,46 @@
+package seed
+
+import (
+	"reflect"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// requestFields maps the request fields worth recording on an API call's
+// span to their attribute keys.
+var requestFields = []struct {
+	field string
+	key   string
+}{
+	{"Year", "cfbd.year"},
+	{"Week", "cfbd.week"},
+	{"SeasonType", "cfbd.season_type"},
+	{"GameID", "cfbd.game_id"},
+}
+
+// requestAttributes returns the span attributes of the year, week, season
+// type and game ID set on an API request struct.
+func requestAttributes(req any) []attribute.KeyValue {
+	v := reflect.Indirect(reflect.ValueOf(req))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+	for _, f := range requestFields {
+		field := reflect.Indirect(v.FieldByName(f.field))
+		if !field.IsValid() || field.IsZero() {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+			reflect.Int64:
+			attrs = append(attrs, attribute.Int64(f.key, field.Int()))
+		case reflect.String:
+			attrs = append(attrs, attribute.String(f.key, field.String()))
+		}
+	}
+
+	return attrs
+}
@@ -0,0 +1,61 @@
+package seed
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Transform filters or augments a batch of records fetched from an endpoint
+// before it is inserted, e.g. to drop FCS games or fill in a computed
+// field. It may modify the records in place and must return the records to
+// insert.
+type Transform[T any] func([]T) []T
+
+// transformRegistry holds the transforms registered per endpoint, in the
+// order they run. Transforms are stored untyped because every endpoint
+// returns a different record type; they are matched back up in transform.
+type transformRegistry struct {
+	mu        sync.RWMutex
+	endpoints map[string][]any
+}
+
+func newTransformRegistry() *transformRegistry {
+	return &transformRegistry{endpoints: make(map[string][]any)}
+}
+
+// AddTransform appends fn to the transforms run on records fetched from the
+// CFBD endpoint path (e.g. "/games") before they are inserted. Transforms
+// run in the order they were added. T must be the endpoint's record type,
+// e.g. *cfbd.Game for "/games"; transforms of any other type are skipped.
+func AddTransform[T any](s *Seeder, endpoint string, fn Transform[T]) {
+	s.transforms.mu.Lock()
+	defer s.transforms.mu.Unlock()
+
+	s.transforms.endpoints[endpoint] = append(
+		s.transforms.endpoints[endpoint], fn,
+	)
+}
+
+// transform runs the transforms registered for the endpoint over records.
+func transform[T any](s *Seeder, endpoint string, records []T) []T {
+	s.transforms.mu.RLock()
+	fns := s.transforms.endpoints[endpoint]
+	s.transforms.mu.RUnlock()
+
+	for _, fn := range fns {
+		typed, ok := fn.(Transform[T])
+		if !ok {
+			slog.Warn(
+				"skipping transform with mismatched record type",
+				"endpoint", endpoint,
+				"records", fmt.Sprintf("%T", records),
+				"transform", fmt.Sprintf("%T", fn),
+			)
+			continue
+		}
+		records = typed(records)
+	}
+
+	return records
+}
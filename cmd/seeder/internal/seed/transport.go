@@ -0,0 +1,66 @@
+package seed
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+// ErrClientTransport is returned by WrapClientTransport when the cfbd
+// client does not hold its HTTP client where this version of it does.
+var ErrClientTransport = errors.New("cannot reach cfbd client transport")
+
+// WrapClientTransport replaces the transport of the HTTP client the cfbd
+// client sends its requests with by wrap applied to it, so that only that
+// client's requests go through the wrapper. A nil transport is passed on
+// as http.DefaultTransport, which the HTTP client used in its place.
+//
+// The cfbd client takes no HTTP client or transport of its own, so its
+// HTTP client is reached through the client's unexported fields.
+func WrapClientTransport(
+	client *cfbd.Client,
+	wrap func(next http.RoundTripper) http.RoundTripper,
+) error {
+	httpClient, err := clientHTTPClient(client)
+	if err != nil {
+		return err
+	}
+
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	httpClient.Transport = wrap(next)
+
+	return nil
+}
+
+// clientHTTPClient returns the HTTP client of the cfbd client's request
+// executor, its httpGet field.
+func clientHTTPClient(client *cfbd.Client) (*http.Client, error) {
+	if client == nil {
+		return nil, ErrClientTransport
+	}
+
+	executor := reflect.ValueOf(client).Elem().FieldByName("httpGet")
+	if executor.Kind() != reflect.Interface || executor.IsNil() {
+		return nil, ErrClientTransport
+	}
+	executor = executor.Elem()
+	if executor.Kind() != reflect.Pointer || executor.IsNil() ||
+		executor.Elem().Kind() != reflect.Struct {
+		return nil, ErrClientTransport
+	}
+
+	field := executor.Elem().FieldByName("HTTPClient")
+	if !field.IsValid() || field.Type() != reflect.TypeFor[*http.Client]() ||
+		field.IsNil() {
+		return nil, ErrClientTransport
+	}
+
+	// The field is read through unexported ones, so reflect only hands out
+	// its pointer.
+	return (*http.Client)(field.UnsafePointer()), nil
+}
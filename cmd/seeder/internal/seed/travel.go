@@ -0,0 +1,120 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/geocode"
+)
+
+// ComputeTravel derives, for both teams of every game of each selected
+// season, the distance from the team's home venue to the game's venue and
+// the days of rest since the team's previous game of the season, into
+// game_travel. Rest days are the time between the start of the two games
+// rounded to whole days, so consecutive Saturdays are 7 apart whatever the
+// kickoff times. It makes no API requests.
+func (s *Seeder) ComputeTravel(ctx context.Context) error {
+	for _, year := range s.years {
+		if err := etl.Err(ctx); err != nil {
+			return err
+		}
+
+		if err := s.computeSeasonTravel(ctx, year); err != nil {
+			slog.Error(
+				"failed to compute travel",
+				"year", int32ToString(year),
+				"err", err,
+			)
+			return fmt.Errorf("failed to compute travel for %d; %w", year, err)
+		}
+	}
+
+	slog.Info("computed travel", "years", len(s.years))
+	return nil
+}
+
+// computeSeasonTravel computes and stores a season's travel.
+func (s *Seeder) computeSeasonTravel(ctx context.Context, year int32) error {
+	games, err := s.db.GetTravelGames(ctx, year)
+	if err != nil {
+		return err
+	}
+
+	type previousGame struct {
+		id        int32
+		startDate time.Time
+	}
+	previous := make(map[string]previousGame)
+
+	now := time.Now().UTC()
+	travel := make([]db.GameTravel, 0, 2*len(games))
+	for _, g := range games {
+		venue := location(g.VenueLatitude, g.VenueLongitude)
+		sides := []struct {
+			team, homeAway string
+			homeVenueID    *int32
+			home           *geocode.Location
+		}{
+			{
+				g.HomeTeam, "home", g.HomeVenueID,
+				location(g.HomeVenueLatitude, g.HomeVenueLongitude),
+			},
+			{
+				g.AwayTeam, "away", g.AwayVenueID,
+				location(g.AwayVenueLatitude, g.AwayVenueLongitude),
+			},
+		}
+
+		for _, side := range sides {
+			row := db.GameTravel{
+				GameID:      g.ID,
+				Team:        side.team,
+				Season:      year,
+				Week:        g.Week,
+				SeasonType:  g.SeasonType,
+				HomeAway:    side.homeAway,
+				NeutralSite: g.NeutralSite,
+				HomeVenueID: side.homeVenueID,
+				VenueID:     g.VenueID,
+				ComputedAt:  now,
+			}
+			if venue != nil && side.home != nil {
+				miles := geocode.Miles(*side.home, *venue)
+				row.DistanceMiles = &miles
+			}
+
+			if g.StartDate != nil {
+				if prev, ok := previous[side.team]; ok {
+					days := int32(math.Round(
+						g.StartDate.Sub(prev.startDate).Hours() / 24,
+					))
+					row.PreviousGameID = &prev.id
+					row.RestDays = &days
+				}
+				previous[side.team] = previousGame{
+					id:        g.ID,
+					startDate: *g.StartDate,
+				}
+			}
+
+			travel = append(travel, row)
+		}
+	}
+
+	return s.db.ReplaceGameTravel(ctx, year, travel)
+}
+
+// location returns the point at the coordinates, or nil if either is
+// missing.
+func location(latitude, longitude *float64) *geocode.Location {
+	if latitude == nil || longitude == nil {
+		return nil
+	}
+
+	return &geocode.Location{Latitude: *latitude, Longitude: *longitude}
+}
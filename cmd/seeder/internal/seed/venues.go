@@ -0,0 +1,129 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/geocode"
+)
+
+// Geocoder locates venues the API has no coordinates or elevation for.
+// *geocode.Client implements it.
+type Geocoder interface {
+	Locate(
+		ctx context.Context,
+		city, state, country string,
+	) (geocode.Location, error)
+	Elevation(ctx context.Context, latitude, longitude float64) (float64, error)
+}
+
+// SetGeocoder sets the geocoder EnrichVenues uses. Without one, it does
+// nothing.
+func (s *Seeder) SetGeocoder(g Geocoder) {
+	s.geocoder = g
+}
+
+// EnrichVenues fills in the coordinates and elevation the API is missing
+// for venues, from the geocoder. Venues without coordinates are placed at
+// their city's; venues with coordinates but no elevation have it looked
+// up. Each result is kept in venue_geocodes, so venues re-seeded without
+// them are refilled without asking the geocoder again. Venues the geocoder
+// cannot place are logged and skipped. It makes no API requests.
+func (s *Seeder) EnrichVenues(ctx context.Context) error {
+	if s.geocoder == nil {
+		slog.Info("no geocoder set; skipping venue enrichment")
+		return nil
+	}
+
+	venues, err := s.db.GetVenuesMissingLocation(ctx)
+	if err != nil {
+		slog.Error("failed to get venues missing location", "err", err)
+		return fmt.Errorf("failed to get venues missing location; %w", err)
+	}
+	cached, err := s.db.GetVenueGeocodes(ctx)
+	if err != nil {
+		slog.Error("failed to get venue geocodes", "err", err)
+		return fmt.Errorf("failed to get venue geocodes; %w", err)
+	}
+
+	var filled, skipped int
+	for _, venue := range venues {
+		if err = etl.Err(ctx); err != nil {
+			return err
+		}
+
+		location, ok := cached[venue.ID]
+		if !ok {
+			location, err = s.geocodeVenue(ctx, venue)
+			if err != nil {
+				if !errors.Is(err, geocode.ErrNotFound) {
+					slog.Error(
+						"failed to geocode venue", "venue", venue.ID, "err", err,
+					)
+					return fmt.Errorf(
+						"failed to geocode venue %d; %w", venue.ID, err,
+					)
+				}
+				slog.Warn(
+					"could not geocode venue",
+					"venue", venue.ID,
+					"name", venue.Name,
+					"city", venue.City,
+				)
+				skipped++
+				continue
+			}
+		}
+
+		if err = s.db.FillVenueLocation(ctx, location); err != nil {
+			slog.Error(
+				"failed to fill venue location", "venue", venue.ID, "err", err,
+			)
+			return fmt.Errorf("failed to fill venue location; %w", err)
+		}
+		filled++
+	}
+
+	slog.Info("enriched venues", "filled", filled, "skipped", skipped)
+	return nil
+}
+
+// geocodeVenue looks up a venue's location: its city's if it has no
+// coordinates, or the elevation at its coordinates if it has them.
+func (s *Seeder) geocodeVenue(
+	ctx context.Context,
+	venue db.Venue,
+) (db.VenueGeocode, error) {
+	result := db.VenueGeocode{
+		VenueID:    venue.ID,
+		GeocodedAt: time.Now().UTC(),
+	}
+
+	if venue.Latitude != nil && venue.Longitude != nil {
+		elevation, err := s.geocoder.Elevation(
+			ctx, *venue.Latitude, *venue.Longitude,
+		)
+		if err != nil {
+			return db.VenueGeocode{}, err
+		}
+		result.Latitude, result.Longitude = *venue.Latitude, *venue.Longitude
+		result.Elevation = elevation
+		return result, nil
+	}
+
+	location, err := s.geocoder.Locate(
+		ctx, venue.City, venue.State, strings.ToUpper(venue.CountryCode),
+	)
+	if err != nil {
+		return db.VenueGeocode{}, err
+	}
+	result.Latitude, result.Longitude = location.Latitude, location.Longitude
+	result.Elevation = location.Elevation
+	return result, nil
+}
@@ -0,0 +1,90 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+// verificationSweep names the cursor used by VerifyNextWeek.
+const verificationSweep = "games"
+
+// VerifyNextWeek re-fetches the games for the next historical week in a
+// round-robin sweep and diffs them against the stored rows. Every changed
+// field is recorded in verification_diffs before the corrected games are
+// upserted, so silent upstream corrections are detected over time for a
+// single request per run.
+func (s *Seeder) VerifyNextWeek(ctx context.Context) error {
+	week, ok, err := s.db.NextVerificationWeek(ctx, verificationSweep)
+	if err != nil {
+		slog.Error("failed to pick verification week", "err", err)
+		return fmt.Errorf("failed to pick verification week; %w", err)
+	}
+	if !ok {
+		slog.Info("no historical weeks to verify")
+		return nil
+	}
+
+	if err = s.throttle(ctx, endpointGames); err != nil {
+		return fmt.Errorf("failed to wait for rate limit; %w", err)
+	}
+
+	games, err := retryReq(
+		s, ctx, endpointGames, s.api.GetGames,
+		cfbd.GetGamesRequest{
+			Year:       week.Season,
+			Week:       week.Week,
+			SeasonType: week.SeasonType,
+		},
+	)
+	if err != nil {
+		slog.Error(
+			"failed to get games",
+			"year", int32ToString(week.Season),
+			"week", int32ToString(week.Week),
+			"err", err,
+		)
+		return fmt.Errorf(
+			"failed to get games for %d week %d; %w",
+			week.Season, week.Week, err,
+		)
+	}
+
+	s.usage.Record(endpointGames)
+
+	diffs, err := s.db.DiffGames(ctx, week, games)
+	if err != nil {
+		slog.Error("failed to diff games", "err", err)
+		return fmt.Errorf("failed to diff games; %w", err)
+	}
+
+	if err = s.db.InsertVerificationDiffs(ctx, diffs); err != nil {
+		return fmt.Errorf("failed to record verification diffs; %w", err)
+	}
+
+	if len(diffs) > 0 {
+		if err = s.db.InsertGames(ctx, games); err != nil {
+			slog.Error("failed to insert games", "err", err)
+			return fmt.Errorf("failed to insert games; %w", err)
+		}
+	}
+
+	if err = s.db.SetVerificationCursor(
+		ctx, verificationSweep, week,
+	); err != nil {
+		slog.Error("failed to advance verification cursor", "err", err)
+		return fmt.Errorf("failed to advance verification cursor; %w", err)
+	}
+
+	slog.Info(
+		"verified historical week",
+		"year", int32ToString(week.Season),
+		"week", int32ToString(week.Week),
+		"season_type", week.SeasonType,
+		"games", len(games),
+		"diffs", len(diffs),
+	)
+	return nil
+}
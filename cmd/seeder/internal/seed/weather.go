@@ -0,0 +1,257 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/weather"
+	"github.com/clintrovert/cfbd-go/cfbd"
+)
+
+// WatchWeather captures a weather forecast for games kicking off within the
+// window and the actual weather once they complete, every interval, until
+// ctx is done. Failures are logged and retried on the next tick.
+func (s *Seeder) WatchWeather(
+	ctx context.Context,
+	window, interval time.Duration,
+) error {
+	if window <= 0 {
+		return fmt.Errorf("invalid weather forecast window %s", window)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("invalid weather interval %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SnapshotWeatherForecasts(ctx, window); err != nil {
+			slog.Warn("weather forecast snapshot failed", "err", err)
+		}
+		if err := s.SnapshotActualWeather(ctx); err != nil {
+			slog.Warn("actual weather snapshot failed", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("weather watch stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// SnapshotWeatherForecasts stores the current weather for every game kicking
+// off within the window as its forecast, replacing any earlier forecast.
+func (s *Seeder) SnapshotWeatherForecasts(
+	ctx context.Context,
+	window time.Duration,
+) error {
+	ids, weeks, err := s.db.GetGamesKickingOffWithin(ctx, window)
+	if err != nil {
+		slog.Error("failed to get upcoming games", "err", err)
+		return fmt.Errorf("failed to get upcoming games; %w", err)
+	}
+
+	return s.snapshotWeather(ctx, ids, weeks, db.WeatherForecast)
+}
+
+// SnapshotActualWeather stores the weather for every completed game that has
+// a forecast but no actual snapshot yet, and refreshes game_weather with it.
+func (s *Seeder) SnapshotActualWeather(ctx context.Context) error {
+	ids, weeks, err := s.db.GetGamesMissingActualWeather(ctx)
+	if err != nil {
+		slog.Error("failed to get completed games", "err", err)
+		return fmt.Errorf("failed to get completed games; %w", err)
+	}
+
+	return s.snapshotWeather(ctx, ids, weeks, db.WeatherActual)
+}
+
+// snapshotWeather fetches weather for the given games, one request per week,
+// and stores it as a snapshot of the given kind.
+func (s *Seeder) snapshotWeather(
+	ctx context.Context,
+	ids []int32,
+	weeks []db.GameWeek,
+	kind string,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	wanted := make(map[int32]struct{}, len(ids))
+	for _, id := range ids {
+		wanted[id] = struct{}{}
+	}
+
+	capturedAt := time.Now().UTC()
+	var all []*cfbd.GameWeather
+	for _, week := range weeks {
+		if err := s.throttle(ctx, endpointGameWeather); err != nil {
+			return fmt.Errorf("failed to wait for rate limit; %w", err)
+		}
+
+		weather, err := retryReq(
+			s, ctx, endpointGameWeather, s.api.GetGameWeather,
+			cfbd.GetGameWeatherRequest{
+				Year:       week.Season,
+				Week:       week.Week,
+				SeasonType: week.SeasonType,
+			},
+		)
+		if err != nil {
+			slog.Error(
+				"failed to get game weather",
+				"year", int32ToString(week.Season),
+				"week", int32ToString(week.Week),
+				"err", err,
+			)
+			return fmt.Errorf(
+				"failed to get game weather for %d week %d; %w",
+				week.Season, week.Week, err,
+			)
+		}
+
+		s.usage.Record(endpointGameWeather)
+		weather = transform(s, endpointGameWeather, weather)
+
+		for _, w := range weather {
+			if w == nil {
+				continue
+			}
+			if _, ok := wanted[w.Id]; ok {
+				all = append(all, w)
+			}
+		}
+	}
+
+	err := s.db.InsertGameWeatherSnapshots(ctx, all, kind, capturedAt)
+	if err != nil {
+		slog.Error("failed to insert weather snapshots", "err", err)
+		return fmt.Errorf("failed to insert weather snapshots; %w", err)
+	}
+
+	if kind == db.WeatherActual {
+		if err = s.db.InsertGameWeather(ctx, all); err != nil {
+			slog.Error("failed to insert game weather", "err", err)
+			return fmt.Errorf("failed to insert game weather; %w", err)
+		}
+	}
+
+	slog.Info("snapshotted game weather", "kind", kind, "games", len(all))
+	return nil
+}
+
+// SetWeatherProvider sets the provider BackfillWeather fills gaps from.
+// Without one, it does nothing.
+func (s *Seeder) SetWeatherProvider(p weather.Provider) {
+	s.weather = p
+}
+
+// BackfillWeather fills in game_weather from the weather provider for the
+// games of each selected season that the API has no weather, or no
+// temperature, for: typically older seasons. Each game is looked up at its
+// venue's coordinates in the hour of kickoff, and recorded with the
+// provider's name as its source. Games at venues without coordinates are
+// left out, and games the provider has no observation for are logged and
+// skipped. It makes no API requests.
+func (s *Seeder) BackfillWeather(ctx context.Context) error {
+	if s.weather == nil {
+		slog.Info("no weather provider set; skipping weather backfill")
+		return nil
+	}
+
+	for _, year := range s.years {
+		if err := etl.Err(ctx); err != nil {
+			return err
+		}
+
+		if err := s.backfillSeasonWeather(ctx, year); err != nil {
+			slog.Error(
+				"failed to backfill weather",
+				"year", int32ToString(year),
+				"err", err,
+			)
+			return fmt.Errorf(
+				"failed to backfill weather for %d; %w", year, err,
+			)
+		}
+	}
+
+	slog.Info("backfilled weather", "years", len(s.years))
+	return nil
+}
+
+// backfillSeasonWeather looks up and stores the weather of a season's games
+// without it.
+func (s *Seeder) backfillSeasonWeather(ctx context.Context, year int32) error {
+	gaps, err := s.db.GetWeatherGaps(ctx, year, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	filled := make([]db.GameWeather, 0, len(gaps))
+	var skipped int
+	for _, g := range gaps {
+		observation, err := s.weather.Observe(
+			ctx, g.Latitude, g.Longitude, g.StartDate,
+		)
+		if errors.Is(err, weather.ErrNoObservation) {
+			slog.Warn("no weather observation for game", "game", g.ID)
+			skipped++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf(
+				"failed to observe weather for game %d; %w", g.ID, err,
+			)
+		}
+
+		startTime := g.StartDate
+		venueID := g.VenueID
+		filled = append(filled, db.GameWeather{
+			ID:                   g.ID,
+			Season:               g.Season,
+			Week:                 g.Week,
+			SeasonType:           g.SeasonType,
+			StartTime:            &startTime,
+			GameIndoors:          g.Dome != nil && *g.Dome,
+			HomeTeam:             g.HomeTeam,
+			HomeConference:       g.HomeConference,
+			AwayTeam:             g.AwayTeam,
+			AwayConference:       g.AwayConference,
+			VenueID:              &venueID,
+			Venue:                g.Venue,
+			Temperature:          observation.Temperature,
+			DewPoint:             observation.DewPoint,
+			Humidity:             observation.Humidity,
+			Precipitation:        observation.Precipitation,
+			Snowfall:             observation.Snowfall,
+			WindDirection:        observation.WindDirection,
+			WindSpeed:            observation.WindSpeed,
+			Pressure:             observation.Pressure,
+			WeatherConditionCode: observation.ConditionCode,
+			WeatherCondition:     observation.Condition,
+			Source:               s.weather.Name(),
+		})
+	}
+
+	if err = s.db.FillGameWeather(ctx, filled); err != nil {
+		return err
+	}
+
+	slog.Info(
+		"backfilled season weather",
+		"year", int32ToString(year),
+		"games", len(filled),
+		"skipped", skipped,
+	)
+	return nil
+}
@@ -0,0 +1,130 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/winprob"
+)
+
+// BackfillWinProbability fills in win probabilities for the completed games
+// of each selected season that the API has none for, typically older ones,
+// into local_play_win_probability. A logistic model of the home team's
+// chance of winning on the score, time left, field position and pregame
+// spread is fitted to every completed game of the season with plays, and
+// applied to the plays of the games without API win probabilities. It makes
+// no API requests.
+func (s *Seeder) BackfillWinProbability(ctx context.Context) error {
+	for _, year := range s.years {
+		if err := etl.Err(ctx); err != nil {
+			return err
+		}
+
+		if err := s.backfillSeasonWinProbability(ctx, year); err != nil {
+			slog.Error(
+				"failed to backfill win probability",
+				"year", int32ToString(year),
+				"err", err,
+			)
+			return fmt.Errorf(
+				"failed to backfill win probability for %d; %w", year, err,
+			)
+		}
+	}
+
+	slog.Info("backfilled win probability", "years", len(s.years))
+	return nil
+}
+
+// backfillSeasonWinProbability fits the model to a season's plays and
+// stores its estimates for the uncovered games.
+func (s *Seeder) backfillSeasonWinProbability(
+	ctx context.Context,
+	year int32,
+) error {
+	plays, err := s.db.GetWinProbabilityPlays(ctx, year)
+	if err != nil {
+		return err
+	}
+
+	examples := make([]winprob.Example, 0, len(plays))
+	var uncovered int
+	for _, p := range plays {
+		if !p.Covered {
+			uncovered++
+		}
+		// A tie has no winner to learn from.
+		if p.HomePoints != p.AwayPoints {
+			examples = append(examples, winprob.Example{
+				Situation: situation(p),
+				HomeWon:   p.HomePoints > p.AwayPoints,
+			})
+		}
+	}
+	if uncovered == 0 {
+		// Clears any estimates for games the API has since covered.
+		return s.db.ReplaceLocalWinProbability(ctx, year, nil)
+	}
+
+	model, err := winprob.Fit(examples)
+	if errors.Is(err, winprob.ErrNoTrainingData) {
+		slog.Warn("not enough plays to fit win probability model",
+			"year", int32ToString(year), "plays", len(examples))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	rows := make([]db.LocalPlayWinProbability, 0, uncovered)
+	for _, p := range plays {
+		if p.Covered {
+			continue
+		}
+		rows = append(rows, db.LocalPlayWinProbability{
+			GameID:             p.GameID,
+			PlayID:             p.PlayID,
+			Season:             year,
+			HomeWinProbability: model.HomeWinProbability(situation(p)),
+			ComputedAt:         now,
+		})
+	}
+	slog.Info("fitted win probability model",
+		"year", int32ToString(year),
+		"plays", len(examples),
+		"coefficients", model.Coefficients,
+		"estimated", len(rows),
+	)
+
+	return s.db.ReplaceLocalWinProbability(ctx, year, rows)
+}
+
+// situation returns the state of the game before a play.
+func situation(p db.WinProbabilityPlay) winprob.Situation {
+	var clock int32
+	if p.ClockMinutes != nil {
+		clock += *p.ClockMinutes * 60
+	}
+	if p.ClockSeconds != nil {
+		clock += *p.ClockSeconds
+	}
+	var spread float64
+	if p.Spread != nil {
+		spread = *p.Spread
+	}
+
+	return winprob.Situation{
+		Period:       p.Period,
+		ClockSeconds: clock,
+		ScoreDiff:    p.HomeScore - p.AwayScore,
+		HomeBall:     p.HomeBall,
+		YardsToGoal:  p.YardsToGoal,
+		Spread:       spread,
+	}
+}
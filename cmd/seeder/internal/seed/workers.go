@@ -0,0 +1,100 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultTaskWorkers is how many requests a per-game task has in flight at
+// once unless configured otherwise.
+const DefaultTaskWorkers = 10
+
+// DefaultYearWorkers is how many seasons a per-year task fetches at once
+// unless configured otherwise.
+const DefaultYearWorkers = 4
+
+// workerTasks are the tasks that fetch per game with a pool of workers.
+var workerTasks = []string{
+	"SeedAdvancedBoxScore",
+	"SeedLiveGames",
+	"SeedWinProbability",
+}
+
+// yearTasks are the tasks that fetch one large response per season and
+// fetch several seasons at once. Together with workerTasks they are the only
+// tasks SetTaskWorkers applies to.
+var yearTasks = []string{
+	"SeedBettingLines",
+	"SeedDrives",
+	"SeedGamePlayerStats",
+	"SeedGameTeamStats",
+}
+
+// SetTaskWorkers sets how many requests the task keeps in flight at once:
+// games for a per-game task, seasons for a per-year one. Naming any other
+// task returns ErrUnknownTask.
+func (s *Seeder) SetTaskWorkers(task string, workers int) error {
+	if !slices.Contains(workerTasks, task) && !slices.Contains(yearTasks, task) {
+		return fmt.Errorf(
+			"%w: %q does not run workers; must be one of %s",
+			ErrUnknownTask, task, strings.Join(
+				slices.Concat(workerTasks, yearTasks), ", ",
+			),
+		)
+	}
+	if workers <= 0 {
+		return fmt.Errorf("invalid worker count %d for %s", workers, task)
+	}
+
+	s.workers[task] = workers
+	return nil
+}
+
+// taskWorkers returns the worker count of the task.
+func (s *Seeder) taskWorkers(task string) int {
+	if workers, ok := s.workers[task]; ok {
+		return workers
+	}
+	if slices.Contains(yearTasks, task) {
+		return DefaultYearWorkers
+	}
+
+	return DefaultTaskWorkers
+}
+
+// eachYear runs fn for every seeded season, as many at once as the task has
+// workers, and returns the total number of rows fn reports inserting. The
+// rate limiters still pace the requests, so fetching seasons concurrently
+// only cuts the time spent waiting on large responses. The first error
+// cancels the seasons still running, unless the seeder was stopped, which
+// lets them finish what they are writing.
+func (s *Seeder) eachYear(
+	ctx context.Context,
+	task string,
+	fn func(ctx context.Context, year int32) (int, error),
+) (int, error) {
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(s.taskWorkers(task))
+	var stop etl.Drainer
+
+	var total atomic.Int64
+	for _, year := range s.years {
+		group.Go(func() error {
+			inserted, err := fn(groupCtx, year)
+			total.Add(int64(inserted))
+			return stop.Catch(err)
+		})
+	}
+
+	if err := stop.Err(group.Wait()); err != nil {
+		return int(total.Load()), fmt.Errorf("error waiting for %s; %w", task, err)
+	}
+
+	return int(total.Load()), nil
+}
@@ -0,0 +1,256 @@
+// Package server exposes the seeder's runtime state over HTTP so that
+// external orchestrators can poll it.
+package server
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"html/template"
+	"log/slog"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+)
+
+const (
+	// readHeaderTimeout bounds how long a client may take to send headers.
+	readHeaderTimeout = 5 * time.Second
+	// checkTimeout bounds each readiness check.
+	checkTimeout = 5 * time.Second
+	// pageRefreshSeconds is how often the status page reloads itself.
+	pageRefreshSeconds = 10
+	// pageRuns is how many past runs the status page lists.
+	pageRuns = 20
+)
+
+//go:embed status.html
+var statusPage string
+
+var statusTemplate = template.Must(template.New("status").Funcs(
+	template.FuncMap{
+		"age": func(t time.Time) string {
+			return time.Since(t).Round(time.Second).String() + " ago"
+		},
+		"elapsed": func(start, end time.Time) string {
+			return end.Sub(start).Round(time.Second).String()
+		},
+	},
+).Parse(statusPage))
+
+// History is the store of past runs and table freshness shown on the status
+// page.
+type History interface {
+	GetSeedRuns(ctx context.Context, limit int) ([]db.SeedRun, error)
+	GetTableManifest(ctx context.Context) ([]db.TableManifest, error)
+}
+
+// pageData is rendered by the status page template.
+type pageData struct {
+	Refresh    int
+	Progress   etl.ProgressSnapshot
+	Manifest   []db.TableManifest
+	Runs       []db.SeedRun
+	HistoryErr string
+}
+
+// Check is a named readiness dependency, such as the database or the CFBD
+// API. Fn returns nil when the dependency is usable.
+type Check struct {
+	Name string
+	Fn   func(ctx context.Context) error
+}
+
+// Config describes the endpoints served by New.
+type Config struct {
+	Addr     string
+	Progress *etl.Progress
+	// Checks are run on every /readyz request.
+	Checks []Check
+	// History, if set, adds run history and table freshness to the status
+	// page.
+	History History
+	// MaxBeatAge is how stale the progress heartbeat may get before /healthz
+	// reports the process as unhealthy. Zero disables the heartbeat check.
+	MaxBeatAge time.Duration
+}
+
+// progressResponse is the body of /progress: how far the current phase has
+// got and what each running task is doing.
+type progressResponse struct {
+	Phase string `json:"phase"`
+	// Percent is the share of the phase's tasks that have finished.
+	Percent   float64        `json:"percent"`
+	Completed int            `json:"completed"`
+	Failed    int            `json:"failed"`
+	Total     int            `json:"total"`
+	Running   []taskResponse `json:"running"`
+}
+
+// taskResponse is a running task in /progress. Percent and ETASeconds are
+// only set for tasks that report their units of work.
+type taskResponse struct {
+	Task       string   `json:"task"`
+	Percent    *float64 `json:"percent,omitempty"`
+	ETASeconds int64    `json:"eta_seconds,omitempty"`
+}
+
+type probeResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// New returns an HTTP server serving a status page at /, the seeder's
+// progress at /status, a condensed view of it at /progress and liveness and
+// readiness probes at /healthz and /readyz.
+func New(conf Config) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		statusPageHandler(w, r, conf)
+	})
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, conf.Progress.Snapshot())
+	})
+	mux.HandleFunc("GET /progress", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, progress(conf.Progress.Snapshot()))
+	})
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		healthz(w, conf)
+	})
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {
+		readyz(w, r, conf)
+	})
+
+	return &http.Server{
+		Addr:              conf.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+}
+
+// progress condenses a snapshot into the current phase's completion and the
+// running tasks' own progress.
+func progress(snap etl.ProgressSnapshot) progressResponse {
+	resp := progressResponse{
+		Phase:     snap.Phase,
+		Completed: snap.Completed,
+		Failed:    snap.Failed,
+		Total:     snap.Total,
+		Running:   make([]taskResponse, 0, len(snap.Running)),
+	}
+	if snap.Total > 0 {
+		resp.Percent = percent(int64(snap.Completed+snap.Failed),
+			int64(snap.Total))
+	}
+
+	units := make(map[string]etl.TaskProgress, len(snap.Tasks))
+	for _, task := range snap.Tasks {
+		units[task.Task] = task
+	}
+	for _, name := range snap.Running {
+		task := taskResponse{Task: name}
+		if u, ok := units[name]; ok && u.Total > 0 {
+			done := percent(u.Done, u.Total)
+			task.Percent, task.ETASeconds = &done, u.ETASeconds
+		}
+		resp.Running = append(resp.Running, task)
+	}
+
+	return resp
+}
+
+// percent returns done as a percentage of total, to one decimal place.
+func percent(done, total int64) float64 {
+	return math.Round(float64(done)*1000/float64(total)) / 10
+}
+
+// healthz reports whether the process is alive. When a long-running loop has
+// started, the process is only considered alive while it keeps beating.
+func healthz(w http.ResponseWriter, conf Config) {
+	lastBeat := conf.Progress.LastBeat()
+	if conf.MaxBeatAge > 0 && !lastBeat.IsZero() &&
+		time.Since(lastBeat) > conf.MaxBeatAge {
+		writeJSON(w, http.StatusServiceUnavailable, probeResponse{
+			Status: "stalled",
+			Checks: map[string]string{
+				"scheduler": "last beat " + lastBeat.Format(time.RFC3339),
+			},
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, probeResponse{Status: "ok"})
+}
+
+// readyz runs every readiness check and reports ready only if all pass.
+func readyz(w http.ResponseWriter, r *http.Request, conf Config) {
+	resp := probeResponse{
+		Status: "ok",
+		Checks: make(map[string]string, len(conf.Checks)),
+	}
+	code := http.StatusOK
+
+	for _, check := range conf.Checks {
+		ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+		err := check.Fn(ctx)
+		cancel()
+
+		if err != nil {
+			resp.Status = "unavailable"
+			resp.Checks[check.Name] = err.Error()
+			code = http.StatusServiceUnavailable
+			continue
+		}
+		resp.Checks[check.Name] = "ok"
+	}
+
+	writeJSON(w, code, resp)
+}
+
+// statusPageHandler renders a human readable page of the current run's
+// progress, table freshness and run history.
+func statusPageHandler(w http.ResponseWriter, r *http.Request, conf Config) {
+	data := pageData{
+		Refresh:  pageRefreshSeconds,
+		Progress: conf.Progress.Snapshot(),
+	}
+
+	// The page is still useful without history, so a failed read is shown
+	// on the page rather than failing the request.
+	if conf.History != nil {
+		var err error
+		data.Manifest, err = conf.History.GetTableManifest(r.Context())
+		if err == nil {
+			data.Runs, err = conf.History.GetSeedRuns(r.Context(), pageRuns)
+		}
+		if err != nil {
+			data.HistoryErr = err.Error()
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := statusTemplate.Execute(&buf, data); err != nil {
+		slog.Warn("could not render status page", "err", err)
+		http.Error(w, "could not render status page",
+			http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		slog.Warn("could not write status page", "err", err)
+	}
+}
+
+// writeJSON encodes v as the response body with the given status code.
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Warn("could not encode response", "err", err)
+	}
+}
@@ -0,0 +1,267 @@
+// Package storage reads and writes files in a local directory or in object
+// storage, S3 or GCS, behind a single interface.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+const (
+	// gcsEndpoint is the S3-compatible XML API of Google Cloud Storage.
+	gcsEndpoint = "https://storage.googleapis.com"
+	// gcsAccessKeyEnv and gcsSecretEnv hold the HMAC key gs:// URLs are
+	// accessed with.
+	gcsAccessKeyEnv = "GCS_HMAC_ACCESS_KEY"
+	gcsSecretEnv    = "GCS_HMAC_SECRET"
+)
+
+// Store is where files are kept: a local directory or an object storage
+// prefix.
+type Store interface {
+	// Create returns a writer for the file at name, a slash-separated path
+	// relative to the store. The file is complete once the writer is closed
+	// without error.
+	Create(ctx context.Context, name string) (io.WriteCloser, error)
+	// Open returns a reader for the file at name.
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+	// List returns the names of every file in the store, sorted.
+	List(ctx context.Context) ([]string, error)
+}
+
+// Open returns the store for dest: an s3://bucket/prefix or
+// gs://bucket/prefix URL, or else a local directory, which is created as
+// files are written to it. S3 credentials and region are read from the
+// environment, as by the AWS CLI; GCS is reached through its S3-compatible
+// API with the HMAC key in GCS_HMAC_ACCESS_KEY and GCS_HMAC_SECRET.
+func Open(ctx context.Context, dest string) (Store, error) {
+	u, err := url.Parse(dest)
+	if err != nil || (u.Scheme != "s3" && u.Scheme != "gs") {
+		return localStore{dir: dest}, nil
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid %s url %q: missing bucket",
+			u.Scheme, dest)
+	}
+
+	var client *s3.Client
+	if u.Scheme == "gs" {
+		client, err = gcsClient(ctx)
+	} else {
+		client, err = s3Client(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s3Store{
+		client:   client,
+		uploader: manager.NewUploader(client),
+		bucket:   u.Host,
+		prefix:   strings.Trim(u.Path, "/"),
+	}, nil
+}
+
+// s3Client returns an S3 client configured from the environment.
+func s3Client(ctx context.Context) (*s3.Client, error) {
+	conf, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not load aws config; %w", err)
+	}
+
+	return s3.NewFromConfig(conf), nil
+}
+
+// gcsClient returns an S3 client for the GCS XML API, authenticated with
+// the HMAC key in the environment.
+func gcsClient(ctx context.Context) (*s3.Client, error) {
+	accessKey, secret := os.Getenv(gcsAccessKeyEnv), os.Getenv(gcsSecretEnv)
+	if accessKey == "" || secret == "" {
+		return nil, fmt.Errorf("%s and %s are required for gs:// urls",
+			gcsAccessKeyEnv, gcsSecretEnv)
+	}
+
+	conf, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion("auto"),
+		config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(accessKey, secret, ""),
+		),
+		// GCS rejects the checksum headers the SDK sends by default.
+		config.WithRequestChecksumCalculation(
+			aws.RequestChecksumCalculationWhenRequired,
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not load gcs config; %w", err)
+	}
+
+	return s3.NewFromConfig(conf, func(o *s3.Options) {
+		o.BaseEndpoint = aws.String(gcsEndpoint)
+	}), nil
+}
+
+// localStore keeps files under a local directory.
+type localStore struct {
+	dir string
+}
+
+func (s localStore) Create(
+	_ context.Context,
+	name string,
+) (io.WriteCloser, error) {
+	file := filepath.Join(s.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return nil, fmt.Errorf("could not create directory; %w", err)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not create %s; %w", file, err)
+	}
+
+	return f, nil
+}
+
+func (s localStore) Open(
+	_ context.Context,
+	name string,
+) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(s.dir, filepath.FromSlash(name)))
+	if err != nil {
+		return nil, fmt.Errorf("could not open %s; %w", name, err)
+	}
+
+	return f, nil
+}
+
+func (s localStore) List(_ context.Context) ([]string, error) {
+	var names []string
+	err := filepath.WalkDir(s.dir,
+		func(file string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			name, err := filepath.Rel(s.dir, file)
+			if err != nil {
+				return err
+			}
+			names = append(names, filepath.ToSlash(name))
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not list %s; %w", s.dir, err)
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// s3Store keeps files in an S3 bucket, or a GCS bucket through its
+// S3-compatible API, under a key prefix. Each file is streamed to a
+// multipart upload as it is written, so no file is ever held in memory or
+// on disk whole.
+type s3Store struct {
+	client   *s3.Client
+	uploader *manager.Uploader
+	bucket   string
+	prefix   string
+}
+
+func (s s3Store) Create(
+	ctx context.Context,
+	name string,
+) (io.WriteCloser, error) {
+	pr, pw := io.Pipe()
+	upload := &s3Upload{pw: pw, done: make(chan error, 1)}
+
+	go func() {
+		_, err := s.uploader.Upload(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(path.Join(s.prefix, name)),
+			Body:   pr,
+		})
+		// A failed upload fails the writes still to come.
+		pr.CloseWithError(err)
+		upload.done <- err
+	}()
+
+	return upload, nil
+}
+
+func (s s3Store) Open(
+	ctx context.Context,
+	name string,
+) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path.Join(s.prefix, name)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not get %s; %w", name, err)
+	}
+
+	return out.Body, nil
+}
+
+func (s s3Store) List(ctx context.Context) ([]string, error) {
+	prefix := s.prefix
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	var names []string
+	pages := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not list %s; %w", s.bucket, err)
+		}
+		for _, object := range page.Contents {
+			names = append(names,
+				strings.TrimPrefix(aws.ToString(object.Key), prefix))
+		}
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// s3Upload is the writer of a file being uploaded.
+type s3Upload struct {
+	pw   *io.PipeWriter
+	done chan error
+}
+
+func (u *s3Upload) Write(p []byte) (int, error) {
+	return u.pw.Write(p)
+}
+
+// Close ends the file and waits for its upload to finish.
+func (u *s3Upload) Close() error {
+	if err := u.pw.Close(); err != nil {
+		return err
+	}
+	if err := <-u.done; err != nil {
+		return fmt.Errorf("could not upload file; %w", err)
+	}
+
+	return nil
+}
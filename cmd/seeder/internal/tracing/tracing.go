@@ -0,0 +1,47 @@
+// Package tracing exports OpenTelemetry traces of seeding runs over OTLP.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ServiceName identifies the seeder in exported traces.
+const ServiceName = "cfbd-seeder"
+
+// Start installs a global tracer provider that exports spans over OTLP/HTTP
+// to endpoint, e.g. "http://localhost:4318". Until Start is called, spans
+// are discarded. The returned function flushes any buffered spans and stops
+// the exporter; it must be called before the process exits.
+func Start(
+	ctx context.Context,
+	endpoint string,
+) (func(context.Context) error, error) {
+	exporter, err := otlptracehttp.New(
+		ctx, otlptracehttp.WithEndpointURL(endpoint),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create OTLP exporter; %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", ServiceName),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		if err := provider.Shutdown(ctx); err != nil {
+			return fmt.Errorf("could not flush traces; %w", err)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,249 @@
+// Package tui renders a live terminal dashboard of a seeding run for
+// operators running long backfills interactively.
+package tui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+)
+
+const (
+	// DefaultRefresh is how often the dashboard is redrawn.
+	DefaultRefresh = time.Second
+	// DefaultLogLines is how many recent log lines the dashboard shows.
+	DefaultLogLines = 8
+	// topEndpoints is how many endpoints are listed in the API table.
+	topEndpoints = 10
+	// clearScreen moves the cursor home and clears the terminal.
+	clearScreen = "\x1b[H\x1b[2J"
+)
+
+// Config describes what the dashboard renders and where.
+type Config struct {
+	Out      io.Writer
+	Progress *etl.Progress
+	Usage    *etl.UsageTracker
+	Logs     *LogBuffer
+	Refresh  time.Duration
+}
+
+// Dashboard periodically redraws the run's progress, API quota and usage,
+// error counts and database write throughput.
+type Dashboard struct {
+	conf    Config
+	started time.Time
+
+	// lastRows and lastFrame are used to derive write throughput between
+	// frames.
+	lastRows  int64
+	lastFrame time.Time
+}
+
+// New returns a Dashboard for the given configuration.
+func New(conf Config) *Dashboard {
+	if conf.Refresh <= 0 {
+		conf.Refresh = DefaultRefresh
+	}
+
+	return &Dashboard{conf: conf}
+}
+
+// Run redraws the dashboard until ctx is done, then draws a final frame so
+// the terminal is left showing the end state of the run.
+func (d *Dashboard) Run(ctx context.Context) {
+	d.started = time.Now()
+	d.lastFrame = d.started
+
+	ticker := time.NewTicker(d.conf.Refresh)
+	defer ticker.Stop()
+
+	d.draw()
+	for {
+		select {
+		case <-ctx.Done():
+			d.draw()
+			return
+		case <-ticker.C:
+			d.draw()
+		}
+	}
+}
+
+// draw renders a single frame.
+func (d *Dashboard) draw() {
+	now := time.Now()
+	snap := d.conf.Progress.Snapshot()
+
+	var rate float64
+	if elapsed := now.Sub(d.lastFrame).Seconds(); elapsed > 0 {
+		rate = float64(snap.RowsWritten-d.lastRows) / elapsed
+	}
+	d.lastRows = snap.RowsWritten
+	d.lastFrame = now
+
+	var buf bytes.Buffer
+	buf.WriteString(clearScreen)
+	fmt.Fprintf(&buf, "CFBD Seeder    elapsed %s\n\n",
+		now.Sub(d.started).Round(time.Second))
+
+	writeProgress(&buf, snap, now)
+	writeWrites(&buf, snap, rate)
+	writeAPI(&buf, snap, d.conf.Usage.Snapshot())
+	if d.conf.Logs != nil {
+		writeLogs(&buf, d.conf.Logs.Lines())
+	}
+
+	// A failed write to the terminal is not worth interrupting the run for.
+	_, _ = d.conf.Out.Write(buf.Bytes())
+}
+
+func writeProgress(w io.Writer, snap etl.ProgressSnapshot, now time.Time) {
+	phaseAge := ""
+	if snap.PhaseStarted != nil {
+		phaseAge = " (" + now.Sub(*snap.PhaseStarted).Round(time.Second).
+			String() + ")"
+	}
+
+	fmt.Fprintf(w, "Phase     %s%s\n", snap.Phase, phaseAge)
+	fmt.Fprintf(w, "Seeders   %s %d/%d done, %d running, %d failed\n",
+		bar(int64(snap.Completed+snap.Failed), int64(snap.Total)),
+		snap.Completed+snap.Failed, snap.Total,
+		len(snap.Running), snap.Failed)
+	units := make(map[string]etl.TaskProgress, len(snap.Tasks))
+	for _, task := range snap.Tasks {
+		units[task.Task] = task
+	}
+	for _, name := range snap.Running {
+		task, ok := units[name]
+		if !ok {
+			fmt.Fprintf(w, "  • %s\n", name)
+			continue
+		}
+
+		eta := "eta unknown"
+		if task.Done > 0 {
+			eta = "eta " + task.ETA().String()
+		}
+		fmt.Fprintf(w, "  • %-28s %s %d/%d, %s\n", name,
+			bar(task.Done, task.Total), task.Done, task.Total, eta)
+	}
+	fmt.Fprintln(w)
+}
+
+func writeWrites(w io.Writer, snap etl.ProgressSnapshot, rate float64) {
+	fmt.Fprintf(w, "DB writes %d rows (%.0f rows/s), %d tables\n",
+		snap.RowsWritten, rate, len(snap.LastSync))
+
+	var latest string
+	var latestAt time.Time
+	for table, at := range snap.LastSync {
+		if at.After(latestAt) {
+			latest, latestAt = table, at
+		}
+	}
+	if latest != "" {
+		fmt.Fprintf(w, "  last    %s at %s\n", latest,
+			latestAt.Format(time.TimeOnly))
+	}
+	fmt.Fprintln(w)
+}
+
+func writeAPI(
+	w io.Writer,
+	snap etl.ProgressSnapshot,
+	usage []etl.EndpointUsage,
+) {
+	var requests, errs int64
+	for _, u := range usage {
+		requests += u.Requests
+		errs += u.Errors
+	}
+
+	quota := "unknown"
+	if snap.QuotaRemaining != nil {
+		quota = fmt.Sprintf("%d calls remaining", *snap.QuotaRemaining)
+	}
+	fmt.Fprintf(w, "API       %d requests, %d errors, quota %s\n",
+		requests, errs, quota)
+
+	// Endpoints that are erroring are the most interesting to an operator,
+	// so they are listed first, then the busiest.
+	sort.SliceStable(usage, func(i, j int) bool {
+		if usage[i].Errors != usage[j].Errors {
+			return usage[i].Errors > usage[j].Errors
+		}
+		return usage[i].Requests > usage[j].Requests
+	})
+	if len(usage) > topEndpoints {
+		usage = usage[:topEndpoints]
+	}
+	for _, u := range usage {
+		fmt.Fprintf(w, "  %-32s %8d req %6d err\n",
+			u.Endpoint, u.Requests, u.Errors)
+	}
+	fmt.Fprintln(w)
+}
+
+func writeLogs(w io.Writer, lines []string) {
+	fmt.Fprintln(w, "Log")
+	for _, line := range lines {
+		fmt.Fprintf(w, "  %s\n", line)
+	}
+}
+
+// bar renders a fixed-width progress bar for done out of total.
+func bar(done, total int64) string {
+	const width = 20
+
+	var filled int64
+	if total > 0 {
+		filled = done * width / total
+	}
+
+	return "[" + strings.Repeat("#", int(filled)) +
+		strings.Repeat(".", int(width-filled)) + "]"
+}
+
+// LogBuffer is an io.Writer that keeps the most recent lines written to it,
+// so log output can be shown inside the dashboard instead of scrolling it
+// off the screen. It is safe for concurrent use.
+type LogBuffer struct {
+	mu    sync.Mutex
+	size  int
+	lines []string
+}
+
+// NewLogBuffer returns a LogBuffer that keeps the last size lines.
+func NewLogBuffer(size int) *LogBuffer {
+	return &LogBuffer{size: size}
+}
+
+// Write implements io.Writer.
+func (b *LogBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	text := strings.TrimRight(string(p), "\n")
+	b.lines = append(b.lines, strings.Split(text, "\n")...)
+	if len(b.lines) > b.size {
+		b.lines = b.lines[len(b.lines)-b.size:]
+	}
+
+	return len(p), nil
+}
+
+// Lines returns a copy of the buffered lines, oldest first.
+func (b *LogBuffer) Lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]string(nil), b.lines...)
+}
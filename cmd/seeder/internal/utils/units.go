@@ -0,0 +1,51 @@
+package utils
+
+import "math"
+
+const (
+	centimetersPerInch = 2.54
+	millimetersPerInch = 25.4
+	kilometersPerMile  = 1.609344
+	kilogramsPerPound  = 0.45359237
+)
+
+func FahrenheitToCelsius(f float64) float64 {
+	return (f - 32) * 5 / 9
+}
+
+func InchesToCentimeters(in float64) float64 {
+	return in * centimetersPerInch
+}
+
+func InchesToMillimeters(in float64) float64 {
+	return in * millimetersPerInch
+}
+
+func MilesPerHourToKilometersPerHour(mph float64) float64 {
+	return mph * kilometersPerMile
+}
+
+// PoundsToKilograms converts a whole-pound weight, rounding to the nearest
+// kilogram so it still fits integer weight columns.
+func PoundsToKilograms(lb int32) int32 {
+	//nolint:gosec // A kilogram value is always smaller than its pound value
+	return int32(math.Round(float64(lb) * kilogramsPerPound))
+}
+
+// ConvertFloat applies fn to the value p points to, preserving nil.
+func ConvertFloat(p *float64, fn func(float64) float64) *float64 {
+	if p == nil {
+		return nil
+	}
+	v := fn(*p)
+	return &v
+}
+
+// ConvertInt32 applies fn to the value p points to, preserving nil.
+func ConvertInt32(p *int32, fn func(int32) int32) *int32 {
+	if p == nil {
+		return nil
+	}
+	v := fn(*p)
+	return &v
+}
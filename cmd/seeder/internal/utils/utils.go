@@ -2,6 +2,8 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -9,6 +11,13 @@ import (
 	"github.com/lib/pq"
 )
 
+var (
+	// ErrInvalidYears is returned by ParseYears for a malformed year list.
+	ErrInvalidYears = errors.New("invalid years")
+	// ErrInvalidWeeks is returned by ParseWeeks for a malformed week list.
+	ErrInvalidWeeks = errors.New("invalid weeks")
+)
+
 func YearsFrom2005ToNow() []int32 {
 	currentYear := time.Now().Year()
 	years := make([]int32, 0, currentYear-2005+1)
@@ -36,6 +45,18 @@ func Int32SliceToInt64Array(xs []int32) pq.Int64Array {
 	return out
 }
 
+func Int64ArrayToInt32Slice(xs pq.Int64Array) []int32 {
+	if len(xs) == 0 {
+		return nil
+	}
+	out := make([]int32, 0, len(xs))
+	for _, v := range xs {
+		//nolint:gosec // Values were stored from int32s
+		out = append(out, int32(v))
+	}
+	return out
+}
+
 func ToStringArray(in []string) pq.StringArray {
 	if len(in) == 0 {
 		// store empty array rather than NULL
@@ -51,3 +72,51 @@ func ToStringArray(in []string) pq.StringArray {
 	}
 	return pq.StringArray(out)
 }
+
+// ParseYears parses a comma separated list of years and inclusive year
+// ranges, e.g. "2005-2010,2024".
+func ParseYears(spec string) ([]int32, error) {
+	return parseRanges(spec, ErrInvalidYears)
+}
+
+// ParseWeeks parses a comma separated list of weeks and inclusive week
+// ranges, e.g. "8-10" or "1,3".
+func ParseWeeks(spec string) ([]int32, error) {
+	return parseRanges(spec, ErrInvalidWeeks)
+}
+
+// parseRanges parses a comma separated list of numbers and inclusive ranges
+// of them, returning errInvalid for a malformed list.
+func parseRanges(spec string, errInvalid error) ([]int32, error) {
+	var values []int32
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.ParseInt(strings.TrimSpace(first), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", errInvalid, part)
+		}
+		end := start
+		if isRange {
+			end, err = strconv.ParseInt(strings.TrimSpace(last), 10, 32)
+			if err != nil || end < start {
+				return nil, fmt.Errorf("%w: %q", errInvalid, part)
+			}
+		}
+
+		for v := start; v <= end; v++ {
+			//nolint:gosec // Parsed with a 32 bit size, so always in range
+			values = append(values, int32(v))
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%w: %q", errInvalid, spec)
+	}
+
+	return values, nil
+}
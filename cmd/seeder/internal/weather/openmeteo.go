@@ -0,0 +1,184 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// OpenMeteoName is the name of the Open-Meteo provider.
+	OpenMeteoName = "open-meteo"
+	// DefaultOpenMeteoURL is the Open-Meteo historical weather API, which
+	// needs no API key and covers every season since 1940.
+	DefaultOpenMeteoURL = "https://archive-api.open-meteo.com/v1/archive"
+
+	// requestTimeout bounds how long a single lookup may take.
+	requestTimeout = 10 * time.Second
+	// requestInterval spaces out requests, to stay within the free tier's
+	// fair use.
+	requestInterval = 200 * time.Millisecond
+)
+
+// hourlyVariables are the hourly values requested, in the order of the
+// fields of openMeteoHourly.
+const hourlyVariables = "temperature_2m,dew_point_2m,relative_humidity_2m," +
+	"precipitation,snowfall,wind_direction_10m,wind_speed_10m," +
+	"pressure_msl,weather_code"
+
+// wmoConditions maps the WMO weather codes Open-Meteo reports to the
+// nearest Meteostat condition codes.
+var wmoConditions = map[int]int{
+	0: 1, 1: 2, 2: 3, 3: 4,
+	45: 5, 48: 6,
+	51: 7, 53: 7, 55: 8, 56: 10, 57: 11,
+	61: 7, 63: 8, 65: 9, 66: 10, 67: 11,
+	71: 14, 73: 15, 75: 16, 77: 14,
+	80: 17, 81: 17, 82: 18, 85: 21, 86: 22,
+	95: 25, 96: 24, 99: 24,
+}
+
+// OpenMeteo is a Provider backed by the Open-Meteo historical weather API.
+type OpenMeteo struct {
+	url     string
+	client  *http.Client
+	limiter *rate.Limiter
+}
+
+var _ Provider = (*OpenMeteo)(nil)
+
+// NewOpenMeteo returns a provider querying the Open-Meteo historical
+// weather API at archiveURL.
+func NewOpenMeteo(archiveURL string) (*OpenMeteo, error) {
+	if u, err := url.Parse(archiveURL); err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid open-meteo url %q", archiveURL)
+	}
+
+	return &OpenMeteo{
+		url:     archiveURL,
+		client:  &http.Client{Timeout: requestTimeout},
+		limiter: rate.NewLimiter(rate.Every(requestInterval), 1),
+	}, nil
+}
+
+// Name returns OpenMeteoName.
+func (o *OpenMeteo) Name() string { return OpenMeteoName }
+
+// openMeteoHourly is the hourly series of an Open-Meteo response. Times
+// are in UTC, one per hour of the requested day.
+type openMeteoHourly struct {
+	Time          []string   `json:"time"`
+	Temperature   []*float64 `json:"temperature_2m"`
+	DewPoint      []*float64 `json:"dew_point_2m"`
+	Humidity      []*float64 `json:"relative_humidity_2m"`
+	Precipitation []*float64 `json:"precipitation"`
+	Snowfall      []*float64 `json:"snowfall"`
+	WindDirection []*float64 `json:"wind_direction_10m"`
+	WindSpeed     []*float64 `json:"wind_speed_10m"`
+	Pressure      []*float64 `json:"pressure_msl"`
+	WeatherCode   []*float64 `json:"weather_code"`
+}
+
+// Observe returns the weather at the coordinates in the hour containing
+// at.
+func (o *OpenMeteo) Observe(
+	ctx context.Context,
+	latitude, longitude float64,
+	at time.Time,
+) (Observation, error) {
+	at = at.UTC()
+	day := at.Format(time.DateOnly)
+	query := url.Values{
+		"latitude":           {strconv.FormatFloat(latitude, 'f', -1, 64)},
+		"longitude":          {strconv.FormatFloat(longitude, 'f', -1, 64)},
+		"start_date":         {day},
+		"end_date":           {day},
+		"hourly":             {hourlyVariables},
+		"timezone":           {"GMT"},
+		"temperature_unit":   {"fahrenheit"},
+		"wind_speed_unit":    {"mph"},
+		"precipitation_unit": {"inch"},
+	}
+
+	if err := o.limiter.Wait(ctx); err != nil {
+		return Observation{}, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, o.url+"?"+query.Encode(), nil,
+	)
+	if err != nil {
+		return Observation{}, fmt.Errorf(
+			"could not build open-meteo request; %w", err,
+		)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return Observation{}, fmt.Errorf("could not reach open-meteo; %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Observation{}, fmt.Errorf(
+			"open-meteo returned status %d", resp.StatusCode,
+		)
+	}
+
+	var response struct {
+		Hourly openMeteoHourly `json:"hourly"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return Observation{}, fmt.Errorf(
+			"could not decode open-meteo response; %w", err,
+		)
+	}
+
+	hour := at.Truncate(time.Hour).Format("2006-01-02T15:04")
+	for i, t := range response.Hourly.Time {
+		if t == hour {
+			return response.Hourly.observation(i)
+		}
+	}
+
+	return Observation{}, ErrNoObservation
+}
+
+// observation returns the i-th hour of the series.
+func (h openMeteoHourly) observation(i int) (Observation, error) {
+	value := func(series []*float64) *float64 {
+		if i < len(series) {
+			return series[i]
+		}
+		return nil
+	}
+
+	o := Observation{
+		Temperature:   value(h.Temperature),
+		DewPoint:      value(h.DewPoint),
+		Humidity:      value(h.Humidity),
+		Precipitation: value(h.Precipitation),
+		Snowfall:      value(h.Snowfall),
+		WindDirection: value(h.WindDirection),
+		WindSpeed:     value(h.WindSpeed),
+		Pressure:      value(h.Pressure),
+	}
+	if o.Temperature == nil {
+		// The archive lags a few days behind, and pads the days it does
+		// not have yet with nulls.
+		return Observation{}, ErrNoObservation
+	}
+	if code := value(h.WeatherCode); code != nil {
+		if meteostat, ok := wmoConditions[int(*code)]; ok {
+			o.condition(meteostat)
+		}
+	}
+
+	return o, nil
+}
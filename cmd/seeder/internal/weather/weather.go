@@ -0,0 +1,104 @@
+// Package weather looks up the historical weather at a place and time from
+// external providers, to fill in games the CFBD API has no weather for.
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnknownProvider is returned by New for a provider it does not know.
+	ErrUnknownProvider = errors.New("unknown weather provider")
+	// ErrNoObservation is returned by a provider with no observation for
+	// the place and time.
+	ErrNoObservation = errors.New("no weather observation")
+)
+
+// Observation is the weather at a place and time, in the units the CFBD
+// API reports weather in: degrees Fahrenheit, inches, miles per hour and
+// hectopascals. Values the provider does not report are nil.
+type Observation struct {
+	Temperature   *float64
+	DewPoint      *float64
+	Humidity      *float64
+	Precipitation *float64
+	Snowfall      *float64
+	WindDirection *float64
+	WindSpeed     *float64
+	Pressure      *float64
+	// ConditionCode is a Meteostat weather condition code, as the API
+	// uses, and Condition its description.
+	ConditionCode *float64
+	Condition     string
+}
+
+// Provider looks up historical weather.
+type Provider interface {
+	// Name identifies the provider in the rows it fills.
+	Name() string
+	// Observe returns the weather at the coordinates in the hour containing
+	// at.
+	Observe(
+		ctx context.Context,
+		latitude, longitude float64,
+		at time.Time,
+	) (Observation, error)
+}
+
+// providers builds each known provider by name.
+var providers = map[string]func() (Provider, error){
+	OpenMeteoName: func() (Provider, error) {
+		return NewOpenMeteo(DefaultOpenMeteoURL)
+	},
+}
+
+// Providers returns the names New accepts, sorted.
+func Providers() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// New returns the named provider with its default settings.
+func New(name string) (Provider, error) {
+	build, ok := providers[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w %q; known providers are %s",
+			ErrUnknownProvider, name, strings.Join(Providers(), ", "))
+	}
+
+	return build()
+}
+
+// conditions describes each Meteostat weather condition code.
+var conditions = map[int]string{
+	1: "Clear", 2: "Fair", 3: "Cloudy", 4: "Overcast", 5: "Fog",
+	6: "Freezing Fog", 7: "Light Rain", 8: "Rain", 9: "Heavy Rain",
+	10: "Freezing Rain", 11: "Heavy Freezing Rain", 12: "Sleet",
+	13: "Heavy Sleet", 14: "Light Snowfall", 15: "Snowfall",
+	16: "Heavy Snowfall", 17: "Rain Shower", 18: "Heavy Rain Shower",
+	19: "Sleet Shower", 20: "Heavy Sleet Shower", 21: "Snow Shower",
+	22: "Heavy Snow Shower", 23: "Lightning", 24: "Hail",
+	25: "Thunderstorm", 26: "Heavy Thunderstorm", 27: "Storm",
+}
+
+// condition sets an observation's condition from a Meteostat code.
+func (o *Observation) condition(code int) {
+	description, ok := conditions[code]
+	if !ok {
+		return
+	}
+
+	c := float64(code)
+	o.ConditionCode = &c
+	o.Condition = description
+}
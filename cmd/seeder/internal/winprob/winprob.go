@@ -0,0 +1,205 @@
+// Package winprob fits a simple in-game win probability model to play by
+// play data and final results, for games the API has no win probability
+// for.
+package winprob
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNoTrainingData is returned by Fit when the plays do not include both
+// home wins and home losses.
+var ErrNoTrainingData = errors.New("not enough plays to fit a model")
+
+const (
+	// regulation is the length of a regulation game in seconds.
+	regulation = 3600
+	// quarter is the length of a quarter in seconds.
+	quarter = 900
+	// fieldLength is the length of the field in yards.
+	fieldLength = 100
+
+	// iterations bounds the Newton steps Fit takes.
+	iterations = 50
+	// tolerance is the largest change in any coefficient at which Fit
+	// stops early.
+	tolerance = 1e-8
+	// ridge is the L2 penalty keeping the fit stable when a feature barely
+	// varies, such as the spread of seasons without betting lines.
+	ridge = 1e-3
+)
+
+// Situation is the state of a game before a play, from the home team's
+// side.
+type Situation struct {
+	// Period is the quarter, past 4 in overtime.
+	Period int32
+	// ClockSeconds is the time left in the period.
+	ClockSeconds int32
+	// ScoreDiff is the home score minus the away score.
+	ScoreDiff int32
+	// HomeBall reports whether the home team has the ball.
+	HomeBall bool
+	// YardsToGoal is how far the team with the ball is from scoring.
+	YardsToGoal int32
+	// Spread is the pregame spread from the home team's side, negative when
+	// the home team is favored, or zero if unknown.
+	Spread float64
+}
+
+// Example is a situation and whether the home team went on to win.
+type Example struct {
+	Situation
+	HomeWon bool
+}
+
+// Model is a logistic regression of the home team's chance of winning on
+// the features of a situation.
+type Model struct {
+	Coefficients []float64
+}
+
+// features returns the model's inputs for a situation: the score
+// difference, the score difference weighted by how little time is left, the
+// spread weighted by how much time is left, and field position from the
+// home team's side, after a constant term.
+func features(s Situation) []float64 {
+	remaining := float64(s.ClockSeconds)
+	if s.Period <= 4 {
+		remaining += float64((4 - s.Period) * quarter)
+	} else {
+		remaining = 0
+	}
+	left := math.Max(0, math.Min(1, remaining/regulation))
+
+	field := 1 - float64(s.YardsToGoal)/fieldLength
+	if !s.HomeBall {
+		field = -field
+	}
+	diff := float64(s.ScoreDiff)
+
+	return []float64{
+		1,
+		diff / 7,
+		diff / 7 / math.Sqrt(left+0.01),
+		-s.Spread / 7 * left,
+		field,
+	}
+}
+
+// Fit fits a model to the examples by maximum likelihood, with Newton's
+// method.
+func Fit(examples []Example) (Model, error) {
+	var wins int
+	for _, e := range examples {
+		if e.HomeWon {
+			wins++
+		}
+	}
+	if wins == 0 || wins == len(examples) {
+		return Model{}, ErrNoTrainingData
+	}
+
+	n := len(features(Situation{}))
+	beta := make([]float64, n)
+	for range iterations {
+		gradient := make([]float64, n)
+		hessian := make([][]float64, n)
+		for i := range hessian {
+			hessian[i] = make([]float64, n)
+			hessian[i][i] = ridge * float64(len(examples))
+			gradient[i] = -ridge * float64(len(examples)) * beta[i]
+		}
+
+		for _, e := range examples {
+			x := features(e.Situation)
+			p := logistic(dot(beta, x))
+			y := 0.0
+			if e.HomeWon {
+				y = 1
+			}
+			w := p * (1 - p)
+			for i := range x {
+				gradient[i] += (y - p) * x[i]
+				for j := range x {
+					hessian[i][j] += w * x[i] * x[j]
+				}
+			}
+		}
+
+		step, err := solve(hessian, gradient)
+		if err != nil {
+			return Model{}, err
+		}
+		var change float64
+		for i := range beta {
+			beta[i] += step[i]
+			change = math.Max(change, math.Abs(step[i]))
+		}
+		if change < tolerance {
+			break
+		}
+	}
+
+	return Model{Coefficients: beta}, nil
+}
+
+// HomeWinProbability returns the model's chance of the home team winning
+// from the situation.
+func (m Model) HomeWinProbability(s Situation) float64 {
+	return logistic(dot(m.Coefficients, features(s)))
+}
+
+// logistic maps log odds to a probability.
+func logistic(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
+
+// dot returns the dot product of a and b, which have the same length.
+func dot(a, b []float64) float64 {
+	var total float64
+	for i := range a {
+		total += a[i] * b[i]
+	}
+
+	return total
+}
+
+// solve solves a x = b by Gaussian elimination with partial pivoting. a and
+// b are overwritten.
+func solve(a [][]float64, b []float64) ([]float64, error) {
+	n := len(b)
+	for col := range n {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if a[pivot][col] == 0 {
+			return nil, errors.New("could not fit model; singular matrix")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for row := col + 1; row < n; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+			b[row] -= factor * b[col]
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := b[row]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+
+	return x, nil
+}
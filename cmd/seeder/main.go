@@ -2,38 +2,432 @@ package main
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/clintrovert/cfbd-etl/seeder/internal/config"
 	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/events"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/fixtures"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/geocode"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/notify"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/ratings"
 	"github.com/clintrovert/cfbd-etl/seeder/internal/seed"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/server"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/storage"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/tracing"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/tui"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/utils"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/weather"
 	"github.com/clintrovert/cfbd-go/cfbd"
-	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
 )
 
+const (
+	// seedCommand runs a full seed, as does running without a command.
+	seedCommand = "seed"
+	// retryFailedCommand re-attempts the fetches recorded in the failure
+	// ledger instead of running a full seed.
+	retryFailedCommand = "retry-failed"
+	// syncCommand refreshes only the latest calendar week.
+	syncCommand = "sync"
+	// backfillCommand refreshes the week-scoped tables of a few weeks of a
+	// season.
+	backfillCommand = "backfill"
+	// gameCommand refreshes everything stored about a single game.
+	gameCommand = "game"
+	// planCommand prints the projected API requests of a full seed instead
+	// of running it.
+	planCommand = "plan"
+	// daemonCommand runs the tasks in the config's schedules until the
+	// process is stopped.
+	daemonCommand = "daemon"
+	// runTaskCommand runs a single task, optionally narrowed to one season
+	// or week, and prints its result.
+	runTaskCommand = "run-task"
+	// preflightCommand checks the API key, database connection and schema
+	// privileges and reports the outcome without seeding anything.
+	preflightCommand = "preflight"
+	// migrateCommand inspects or changes the schema version without
+	// seeding anything.
+	migrateCommand = "migrate"
+	// exportCommand writes the seeded tables to files instead of seeding.
+	exportCommand = "export"
+	// importCommand loads a bundle written by the export command instead
+	// of seeding.
+	importCommand = "import"
+	// replayCommand seeds from archived API responses instead of the API.
+	replayCommand = "replay"
+	// serveCommand serves the seeded tables over GraphQL and gRPC until
+	// interrupted.
+	serveCommand = "serve"
+	// verifyCommand compares the stored row counts of a season against the
+	// API and reports the gaps without seeding anything.
+	verifyCommand = "verify"
+	// diffCommand reports the fields of stored rows that differ from the
+	// API without seeding anything.
+	diffCommand = "diff"
+	// refreshCommand re-fetches an entity and writes only the rows that
+	// changed upstream.
+	refreshCommand = "refresh"
+	// auditCommand reports rows referencing missing parent rows, optionally
+	// removing them, without seeding anything.
+	auditCommand = "audit"
+	// resetCommand deletes the rows of some tables or seasons, after
+	// confirmation, without seeding anything.
+	resetCommand = "reset"
+	// nukeCommand drops the schema and recreates it empty, for development
+	// databases.
+	nukeCommand = "nuke"
+	// finalizeCommand builds deferred indexes and installs constraints
+	// after a load without seeding anything.
+	finalizeCommand = "finalize"
+	// refreshViewsCommand creates or refreshes the analytics materialized
+	// views without seeding anything.
+	refreshViewsCommand = "refresh-views"
+)
+
+// Verbs of the migrate command.
+const (
+	migrateStatus = "status"
+	migrateUp     = "up"
+	migrateDown   = "down"
+	migrateForce  = "force"
+)
+
+const (
+	// outputText prints run-task and preflight results as an aligned table.
+	outputText = "text"
+	// outputJSON prints run-task and preflight results as a JSON object.
+	outputJSON = "json"
+)
+
+const (
+	// profileDevelopment applies schema changes as soon as they are found.
+	profileDevelopment = "development"
+	// profileProduction holds back schema changes that lock populated
+	// tables until --allow-ddl is passed.
+	profileProduction = "production"
+)
+
+// exitStopped is the exit status of a run stopped by a signal before it
+// finished, which --resume can pick up, as opposed to 1 for a failed run.
+const exitStopped = 3
+
+// missedBeatsAllowed is how many watch intervals may pass without a heartbeat
+// before /healthz reports the scoreboard watcher as stalled.
+const missedBeatsAllowed = 3
+
+const (
+	// defaultQuotaWarn is the default remaining-call count below which a low
+	// quota warning is logged.
+	defaultQuotaWarn = 1000
+	// defaultQuotaSlow is the default remaining-call count below which the
+	// request rate is scaled down.
+	defaultQuotaSlow = 5000
+	// defaultQuotaPause is the default remaining-call count below which
+	// seeding pauses until the quota recovers.
+	defaultQuotaPause = 250
+	// defaultQuotaInterval is how often the remaining quota is polled.
+	defaultQuotaInterval = 5 * time.Minute
+	// defaultClosingWindow is how long before kickoff lines start being
+	// snapshotted in watch mode.
+	defaultClosingWindow = 3 * time.Hour
+	// defaultClosingInterval is how often lines are snapshotted for games
+	// inside the closing line window.
+	defaultClosingInterval = 10 * time.Minute
+	// defaultWeatherWindow is how long before kickoff weather forecasts start
+	// being captured in watch mode.
+	defaultWeatherWindow = 24 * time.Hour
+	// defaultWeatherInterval is how often weather is captured in watch mode.
+	defaultWeatherInterval = time.Hour
+)
+
 func main() {
-	slog.Info("Starting CFBD Database seeder...")
+	opts := parseOptions()
+	opts.check()
+
+	// The dashboard owns the terminal, so logs go to a file instead and the
+	// most recent lines are shown inside the dashboard.
+	var logs *tui.LogBuffer
+	if opts.dashboard {
+		logFile, logErr := os.OpenFile(
+			opts.dashboardLog, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600,
+		)
+		if logErr != nil {
+			slog.Error("failed to open dashboard log", "err", logErr)
+			os.Exit(1)
+		}
+		defer logFile.Close()
+
+		logs = tui.NewLogBuffer(tui.DefaultLogLines)
+		slog.SetDefault(slog.New(
+			slog.NewTextHandler(io.MultiWriter(logFile, logs), nil),
+		))
+	}
+
+	var conf config.Config
+	if opts.configPath != "" {
+		loaded, confErr := config.Load(opts.configPath)
+		if confErr != nil {
+			slog.Error("failed to load config", "err", confErr)
+			os.Exit(1)
+		}
+		conf = loaded
+	}
 
-	database, err := db.NewDatabase(db.Config{
-		DSN:                      os.Getenv("DATABASE_DSN"),
+	dsn := os.Getenv("DATABASE_DSN")
+	if opts.out != "" && opts.command != exportCommand {
+		dsn = opts.out
+	}
+	dbConf := db.Config{
+		Driver:                   opts.driver,
+		DSN:                      dsn,
 		MaxOpenConnections:       db.DefaultMaxOpenConnections,
 		MaxIdleConnections:       10,
 		MaxConnectionLifetimeMin: 30,
-	})
+		CompressPayloads:         opts.compress,
+		Units:                    db.UnitSystem(opts.units),
+		Schema:                   conf.Schema,
+	}
+
+	// Preflight makes its own connections, so a bad DSN or key is reported
+	// alongside every other check instead of ending the process.
+	if opts.command == preflightCommand {
+		runPreflightCommand(opts, dbConf)
+		return
+	}
+
+	slog.Info("Starting CFBD Database seeder...")
+
+	database, err := db.NewDatabase(dbConf)
 	if err != nil {
 		slog.Error("failed to create database connection", "err", err)
 		os.Exit(1)
 	}
 	slog.Info("Database connection created.")
 
+	if err = database.Redact(conf.ExcludeColumns); err != nil {
+		slog.Error("failed to register column exclusions", "err", err)
+		os.Exit(1)
+	}
+
+	// Schema management runs on its own, before any migration is applied
+	// implicitly, and the other commands here use the tables as they are.
+	switch opts.command {
+	case migrateCommand:
+		runMigrateCommand(opts, database)
+		return
+	case nukeCommand:
+		runNukeCommand(opts, database)
+		return
+	case auditCommand:
+		runAuditCommand(opts, database)
+		return
+	case resetCommand:
+		runResetCommand(opts, database)
+		return
+	case finalizeCommand:
+		runFinalizeCommand(opts, database)
+		return
+	case refreshViewsCommand:
+		runRefreshViewsCommand(opts, database)
+		return
+	case exportCommand:
+		runExportCommand(opts, database)
+		return
+	case serveCommand:
+		runServeCommand(opts, database)
+		return
+	}
+
+	migrateDatabase(opts, database)
+
+	// Imports load the migrated tables as the bundle holds them, without
+	// the write hooks seeding enables.
+	if opts.command == importCommand {
+		runImportCommand(opts, database)
+		return
+	}
+
+	enableWriteHooks(opts, conf, database)
+	seeder, throttle := newSeeder(opts, conf, database)
+	publisher := registerTableHooks(opts, database, seeder)
+
+	if opts.statusAddr != "" {
+		serveStatus(opts, database, seeder)
+	}
+
+	ctx := context.Background()
+
+	// Without an endpoint, spans are discarded as they end.
+	stopTracing := func(context.Context) error { return nil }
+	if opts.otlpEndpoint != "" {
+		stopTracing, err = tracing.Start(ctx, opts.otlpEndpoint)
+		if err == nil {
+			err = database.Trace()
+		}
+		if err != nil {
+			slog.Error("failed to start tracing", "err", err)
+			os.Exit(1)
+		}
+		slog.Info("Exporting traces...", "endpoint", opts.otlpEndpoint)
+	}
+
+	var notifier *notify.Notifier
+	if opts.notifyURL != "" {
+		if notifier, err = notify.New(opts.notifyURL); err != nil {
+			slog.Error("invalid notification url", "err", err)
+			os.Exit(1)
+		}
+	}
+
+	// Long tasks log their unit progress and ETA; the dashboard shows the
+	// same as progress bars.
+	progress := seeder.Progress()
+	logCtx, stopProgressLog := context.WithCancel(ctx)
+	defer stopProgressLog()
+	go progress.LogEvery(logCtx, opts.progressInterval)
+
+	stopOnSignal(seeder)
+	// Tasks that make no API requests see the stop through the context.
+	ctx = seeder.StopContext(ctx)
+
+	if opts.dashboard {
+		defer startDashboard(ctx, seeder, logs)()
+	}
+
+	// Planning, verification and diffs only read the database and the API,
+	// so they run before any run bookkeeping is written.
+	switch opts.command {
+	case planCommand:
+		runPlanCommand(ctx, seeder)
+		return
+	case verifyCommand:
+		runVerifyCommand(ctx, opts, seeder)
+		return
+	case diffCommand:
+		runDiffCommand(ctx, opts, seeder)
+		return
+	}
+
+	r := startRun(ctx, opts, seeder)
+	r.notifier = notifier
+	r.publisher = publisher
+	r.stopTracing = stopTracing
+
+	switch opts.command {
+	case runTaskCommand:
+		runTaskRunCommand(ctx, opts, seeder, r)
+		return
+	case refreshCommand:
+		runRefreshCommand(ctx, opts, seeder, r)
+		return
+	case replayCommand:
+		runReplayCommand(ctx, opts, seeder, r)
+		return
+	}
+
+	// Quota snapshots bracket every run; failing to take one is not fatal.
+	if err = seeder.SnapshotQuota(ctx, "start"); err != nil {
+		slog.Warn("failed to snapshot api quota", "err", err)
+	}
+
+	if opts.quotaInterval > 0 {
+		governCtx, stopGovern := context.WithCancel(ctx)
+		defer stopGovern()
+
+		go func() {
+			if govErr := seeder.GovernQuota(governCtx, seed.QuotaPolicy{
+				CheckEvery: opts.quotaInterval,
+				SlowBelow:  opts.quotaSlow,
+				PauseBelow: opts.quotaPause,
+			}); govErr != nil {
+				slog.Error("adaptive rate limiting disabled", "err", govErr)
+			}
+		}()
+	}
+
+	switch {
+	case opts.command == daemonCommand:
+		runDaemonCommand(ctx, conf, seeder, r)
+	case opts.command == syncCommand:
+		runSyncCommand(ctx, seeder, r)
+	case opts.command == backfillCommand:
+		runBackfillCommand(ctx, opts, seeder, r)
+	case opts.command == gameCommand:
+		runGameCommand(ctx, opts, seeder, r)
+	case opts.command == retryFailedCommand:
+		runRetryFailedCommand(ctx, seeder, r)
+	case opts.verify:
+		runVerifySweep(ctx, seeder, throttle, r)
+	case opts.watch:
+		runWatch(ctx, opts, seeder, r)
+	default:
+		runSeed(ctx, opts, conf, database, seeder, r)
+	}
+}
+
+// migrateDatabase creates a new database, or applies the pending migrations
+// to an existing one, and prepares the migrated tables for seeding. It
+// exits if the migrations may not be applied now.
+func migrateDatabase(opts options, database *db.Database) {
 	isInitialized, err := database.IsInitialized()
 	if err != nil {
 		slog.Error("failed to verify initialized status", "err", err)
 		os.Exit(1)
 	}
 
-	if !isInitialized {
+	pending, err := database.PendingMigrations(context.Background())
+	if err != nil {
+		slog.Error("failed to list pending migrations", "err", err)
+		os.Exit(1)
+	}
+
+	// A new database is simply created, whatever --auto-migrate says. An
+	// existing one is only migrated when migrations are pending and
+	// --auto-migrate allows it, and in production only once --allow-ddl
+	// confirms that locking populated tables is acceptable right now.
+	migrate := len(pending) > 0
+	if isInitialized && migrate && !opts.autoMigrate {
+		slog.Error("schema migrations are pending; apply them with "+
+			"\"migrate up\" or rerun with --auto-migrate",
+			"pending", len(pending))
+		os.Exit(1)
+	}
+	if isInitialized && migrate {
+		plan, planErr := database.PlanMigration(context.Background())
+		if planErr != nil {
+			slog.Error("failed to plan schema migration", "err", planErr)
+			os.Exit(1)
+		}
+		for _, ddl := range plan {
+			slog.Info(
+				"pending schema change",
+				"table", ddl.Table,
+				"lock", ddl.Lock,
+				"impact", ddl.Impact.String(),
+				"rows", ddl.Rows,
+				"sql", ddl.SQL,
+			)
+		}
+		if opts.profile == profileProduction && !opts.allowDDL &&
+			db.RequiresApproval(plan) {
+			slog.Error("schema changes would lock populated tables; " +
+				"rerun with --allow-ddl to apply them")
+			os.Exit(1)
+		}
+	}
+
+	if migrate {
 		if err = database.Initialize(); err != nil {
 			slog.Error("failed to initialize database", "err", err)
 			os.Exit(1)
@@ -41,148 +435,366 @@ func main() {
 	}
 	slog.Info("Database initialized.")
 
-	api, err := cfbd.New(os.Getenv("CFBD_API_KEY"))
+	drift, err := database.SchemaDrift(context.Background())
 	if err != nil {
-		slog.Error("failed to create API client", "err", err)
+		slog.Error("failed to check for schema drift", "err", err)
 		os.Exit(1)
 	}
+	for _, ddl := range drift {
+		slog.Warn(
+			"schema drift: models differ from the migrated schema",
+			"table", ddl.Table,
+			"sql", ddl.SQL,
+		)
+	}
 
-	throttle := rate.NewLimiter(rate.Limit(10), db.RateLimiterBurst)
+	if opts.queryIndexes {
+		if err = database.CreateQueryIndexes(context.Background()); err != nil {
+			slog.Error("failed to create query indexes", "err", err)
+			os.Exit(1)
+		}
+	}
 
-	// Rate limiter: 10 requests per second with burst of 20
-	seeder, err := seed.NewSeeder(database, api, throttle)
-	if err != nil {
-		slog.Error("failed to create seeder", "err", err)
+	if err = database.EnforceUnits(context.Background()); err != nil {
+		slog.Error("failed to enforce unit system", "err", err)
 		os.Exit(1)
 	}
 
-	// The seeding processes is split into multiple phases based on dependencies.
-	// Each phase will be concurrently executed and depend on the one before it.
-	// The number of API requests for each phase should be listed in the phase
-	// caption above it.
-	ctx := context.Background()
-
-	// ========================== Phase 1 (7 requests) ==========================
-	slog.Info("Starting Phase 1...")
-	phase1, phase1Ctx := errgroup.WithContext(ctx)
-	seeder.SetExecutionContext(phase1Ctx)
+	// Fuzzy name search is a convenience for querying the seeded data, so a
+	// database role that may not create extensions does not stop seeding.
+	if err = database.EnableFuzzySearch(context.Background()); err != nil {
+		slog.Warn("fuzzy name search unavailable", "err", err)
+	}
+}
 
-	phase1.Go(seeder.SeedVenues)         // 1 request
-	phase1.Go(seeder.SeedPlayTypes)      // 1 request
-	phase1.Go(seeder.SeedStatTypes)      // 1 request
-	phase1.Go(seeder.SeedDraftTeams)     // 1 request
-	phase1.Go(seeder.SeedConferences)    // 1 request
-	phase1.Go(seeder.SeedFieldGoalEP)    // 1 request
-	phase1.Go(seeder.SeedDraftPositions) // 1 request
+// enableWriteHooks registers the callbacks that change how seeded rows are
+// written: bulk copies, validation, change detection, history and dry
+// runs.
+func enableWriteHooks(opts options, conf config.Config, database *db.Database) {
+	// Bulk copies replace the create callback that change detection and
+	// dry runs build on, so they are enabled first.
+	if opts.bulkCopy {
+		if err := database.BulkCopy(db.BulkCopyTables...); err != nil {
+			slog.Error("failed to enable bulk copy", "err", err)
+			os.Exit(1)
+		}
+	}
 
-	if phase1Err := phase1.Wait(); phase1Err != nil {
-		slog.Error("phase 1 seeding tables failed", "err", phase1Err)
+	// Rows are validated before change detection hashes them, so that rows
+	// dropped as invalid are not recorded as written.
+	validationTables := make(map[string]db.ValidationMode, len(conf.Validation))
+	for table, mode := range conf.Validation {
+		validationTables[table] = db.ValidationMode(mode)
+	}
+	err := database.Validate(context.Background(),
+		db.ValidationMode(opts.validation), validationTables)
+	if err != nil {
+		slog.Error("failed to enable validation", "err", err)
 		os.Exit(1)
 	}
 
-	slog.Info("Phase 1 Complete.")
-
-	// ========================== Phase 2 (1 request) ===========================
-	slog.Info("Starting Phase 2...")
-	phase2, phase2Ctx := errgroup.WithContext(ctx)
-	seeder.SetExecutionContext(phase2Ctx)
-
-	// There's technically no point to set up concurrent execution for one
-	// request but adding it here in case more seeds are added for this phase
-	// in the future.
-	phase2.Go(seeder.SeedTeams) // 1 request
+	// A refresh always leaves the rows whose stored hash matches alone.
+	if opts.skipUnchanged || opts.command == refreshCommand {
+		if err = database.SkipUnchanged(context.Background()); err != nil {
+			slog.Error("failed to enable change detection", "err", err)
+			os.Exit(1)
+		}
+	}
 
-	if phase2Err := phase2.Wait(); phase2Err != nil {
-		slog.Error("phase 2 seeding tables failed", "err", phase2Err)
-		os.Exit(1)
+	if opts.history {
+		if err = database.TrackHistory(); err != nil {
+			slog.Error("failed to enable history", "err", err)
+			os.Exit(1)
+		}
 	}
 
-	slog.Info("Phase 2 Complete.")
+	// The schema is still migrated in a dry run so that the lookups the
+	// fetch plan depends on (e.g. game IDs) can be read.
+	if opts.dryRun {
+		if err = database.DisableWrites(); err != nil {
+			slog.Error("failed to enable dry run", "err", err)
+			os.Exit(1)
+		}
+		slog.Info("Dry run: database writes are disabled.")
+	}
+}
 
-	// ========================= Phase 3 (~40 requests) =========================
-	slog.Info("Starting Phase 3...")
-	phase3, phase3Ctx := errgroup.WithContext(ctx)
-	seeder.SetExecutionContext(phase3Ctx)
+// newSeeder returns the seeder configured by the options and config, along
+// with the shared limiter its requests wait on.
+func newSeeder(
+	opts options,
+	conf config.Config,
+	database *db.Database,
+) (*seed.Seeder, *rate.Limiter) {
+	// A replay makes no API requests, so it runs without an API key.
+	var api seed.CFBDAPI
+	if opts.command != replayCommand {
+		client, err := cfbd.New(os.Getenv("CFBD_API_KEY"))
+		if err != nil {
+			slog.Error("failed to create API client", "err", err)
+			os.Exit(1)
+		}
+		if opts.recordFixtures != "" {
+			err = seed.WrapClientTransport(client,
+				func(next http.RoundTripper) http.RoundTripper {
+					return fixtures.Record(opts.recordFixtures, next)
+				},
+			)
+			if err != nil {
+				slog.Error("failed to record fixtures", "err", err)
+				os.Exit(1)
+			}
+			slog.Info("Recording API responses as fixtures...",
+				"dir", opts.recordFixtures)
+		}
+		api = client
+	}
 
-	phase3.Go(seeder.SeedCalendar) // ~20 requests
-	phase3.Go(seeder.SeedGames)    // ~20 requests
+	throttle := rate.NewLimiter(rate.Limit(10), db.RateLimiterBurst)
+	if limit, ok := conf.RateLimits[config.GlobalRateLimit]; ok {
+		throttle = rate.NewLimiter(rate.Limit(limit.RPS), limit.Burst)
+	}
 
-	if phase3Err := phase3.Wait(); phase3Err != nil {
-		slog.Error("phase 3 seeding tables failed", "err", phase3Err)
+	// Rate limiter: 10 requests per second with burst of 20
+	seeder, err := seed.NewSeeder(database, api, throttle)
+	if err != nil {
+		slog.Error("failed to create seeder", "err", err)
+		os.Exit(1)
+	}
+	if err = seeder.CountResponseBytes(); err != nil {
+		slog.Error("failed to count response bytes", "err", err)
 		os.Exit(1)
 	}
 
-	slog.Info("Phase 3 Complete.")
+	// Every other configured limit throttles one endpoint class on top of
+	// the shared limiter.
+	for name, limit := range conf.RateLimits {
+		if name == config.GlobalRateLimit {
+			continue
+		}
+		if err = seeder.Limiters().Set(
+			seed.EndpointClass(name),
+			rate.NewLimiter(rate.Limit(limit.RPS), limit.Burst),
+		); err != nil {
+			slog.Error("invalid rate limit config", "err", err)
+			os.Exit(1)
+		}
+	}
 
-	// ========================= Phase 4 (~206K requests) =======================
-	slog.Info("Starting Phase 4...")
-	phase4, phase4Ctx := errgroup.WithContext(ctx)
-	seeder.SetExecutionContext(phase4Ctx)
+	for task, workers := range conf.Concurrency.Tasks {
+		if err = seeder.SetTaskWorkers(task, workers); err != nil {
+			slog.Error("invalid concurrency config", "err", err)
+			os.Exit(1)
+		}
+	}
 
-	phase4.Go(seeder.SeedDrives)          // 20 requests
-	phase4.Go(seeder.SeedPlays)           // 400 requests
-	phase4.Go(seeder.SeedPlayStats)       // 400 requests
-	phase4.Go(seeder.SeedGameTeamStats)   // 400 requests
-	phase4.Go(seeder.SeedGamePlayerStats) // 400 requests
+	seeder.SetQuotaWarningThreshold(opts.quotaWarn)
+	seeder.SetRatingParams(ratings.Params{
+		K:             conf.Ratings.K,
+		HomeAdvantage: conf.Ratings.HomeAdvantage,
+		Regression:    conf.Ratings.Regression,
+		Initial:       conf.Ratings.Initial,
+		InitialFCS:    conf.Ratings.InitialFCS,
+		MarginCap:     conf.Ratings.MarginCap,
+	})
 
-	// TODO: Introduce rate limiter to mitigate request bursts
-	phase4.Go(seeder.SeedAdvancedBoxScore) // ~41,000 requests (as of 2025)
-	phase4.Go(seeder.SeedGameWeather)      // ~41,000 requests (as of 2025)
-	phase4.Go(seeder.SeedGameMedia)        // ~41,000 requests (as of 2025)
-	phase4.Go(seeder.SeedBettingLines)     // ~41,000 requests (as of 2025)
-	phase4.Go(seeder.SeedWinProbability)   // ~41,000 requests (as of 2025)
+	retryPolicy := etl.DefaultRetryPolicy()
+	retryPolicy.MaxRetries = opts.maxRetries
+	seeder.SetRetryPolicy(retryPolicy)
+	seeder.SetMaxFailureAttempts(opts.maxFailureAttempts)
+	seeder.SetSkipIdentical(opts.skipIdentical)
+	seeder.SetBreakerPolicy(etl.BreakerPolicy{
+		Threshold: opts.breakerThreshold,
+		Cooldown:  opts.breakerCooldown,
+	})
 
-	if phase4Err := phase4.Wait(); phase4Err != nil {
-		slog.Error("phase 4 seeding tables failed", "err", phase4Err)
-		os.Exit(1)
+	if opts.archive != "" {
+		store, storeErr := storage.Open(context.Background(), opts.archive)
+		if storeErr != nil {
+			slog.Error("failed to open archive", "err", storeErr)
+			os.Exit(1)
+		}
+		seeder.SetArchive(store)
 	}
 
-	slog.Info("Phase 4 Complete.")
+	if opts.geocoder {
+		client, geocodeErr := geocode.New(
+			geocode.DefaultSearchURL, geocode.DefaultElevationURL,
+		)
+		if geocodeErr != nil {
+			slog.Error("failed to create geocoder", "err", geocodeErr)
+			os.Exit(1)
+		}
+		seeder.SetGeocoder(client)
+	}
 
-	// ============================== Phase 5 ===============================
-	slog.Info("Starting Phase 5...")
-	phase5, phase5Ctx := errgroup.WithContext(ctx)
-	seeder.SetExecutionContext(phase5Ctx)
+	if opts.weatherProvider != "" {
+		provider, providerErr := weather.New(opts.weatherProvider)
+		if providerErr != nil {
+			slog.Error("failed to create weather provider", "err", providerErr)
+			os.Exit(1)
+		}
+		seeder.SetWeatherProvider(provider)
+	}
 
-	phase5.Go(seeder.SeedTeamRecords)
-	phase5.Go(seeder.SeedTeamTalentComposite)
-	phase5.Go(seeder.SeedTeamATS)
-	phase5.Go(seeder.SeedTeamSPPlus)
-	phase5.Go(seeder.SeedConferenceSPPlus)
-	phase5.Go(seeder.SeedTeamSRSRankings)
-	phase5.Go(seeder.SeedTeamEloRankings)
-	phase5.Go(seeder.SeedTeamFPIRankings)
-	phase5.Go(seeder.SeedWepaTeamSeason)
-	phase5.Go(seeder.SeedWepaPassing)
-	phase5.Go(seeder.SeedWepaRushing)
-	phase5.Go(seeder.SeedWepaKicking)
-	phase5.Go(seeder.SeedReturningProduction)
-	phase5.Go(seeder.SeedPortalPlayers)
-	phase5.Go(seeder.SeedSeasonPlayerStats)
-	phase5.Go(seeder.SeedSeasonTeamStats)
-	phase5.Go(seeder.SeedRankings)
+	if opts.positionGroups != "" {
+		seeder.SetPositionGroups(strings.Split(opts.positionGroups, ","))
+	}
 
-	if phase5Err := phase5.Wait(); phase5Err != nil {
-		slog.Error("phase 5 seeding tables failed", "err", phase5Err)
+	err = seeder.SetSeasonTypes(strings.Split(opts.seasonTypes, ","))
+	if err != nil {
+		slog.Error("invalid season types", "err", err)
 		os.Exit(1)
 	}
+	seeder.SetScope(
+		strings.Split(opts.teams, ","), strings.Split(opts.conferences, ","),
+	)
+	if err = seeder.SetClassification(opts.classification); err != nil {
+		slog.Error("invalid classification", "err", err)
+		os.Exit(1)
+	}
+
+	if opts.yearSpec != "" {
+		years, yearsErr := utils.ParseYears(opts.yearSpec)
+		if yearsErr == nil {
+			yearsErr = seeder.SetYears(years)
+		}
+		if yearsErr != nil {
+			slog.Error("invalid years", "err", yearsErr)
+			os.Exit(1)
+		}
+	}
 
-	slog.Info("Phase 5 Complete.")
+	return seeder, throttle
+}
 
-	// =============================== Phase 6 ===============================
-	slog.Info("Starting Phase 6...")
-	phase6, phase6Ctx := errgroup.WithContext(ctx)
-	seeder.SetExecutionContext(phase6Ctx)
+// registerTableHooks reports the rows written to the seeder's progress and,
+// as the options ask, to Postgres listeners and an event bus, whose
+// publisher it returns.
+func registerTableHooks(
+	opts options,
+	database *db.Database,
+	seeder *seed.Seeder,
+) events.Publisher {
+	progress := seeder.Progress()
+	if err := database.OnTableSync(progress.RecordTableSync); err != nil {
+		slog.Error("failed to register table sync hook", "err", err)
+		os.Exit(1)
+	}
 
-	phase6.Go(seeder.SeedRecruits)
-	phase6.Go(seeder.SeedRecruitingRankings)
-	phase6.Go(seeder.SeedDraftPicks)
+	if opts.pgNotify && !opts.dryRun {
+		if err := database.NotifyUpdates(); err != nil {
+			slog.Error("failed to enable update notifications", "err", err)
+			os.Exit(1)
+		}
+		progress.OnTaskDone(func(ctx context.Context, task string) {
+			if notifyErr := database.FlushUpdates(ctx, task); notifyErr != nil {
+				slog.Warn(
+					"failed to notify table updates",
+					"task", task,
+					"err", notifyErr,
+				)
+			}
+		})
+	}
 
-	if phase6Err := phase6.Wait(); phase6Err != nil {
-		slog.Error("phase 6 seeding tables failed", "err", phase6Err)
+	// A dry run writes nothing, so it has no changes to publish.
+	if opts.eventsURL == "" || opts.dryRun {
+		return nil
+	}
+	publisher, err := events.Open(opts.eventsURL)
+	if err != nil {
+		slog.Error("failed to open event bus", "err", err)
+		os.Exit(1)
+	}
+	var tables []string
+	if opts.eventTables != "" {
+		tables = strings.Split(opts.eventTables, ",")
+	}
+	err = database.OnRowChanges(tables,
+		func(ctx context.Context, changes []db.RowChange) {
+			if pubErr := publisher.Publish(ctx, changes); pubErr != nil {
+				slog.Warn(
+					"failed to publish events",
+					"table", changes[0].Table,
+					"err", pubErr,
+				)
+			}
+		},
+	)
+	if err != nil {
+		slog.Error("failed to register row changes hook", "err", err)
 		os.Exit(1)
 	}
 
-	slog.Info("Phase 6 Complete.")
-	slog.Info("Seeding process complete.")
+	return publisher
+}
+
+// serveStatus serves the seeder's status and health checks on
+// --status-addr in the background.
+func serveStatus(opts options, database *db.Database, seeder *seed.Seeder) {
+	srv := server.New(server.Config{
+		Addr:     opts.statusAddr,
+		Progress: seeder.Progress(),
+		History:  database,
+		Checks: []server.Check{
+			{Name: "database", Fn: database.Ping},
+			{Name: "api", Fn: seeder.PingAPI},
+			{Name: "api_key", Fn: seeder.CheckAPIKey},
+		},
+		// A watch tick may run long while live games are fetched, so
+		// allow a few missed intervals before reporting a stall.
+		MaxBeatAge: missedBeatsAllowed * opts.watchInterval,
+	})
+	go func() {
+		slog.Info("Serving status...", "addr", opts.statusAddr)
+		if srvErr := srv.ListenAndServe(); srvErr != nil &&
+			!errors.Is(srvErr, http.ErrServerClosed) {
+			slog.Error("status server failed", "err", srvErr)
+		}
+	}()
+}
+
+// stopOnSignal stops the seeder on the first SIGINT or SIGTERM, which winds
+// the run down: no further requests are made, but those in flight finish
+// and their rows are written, and the seed functions completed so far are
+// checkpointed for --resume. A second signal exits at once.
+func stopOnSignal(seeder *seed.Seeder) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		slog.Warn("Stopping after in-flight requests; " +
+			"interrupt again to exit now...")
+		seeder.Stop()
+		<-signals
+		slog.Error("interrupted again, exiting")
+		os.Exit(1)
+	}()
+}
+
+// startDashboard draws the live terminal dashboard of the seeder's progress
+// until the returned function is called, which waits for the final frame
+// so the end state stays on screen.
+func startDashboard(
+	ctx context.Context,
+	seeder *seed.Seeder,
+	logs *tui.LogBuffer,
+) func() {
+	dashCtx, stopDashboard := context.WithCancel(ctx)
+	drawn := make(chan struct{})
+	go func() {
+		defer close(drawn)
+		tui.New(tui.Config{
+			Out:      os.Stdout,
+			Progress: seeder.Progress(),
+			Usage:    seeder.Usage(),
+			Logs:     logs,
+		}).Run(dashCtx)
+	}()
+
+	return func() {
+		stopDashboard()
+		<-drawn
+	}
 }
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/events"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/notify"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/seed"
+)
+
+// run is a run of a command recorded in the run history, which is finished
+// once by finish or fail.
+type run struct {
+	// command names the run in the run history, e.g. "seed" or "sync".
+	command string
+	seeder  *seed.Seeder
+	// printSummary writes the run summary to stdout when it finishes.
+	printSummary bool
+	// storeSummary keeps the run summary with the run in seed_runs.
+	storeSummary bool
+	// notify posts the run summary to notifier, if there is one.
+	notify   bool
+	notifier *notify.Notifier
+	// publisher, if not nil, is closed once the run finishes.
+	publisher   events.Publisher
+	stopTracing func(context.Context) error
+}
+
+// startRun records the start of the command's run in the run history, along
+// with the tables it writes, for the status page.
+func startRun(ctx context.Context, opts options, seeder *seed.Seeder) *run {
+	command := opts.command
+	switch {
+	case command != "":
+	case opts.verify:
+		command = "verify-sweep"
+	case opts.watch:
+		command = "watch"
+	default:
+		command = "seed"
+	}
+	if err := seeder.StartRun(ctx, command); err != nil {
+		slog.Warn("failed to record run", "err", err)
+	}
+
+	return &run{
+		command: command,
+		seeder:  seeder,
+		// The summary goes to stdout, which the dashboard owns while it
+		// runs and run-task and refresh already use for their own result.
+		printSummary: opts.summaryOut && !opts.dashboard &&
+			opts.command != runTaskCommand && opts.command != refreshCommand,
+		storeSummary: opts.storeSummary,
+		// A single task is one step of a larger run, which the
+		// orchestrator reports on instead.
+		notify:      opts.command != runTaskCommand,
+		stopTracing: func(context.Context) error { return nil },
+	}
+}
+
+// finish records the outcome of the run, given by runErr, and reports its
+// summary.
+func (r *run) finish(ctx context.Context, runErr error) {
+	summary := r.seeder.RunSummary(runErr)
+	var stored *etl.RunSummary
+	if r.storeSummary {
+		stored = &summary
+	}
+	if err := r.seeder.FinishRun(ctx, runErr, stored); err != nil {
+		slog.Warn("failed to record run", "err", err)
+	}
+	if r.printSummary {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(summary); err != nil {
+			slog.Warn("failed to write run summary", "err", err)
+		}
+	}
+	if r.notify && r.notifier != nil {
+		if err := r.notifier.Notify(ctx, summary); err != nil {
+			slog.Warn("failed to send run notification", "err", err)
+		}
+	}
+	if err := r.stopTracing(ctx); err != nil {
+		slog.Warn("failed to export traces", "err", err)
+	}
+	if r.publisher != nil {
+		if err := r.publisher.Close(); err != nil {
+			slog.Warn("failed to publish events", "err", err)
+		}
+	}
+}
+
+// fail logs msg with runErr, finishes the run with it and exits: with
+// exitStopped if the run was stopped by a signal, otherwise with 1.
+func (r *run) fail(ctx context.Context, msg string, runErr error) {
+	if errors.Is(runErr, etl.ErrStopped) {
+		slog.Warn("run stopped before finishing", "err", runErr)
+	} else {
+		slog.Error(msg, "err", runErr)
+	}
+	r.finish(ctx, runErr)
+	if errors.Is(runErr, etl.ErrStopped) {
+		os.Exit(exitStopped)
+	}
+	os.Exit(1)
+}
@@ -0,0 +1,440 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/clintrovert/cfbd-etl/seeder/internal/config"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/db"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/etl"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/schedule"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/seed"
+	"github.com/clintrovert/cfbd-etl/seeder/internal/storage"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+// runPlanCommand prints the projected API requests of a full seed. Planning
+// only reads the database and the key's quota.
+func runPlanCommand(ctx context.Context, seeder *seed.Seeder) {
+	plan, err := seeder.Plan(ctx)
+	if err == nil {
+		err = plan.Write(os.Stdout)
+	}
+	if err != nil {
+		slog.Error("failed to plan seeding", "err", err)
+		os.Exit(1)
+	}
+}
+
+// runVerifyCommand prints how the stored row counts of --year compare with
+// the API, exiting with 1 if any fall short.
+func runVerifyCommand(ctx context.Context, opts options, seeder *seed.Seeder) {
+	//nolint:gosec // always within int32 range
+	report, err := seeder.VerifyCounts(ctx, int32(opts.taskYear))
+	if err != nil {
+		slog.Error("failed to verify row counts", "err", err)
+		os.Exit(1)
+	}
+
+	if opts.output == outputJSON {
+		err = json.NewEncoder(os.Stdout).Encode(report)
+	} else {
+		err = report.Write(os.Stdout)
+	}
+	if err != nil {
+		slog.Warn("failed to write verify report", "err", err)
+	}
+
+	if !report.Passed {
+		os.Exit(1)
+	}
+}
+
+// runDiffCommand prints the fields of stored rows that differ from the API,
+// exiting with 1 if there are any.
+func runDiffCommand(ctx context.Context, opts options, seeder *seed.Seeder) {
+	report, err := seeder.Diff(ctx, diffSpec(opts))
+	if err != nil {
+		slog.Error("failed to diff against api", "err", err)
+		os.Exit(1)
+	}
+
+	if opts.output == outputJSON {
+		err = json.NewEncoder(os.Stdout).Encode(report)
+	} else {
+		err = report.Write(os.Stdout)
+	}
+	if err != nil {
+		slog.Warn("failed to write diff report", "err", err)
+	}
+
+	if len(report.Diffs) > 0 {
+		os.Exit(1)
+	}
+}
+
+// diffSpec returns the rows the diff and refresh commands compare.
+func diffSpec(opts options) seed.DiffSpec {
+	return seed.DiffSpec{
+		Entity:     opts.diffEntity,
+		Year:       int32(opts.taskYear), //nolint:gosec // always within int32 range
+		Week:       int32(opts.taskWeek), //nolint:gosec // always within int32 range
+		SeasonType: opts.taskSeasonType,
+	}
+}
+
+// runTaskRunCommand runs a single task and prints its result. A single task
+// is one unit of an orchestrator's DAG, so it skips the quota snapshots and
+// governor and reports only its own requests.
+func runTaskRunCommand(
+	ctx context.Context,
+	opts options,
+	seeder *seed.Seeder,
+	r *run,
+) {
+	result, err := seeder.RunTask(ctx, seed.TaskSpec{
+		Name:       opts.taskName,
+		Year:       int32(opts.taskYear), //nolint:gosec // always within int32 range
+		Week:       int32(opts.taskWeek), //nolint:gosec // always within int32 range
+		SeasonType: opts.taskSeasonType,
+	})
+	if err != nil {
+		r.fail(ctx, "invalid task", err)
+	}
+
+	if opts.output == outputJSON {
+		err = json.NewEncoder(os.Stdout).Encode(result)
+	} else {
+		err = result.Write(os.Stdout)
+	}
+	if err != nil {
+		slog.Warn("failed to write task result", "err", err)
+	}
+
+	if result.Status != db.RunSucceeded {
+		r.finish(ctx, errors.New(result.Error))
+		os.Exit(1)
+	}
+	r.finish(ctx, nil)
+}
+
+// runRefreshCommand writes the rows of an entity that changed upstream and
+// prints what changed.
+func runRefreshCommand(
+	ctx context.Context,
+	opts options,
+	seeder *seed.Seeder,
+	r *run,
+) {
+	report, err := seeder.Refresh(ctx, diffSpec(opts))
+	if err != nil {
+		r.fail(ctx, "refresh failed", err)
+	}
+
+	if opts.output == outputJSON {
+		err = json.NewEncoder(os.Stdout).Encode(report)
+	} else {
+		err = report.Write(os.Stdout)
+	}
+	if err != nil {
+		slog.Warn("failed to write refresh report", "err", err)
+	}
+	r.finish(ctx, nil)
+}
+
+// runReplayCommand seeds from the archived responses at --source.
+func runReplayCommand(
+	ctx context.Context,
+	opts options,
+	seeder *seed.Seeder,
+	r *run,
+) {
+	seeder.Progress().StartPhase("replay")
+	store, err := storage.Open(ctx, opts.source)
+	if err != nil {
+		r.fail(ctx, "failed to open replay source", err)
+	}
+	if err = seeder.Replay(ctx, store); err != nil {
+		r.fail(ctx, "replay failed", err)
+	}
+	r.finish(ctx, nil)
+}
+
+// runDaemonCommand keeps the database fresh on the configured schedules
+// until interrupted; like watch mode it expects a regular run to have
+// seeded the database beforehand.
+func runDaemonCommand(
+	ctx context.Context,
+	conf config.Config,
+	seeder *seed.Seeder,
+	r *run,
+) {
+	progress := seeder.Progress()
+	jobs, err := scheduledJobs(seeder, conf.Schedules, progress.Track)
+	if err != nil {
+		r.fail(ctx, "invalid schedules", err)
+	}
+
+	daemonCtx, stop := signal.NotifyContext(
+		ctx, os.Interrupt, syscall.SIGTERM,
+	)
+	defer stop()
+
+	slog.Info("Running scheduled tasks...", "tasks", len(jobs))
+	progress.StartPhase("daemon")
+
+	scheduler := schedule.New(jobs)
+	// Each tick also saves the table manifest, since a daemon run only
+	// finishes when the process is stopped.
+	scheduler.OnTick = func() {
+		progress.Beat()
+		if saveErr := seeder.SaveManifest(daemonCtx); saveErr != nil {
+			slog.Warn("failed to save table manifest", "err", saveErr)
+		}
+	}
+	progress.Beat()
+	scheduler.Run(daemonCtx)
+
+	seeder.Usage().LogSummary()
+	r.finish(ctx, nil)
+	slog.Info("Daemon stopped.")
+}
+
+// runSyncCommand refreshes the latest calendar week.
+func runSyncCommand(ctx context.Context, seeder *seed.Seeder, r *run) {
+	seeder.Progress().StartPhase("sync")
+	if err := seeder.SyncLatestWeek(ctx); err != nil {
+		r.fail(ctx, "sync failed", err)
+	}
+	seeder.Usage().LogSummary()
+	r.finish(ctx, nil)
+}
+
+// runBackfillCommand refreshes the week-scoped tables of --weeks of --year.
+func runBackfillCommand(
+	ctx context.Context,
+	opts options,
+	seeder *seed.Seeder,
+	r *run,
+) {
+	seeder.Progress().StartPhase("backfill")
+	err := seeder.BackfillWeeks(
+		ctx,
+		int32(opts.taskYear), //nolint:gosec // always within int32 range
+		opts.weeks,
+	)
+	if err != nil {
+		r.fail(ctx, "backfill failed", err)
+	}
+	seeder.Usage().LogSummary()
+	r.finish(ctx, nil)
+}
+
+// runGameCommand refreshes everything stored about the game --id.
+func runGameCommand(
+	ctx context.Context,
+	opts options,
+	seeder *seed.Seeder,
+	r *run,
+) {
+	seeder.Progress().StartPhase("game")
+	err := seeder.RefreshGame(
+		ctx,
+		int32(opts.gameID), //nolint:gosec // always within int32 range
+	)
+	if err != nil {
+		r.fail(ctx, "game refresh failed", err)
+	}
+	seeder.Usage().LogSummary()
+	r.finish(ctx, nil)
+}
+
+// runRetryFailedCommand re-attempts the fetches in the failure ledger.
+func runRetryFailedCommand(ctx context.Context, seeder *seed.Seeder, r *run) {
+	if err := seeder.RetryFailed(ctx); err != nil {
+		r.fail(ctx, "retrying failed fetches failed", err)
+	}
+	seeder.Usage().LogSummary()
+	r.finish(ctx, nil)
+}
+
+// runVerifySweep re-verifies the next historical week. The sweep is meant to
+// run often (e.g. from cron) on a tiny quota budget, so it runs alone and at
+// a low priority rate.
+func runVerifySweep(
+	ctx context.Context,
+	seeder *seed.Seeder,
+	throttle *rate.Limiter,
+	r *run,
+) {
+	throttle.SetLimit(rate.Limit(1))
+	if err := seeder.VerifyNextWeek(ctx); err != nil {
+		r.fail(ctx, "verification sweep failed", err)
+	}
+	seeder.Usage().LogSummary()
+	r.finish(ctx, nil)
+}
+
+// runWatch keeps the scoreboard, and optionally the weather and closing
+// lines, fresh during game days until interrupted. It expects the database
+// to have been seeded by a regular run beforehand.
+func runWatch(ctx context.Context, opts options, seeder *seed.Seeder, r *run) {
+	slog.Info("Watching scoreboard...", "interval", opts.watchInterval.String())
+	seeder.Progress().StartPhase("watch")
+
+	// Watching only ends when interrupted.
+	signalCtx, stopWatching := signal.NotifyContext(
+		ctx, os.Interrupt, syscall.SIGTERM,
+	)
+	defer stopWatching()
+
+	watchers, watchCtx := errgroup.WithContext(signalCtx)
+	watchers.Go(func() error {
+		return seeder.WatchScoreboard(watchCtx, opts.watchInterval)
+	})
+	if opts.weatherWindow > 0 {
+		slog.Info("Watching weather...", "window", opts.weatherWindow.String())
+		watchers.Go(func() error {
+			return seeder.WatchWeather(
+				watchCtx, opts.weatherWindow, opts.weatherInterval,
+			)
+		})
+	}
+	if opts.closingWindow > 0 {
+		slog.Info("Watching closing lines...",
+			"window", opts.closingWindow.String())
+		watchers.Go(func() error {
+			return seeder.WatchClosingLines(
+				watchCtx, opts.closingWindow, opts.closingInterval,
+			)
+		})
+	}
+
+	if err := watchers.Wait(); err != nil {
+		r.fail(ctx, "watch failed", err)
+	}
+	r.finish(ctx, nil)
+}
+
+// runSeed runs a full seed: the task graph declared by SeedTasks, followed
+// by building the deferred indexes and, as asked, installing constraints and
+// refreshing the views.
+func runSeed(
+	ctx context.Context,
+	opts options,
+	conf config.Config,
+	database *db.Database,
+	seeder *seed.Seeder,
+	r *run,
+) {
+	progress := seeder.Progress()
+
+	// A resumed run skips the seed functions the stopped run it resumes
+	// completed.
+	if opts.resume {
+		completed, err := database.SeedCheckpoint(ctx, r.command)
+		if err != nil {
+			r.fail(ctx, "failed to read checkpoint", err)
+		}
+		progress.SetCompleted(completed)
+		slog.Info("Resuming stopped run...", "completed", len(completed))
+	}
+
+	// Each phase holds the tasks whose prerequisites finished in earlier
+	// phases and runs them concurrently. A task that runs past
+	// --task-timeout is cancelled.
+	runner := etl.NewPhaseRunner(progress)
+	runner.TaskTimeout = opts.taskTimeout
+	runner.MaxConcurrent = opts.maxConcurrent
+	runner.PhaseConcurrent = conf.Concurrency.Phases
+
+	// A bulk load maintains only the indexes upserts need; the rest are
+	// rebuilt once it is done, or by the next run to finish if it fails.
+	if opts.deferIndexes && !opts.dryRun {
+		if err := database.DeferIndexes(ctx); err != nil {
+			r.fail(ctx, "failed to defer indexes", err)
+		}
+	}
+
+	if err := runner.Run(ctx, seeder.SeedTasks()); err != nil {
+		r.fail(ctx, "seeding failed", err)
+	}
+
+	if !opts.dryRun {
+		progress.StartPhase("finalize")
+		if err := database.Finalize(ctx); err != nil {
+			r.fail(ctx, "failed to build deferred indexes", err)
+		}
+		// Constraints that the loaded rows do not satisfy are reported
+		// without failing the run.
+		if opts.installConstraints {
+			if _, err := database.InstallConstraints(ctx); err != nil {
+				r.fail(ctx, "failed to install constraints", err)
+			}
+		}
+		if opts.refreshViews {
+			if _, err := database.RefreshViews(ctx); err != nil {
+				r.fail(ctx, "failed to refresh views", err)
+			}
+		}
+	}
+
+	if err := seeder.SnapshotQuota(ctx, "end"); err != nil {
+		slog.Warn("failed to snapshot api quota", "err", err)
+	}
+
+	seeder.Usage().LogSummary()
+	r.finish(ctx, nil)
+	if opts.dryRun {
+		progress.LogTableSummary()
+	}
+	slog.Info("Seeding process complete.")
+}
+
+// scheduledJobs resolves each configured schedule to the seeder task it
+// names.
+func scheduledJobs(
+	seeder *seed.Seeder,
+	schedules []config.Schedule,
+	track func(etl.Task) func(context.Context) error,
+) ([]schedule.Job, error) {
+	if len(schedules) == 0 {
+		return nil, errors.New("no schedules configured")
+	}
+
+	tasks := seeder.Tasks()
+	jobs := make([]schedule.Job, 0, len(schedules))
+	for _, sched := range schedules {
+		task, ok := tasks[sched.Task]
+		if !ok {
+			return nil, fmt.Errorf(
+				"unknown task %q; must be one of %s",
+				sched.Task, strings.Join(seeder.TaskNames(), ", "),
+			)
+		}
+
+		cron, err := schedule.ParseCron(sched.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule for %q; %w", sched.Task, err)
+		}
+
+		// Each run is tracked separately so progress counts runs, not jobs.
+		jobs = append(jobs, schedule.Job{
+			Name: sched.Task,
+			Cron: cron,
+			Run: func(ctx context.Context) error {
+				return track(etl.Task{Name: sched.Task, Run: task})(ctx)
+			},
+		})
+	}
+
+	return jobs, nil
+}